@@ -2,9 +2,14 @@ package v1
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
+	"github.com/odpf/optimus/store/local"
+	"github.com/spf13/afero"
+
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/odpf/optimus/core/tree"
@@ -14,6 +19,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/utils"
 	"github.com/pkg/errors"
 )
 
@@ -23,7 +29,53 @@ type Adapter struct {
 	supportedDatastoreRepo models.DatastoreRepo
 }
 
+// jobProtoMigrations upgrade a job specification of a version to the next
+// version, specs are sent as read by clients which may be older than server
+var jobProtoMigrations = map[int32]func(spec *pb.JobSpecification){
+	1: func(spec *pb.JobSpecification) {
+		// month truncation was named m till version 1
+		if spec.WindowTruncateTo == "m" {
+			spec.WindowTruncateTo = "M"
+		}
+	},
+}
+
+// migrateJobProto upgrades spec to models.JobSpecVersion without modifying
+// it, specs without a version are migrated from the first version
+func migrateJobProto(spec *pb.JobSpecification) (*pb.JobSpecification, error) {
+	version := spec.GetVersion()
+	if version == 0 {
+		version = 1
+	}
+	if version > models.JobSpecVersion {
+		return nil, fmt.Errorf("%w %d, latest supported version is %d",
+			models.ErrUnsupportedJobSpecVersion, version, models.JobSpecVersion)
+	}
+	if version == models.JobSpecVersion {
+		return spec, nil
+	}
+
+	migrated := proto.Clone(spec).(*pb.JobSpecification)
+	for ; version < models.JobSpecVersion; version++ {
+		migrate, ok := jobProtoMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w %d, no migration to version %d",
+				models.ErrUnsupportedJobSpecVersion, version, version+1)
+		}
+		migrate(migrated)
+	}
+	if migrated.Version != 0 {
+		migrated.Version = models.JobSpecVersion
+	}
+	return migrated, nil
+}
+
 func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, error) {
+	spec, err := migrateJobProto(spec)
+	if err != nil {
+		return models.JobSpec{}, err
+	}
+
 	startDate, err := time.Parse(models.JobDatetimeLayout, spec.StartDate)
 	if err != nil {
 		return models.JobSpec{}, err
@@ -37,25 +89,108 @@ func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, e
 		}
 		endDate = &end
 	}
+	if err := utils.TimezoneValidator(spec.Timezone, ""); err != nil {
+		return models.JobSpec{}, err
+	}
+
+	interval := spec.Interval
+	var trigger *models.JobSpecTrigger
+	if spec.Trigger != nil {
+		trigger = &models.JobSpecTrigger{
+			Type:   models.JobTriggerType(spec.Trigger.Type),
+			Config: map[string]string{},
+		}
+		for key, value := range spec.Trigger.Config {
+			trigger.Config[strings.ToUpper(key)] = value
+		}
+		if err := trigger.Validate(); err != nil {
+			return models.JobSpec{}, err
+		}
+		if interval == "" {
+			interval = models.JobTriggerInterval
+		}
+	}
 
 	// prep dirty dependencies
 	dependencies := map[string]models.JobSpecDependency{}
 	for _, dep := range spec.Dependencies {
-		dependencies[dep.GetName()] = models.JobSpecDependency{
-			Type: models.JobSpecDependencyType(dep.GetType()),
+		dependency := models.JobSpecDependency{
+			Type:     models.JobSpecDependencyType(dep.GetType()),
+			Optional: dep.GetOptional(),
+		}
+		if dep.GetTimeout() != nil {
+			dependency.Timeout = dep.GetTimeout().AsDuration()
+		}
+		if dep.GetPokeInterval() != nil {
+			dependency.PokeInterval = dep.GetPokeInterval().AsDuration()
 		}
+		if err := dependency.Validate(); err != nil {
+			return models.JobSpec{}, errors.Wrapf(err, "invalid dependency %s", dep.GetName())
+		}
+		dependencies[dep.GetName()] = dependency
+	}
+	var httpDependencies []models.JobSpecHTTPDependency
+	for _, dep := range spec.HttpDependencies {
+		httpDependencies = append(httpDependencies, models.JobSpecHTTPDependency{
+			Name:           dep.GetName(),
+			URL:            dep.GetUrl(),
+			Headers:        dep.GetHeaders(),
+			ExpectedStatus: int(dep.GetExpectedStatus()),
+			JSONPath:       dep.GetJsonPath(),
+			ExpectedValue:  dep.GetExpectedValue(),
+		})
+	}
+	var externalDependencies []models.JobSpecExternalDependency
+	for _, dep := range spec.ExternalDependencies {
+		externalDependencies = append(externalDependencies, models.JobSpecExternalDependency{
+			Host:        dep.GetHost(),
+			ProjectName: dep.GetProjectName(),
+			JobName:     dep.GetJobName(),
+		})
+	}
+	var resourceDependencies []models.JobSpecResourceDependency
+	for _, dep := range spec.ResourceDependencies {
+		resourceDep := models.JobSpecResourceDependency{
+			URN:      dep.GetUrn(),
+			Optional: dep.GetOptional(),
+		}
+		if dep.GetTimeout() != nil {
+			resourceDep.Timeout = dep.GetTimeout().AsDuration()
+		}
+		if dep.GetPokeInterval() != nil {
+			resourceDep.PokeInterval = dep.GetPokeInterval().AsDuration()
+		}
+		resourceDependencies = append(resourceDependencies, resourceDep)
 	}
 
-	window, err := prepareWindow(spec.WindowSize, spec.WindowOffset, spec.WindowTruncateTo)
+	window, err := prepareWindow(spec.WindowSize, spec.WindowOffset, spec.WindowTruncateTo, "", spec.WindowModifier)
 	if err != nil {
 		return models.JobSpec{}, err
 	}
 
-	execUnit, err := adapt.pluginRepo.GetByName(spec.TaskName)
+	var execUnit *models.Plugin
+	if spec.TaskVersion != "" {
+		execUnit, err = adapt.pluginRepo.GetByNameAndVersion(spec.TaskName, spec.TaskVersion)
+	} else {
+		execUnit, err = adapt.pluginRepo.GetByName(spec.TaskName)
+	}
 	if err != nil {
 		return models.JobSpec{}, err
 	}
 
+	taskResource := fromResourceProto(spec.TaskResource)
+	if err := taskResource.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrap(err, "invalid task resource")
+	}
+
+	runtime := models.JobSpecRuntime{
+		Env:    spec.Env,
+		Labels: spec.RuntimeLabels,
+	}
+	if err := runtime.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrap(err, "invalid runtime")
+	}
+
 	// adapt hooks
 	hooks, err := adapt.FromHookProto(spec.Hooks)
 	if err != nil {
@@ -91,16 +226,18 @@ func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, e
 			})
 		}
 	}
-	return models.JobSpec{
+	jobSpec := models.JobSpec{
 		Version:     int(spec.Version),
 		Name:        spec.Name,
 		Owner:       spec.Owner,
 		Description: spec.Description,
 		Labels:      spec.Labels,
 		Schedule: models.JobSpecSchedule{
-			Interval:  spec.Interval,
+			Interval:  interval,
 			StartDate: startDate,
 			EndDate:   endDate,
+			Timezone:  spec.Timezone,
+			Trigger:   trigger,
 		},
 		Assets: models.JobAssets{}.FromMap(spec.Assets),
 		Behavior: models.JobSpecBehavior{
@@ -114,25 +251,119 @@ func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, e
 			Notify: notifiers,
 		},
 		Task: models.JobSpecTask{
-			Unit:   execUnit,
-			Config: taskConfigs,
-			Window: window,
+			Unit:     execUnit,
+			Version:  spec.TaskVersion,
+			Config:   taskConfigs,
+			Window:   window,
+			Resource: taskResource,
 		},
-		Dependencies: dependencies,
-		Hooks:        hooks,
-	}, nil
+		Dependencies:         dependencies,
+		Hooks:                hooks,
+		Runtime:              runtime,
+		HTTPDependencies:     httpDependencies,
+		ExternalDependencies: externalDependencies,
+		ResourceDependencies: resourceDependencies,
+		Paused:               spec.Paused,
+		Revision:             int(spec.Revision),
+	}
+	if err := jobSpec.ValidateLabels(); err != nil {
+		return models.JobSpec{}, err
+	}
+	if err := jobSpec.ValidateHTTPDependencies(); err != nil {
+		return models.JobSpec{}, err
+	}
+	if err := jobSpec.ValidateExternalDependencies(); err != nil {
+		return models.JobSpec{}, err
+	}
+	if err := jobSpec.ValidateResourceDependencies(); err != nil {
+		return models.JobSpec{}, err
+	}
+	return jobSpec, nil
+}
+
+func fromResourceProto(resource *pb.JobSpecResource) models.JobSpecResource {
+	return models.JobSpecResource{
+		Request: models.JobSpecResourceConfig{
+			CPU:    resource.GetRequest().GetCpu(),
+			Memory: resource.GetRequest().GetMemory(),
+		},
+		Limit: models.JobSpecResourceConfig{
+			CPU:    resource.GetLimit().GetCpu(),
+			Memory: resource.GetLimit().GetMemory(),
+		},
+	}
 }
 
-func prepareWindow(windowSize, windowOffset, truncateTo string) (models.JobSpecTaskWindow, error) {
+func toPluginProto(info *models.PluginInfoResponse, questions models.PluginQuestions) *pb.PluginSpecification {
+	return &pb.PluginSpecification{
+		Name:          info.Name,
+		Description:   info.Description,
+		Image:         info.Image,
+		PluginVersion: info.PluginVersion,
+		HookType:      info.HookType.String(),
+		DependsOn:     info.DependsOn,
+		Questions:     toPluginQuestionsProto(questions),
+	}
+}
+
+func toPluginQuestionsProto(questions models.PluginQuestions) []*pb.PluginQuestion {
+	var protoQuestions []*pb.PluginQuestion
+	for _, question := range questions {
+		var subQuestions []*pb.PluginSubQuestion
+		for _, subQuestion := range question.SubQuestions {
+			subQuestions = append(subQuestions, &pb.PluginSubQuestion{
+				IfValue:   subQuestion.IfValue,
+				Questions: toPluginQuestionsProto(subQuestion.Questions),
+			})
+		}
+		protoQuestions = append(protoQuestions, &pb.PluginQuestion{
+			Name:         question.Name,
+			Prompt:       question.Prompt,
+			Help:         question.Help,
+			Default:      question.Default,
+			Multiselect:  question.Multiselect,
+			Validation:   question.Validation,
+			SubQuestions: subQuestions,
+		})
+	}
+	return protoQuestions
+}
+
+func toResourceProto(resource models.JobSpecResource) *pb.JobSpecResource {
+	if resource.IsEmpty() {
+		return nil
+	}
+	return &pb.JobSpecResource{
+		Request: &pb.JobSpecResource_Config{
+			Cpu:    resource.Request.CPU,
+			Memory: resource.Request.Memory,
+		},
+		Limit: &pb.JobSpecResource_Config{
+			Cpu:    resource.Limit.CPU,
+			Memory: resource.Limit.Memory,
+		},
+	}
+}
+
+// prepareWindow builds the window from a preset if provided, size, offset and
+// truncateTo override values of the preset
+func prepareWindow(windowSize, windowOffset, truncateTo, preset, modifier string) (models.JobSpecTaskWindow, error) {
 	var err error
 	window := models.JobSpecTaskWindow{}
 	window.Size = time.Hour * 24
 	window.Offset = 0
 	window.TruncateTo = "d"
 
+	if preset != "" {
+		window, err = models.GetJobSpecTaskWindowPreset(preset)
+		if err != nil {
+			return window, err
+		}
+	}
 	if truncateTo != "" {
 		window.TruncateTo = truncateTo
 	}
+	window.Modifier = modifier
 	if windowSize != "" {
 		window.Size, err = time.ParseDuration(windowSize)
 		if err != nil {
@@ -145,6 +376,9 @@ func prepareWindow(windowSize, windowOffset, truncateTo string) (models.JobSpecT
 			return window, errors.Wrapf(err, "failed to parse task window with offset %v", windowOffset)
 		}
 	}
+	if err := window.Validate(); err != nil {
+		return window, errors.Wrap(err, "invalid task window")
+	}
 	return window, nil
 }
 
@@ -169,17 +403,25 @@ func (adapt *Adapter) ToJobProto(spec models.JobSpec) (*pb.JobSpecification, err
 		Owner:            spec.Owner,
 		Interval:         spec.Schedule.Interval,
 		StartDate:        spec.Schedule.StartDate.Format(models.JobDatetimeLayout),
+		Timezone:         spec.Schedule.Timezone,
 		DependsOnPast:    spec.Behavior.DependsOnPast,
 		CatchUp:          spec.Behavior.CatchUp,
 		TaskName:         spec.Task.Unit.Info().Name,
+		TaskVersion:      spec.Task.Version,
 		WindowSize:       spec.Task.Window.SizeString(),
 		WindowOffset:     spec.Task.Window.OffsetString(),
 		WindowTruncateTo: spec.Task.Window.TruncateTo,
+		WindowModifier:   spec.Task.Window.Modifier,
+		TaskResource:     toResourceProto(spec.Task.Resource),
+		Env:              spec.Runtime.Env,
+		RuntimeLabels:    spec.Runtime.Labels,
 		Assets:           spec.Assets.ToMap(),
 		Dependencies:     []*pb.JobDependency{},
 		Hooks:            adaptedHook,
 		Description:      spec.Description,
 		Labels:           spec.Labels,
+		Paused:           spec.Paused,
+		Revision:         int32(spec.Revision),
 		Behavior: &pb.JobSpecification_Behavior{
 			Retry: &pb.JobSpecification_Behavior_Retry{
 				Count:              int32(spec.Behavior.Retry.Count),
@@ -192,12 +434,56 @@ func (adapt *Adapter) ToJobProto(spec models.JobSpec) (*pb.JobSpecification, err
 	if spec.Schedule.EndDate != nil {
 		conf.EndDate = spec.Schedule.EndDate.Format(models.JobDatetimeLayout)
 	}
+	if spec.Schedule.Trigger != nil {
+		conf.Trigger = &pb.JobSpecification_Trigger{
+			Type:   spec.Schedule.Trigger.Type.String(),
+			Config: spec.Schedule.Trigger.Config,
+		}
+	}
 	for name, dep := range spec.Dependencies {
-		conf.Dependencies = append(conf.Dependencies, &pb.JobDependency{
-			Name: name,
-			Type: dep.Type.String(),
+		dependency := &pb.JobDependency{
+			Name:     name,
+			Type:     dep.Type.String(),
+			Optional: dep.Optional,
+		}
+		if dep.Timeout > 0 {
+			dependency.Timeout = ptypes.DurationProto(dep.Timeout)
+		}
+		if dep.PokeInterval > 0 {
+			dependency.PokeInterval = ptypes.DurationProto(dep.PokeInterval)
+		}
+		conf.Dependencies = append(conf.Dependencies, dependency)
+	}
+	for _, dep := range spec.HTTPDependencies {
+		conf.HttpDependencies = append(conf.HttpDependencies, &pb.JobSpecHTTPDependency{
+			Name:           dep.Name,
+			Url:            dep.URL,
+			Headers:        dep.Headers,
+			ExpectedStatus: int32(dep.ExpectedStatus),
+			JsonPath:       dep.JSONPath,
+			ExpectedValue:  dep.ExpectedValue,
 		})
 	}
+	for _, dep := range spec.ExternalDependencies {
+		conf.ExternalDependencies = append(conf.ExternalDependencies, &pb.JobSpecExternalDependency{
+			Host:        dep.Host,
+			ProjectName: dep.ProjectName,
+			JobName:     dep.JobName,
+		})
+	}
+	for _, dep := range spec.ResourceDependencies {
+		resourceDep := &pb.JobSpecResourceDependency{
+			Urn:      dep.URN,
+			Optional: dep.Optional,
+		}
+		if dep.Timeout > 0 {
+			resourceDep.Timeout = ptypes.DurationProto(dep.Timeout)
+		}
+		if dep.PokeInterval > 0 {
+			resourceDep.PokeInterval = ptypes.DurationProto(dep.PokeInterval)
+		}
+		conf.ResourceDependencies = append(conf.ResourceDependencies, resourceDep)
+	}
 
 	var taskConfigs []*pb.JobConfigItem
 	for _, c := range spec.Task.Config {
@@ -211,6 +497,16 @@ func (adapt *Adapter) ToJobProto(spec models.JobSpec) (*pb.JobSpecification, err
 	return conf, nil
 }
 
+// FromJobArchive reads all job specs packed in a gzipped tar archive, archive
+// should follow the same directory layout as of local job specifications
+func (adapt *Adapter) FromJobArchive(archive io.Reader) ([]models.JobSpec, error) {
+	archiveFs := afero.NewMemMapFs()
+	if err := local.ExtractArchive(archive, archiveFs); err != nil {
+		return nil, err
+	}
+	return local.NewJobSpecRepository(archiveFs, local.NewJobSpecAdapter(adapt.pluginRepo)).GetAll()
+}
+
 func (adapt *Adapter) ToProjectProto(spec models.ProjectSpec) *pb.ProjectSpecification {
 	return &pb.ProjectSpecification{
 		Name:   spec.Name,
@@ -357,7 +653,13 @@ func (adapt *Adapter) FromInstanceProto(conf *pb.InstanceSpec) (models.InstanceS
 func (adapt *Adapter) FromHookProto(hooksProto []*pb.JobSpecHook) ([]models.JobSpecHook, error) {
 	var hooks []models.JobSpecHook
 	for _, hook := range hooksProto {
-		hookUnit, err := adapt.pluginRepo.GetByName(hook.Name)
+		var hookUnit *models.Plugin
+		var err error
+		if hook.Version != "" {
+			hookUnit, err = adapt.pluginRepo.GetByNameAndVersion(hook.Name, hook.Version)
+		} else {
+			hookUnit, err = adapt.pluginRepo.GetByName(hook.Name)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -370,9 +672,16 @@ func (adapt *Adapter) FromHookProto(hooksProto []*pb.JobSpecHook) ([]models.JobS
 			})
 		}
 
+		resource := fromResourceProto(hook.Resource)
+		if err := resource.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid resource of hook %s", hook.Name)
+		}
+
 		hooks = append(hooks, models.JobSpecHook{
-			Config: configs,
-			Unit:   hookUnit,
+			Config:   configs,
+			Unit:     hookUnit,
+			Version:  hook.Version,
+			Resource: resource,
 		})
 	}
 	return hooks, nil
@@ -389,8 +698,10 @@ func (adapt *Adapter) ToHookProto(hooks []models.JobSpecHook) (protoHooks []*pb.
 		}
 
 		protoHooks = append(protoHooks, &pb.JobSpecHook{
-			Name:   hook.Unit.Info().Name,
-			Config: hookConfigs,
+			Name:     hook.Unit.Info().Name,
+			Version:  hook.Version,
+			Config:   hookConfigs,
+			Resource: toResourceProto(hook.Resource),
 		})
 	}
 	return
@@ -449,9 +760,89 @@ func (adapt *Adapter) ToReplayExecutionTreeNode(res *tree.TreeNode) (*pb.ReplayE
 	return response, nil
 }
 
+// ToReplayJobRuns flattens the execution tree to runs of each job in the
+// order replay clears them, along with the number of runs
+func (adapt *Adapter) ToReplayJobRuns(res *tree.TreeNode) ([]*pb.ReplayJobRuns, int64) {
+	var jobRuns []*pb.ReplayJobRuns
+	var runCount int64
+	for _, node := range res.GetAllNodesInTopologicalOrder() {
+		if node.Runs.Size() == 0 {
+			continue
+		}
+		runs := &pb.ReplayJobRuns{
+			JobName: node.GetName(),
+		}
+		for _, run := range node.Runs.Values() {
+			runs.Runs = append(runs.Runs, timestamppb.New(run.(time.Time)))
+		}
+		jobRuns = append(jobRuns, runs)
+		runCount += int64(len(runs.Runs))
+	}
+	return jobRuns, runCount
+}
+
+func (adapt *Adapter) ToReplaySpecProto(replay models.ReplaySpec) *pb.ReplaySpec {
+	return &pb.ReplaySpec{
+		Id:          replay.ID.String(),
+		JobName:     replay.Job.Name,
+		StartDate:   timestamppb.New(replay.StartDate),
+		EndDate:     timestamppb.New(replay.EndDate),
+		Status:      replay.Status,
+		MessageType: replay.Message.Type,
+		Message:     replay.Message.Message,
+		CreatedAt:   timestamppb.New(replay.CreatedAt),
+	}
+}
+
 func NewAdapter(pluginRepo models.PluginRepository, datastoreRepo models.DatastoreRepo) *Adapter {
 	return &Adapter{
 		pluginRepo:             pluginRepo,
 		supportedDatastoreRepo: datastoreRepo,
 	}
 }
+
+func (adapt *Adapter) ToDeploymentStatusProto(deployment models.DeploymentSpec) *pb.GetDeploymentStatusResponse {
+	var jobs []*pb.GetDeploymentStatusResponse_JobDeploymentStatus
+	for _, jobDeployment := range deployment.Jobs {
+		jobs = append(jobs, &pb.GetDeploymentStatusResponse_JobDeploymentStatus{
+			JobName: jobDeployment.Name,
+			Status:  jobDeployment.Status,
+			Message: jobDeployment.Message,
+		})
+	}
+	return &pb.GetDeploymentStatusResponse{
+		DeploymentId: deployment.ID.String(),
+		Namespace:    deployment.Namespace.Name,
+		Status:       deployment.Status,
+		Message:      deployment.Message,
+		Jobs:         jobs,
+		CreatedAt:    timestamppb.New(deployment.CreatedAt),
+		UpdatedAt:    timestamppb.New(deployment.UpdatedAt),
+	}
+}
+
+func (adapt *Adapter) ToDeploymentProto(deployment models.DeploymentSpec) *pb.DeploymentSpecification {
+	var jobs []*pb.GetDeploymentStatusResponse_JobDeploymentStatus
+	for _, jobDeployment := range deployment.Jobs {
+		jobs = append(jobs, &pb.GetDeploymentStatusResponse_JobDeploymentStatus{
+			JobName: jobDeployment.Name,
+			Status:  jobDeployment.Status,
+			Message: jobDeployment.Message,
+		})
+	}
+	deploymentProto := &pb.DeploymentSpecification{
+		DeploymentId: deployment.ID.String(),
+		Namespace:    deployment.Namespace.Name,
+		Status:       deployment.Status,
+		Message:      deployment.Message,
+		RequestedBy:  deployment.RequestedBy,
+		JobCount:     int32(len(deployment.Jobs)),
+		Jobs:         jobs,
+		CreatedAt:    timestamppb.New(deployment.CreatedAt),
+	}
+	if deployment.IsFinished() {
+		deploymentProto.Duration = durationpb.New(deployment.Duration())
+		deploymentProto.FinishedAt = timestamppb.New(deployment.UpdatedAt)
+	}
+	return deploymentProto
+}
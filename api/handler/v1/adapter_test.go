@@ -1,6 +1,7 @@
 package v1_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -29,6 +30,24 @@ func TestAdapter(t *testing.T) {
 		assert.Equal(t, 1, len(replayExecutionTreeNode.Dependents))
 		assert.Equal(t, replayExecutionTreeNode.Dependents[0].JobName, "nested-job-name")
 	})
+	t.Run("should flatten dag node to runs of each job in the order they are cleared", func(t *testing.T) {
+		timeRun := time.Date(2021, 11, 8, 0, 0, 0, 0, time.UTC)
+		treeNode := tree.NewTreeNode(models.JobSpec{Name: "job-name"})
+		treeNode.Runs.Add(timeRun)
+		treeNode.Runs.Add(timeRun.Add(time.Hour * 24))
+		nestedTreeNode := tree.NewTreeNode(models.JobSpec{Name: "nested-job-name"})
+		nestedTreeNode.Runs.Add(timeRun)
+		treeNode.AddDependent(nestedTreeNode)
+		treeNode.AddDependent(tree.NewTreeNode(models.JobSpec{Name: "job-without-runs"}))
+
+		adap := v1.Adapter{}
+		jobRuns, runCount := adap.ToReplayJobRuns(treeNode)
+		assert.Equal(t, int64(3), runCount)
+		assert.Equal(t, 2, len(jobRuns))
+		assert.Equal(t, "job-name", jobRuns[0].JobName)
+		assert.Equal(t, timeRun, jobRuns[0].Runs[0].AsTime())
+		assert.Equal(t, "nested-job-name", jobRuns[1].JobName)
+	})
 	t.Run("should successfully parse job spec to and from proto", func(t *testing.T) {
 		execUnit1 := new(mock.BasePlugin)
 		execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
@@ -79,6 +98,10 @@ func TestAdapter(t *testing.T) {
 					Offset:     time.Hour,
 					TruncateTo: "h",
 				},
+				Resource: models.JobSpecResource{
+					Request: models.JobSpecResourceConfig{CPU: "250m", Memory: "1Gi"},
+					Limit:   models.JobSpecResourceConfig{CPU: "1", Memory: "4Gi"},
+				},
 			},
 			Assets: *models.JobAssets{}.New(
 				[]models.JobSpecAsset{
@@ -88,7 +111,23 @@ func TestAdapter(t *testing.T) {
 					},
 				},
 			),
-			Dependencies: map[string]models.JobSpecDependency{},
+			Dependencies: map[string]models.JobSpecDependency{
+				"upstream-job": {Type: models.JobSpecDependencyTypeIntra, Optional: true,
+					Timeout: time.Hour, PokeInterval: 10 * time.Minute},
+			},
+			HTTPDependencies: []models.JobSpecHTTPDependency{
+				{
+					Name:           "billing-export",
+					URL:            "https://billing.example.io/exports",
+					Headers:        map[string]string{"Authorization": "Bearer token"},
+					ExpectedStatus: 200,
+					JSONPath:       "$.status",
+					ExpectedValue:  "done",
+				},
+			},
+			ExternalDependencies: []models.JobSpecExternalDependency{
+				{Host: "optimus.partner.example.io", ProjectName: "partner-project", JobName: "billing-export"},
+			},
 			Hooks: []models.JobSpecHook{
 				{
 					Config: models.JobSpecConfigs{
@@ -98,8 +137,15 @@ func TestAdapter(t *testing.T) {
 						},
 					},
 					Unit: &models.Plugin{Base: execUnit1},
+					Resource: models.JobSpecResource{
+						Limit: models.JobSpecResourceConfig{Memory: "512Mi"},
+					},
 				},
 			},
+			Runtime: models.JobSpecRuntime{
+				Env:    map[string]string{"LOG_LEVEL": "debug"},
+				Labels: map[string]string{models.JobRuntimeLabelPool: "bigquery"},
+			},
 		}
 
 		inProto, err := adapter.ToJobProto(jobSpec)
@@ -108,6 +154,151 @@ func TestAdapter(t *testing.T) {
 		assert.Equal(t, jobSpec, original)
 		assert.Nil(t, err)
 	})
+	t.Run("should parse job trigger from proto and default its interval", func(t *testing.T) {
+		execUnit1 := new(mock.BasePlugin)
+		defer execUnit1.AssertExpectations(t)
+
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "sample-task").Return(&models.Plugin{
+			Base: execUnit1,
+		}, nil)
+		adapter := v1.NewAdapter(pluginRepo, nil)
+
+		jobSpec, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			TaskName:  "sample-task",
+			Trigger: &pb.JobSpecification_Trigger{
+				Type:   "gcs",
+				Config: map[string]string{"bucket": "landing", "object": "events/{{ ds }}/_SUCCESS"},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, models.JobTriggerInterval, jobSpec.Schedule.Interval)
+		assert.Equal(t, &models.JobSpecTrigger{
+			Type:   models.JobTriggerTypeGCS,
+			Config: map[string]string{"BUCKET": "landing", "OBJECT": "events/{{ ds }}/_SUCCESS"},
+		}, jobSpec.Schedule.Trigger)
+	})
+	t.Run("should upgrade job of older version from proto", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "sample-task").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := v1.NewAdapter(pluginRepo, nil)
+
+		jobProto := &pb.JobSpecification{
+			Version:          1,
+			Name:             "test-job",
+			StartDate:        "2021-10-06",
+			Interval:         "@daily",
+			TaskName:         "sample-task",
+			WindowSize:       "720h",
+			WindowOffset:     "0",
+			WindowTruncateTo: "m",
+		}
+		jobSpec, err := adapter.FromJobProto(jobProto)
+		assert.Nil(t, err)
+		assert.Equal(t, models.JobSpecVersion, jobSpec.Version)
+		assert.Equal(t, "M", jobSpec.Task.Window.TruncateTo)
+		// request is left untouched
+		assert.Equal(t, "m", jobProto.WindowTruncateTo)
+	})
+	t.Run("should fail to parse job of unsupported version from proto", func(t *testing.T) {
+		adapter := v1.NewAdapter(nil, nil)
+
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Version:   models.JobSpecVersion + 1,
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			TaskName:  "sample-task",
+		})
+		assert.True(t, errors.Is(err, models.ErrUnsupportedJobSpecVersion))
+	})
+	t.Run("should fail to parse job pinning a plugin version which is not installed from proto", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByNameAndVersion", "sample-task", "0.3.0").Return((*models.Plugin)(nil), models.ErrUnsupportedPluginVersion)
+		defer pluginRepo.AssertExpectations(t)
+		adapter := v1.NewAdapter(pluginRepo, nil)
+
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Version:     models.JobSpecVersion,
+			Name:        "test-job",
+			StartDate:   "2021-10-06",
+			Interval:    "@daily",
+			TaskName:    "sample-task",
+			TaskVersion: "0.3.0",
+		})
+		assert.True(t, errors.Is(err, models.ErrUnsupportedPluginVersion))
+	})
+	t.Run("should fail to parse job with invalid task resource from proto", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "sample-task").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := v1.NewAdapter(pluginRepo, nil)
+
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			TaskResource: &pb.JobSpecResource{
+				Limit: &pb.JobSpecResource_Config{Memory: "4 gigs"},
+			},
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "invalid task resource")
+	})
+	t.Run("should fail to parse job overriding env reserved by optimus from proto", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "sample-task").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := v1.NewAdapter(pluginRepo, nil)
+
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			Env:       map[string]string{"JOB_NAME": "other-job"},
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "env JOB_NAME is reserved by optimus")
+	})
+	t.Run("should fail to parse job with invalid http dependency from proto", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "sample-task").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := v1.NewAdapter(pluginRepo, nil)
+
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			HttpDependencies: []*pb.JobSpecHTTPDependency{
+				{Name: "billing-export", Url: "billing.example.io/exports"},
+			},
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "invalid url billing.example.io/exports of http dependency billing-export")
+	})
+	t.Run("should fail to parse job with invalid trigger from proto", func(t *testing.T) {
+		adapter := v1.NewAdapter(nil, nil)
+
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			TaskName:  "sample-task",
+			Trigger: &pb.JobSpecification_Trigger{
+				Type: "kafka",
+			},
+		})
+		assert.NotNil(t, err)
+	})
 }
 
 func TestAdapter_FromProjectProtoWithSecrets(t *testing.T) {
@@ -0,0 +1,258 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// AuditedMethods are the mutating rpcs recorded in audit log
+var AuditedMethods = []string{
+	servicePrefix + "RegisterProject",
+	servicePrefix + "DeleteProject",
+	servicePrefix + "RestoreProject",
+	servicePrefix + "RegisterProjectNamespace",
+	servicePrefix + "RegisterSecret",
+	servicePrefix + "UpdateSecret",
+	servicePrefix + "DeployJobSpecification",
+	servicePrefix + "DeployJobSpecificationStream",
+	servicePrefix + "DeployJobSpecificationAsync",
+	servicePrefix + "ImportJobSpecifications",
+	servicePrefix + "CreateJobSpecification",
+	servicePrefix + "DeleteJobSpecification",
+	servicePrefix + "PauseJob",
+	servicePrefix + "ResumeJob",
+	servicePrefix + "RestoreJob",
+	servicePrefix + "RollbackJob",
+	servicePrefix + "DeployResourceSpecification",
+	servicePrefix + "CreateResource",
+	servicePrefix + "UpdateResource",
+	servicePrefix + "Replay",
+	servicePrefix + "ClearJobRuns",
+	servicePrefix + "CreateRoleBinding",
+	servicePrefix + "DeleteRoleBinding",
+	servicePrefix + "RegisterMacro",
+	servicePrefix + "DeleteMacro",
+}
+
+// auditChange accumulates the change requested by the messages of a call
+type auditChange struct {
+	// req is the first message received
+	req          interface{}
+	jobs         int
+	archiveBytes int
+}
+
+func (c *auditChange) add(req interface{}) {
+	if c.req == nil {
+		c.req = req
+	}
+	switch r := req.(type) {
+	case *pb.DeployJobSpecificationRequest:
+		c.jobs += len(r.GetJobs())
+	case *pb.ImportJobSpecificationsRequest:
+		c.archiveBytes += len(r.GetArchive())
+	}
+}
+
+// scope returns the project and namespace the change is made in
+func (c *auditChange) scope(method string) (string, string) {
+	switch r := c.req.(type) {
+	case *pb.RegisterProjectRequest:
+		return r.GetProject().GetName(), r.GetNamespace().GetName()
+	case *pb.RegisterProjectNamespaceRequest:
+		return r.GetProjectName(), r.GetNamespace().GetName()
+	}
+	return requestScope(method, c.req)
+}
+
+// summary describes the change without values of secrets and configs
+func (c *auditChange) summary() string {
+	switch r := c.req.(type) {
+	case *pb.RegisterProjectRequest:
+		summary := fmt.Sprintf("register project %s", r.GetProject().GetName())
+		if r.GetNamespace() != nil {
+			summary += fmt.Sprintf(" with namespace %s", r.GetNamespace().GetName())
+		}
+		return summary
+	case *pb.DeleteProjectRequest:
+		return fmt.Sprintf("delete project %s", r.GetProjectName())
+	case *pb.RestoreProjectRequest:
+		return fmt.Sprintf("restore project %s", r.GetProjectName())
+	case *pb.RegisterProjectNamespaceRequest:
+		return fmt.Sprintf("register namespace %s", r.GetNamespace().GetName())
+	case *pb.RegisterSecretRequest:
+		return fmt.Sprintf("register secret %s", r.GetSecretName())
+	case *pb.UpdateSecretRequest:
+		return fmt.Sprintf("update secret %s", r.GetSecretName())
+	case *pb.DeployJobSpecificationRequest:
+		summary := fmt.Sprintf("deploy %d jobs", c.jobs)
+		if r.GetReconcile() {
+			summary += " with reconcile"
+		}
+		return summary
+	case *pb.ImportJobSpecificationsRequest:
+		return fmt.Sprintf("import jobs from archive of %d bytes", c.archiveBytes)
+	case *pb.CreateJobSpecificationRequest:
+		return fmt.Sprintf("create job %s", r.GetSpec().GetName())
+	case *pb.DeleteJobSpecificationRequest:
+		return fmt.Sprintf("delete job %s", r.GetJobName())
+	case *pb.PauseJobRequest:
+		return fmt.Sprintf("pause job %s", r.GetJobName())
+	case *pb.ResumeJobRequest:
+		return fmt.Sprintf("resume job %s", r.GetJobName())
+	case *pb.RestoreJobRequest:
+		return fmt.Sprintf("restore job %s", r.GetJobName())
+	case *pb.RollbackJobRequest:
+		return fmt.Sprintf("rollback job %s to revision %d", r.GetJobName(), r.GetRevision())
+	case *pb.DeployResourceSpecificationRequest:
+		return fmt.Sprintf("deploy %d resources of datastore %s", len(r.GetResources()), r.GetDatastoreName())
+	case *pb.CreateResourceRequest:
+		return fmt.Sprintf("create resource %s of datastore %s", r.GetResource().GetName(), r.GetDatastoreName())
+	case *pb.UpdateResourceRequest:
+		return fmt.Sprintf("update resource %s of datastore %s", r.GetResource().GetName(), r.GetDatastoreName())
+	case *pb.ReplayRequest:
+		summary := fmt.Sprintf("replay job %s from %s to %s", r.GetJobName(), r.GetStartDate(), r.GetEndDate())
+		if r.GetForce() {
+			summary += " with force"
+		}
+		return summary
+	case *pb.ClearJobRunsRequest:
+		summary := fmt.Sprintf("clear runs of job %s from %s", r.GetJobName(), r.GetStartDate().AsTime().Format(time.RFC3339))
+		if r.GetEndDate() != nil {
+			summary += fmt.Sprintf(" to %s", r.GetEndDate().AsTime().Format(time.RFC3339))
+		}
+		if r.GetDryRun() {
+			summary += " as dry run"
+		}
+		return summary
+	case *pb.CreateRoleBindingRequest:
+		summary := fmt.Sprintf("grant %s role to %s", r.GetBinding().GetRole(), r.GetBinding().GetSubject())
+		if r.GetBinding().GetNamespace() != "" {
+			summary += fmt.Sprintf(" in namespace %s", r.GetBinding().GetNamespace())
+		}
+		return summary
+	case *pb.DeleteRoleBindingRequest:
+		summary := fmt.Sprintf("revoke role of %s", r.GetSubject())
+		if r.GetNamespace() != "" {
+			summary += fmt.Sprintf(" in namespace %s", r.GetNamespace())
+		}
+		return summary
+	case *pb.RegisterMacroRequest:
+		summary := fmt.Sprintf("register macro %s", r.GetMacro().GetName())
+		if r.GetMacro().GetNamespace() != "" {
+			summary += fmt.Sprintf(" in namespace %s", r.GetMacro().GetNamespace())
+		}
+		return summary
+	case *pb.DeleteMacroRequest:
+		summary := fmt.Sprintf("delete macro %s", r.GetName())
+		if r.GetNamespace() != "" {
+			summary += fmt.Sprintf(" in namespace %s", r.GetNamespace())
+		}
+		return summary
+	}
+	return ""
+}
+
+// Auditor records calls of mutating rpcs with identity of the caller,
+// it should be chained after authentication and before authorization
+// so denied calls are recorded as well
+type Auditor struct {
+	repo    store.AuditEventRepository
+	methods map[string]bool
+
+	Now func() time.Time
+}
+
+func (a *Auditor) record(ctx context.Context, method string, change *auditChange, callErr error) {
+	event := models.AuditEvent{
+		Method:    strings.TrimPrefix(method, servicePrefix),
+		Actor:     requestedBy(ctx),
+		Success:   callErr == nil,
+		CreatedAt: a.Now(),
+	}
+	if change.req != nil {
+		event.ProjectName, event.Namespace = change.scope(method)
+		event.Summary = change.summary()
+	}
+	if callErr != nil {
+		event.Error = status.Convert(callErr).Message()
+	}
+	// the call has already been applied, failing to audit it is only logged
+	if err := a.repo.Save(event); err != nil {
+		logger.E(errors.Wrapf(err, "failed to record audit event of %s", method))
+	}
+}
+
+// UnaryServerInterceptor records audited unary calls once handled
+func (a *Auditor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !a.methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		resp, err := handler(ctx, req)
+		change := &auditChange{}
+		change.add(req)
+		a.record(ctx, info.FullMethod, change, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records audited streams once closed, changes
+// requested by all the messages received are summarized in one event
+func (a *Auditor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !a.methods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		stream := &auditedServerStream{ServerStream: ss}
+		err := handler(srv, stream)
+
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		a.record(ss.Context(), info.FullMethod, &stream.change, err)
+		return err
+	}
+}
+
+// auditedServerStream accumulates the change requested by each message
+// received on stream
+type auditedServerStream struct {
+	grpc.ServerStream
+
+	mu     sync.Mutex
+	change auditChange
+}
+
+func (s *auditedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.change.add(m)
+	return nil
+}
+
+// NewAuditor records calls of methods in repo
+func NewAuditor(repo store.AuditEventRepository, methods []string) *Auditor {
+	methodSet := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		methodSet[method] = true
+	}
+	return &Auditor{
+		repo:    repo,
+		methods: methodSet,
+		Now:     time.Now,
+	}
+}
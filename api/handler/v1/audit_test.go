@@ -0,0 +1,166 @@
+package v1_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// receivedMessagesStream returns reqs one by one on reads
+type receivedMessagesStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*pb.DeployJobSpecificationRequest
+}
+
+func (s *receivedMessagesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *receivedMessagesStream) RecvMsg(m interface{}) error {
+	if len(s.reqs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(*pb.DeployJobSpecificationRequest), s.reqs[0])
+	s.reqs = s.reqs[1:]
+	return nil
+}
+
+func TestAuditor(t *testing.T) {
+	logger.InitWithWriter(logger.ERROR, ioutil.Discard)
+	now := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+	identityCtx := models.ContextWithIdentity(context.Background(), models.Identity{Subject: "ci", Method: models.AuthMethodAPIKey})
+	unaryInfo := func(method string) *grpc.UnaryServerInfo {
+		return &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/" + method}
+	}
+	newAuditor := func(repo *mock.AuditEventRepository) *v1.Auditor {
+		auditor := v1.NewAuditor(repo, v1.AuditedMethods)
+		auditor.Now = func() time.Time { return now }
+		return auditor
+	}
+
+	t.Run("UnaryServerInterceptor", func(t *testing.T) {
+		t.Run("should record successful calls without secret values", func(t *testing.T) {
+			repo := new(mock.AuditEventRepository)
+			repo.On("Save", models.AuditEvent{
+				ProjectName: "a-data-project",
+				Method:      "RegisterSecret",
+				Actor:       "ci",
+				Summary:     "register secret STORAGE",
+				Success:     true,
+				CreatedAt:   now,
+			}).Return(nil)
+			defer repo.AssertExpectations(t)
+
+			interceptor := newAuditor(repo).UnaryServerInterceptor()
+			_, err := interceptor(identityCtx, &pb.RegisterSecretRequest{
+				ProjectName: "a-data-project",
+				SecretName:  "STORAGE",
+				Value:       "c29tZS1zZWNyZXQ=",
+			}, unaryInfo("RegisterSecret"), func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.RegisterSecretResponse{Success: true}, nil
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should record failed calls with error", func(t *testing.T) {
+			repo := new(mock.AuditEventRepository)
+			repo.On("Save", models.AuditEvent{
+				ProjectName: "a-data-project",
+				Namespace:   "dev-team-1",
+				Method:      "RegisterProjectNamespace",
+				Actor:       "ci",
+				Summary:     "register namespace dev-team-1",
+				Success:     false,
+				Error:       "ci needs admin role in project a-data-project",
+				CreatedAt:   now,
+			}).Return(nil)
+			defer repo.AssertExpectations(t)
+
+			interceptor := newAuditor(repo).UnaryServerInterceptor()
+			_, err := interceptor(identityCtx, &pb.RegisterProjectNamespaceRequest{
+				ProjectName: "a-data-project",
+				Namespace:   &pb.NamespaceSpecification{Name: "dev-team-1"},
+			}, unaryInfo("RegisterProjectNamespace"), func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, status.Error(codes.PermissionDenied, "ci needs admin role in project a-data-project")
+			})
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+		t.Run("should not fail calls if recording fails", func(t *testing.T) {
+			repo := new(mock.AuditEventRepository)
+			repo.On("Save", mock2.Anything).Return(errors.New("db is down"))
+			defer repo.AssertExpectations(t)
+
+			interceptor := newAuditor(repo).UnaryServerInterceptor()
+			_, err := interceptor(identityCtx, &pb.DeleteJobSpecificationRequest{
+				ProjectName: "a-data-project",
+				Namespace:   "dev-team-1",
+				JobName:     "job-1",
+			}, unaryInfo("DeleteJobSpecification"), func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.DeleteJobSpecificationResponse{Success: true}, nil
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should skip methods which are not audited", func(t *testing.T) {
+			repo := new(mock.AuditEventRepository)
+			defer repo.AssertExpectations(t)
+
+			interceptor := newAuditor(repo).UnaryServerInterceptor()
+			_, err := interceptor(identityCtx, &pb.ListSecretKeysRequest{ProjectName: "a-data-project"},
+				unaryInfo("ListSecretKeys"), func(ctx context.Context, req interface{}) (interface{}, error) {
+					return &pb.ListSecretKeysResponse{}, nil
+				})
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("StreamServerInterceptor", func(t *testing.T) {
+		t.Run("should record one event summarizing all messages of stream", func(t *testing.T) {
+			repo := new(mock.AuditEventRepository)
+			repo.On("Save", models.AuditEvent{
+				ProjectName: "a-data-project",
+				Namespace:   "dev-team-1",
+				Method:      "DeployJobSpecificationStream",
+				Actor:       "ci",
+				Summary:     "deploy 3 jobs",
+				Success:     true,
+				CreatedAt:   now,
+			}).Return(nil)
+			defer repo.AssertExpectations(t)
+
+			stream := &receivedMessagesStream{
+				ctx: identityCtx,
+				reqs: []*pb.DeployJobSpecificationRequest{
+					{ProjectName: "a-data-project", Namespace: "dev-team-1", Jobs: []*pb.JobSpecification{{Name: "job-1"}, {Name: "job-2"}}},
+					{ProjectName: "a-data-project", Namespace: "dev-team-1", Jobs: []*pb.JobSpecification{{Name: "job-3"}}},
+				},
+			}
+			streamInfo := &grpc.StreamServerInfo{FullMethod: "/odpf.optimus.RuntimeService/DeployJobSpecificationStream"}
+			interceptor := newAuditor(repo).StreamServerInterceptor()
+			err := interceptor(nil, stream, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+				for {
+					if err := stream.RecvMsg(&pb.DeployJobSpecificationRequest{}); err != nil {
+						if err == io.EOF {
+							return nil
+						}
+						return err
+					}
+				}
+			})
+			assert.Nil(t, err)
+		})
+	})
+}
@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AuthorizationHeader carries either a static api key or an oidc token
+	// e.g.: authorization: Bearer <token>
+	AuthorizationHeader = "authorization"
+
+	// GatewayClientCertHeader carries the common name of the client
+	// certificate http server verified for calls the REST gateway proxies,
+	// it is only trusted on calls made through the gateway's connection
+	GatewayClientCertHeader = "x-optimus-gateway-client-cert"
+
+	bearerScheme = "bearer"
+)
+
+// UnauthenticatedMethods are the rpcs which can be called without
+// credentials
+var UnauthenticatedMethods = []string{
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+	"/odpf.optimus.RuntimeService/Version",
+}
+
+// TokenVerifier validates bearer tokens issued by an identity provider
+type TokenVerifier interface {
+	Verify(ctx context.Context, rawToken string) (models.Identity, error)
+}
+
+// Authenticator rejects calls without valid credentials and attaches the
+// identity of caller to the context of handlers
+type Authenticator struct {
+	// apiKeys maps static keys to their names
+	apiKeys map[string]string
+
+	// verifier of oidc tokens, nil if only api keys are accepted
+	verifier TokenVerifier
+
+	skipMethods map[string]bool
+
+	// ClientCertIdentity identifies callers without bearer token by common
+	// name of their client certificate verified during tls handshake
+	ClientCertIdentity bool
+
+	// GatewayNetwork is the network of the in memory connection REST gateway
+	// calls grpc server through. Calls on it have no tls, the certificate
+	// gateway forwards in GatewayClientCertHeader identifies them instead
+	GatewayNetwork string
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if a.skipMethods[method] {
+		return ctx, nil
+	}
+	token, err := bearerToken(ctx)
+	if err != nil {
+		if a.ClientCertIdentity {
+			if identity, ok := clientCertIdentity(ctx); ok {
+				return models.ContextWithIdentity(ctx, identity), nil
+			}
+			if identity, ok := a.gatewayClientCertIdentity(ctx); ok {
+				return models.ContextWithIdentity(ctx, identity), nil
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	for key, name := range a.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1 {
+			return models.ContextWithIdentity(ctx, models.Identity{
+				Subject: name,
+				Method:  models.AuthMethodAPIKey,
+			}), nil
+		}
+	}
+	if a.verifier == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	}
+
+	identity, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	return models.ContextWithIdentity(ctx, identity), nil
+}
+
+// bearerToken extracts the credential sent in authorization header
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errors.New("missing credentials")
+	}
+	values := md.Get(AuthorizationHeader)
+	if len(values) == 0 || values[0] == "" {
+		return "", errors.New("missing credentials")
+	}
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], bearerScheme) || strings.TrimSpace(parts[1]) == "" {
+		return "", errors.New("authorization header should be of the form 'Bearer <token>'")
+	}
+	return strings.TrimSpace(parts[1]), nil
+}
+
+// clientCertIdentity returns the identity of caller from the client
+// certificate, only certificates verified against client CAs are trusted
+func clientCertIdentity(ctx context.Context) (models.Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return models.Identity{}, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return models.Identity{}, false
+	}
+	commonName := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	if commonName == "" {
+		return models.Identity{}, false
+	}
+	return models.Identity{
+		Subject: commonName,
+		Method:  models.AuthMethodClientCert,
+	}, true
+}
+
+// gatewayClientCertIdentity returns the identity of caller from the client
+// certificate REST gateway forwards, the header is ignored on calls which
+// didn't come through the gateway as any client can set it
+func (a *Authenticator) gatewayClientCertIdentity(ctx context.Context) (models.Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || a.GatewayNetwork == "" || p.Addr == nil || p.Addr.Network() != a.GatewayNetwork {
+		return models.Identity{}, false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return models.Identity{}, false
+	}
+	values := md.Get(GatewayClientCertHeader)
+	if len(values) != 1 || values[0] == "" {
+		return models.Identity{}, false
+	}
+	return models.Identity{
+		Subject: values[0],
+		Method:  models.AuthMethodClientCert,
+	}, true
+}
+
+// UnaryServerInterceptor authenticates unary calls
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		authCtx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(authCtx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming calls
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		authCtx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedServerStream{ServerStream: ss, ctx: authCtx})
+	}
+}
+
+// authenticatedServerStream exposes the context carrying caller identity
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// NewAuthenticator accepts callers presenting one of apiKeys, a map of
+// key to its name, or a token accepted by verifier if it is not nil
+func NewAuthenticator(apiKeys map[string]string, verifier TokenVerifier, skipMethods []string) *Authenticator {
+	methodSet := make(map[string]bool, len(skipMethods))
+	for _, method := range skipMethods {
+		methodSet[method] = true
+	}
+	return &Authenticator{
+		apiKeys:     apiKeys,
+		verifier:    verifier,
+		skipMethods: methodSet,
+	}
+}
+
+// oidcVerifier validates signature, expiry, issuer and audience of jwt
+// tokens using keys published by the issuer
+type oidcVerifier struct {
+	verifier *oidc.IDTokenVerifier
+}
+
+func (v *oidcVerifier) Verify(ctx context.Context, rawToken string) (models.Identity, error) {
+	token, err := v.verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return models.Identity{}, err
+	}
+	var claims struct {
+		Email string `json:"email"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return models.Identity{}, err
+	}
+	return models.Identity{
+		Subject: token.Subject,
+		Email:   claims.Email,
+		Method:  models.AuthMethodOIDC,
+	}, nil
+}
+
+// NewOIDCVerifier discovers the provider configuration of issuer, tokens
+// need to be issued for audience
+func NewOIDCVerifier(ctx context.Context, issuer, audience string) (*oidcVerifier, error) {
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover oidc provider %s", issuer)
+	}
+	return &oidcVerifier{
+		verifier: provider.Verifier(&oidc.Config{ClientID: audience}),
+	}, nil
+}
@@ -0,0 +1,215 @@
+package v1_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type staticTokenVerifier map[string]models.Identity
+
+func (v staticTokenVerifier) Verify(ctx context.Context, rawToken string) (models.Identity, error) {
+	identity, ok := v[rawToken]
+	if !ok {
+		return models.Identity{}, errors.New("token expired")
+	}
+	return identity, nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	listMethod := "/odpf.optimus.RuntimeService/ListProjects"
+	apiKeys := map[string]string{"key-1": "ci"}
+	verifier := staticTokenVerifier{
+		"jwt-1": {Subject: "1234", Email: "alice@example.io", Method: models.AuthMethodOIDC},
+	}
+	authCtx := func(header string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(v1.AuthorizationHeader, header))
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: listMethod}
+	// certCtx returns context of a caller which presented a client certificate
+	certCtx := func(verified bool) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "airflow"}}
+		state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		if verified {
+			state.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+	}
+	// gatewayCtx returns context of a call proxied by REST gateway over
+	// network, with the client certificate gateway verified
+	gatewayCtx := func(network string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Name: "bufconn", Net: network}})
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(v1.GatewayClientCertHeader, "airflow"))
+	}
+
+	t.Run("UnaryServerInterceptor", func(t *testing.T) {
+		t.Run("should attach identity of api key to context", func(t *testing.T) {
+			interceptor := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods).UnaryServerInterceptor()
+
+			var identity models.Identity
+			_, err := interceptor(authCtx("Bearer key-1"), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ = models.IdentityFromContext(ctx)
+				return nil, nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, models.Identity{Subject: "ci", Method: models.AuthMethodAPIKey}, identity)
+		})
+		t.Run("should attach identity of verified token to context", func(t *testing.T) {
+			interceptor := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods).UnaryServerInterceptor()
+
+			var identity models.Identity
+			_, err := interceptor(authCtx("bearer jwt-1"), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ = models.IdentityFromContext(ctx)
+				return nil, nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, "alice@example.io", identity.Name())
+			assert.Equal(t, models.AuthMethodOIDC, identity.Method)
+		})
+		t.Run("should reject calls with invalid credentials", func(t *testing.T) {
+			cases := map[string]context.Context{
+				"missing header":   context.Background(),
+				"unknown scheme":   authCtx("Basic key-1"),
+				"empty token":      authCtx("Bearer "),
+				"unknown api key":  authCtx("Bearer key-2"),
+				"unverified token": authCtx("Bearer jwt-2"),
+			}
+			interceptor := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods).UnaryServerInterceptor()
+			for name, ctx := range cases {
+				t.Run(name, func(t *testing.T) {
+					called := false
+					_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+						called = true
+						return nil, nil
+					})
+					assert.Equal(t, codes.Unauthenticated, status.Code(err))
+					assert.False(t, called)
+				})
+			}
+		})
+		t.Run("should only accept api keys when oidc is not configured", func(t *testing.T) {
+			interceptor := v1.NewAuthenticator(apiKeys, nil, v1.UnauthenticatedMethods).UnaryServerInterceptor()
+			_, err := interceptor(authCtx("Bearer jwt-1"), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+		t.Run("should allow unauthenticated methods without credentials", func(t *testing.T) {
+			interceptor := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods).UnaryServerInterceptor()
+			versionInfo := &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/Version"}
+			_, err := interceptor(context.Background(), nil, versionInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				_, ok := models.IdentityFromContext(ctx)
+				assert.False(t, ok)
+				return nil, nil
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should identify callers by verified client certificate when enabled", func(t *testing.T) {
+			authenticator := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods)
+			authenticator.ClientCertIdentity = true
+			interceptor := authenticator.UnaryServerInterceptor()
+
+			var identity models.Identity
+			_, err := interceptor(certCtx(true), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ = models.IdentityFromContext(ctx)
+				return nil, nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, models.Identity{Subject: "airflow", Method: models.AuthMethodClientCert}, identity)
+		})
+		t.Run("should prefer bearer token over client certificate", func(t *testing.T) {
+			authenticator := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods)
+			authenticator.ClientCertIdentity = true
+			interceptor := authenticator.UnaryServerInterceptor()
+
+			ctx := metadata.NewIncomingContext(certCtx(true), metadata.Pairs(v1.AuthorizationHeader, "Bearer key-1"))
+			var identity models.Identity
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ = models.IdentityFromContext(ctx)
+				return nil, nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, "ci", identity.Subject)
+		})
+		t.Run("should reject client certificates which are not verified or not enabled", func(t *testing.T) {
+			authenticator := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods)
+			authenticator.ClientCertIdentity = true
+			_, err := authenticator.UnaryServerInterceptor()(certCtx(false), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+			interceptor := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods).UnaryServerInterceptor()
+			_, err = interceptor(certCtx(true), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+		t.Run("should identify callers by client certificate forwarded by gateway", func(t *testing.T) {
+			authenticator := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods)
+			authenticator.ClientCertIdentity = true
+			authenticator.GatewayNetwork = "bufconn"
+			interceptor := authenticator.UnaryServerInterceptor()
+
+			var identity models.Identity
+			_, err := interceptor(gatewayCtx("bufconn"), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ = models.IdentityFromContext(ctx)
+				return nil, nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, models.Identity{Subject: "airflow", Method: models.AuthMethodClientCert}, identity)
+		})
+		t.Run("should reject gateway client certificates sent by other callers or when not enabled", func(t *testing.T) {
+			authenticator := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods)
+			authenticator.ClientCertIdentity = true
+			authenticator.GatewayNetwork = "bufconn"
+			_, err := authenticator.UnaryServerInterceptor()(gatewayCtx("tcp"), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+			authenticator = v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods)
+			authenticator.GatewayNetwork = "bufconn"
+			_, err = authenticator.UnaryServerInterceptor()(gatewayCtx("bufconn"), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	})
+	t.Run("StreamServerInterceptor", func(t *testing.T) {
+		streamInfo := &grpc.StreamServerInfo{FullMethod: "/odpf.optimus.RuntimeService/DeployJobSpecification"}
+
+		t.Run("should expose identity through stream context", func(t *testing.T) {
+			interceptor := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods).StreamServerInterceptor()
+
+			var identity models.Identity
+			err := interceptor(nil, &sentMessagesStream{ctx: authCtx("Bearer key-1")}, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+				identity, _ = models.IdentityFromContext(stream.Context())
+				return nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, "ci", identity.Subject)
+		})
+		t.Run("should reject streams with invalid credentials", func(t *testing.T) {
+			interceptor := v1.NewAuthenticator(apiKeys, verifier, v1.UnauthenticatedMethods).StreamServerInterceptor()
+			err := interceptor(nil, &sentMessagesStream{ctx: context.Background()}, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+				return nil
+			})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	})
+}
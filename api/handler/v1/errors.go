@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/odpf/optimus/core/tree"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorDomain is set as domain of google.rpc.ErrorInfo details
+	// attached to failures of runtime rpcs
+	ErrorDomain = "optimus.odpf.io"
+)
+
+// reasons set in google.rpc.ErrorInfo details, clients can rely on these
+// to tell apart the cause of failure instead of parsing messages
+const (
+	ErrReasonNotFound           = "NOT_FOUND"
+	ErrReasonAlreadyExists      = "ALREADY_EXISTS"
+	ErrReasonFailedPrecondition = "FAILED_PRECONDITION"
+	ErrReasonInvalidArgument    = "INVALID_ARGUMENT"
+	ErrReasonResourceExhausted  = "RESOURCE_EXHAUSTED"
+	ErrReasonUnavailable        = "UNAVAILABLE"
+	ErrReasonInternal           = "INTERNAL"
+)
+
+var errorReasons = map[codes.Code]string{
+	codes.NotFound:           ErrReasonNotFound,
+	codes.AlreadyExists:      ErrReasonAlreadyExists,
+	codes.FailedPrecondition: ErrReasonFailedPrecondition,
+	codes.InvalidArgument:    ErrReasonInvalidArgument,
+	codes.ResourceExhausted:  ErrReasonResourceExhausted,
+	codes.Unavailable:        ErrReasonUnavailable,
+	codes.Internal:           ErrReasonInternal,
+}
+
+// statusErrorf builds a grpc status error with google.rpc.ErrorInfo details,
+// well known errors of stores and services take precedence over the
+// provided code
+func statusErrorf(code codes.Code, err error, format string, args ...interface{}) error {
+	code = errorCode(code, err)
+	return statusWithDetails(status.New(code, fmt.Sprintf(format, args...)), errorInfo(code))
+}
+
+// invalidArgumentf builds a grpc status error for a malformed request field,
+// violation is attached as google.rpc.BadRequest details
+func invalidArgumentf(field string, err error, format string, args ...interface{}) error {
+	violation := &errdetails.BadRequest_FieldViolation{
+		Field: field,
+	}
+	if err != nil {
+		violation.Description = err.Error()
+	}
+	return statusWithDetails(status.New(codes.InvalidArgument, fmt.Sprintf(format, args...)),
+		errorInfo(codes.InvalidArgument),
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{violation},
+		},
+	)
+}
+
+// errorCode maps errors returned by stores and services to grpc codes
+func errorCode(fallback codes.Code, err error) codes.Code {
+	if err == nil {
+		return fallback
+	}
+	switch {
+	case errors.Is(err, store.ErrResourceNotFound),
+		errors.Is(err, models.ErrNoSuchSpec),
+		errors.Is(err, models.ErrNoSuchJob),
+		errors.Is(err, models.ErrNoSuchAsset),
+		errors.Is(err, models.ErrNoSuchHook),
+		errors.Is(err, job.ErrJobSpecNotFound):
+		return codes.NotFound
+	case errors.Is(err, store.ErrResourceAlreadyExists):
+		return codes.AlreadyExists
+	case errors.Is(err, job.ErrConflictedJobRun),
+		errors.Is(err, tree.ErrCyclicDependencyEncountered),
+		errors.Is(err, job.ErrDuplicateDestination),
+		errors.Is(err, store.ErrRevisionConflict),
+		errors.Is(err, store.ErrResourceDeleted):
+		return codes.FailedPrecondition
+	case errors.Is(err, job.ErrRequestQueueFull):
+		return codes.Unavailable
+	case errors.Is(err, models.ErrUnsupportedPlugin),
+		errors.Is(err, models.ErrUnsupportedPluginVersion),
+		errors.Is(err, models.ErrUnsupportedDatastore):
+		return codes.InvalidArgument
+	}
+	return fallback
+}
+
+func errorInfo(code codes.Code) *errdetails.ErrorInfo {
+	reason, ok := errorReasons[code]
+	if !ok {
+		reason = ErrReasonInternal
+	}
+	return &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	}
+}
+
+func statusWithDetails(st *status.Status, details ...proto.Message) error {
+	stWithDetails, err := st.WithDetails(details...)
+	if err != nil {
+		// details are best effort, fallback to plain status
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
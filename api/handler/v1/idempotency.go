@@ -0,0 +1,296 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// IdempotencyKeyHeader is the metadata header carrying a client
+	// generated key, retries of a mutating rpc with the same key are not
+	// applied again and get the response of the first call. Keys are scoped
+	// to the caller, and reusing one with another request fails
+	IdempotencyKeyHeader = "idempotency-key"
+)
+
+// IdempotentMethods are the rpcs honoring idempotency key
+var IdempotentMethods = []string{
+	"/odpf.optimus.RuntimeService/RegisterProject",
+	"/odpf.optimus.RuntimeService/RegisterProjectNamespace",
+	"/odpf.optimus.RuntimeService/DeployJobSpecification",
+	"/odpf.optimus.RuntimeService/DeployJobSpecificationStream",
+	"/odpf.optimus.RuntimeService/DeployJobSpecificationAsync",
+	"/odpf.optimus.RuntimeService/ImportJobSpecifications",
+	"/odpf.optimus.RuntimeService/DeployResourceSpecification",
+	"/odpf.optimus.RuntimeService/DeleteJobSpecification",
+}
+
+// idempotentCall is the outcome of the first call made with a key
+type idempotentCall struct {
+	done chan struct{}
+
+	// requestHash is the hash of requests of the call, set once requests
+	// are read
+	requestHash string
+	// recvCount and recvType are the number and type of messages received
+	// by a streaming rpc, retries read as many to compare their hash
+	recvCount int
+	recvType  reflect.Type
+
+	resp interface{}
+	// msgs are the messages sent by a streaming rpc
+	msgs       []interface{}
+	err        error
+	finishedAt time.Time
+}
+
+// IdempotencyCache dedupes calls of idempotent methods made with the same
+// idempotency key. Only successful calls are cached, failed calls can be
+// retried with the same key
+type IdempotencyCache struct {
+	mu      sync.Mutex
+	methods map[string]bool
+	calls   map[string]*idempotentCall
+
+	// retention is the duration a finished call is kept for
+	retention time.Duration
+	Now       func() time.Time
+}
+
+// begin returns the call made earlier with the key, if there is none the
+// caller is registered as the first call
+func (c *IdempotencyCache) begin(key string) (*idempotentCall, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.Now()
+	for callKey, call := range c.calls {
+		if !call.finishedAt.IsZero() && now.Sub(call.finishedAt) > c.retention {
+			delete(c.calls, callKey)
+		}
+	}
+
+	if call, ok := c.calls[key]; ok {
+		return call, true
+	}
+	call := &idempotentCall{done: make(chan struct{})}
+	c.calls[key] = call
+	return call, false
+}
+
+func (c *IdempotencyCache) finish(key string, call *idempotentCall, resp interface{}, msgs []interface{}, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	call.resp, call.msgs, call.err = resp, msgs, err
+	call.finishedAt = c.Now()
+	if err != nil {
+		delete(c.calls, key)
+	}
+	close(call.done)
+}
+
+// wait blocks till the call in progress with the same key is finished
+func (c *IdempotencyCache) wait(ctx context.Context, call *idempotentCall) error {
+	select {
+	case <-call.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// callKey scopes the idempotency key of a call to its method and caller,
+// so callers can't get responses of each other's calls
+func (c *IdempotencyCache) callKey(ctx context.Context, method string) string {
+	if !c.methods[method] {
+		return ""
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	keys := md.Get(IdempotencyKeyHeader)
+	if len(keys) == 0 || keys[0] == "" {
+		return ""
+	}
+	var caller string
+	if identity, ok := models.IdentityFromContext(ctx); ok {
+		caller = identity.Subject
+	}
+	return method + "/" + caller + "/" + keys[0]
+}
+
+// writeRequest adds the deterministic encoding of a request to the hash,
+// requests which are not protos are left out
+func writeRequest(h hash.Hash, req interface{}) {
+	msg, ok := req.(proto.Message)
+	if !ok || reflect.ValueOf(msg).IsNil() {
+		return
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return
+	}
+	h.Write(raw)
+}
+
+func requestHash(req interface{}) string {
+	h := sha256.New()
+	writeRequest(h, req)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func reusedKeyError() error {
+	return status.Errorf(codes.FailedPrecondition, "%s is already used for another request, a new key is needed",
+		IdempotencyKeyHeader)
+}
+
+// UnaryServerInterceptor returns the cached response for retried calls
+func (c *IdempotencyCache) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := c.callKey(ctx, info.FullMethod)
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		reqHash := requestHash(req)
+		call, found := c.begin(key)
+		if found {
+			if err := c.wait(ctx, call); err != nil {
+				return nil, err
+			}
+			if call.err == nil && call.requestHash != reqHash {
+				return nil, reusedKeyError()
+			}
+			return call.resp, call.err
+		}
+
+		call.requestHash = reqHash
+		resp, err := handler(ctx, req)
+		c.finish(key, call, resp, nil, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor replays the messages sent by the first call
+// for retried calls
+func (c *IdempotencyCache) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		key := c.callKey(ss.Context(), info.FullMethod)
+		if key == "" {
+			return handler(srv, ss)
+		}
+
+		call, found := c.begin(key)
+		if found {
+			if err := c.wait(ss.Context(), call); err != nil {
+				return err
+			}
+			if call.err != nil {
+				return call.err
+			}
+			reqHash, err := receivedHash(ss, call.recvType, call.recvCount)
+			if err != nil {
+				return err
+			}
+			if reqHash != call.requestHash {
+				return reusedKeyError()
+			}
+			for _, msg := range call.msgs {
+				if err := ss.SendMsg(msg); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		stream := &recordingServerStream{ServerStream: ss, recvHash: sha256.New()}
+		err := handler(srv, stream)
+		call.requestHash = hex.EncodeToString(stream.recvHash.Sum(nil))
+		call.recvCount, call.recvType = stream.recvCount, stream.recvType
+		c.finish(key, call, nil, stream.msgs, err)
+		return err
+	}
+}
+
+// receivedHash reads as many messages as the first call received from a
+// retried stream and returns their hash, reading stops early if the
+// client closes the stream
+func receivedHash(ss grpc.ServerStream, recvType reflect.Type, count int) (string, error) {
+	h := sha256.New()
+	for i := 0; i < count && recvType != nil; i++ {
+		msg := reflect.New(recvType.Elem()).Interface()
+		if err := ss.RecvMsg(msg); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", err
+		}
+		writeRequest(h, msg)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// recordingServerStream keeps a copy of all the messages sent, and the hash
+// of the ones received
+type recordingServerStream struct {
+	grpc.ServerStream
+
+	mu   sync.Mutex
+	msgs []interface{}
+
+	recvHash  hash.Hash
+	recvCount int
+	recvType  reflect.Type
+}
+
+func (s *recordingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeRequest(s.recvHash, m)
+	s.recvCount++
+	s.recvType = reflect.TypeOf(m)
+	return nil
+}
+
+func (s *recordingServerStream) SendMsg(m interface{}) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs = append(s.msgs, m)
+	return nil
+}
+
+// NewIdempotencyCache creates a cache for the provided methods, finished
+// calls are kept for retention
+func NewIdempotencyCache(methods []string, retention time.Duration) *IdempotencyCache {
+	methodSet := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		methodSet[method] = true
+	}
+	return &IdempotencyCache{
+		methods:   methodSet,
+		calls:     map[string]*idempotentCall{},
+		retention: retention,
+		Now:       time.Now,
+	}
+}
@@ -0,0 +1,183 @@
+package v1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type sentMessagesStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []interface{}
+}
+
+func (s *sentMessagesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *sentMessagesStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestIdempotencyCache(t *testing.T) {
+	registerMethod := "/odpf.optimus.RuntimeService/RegisterProject"
+	deployMethod := "/odpf.optimus.RuntimeService/DeployJobSpecification"
+	keyCtx := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(v1.IdempotencyKeyHeader, key))
+	}
+
+	t.Run("UnaryServerInterceptor", func(t *testing.T) {
+		t.Run("should return response of the first call for retried calls", func(t *testing.T) {
+			cache := v1.NewIdempotencyCache(v1.IdempotentMethods, time.Hour)
+			interceptor := cache.UnaryServerInterceptor()
+
+			calls := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return &pb.RegisterProjectResponse{Success: true}, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: registerMethod}
+
+			first, err := interceptor(keyCtx("key-1"), nil, info, handler)
+			assert.Nil(t, err)
+			retried, err := interceptor(keyCtx("key-1"), nil, info, handler)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, calls)
+			assert.Equal(t, first, retried)
+
+			_, err = interceptor(keyCtx("key-2"), nil, info, handler)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, calls)
+		})
+		t.Run("should not share responses between callers using the same key", func(t *testing.T) {
+			cache := v1.NewIdempotencyCache(v1.IdempotentMethods, time.Hour)
+			interceptor := cache.UnaryServerInterceptor()
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ := models.IdentityFromContext(ctx)
+				return &pb.RegisterProjectResponse{Success: true, Message: identity.Subject}, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: registerMethod}
+			req := &pb.RegisterProjectRequest{Project: &pb.ProjectSpecification{Name: "a-data-project"}}
+
+			aliceResp, err := interceptor(models.ContextWithIdentity(keyCtx("key-1"), models.Identity{Subject: "alice"}), req, info, handler)
+			assert.Nil(t, err)
+			bobResp, err := interceptor(models.ContextWithIdentity(keyCtx("key-1"), models.Identity{Subject: "bob"}), req, info, handler)
+			assert.Nil(t, err)
+			assert.Equal(t, "alice", aliceResp.(*pb.RegisterProjectResponse).Message)
+			assert.Equal(t, "bob", bobResp.(*pb.RegisterProjectResponse).Message)
+		})
+		t.Run("should reject reused key with a changed request", func(t *testing.T) {
+			cache := v1.NewIdempotencyCache(v1.IdempotentMethods, time.Hour)
+			interceptor := cache.UnaryServerInterceptor()
+
+			calls := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return &pb.RegisterProjectResponse{Success: true}, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: registerMethod}
+
+			_, err := interceptor(keyCtx("key-1"), &pb.RegisterProjectRequest{
+				Project: &pb.ProjectSpecification{Name: "a-data-project"},
+			}, info, handler)
+			assert.Nil(t, err)
+			_, err = interceptor(keyCtx("key-1"), &pb.RegisterProjectRequest{
+				Project: &pb.ProjectSpecification{Name: "another-project"},
+			}, info, handler)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Equal(t, 1, calls)
+		})
+		t.Run("should apply failed calls again on retry", func(t *testing.T) {
+			cache := v1.NewIdempotencyCache(v1.IdempotentMethods, time.Hour)
+			interceptor := cache.UnaryServerInterceptor()
+
+			calls := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				if calls == 1 {
+					return nil, errors.New("db down")
+				}
+				return &pb.RegisterProjectResponse{Success: true}, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: registerMethod}
+
+			_, err := interceptor(keyCtx("key-1"), nil, info, handler)
+			assert.NotNil(t, err)
+			resp, err := interceptor(keyCtx("key-1"), nil, info, handler)
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.RegisterProjectResponse{Success: true}, resp)
+			assert.Equal(t, 2, calls)
+		})
+		t.Run("should apply calls without key or of other methods every time", func(t *testing.T) {
+			cache := v1.NewIdempotencyCache(v1.IdempotentMethods, time.Hour)
+			interceptor := cache.UnaryServerInterceptor()
+
+			calls := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return nil, nil
+			}
+
+			interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: registerMethod}, handler)
+			interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: registerMethod}, handler)
+			interceptor(keyCtx("key-1"), nil, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/ListProjects"}, handler)
+			interceptor(keyCtx("key-1"), nil, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/ListProjects"}, handler)
+			assert.Equal(t, 4, calls)
+		})
+		t.Run("should apply retried calls again once retention is over", func(t *testing.T) {
+			cache := v1.NewIdempotencyCache(v1.IdempotentMethods, time.Hour)
+			now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			cache.Now = func() time.Time { return now }
+			interceptor := cache.UnaryServerInterceptor()
+
+			calls := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return nil, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: registerMethod}
+
+			interceptor(keyCtx("key-1"), nil, info, handler)
+			now = now.Add(2 * time.Hour)
+			interceptor(keyCtx("key-1"), nil, info, handler)
+			assert.Equal(t, 2, calls)
+		})
+	})
+	t.Run("StreamServerInterceptor", func(t *testing.T) {
+		t.Run("should replay messages of the first call for retried calls", func(t *testing.T) {
+			cache := v1.NewIdempotencyCache(v1.IdempotentMethods, time.Hour)
+			interceptor := cache.StreamServerInterceptor()
+
+			calls := 0
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				calls++
+				stream.SendMsg(&pb.DeployJobSpecificationResponse{JobName: "job-1", Ack: true, Success: true})
+				stream.SendMsg(&pb.DeployJobSpecificationResponse{JobName: "job-2", Ack: true, Success: true})
+				return nil
+			}
+			info := &grpc.StreamServerInfo{FullMethod: deployMethod}
+
+			first := &sentMessagesStream{ctx: keyCtx("key-1")}
+			assert.Nil(t, interceptor(nil, first, info, handler))
+			retried := &sentMessagesStream{ctx: keyCtx("key-1")}
+			assert.Nil(t, interceptor(nil, retried, info, handler))
+
+			assert.Equal(t, 1, calls)
+			assert.Equal(t, 2, len(retried.sent))
+			assert.Equal(t, first.sent, retried.sent)
+		})
+	})
+}
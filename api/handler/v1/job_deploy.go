@@ -0,0 +1,506 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kushsharma/parallel"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// JobSkippedUnchangedMessage is streamed back for jobs of a deployment
+	// which are same as the stored specification
+	JobSkippedUnchangedMessage = "skipped (unchanged)"
+
+	// JobSavedMessage is streamed back for jobs of a streamed deployment
+	// as they are saved, jobs are acknowledged once uploaded to scheduler
+	JobSavedMessage = "saved"
+
+	// DefaultJobUploadConcurrency is the number of jobs of a deployment
+	// adapted in parallel, also the number of batches saved in parallel
+	DefaultJobUploadConcurrency = 8
+
+	// DefaultJobUploadBatchSize is the number of jobs of a deployment
+	// saved in a single transaction
+	DefaultJobUploadBatchSize = 100
+
+	// RequestedByHeader is the metadata header carrying the user requesting
+	// a deployment, it is kept in deployment history
+	RequestedByHeader = "requested-by"
+)
+
+// requestedBy returns the user who made the request, authenticated callers
+// take precedence over the header, empty if not known
+func requestedBy(ctx context.Context) string {
+	if identity, ok := models.IdentityFromContext(ctx); ok {
+		return identity.Name()
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if users := md.Get(RequestedByHeader); len(users) > 0 {
+		return users[0]
+	}
+	return ""
+}
+
+// phases of a deployment reported in heartbeats
+const (
+	DeployPhaseSyncing               = "syncing"
+	DeployPhaseResolvingDependencies = "resolving dependencies"
+	DeployPhaseAssigningPriorities   = "assigning priorities"
+	DeployPhaseUploading             = "uploading"
+)
+
+// DefaultDeployHeartbeatInterval is the interval at which progress of sync
+// is sent on deploy streams
+const DefaultDeployHeartbeatInterval = 15 * time.Second
+
+// startHeartbeat sends progress of sync every interval till stopped, this
+// keeps the stream alive while sync is not sending any other event
+func (obs *jobSyncObserver) startHeartbeat(interval time.Duration, totalJobs int) (stop func()) {
+	obs.mu.Lock()
+	obs.phase = DeployPhaseSyncing
+	obs.processedJobs = 0
+	obs.totalJobs = totalJobs
+	obs.mu.Unlock()
+
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				obs.sendHeartbeat()
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+func (obs *jobSyncObserver) sendHeartbeat() {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	message := fmt.Sprintf("%s, processed %d jobs", obs.phase, obs.processedJobs)
+	if obs.totalJobs > 0 {
+		message = fmt.Sprintf("%s, processed %d/%d jobs", obs.phase, obs.processedJobs, obs.totalJobs)
+	}
+	resp := &pb.DeployJobSpecificationResponse{
+		Message: message,
+		Heartbeat: &pb.DeployHeartbeat{
+			Phase:         obs.phase,
+			ProcessedJobs: int32(obs.processedJobs),
+			TotalJobs:     int32(obs.totalJobs),
+		},
+	}
+	if err := obs.stream.Send(resp); err != nil {
+		obs.log.Error(errors.Wrap(err, "failed to send heartbeat"))
+	}
+}
+
+// jobUploadResult is the outcome of saving a single job of a deployment
+type jobUploadResult struct {
+	name    string
+	spec    models.JobSpec
+	skipped bool
+
+	// adaptErr is set if the requested job is not a valid specification
+	adaptErr error
+	err      error
+}
+
+// uploadJobSpecs adapts requested jobs using a bounded number of workers
+// and saves them in batches, jobs same as the stored specification are
+// skipped. Jobs of a batch which fails to save are saved one by one so
+// that only the jobs which fail are reported. Results are in the order of
+// requested jobs
+func (sv *RuntimeServiceServer) uploadJobSpecs(deployTx *job.DeployTransaction, reqJobs []*pb.JobSpecification,
+	storedHashes map[string]string) []jobUploadResult {
+	concurrency := sv.UploadConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	batchSize := sv.UploadBatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	runner := parallel.NewRunner(parallel.WithLimit(concurrency))
+	for _, reqJob := range reqJobs {
+		runner.Add(func(reqJob *pb.JobSpecification) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				return sv.prepareJobSpec(reqJob, storedHashes), nil
+			}
+		}(reqJob))
+	}
+
+	states := runner.Run()
+	results := make([]jobUploadResult, len(states))
+	var toSave []int
+	for idx, state := range states {
+		results[idx] = state.Val.(jobUploadResult)
+		if results[idx].adaptErr == nil && results[idx].err == nil && !results[idx].skipped {
+			toSave = append(toSave, idx)
+		}
+	}
+
+	runner = parallel.NewRunner(parallel.WithLimit(concurrency))
+	for start := 0; start < len(toSave); start += batchSize {
+		end := start + batchSize
+		if end > len(toSave) {
+			end = len(toSave)
+		}
+		runner.Add(func(batch []int) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				jobSpecs := make([]models.JobSpec, len(batch))
+				for i, idx := range batch {
+					jobSpecs[i] = results[idx].spec
+				}
+				if err := deployTx.CreateAll(jobSpecs); err == nil {
+					return nil, nil
+				}
+				// nothing of the batch is saved, batches don't share
+				// results so no need to lock
+				for _, idx := range batch {
+					if err := deployTx.Create(results[idx].spec); err != nil {
+						results[idx].err = errors.Wrapf(err, "failed to save %s", results[idx].name)
+					}
+				}
+				return nil, nil
+			}
+		}(toSave[start:end]))
+	}
+	runner.Run()
+	return results
+}
+
+// prepareJobSpec adapts requested job and checks if it differs from the
+// stored specification
+func (sv *RuntimeServiceServer) prepareJobSpec(reqJob *pb.JobSpecification, storedHashes map[string]string) jobUploadResult {
+	result := jobUploadResult{name: reqJob.GetName()}
+
+	adaptJob, err := sv.adapter.FromJobProto(reqJob)
+	if err != nil {
+		result.adaptErr = errors.Wrapf(err, "cannot adapt job %s", reqJob.GetName())
+		return result
+	}
+	result.spec = adaptJob
+	if err := job.ValidateSchedule(adaptJob); err != nil {
+		result.adaptErr = err
+		return result
+	}
+
+	// only upsert jobs which differ from the stored specification
+	hash, err := jobSpecHash(sv.adapter, adaptJob)
+	if err != nil {
+		result.err = errors.Wrapf(err, "failed to hash job %s", adaptJob.Name)
+		return result
+	}
+	if storedHash, ok := storedHashes[adaptJob.Name]; ok && storedHash == hash {
+		result.skipped = true
+	}
+	return result
+}
+
+// jobSpecHash returns a digest of job specification, specs are hashed in
+// their proto form so that a spec read from the store and the same spec
+// sent by a client produce the same digest. Revision is left out as it is
+// not part of the specification itself
+func jobSpecHash(adapter ProtoAdapter, jobSpec models.JobSpec) (string, error) {
+	jobSpec.Revision = 0
+	jobProto, err := adapter.ToJobProto(jobSpec)
+	if err != nil {
+		return "", err
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(jobProto)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to serialize job %s", jobSpec.Name)
+	}
+	digest := sha256.Sum256(raw)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// jobSpecHashes returns digest of each job specification keyed by job name
+func jobSpecHashes(adapter ProtoAdapter, jobSpecs []models.JobSpec) (map[string]string, error) {
+	hashes := make(map[string]string, len(jobSpecs))
+	for _, jobSpec := range jobSpecs {
+		hash, err := jobSpecHash(adapter, jobSpec)
+		if err != nil {
+			return nil, err
+		}
+		hashes[jobSpec.Name] = hash
+	}
+	return hashes, nil
+}
+
+// hasDeletableJobs returns true if any of the stored jobs is not part of
+// the requested jobs and will be deleted by the deployment
+func hasDeletableJobs(storedHashes map[string]string, requestedJobs []models.JobSpec) bool {
+	requested := make(map[string]bool, len(requestedJobs))
+	for _, jobSpec := range requestedJobs {
+		requested[jobSpec.Name] = true
+	}
+	for name := range storedHashes {
+		if !requested[name] && !strings.HasPrefix(name, job.PersistJobPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// jobDeployStream is the response stream of rpcs deploying jobs
+type jobDeployStream interface {
+	Send(*pb.DeployJobSpecificationResponse) error
+	Context() context.Context
+}
+
+// jobDeployment deploys jobs of a namespace received in one or more
+// batches, changes are reverted if any of the jobs fail to deploy
+type jobDeployment struct {
+	sv           *RuntimeServiceServer
+	namespace    models.NamespaceSpec
+	stream       jobDeployStream
+	observers    progress.Observer
+	syncObserver *jobSyncObserver
+	deployTx     *job.DeployTransaction
+
+	storedHashes map[string]string
+	jobsToKeep   []models.JobSpec
+	modifiedJobs int
+	startTime    time.Time
+
+	// outcome of each job kept in deployment history
+	mu   sync.Mutex
+	jobs []models.JobDeployment
+}
+
+func (sv *RuntimeServiceServer) newJobDeployment(namespace models.NamespaceSpec, stream jobDeployStream) (*jobDeployment, error) {
+	storedJobSpecs, err := sv.jobSvc.GetAll(namespace)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve jobs for namespace %s", err.Error(), namespace.Name)
+	}
+	storedHashes, err := jobSpecHashes(sv.adapter, storedJobSpecs)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to hash stored jobs", err.Error())
+	}
+
+	syncObserver := &jobSyncObserver{
+		stream: stream,
+		log:    logrus.New(),
+	}
+	observers := new(progress.ObserverChain)
+	deployment := &jobDeployment{
+		sv:           sv,
+		namespace:    namespace,
+		stream:       stream,
+		observers:    observers,
+		syncObserver: syncObserver,
+		deployTx:     job.NewDeployTransaction(sv.jobSvc, namespace, storedJobSpecs),
+		storedHashes: storedHashes,
+		startTime:    time.Now(),
+	}
+	observers.Join(sv.progressObserver)
+	observers.Join(syncObserver)
+	observers.Join(deployment)
+	return deployment, nil
+}
+
+// Notify tracks outcome of jobs while they are synced and reverted
+func (d *jobDeployment) Notify(e progress.Event) {
+	switch evt := e.(type) {
+	case *job.EventJobUpload:
+		if evt.Err != nil {
+			d.setJobStatus(evt.Job.Name, models.JobDeploymentStatusFailed, evt.Err.Error())
+		}
+	case *job.EventSavedJobDelete:
+		d.setJobStatus(evt.Name, models.JobDeploymentStatusDeleted, "")
+	case *job.EventJobSpecRevert:
+		d.setJobStatus(evt.Name, models.JobDeploymentStatusReverted, "")
+	}
+}
+
+func (d *jobDeployment) setJobStatus(name, status, message string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for idx := range d.jobs {
+		if d.jobs[idx].Name == name {
+			d.jobs[idx].Status = status
+			d.jobs[idx].Message = message
+			return
+		}
+	}
+	d.jobs = append(d.jobs, models.JobDeployment{Name: name, Status: status, Message: message})
+}
+
+// record keeps the deployment in history along with the outcome of each
+// job, err is the failure of deployment if any and is returned as is
+func (d *jobDeployment) record(err error) error {
+	if d.sv.deployManager == nil {
+		return err
+	}
+
+	d.mu.Lock()
+	deployment := models.DeploymentSpec{
+		Namespace:   d.namespace,
+		Status:      models.DeploymentStatusSuccess,
+		RequestedBy: requestedBy(d.stream.Context()),
+		Jobs:        append([]models.JobDeployment(nil), d.jobs...),
+		CreatedAt:   d.startTime,
+		UpdatedAt:   time.Now(),
+	}
+	d.mu.Unlock()
+	if err != nil {
+		deployment.Status = models.DeploymentStatusFailed
+		deployment.Message = err.Error()
+	}
+	if recordErr := d.sv.deployManager.Record(deployment); recordErr != nil {
+		logger.E(errors.Wrap(recordErr, "failed to record deployment"))
+	}
+	return err
+}
+
+// upload saves a batch of requested jobs, skipped and failed jobs are
+// acknowledged right away. Saved jobs are acknowledged once uploaded to
+// scheduler, if ackSaved is set a progress event is sent as they are saved
+func (d *jobDeployment) upload(reqJobs []*pb.JobSpecification, ackSaved bool) error {
+	var adaptErr, uploadErr error
+	for _, result := range d.sv.uploadJobSpecs(d.deployTx, reqJobs, d.storedHashes) {
+		resp := &pb.DeployJobSpecificationResponse{
+			Success: true,
+			Ack:     true,
+			JobName: result.name,
+		}
+		switch {
+		case result.adaptErr != nil:
+			if adaptErr == nil {
+				adaptErr = result.adaptErr
+			}
+			resp.Success = false
+			resp.Message = result.adaptErr.Error()
+			d.setJobStatus(result.name, models.JobDeploymentStatusFailed, resp.Message)
+		case result.err != nil:
+			// conflicts are reported over other failures, deploying again
+			// after pulling the changed specs resolves them
+			if uploadErr == nil || (errors.Is(result.err, store.ErrRevisionConflict) && !errors.Is(uploadErr, store.ErrRevisionConflict)) {
+				uploadErr = result.err
+			}
+			resp.Success = false
+			resp.Message = result.err.Error()
+			d.setJobStatus(result.name, models.JobDeploymentStatusFailed, resp.Message)
+		case result.skipped:
+			d.jobsToKeep = append(d.jobsToKeep, result.spec)
+			resp.Message = JobSkippedUnchangedMessage
+			d.setJobStatus(result.name, models.JobDeploymentStatusSkipped, "")
+		default:
+			d.jobsToKeep = append(d.jobsToKeep, result.spec)
+			d.modifiedJobs++
+			d.setJobStatus(result.name, models.JobDeploymentStatusDeployed, "")
+			if !ackSaved {
+				continue
+			}
+			resp = &pb.DeployJobSpecificationResponse{
+				JobName: result.name,
+				Message: JobSavedMessage,
+			}
+		}
+		if err := d.stream.Send(resp); err != nil {
+			logger.W(errors.Wrapf(err, "failed to send deploy spec ack for: %s", result.name))
+		}
+	}
+	if adaptErr != nil {
+		return invalidArgumentf("jobs", adaptErr, "%s, %s", adaptErr.Error(), rollbackDeployment(d.deployTx, d.observers))
+	}
+	if uploadErr != nil {
+		return statusErrorf(codes.Internal, uploadErr, "%s, %s", uploadErr.Error(), rollbackDeployment(d.deployTx, d.observers))
+	}
+	return nil
+}
+
+// finish deletes jobs missing from the deployment on reconcile and syncs
+// the namespace with scheduler, sync is skipped if no job changed unless
+// it is forced
+func (d *jobDeployment) finish(reconcile, force bool) error {
+	reconcile = reconcile && hasDeletableJobs(d.storedHashes, d.jobsToKeep)
+	if d.modifiedJobs == 0 && !reconcile && !force {
+		logger.I("no job changes found, skipped job deployment in", time.Since(d.startTime))
+		return nil
+	}
+
+	stopHeartbeat := d.syncObserver.startHeartbeat(d.sv.HeartbeatInterval, d.namespaceJobCount(reconcile))
+	defer stopHeartbeat()
+
+	if reconcile {
+		// delete specs not sent for deployment from internal repository,
+		// sync removes them from the scheduler
+		if err := d.deployTx.KeepOnly(d.jobsToKeep, d.observers); err != nil {
+			return statusErrorf(codes.Internal, err, "%s: failed to delete jobs, %s", err.Error(), rollbackDeployment(d.deployTx, d.observers))
+		}
+	}
+
+	// whole namespace is compiled as priorities and dependencies of unchanged
+	// jobs are affected by the modified ones
+	if err := d.deployTx.Commit(d.stream.Context(), d.observers); err != nil {
+		return statusErrorf(codes.Internal, err, "%s\nfailed to sync jobs, %s", err.Error(), rollbackDeployment(d.deployTx, d.observers))
+	}
+	return nil
+}
+
+// namespaceJobCount returns the number of jobs in namespace once the
+// deployed jobs are saved
+func (d *jobDeployment) namespaceJobCount(reconcile bool) int {
+	if reconcile {
+		return len(d.jobsToKeep)
+	}
+	count := len(d.storedHashes)
+	for _, jobSpec := range d.jobsToKeep {
+		if _, ok := d.storedHashes[jobSpec.Name]; !ok {
+			count++
+		}
+	}
+	return count
+}
+
+// rollbackDeployment reverts the changes made by a failed deployment,
+// returns the outcome to be reported along with the failure
+func rollbackDeployment(deployTx *job.DeployTransaction, obs progress.Observer) string {
+	// request context may already be done by the time deployment fails
+	reverted, err := deployTx.Rollback(context.Background(), obs)
+	if err != nil {
+		logger.E(errors.Wrap(err, "failed to rollback deployment"))
+		return fmt.Sprintf("failed to rollback deployment: %s", err.Error())
+	}
+	if len(reverted) == 0 {
+		return "no jobs were changed"
+	}
+	return fmt.Sprintf("reverted jobs: %s", strings.Join(reverted, ", "))
+}
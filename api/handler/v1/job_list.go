@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"encoding/base64"
+	"sort"
+	"strings"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// MaxJobListPageSize caps the number of jobs returned in a single page
+	MaxJobListPageSize = 1000
+
+	jobAssetsField = "assets"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// filterJobSpecs returns jobs matching all the fields set in filter
+func filterJobSpecs(jobSpecs []models.JobSpec, filter *pb.ListJobSpecificationRequest_Filter) []models.JobSpec {
+	if filter == nil {
+		return jobSpecs
+	}
+
+	var filtered []models.JobSpec
+	for _, jobSpec := range jobSpecs {
+		if filter.GetNamePrefix() != "" && !strings.HasPrefix(jobSpec.Name, filter.GetNamePrefix()) {
+			continue
+		}
+		if filter.GetOwner() != "" && jobSpec.Owner != filter.GetOwner() {
+			continue
+		}
+		if filter.GetTaskName() != "" && jobTaskName(jobSpec) != filter.GetTaskName() {
+			continue
+		}
+		if !hasLabels(jobSpec, filter.GetLabels()) {
+			continue
+		}
+		filtered = append(filtered, jobSpec)
+	}
+	return filtered
+}
+
+func jobTaskName(jobSpec models.JobSpec) string {
+	if jobSpec.Task.Unit == nil || jobSpec.Task.Unit.Base == nil {
+		return ""
+	}
+	if info := jobSpec.Task.Unit.Info(); info != nil {
+		return info.Name
+	}
+	return ""
+}
+
+func hasLabels(jobSpec models.JobSpec, labels map[string]string) bool {
+	for key, value := range labels {
+		if jobValue, ok := jobSpec.Labels[key]; !ok || jobValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// paginateJobSpecs orders jobs by name and returns the page following the
+// job referred by pageToken, token of the next page is empty once all the
+// jobs are returned
+func paginateJobSpecs(jobSpecs []models.JobSpec, pageSize int32, pageToken string) ([]models.JobSpec, string, error) {
+	sort.Slice(jobSpecs, func(i, j int) bool {
+		return jobSpecs[i].Name < jobSpecs[j].Name
+	})
+
+	if pageToken != "" {
+		lastName, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil || len(lastName) == 0 {
+			return nil, "", ErrInvalidPageToken
+		}
+		start := sort.Search(len(jobSpecs), func(i int) bool {
+			return jobSpecs[i].Name > string(lastName)
+		})
+		jobSpecs = jobSpecs[start:]
+	}
+
+	if pageSize > MaxJobListPageSize {
+		pageSize = MaxJobListPageSize
+	}
+	if pageSize <= 0 || int(pageSize) >= len(jobSpecs) {
+		return jobSpecs, "", nil
+	}
+	page := jobSpecs[:pageSize]
+	return page, base64.RawURLEncoding.EncodeToString([]byte(page[len(page)-1].Name)), nil
+}
+
+// isJobFieldMasked returns true if the top level field of job specification
+// is excluded by mask, nothing is excluded by an empty mask
+func isJobFieldMasked(mask *fieldmaskpb.FieldMask, field string) bool {
+	if len(mask.GetPaths()) == 0 {
+		return false
+	}
+	for _, path := range mask.GetPaths() {
+		if path == field || strings.HasPrefix(path, field+".") {
+			return false
+		}
+	}
+	return true
+}
+
+// applyFieldMask clears all the fields of message not selected by mask,
+// nested fields are selected with dot separated paths e.g. "behavior.retry"
+func applyFieldMask(msg protoreflect.ProtoMessage, mask *fieldmaskpb.FieldMask) {
+	if len(mask.GetPaths()) == 0 {
+		return
+	}
+	pruneMessage(msg.ProtoReflect(), mask.GetPaths())
+}
+
+func pruneMessage(msg protoreflect.Message, paths []string) {
+	selected := map[string][]string{}
+	for _, path := range paths {
+		field, subPath := path, ""
+		if idx := strings.Index(path, "."); idx != -1 {
+			field, subPath = path[:idx], path[idx+1:]
+		}
+		subPaths, ok := selected[field]
+		if ok && subPaths == nil {
+			// whole field is already selected
+			continue
+		}
+		if subPath == "" {
+			selected[field] = nil
+			continue
+		}
+		selected[field] = append(subPaths, subPath)
+	}
+
+	var toClear []protoreflect.FieldDescriptor
+	var toPrune []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		subPaths, ok := selected[string(fd.Name())]
+		if !ok {
+			toClear = append(toClear, fd)
+		} else if subPaths != nil && fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+			toPrune = append(toPrune, fd)
+		}
+		return true
+	})
+	for _, fd := range toClear {
+		msg.Clear(fd)
+	}
+	for _, fd := range toPrune {
+		pruneMessage(msg.Mutable(fd).Message(), selected[string(fd.Name())])
+	}
+}
@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// jobRevisionField is bumped on every save and left out of diffs
+const jobRevisionField = "revision"
+
+// diffJobSpecs compares two job specifications field by field, nested
+// fields are named with dot separated paths e.g. "behavior.retry.count" and
+// list items with their index e.g. "dependencies[0].name". Changes are
+// ordered by field, a field missing in one of the specs has an empty value
+func diffJobSpecs(from, to *pb.JobSpecification) ([]*pb.JobSpecFieldChange, error) {
+	fromFields, err := flattenJobSpec(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := flattenJobSpec(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*pb.JobSpecFieldChange
+	for field, fromValue := range fromFields {
+		if toValue := toFields[field]; toValue != fromValue {
+			changes = append(changes, &pb.JobSpecFieldChange{
+				Field:     field,
+				FromValue: fromValue,
+				ToValue:   toValue,
+			})
+		}
+	}
+	for field, toValue := range toFields {
+		if _, ok := fromFields[field]; !ok {
+			changes = append(changes, &pb.JobSpecFieldChange{
+				Field:   field,
+				ToValue: toValue,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes, nil
+}
+
+// flattenJobSpec maps paths of all the fields set in spec to their values
+func flattenJobSpec(spec *pb.JobSpecification) (map[string]string, error) {
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode job specification")
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, errors.Wrap(err, "failed to decode job specification")
+	}
+	delete(decoded, jobRevisionField)
+
+	fields := map[string]string{}
+	flattenValue(fields, "", decoded)
+	return fields, nil
+}
+
+func flattenValue(fields map[string]string, path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			nestedPath := key
+			if path != "" {
+				nestedPath = path + "." + key
+			}
+			flattenValue(fields, nestedPath, nested)
+		}
+	case []interface{}:
+		for idx, nested := range v {
+			flattenValue(fields, fmt.Sprintf("%s[%d]", path, idx), nested)
+		}
+	case string:
+		fields[path] = v
+	default:
+		fields[path] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimit is a token bucket allowing PerMinute calls every minute and
+// up to Burst calls at once, calls are not limited if PerMinute is not set
+type RateLimit struct {
+	PerMinute int
+	// Burst defaults to PerMinute if not set
+	Burst int
+}
+
+func (l RateLimit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.PerMinute
+}
+
+// callerLimiter is the bucket of a caller for a method
+type callerLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter rejects calls of a caller to a method once its bucket is
+// empty, so a single caller can not starve others. Callers are identified
+// by their authenticated identity, or by their address if not known
+type RateLimiter struct {
+	mu sync.Mutex
+
+	defaultLimit RateLimit
+	// methodLimits override defaultLimit for methods
+	methodLimits map[string]RateLimit
+	limiters     map[string]*callerLimiter
+
+	// buckets of callers idle for idleTimeout are dropped
+	idleTimeout time.Duration
+	lastSweep   time.Time
+
+	Now func() time.Time
+}
+
+// callerName returns the identity of caller used to pick its bucket,
+// unauthenticated callers proxied by http gateway share one bucket
+func callerName(ctx context.Context) string {
+	if identity, ok := models.IdentityFromContext(ctx); ok {
+		return identity.Name()
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func (l *RateLimiter) allow(ctx context.Context, method string) error {
+	limit, ok := l.methodLimits[method]
+	if !ok {
+		limit = l.defaultLimit
+	}
+	if limit.PerMinute <= 0 {
+		return nil
+	}
+	caller := callerName(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.Now()
+	if now.Sub(l.lastSweep) > l.idleTimeout {
+		for key, bucket := range l.limiters {
+			if now.Sub(bucket.lastSeen) > l.idleTimeout {
+				delete(l.limiters, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	key := caller + "/" + method
+	bucket, ok := l.limiters[key]
+	if !ok {
+		bucket = &callerLimiter{
+			limiter: rate.NewLimiter(rate.Limit(float64(limit.PerMinute)/60), limit.burst()),
+		}
+		l.limiters[key] = bucket
+	}
+	bucket.lastSeen = now
+	if !bucket.limiter.AllowN(now, 1) {
+		return status.Errorf(codes.ResourceExhausted, "rate limit of %d calls per minute to %s exceeded by %s",
+			limit.PerMinute, method, caller)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor limits rate of unary calls, it should be chained
+// after authentication to limit each authenticated caller separately
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor limits rate of opening streams, messages of an
+// open stream are not limited
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// NewRateLimiter limits every method to defaultLimit unless it is
+// overridden in methodLimits, buckets idle for idleTimeout are dropped
+func NewRateLimiter(defaultLimit RateLimit, methodLimits map[string]RateLimit, idleTimeout time.Duration) *RateLimiter {
+	return &RateLimiter{
+		defaultLimit: defaultLimit,
+		methodLimits: methodLimits,
+		limiters:     map[string]*callerLimiter{},
+		idleTimeout:  idleTimeout,
+		Now:          time.Now,
+	}
+}
@@ -0,0 +1,112 @@
+package v1_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimiter(t *testing.T) {
+	deployMethod := "/odpf.optimus.RuntimeService/DeployJobSpecificationAsync"
+	listMethod := "/odpf.optimus.RuntimeService/ListProjects"
+	callerCtx := func(name string) context.Context {
+		return models.ContextWithIdentity(context.Background(), models.Identity{Subject: name})
+	}
+	noopHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	newLimiter := func(now *time.Time) *v1.RateLimiter {
+		limiter := v1.NewRateLimiter(v1.RateLimit{PerMinute: 60, Burst: 2}, map[string]v1.RateLimit{
+			deployMethod: {PerMinute: 1},
+		}, time.Hour)
+		limiter.Now = func() time.Time { return *now }
+		return limiter
+	}
+
+	t.Run("UnaryServerInterceptor", func(t *testing.T) {
+		t.Run("should reject calls once bucket of caller is empty", func(t *testing.T) {
+			now := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+			interceptor := newLimiter(&now).UnaryServerInterceptor()
+			info := &grpc.UnaryServerInfo{FullMethod: listMethod}
+
+			for i := 0; i < 2; i++ {
+				_, err := interceptor(callerCtx("ci"), nil, info, noopHandler)
+				assert.Nil(t, err)
+			}
+			_, err := interceptor(callerCtx("ci"), nil, info, noopHandler)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+			// tokens are refilled at per minute rate
+			now = now.Add(time.Second)
+			_, err = interceptor(callerCtx("ci"), nil, info, noopHandler)
+			assert.Nil(t, err)
+		})
+		t.Run("should limit callers and methods separately", func(t *testing.T) {
+			now := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+			interceptor := newLimiter(&now).UnaryServerInterceptor()
+			deployInfo := &grpc.UnaryServerInfo{FullMethod: deployMethod}
+
+			_, err := interceptor(callerCtx("ci"), nil, deployInfo, noopHandler)
+			assert.Nil(t, err)
+			_, err = interceptor(callerCtx("ci"), nil, deployInfo, noopHandler)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+			_, err = interceptor(callerCtx("alice@example.io"), nil, deployInfo, noopHandler)
+			assert.Nil(t, err)
+			_, err = interceptor(callerCtx("ci"), nil, &grpc.UnaryServerInfo{FullMethod: listMethod}, noopHandler)
+			assert.Nil(t, err)
+		})
+		t.Run("should identify unauthenticated callers by address", func(t *testing.T) {
+			now := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+			interceptor := newLimiter(&now).UnaryServerInterceptor()
+			deployInfo := &grpc.UnaryServerInfo{FullMethod: deployMethod}
+			addrCtx := func(addr string) context.Context {
+				tcpAddr, _ := net.ResolveTCPAddr("tcp", addr)
+				return peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr})
+			}
+
+			_, err := interceptor(addrCtx("10.0.0.1:5000"), nil, deployInfo, noopHandler)
+			assert.Nil(t, err)
+			_, err = interceptor(addrCtx("10.0.0.1:5001"), nil, deployInfo, noopHandler)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			_, err = interceptor(addrCtx("10.0.0.2:5000"), nil, deployInfo, noopHandler)
+			assert.Nil(t, err)
+		})
+		t.Run("should not limit methods without limit", func(t *testing.T) {
+			now := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+			limiter := v1.NewRateLimiter(v1.RateLimit{}, map[string]v1.RateLimit{
+				deployMethod: {PerMinute: 1},
+			}, time.Hour)
+			limiter.Now = func() time.Time { return now }
+			interceptor := limiter.UnaryServerInterceptor()
+			for i := 0; i < 10; i++ {
+				_, err := interceptor(callerCtx("ci"), nil, &grpc.UnaryServerInfo{FullMethod: listMethod}, noopHandler)
+				assert.Nil(t, err)
+			}
+		})
+	})
+	t.Run("StreamServerInterceptor", func(t *testing.T) {
+		t.Run("should limit opening streams", func(t *testing.T) {
+			now := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+			interceptor := newLimiter(&now).StreamServerInterceptor()
+			streamInfo := &grpc.StreamServerInfo{FullMethod: deployMethod}
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				return nil
+			}
+
+			err := interceptor(nil, &sentMessagesStream{ctx: callerCtx("ci")}, streamInfo, handler)
+			assert.Nil(t, err)
+			err = interceptor(nil, &sentMessagesStream{ctx: callerCtx("ci")}, streamInfo, handler)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		})
+	})
+}
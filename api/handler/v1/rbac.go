@@ -0,0 +1,294 @@
+package v1
+
+import (
+	"context"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const servicePrefix = "/odpf.optimus.RuntimeService/"
+
+// MethodRoles are the minimum roles needed in a project, or in the
+// namespace of request, to call rpcs. Rpcs not listed here can only be
+// called by server admins, e.g. RestoreProject as roles of a deleted
+// project can't be read
+var MethodRoles = map[string]string{
+	servicePrefix + "GetDeploymentStatus":       models.RoleViewer,
+	servicePrefix + "ListDeployments":           models.RoleViewer,
+	servicePrefix + "GetDeployment":             models.RoleViewer,
+	servicePrefix + "ReadJobSpecification":      models.RoleViewer,
+	servicePrefix + "ListJobSpecification":      models.RoleViewer,
+	servicePrefix + "DumpJobSpecification":      models.RoleViewer,
+	servicePrefix + "CompileJob":                models.RoleViewer,
+	servicePrefix + "CheckJobSpecification":     models.RoleViewer,
+	servicePrefix + "CheckJobSpecifications":    models.RoleViewer,
+	servicePrefix + "ListSecretKeys":            models.RoleViewer,
+	servicePrefix + "ListProjectNamespaces":     models.RoleViewer,
+	servicePrefix + "JobStatus":                 models.RoleViewer,
+	servicePrefix + "ListResourceSpecification": models.RoleViewer,
+	servicePrefix + "ReadResource":              models.RoleViewer,
+	servicePrefix + "CheckResource":             models.RoleViewer,
+	servicePrefix + "ReplayDryRun":              models.RoleViewer,
+	servicePrefix + "ReplayStatus":              models.RoleViewer,
+	servicePrefix + "ListReplays":               models.RoleViewer,
+	servicePrefix + "ListMacros":                models.RoleViewer,
+	servicePrefix + "ListJobVersions":           models.RoleViewer,
+	servicePrefix + "GetJobVersionDiff":         models.RoleViewer,
+	servicePrefix + "GetLineage":                models.RoleViewer,
+	servicePrefix + "ListBackups":               models.RoleViewer,
+	servicePrefix + "GetJobRuns":                models.RoleViewer,
+
+	servicePrefix + "DeployJobSpecification":       models.RoleEditor,
+	servicePrefix + "DeployJobSpecificationStream": models.RoleEditor,
+	servicePrefix + "DeployJobSpecificationAsync":  models.RoleEditor,
+	servicePrefix + "ImportJobSpecifications":      models.RoleEditor,
+	servicePrefix + "CreateJobSpecification":       models.RoleEditor,
+	servicePrefix + "DeleteJobSpecification":       models.RoleEditor,
+	servicePrefix + "PauseJob":                     models.RoleEditor,
+	servicePrefix + "ResumeJob":                    models.RoleEditor,
+	servicePrefix + "RestoreJob":                   models.RoleEditor,
+	servicePrefix + "RollbackJob":                  models.RoleEditor,
+	servicePrefix + "RegisterInstance":             models.RoleEditor,
+	servicePrefix + "RegisterJobEvent":             models.RoleEditor,
+	servicePrefix + "DeployResourceSpecification":  models.RoleEditor,
+	servicePrefix + "CreateResource":               models.RoleEditor,
+	servicePrefix + "UpdateResource":               models.RoleEditor,
+	servicePrefix + "BackupResource":               models.RoleEditor,
+	servicePrefix + "RestoreBackup":                models.RoleEditor,
+	servicePrefix + "Replay":                       models.RoleEditor,
+	servicePrefix + "ClearJobRuns":                 models.RoleEditor,
+
+	servicePrefix + "RegisterProject":          models.RoleAdmin,
+	servicePrefix + "DeleteProject":            models.RoleAdmin,
+	servicePrefix + "RegisterProjectNamespace": models.RoleAdmin,
+	servicePrefix + "RegisterSecret":           models.RoleAdmin,
+	servicePrefix + "UpdateSecret":             models.RoleAdmin,
+	servicePrefix + "CreateRoleBinding":        models.RoleAdmin,
+	servicePrefix + "DeleteRoleBinding":        models.RoleAdmin,
+	servicePrefix + "ListRoleBindings":         models.RoleAdmin,
+	servicePrefix + "ListAuditEvents":          models.RoleAdmin,
+	servicePrefix + "RegisterMacro":            models.RoleAdmin,
+	servicePrefix + "DeleteMacro":              models.RoleAdmin,
+}
+
+// SecretReadRole is needed in a project to read values of its sensitive
+// config, they are redacted in responses to everyone else
+const SecretReadRole = models.RoleAdmin
+
+// UnscopedMethods can be called by every authenticated caller as they do
+// not touch any project
+var UnscopedMethods = []string{
+	servicePrefix + "Version",
+	servicePrefix + "GetWindow",
+	servicePrefix + "ListSupportedTasks",
+	servicePrefix + "ListSupportedHooks",
+	servicePrefix + "ValidatePluginAnswers",
+}
+
+// projectLevelMethods need the role in project even if request names a
+// namespace, e.g. namespace of a role binding is the scope of binding
+var projectLevelMethods = map[string]bool{
+	servicePrefix + "CreateRoleBinding": true,
+	servicePrefix + "DeleteRoleBinding": true,
+	servicePrefix + "ListRoleBindings":  true,
+	servicePrefix + "RegisterMacro":     true,
+	servicePrefix + "DeleteMacro":       true,
+}
+
+type RoleBindingRepoFactory interface {
+	New(spec models.ProjectSpec) store.RoleBindingRepository
+}
+
+// Authorizer allows authenticated callers to invoke an rpc only if they
+// are bound to a role in the project or namespace of the request which
+// includes the role needed by rpc
+type Authorizer struct {
+	projectRepoFactory     ProjectRepoFactory
+	roleBindingRepoFactory RoleBindingRepoFactory
+
+	methodRoles map[string]string
+	skipMethods map[string]bool
+
+	// admins are subjects allowed to call every rpc in every project
+	admins map[string]bool
+}
+
+// requestScope returns the project and namespace a request is made for
+func requestScope(method string, req interface{}) (string, string) {
+	var projectName, namespace string
+	switch r := req.(type) {
+	case interface{ GetProjectName() string }:
+		projectName = r.GetProjectName()
+	case interface {
+		GetProject() *pb.ProjectSpecification
+	}:
+		projectName = r.GetProject().GetName()
+	}
+	if r, ok := req.(interface{ GetNamespace() string }); ok && !projectLevelMethods[method] {
+		namespace = r.GetNamespace()
+	}
+	return projectName, namespace
+}
+
+func (a *Authorizer) authorize(ctx context.Context, method string, req interface{}) error {
+	if a.skipMethods[method] {
+		return nil
+	}
+	identity, ok := models.IdentityFromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "caller is not authenticated")
+	}
+	if a.isAdmin(identity) {
+		return nil
+	}
+
+	requiredRole, ok := a.methodRoles[method]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s can only be called by server admins", method)
+	}
+	projectName, namespace := requestScope(method, req)
+	if projectName == "" {
+		return status.Errorf(codes.PermissionDenied, "%s can only be called by server admins", method)
+	}
+
+	granted, err := a.hasRole(identity, projectName, namespace, requiredRole)
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return status.Errorf(codes.PermissionDenied, "%s has no role in project %s", identity.Name(), projectName)
+		}
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	if granted {
+		return nil
+	}
+
+	scope := "project " + projectName
+	if namespace != "" {
+		scope = "namespace " + namespace + " of " + scope
+	}
+	return status.Errorf(codes.PermissionDenied, "%s needs %s role in %s", identity.Name(), requiredRole, scope)
+}
+
+func (a *Authorizer) isAdmin(identity models.Identity) bool {
+	return a.admins[identity.Subject] || (identity.Email != "" && a.admins[identity.Email])
+}
+
+// hasRole checks if identity is bound to a role including required role
+// in namespace of the project, or in the whole project if namespace is empty
+func (a *Authorizer) hasRole(identity models.Identity, projectName, namespace, requiredRole string) (bool, error) {
+	projSpec, err := a.projectRepoFactory.New().GetByName(projectName)
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return false, err
+		}
+		return false, errors.Wrapf(err, "failed to find project %s", projectName)
+	}
+	bindings, err := a.roleBindingRepoFactory.New(projSpec).GetAll()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read role bindings of project %s", projectName)
+	}
+	for _, binding := range bindings {
+		if binding.Matches(identity) && binding.AppliesTo(namespace) && models.RoleIncludes(binding.Role, requiredRole) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CanReadSecrets checks if caller is allowed to read values of sensitive
+// config of the project, only project admins and server admins are
+func (a *Authorizer) CanReadSecrets(ctx context.Context, projectName string) bool {
+	identity, ok := models.IdentityFromContext(ctx)
+	if !ok {
+		return false
+	}
+	if a.isAdmin(identity) {
+		return true
+	}
+	granted, err := a.hasRole(identity, projectName, "", SecretReadRole)
+	return err == nil && granted
+}
+
+// UnaryServerInterceptor authorizes unary calls, it should be chained
+// after authentication
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authorizes the first message received on streams
+// and keeps the rest of the stream to its scope, it should be chained after
+// authentication
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &authorizedServerStream{
+			ServerStream: ss,
+			authorizer:   a,
+			method:       info.FullMethod,
+		})
+	}
+}
+
+// authorizedServerStream authorizes the first request read from stream.
+// Clients send the scope only with the first request of chunked streams,
+// later requests are accepted if they name no scope or the same one
+type authorizedServerStream struct {
+	grpc.ServerStream
+	authorizer *Authorizer
+	method     string
+
+	authorized  bool
+	projectName string
+	namespace   string
+}
+
+func (s *authorizedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	projectName, namespace := requestScope(s.method, m)
+	if !s.authorized {
+		if err := s.authorizer.authorize(s.Context(), s.method, m); err != nil {
+			return err
+		}
+		s.authorized, s.projectName, s.namespace = true, projectName, namespace
+		return nil
+	}
+	if (projectName != "" && projectName != s.projectName) || (namespace != "" && namespace != s.namespace) {
+		return status.Errorf(codes.PermissionDenied, "%s stream is authorized for project %s and namespace %s only",
+			s.method, s.projectName, s.namespace)
+	}
+	return nil
+}
+
+// NewAuthorizer enforces methodRoles using role bindings of projects,
+// skipMethods are allowed for every authenticated caller and admins can
+// call every method
+func NewAuthorizer(projectRepoFactory ProjectRepoFactory, roleBindingRepoFactory RoleBindingRepoFactory,
+	methodRoles map[string]string, skipMethods []string, admins []string) *Authorizer {
+	methodSet := make(map[string]bool, len(skipMethods))
+	for _, method := range skipMethods {
+		methodSet[method] = true
+	}
+	adminSet := make(map[string]bool, len(admins))
+	for _, admin := range admins {
+		adminSet[admin] = true
+	}
+	return &Authorizer{
+		projectRepoFactory:     projectRepoFactory,
+		roleBindingRepoFactory: roleBindingRepoFactory,
+		methodRoles:            methodRoles,
+		skipMethods:            methodSet,
+		admins:                 adminSet,
+	}
+}
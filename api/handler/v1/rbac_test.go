@@ -0,0 +1,259 @@
+package v1_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// requestStream returns reqs one by one, and io.EOF once all are read
+type requestStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*pb.DeployJobSpecificationRequest
+}
+
+func (s *requestStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *requestStream) RecvMsg(m interface{}) error {
+	if len(s.reqs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(*pb.DeployJobSpecificationRequest), s.reqs[0])
+	s.reqs = s.reqs[1:]
+	return nil
+}
+
+func TestAuthorizer(t *testing.T) {
+	projectSpec := models.ProjectSpec{
+		Name: "a-data-project",
+	}
+	bindings := []models.RoleBinding{
+		{Subject: "alice@example.io", Role: models.RoleAdmin},
+		{Subject: "ci", Role: models.RoleEditor, Namespace: "team-a"},
+		{Subject: "bob", Role: models.RoleViewer},
+	}
+	identityCtx := func(identity models.Identity) context.Context {
+		return models.ContextWithIdentity(context.Background(), identity)
+	}
+	newAuthorizer := func(t *testing.T) *v1.Authorizer {
+		projectRepository := new(mock.ProjectRepository)
+		projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+		projectRepository.On("GetByName", "unknown-project").Return(models.ProjectSpec{}, store.ErrResourceNotFound)
+
+		projectRepoFactory := new(mock.ProjectRepoFactory)
+		projectRepoFactory.On("New").Return(projectRepository)
+
+		roleBindingRepository := new(mock.RoleBindingRepository)
+		roleBindingRepository.On("GetAll").Return(bindings, nil)
+
+		roleBindingRepoFactory := new(mock.RoleBindingRepoFactory)
+		roleBindingRepoFactory.On("New", projectSpec).Return(roleBindingRepository)
+
+		return v1.NewAuthorizer(projectRepoFactory, roleBindingRepoFactory, v1.MethodRoles,
+			append(v1.UnauthenticatedMethods, v1.UnscopedMethods...), []string{"root"})
+	}
+	noopHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	unaryInfo := func(method string) *grpc.UnaryServerInfo {
+		return &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/" + method}
+	}
+
+	t.Run("UnaryServerInterceptor", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			identity models.Identity
+			method   string
+			req      interface{}
+			allowed  bool
+		}{
+			{
+				name:     "project admin matched by email can register secrets",
+				identity: models.Identity{Subject: "1234", Email: "alice@example.io"},
+				method:   "RegisterSecret",
+				req:      &pb.RegisterSecretRequest{ProjectName: projectSpec.Name},
+				allowed:  true,
+			},
+			{
+				name:     "project admin can update project",
+				identity: models.Identity{Subject: "alice@example.io"},
+				method:   "RegisterProject",
+				req:      &pb.RegisterProjectRequest{Project: &pb.ProjectSpecification{Name: projectSpec.Name}},
+				allowed:  true,
+			},
+			{
+				name:     "namespace editor can deploy in namespace",
+				identity: models.Identity{Subject: "ci"},
+				method:   "DeployJobSpecificationAsync",
+				req:      &pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: "team-a"},
+				allowed:  true,
+			},
+			{
+				name:     "namespace editor can not deploy in other namespaces",
+				identity: models.Identity{Subject: "ci"},
+				method:   "DeployJobSpecificationAsync",
+				req:      &pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: "team-b"},
+				allowed:  false,
+			},
+			{
+				name:     "namespace editor can not call project level rpcs",
+				identity: models.Identity{Subject: "ci"},
+				method:   "ListSecretKeys",
+				req:      &pb.ListSecretKeysRequest{ProjectName: projectSpec.Name},
+				allowed:  false,
+			},
+			{
+				name:     "namespace role does not allow managing bindings of namespace",
+				identity: models.Identity{Subject: "ci"},
+				method:   "DeleteRoleBinding",
+				req:      &pb.DeleteRoleBindingRequest{ProjectName: projectSpec.Name, Subject: "bob", Namespace: "team-a"},
+				allowed:  false,
+			},
+			{
+				name:     "viewer can read specifications",
+				identity: models.Identity{Subject: "bob"},
+				method:   "ReadJobSpecification",
+				req:      &pb.ReadJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: "team-b"},
+				allowed:  true,
+			},
+			{
+				name:     "viewer can not deploy",
+				identity: models.Identity{Subject: "bob"},
+				method:   "DeployResourceSpecification",
+				req:      &pb.DeployResourceSpecificationRequest{ProjectName: projectSpec.Name, Namespace: "team-b"},
+				allowed:  false,
+			},
+			{
+				name:     "unknown subjects are denied",
+				identity: models.Identity{Subject: "mallory"},
+				method:   "ReadJobSpecification",
+				req:      &pb.ReadJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: "team-a"},
+				allowed:  false,
+			},
+			{
+				name:     "only server admins can register new projects",
+				identity: models.Identity{Subject: "alice@example.io"},
+				method:   "RegisterProject",
+				req:      &pb.RegisterProjectRequest{Project: &pb.ProjectSpecification{Name: "unknown-project"}},
+				allowed:  false,
+			},
+			{
+				name:     "server admins can call every rpc",
+				identity: models.Identity{Subject: "root"},
+				method:   "ListProjects",
+				req:      &pb.ListProjectsRequest{},
+				allowed:  true,
+			},
+			{
+				name:     "only server admins can call rpcs outside projects",
+				identity: models.Identity{Subject: "alice@example.io"},
+				method:   "ListProjects",
+				req:      &pb.ListProjectsRequest{},
+				allowed:  false,
+			},
+			{
+				name:     "unscoped rpcs can be called by everyone",
+				identity: models.Identity{Subject: "mallory"},
+				method:   "Version",
+				req:      &pb.VersionRequest{},
+				allowed:  true,
+			},
+		}
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				interceptor := newAuthorizer(t).UnaryServerInterceptor()
+				_, err := interceptor(identityCtx(tt.identity), tt.req, unaryInfo(tt.method), noopHandler)
+				if tt.allowed {
+					assert.Nil(t, err)
+				} else {
+					assert.Equal(t, codes.PermissionDenied, status.Code(err))
+				}
+			})
+		}
+		t.Run("should deny callers without identity", func(t *testing.T) {
+			interceptor := newAuthorizer(t).UnaryServerInterceptor()
+			_, err := interceptor(context.Background(), &pb.ReadJobSpecificationRequest{ProjectName: projectSpec.Name},
+				unaryInfo("ReadJobSpecification"), noopHandler)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+	})
+	t.Run("StreamServerInterceptor", func(t *testing.T) {
+		streamInfo := &grpc.StreamServerInfo{FullMethod: "/odpf.optimus.RuntimeService/DeployJobSpecification"}
+		recvHandler := func(srv interface{}, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&pb.DeployJobSpecificationRequest{})
+		}
+
+		t.Run("should authorize requests read from stream", func(t *testing.T) {
+			interceptor := newAuthorizer(t).StreamServerInterceptor()
+			err := interceptor(nil, &requestStream{
+				ctx:  identityCtx(models.Identity{Subject: "ci"}),
+				reqs: []*pb.DeployJobSpecificationRequest{{ProjectName: projectSpec.Name, Namespace: "team-a"}},
+			}, streamInfo, recvHandler)
+			assert.Nil(t, err)
+		})
+		t.Run("should reject requests outside role scope", func(t *testing.T) {
+			interceptor := newAuthorizer(t).StreamServerInterceptor()
+			err := interceptor(nil, &requestStream{
+				ctx:  identityCtx(models.Identity{Subject: "ci"}),
+				reqs: []*pb.DeployJobSpecificationRequest{{ProjectName: projectSpec.Name, Namespace: "team-b"}},
+			}, streamInfo, recvHandler)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+
+		recvAllHandler := func(srv interface{}, stream grpc.ServerStream) error {
+			for {
+				if err := stream.RecvMsg(&pb.DeployJobSpecificationRequest{}); err != nil {
+					if err == io.EOF {
+						return nil
+					}
+					return err
+				}
+			}
+		}
+		t.Run("should accept chunks without scope after the first of an editor", func(t *testing.T) {
+			interceptor := newAuthorizer(t).StreamServerInterceptor()
+			err := interceptor(nil, &requestStream{
+				ctx: identityCtx(models.Identity{Subject: "ci"}),
+				reqs: []*pb.DeployJobSpecificationRequest{
+					{ProjectName: projectSpec.Name, Namespace: "team-a", Jobs: []*pb.JobSpecification{{Name: "job-1"}}},
+					{Jobs: []*pb.JobSpecification{{Name: "job-2"}}},
+					{Jobs: []*pb.JobSpecification{{Name: "job-3"}}},
+				},
+			}, streamInfo, recvAllHandler)
+			assert.Nil(t, err)
+		})
+		t.Run("should reject chunks naming another scope than the first", func(t *testing.T) {
+			interceptor := newAuthorizer(t).StreamServerInterceptor()
+			err := interceptor(nil, &requestStream{
+				ctx: identityCtx(models.Identity{Subject: "ci"}),
+				reqs: []*pb.DeployJobSpecificationRequest{
+					{ProjectName: projectSpec.Name, Namespace: "team-a"},
+					{ProjectName: projectSpec.Name, Namespace: "team-b"},
+				},
+			}, streamInfo, recvAllHandler)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+	})
+	t.Run("CanReadSecrets", func(t *testing.T) {
+		authorizer := newAuthorizer(t)
+		assert.True(t, authorizer.CanReadSecrets(identityCtx(models.Identity{Subject: "alice@example.io"}), projectSpec.Name))
+		assert.True(t, authorizer.CanReadSecrets(identityCtx(models.Identity{Subject: "root"}), projectSpec.Name))
+		assert.False(t, authorizer.CanReadSecrets(identityCtx(models.Identity{Subject: "ci"}), projectSpec.Name))
+		assert.False(t, authorizer.CanReadSecrets(identityCtx(models.Identity{Subject: "alice@example.io"}), "unknown-project"))
+		assert.False(t, authorizer.CanReadSecrets(context.Background(), projectSpec.Name))
+	})
+}
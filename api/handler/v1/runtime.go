@@ -1,10 +1,14 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -16,6 +20,7 @@ import (
 	"github.com/odpf/optimus/datastore"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 	"github.com/odpf/optimus/core/logger"
 	log "github.com/odpf/optimus/core/logger"
@@ -23,10 +28,24 @@ import (
 	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/utils"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxImportArchiveSize is the maximum size of a compressed job
+	// specification archive accepted by ImportJobSpecifications
+	MaxImportArchiveSize = 64 << 20
+
+	// clearJobRunsBatchSize is the number of runs fetched from scheduler
+	// in a request while listing runs to clear
+	clearJobRunsBatchSize = 100
+
+	// defaultReplayPollInterval is how often replays are read while their
+	// status is streamed
+	defaultReplayPollInterval = 5 * time.Second
 )
 
 type ProjectRepoFactory interface {
@@ -41,13 +60,45 @@ type SecretRepoFactory interface {
 	New(spec models.ProjectSpec) store.ProjectSecretRepository
 }
 
+type MacroRepoFactory interface {
+	New(spec models.ProjectSpec) store.MacroRepository
+}
+
+type JobRunRepoFactory interface {
+	New(spec models.ProjectSpec) store.JobRunRepository
+}
+
 type JobEventService interface {
 	Register(context.Context, models.NamespaceSpec, models.JobSpec, models.JobEvent) error
 }
 
+// RunDurationChecker looks for runs of jobs taking unusually long
+type RunDurationChecker interface {
+	Check(context.Context, models.NamespaceSpec, models.JobSpec, models.JobRun) error
+}
+
+// RunLineage reports runs of jobs as they start and finish
+type RunLineage interface {
+	EmitRunStarted(context.Context, models.NamespaceSpec, models.JobSpec, time.Time) error
+	EmitRunEvent(context.Context, models.NamespaceSpec, models.JobSpec, models.JobEvent) error
+}
+
+// JobLineage walks jobs connected through what they read and write
+type JobLineage interface {
+	GetLineage(projectName, jobName string, direction models.LineageDirection, depth int) (models.LineageGraph, error)
+}
+
+// ResourceBackups backs up resources of datastores and restores them
+type ResourceBackups interface {
+	Backup(context.Context, models.BackupRequest) (models.BackupResponse, error)
+	List(ctx context.Context, namespace models.NamespaceSpec, datastoreName string) ([]models.BackupSpec, error)
+	Restore(ctx context.Context, namespace models.NamespaceSpec, datastoreName string, id uuid.UUID) (models.BackupSpec, error)
+}
+
 type ProtoAdapter interface {
 	FromJobProto(*pb.JobSpecification) (models.JobSpec, error)
 	ToJobProto(models.JobSpec) (*pb.JobSpecification, error)
+	FromJobArchive(io.Reader) ([]models.JobSpec, error)
 
 	FromProjectProto(*pb.ProjectSpecification) models.ProjectSpec
 	ToProjectProto(models.ProjectSpec) *pb.ProjectSpecification
@@ -63,23 +114,71 @@ type ProtoAdapter interface {
 	ToResourceProto(res models.ResourceSpec) (*pb.ResourceSpecification, error)
 
 	ToReplayExecutionTreeNode(res *tree.TreeNode) (*pb.ReplayExecutionTreeNode, error)
+	ToReplayJobRuns(res *tree.TreeNode) ([]*pb.ReplayJobRuns, int64)
+	ToReplaySpecProto(models.ReplaySpec) *pb.ReplaySpec
+
+	ToDeploymentStatusProto(models.DeploymentSpec) *pb.GetDeploymentStatusResponse
+	ToDeploymentProto(models.DeploymentSpec) *pb.DeploymentSpecification
 }
 
 type RuntimeServiceServer struct {
-	version              string
-	jobSvc               models.JobService
-	jobEventSvc          JobEventService
-	resourceSvc          models.DatastoreService
-	adapter              ProtoAdapter
-	projectRepoFactory   ProjectRepoFactory
-	namespaceRepoFactory NamespaceRepoFactory
-	secretRepoFactory    SecretRepoFactory
-	instSvc              models.InstanceService
-	scheduler            models.SchedulerUnit
+	version                string
+	jobSvc                 models.JobService
+	jobEventSvc            JobEventService
+	resourceSvc            models.DatastoreService
+	adapter                ProtoAdapter
+	projectRepoFactory     ProjectRepoFactory
+	namespaceRepoFactory   NamespaceRepoFactory
+	secretRepoFactory      SecretRepoFactory
+	roleBindingRepoFactory RoleBindingRepoFactory
+	auditEventRepo         store.AuditEventRepository
+	macroRepoFactory       MacroRepoFactory
+	pluginRepo             models.PluginRepository
+	instSvc                models.InstanceService
+	scheduler              models.SchedulerUnit
+	deployManager          job.DeployManager
 
 	progressObserver progress.Observer
 	Now              func() time.Time
 
+	// UploadConcurrency is the number of jobs adapted and batches saved in
+	// parallel during a deployment
+	UploadConcurrency int
+	// UploadBatchSize is the number of jobs of a deployment saved in a
+	// single transaction
+	UploadBatchSize int
+	// ReconcileOptIn deletes jobs missing from a deployment only if the
+	// request asks to reconcile, by default every deployment reconciles
+	ReconcileOptIn bool
+	// HeartbeatInterval is the interval at which progress is sent on deploy
+	// streams while jobs are synced, heartbeats are disabled if not set
+	HeartbeatInterval time.Duration
+	// JobRunRepoFactory reads run history of jobs when the scheduler is
+	// unavailable, statuses are only read from scheduler if not set
+	JobRunRepoFactory JobRunRepoFactory
+	// RunDurations checks durations of runs recorded as succeeded from job
+	// events, durations are not checked if not set
+	RunDurations RunDurationChecker
+	// SensitiveConfig are patterns of config keys whose values, along with
+	// keys flagged by projects, are redacted in responses unless the caller
+	// can read secrets
+	SensitiveConfig models.ConfigKeyPatterns
+	// SecretReaders decides who can read sensitive config, values are never
+	// redacted if not set
+	SecretReaders SecretReaders
+	// RunLineage reports runs as instances of job tasks are registered and
+	// the scheduler posts events of jobs, runs are not reported if not set
+	RunLineage RunLineage
+	// JobLineage serves lineage graphs of jobs, GetLineage is unimplemented
+	// if not set
+	JobLineage JobLineage
+	// Backups backs up and restores resources, BackupResource, ListBackups
+	// and RestoreBackup are unimplemented if not set
+	Backups ResourceBackups
+	// ReplayPollInterval is how often replays are read while their status
+	// is streamed, defaults to 5 seconds
+	ReplayPollInterval time.Duration
+
 	pb.UnimplementedRuntimeServiceServer
 }
 
@@ -97,46 +196,257 @@ func (sv *RuntimeServiceServer) DeployJobSpecification(req *pb.DeployJobSpecific
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	deployment, err := sv.newJobDeployment(namespaceSpec, respStream)
+	if err != nil {
+		return err
+	}
+	if err := deployment.upload(req.GetJobs(), false); err != nil {
+		return deployment.record(err)
+	}
+	if err := deployment.finish(sv.reconcile(req), req.GetForce()); err != nil {
+		return deployment.record(err)
+	}
+	deployment.record(nil)
+
+	logger.I("finished job deployment in", time.Since(startTime))
+	return nil
+}
+
+// reconcile tells if jobs of the namespace missing from a deployment are
+// deleted, they always are unless the server makes reconcile opt in
+func (sv *RuntimeServiceServer) reconcile(req *pb.DeployJobSpecificationRequest) bool {
+	return req.GetReconcile() || !sv.ReconcileOptIn
+}
+
+func (sv *RuntimeServiceServer) DeployJobSpecificationStream(respStream pb.RuntimeService_DeployJobSpecificationStreamServer) error {
+	startTime := time.Now()
+
+	// project, namespace, reconcile and force are picked from the first chunk
+	req, err := respStream.Recv()
+	if err != nil {
+		return statusErrorf(codes.InvalidArgument, err, "%s: failed to receive jobs", err.Error())
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	deployment, err := sv.newJobDeployment(namespaceSpec, respStream)
+	if err != nil {
+		return err
+	}
+	reconcile, force := sv.reconcile(req), req.GetForce()
+	for {
+		if err := deployment.upload(req.GetJobs(), true); err != nil {
+			return deployment.record(err)
+		}
+
+		req, err = respStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return deployment.record(statusErrorf(codes.InvalidArgument, err, "%s: failed to receive jobs, %s", err.Error(),
+				rollbackDeployment(deployment.deployTx, deployment.observers)))
+		}
+	}
+	if err := deployment.finish(reconcile, force); err != nil {
+		return deployment.record(err)
+	}
+	deployment.record(nil)
+
+	logger.I("finished streamed job deployment in", time.Since(startTime))
+	return nil
+}
+
+func (sv *RuntimeServiceServer) DeployJobSpecificationAsync(ctx context.Context, req *pb.DeployJobSpecificationRequest) (*pb.DeployJobSpecificationAsyncResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	var jobsToKeep []models.JobSpec
+	var jobSpecs []models.JobSpec
 	for _, reqJob := range req.GetJobs() {
 		adaptJob, err := sv.adapter.FromJobProto(reqJob)
 		if err != nil {
-			return status.Errorf(codes.Internal, "%s: cannot adapt job %s", err.Error(), reqJob.GetName())
+			return nil, invalidArgumentf("jobs", err, "%s: cannot adapt job %s", err.Error(), reqJob.GetName())
+		}
+		jobSpecs = append(jobSpecs, adaptJob)
+	}
+
+	deploymentID, err := sv.deployManager.Deploy(models.DeployRequest{
+		Namespace:   namespaceSpec,
+		Jobs:        jobSpecs,
+		Reconcile:   sv.reconcile(req),
+		RequestedBy: requestedBy(ctx),
+	})
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to queue deployment", err.Error())
+	}
+	return &pb.DeployJobSpecificationAsyncResponse{
+		DeploymentId: deploymentID,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetDeploymentStatus(ctx context.Context, req *pb.GetDeploymentStatusRequest) (*pb.GetDeploymentStatusResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	deploymentID, err := uuid.Parse(req.GetDeploymentId())
+	if err != nil {
+		return nil, invalidArgumentf("deployment_id", err, "%s: invalid deployment id %s", err.Error(), req.GetDeploymentId())
+	}
+	deployment, err := sv.deployManager.GetStatus(deploymentID)
+	if err == nil && deployment.Namespace.ProjectSpec.Name != projSpec.Name {
+		err = store.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to fetch deployment %s", err.Error(), req.GetDeploymentId())
+	}
+	return sv.adapter.ToDeploymentStatusProto(deployment), nil
+}
+
+func (sv *RuntimeServiceServer) ListDeployments(ctx context.Context, req *pb.ListDeploymentsRequest) (*pb.ListDeploymentsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	filter := models.DeploymentFilter{
+		Namespace: req.GetNamespace(),
+		Limit:     int(req.GetPageSize()),
+	}
+	if req.GetSince() != nil {
+		filter.Since = req.GetSince().AsTime()
+	}
+	deployments, err := sv.deployManager.ListDeployments(projSpec, filter)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to list deployments", err.Error())
+	}
+
+	var deploymentProtos []*pb.DeploymentSpecification
+	for _, deployment := range deployments {
+		deploymentProto := sv.adapter.ToDeploymentProto(deployment)
+		// job outcomes are only returned for a single deployment
+		deploymentProto.Jobs = nil
+		deploymentProtos = append(deploymentProtos, deploymentProto)
+	}
+	return &pb.ListDeploymentsResponse{
+		Deployments: deploymentProtos,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetDeployment(ctx context.Context, req *pb.GetDeploymentRequest) (*pb.GetDeploymentResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	deploymentID, err := uuid.Parse(req.GetDeploymentId())
+	if err != nil {
+		return nil, invalidArgumentf("deployment_id", err, "%s: invalid deployment id %s", err.Error(), req.GetDeploymentId())
+	}
+	deployment, err := sv.deployManager.GetDeployment(projSpec, deploymentID)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to fetch deployment %s", err.Error(), req.GetDeploymentId())
+	}
+	return &pb.GetDeploymentResponse{
+		Deployment: sv.adapter.ToDeploymentProto(deployment),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ImportJobSpecifications(respStream pb.RuntimeService_ImportJobSpecificationsServer) error {
+	startTime := time.Now()
+
+	// project and namespace are picked from the first chunk
+	req, err := respStream.Recv()
+	if err != nil {
+		return statusErrorf(codes.InvalidArgument, err, "%s: failed to receive archive", err.Error())
+	}
+	archive := new(bytes.Buffer)
+	for {
+		if archive.Len()+len(req.GetArchive()) > MaxImportArchiveSize {
+			return statusErrorf(codes.ResourceExhausted, nil, "archive exceeds maximum size of %d bytes", MaxImportArchiveSize)
 		}
+		archive.Write(req.GetArchive())
 
-		err = sv.jobSvc.Create(namespaceSpec, adaptJob)
+		chunk, err := respStream.Recv()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return status.Errorf(codes.Internal, "%s: failed to save %s", err.Error(), adaptJob.Name)
+			return statusErrorf(codes.InvalidArgument, err, "%s: failed to receive archive", err.Error())
 		}
-		jobsToKeep = append(jobsToKeep, adaptJob)
+		req.Archive = chunk.GetArchive()
 	}
 
-	observers := new(progress.ObserverChain)
-	observers.Join(sv.progressObserver)
-	observers.Join(&jobSyncObserver{
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	jobSpecs, err := sv.adapter.FromJobArchive(archive)
+	if err != nil {
+		return invalidArgumentf("archive", err, "%s: cannot read job specifications from archive", err.Error())
+	}
+	for _, jobSpec := range jobSpecs {
+		if err := sv.jobSvc.Create(namespaceSpec, jobSpec); err != nil {
+			return statusErrorf(codes.Internal, err, "%s: failed to save %s", err.Error(), jobSpec.Name)
+		}
+	}
+
+	syncObserver := &jobSyncObserver{
 		stream: respStream,
 		log:    logrus.New(),
-	})
-
-	// delete specs not sent for deployment from internal repository
-	if err := sv.jobSvc.KeepOnly(namespaceSpec, jobsToKeep, observers); err != nil {
-		return status.Errorf(codes.Internal, "%s: failed to delete jobs", err.Error())
 	}
+	observers := new(progress.ObserverChain)
+	observers.Join(sv.progressObserver)
+	observers.Join(syncObserver)
 
+	// namespace may have jobs other than the imported ones, total is unknown
+	stopHeartbeat := syncObserver.startHeartbeat(sv.HeartbeatInterval, 0)
+	defer stopHeartbeat()
 	if err := sv.jobSvc.Sync(respStream.Context(), namespaceSpec, observers); err != nil {
-		return status.Errorf(codes.Internal, "%s\nfailed to sync jobs", err.Error())
+		return statusErrorf(codes.Internal, err, "%s\nfailed to sync jobs", err.Error())
 	}
 
-	logger.I("finished job deployment in", time.Since(startTime))
+	logger.I("finished job import in", time.Since(startTime))
 	return nil
 }
 
@@ -144,30 +454,45 @@ func (sv *RuntimeServiceServer) ListJobSpecification(ctx context.Context, req *p
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	if req.GetReadMask() != nil && !req.GetReadMask().IsValid(&pb.JobSpecification{}) {
+		return nil, invalidArgumentf("read_mask", nil, "invalid field mask %v for job specification", req.GetReadMask().GetPaths())
 	}
 
 	jobSpecs, err := sv.jobSvc.GetAll(namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
+	}
+
+	jobSpecs, nextPageToken, err := paginateJobSpecs(filterJobSpecs(jobSpecs, req.GetFilter()), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, invalidArgumentf("page_token", err, "%s: %s", err.Error(), req.GetPageToken())
 	}
 
 	jobProtos := []*pb.JobSpecification{}
 	for _, jobSpec := range jobSpecs {
+		if isJobFieldMasked(req.GetReadMask(), jobAssetsField) {
+			// assets are the bulk of a job, skip adapting them if not requested
+			jobSpec.Assets = models.JobAssets{}
+		}
 		jobProto, err := sv.adapter.ToJobProto(jobSpec)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "%s: failed to parse job spec %s", err.Error(), jobSpec.Name)
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to parse job spec %s", err.Error(), jobSpec.Name)
 		}
+		applyFieldMask(jobProto, req.GetReadMask())
 		jobProtos = append(jobProtos, jobProto)
 	}
 	return &pb.ListJobSpecificationResponse{
-		Jobs: jobProtos,
+		Jobs:          jobProtos,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -175,64 +500,115 @@ func (sv *RuntimeServiceServer) DumpJobSpecification(ctx context.Context, req *p
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	reqJobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: job %s not found", err.Error(), req.GetJobName())
+		return nil, statusErrorf(codes.Internal, err, "%s: job %s not found", err.Error(), req.GetJobName())
 	}
 
 	compiledJob, err := sv.jobSvc.Dump(namespaceSpec, reqJobSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to compile %s", err.Error(), reqJobSpec.Name)
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to compile %s", err.Error(), reqJobSpec.Name)
 	}
 
 	return &pb.DumpJobSpecificationResponse{Success: true, Content: string(compiledJob.Contents)}, nil
 }
 
+func (sv *RuntimeServiceServer) CompileJob(ctx context.Context, req *pb.CompileJobRequest) (*pb.CompileJobResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	var compiledJob models.Job
+	if req.GetJob() == nil {
+		jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
+		if err != nil {
+			return nil, statusErrorf(codes.NotFound, err, "%s: job %s not found", err.Error(), req.GetJobName())
+		}
+		if compiledJob, err = sv.jobSvc.Dump(namespaceSpec, jobSpec); err != nil {
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to compile %s", err.Error(), jobSpec.Name)
+		}
+	} else {
+		if req.GetJob().GetName() != req.GetJobName() {
+			return nil, invalidArgumentf("job", nil, "name of job %s doesn't match %s", req.GetJob().GetName(), req.GetJobName())
+		}
+		jobSpec, err := sv.adapter.FromJobProto(req.GetJob())
+		if err != nil {
+			return nil, invalidArgumentf("job", err, "failed to adapt job %s\n%s", req.GetJobName(), err.Error())
+		}
+		if compiledJob, err = sv.jobSvc.Compile(namespaceSpec, jobSpec); err != nil {
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to compile %s", err.Error(), jobSpec.Name)
+		}
+	}
+
+	return &pb.CompileJobResponse{Success: true, Content: string(compiledJob.Contents)}, nil
+}
+
 func (sv *RuntimeServiceServer) CheckJobSpecification(ctx context.Context, req *pb.CheckJobSpecificationRequest) (*pb.CheckJobSpecificationResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	j, err := sv.adapter.FromJobProto(req.GetJob())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to adapt job %s\n%s", req.GetJob().Name, err.Error())
+		return nil, invalidArgumentf("job", err, "failed to adapt job %s\n%s", req.GetJob().Name, err.Error())
+	}
+	// check job as it would be scaffolded, with config and assets its task
+	// plugin generates by default
+	j, err = job.ApplyTaskDefaults(ctx, j)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to apply defaults of job %s", err.Error(), j.Name)
 	}
 	reqJobs := []models.JobSpec{j}
 
 	if err = sv.jobSvc.Check(namespaceSpec, reqJobs, nil); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to compile jobs\n%s", err.Error())
+		return nil, statusErrorf(codes.Internal, err, "failed to compile jobs\n%s", err.Error())
+	}
+	jobProto, err := sv.adapter.ToJobProto(j)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to parse job %s", err.Error(), j.Name)
 	}
-	return &pb.CheckJobSpecificationResponse{Success: true}, nil
+	return &pb.CheckJobSpecificationResponse{
+		Success: true,
+		Job:     jobProto,
+	}, nil
 }
 
 func (sv *RuntimeServiceServer) CheckJobSpecifications(req *pb.CheckJobSpecificationsRequest, respStream pb.RuntimeService_CheckJobSpecificationsServer) error {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	observers := new(progress.ObserverChain)
@@ -246,13 +622,13 @@ func (sv *RuntimeServiceServer) CheckJobSpecifications(req *pb.CheckJobSpecifica
 	for _, jobProto := range req.GetJobs() {
 		j, err := sv.adapter.FromJobProto(jobProto)
 		if err != nil {
-			return status.Errorf(codes.Internal, "failed to adapt job %s\n%s", jobProto.Name, err.Error())
+			return invalidArgumentf("jobs", err, "failed to adapt job %s\n%s", jobProto.Name, err.Error())
 		}
 		reqJobs = append(reqJobs, j)
 	}
 
 	if err = sv.jobSvc.Check(namespaceSpec, reqJobs, observers); err != nil {
-		return status.Errorf(codes.Internal, "failed to compile jobs\n%s", err.Error())
+		return statusErrorf(codes.Internal, err, "failed to compile jobs\n%s", err.Error())
 	}
 	return nil
 }
@@ -260,29 +636,42 @@ func (sv *RuntimeServiceServer) CheckJobSpecifications(req *pb.CheckJobSpecifica
 func (sv *RuntimeServiceServer) RegisterProject(ctx context.Context, req *pb.RegisterProjectRequest) (*pb.RegisterProjectResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projectSpec := sv.adapter.FromProjectProto(req.GetProject())
+	if _, err := projectSpec.Calendar(); err != nil {
+		return nil, invalidArgumentf("project.config", err, "%s: invalid calendar of project %s", err.Error(), req.GetProject().GetName())
+	}
+	if err := sv.scheduler.ValidateProject(projectSpec); err != nil {
+		return nil, invalidArgumentf("project.config", err, "%s: invalid scheduler config of project %s", err.Error(), req.GetProject().GetName())
+	}
 
 	if err := projectRepo.Save(projectSpec); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to save project %s", err.Error(), req.GetProject().GetName())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to save project %s", err.Error(), req.GetProject().GetName())
 	}
 
-	if req.GetNamespace() != nil {
-		savedProjectSpec, err := projectRepo.GetByName(projectSpec.Name)
-		if err != nil {
-			return nil, status.Errorf(codes.NotFound, "%s: failed to find project %s",
-				err.Error(), req.GetProject().GetName())
-		}
+	savedProjectSpec, err := projectRepo.GetByName(projectSpec.Name)
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to find project %s",
+			err.Error(), req.GetProject().GetName())
+	}
 
+	if req.GetNamespace() != nil {
 		namespaceRepo := sv.namespaceRepoFactory.New(savedProjectSpec)
 		namespaceSpec := sv.adapter.FromNamespaceProto(req.GetNamespace())
 		if err = namespaceRepo.Save(namespaceSpec); err != nil {
-			return nil, status.Errorf(codes.Internal, "%s: failed to save project %s with namespace %s",
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to save project %s with namespace %s",
 				err.Error(), req.GetProject().GetName(), req.GetNamespace().GetName())
 		}
 	}
 
+	message := "saved successfully"
+	if err = sv.scheduler.Bootstrap(ctx, savedProjectSpec, sv.progressObserver); err != nil {
+		// secrets needed by scheduler can only be registered after the
+		// project, it gets bootstrapped again when the server boots up
+		message = fmt.Sprintf("%s, scheduler not bootstrapped: %s", message, err.Error())
+	}
+
 	return &pb.RegisterProjectResponse{
 		Success: true,
-		Message: "saved successfully",
+		Message: message,
 	}, nil
 }
 
@@ -290,13 +679,13 @@ func (sv *RuntimeServiceServer) RegisterProjectNamespace(ctx context.Context, re
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceSpec := sv.adapter.FromNamespaceProto(req.GetNamespace())
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	if err = namespaceRepo.Save(namespaceSpec); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to save namespace %s for project %s", err.Error(), namespaceSpec.Name, projSpec.Name)
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to save namespace %s for project %s", err.Error(), namespaceSpec.Name, projSpec.Name)
 	}
 
 	return &pb.RegisterProjectNamespaceResponse{
@@ -309,32 +698,32 @@ func (sv *RuntimeServiceServer) CreateJobSpecification(ctx context.Context, req
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
 	}
 
 	jobSpec, err := sv.adapter.FromJobProto(req.GetSpec())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: cannot deserialize job", err.Error())
+		return nil, invalidArgumentf("spec", err, "%s: cannot deserialize job", err.Error())
 	}
 
 	// validate job spec
 	if err = sv.jobSvc.Check(namespaceSpec, []models.JobSpec{jobSpec}, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "spec validation failed\n%s", err.Error())
+		return nil, invalidArgumentf("spec", err, "spec validation failed\n%s", err.Error())
 	}
 
 	err = sv.jobSvc.Create(namespaceSpec, jobSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to save job %s", err.Error(), jobSpec.Name)
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to save job %s", err.Error(), jobSpec.Name)
 	}
 
 	if err := sv.jobSvc.Sync(ctx, namespaceSpec, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s\nfailed to sync jobs", err.Error())
+		return nil, statusErrorf(codes.Internal, err, "%s\nfailed to sync jobs", err.Error())
 	}
 
 	return &pb.CreateJobSpecificationResponse{
@@ -347,27 +736,39 @@ func (sv *RuntimeServiceServer) ReadJobSpecification(ctx context.Context, req *p
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+	}
+
+	if req.GetReadMask() != nil && !req.GetReadMask().IsValid(&pb.JobSpecification{}) {
+		return nil, invalidArgumentf("read_mask", nil, "invalid field mask %v for job specification", req.GetReadMask().GetPaths())
 	}
 
 	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: error while finding the job %s", err.Error(), req.GetJobName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: error while finding the job %s", err.Error(), req.GetJobName())
 	}
 
+	if isJobFieldMasked(req.GetReadMask(), jobAssetsField) {
+		jobSpec.Assets = models.JobAssets{}
+	}
 	jobSpecAdapt, err := sv.adapter.ToJobProto(jobSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: cannot serialize job", err.Error())
+		return nil, statusErrorf(codes.Internal, err, "%s: cannot serialize job", err.Error())
 	}
+	applyFieldMask(jobSpecAdapt, req.GetReadMask())
+
+	// specs saved before intervals were validated are served without a description
+	scheduleDescription, _ := utils.DescribeCronInterval(jobSpec.Schedule.Interval, jobSpec.Schedule.Timezone)
 
 	return &pb.ReadJobSpecificationResponse{
-		Spec: jobSpecAdapt,
+		Spec:                jobSpecAdapt,
+		ScheduleDescription: scheduleDescription,
 	}, nil
 }
 
@@ -375,321 +776,1481 @@ func (sv *RuntimeServiceServer) DeleteJobSpecification(ctx context.Context, req
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
 	}
 
 	jobSpecToDelete, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: job %s does not exist", err.Error(), req.GetJobName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: job %s does not exist", err.Error(), req.GetJobName())
 	}
 
 	if err := sv.jobSvc.Delete(ctx, namespaceSpec, jobSpecToDelete); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to delete job %s", err.Error(), req.GetJobName())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to delete job %s", err.Error(), req.GetJobName())
+	}
+
+	return &pb.DeleteJobSpecificationResponse{
+		Success: true,
+		Message: fmt.Sprintf("job %s has been deleted", jobSpecToDelete.Name),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) PauseJob(ctx context.Context, req *pb.PauseJobRequest) (*pb.PauseJobResponse, error) {
+	if err := sv.setJobPaused(ctx, req.GetProjectName(), req.GetNamespace(), req.GetJobName(), true); err != nil {
+		return nil, err
+	}
+	return &pb.PauseJobResponse{
+		Success: true,
+		Message: fmt.Sprintf("job %s has been paused", req.GetJobName()),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ResumeJob(ctx context.Context, req *pb.ResumeJobRequest) (*pb.ResumeJobResponse, error) {
+	if err := sv.setJobPaused(ctx, req.GetProjectName(), req.GetNamespace(), req.GetJobName(), false); err != nil {
+		return nil, err
+	}
+	return &pb.ResumeJobResponse{
+		Success: true,
+		Message: fmt.Sprintf("job %s has been resumed", req.GetJobName()),
+	}, nil
+}
+
+// RestoreJob brings back a deleted job which is not purged yet
+func (sv *RuntimeServiceServer) RestoreJob(ctx context.Context, req *pb.RestoreJobRequest) (*pb.RestoreJobResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+	}
+
+	if _, err := sv.jobSvc.Restore(ctx, namespaceSpec, req.GetJobName()); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to restore job %s", err.Error(), req.GetJobName())
+	}
+	return &pb.RestoreJobResponse{
+		Success: true,
+		Message: fmt.Sprintf("job %s has been restored", req.GetJobName()),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListJobVersions(ctx context.Context, req *pb.ListJobVersionsRequest) (*pb.ListJobVersionsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+	}
+
+	versions, err := sv.jobSvc.GetVersions(namespaceSpec, req.GetJobName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to list versions of job %s", err.Error(), req.GetJobName())
+	}
+
+	versionsProto := make([]*pb.JobSpecVersion, 0, len(versions))
+	for _, version := range versions {
+		specProto, err := sv.adapter.ToJobProto(version.Spec)
+		if err != nil {
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to parse revision %d of job %s", err.Error(), version.Revision, req.GetJobName())
+		}
+		versionsProto = append(versionsProto, &pb.JobSpecVersion{
+			Revision:  int32(version.Revision),
+			CreatedAt: timestamppb.New(version.CreatedAt),
+			Spec:      specProto,
+		})
+	}
+	return &pb.ListJobVersionsResponse{
+		Versions: versionsProto,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetJobVersionDiff(ctx context.Context, req *pb.GetJobVersionDiffRequest) (*pb.GetJobVersionDiffResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+	}
+
+	fromVersion, err := sv.jobSvc.GetVersion(namespaceSpec, req.GetJobName(), int(req.GetFromRevision()))
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: revision %d of job %s not found", err.Error(), req.GetFromRevision(), req.GetJobName())
+	}
+	toSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: job %s not found", err.Error(), req.GetJobName())
+	}
+	if req.GetToRevision() != 0 {
+		toVersion, err := sv.jobSvc.GetVersion(namespaceSpec, req.GetJobName(), int(req.GetToRevision()))
+		if err != nil {
+			return nil, statusErrorf(codes.NotFound, err, "%s: revision %d of job %s not found", err.Error(), req.GetToRevision(), req.GetJobName())
+		}
+		toSpec = toVersion.Spec
+	}
+
+	fromProto, err := sv.adapter.ToJobProto(fromVersion.Spec)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to parse revision %d of job %s", err.Error(), req.GetFromRevision(), req.GetJobName())
+	}
+	toProto, err := sv.adapter.ToJobProto(toSpec)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to parse job %s", err.Error(), req.GetJobName())
+	}
+	changes, err := diffJobSpecs(fromProto, toProto)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to compare versions of job %s", err.Error(), req.GetJobName())
+	}
+	return &pb.GetJobVersionDiffResponse{
+		Changes: changes,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) RollbackJob(ctx context.Context, req *pb.RollbackJobRequest) (*pb.RollbackJobResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+	}
+
+	if _, err := sv.jobSvc.Rollback(ctx, namespaceSpec, req.GetJobName(), int(req.GetRevision())); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to rollback job %s to revision %d", err.Error(), req.GetJobName(), req.GetRevision())
+	}
+	return &pb.RollbackJobResponse{
+		Success: true,
+		Message: fmt.Sprintf("job %s has been rolled back to revision %d", req.GetJobName(), req.GetRevision()),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetLineage(ctx context.Context, req *pb.GetLineageRequest) (*pb.GetLineageResponse, error) {
+	if sv.JobLineage == nil {
+		return nil, statusErrorf(codes.Unimplemented, nil, "lineage of jobs is not served")
+	}
+	direction := models.LineageDirection(req.GetDirection())
+	switch direction {
+	case models.LineageDirectionUpstream, models.LineageDirectionDownstream, models.LineageDirectionBoth:
+	default:
+		return nil, invalidArgumentf("direction", nil, "direction should be %s or %s, or empty for both",
+			models.LineageDirectionUpstream, models.LineageDirectionDownstream)
+	}
+	if req.GetDepth() < 0 {
+		return nil, invalidArgumentf("depth", nil, "depth should not be negative")
+	}
+
+	graph, err := sv.JobLineage.GetLineage(req.GetProjectName(), req.GetJobName(), direction, int(req.GetDepth()))
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, statusErrorf(codes.NotFound, err, "%s: lineage of job %s not found. Is it deployed?", err.Error(), req.GetJobName())
+		}
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to get lineage of job %s", err.Error(), req.GetJobName())
+	}
+
+	resp := &pb.GetLineageResponse{}
+	for _, node := range graph.Nodes {
+		resp.Nodes = append(resp.Nodes, &pb.LineageNode{
+			Id:          node.ID,
+			Type:        string(node.Type),
+			ProjectName: node.ProjectName,
+			Namespace:   node.NamespaceName,
+			JobName:     node.JobName,
+			Urn:         node.Urn,
+		})
+	}
+	for _, edge := range graph.Edges {
+		resp.Edges = append(resp.Edges, &pb.LineageEdge{
+			Source: edge.Source,
+			Target: edge.Target,
+		})
+	}
+	return resp, nil
+}
+
+// setJobPaused toggles scheduling of a stored job, spec is kept as is
+// apart from the flag so the job doesn't need to be deployed again
+func (sv *RuntimeServiceServer) setJobPaused(ctx context.Context, projectName, namespace, jobName string, paused bool) error {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(projectName)
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), projectName)
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(namespace)
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: namespace %s not found. Is it registered?", err.Error(), namespace)
+	}
+
+	jobSpec, err := sv.jobSvc.GetByName(jobName, namespaceSpec)
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: job %s does not exist", err.Error(), jobName)
+	}
+
+	if err := sv.jobSvc.SetPaused(ctx, namespaceSpec, jobSpec, paused); err != nil {
+		return statusErrorf(codes.Internal, err, "%s: failed to update job %s", err.Error(), jobName)
+	}
+	return nil
+}
+
+func (sv *RuntimeServiceServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to retrieve saved projects", err.Error())
+	}
+
+	projSpecsProto := []*pb.ProjectSpecification{}
+	for _, project := range projects {
+		projSpecsProto = append(projSpecsProto, sv.adapter.ToProjectProto(sv.redactProject(ctx, project)))
+	}
+
+	return &pb.ListProjectsResponse{
+		Projects: projSpecsProto,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListProjectNamespaces(ctx context.Context, req *pb.ListProjectNamespacesRequest) (*pb.ListProjectNamespacesResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpecs, err := namespaceRepo.GetAll()
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: error while fetching namespaces", err.Error())
+	}
+
+	canReadSecrets := sv.canReadSecrets(ctx, projSpec.Name)
+	namespaceSpecsProto := []*pb.NamespaceSpecification{}
+	for _, namespace := range namespaceSpecs {
+		if !canReadSecrets {
+			namespace.Config = sv.redactConfig(namespace.Config, projSpec)
+		}
+		namespaceSpecsProto = append(namespaceSpecsProto, sv.adapter.ToNamespaceProto(namespace))
+	}
+
+	return &pb.ListProjectNamespacesResponse{
+		Namespaces: namespaceSpecsProto,
+	}, nil
+}
+
+// DeleteProject removes jobs of a project from scheduler and soft deletes
+// it, the project can be restored till it is purged
+func (sv *RuntimeServiceServer) DeleteProject(ctx context.Context, req *pb.DeleteProjectRequest) (*pb.DeleteProjectResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceSpecs, err := sv.namespaceRepoFactory.New(projSpec).GetAll()
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: error while fetching namespaces", err.Error())
+	}
+	for _, namespaceSpec := range namespaceSpecs {
+		jobSpecs, err := sv.jobSvc.GetAll(namespaceSpec)
+		if err != nil {
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve jobs of namespace %s", err.Error(), namespaceSpec.Name)
+		}
+		if len(jobSpecs) == 0 {
+			continue
+		}
+		var jobNames []string
+		for _, jobSpec := range jobSpecs {
+			jobNames = append(jobNames, jobSpec.Name)
+		}
+		if err := sv.scheduler.DeleteJobs(ctx, namespaceSpec, jobNames, sv.progressObserver); err != nil {
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to remove jobs of namespace %s from scheduler", err.Error(), namespaceSpec.Name)
+		}
+	}
+
+	if err := projectRepo.Delete(projSpec.Name); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to delete project %s", err.Error(), req.GetProjectName())
+	}
+	return &pb.DeleteProjectResponse{
+		Success: true,
+		Message: fmt.Sprintf("project %s has been deleted", projSpec.Name),
+	}, nil
+}
+
+// RestoreProject brings back a deleted project which is not purged yet and
+// schedules jobs of its namespaces again
+func (sv *RuntimeServiceServer) RestoreProject(ctx context.Context, req *pb.RestoreProjectRequest) (*pb.RestoreProjectResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	if err := projectRepo.Restore(req.GetProjectName()); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to restore project %s", err.Error(), req.GetProjectName())
+	}
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceSpecs, err := sv.namespaceRepoFactory.New(projSpec).GetAll()
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: error while fetching namespaces", err.Error())
+	}
+	message := fmt.Sprintf("project %s has been restored", projSpec.Name)
+	for _, namespaceSpec := range namespaceSpecs {
+		if err := sv.jobSvc.Sync(ctx, namespaceSpec, sv.progressObserver); err != nil {
+			// the project stays restored, jobs of the namespace get
+			// scheduled on its next deployment
+			message = fmt.Sprintf("%s, jobs of namespace %s not scheduled: %s", message, namespaceSpec.Name, err.Error())
+		}
+	}
+	return &pb.RestoreProjectResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) RegisterInstance(ctx context.Context, req *pb.RegisterInstanceRequest) (*pb.RegisterInstanceResponse, error) {
+	jobScheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
+	if err != nil {
+		return nil, invalidArgumentf("scheduled_at", err, "%s: failed to parse schedule time of job %s", err.Error(), req.GetScheduledAt())
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	jobSpec, namespaceSpec, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: job %s not found", err.Error(), req.GetJobName())
+	}
+	jobProto, err := sv.adapter.ToJobProto(jobSpec)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: cannot adapt job %s", err.Error(), jobSpec.Name)
+	}
+
+	instanceType, err := models.InstanceType("").New(req.InstanceType.String())
+	if err != nil {
+		return nil, invalidArgumentf("instance_type", err, "%s: instance type %s not found", err.Error(), req.InstanceType.String())
+	}
+	// window is computed with the calendar of the project
+	if jobSpec.Task.Window.Calendar, err = projSpec.Calendar(); err != nil {
+		return nil, statusErrorf(codes.FailedPrecondition, err, "%s: invalid calendar of project %s", err.Error(), req.GetProjectName())
+	}
+	instance, err := sv.instSvc.Register(jobSpec, jobScheduledTime, instanceType)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to register instance of job %s", err.Error(), req.GetJobName())
+	}
+	envMap, fileMap, err := sv.instSvc.Compile(namespaceSpec, jobSpec, instance, instanceType, req.InstanceName)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to compile instance of job %s", err.Error(), req.GetJobName())
+	}
+	if sv.RunLineage != nil && instanceType == models.InstanceTypeTask {
+		if err := sv.RunLineage.EmitRunStarted(ctx, namespaceSpec, jobSpec, jobScheduledTime); err != nil {
+			logger.W(fmt.Sprintf("%s: failed to report start of run of job %s", err.Error(), jobSpec.Name))
+		}
+	}
+
+	instanceProto, err := sv.adapter.ToInstanceProto(instance)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: cannot adapt instance for job %s", err.Error(), jobSpec.Name)
+	}
+	// compiled context already carries the config instance needs
+	if !sv.canReadSecrets(ctx, projSpec.Name) {
+		projSpec.Config = sv.redactConfig(projSpec.Config, projSpec)
+		namespaceSpec.Config = sv.redactConfig(namespaceSpec.Config, projSpec)
+	}
+	return &pb.RegisterInstanceResponse{
+		Project:   sv.adapter.ToProjectProto(projSpec),
+		Job:       jobProto,
+		Instance:  instanceProto,
+		Namespace: sv.adapter.ToNamespaceProto(namespaceSpec),
+		Context: &pb.InstanceContext{
+			Envs:  envMap,
+			Files: fileMap,
+		},
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) JobStatus(ctx context.Context, req *pb.JobStatusRequest) (*pb.JobStatusResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	_, _, err = sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
+	}
+
+	jobStatuses, err := sv.scheduler.GetJobStatus(ctx, projSpec, req.GetJobName())
+	if err != nil {
+		historyStatuses, historyErr := sv.jobStatusHistory(projSpec, req.GetJobName())
+		if historyErr != nil {
+			return nil, statusErrorf(codes.NotFound, err, "%s: failed to fetch jobStatus %s", err.Error(),
+				req.GetJobName())
+		}
+		logger.W(fmt.Sprintf("%s: reading status of %s from run history", err.Error(), req.GetJobName()))
+		jobStatuses = historyStatuses
+	}
+
+	var adaptedJobStatus []*pb.JobStatus
+	for _, jobStatus := range jobStatuses {
+		ts := timestamppb.New(jobStatus.ScheduledAt)
+		adaptedJobStatus = append(adaptedJobStatus, &pb.JobStatus{
+			State:       jobStatus.State.String(),
+			ScheduledAt: ts,
+		})
+	}
+	return &pb.JobStatusResponse{
+		Statuses: adaptedJobStatus,
+	}, nil
+}
+
+// ClearJobRuns clears runs of a job scheduled between start and end dates so
+// scheduler runs them again, runs are only listed on dry run
+func (sv *RuntimeServiceServer) ClearJobRuns(ctx context.Context, req *pb.ClearJobRunsRequest) (*pb.ClearJobRunsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	if _, _, err = sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec); err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
+	}
+
+	if req.GetStartDate() == nil {
+		return nil, invalidArgumentf("start_date", nil, "start date of runs to clear is required")
+	}
+	startDate := req.GetStartDate().AsTime()
+	endDate := startDate
+	if req.GetEndDate() != nil {
+		endDate = req.GetEndDate().AsTime()
+	}
+	if endDate.Before(startDate) {
+		return nil, invalidArgumentf("end_date", nil, "end date cannot be before start date")
+	}
+
+	runs, err := sv.scheduler.GetDagRunStatus(ctx, projSpec, req.GetJobName(), startDate, endDate, clearJobRunsBatchSize)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to fetch runs of job %s", err.Error(), req.GetJobName())
+	}
+	if !req.GetDryRun() && len(runs) > 0 {
+		if err = sv.scheduler.Clear(ctx, projSpec, req.GetJobName(), startDate, endDate); err != nil {
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to clear runs of job %s", err.Error(), req.GetJobName())
+		}
+	}
+
+	var clearedRuns []*pb.JobStatus
+	for _, run := range runs {
+		clearedRuns = append(clearedRuns, &pb.JobStatus{
+			State:       run.State.String(),
+			ScheduledAt: timestamppb.New(run.ScheduledAt),
+		})
+	}
+	return &pb.ClearJobRunsResponse{
+		Success: true,
+		Runs:    clearedRuns,
+	}, nil
+}
+
+// jobStatusHistory reads statuses of a job from its run history
+func (sv *RuntimeServiceServer) jobStatusHistory(projSpec models.ProjectSpec, jobName string) ([]models.JobStatus, error) {
+	if sv.JobRunRepoFactory == nil {
+		return nil, errors.New("run history is not enabled")
+	}
+	runs, err := sv.JobRunRepoFactory.New(projSpec).GetAll(models.JobRunFilter{JobName: jobName})
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, store.ErrResourceNotFound
+	}
+	var statuses []models.JobStatus
+	for _, run := range runs {
+		statuses = append(statuses, models.JobStatus{
+			ScheduledAt: run.ScheduledAt,
+			State:       run.State,
+		})
+	}
+	return statuses, nil
+}
+
+// GetJobRuns reads run history of jobs of a project along with the number of
+// runs in each state, counts ignore the states filter so dashboards can show
+// them next to the runs of a single state
+func (sv *RuntimeServiceServer) GetJobRuns(ctx context.Context, req *pb.GetJobRunsRequest) (*pb.GetJobRunsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+	if sv.JobRunRepoFactory == nil {
+		return nil, statusErrorf(codes.Unimplemented, nil, "run history of jobs is not served")
+	}
+
+	filter := models.JobRunFilter{JobName: req.GetJobName()}
+	if req.GetStartDate() != nil {
+		filter.StartDate = req.GetStartDate().AsTime()
+	}
+	if req.GetEndDate() != nil {
+		filter.EndDate = req.GetEndDate().AsTime()
+	}
+	if !filter.StartDate.IsZero() && !filter.EndDate.IsZero() && filter.EndDate.Before(filter.StartDate) {
+		return nil, invalidArgumentf("end_date", nil, "end date cannot be before start date")
+	}
+
+	jobRunRepo := sv.JobRunRepoFactory.New(projSpec)
+	counts, err := jobRunRepo.CountByState(filter)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to count runs of project %s", err.Error(), req.GetProjectName())
+	}
+	for _, state := range req.GetStates() {
+		filter.States = append(filter.States, models.JobStatusState(strings.ToLower(state)))
+	}
+	runs, err := jobRunRepo.GetAll(filter)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to read runs of project %s", err.Error(), req.GetProjectName())
+	}
+
+	resp := &pb.GetJobRunsResponse{
+		StateCounts: map[string]int64{},
+	}
+	for state, count := range counts {
+		resp.StateCounts[state.String()] = int64(count)
+	}
+	for _, run := range runs {
+		runProto := &pb.JobRun{
+			JobName:     run.JobName,
+			Namespace:   run.Namespace.Name,
+			ScheduledAt: timestamppb.New(run.ScheduledAt),
+			State:       run.State.String(),
+			Attempt:     int32(run.Attempt),
+		}
+		if !run.StartTime.IsZero() {
+			runProto.StartTime = timestamppb.New(run.StartTime)
+		}
+		if !run.EndTime.IsZero() {
+			runProto.EndTime = timestamppb.New(run.EndTime)
+		}
+		resp.Runs = append(resp.Runs, runProto)
+	}
+	return resp, nil
+}
+
+func (sv *RuntimeServiceServer) RegisterJobEvent(ctx context.Context, req *pb.RegisterJobEventRequest) (*pb.RegisterJobEventResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to find the job %s for namespace %s", err.Error(),
+			req.GetJobName(), req.GetNamespace())
+	}
+
+	if req.GetEvent() == nil {
+		return nil, invalidArgumentf("event", nil, "missing required job event values")
+	}
+
+	eventValues := map[string]*structpb.Value{}
+	if req.GetEvent().Value != nil {
+		eventValues = req.GetEvent().Value.GetFields()
+	}
+	jobEvent := models.JobEvent{
+		Type:  models.JobEventType(strings.ToLower(req.GetEvent().Type.String())),
+		Value: eventValues,
+	}
+	if sv.RunLineage != nil {
+		if err := sv.RunLineage.EmitRunEvent(ctx, namespaceSpec, jobSpec, jobEvent); err != nil {
+			logger.W(fmt.Sprintf("%s: failed to report %s event of job %s", err.Error(), jobEvent.Type, jobSpec.Name))
+		}
+	}
+	if err := sv.jobEventSvc.Register(ctx, namespaceSpec, jobSpec, jobEvent); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "failed to register event: %s", err)
+	}
+	if sv.JobRunRepoFactory != nil && jobEvent.Type == models.JobEventTypeSLAMiss {
+		if err := sv.recordSLAMiss(namespaceSpec, jobSpec, jobEvent); err != nil {
+			logger.W(fmt.Sprintf("%s: failed to record sla miss of job %s", err.Error(), jobSpec.Name))
+		}
+	} else if sv.JobRunRepoFactory != nil {
+		run, err := sv.recordJobRun(namespaceSpec, jobSpec, jobEvent)
+		if err != nil {
+			logger.W(fmt.Sprintf("%s: failed to record run of job %s from %s event", err.Error(), jobSpec.Name, jobEvent.Type))
+		} else if sv.RunDurations != nil && run.State == models.JobStatusStateSuccess {
+			if err := sv.RunDurations.Check(ctx, namespaceSpec, jobSpec, run); err != nil {
+				logger.W(fmt.Sprintf("%s: failed to check duration of run of job %s", err.Error(), jobSpec.Name))
+			}
+		}
+	}
+
+	return &pb.RegisterJobEventResponse{}, nil
+}
+
+// recordJobRun saves the run an event is posted for in run history, so
+// history is up to date without waiting for the poller. Run is identified by
+// scheduled_at, it is running once any of its tasks start or are retried.
+// Earliest start_time sent is kept, end_time and attempt are kept as is if
+// not sent along with the event. Recorded run is returned, it is empty if
+// the event is not of a run
+func (sv *RuntimeServiceServer) recordJobRun(namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec, jobEvent models.JobEvent) (models.JobRun, error) {
+	var state models.JobStatusState
+	switch jobEvent.Type {
+	case models.JobEventTypeStart, models.JobEventTypeRetry:
+		state = models.JobStatusStateRunning
+	case models.JobEventTypeSuccess:
+		state = models.JobStatusStateSuccess
+	case models.JobEventTypeFailure:
+		state = models.JobStatusStateFailed
+	default:
+		return models.JobRun{}, nil
+	}
+
+	scheduledAt, err := jobEventTime(jobEvent, "scheduled_at")
+	if err != nil {
+		return models.JobRun{}, err
+	}
+	if scheduledAt.IsZero() {
+		return models.JobRun{}, errors.New("missing scheduled_at")
+	}
+	startTime, err := jobEventTime(jobEvent, "start_time")
+	if err != nil {
+		return models.JobRun{}, err
+	}
+	endTime, err := jobEventTime(jobEvent, "end_time")
+	if err != nil {
+		return models.JobRun{}, err
+	}
+
+	jobRunRepo := sv.JobRunRepoFactory.New(namespaceSpec.ProjectSpec)
+	knownRuns, err := jobRunRepo.GetAll(models.JobRunFilter{
+		JobName:   jobSpec.Name,
+		StartDate: scheduledAt,
+		EndDate:   scheduledAt,
+	})
+	if err != nil {
+		return models.JobRun{}, err
+	}
+	now := time.Now()
+	run := models.JobRun{
+		JobName:     jobSpec.Name,
+		ScheduledAt: scheduledAt,
+		CreatedAt:   now,
+	}
+	if len(knownRuns) > 0 {
+		run = knownRuns[0]
+	}
+	run.Namespace = namespaceSpec
+	run.State = state
+	run.UpdatedAt = now
+	if startTime.IsZero() && jobEvent.Type == models.JobEventTypeStart {
+		startTime = now
+	}
+	if !startTime.IsZero() && (run.StartTime.IsZero() || startTime.Before(run.StartTime)) {
+		run.StartTime = startTime
+	}
+	if !endTime.IsZero() {
+		run.EndTime = endTime
+	}
+	if attempt, ok := jobEvent.Value["attempt"]; ok && attempt.GetNumberValue() > 0 {
+		run.Attempt = int(attempt.GetNumberValue())
+	}
+	if err := jobRunRepo.Save(run); err != nil {
+		return models.JobRun{}, err
+	}
+	return run, nil
+}
+
+// recordSLAMiss marks runs scheduler reported an sla miss for in run
+// history, so the sla checker doesn't raise the miss again. Runs are
+// identified by scheduled_at of each of the slas sent
+func (sv *RuntimeServiceServer) recordSLAMiss(namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec, jobEvent models.JobEvent) error {
+	if synthetic, ok := jobEvent.Value["synthetic"]; ok && synthetic.GetBoolValue() {
+		return nil
+	}
+	slas, ok := jobEvent.Value["slas"]
+	if !ok {
+		return nil
+	}
+
+	jobRunRepo := sv.JobRunRepoFactory.New(namespaceSpec.ProjectSpec)
+	now := time.Now()
+	recorded := map[int64]bool{}
+	for _, sla := range slas.GetListValue().GetValues() {
+		value, ok := sla.GetStructValue().GetFields()["scheduled_at"]
+		if !ok || value.GetStringValue() == "" {
+			continue
+		}
+		scheduledAt, err := time.Parse(time.RFC3339, value.GetStringValue())
+		if err != nil {
+			return errors.Wrapf(err, "invalid scheduled_at %s", value.GetStringValue())
+		}
+		if recorded[scheduledAt.Unix()] {
+			continue
+		}
+		recorded[scheduledAt.Unix()] = true
+
+		knownRuns, err := jobRunRepo.GetAll(models.JobRunFilter{
+			JobName:   jobSpec.Name,
+			StartDate: scheduledAt,
+			EndDate:   scheduledAt,
+		})
+		if err != nil {
+			return err
+		}
+		// scheduler only reports misses of runs it hasn't finished
+		run := models.JobRun{
+			JobName:     jobSpec.Name,
+			ScheduledAt: scheduledAt,
+			State:       models.JobStatusStateRunning,
+			CreatedAt:   now,
+		}
+		if len(knownRuns) > 0 {
+			run = knownRuns[0]
+		}
+		run.Namespace = namespaceSpec
+		run.SLAMissedAt = now
+		run.UpdatedAt = now
+		if err := jobRunRepo.Save(run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jobEventTime parses a RFC3339 time sent with the event, zero time is
+// returned if key is not sent
+func jobEventTime(jobEvent models.JobEvent, key string) (time.Time, error) {
+	value, ok := jobEvent.Value[key]
+	if !ok || value.GetStringValue() == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value.GetStringValue())
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid %s", key)
+	}
+	return t, nil
+}
+
+func (sv *RuntimeServiceServer) GetWindow(ctx context.Context, req *pb.GetWindowRequest) (*pb.GetWindowResponse, error) {
+	scheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
+	if err != nil {
+		return nil, invalidArgumentf("scheduled_at", err, "%s: failed to parse schedule time %s", err.Error(), req.GetScheduledAt())
+	}
+
+	if req.GetPreset() == "" && (req.GetSize() == "" || req.GetOffset() == "" || req.GetTruncateTo() == "") {
+		return nil, invalidArgumentf("size", nil, "window preset or size, offset and truncate_to must be provided")
+	}
+
+	window, err := prepareWindow(req.GetSize(), req.GetOffset(), req.GetTruncateTo(), req.GetPreset(), req.GetModifier())
+	if err != nil {
+		if errors.Is(err, models.ErrUnknownWindowPreset) {
+			return nil, invalidArgumentf("preset", err, "%s", err.Error())
+		}
+		return nil, invalidArgumentf("size", err, "%s", err.Error())
+	}
+
+	// weekends, holidays and fiscal year of the project if provided
+	if req.GetProjectName() != "" {
+		projSpec, err := sv.projectRepoFactory.New().GetByName(req.GetProjectName())
+		if err != nil {
+			return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+		}
+		if window.Calendar, err = projSpec.Calendar(); err != nil {
+			return nil, statusErrorf(codes.FailedPrecondition, err, "%s: invalid calendar of project %s", err.Error(), req.GetProjectName())
+		}
+	}
+
+	if err := utils.TimezoneValidator(req.GetTimezone(), ""); err != nil {
+		return nil, invalidArgumentf("timezone", err, "%s", err.Error())
+	}
+	// window is truncated at day boundaries of the timezone
+	scheduledTime = scheduledTime.In(models.JobSpecSchedule{Timezone: req.GetTimezone()}.Location())
+
+	windowStart := timestamppb.New(window.GetStart(scheduledTime))
+	windowEnd := timestamppb.New(window.GetEnd(scheduledTime))
+
+	return &pb.GetWindowResponse{
+		Start: windowStart,
+		End:   windowEnd,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListSupportedTasks(ctx context.Context, req *pb.ListSupportedTasksRequest) (*pb.ListSupportedTasksResponse, error) {
+	tasks, err := sv.pluginSpecs(ctx, sv.pluginRepo.GetTasks())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListSupportedTasksResponse{
+		Tasks: tasks,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListSupportedHooks(ctx context.Context, req *pb.ListSupportedHooksRequest) (*pb.ListSupportedHooksResponse, error) {
+	hooks, err := sv.pluginSpecs(ctx, sv.pluginRepo.GetHooks())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListSupportedHooksResponse{
+		Hooks: hooks,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ValidatePluginAnswers(ctx context.Context, req *pb.ValidatePluginAnswersRequest) (*pb.ValidatePluginAnswersResponse, error) {
+	var plugin *models.Plugin
+	var err error
+	if req.GetPluginVersion() != "" {
+		plugin, err = sv.pluginRepo.GetByNameAndVersion(req.GetPluginName(), req.GetPluginVersion())
+	} else {
+		plugin, err = sv.pluginRepo.GetByName(req.GetPluginName())
+	}
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to find plugin %s", err.Error(), req.GetPluginName())
+	}
+	if plugin.CLIMod == nil {
+		return nil, invalidArgumentf("plugin_name", nil, "plugin %s doesn't ask any questions", req.GetPluginName())
+	}
+
+	questionsResp, err := plugin.CLIMod.GetQuestions(ctx, models.GetQuestionsRequest{
+		PluginOptions: models.PluginOptions{DryRun: true},
+	})
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to get questions of plugin %s", err.Error(), req.GetPluginName())
+	}
+	answerErrors, err := validatePluginAnswers(ctx, plugin.CLIMod, questionsResp.Questions, req.GetAnswers())
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to validate answers of plugin %s", err.Error(), req.GetPluginName())
+	}
+	return &pb.ValidatePluginAnswersResponse{
+		Success: len(answerErrors) == 0,
+		Errors:  answerErrors,
+	}, nil
+}
+
+// validatePluginAnswers checks answers the same way cli does while asking
+// questions, default of question is used if it isn't answered and sub
+// questions are checked only if their condition matches the answer
+func validatePluginAnswers(ctx context.Context, cliMod models.CommandLineMod, questions models.PluginQuestions,
+	answers map[string]string) ([]*pb.PluginAnswerError, error) {
+	answerErrors := []*pb.PluginAnswerError{}
+	for _, question := range questions {
+		value, ok := answers[question.Name]
+		if !ok {
+			value = question.Default
+		}
+		if err := question.IsValid(value); err != nil {
+			answerErrors = append(answerErrors, &pb.PluginAnswerError{
+				Name:    question.Name,
+				Message: err.Error(),
+			})
+			continue
+		}
+		resp, err := cliMod.ValidateQuestion(ctx, models.ValidateQuestionRequest{
+			PluginOptions: models.PluginOptions{DryRun: true},
+			Answer: models.PluginAnswer{
+				Question: question,
+				Value:    value,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			answerErrors = append(answerErrors, &pb.PluginAnswerError{
+				Name:    question.Name,
+				Message: resp.Error,
+			})
+			continue
+		}
+
+		for _, subQuestion := range question.SubQuestions {
+			if value != subQuestion.IfValue {
+				continue
+			}
+			subErrors, err := validatePluginAnswers(ctx, cliMod, subQuestion.Questions, answers)
+			if err != nil {
+				return nil, err
+			}
+			answerErrors = append(answerErrors, subErrors...)
+		}
+	}
+	return answerErrors, nil
+}
+
+// pluginSpecs converts plugins sorted by name along with the questions of
+// their cli mod, clients use them to render inputs of a new job
+func (sv *RuntimeServiceServer) pluginSpecs(ctx context.Context, plugins []*models.Plugin) ([]*pb.PluginSpecification, error) {
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Info().Name < plugins[j].Info().Name
+	})
+	specs := []*pb.PluginSpecification{}
+	for _, plugin := range plugins {
+		var questions models.PluginQuestions
+		if plugin.CLIMod != nil {
+			resp, err := plugin.CLIMod.GetQuestions(ctx, models.GetQuestionsRequest{
+				PluginOptions: models.PluginOptions{DryRun: true},
+			})
+			if err != nil {
+				return nil, statusErrorf(codes.Internal, err, "%s: failed to get questions of plugin %s", err.Error(), plugin.Info().Name)
+			}
+			questions = resp.Questions
+		}
+		specs = append(specs, toPluginProto(plugin.Info(), questions))
+	}
+	return specs, nil
+}
+
+func (sv *RuntimeServiceServer) RegisterSecret(ctx context.Context, req *pb.RegisterSecretRequest) (*pb.RegisterSecretResponse, error) {
+	if req.GetValue() == "" {
+		return nil, invalidArgumentf("value", nil, "empty value for secret")
+	}
+	// decode base64
+	base64Decoded, err := base64.StdEncoding.DecodeString(req.GetValue())
+	if err != nil {
+		return nil, invalidArgumentf("value", err, "%s: failed to decode base64 string", err.Error())
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	secretRepo := sv.secretRepoFactory.New(projSpec)
+	if err := secretRepo.Save(models.ProjectSecretItem{
+		Name:  req.GetSecretName(),
+		Value: string(base64Decoded),
+	}); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to save secret %s", err.Error(), req.GetSecretName())
+	}
+
+	return &pb.RegisterSecretResponse{
+		Success: true,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) UpdateSecret(ctx context.Context, req *pb.UpdateSecretRequest) (*pb.UpdateSecretResponse, error) {
+	if req.GetValue() == "" {
+		return nil, invalidArgumentf("value", nil, "empty value for secret")
+	}
+	// decode base64
+	base64Decoded, err := base64.StdEncoding.DecodeString(req.GetValue())
+	if err != nil {
+		return nil, invalidArgumentf("value", err, "%s: failed to decode base64 string", err.Error())
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	secretRepo := sv.secretRepoFactory.New(projSpec)
+	if _, err := secretRepo.GetByName(req.GetSecretName()); err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: secret %s not found", err.Error(), req.GetSecretName())
+	}
+	if err := secretRepo.Save(models.ProjectSecretItem{
+		Name:  req.GetSecretName(),
+		Value: string(base64Decoded),
+	}); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to update secret %s", err.Error(), req.GetSecretName())
+	}
+
+	return &pb.UpdateSecretResponse{
+		Success: true,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListSecretKeys(ctx context.Context, req *pb.ListSecretKeysRequest) (*pb.ListSecretKeysResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	secrets, err := sv.secretRepoFactory.New(projSpec).GetAll()
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve secrets", err.Error())
+	}
+	var secretNames []string
+	for _, secret := range secrets {
+		secretNames = append(secretNames, secret.Name)
+	}
+	sort.Strings(secretNames)
+
+	return &pb.ListSecretKeysResponse{
+		SecretNames: secretNames,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) CreateRoleBinding(ctx context.Context, req *pb.CreateRoleBindingRequest) (*pb.CreateRoleBindingResponse, error) {
+	binding := req.GetBinding()
+	if binding.GetSubject() == "" {
+		return nil, invalidArgumentf("binding.subject", nil, "subject cannot be empty")
+	}
+	if !models.IsValidRole(binding.GetRole()) {
+		return nil, invalidArgumentf("binding.role", nil, "unknown role %s, should be one of %s, %s, %s",
+			binding.GetRole(), models.RoleViewer, models.RoleEditor, models.RoleAdmin)
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+	if binding.GetNamespace() != "" {
+		if _, err := sv.namespaceRepoFactory.New(projSpec).GetByName(binding.GetNamespace()); err != nil {
+			return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), binding.GetNamespace())
+		}
+	}
+
+	if err := sv.roleBindingRepoFactory.New(projSpec).Save(models.RoleBinding{
+		Subject:   binding.GetSubject(),
+		Role:      binding.GetRole(),
+		Namespace: binding.GetNamespace(),
+	}); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to save role binding of %s", err.Error(), binding.GetSubject())
 	}
 
-	return &pb.DeleteJobSpecificationResponse{
+	return &pb.CreateRoleBindingResponse{
 		Success: true,
-		Message: fmt.Sprintf("job %s has been deleted", jobSpecToDelete.Name),
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+func (sv *RuntimeServiceServer) DeleteRoleBinding(ctx context.Context, req *pb.DeleteRoleBindingRequest) (*pb.DeleteRoleBindingResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
-	projects, err := projectRepo.GetAll()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to retrieve saved projects", err.Error())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	projSpecsProto := []*pb.ProjectSpecification{}
-	for _, project := range projects {
-		projSpecsProto = append(projSpecsProto, sv.adapter.ToProjectProto(project))
+	if err := sv.roleBindingRepoFactory.New(projSpec).Delete(req.GetSubject(), req.GetNamespace()); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to delete role binding of %s", err.Error(), req.GetSubject())
 	}
 
-	return &pb.ListProjectsResponse{
-		Projects: projSpecsProto,
+	return &pb.DeleteRoleBindingResponse{
+		Success: true,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) ListProjectNamespaces(ctx context.Context, req *pb.ListProjectNamespacesRequest) (*pb.ListProjectNamespacesResponse, error) {
+func (sv *RuntimeServiceServer) ListRoleBindings(ctx context.Context, req *pb.ListRoleBindingsRequest) (*pb.ListRoleBindingsResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
-	namespaceSpecs, err := namespaceRepo.GetAll()
+	bindings, err := sv.roleBindingRepoFactory.New(projSpec).GetAll()
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: error while fetching namespaces", err.Error())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve role bindings", err.Error())
 	}
-
-	namespaceSpecsProto := []*pb.NamespaceSpecification{}
-	for _, namespace := range namespaceSpecs {
-		namespaceSpecsProto = append(namespaceSpecsProto, sv.adapter.ToNamespaceProto(namespace))
+	bindingProtos := []*pb.RoleBindingSpecification{}
+	for _, binding := range bindings {
+		bindingProtos = append(bindingProtos, &pb.RoleBindingSpecification{
+			Subject:   binding.Subject,
+			Role:      binding.Role,
+			Namespace: binding.Namespace,
+		})
 	}
 
-	return &pb.ListProjectNamespacesResponse{
-		Namespaces: namespaceSpecsProto,
+	return &pb.ListRoleBindingsResponse{
+		Bindings: bindingProtos,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) RegisterInstance(ctx context.Context, req *pb.RegisterInstanceRequest) (*pb.RegisterInstanceResponse, error) {
-	jobScheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse schedule time of job %s", err.Error(), req.GetScheduledAt())
+func (sv *RuntimeServiceServer) RegisterMacro(ctx context.Context, req *pb.RegisterMacroRequest) (*pb.RegisterMacroResponse, error) {
+	macro := models.Macro{
+		Name:        req.GetMacro().GetName(),
+		Type:        req.GetMacro().GetType(),
+		Default:     req.GetMacro().GetDefault(),
+		Description: req.GetMacro().GetDescription(),
+		Namespace:   req.GetMacro().GetNamespace(),
+	}
+	if err := macro.Validate(); err != nil {
+		return nil, invalidArgumentf("macro", err, "%s", err.Error())
 	}
 
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+	if macro.Namespace != "" {
+		if _, err := sv.namespaceRepoFactory.New(projSpec).GetByName(macro.Namespace); err != nil {
+			return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), macro.Namespace)
+		}
 	}
 
-	jobSpec, namespaceSpec, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: job %s not found", err.Error(), req.GetJobName())
+	if err := sv.macroRepoFactory.New(projSpec).Save(macro); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to save macro %s", err.Error(), macro.Name)
 	}
-	jobProto, err := sv.adapter.ToJobProto(jobSpec)
+
+	return &pb.RegisterMacroResponse{
+		Success: true,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) DeleteMacro(ctx context.Context, req *pb.DeleteMacroRequest) (*pb.DeleteMacroResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: cannot adapt job %s", err.Error(), jobSpec.Name)
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	instanceType, err := models.InstanceType("").New(req.InstanceType.String())
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "%s: instance type %s not found", err.Error(), req.InstanceType.String())
+	if err := sv.macroRepoFactory.New(projSpec).Delete(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to delete macro %s", err.Error(), req.GetName())
 	}
-	instance, err := sv.instSvc.Register(jobSpec, jobScheduledTime, instanceType)
+
+	return &pb.DeleteMacroResponse{
+		Success: true,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListMacros(ctx context.Context, req *pb.ListMacrosRequest) (*pb.ListMacrosResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to register instance of job %s", err.Error(), req.GetJobName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
-	envMap, fileMap, err := sv.instSvc.Compile(namespaceSpec, jobSpec, instance, instanceType, req.InstanceName)
+
+	macros, err := sv.macroRepoFactory.New(projSpec).GetAll()
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to compile instance of job %s", err.Error(), req.GetJobName())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve macros", err.Error())
+	}
+	macroProtos := []*pb.MacroSpecification{}
+	for _, macro := range macros {
+		if macro.Namespace != "" && macro.Namespace != req.GetNamespace() {
+			continue
+		}
+		macroProtos = append(macroProtos, &pb.MacroSpecification{
+			Name:        macro.Name,
+			Type:        macro.GetType(),
+			Default:     macro.Default,
+			Description: macro.Description,
+			Namespace:   macro.Namespace,
+		})
 	}
 
-	instanceProto, err := sv.adapter.ToInstanceProto(instance)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: cannot adapt instance for job %s", err.Error(), jobSpec.Name)
+	return &pb.ListMacrosResponse{
+		Macros: macroProtos,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	if _, err := projectRepo.GetByName(req.GetProjectName()); err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	filter := models.AuditEventFilter{
+		ProjectName: req.GetProjectName(),
+		Namespace:   req.GetNamespace(),
+		Actor:       req.GetActor(),
+		Method:      req.GetMethod(),
+		Limit:       int(req.GetPageSize()),
+	}
+	if req.GetSince() != nil {
+		filter.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		filter.Until = req.GetUntil().AsTime()
+	}
+	events, err := sv.auditEventRepo.GetAll(filter)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve audit events", err.Error())
+	}
+	eventProtos := []*pb.AuditEventSpecification{}
+	for _, event := range events {
+		eventProtos = append(eventProtos, &pb.AuditEventSpecification{
+			Id:          event.ID.String(),
+			ProjectName: event.ProjectName,
+			Namespace:   event.Namespace,
+			Method:      event.Method,
+			Actor:       event.Actor,
+			Summary:     event.Summary,
+			Success:     event.Success,
+			Error:       event.Error,
+			CreatedAt:   timestamppb.New(event.CreatedAt),
+		})
 	}
-	return &pb.RegisterInstanceResponse{
-		Project:   sv.adapter.ToProjectProto(projSpec),
-		Job:       jobProto,
-		Instance:  instanceProto,
-		Namespace: sv.adapter.ToNamespaceProto(namespaceSpec),
-		Context: &pb.InstanceContext{
-			Envs:  envMap,
-			Files: fileMap,
-		},
+
+	return &pb.ListAuditEventsResponse{
+		Events: eventProtos,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) JobStatus(ctx context.Context, req *pb.JobStatusRequest) (*pb.JobStatusResponse, error) {
+func (sv *RuntimeServiceServer) CreateResource(ctx context.Context, req *pb.CreateResourceRequest) (*pb.CreateResourceResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	_, _, err = sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to find the job %s for project %s", err.Error(),
-			req.GetJobName(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	jobStatuses, err := sv.scheduler.GetJobStatus(ctx, projSpec, req.GetJobName())
+	optResource, err := sv.adapter.FromResourceProto(req.Resource, req.DatastoreName)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to fetch jobStatus %s", err.Error(),
-			req.GetJobName())
+		return nil, invalidArgumentf("resource", err, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
 	}
 
-	var adaptedJobStatus []*pb.JobStatus
-	for _, jobStatus := range jobStatuses {
-		ts := timestamppb.New(jobStatus.ScheduledAt)
-		adaptedJobStatus = append(adaptedJobStatus, &pb.JobStatus{
-			State:       jobStatus.State.String(),
-			ScheduledAt: ts,
-		})
+	if err := sv.resourceSvc.CreateResource(ctx, namespaceSpec, []models.ResourceSpec{optResource}, sv.progressObserver); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
 	}
-	return &pb.JobStatusResponse{
-		Statuses: adaptedJobStatus,
+	return &pb.CreateResourceResponse{
+		Success: true,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) RegisterJobEvent(ctx context.Context, req *pb.RegisterJobEventRequest) (*pb.RegisterJobEventResponse, error) {
+func (sv *RuntimeServiceServer) UpdateResource(ctx context.Context, req *pb.UpdateResourceRequest) (*pb.UpdateResourceResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
+	optResource, err := sv.adapter.FromResourceProto(req.Resource, req.DatastoreName)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to find the job %s for namespace %s", err.Error(),
-			req.GetJobName(), req.GetNamespace())
-	}
-
-	if req.GetEvent() == nil {
-		return nil, status.Error(codes.InvalidArgument, "missing required job event values")
+		return nil, invalidArgumentf("resource", err, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
 	}
 
-	eventValues := map[string]*structpb.Value{}
-	if req.GetEvent().Value != nil {
-		eventValues = req.GetEvent().Value.GetFields()
-	}
-	if err := sv.jobEventSvc.Register(ctx, namespaceSpec, jobSpec, models.JobEvent{
-		Type:  models.JobEventType(strings.ToLower(req.GetEvent().Type.String())),
-		Value: eventValues,
-	}); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to register event: %s", err)
+	if err := sv.resourceSvc.UpdateResource(ctx, namespaceSpec, []models.ResourceSpec{optResource}, sv.progressObserver); err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
 	}
-
-	return &pb.RegisterJobEventResponse{}, nil
+	return &pb.UpdateResourceResponse{
+		Success: true,
+	}, nil
 }
 
-func (sv *RuntimeServiceServer) GetWindow(ctx context.Context, req *pb.GetWindowRequest) (*pb.GetWindowResponse, error) {
-	scheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
+func (sv *RuntimeServiceServer) ReadResource(ctx context.Context, req *pb.ReadResourceRequest) (*pb.ReadResourceResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to parse schedule time %s", err.Error(), req.GetScheduledAt())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	if req.GetSize() == "" || req.GetOffset() == "" || req.GetTruncateTo() == "" {
-		return nil, status.Error(codes.InvalidArgument, "window size, offset and truncate_to must be provided")
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	window, err := prepareWindow(req.GetSize(), req.GetOffset(), req.GetTruncateTo())
+	response, err := sv.resourceSvc.ReadResource(ctx, namespaceSpec, req.DatastoreName, req.ResourceName)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to read resource %s", err.Error(), req.ResourceName)
 	}
 
-	windowStart := timestamppb.New(window.GetStart(scheduledTime))
-	windowEnd := timestamppb.New(window.GetEnd(scheduledTime))
+	protoResource, err := sv.adapter.ToResourceProto(response)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to adapt resource %s", err.Error(), req.ResourceName)
+	}
 
-	return &pb.GetWindowResponse{
-		Start: windowStart,
-		End:   windowEnd,
+	return &pb.ReadResourceResponse{
+		Success:  true,
+		Resource: protoResource,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) RegisterSecret(ctx context.Context, req *pb.RegisterSecretRequest) (*pb.RegisterSecretResponse, error) {
-	if req.GetValue() == "" {
-		return nil, status.Error(codes.Internal, "empty value for secret")
-	}
-	// decode base64
-	base64Decoded, err := base64.StdEncoding.DecodeString(req.GetValue())
+func (sv *RuntimeServiceServer) CheckResource(ctx context.Context, req *pb.CheckResourceRequest) (*pb.CheckResourceResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to decode base64 string", err.Error())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	projectRepo := sv.projectRepoFactory.New()
-	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	secretRepo := sv.secretRepoFactory.New(projSpec)
-	if err := secretRepo.Save(models.ProjectSecretItem{
-		Name:  req.GetSecretName(),
-		Value: string(base64Decoded),
-	}); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to save secret %s", err.Error(), req.GetSecretName())
+	resp, err := sv.resourceSvc.CheckResource(ctx, namespaceSpec, req.GetDatastoreName(), req.GetResourceName())
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, statusErrorf(codes.NotFound, err, "%s: resource %s not found", err.Error(), req.GetResourceName())
+		}
+		if errors.Is(err, models.ErrUnsupportedResource) {
+			return nil, statusErrorf(codes.FailedPrecondition, err, "%s: failed to check resource %s", err.Error(), req.GetResourceName())
+		}
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to check resource %s", err.Error(), req.GetResourceName())
 	}
 
-	return &pb.RegisterSecretResponse{
-		Success: true,
+	var drifts []*pb.ResourceDrift
+	for _, drift := range resp.Drifts {
+		drifts = append(drifts, &pb.ResourceDrift{
+			Field:        drift.Field,
+			Declared:     drift.Declared,
+			Live:         drift.Live,
+			Incompatible: drift.Incompatible,
+		})
+	}
+	return &pb.CheckResourceResponse{
+		Drifts:       drifts,
+		Incompatible: resp.Incompatible(),
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) CreateResource(ctx context.Context, req *pb.CreateResourceRequest) (*pb.CreateResourceResponse, error) {
+func (sv *RuntimeServiceServer) BackupResource(ctx context.Context, req *pb.BackupResourceRequest) (*pb.BackupResourceResponse, error) {
+	if sv.Backups == nil {
+		return nil, statusErrorf(codes.Unimplemented, nil, "backups of resources are not served")
+	}
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	optResource, err := sv.adapter.FromResourceProto(req.Resource, req.DatastoreName)
+	resp, err := sv.Backups.Backup(ctx, models.BackupRequest{
+		ResourceName:     req.GetResourceName(),
+		Namespace:        namespaceSpec,
+		Datastore:        req.GetDatastoreName(),
+		Description:      req.GetDescription(),
+		IgnoreDownstream: req.GetIgnoreDownstream(),
+		Config:           req.GetConfig(),
+		DryRun:           req.GetDryRun(),
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
-	}
-
-	if err := sv.resourceSvc.CreateResource(ctx, namespaceSpec, []models.ResourceSpec{optResource}, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, statusErrorf(codes.NotFound, err, "%s: resource %s not found", err.Error(), req.GetResourceName())
+		}
+		if errors.Is(err, models.ErrUnsupportedResource) {
+			return nil, statusErrorf(codes.FailedPrecondition, err, "%s: failed to backup resource %s", err.Error(), req.GetResourceName())
+		}
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to backup resource %s", err.Error(), req.GetResourceName())
 	}
-	return &pb.CreateResourceResponse{
-		Success: true,
+	return &pb.BackupResourceResponse{
+		Success:          true,
+		Backup:           toBackupProto(resp.Backup),
+		IgnoredResources: resp.IgnoredResources,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) UpdateResource(ctx context.Context, req *pb.UpdateResourceRequest) (*pb.UpdateResourceResponse, error) {
+func (sv *RuntimeServiceServer) ListBackups(ctx context.Context, req *pb.ListBackupsRequest) (*pb.ListBackupsResponse, error) {
+	if sv.Backups == nil {
+		return nil, statusErrorf(codes.Unimplemented, nil, "backups of resources are not served")
+	}
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	optResource, err := sv.adapter.FromResourceProto(req.Resource, req.DatastoreName)
+	backups, err := sv.Backups.List(ctx, namespaceSpec, req.GetDatastoreName())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to list backups of %s", err.Error(), req.GetDatastoreName())
 	}
-
-	if err := sv.resourceSvc.UpdateResource(ctx, namespaceSpec, []models.ResourceSpec{optResource}, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
+	var protoBackups []*pb.BackupSpec
+	for _, backup := range backups {
+		protoBackups = append(protoBackups, toBackupProto(backup))
 	}
-	return &pb.UpdateResourceResponse{
-		Success: true,
+	return &pb.ListBackupsResponse{
+		Backups: protoBackups,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) ReadResource(ctx context.Context, req *pb.ReadResourceRequest) (*pb.ReadResourceResponse, error) {
+func (sv *RuntimeServiceServer) RestoreBackup(ctx context.Context, req *pb.RestoreBackupRequest) (*pb.RestoreBackupResponse, error) {
+	if sv.Backups == nil {
+		return nil, statusErrorf(codes.Unimplemented, nil, "backups of resources are not served")
+	}
+	backupID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgumentf("id", err, "%s: invalid backup id %s", err.Error(), req.GetId())
+	}
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	response, err := sv.resourceSvc.ReadResource(ctx, namespaceSpec, req.DatastoreName, req.ResourceName)
+	backup, err := sv.Backups.Restore(ctx, namespaceSpec, req.GetDatastoreName(), backupID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to read resource %s", err.Error(), req.ResourceName)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, statusErrorf(codes.NotFound, err, "%s: backup %s not found", err.Error(), req.GetId())
+		}
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to restore backup %s", err.Error(), req.GetId())
 	}
+	return &pb.RestoreBackupResponse{
+		Success: true,
+		Backup:  toBackupProto(backup),
+	}, nil
+}
 
-	protoResource, err := sv.adapter.ToResourceProto(response)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to adapt resource %s", err.Error(), req.ResourceName)
+func toBackupProto(backup models.BackupSpec) *pb.BackupSpec {
+	var results []*pb.BackupResult
+	for _, result := range backup.Results {
+		results = append(results, &pb.BackupResult{
+			ResourceName: result.ResourceName,
+			Urn:          result.URN,
+		})
+	}
+	return &pb.BackupSpec{
+		Id:            backup.ID.String(),
+		ResourceName:  backup.ResourceName,
+		DatastoreName: backup.Datastore,
+		Description:   backup.Description,
+		CreatedAt:     timestamppb.New(backup.CreatedAt),
+		Results:       results,
+		Config:        backup.Config,
 	}
-
-	return &pb.ReadResourceResponse{
-		Success:  true,
-		Resource: protoResource,
-	}, nil
 }
 
 func (sv *RuntimeServiceServer) DeployResourceSpecification(req *pb.DeployResourceSpecificationRequest, respStream pb.RuntimeService_DeployResourceSpecificationServer) error {
@@ -698,20 +2259,20 @@ func (sv *RuntimeServiceServer) DeployResourceSpecification(req *pb.DeployResour
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	var resourceSpecs []models.ResourceSpec
 	for _, resourceProto := range req.GetResources() {
 		adapted, err := sv.adapter.FromResourceProto(resourceProto, req.DatastoreName)
 		if err != nil {
-			return status.Errorf(codes.Internal, "%s: cannot adapt resource %s", err.Error(), resourceProto.GetName())
+			return invalidArgumentf("resources", err, "%s: cannot adapt resource %s", err.Error(), resourceProto.GetName())
 		}
 		resourceSpecs = append(resourceSpecs, adapted)
 	}
@@ -724,7 +2285,7 @@ func (sv *RuntimeServiceServer) DeployResourceSpecification(req *pb.DeployResour
 	})
 
 	if err := sv.resourceSvc.UpdateResource(respStream.Context(), namespaceSpec, resourceSpecs, observers); err != nil {
-		return status.Errorf(codes.Internal, "failed to update resources:\n%s", err.Error())
+		return statusErrorf(codes.Internal, err, "failed to update resources:\n%s", err.Error())
 	}
 	logger.I("finished resource deployment in", time.Since(startTime))
 	return nil
@@ -734,25 +2295,25 @@ func (sv *RuntimeServiceServer) ListResourceSpecification(ctx context.Context, r
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	resourceSpecs, err := sv.resourceSvc.GetAll(namespaceSpec, req.DatastoreName)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
 	}
 
 	resourceProtos := []*pb.ResourceSpecification{}
 	for _, resourceSpec := range resourceSpecs {
 		resourceProto, err := sv.adapter.ToResourceProto(resourceSpec)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "%s: failed to parse job spec %s", err.Error(), resourceSpec.Name)
+			return nil, statusErrorf(codes.Internal, err, "%s: failed to parse job spec %s", err.Error(), resourceSpec.Name)
 		}
 		resourceProtos = append(resourceProtos, resourceProto)
 	}
@@ -769,12 +2330,12 @@ func (sv *RuntimeServiceServer) ReplayDryRun(ctx context.Context, req *pb.Replay
 
 	rootNode, err := sv.jobSvc.ReplayDryRun(replayWorkerRequest)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error while processing replay dry run: %v", err)
+		return nil, statusErrorf(codes.Internal, err, "error while processing replay dry run: %v", err)
 	}
 
 	node, err := sv.adapter.ToReplayExecutionTreeNode(rootNode)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error while preparing replay dry run response: %v", err)
+		return nil, statusErrorf(codes.Internal, err, "error while preparing replay dry run response: %v", err)
 	}
 	return &pb.ReplayDryRunResponse{
 		Success:  true,
@@ -788,14 +2349,26 @@ func (sv *RuntimeServiceServer) Replay(ctx context.Context, req *pb.ReplayReques
 		return nil, err
 	}
 
+	if req.GetDryRun() {
+		rootNode, err := sv.jobSvc.ReplayDryRun(replayWorkerRequest)
+		if err != nil {
+			return nil, statusErrorf(codes.Internal, err, "error while processing replay dry run: %v", err)
+		}
+		jobRuns, runCount := sv.adapter.ToReplayJobRuns(rootNode)
+		return &pb.ReplayResponse{
+			JobRuns:  jobRuns,
+			RunCount: runCount,
+		}, nil
+	}
+
 	replayUUID, err := sv.jobSvc.Replay(ctx, replayWorkerRequest)
 	if err != nil {
 		if errors.Is(err, job.ErrRequestQueueFull) {
-			return nil, status.Errorf(codes.Unavailable, "error while processing replay: %v", err)
+			return nil, statusErrorf(codes.Unavailable, err, "error while processing replay: %v", err)
 		} else if errors.Is(err, job.ErrConflictedJobRun) {
-			return nil, status.Errorf(codes.FailedPrecondition, "error while validating replay: %v", err)
+			return nil, statusErrorf(codes.FailedPrecondition, err, "error while validating replay: %v", err)
 		}
-		return nil, status.Errorf(codes.Internal, "error while processing replay: %v", err)
+		return nil, statusErrorf(codes.Internal, err, "error while processing replay: %v", err)
 	}
 
 	return &pb.ReplayResponse{
@@ -803,38 +2376,127 @@ func (sv *RuntimeServiceServer) Replay(ctx context.Context, req *pb.ReplayReques
 	}, nil
 }
 
+func (sv *RuntimeServiceServer) ListReplays(ctx context.Context, req *pb.ListReplaysRequest) (*pb.ListReplaysResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	replaySpecs, err := sv.jobSvc.GetReplayList(projSpec.ID)
+	if err != nil {
+		return nil, statusErrorf(codes.Internal, err, "%s: failed to list replays of project %s", err.Error(), req.GetProjectName())
+	}
+
+	replays := []*pb.ReplaySpec{}
+	for _, replaySpec := range replaySpecs {
+		replays = append(replays, sv.adapter.ToReplaySpecProto(replaySpec))
+	}
+	return &pb.ListReplaysResponse{
+		Replays: replays,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ReplayStatus(req *pb.ReplayStatusRequest, respStream pb.RuntimeService_ReplayStatusServer) error {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
+	if err != nil {
+		return statusErrorf(codes.NotFound, err, "%s: failed to find the job %s for namespace %s", err.Error(),
+			req.GetJobName(), req.GetNamespace())
+	}
+
+	replayID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return invalidArgumentf("id", err, "%s: invalid replay id %s", err.Error(), req.GetId())
+	}
+
+	pollInterval := sv.ReplayPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultReplayPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// replays are read till they end, and sent whenever they change
+	var lastSent *pb.ReplayStatusResponse
+	for {
+		replaySpec, err := sv.jobSvc.GetReplay(jobSpec, replayID)
+		if err != nil {
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return statusErrorf(codes.NotFound, err, "%s: replay %s not found", err.Error(), req.GetId())
+			}
+			return statusErrorf(codes.Internal, err, "%s: failed to read replay %s", err.Error(), req.GetId())
+		}
+
+		resp := &pb.ReplayStatusResponse{
+			Id:          replaySpec.ID.String(),
+			Status:      replaySpec.Status,
+			MessageType: replaySpec.Message.Type,
+			Message:     replaySpec.Message.Message,
+		}
+		if lastSent == nil || lastSent.Status != resp.Status || lastSent.Message != resp.Message {
+			if err := respStream.Send(resp); err != nil {
+				return err
+			}
+			lastSent = resp
+		}
+
+		switch replaySpec.Status {
+		case models.ReplayStatusSuccess, models.ReplayStatusFailed, models.ReplayStatusCancelled:
+			return nil
+		}
+
+		select {
+		case <-respStream.Context().Done():
+			return statusErrorf(codes.Canceled, respStream.Context().Err(), "stopped streaming status of replay %s", req.GetId())
+		case <-ticker.C:
+		}
+	}
+}
+
 func (sv *RuntimeServiceServer) parseReplayRequest(req *pb.ReplayRequest) (*models.ReplayWorkerRequest, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, statusErrorf(codes.NotFound, err, "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, statusErrorf(codes.NotFound, err, "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to find the job %s for namespace %s", err.Error(),
+		return nil, statusErrorf(codes.NotFound, err, "%s: failed to find the job %s for namespace %s", err.Error(),
 			req.GetJobName(), req.GetNamespace())
 	}
 
 	startDate, err := time.Parse(job.ReplayDateFormat, req.StartDate)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "unable to parse replay start date(e.g. %s): %v", job.ReplayDateFormat, err)
+		return nil, invalidArgumentf("start_date", err, "unable to parse replay start date(e.g. %s): %v", job.ReplayDateFormat, err)
 	}
 
 	endDate := startDate
 	if req.EndDate != "" {
 		if endDate, err = time.Parse(job.ReplayDateFormat, req.EndDate); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "unable to parse replay end date(e.g. %s): %v", job.ReplayDateFormat, err)
+			return nil, invalidArgumentf("end_date", err, "unable to parse replay end date(e.g. %s): %v", job.ReplayDateFormat, err)
 		}
 	}
 	if endDate.Before(startDate) {
-		return nil, status.Errorf(codes.InvalidArgument, "replay end date cannot be before start date")
+		return nil, invalidArgumentf("end_date", nil, "replay end date cannot be before start date")
 	}
 	replayRequest := models.ReplayWorkerRequest{
 		Job:     jobSpec,
@@ -858,30 +2520,61 @@ func NewRuntimeServiceServer(
 	progressObserver progress.Observer,
 	instSvc models.InstanceService,
 	scheduler models.SchedulerUnit,
+	deployManager job.DeployManager,
+	roleBindingRepoFactory RoleBindingRepoFactory,
+	auditEventRepo store.AuditEventRepository,
+	macroRepoFactory MacroRepoFactory,
+	pluginRepo models.PluginRepository,
 ) *RuntimeServiceServer {
 	return &RuntimeServiceServer{
-		version:              version,
-		jobSvc:               jobSvc,
-		jobEventSvc:          jobEventService,
-		resourceSvc:          datastoreSvc,
-		adapter:              adapter,
-		projectRepoFactory:   projectRepoFactory,
-		namespaceRepoFactory: namespaceRepoFactory,
-		progressObserver:     progressObserver,
-		instSvc:              instSvc,
-		scheduler:            scheduler,
-		secretRepoFactory:    secretRepoFactory,
+		version:                version,
+		jobSvc:                 jobSvc,
+		jobEventSvc:            jobEventService,
+		resourceSvc:            datastoreSvc,
+		adapter:                adapter,
+		projectRepoFactory:     projectRepoFactory,
+		namespaceRepoFactory:   namespaceRepoFactory,
+		progressObserver:       progressObserver,
+		instSvc:                instSvc,
+		scheduler:              scheduler,
+		deployManager:          deployManager,
+		secretRepoFactory:      secretRepoFactory,
+		roleBindingRepoFactory: roleBindingRepoFactory,
+		auditEventRepo:         auditEventRepo,
+		macroRepoFactory:       macroRepoFactory,
+		pluginRepo:             pluginRepo,
+		UploadConcurrency:      DefaultJobUploadConcurrency,
+		UploadBatchSize:        DefaultJobUploadBatchSize,
+		HeartbeatInterval:      DefaultDeployHeartbeatInterval,
 	}
 }
 
 type jobSyncObserver struct {
-	stream pb.RuntimeService_DeployJobSpecificationServer
-	log    logrus.FieldLogger
+	stream interface {
+		Send(*pb.DeployJobSpecificationResponse) error
+	}
+	log logrus.FieldLogger
+
+	// mu serializes sends on stream and guards sync progress
+	mu            sync.Mutex
+	phase         string
+	processedJobs int
+	totalJobs     int
 }
 
 func (obs *jobSyncObserver) Notify(e progress.Event) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
 	switch evt := e.(type) {
+	case *job.EventJobSpecFetch:
+		obs.phase = DeployPhaseResolvingDependencies
+	case *job.EventJobSpecDependencyResolve:
+		obs.phase = DeployPhaseAssigningPriorities
+	case *job.EventJobPriorityWeightAssign:
+		obs.phase = DeployPhaseUploading
 	case *job.EventJobUpload:
+		obs.processedJobs++
 		resp := &pb.DeployJobSpecificationResponse{
 			Success: true,
 			Ack:     true,
@@ -895,6 +2588,22 @@ func (obs *jobSyncObserver) Notify(e progress.Event) {
 		if err := obs.stream.Send(resp); err != nil {
 			obs.log.Error(errors.Wrapf(err, "failed to send deploy spec ack for: %s", evt.Job.Name))
 		}
+	case *job.EventSavedJobDelete:
+		resp := &pb.DeployJobSpecificationResponse{
+			JobName: evt.Name,
+			Message: evt.String(),
+		}
+		if err := obs.stream.Send(resp); err != nil {
+			obs.log.Error(errors.Wrapf(err, "failed to send delete notification for: %s", evt.Name))
+		}
+	case *job.EventJobSpecRevert:
+		resp := &pb.DeployJobSpecificationResponse{
+			JobName: evt.Name,
+			Message: evt.String(),
+		}
+		if err := obs.stream.Send(resp); err != nil {
+			obs.log.Error(errors.Wrapf(err, "failed to send revert notification for: %s", evt.Name))
+		}
 	case *job.EventJobRemoteDelete:
 		resp := &pb.DeployJobSpecificationResponse{
 			JobName: evt.Name,
@@ -911,6 +2620,16 @@ func (obs *jobSyncObserver) Notify(e progress.Event) {
 		if err := obs.stream.Send(resp); err != nil {
 			obs.log.Error(errors.Wrapf(err, "failed to send unknown dependency notification for: %s", evt.Job))
 		}
+	case *job.EventJobSpecDuplicateDestination:
+		for _, jobName := range evt.Jobs {
+			resp := &pb.DeployJobSpecificationResponse{
+				JobName: jobName,
+				Message: evt.String(),
+			}
+			if err := obs.stream.Send(resp); err != nil {
+				obs.log.Error(errors.Wrapf(err, "failed to send duplicate destination notification for: %s", jobName))
+			}
+		}
 	}
 }
 
@@ -964,5 +2683,16 @@ func (obs *jobCheckObserver) Notify(e progress.Event) {
 		if err := obs.stream.Send(resp); err != nil {
 			obs.log.Error(errors.Wrapf(err, "failed to send check ack for: %s", evt.Name))
 		}
+	case *job.EventJobSpecDuplicateDestination:
+		for _, jobName := range evt.Jobs {
+			resp := &pb.CheckJobSpecificationsResponse{
+				Success: true,
+				JobName: jobName,
+				Message: evt.String(),
+			}
+			if err := obs.stream.Send(resp); err != nil {
+				obs.log.Error(errors.Wrapf(err, "failed to send duplicate destination notification for: %s", jobName))
+			}
+		}
 	}
 }
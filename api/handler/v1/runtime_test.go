@@ -1,20 +1,28 @@
 package v1_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/odpf/optimus/core/progress"
 	"github.com/odpf/optimus/core/tree"
 	"github.com/odpf/optimus/job"
 
@@ -31,8 +39,32 @@ import (
 
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/memory"
 )
 
+// secretReaders allows or denies every caller to read secrets
+type secretReaders bool
+
+func (r secretReaders) CanReadSecrets(ctx context.Context, projectName string) bool {
+	return bool(r)
+}
+
+// runLineage records events of runs it is asked to report and fails with err
+type runLineage struct {
+	events []models.JobEvent
+	err    error
+}
+
+func (l *runLineage) EmitRunStarted(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec, scheduledAt time.Time) error {
+	return l.err
+}
+
+func (l *runLineage) EmitRunEvent(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec, evt models.JobEvent) error {
+	l.events = append(l.events, evt)
+	return l.err
+}
+
 func TestRuntimeServiceServer(t *testing.T) {
 	logger.InitWithWriter("INFO", ioutil.Discard)
 
@@ -50,6 +82,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			versionRequest := pb.VersionRequest{Client: Version}
 			resp, err := runtimeServiceServer.Version(context.Background(), &versionRequest)
@@ -175,6 +212,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				instanceService,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			versionRequest := pb.RegisterInstanceRequest{ProjectName: projectName, JobName: jobName,
@@ -232,6 +274,10 @@ func TestRuntimeServiceServer(t *testing.T) {
 			jobService := new(mock.JobService)
 			defer jobService.AssertExpectations(t)
 
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ValidateProject", projectSpec).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"someVersion1.0",
 				jobService, nil, nil,
@@ -242,6 +288,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				v1.NewAdapter(nil, nil),
 				nil,
 				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 			)
 
@@ -263,6 +314,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("Save", projectSpec).Return(nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
@@ -272,6 +324,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 			jobService := new(mock.JobService)
 			defer jobService.AssertExpectations(t)
 
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ValidateProject", projectSpec).Return(nil)
+			scheduler.On("Bootstrap", context.Background(), projectSpec, nil).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"someVersion1.0",
 				jobService, nil, nil,
@@ -282,6 +339,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				v1.NewAdapter(nil, nil),
 				nil,
 				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 			)
 
@@ -330,6 +392,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ValidateProject", projectSpec).Return(nil)
+			scheduler.On("Bootstrap", context.Background(), projectSpec, nil).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"someVersion1.0",
 				jobSvc,
@@ -340,6 +407,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				v1.NewAdapter(nil, nil),
 				nil,
 				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 			)
 
@@ -354,6 +426,95 @@ func TestRuntimeServiceServer(t *testing.T) {
 				Message: "saved successfully",
 			}, resp)
 		})
+		t.Run("should return error if scheduler config of project is invalid", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "some_folder",
+				},
+			}
+			adapter := v1.NewAdapter(nil, nil)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(new(mock.ProjectRepository))
+			defer projectRepoFactory.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ValidateProject", projectSpec).Return(errors.New("invalid bucket"))
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				adapter,
+				nil,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			projectRequest := pb.RegisterProjectRequest{Project: adapter.ToProjectProto(projectSpec)}
+			resp, err := runtimeServiceServer.RegisterProject(context.Background(), &projectRequest)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, resp)
+		})
+		t.Run("should register a project even if scheduler bootstrap fails", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+				},
+			}
+			adapter := v1.NewAdapter(nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("Save", projectSpec).Return(nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			obs := new(mock.PipelineLogObserver)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ValidateProject", projectSpec).Return(nil)
+			scheduler.On("Bootstrap", context.Background(), projectSpec, obs).Return(errors.New("STORAGE secret not configured"))
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				adapter,
+				obs,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			projectRequest := pb.RegisterProjectRequest{Project: adapter.ToProjectProto(projectSpec)}
+			resp, err := runtimeServiceServer.RegisterProject(context.Background(), &projectRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.RegisterProjectResponse{
+				Success: true,
+				Message: "saved successfully, scheduler not bootstrapped: STORAGE secret not configured",
+			}, resp)
+		})
 	})
 
 	t.Run("RegisterProjectNamespace", func(t *testing.T) {
@@ -404,6 +565,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			namespaceRequest := pb.RegisterProjectNamespaceRequest{
@@ -461,6 +627,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			namespaceRequest := pb.RegisterProjectNamespaceRequest{
@@ -565,6 +736,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			jobProto, _ := adapter.ToJobProto(jobSpec)
@@ -628,6 +804,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			secretRequest := pb.RegisterSecretRequest{
@@ -686,6 +867,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			secretRequest := pb.RegisterSecretRequest{
@@ -699,414 +885,342 @@ func TestRuntimeServiceServer(t *testing.T) {
 		})
 	})
 
-	t.Run("DeployJobSpecification", func(t *testing.T) {
-		t.Run("should deploy the job", func(t *testing.T) {
-			Version := "1.0.1"
-
-			projectName := "a-data-project"
-			jobName1 := "a-data-job"
-			taskName := "a-data-task"
-
-			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-			}
-
-			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-				ProjectSpec: projectSpec,
-			}
-
-			execUnit1 := new(mock.BasePlugin)
-			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
-				Name: taskName,
-			}, nil)
-			defer execUnit1.AssertExpectations(t)
-
-			jobSpecs := []models.JobSpec{
-				{
-					Name: jobName1,
-					Task: models.JobSpecTask{
-						Unit: &models.Plugin{
-							Base: execUnit1,
-						},
-						Config: models.JobSpecConfigs{
-							{
-								Name:  "do",
-								Value: "this",
-							},
-						},
-					},
-					Assets: *models.JobAssets{}.New(
-						[]models.JobSpecAsset{
-							{
-								Name:  "query.sql",
-								Value: "select * from 1",
-							},
-						}),
-				},
-			}
-
+	t.Run("UpdateSecret", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+		}
+		t.Run("should update value of an existing secret", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSpecRepository := new(mock.JobSpecRepository)
-			defer jobSpecRepository.AssertExpectations(t)
-
-			jobSpecRepoFactory := new(mock.JobSpecRepoFactory)
-			defer jobSpecRepoFactory.AssertExpectations(t)
-
-			pluginRepo := new(mock.SupportedPluginRepo)
-			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
-				Base: execUnit1,
-			}, nil)
-			adapter := v1.NewAdapter(pluginRepo, nil)
-
-			namespaceRepository := new(mock.NamespaceRepository)
-			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
-			defer namespaceRepository.AssertExpectations(t)
-
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
-			defer namespaceRepoFact.AssertExpectations(t)
-
-			projectJobSpecRepository := new(mock.ProjectJobSpecRepository)
-			defer projectJobSpecRepository.AssertExpectations(t)
-
-			projectJobSpecRepoFactory := new(mock.ProjectJobSpecRepoFactory)
-			defer projectJobSpecRepoFactory.AssertExpectations(t)
-
-			jobService := new(mock.JobService)
-			jobService.On("Create", mock2.Anything, namespaceSpec).Return(nil)
-			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
-			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
-			defer jobService.AssertExpectations(t)
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("GetByName", "hello").Return(models.ProjectSecretItem{Name: "hello", Value: "world"}, nil)
+			projectSecretRepository.On("Save", models.ProjectSecretItem{Name: "hello", Value: "new-world"}).Return(nil)
+			defer projectSecretRepository.AssertExpectations(t)
 
-			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
-			grpcRespStream.On("Context").Return(context.Background())
-			defer grpcRespStream.AssertExpectations(t)
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
-				nil, nil,
+				"someVersion1.0",
+				nil, nil, nil,
 				projectRepoFactory,
-				namespaceRepoFact,
 				nil,
-				adapter,
+				projectSecretRepoFactory,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			jobSpecsAdapted := []*pb.JobSpecification{}
-			for _, jobSpec := range jobSpecs {
-				jobSpecAdapted, _ := adapter.ToJobProto(jobSpec)
-				jobSpecsAdapted = append(jobSpecsAdapted, jobSpecAdapted)
-			}
-			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: jobSpecsAdapted, Namespace: namespaceSpec.Name}
-			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			resp, err := runtimeServiceServer.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
+				ProjectName: projectSpec.Name,
+				SecretName:  "hello",
+				Value:       base64.StdEncoding.EncodeToString([]byte("new-world")),
+			})
 			assert.Nil(t, err)
+			assert.True(t, resp.GetSuccess())
 		})
-	})
-
-	t.Run("ReadJobSpecification", func(t *testing.T) {
-		t.Run("should read a job spec", func(t *testing.T) {
-			Version := "1.0.1"
-
-			projectName := "a-data-project"
-			jobName1 := "a-data-job"
-			taskName := "a-data-task"
-
-			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-			}
-
-			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-				ProjectSpec: projectSpec,
-			}
-
-			execUnit1 := new(mock.BasePlugin)
-			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
-				Name: taskName,
-			}, nil)
-			defer execUnit1.AssertExpectations(t)
-
-			jobSpecs := []models.JobSpec{
-				{
-					Name: jobName1,
-					Task: models.JobSpecTask{
-						Unit: &models.Plugin{
-							Base: execUnit1,
-						},
-						Config: models.JobSpecConfigs{
-							{
-								Name:  "do",
-								Value: "this",
-							},
-						},
-					},
-					Assets: *models.JobAssets{}.New(
-						[]models.JobSpecAsset{
-							{
-								Name:  "query.sql",
-								Value: "select * from 1",
-							},
-						}),
-				},
-			}
-
+		t.Run("should return not found if secret is not registered", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			allTasksRepo := new(mock.SupportedPluginRepo)
-			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
-			adapter := v1.NewAdapter(allTasksRepo, nil)
-
-			namespaceRepository := new(mock.NamespaceRepository)
-			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
-			defer namespaceRepository.AssertExpectations(t)
-
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
-			defer namespaceRepoFact.AssertExpectations(t)
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("GetByName", "hello").Return(models.ProjectSecretItem{}, store.ErrResourceNotFound)
+			defer projectSecretRepository.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
-			defer jobService.AssertExpectations(t)
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
-				nil, nil,
+				"someVersion1.0",
+				nil, nil, nil,
 				projectRepoFactory,
-				namespaceRepoFact,
 				nil,
-				adapter,
+				projectSecretRepoFactory,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			jobSpecAdapted, _ := adapter.ToJobProto(jobSpecs[0])
-			deployRequest := pb.ReadJobSpecificationRequest{ProjectName: projectName, JobName: jobSpecs[0].Name, Namespace: namespaceSpec.Name}
-			jobSpecResp, err := runtimeServiceServer.ReadJobSpecification(context.Background(), &deployRequest)
-			assert.Nil(t, err)
-			assert.Equal(t, jobSpecAdapted, jobSpecResp.Spec)
+			_, err := runtimeServiceServer.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
+				ProjectName: projectSpec.Name,
+				SecretName:  "hello",
+				Value:       base64.StdEncoding.EncodeToString([]byte("new-world")),
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			projectSecretRepository.AssertNotCalled(t, "Save", mock2.Anything)
 		})
 	})
 
-	t.Run("ListProjectNamespaces", func(t *testing.T) {
-		t.Run("should read namespaces of a project", func(t *testing.T) {
-			Version := "1.0.1"
-
-			projectName := "a-data-project"
-
+	t.Run("ListSecretKeys", func(t *testing.T) {
+		t.Run("should return sorted secret names without values", func(t *testing.T) {
 			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-			}
-
-			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-				ProjectSpec: projectSpec,
+				Name: "a-data-project",
 			}
-
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			adapter := v1.NewAdapter(nil, nil)
-
-			namespaceRepository := new(mock.NamespaceRepository)
-			namespaceRepository.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
-			defer namespaceRepository.AssertExpectations(t)
-
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
-			defer namespaceRepoFact.AssertExpectations(t)
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("GetAll").Return([]models.ProjectSecretItem{
+				{Name: "TOKEN", Value: "t0k3n"},
+				{Name: "PASSWORD", Value: "p4ss"},
+			}, nil)
+			defer projectSecretRepository.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			defer jobService.AssertExpectations(t)
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
-				nil, nil,
+				"someVersion1.0",
+				nil, nil, nil,
 				projectRepoFactory,
-				namespaceRepoFact,
 				nil,
-				adapter,
+				projectSecretRepoFactory,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			namespaceAdapted := adapter.ToNamespaceProto(namespaceSpec)
-			request := pb.ListProjectNamespacesRequest{ProjectName: projectName}
-			resp, err := runtimeServiceServer.ListProjectNamespaces(context.Background(), &request)
+			resp, err := runtimeServiceServer.ListSecretKeys(context.Background(), &pb.ListSecretKeysRequest{
+				ProjectName: projectSpec.Name,
+			})
 			assert.Nil(t, err)
-			assert.Equal(t, []*pb.NamespaceSpecification{namespaceAdapted}, resp.GetNamespaces())
+			assert.Equal(t, []string{"PASSWORD", "TOKEN"}, resp.GetSecretNames())
 		})
 	})
 
-	t.Run("DeleteJobSpecification", func(t *testing.T) {
-		t.Run("should delete the job", func(t *testing.T) {
-			Version := "1.0.1"
-
-			projectName := "a-data-project"
-			jobName1 := "a-data-job"
-			taskName := "a-data-task"
-
-			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-			}
-
+	t.Run("CreateRoleBinding", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+		}
+		t.Run("should grant role in namespace of project", func(t *testing.T) {
 			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
+				Name:        "dev-test-namespace-1",
 				ProjectSpec: projectSpec,
 			}
-
-			execUnit1 := new(mock.BasePlugin)
-			defer execUnit1.AssertExpectations(t)
-
-			jobSpecs := []models.JobSpec{
-				{
-					Name: jobName1,
-					Task: models.JobSpecTask{
-						Unit: &models.Plugin{
-							Base: execUnit1,
-						},
-						Config: models.JobSpecConfigs{
-							{
-								Name:  "do",
-								Value: "this",
-							},
-						},
-					},
-					Assets: *models.JobAssets{}.New(
-						[]models.JobSpecAsset{
-							{
-								Name:  "query.sql",
-								Value: "select * from 1",
-							},
-						}),
-				},
-			}
-
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			pluginRepo := new(mock.SupportedPluginRepo)
-			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
-				Base: execUnit1,
-			}, nil)
-			adapter := v1.NewAdapter(pluginRepo, nil)
-
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
 
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
-			defer namespaceRepoFact.AssertExpectations(t)
+			namespaceRepoFactory := new(mock.NamespaceRepoFactory)
+			namespaceRepoFactory.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFactory.AssertExpectations(t)
 
-			jobSpec := jobSpecs[0]
+			roleBindingRepository := new(mock.RoleBindingRepository)
+			roleBindingRepository.On("Save", models.RoleBinding{
+				Subject:   "alice@example.io",
+				Role:      models.RoleEditor,
+				Namespace: namespaceSpec.Name,
+			}).Return(nil)
+			defer roleBindingRepository.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
-			jobService.On("Delete", mock2.Anything, namespaceSpec, jobSpec).Return(nil)
-			defer jobService.AssertExpectations(t)
+			roleBindingRepoFactory := new(mock.RoleBindingRepoFactory)
+			roleBindingRepoFactory.On("New", projectSpec).Return(roleBindingRepository)
+			defer roleBindingRepoFactory.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
-				nil, nil,
+				"someVersion1.0",
+				nil, nil, nil,
 				projectRepoFactory,
-				namespaceRepoFact,
+				namespaceRepoFactory,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
 				nil,
-				adapter,
+				nil,
+				nil,
+				roleBindingRepoFactory,
 				nil,
 				nil,
 				nil,
 			)
 
-			deployRequest := pb.DeleteJobSpecificationRequest{ProjectName: projectName, JobName: jobSpec.Name, Namespace: namespaceSpec.Name}
-			resp, err := runtimeServiceServer.DeleteJobSpecification(context.Background(), &deployRequest)
+			resp, err := runtimeServiceServer.CreateRoleBinding(context.Background(), &pb.CreateRoleBindingRequest{
+				ProjectName: projectSpec.Name,
+				Binding: &pb.RoleBindingSpecification{
+					Subject:   "alice@example.io",
+					Role:      models.RoleEditor,
+					Namespace: namespaceSpec.Name,
+				},
+			})
 			assert.Nil(t, err)
-			assert.Equal(t, "job a-data-job has been deleted", resp.GetMessage())
+			assert.True(t, resp.GetSuccess())
 		})
-	})
+		t.Run("should fail for unknown roles", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
 
-	t.Run("JobStatus", func(t *testing.T) {
-		t.Run("should return all job status via scheduler if valid inputs", func(t *testing.T) {
-			Version := "1.0.0"
+			_, err := runtimeServiceServer.CreateRoleBinding(context.Background(), &pb.CreateRoleBindingRequest{
+				ProjectName: projectSpec.Name,
+				Binding: &pb.RoleBindingSpecification{
+					Subject: "alice@example.io",
+					Role:    "owner",
+				},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
 
+	t.Run("DeleteRoleBinding", func(t *testing.T) {
+		t.Run("should return not found when subject has no role in scope", func(t *testing.T) {
 			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
 				Name: "a-data-project",
 			}
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
 
-			namespaceSpec := models.NamespaceSpec{
-				ID:          uuid.Must(uuid.NewRandom()),
-				Name:        "game_jam",
-				ProjectSpec: projectSpec,
-			}
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSpec := models.JobSpec{
-				Name: "transform-tables",
+			roleBindingRepository := new(mock.RoleBindingRepository)
+			roleBindingRepository.On("Delete", "ci", "").Return(store.ErrResourceNotFound)
+			defer roleBindingRepository.AssertExpectations(t)
+
+			roleBindingRepoFactory := new(mock.RoleBindingRepoFactory)
+			roleBindingRepoFactory.On("New", projectSpec).Return(roleBindingRepository)
+			defer roleBindingRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				roleBindingRepoFactory,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.DeleteRoleBinding(context.Background(), &pb.DeleteRoleBindingRequest{
+				ProjectName: projectSpec.Name,
+				Subject:     "ci",
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("ListRoleBindings", func(t *testing.T) {
+		t.Run("should return role bindings of project", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				Name: "a-data-project",
 			}
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
 
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			roleBindingRepository := new(mock.RoleBindingRepository)
+			roleBindingRepository.On("GetAll").Return([]models.RoleBinding{
+				{Subject: "alice@example.io", Role: models.RoleAdmin},
+				{Subject: "ci", Role: models.RoleEditor, Namespace: "dev-test-namespace-1"},
+			}, nil)
+			defer roleBindingRepository.AssertExpectations(t)
+
+			roleBindingRepoFactory := new(mock.RoleBindingRepoFactory)
+			roleBindingRepoFactory.On("New", projectSpec).Return(roleBindingRepository)
+			defer roleBindingRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				roleBindingRepoFactory,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.ListRoleBindings(context.Background(), &pb.ListRoleBindingsRequest{
+				ProjectName: projectSpec.Name,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(resp.GetBindings()))
+			assert.Equal(t, "ci", resp.GetBindings()[1].GetSubject())
+			assert.Equal(t, "dev-test-namespace-1", resp.GetBindings()[1].GetNamespace())
+		})
+	})
+
+	t.Run("RegisterMacro", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+		}
+		t.Run("should register typed macro in project", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
@@ -1115,98 +1229,3130 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			adapter := v1.NewAdapter(nil, nil)
+			macroRepository := new(mock.MacroRepository)
+			macroRepository.On("Save", models.Macro{
+				Name:        "row_limit",
+				Type:        models.MacroTypeInt,
+				Default:     "1000",
+				Description: "rows read by previews",
+			}).Return(nil)
+			defer macroRepository.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
-			defer jobService.AssertExpectations(t)
+			macroRepoFactory := new(mock.MacroRepoFactory)
+			macroRepoFactory.On("New", projectSpec).Return(macroRepository)
+			defer macroRepoFactory.AssertExpectations(t)
 
-			jobStatuses := []models.JobStatus{
-				{
-					ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
-					State:       "failed",
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				macroRepoFactory,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RegisterMacro(context.Background(), &pb.RegisterMacroRequest{
+				ProjectName: projectSpec.Name,
+				Macro: &pb.MacroSpecification{
+					Name:        "row_limit",
+					Type:        models.MacroTypeInt,
+					Default:     "1000",
+					Description: "rows read by previews",
 				},
-				{
-					ScheduledAt: time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC),
-					State:       "success",
+			})
+			assert.Nil(t, err)
+			assert.True(t, resp.GetSuccess())
+		})
+		t.Run("should fail when default value does not match type", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.RegisterMacro(context.Background(), &pb.RegisterMacroRequest{
+				ProjectName: projectSpec.Name,
+				Macro: &pb.MacroSpecification{
+					Name:    "row_limit",
+					Type:    models.MacroTypeInt,
+					Default: "all",
 				},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ListMacros", func(t *testing.T) {
+		t.Run("should return project macros and macros of requested namespace", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				Name: "a-data-project",
 			}
-			scheduler := new(mock.Scheduler)
-			scheduler.On("GetJobStatus", context.Background(), projectSpec, jobSpec.Name).Return(jobStatuses, nil)
-			defer scheduler.AssertExpectations(t)
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			macroRepository := new(mock.MacroRepository)
+			macroRepository.On("GetAll").Return([]models.Macro{
+				{Name: "dataset", Default: "common"},
+				{Name: "dataset", Default: "playground", Namespace: "dev-test-namespace-1"},
+				{Name: "dataset", Default: "scratch", Namespace: "dev-test-namespace-2"},
+			}, nil)
+			defer macroRepository.AssertExpectations(t)
+
+			macroRepoFactory := new(mock.MacroRepoFactory)
+			macroRepoFactory.On("New", projectSpec).Return(macroRepository)
+			defer macroRepoFactory.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService, nil, nil,
+				"someVersion1.0",
+				nil, nil, nil,
 				projectRepoFactory,
 				nil,
 				nil,
-				adapter,
+				v1.NewAdapter(nil, nil),
 				nil,
 				nil,
-				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				macroRepoFactory,
+				nil,
 			)
 
-			req := &pb.JobStatusRequest{
+			resp, err := runtimeServiceServer.ListMacros(context.Background(), &pb.ListMacrosRequest{
 				ProjectName: projectSpec.Name,
-				JobName:     jobSpec.Name,
-			}
-			resp, err := runtimeServiceServer.JobStatus(context.Background(), req)
+				Namespace:   "dev-test-namespace-1",
+			})
 			assert.Nil(t, err)
-			assert.Equal(t, len(jobStatuses), len(resp.Statuses))
-			for _, expectedStatus := range jobStatuses {
-				var found bool
-				for _, respVal := range resp.Statuses {
-					if expectedStatus.ScheduledAt.Equal(respVal.ScheduledAt.AsTime()) &&
-						expectedStatus.State.String() == respVal.State {
-						found = true
-						break
-					}
-				}
-				if !found {
-					assert.Fail(t, fmt.Sprintf("failed to find expected job status %v", expectedStatus))
-				}
-			}
+			assert.Equal(t, 2, len(resp.GetMacros()))
+			assert.Equal(t, models.MacroTypeString, resp.GetMacros()[0].GetType())
+			assert.Equal(t, "playground", resp.GetMacros()[1].GetDefault())
 		})
 	})
 
-	t.Run("RegisterJobEvent", func(t *testing.T) {
-		t.Run("should register the event if valid inputs", func(t *testing.T) {
-			Version := "1.0.0"
-
+	t.Run("ListAuditEvents", func(t *testing.T) {
+		t.Run("should return audit events of project matching filters", func(t *testing.T) {
 			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
 				Name: "a-data-project",
 			}
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
 
-			namespaceSpec := models.NamespaceSpec{
-				ID:          uuid.Must(uuid.NewRandom()),
-				Name:        "game_jam",
-				ProjectSpec: projectSpec,
-			}
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSpecs := []models.JobSpec{
+			since := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+			auditEventRepo := new(mock.AuditEventRepository)
+			auditEventRepo.On("GetAll", models.AuditEventFilter{
+				ProjectName: projectSpec.Name,
+				Actor:       "ci",
+				Since:       since,
+				Limit:       10,
+			}).Return([]models.AuditEvent{
 				{
-					Name: "transform-tables",
+					ID:          uuid.Must(uuid.NewRandom()),
+					ProjectName: projectSpec.Name,
+					Namespace:   "dev-test-namespace-1",
+					Method:      "DeleteJobSpecification",
+					Actor:       "ci",
+					Summary:     "delete job job-1",
+					Success:     true,
+					CreatedAt:   since.Add(time.Hour),
 				},
-			}
+			}, nil)
+			defer auditEventRepo.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				auditEventRepo,
+				nil,
+				nil,
+			)
 
+			resp, err := runtimeServiceServer.ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+				ProjectName: projectSpec.Name,
+				Actor:       "ci",
+				Since:       timestamppb.New(since),
+				PageSize:    10,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.GetEvents()))
+			assert.Equal(t, "delete job job-1", resp.GetEvents()[0].GetSummary())
+			assert.Equal(t, since.Add(time.Hour), resp.GetEvents()[0].GetCreatedAt().AsTime())
+		})
+		t.Run("should fail for unknown project", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepository.On("GetByName", "unknown-project").Return(models.ProjectSpec{}, store.ErrResourceNotFound)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			adapter := v1.NewAdapter(nil, nil)
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
 
-			namespaceRepository := new(mock.NamespaceRepository)
-			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
-			defer namespaceRepository.AssertExpectations(t)
+			_, err := runtimeServiceServer.ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+				ProjectName: "unknown-project",
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
 
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+	t.Run("DeployJobSpecification", func(t *testing.T) {
+		t.Run("should deploy the job", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+			jobName1 := "a-data-job"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name:     jobName1,
+					Schedule: models.JobSpecSchedule{Interval: "@daily"},
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+						Config: models.JobSpecConfigs{
+							{
+								Name:  "do",
+								Value: "this",
+							},
+						},
+					},
+					Assets: *models.JobAssets{}.New(
+						[]models.JobSpecAsset{
+							{
+								Name:  "query.sql",
+								Value: "select * from 1",
+							},
+						}),
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobSpecRepository := new(mock.JobSpecRepository)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			jobSpecRepoFactory := new(mock.JobSpecRepoFactory)
+			defer jobSpecRepoFactory.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			projectJobSpecRepository := new(mock.ProjectJobSpecRepository)
+			defer projectJobSpecRepository.AssertExpectations(t)
+
+			projectJobSpecRepoFactory := new(mock.ProjectJobSpecRepoFactory)
+			defer projectJobSpecRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return([]models.JobSpec{}, nil)
+			jobService.On("CreateAll", namespaceSpec, mock2.Anything).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			jobSpecsAdapted := []*pb.JobSpecification{}
+			for _, jobSpec := range jobSpecs {
+				jobSpecAdapted, _ := adapter.ToJobProto(jobSpec)
+				jobSpecsAdapted = append(jobSpecsAdapted, jobSpecAdapted)
+			}
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: jobSpecsAdapted, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+		})
+		t.Run("should skip jobs same as the stored specification", func(t *testing.T) {
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name:     "a-data-job",
+					Schedule: models.JobSpecSchedule{Interval: "@daily"},
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+						Config: models.JobSpecConfigs{
+							{
+								Name:  "do",
+								Value: "this",
+							},
+						},
+					},
+					Assets: *models.JobAssets{}.New(
+						[]models.JobSpecAsset{
+							{
+								Name:  "query.sql",
+								Value: "select * from 1",
+							},
+						}),
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			// specs are stored as adapted from the request
+			jobProto, _ := adapter.ToJobProto(jobSpecs[0])
+			storedJobSpec, _ := adapter.FromJobProto(jobProto)
+			storedJobSpecs := []models.JobSpec{storedJobSpec}
+
+			// no create, delete or sync for unchanged jobs
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return(storedJobSpecs, nil)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+				Success: true,
+				Ack:     true,
+				JobName: "a-data-job",
+				Message: v1.JobSkippedUnchangedMessage,
+			}).Return(nil)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: []*pb.JobSpecification{jobProto}, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+
+			// forced deployments sync unchanged jobs, e.g. to pick up
+			// updated project config
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil).Once()
+			grpcRespStream.On("Context").Return(context.Background())
+			deployRequest.Force = true
+			err = runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+		})
+		t.Run("should report failure of each job which fails to save", func(t *testing.T) {
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			var jobProtos []*pb.JobSpecification
+			for _, name := range []string{"job-1", "job-2", "job-3"} {
+				jobProto, _ := adapter.ToJobProto(models.JobSpec{
+					Name:     name,
+					Schedule: models.JobSpecSchedule{Interval: "@daily"},
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+					},
+				})
+				jobProtos = append(jobProtos, jobProto)
+			}
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return([]models.JobSpec{}, nil)
+			jobService.On("CreateAll", namespaceSpec, mock2.MatchedBy(func(specs []models.JobSpec) bool {
+				return len(specs) == 1 && specs[0].Name == "job-3"
+			})).Return(nil)
+			jobService.On("CreateAll", namespaceSpec, mock2.MatchedBy(func(specs []models.JobSpec) bool {
+				return len(specs) == 2 && specs[0].Name == "job-1" && specs[1].Name == "job-2"
+			})).Return(errors.New("invalid spec"))
+			// jobs of a failed batch are saved one by one, only the failing
+			// one is reported
+			jobService.On("Create", namespaceSpec, mock2.MatchedBy(func(spec models.JobSpec) bool {
+				return spec.Name == "job-1"
+			})).Return(nil)
+			jobService.On("Create", namespaceSpec, mock2.MatchedBy(func(spec models.JobSpec) bool {
+				return spec.Name == "job-2"
+			})).Return(errors.New("invalid spec"))
+			// saved jobs are deleted on rollback
+			jobService.On("KeepOnly", namespaceSpec, []models.JobSpec(nil), nil).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, nil).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+				Success: false,
+				Ack:     true,
+				JobName: "job-2",
+				Message: "failed to save job-2: invalid spec",
+			}).Return(nil)
+			for _, name := range []string{"job-1", "job-3"} {
+				grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+					JobName: name,
+					Message: (&job.EventJobSpecRevert{Name: name}).String(),
+				}).Return(nil)
+			}
+			grpcRespStream.On("Context").Return(metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs(v1.RequestedByHeader, "alice")))
+			defer grpcRespStream.AssertExpectations(t)
+
+			// failed deployment is kept in history along with job outcomes
+			deployManager := new(mock.DeployManager)
+			deployManager.On("Record", mock2.MatchedBy(func(deployment models.DeploymentSpec) bool {
+				jobStatus := map[string]string{}
+				for _, jobDeployment := range deployment.Jobs {
+					jobStatus[jobDeployment.Name] = jobDeployment.Status
+				}
+				return deployment.Status == models.DeploymentStatusFailed && deployment.RequestedBy == "alice" &&
+					deployment.Namespace.Name == namespaceSpec.Name &&
+					jobStatus["job-1"] == models.JobDeploymentStatusReverted &&
+					jobStatus["job-2"] == models.JobDeploymentStatusFailed &&
+					jobStatus["job-3"] == models.JobDeploymentStatusReverted
+			})).Return(nil)
+			defer deployManager.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				new(progress.ObserverChain),
+				nil,
+				nil,
+				deployManager,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.UploadConcurrency = 2
+			runtimeServiceServer.UploadBatchSize = 2
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: jobProtos, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Contains(t, err.Error(), "failed to save job-2")
+			assert.Contains(t, err.Error(), "reverted jobs: job-1, job-3")
+			jobService.AssertNumberOfCalls(t, "CreateAll", 2)
+		})
+		t.Run("should fail with failed precondition if a job was modified since its revision", func(t *testing.T) {
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			storedJobSpec := models.JobSpec{
+				Name:     "job-1",
+				Owner:    "bob",
+				Revision: 2,
+				Schedule: models.JobSpecSchedule{Interval: "@daily"},
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{
+						Base: execUnit1,
+					},
+				},
+			}
+			requestedJobSpec := storedJobSpec
+			requestedJobSpec.Owner = "alice"
+			requestedJobSpec.Revision = 1
+			jobProto, _ := adapter.ToJobProto(requestedJobSpec)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return([]models.JobSpec{storedJobSpec}, nil)
+			jobService.On("CreateAll", namespaceSpec, mock2.MatchedBy(func(specs []models.JobSpec) bool {
+				return len(specs) == 1 && specs[0].Name == "job-1" && specs[0].Revision == 1
+			})).Return(errors.Wrap(store.ErrRevisionConflict, "job job-1 is at revision 2, expected revision 1"))
+			jobService.On("Create", namespaceSpec, mock2.MatchedBy(func(spec models.JobSpec) bool {
+				return spec.Name == "job-1" && spec.Revision == 1
+			})).Return(errors.Wrap(store.ErrRevisionConflict, "job job-1 is at revision 2, expected revision 1"))
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+				Success: false,
+				Ack:     true,
+				JobName: "job-1",
+				Message: "failed to save job-1: job job-1 is at revision 2, expected revision 1: resource was modified concurrently",
+			}).Return(nil)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				new(progress.ObserverChain),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: []*pb.JobSpecification{jobProto}, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Contains(t, err.Error(), "no jobs were changed")
+		})
+		t.Run("should send heartbeats while jobs are synced", func(t *testing.T) {
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobProto, _ := adapter.ToJobProto(models.JobSpec{
+				Name:     "job-1",
+				Schedule: models.JobSpecSchedule{Interval: "@daily"},
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{
+						Base: execUnit1,
+					},
+				},
+			})
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return([]models.JobSpec{}, nil)
+			jobService.On("CreateAll", namespaceSpec, mock2.Anything).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+				args.Get(2).(progress.Observer).Notify(&job.EventJobPriorityWeightAssign{})
+				time.Sleep(time.Millisecond * 50)
+			}).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			var heartbeat *pb.DeployHeartbeat
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.DeployJobSpecificationResponse) bool {
+				return resp.GetHeartbeat() != nil
+			})).Run(func(args mock2.Arguments) {
+				heartbeat = args.Get(0).(*pb.DeployJobSpecificationResponse).GetHeartbeat()
+			}).Return(nil)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				new(progress.ObserverChain),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.HeartbeatInterval = time.Millisecond * 10
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: []*pb.JobSpecification{jobProto}, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+			assert.Equal(t, v1.DeployPhaseUploading, heartbeat.GetPhase())
+			assert.Equal(t, int32(1), heartbeat.GetTotalJobs())
+		})
+		t.Run("should delete jobs missing from the request", func(t *testing.T) {
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+
+			storedJobSpecs := []models.JobSpec{
+				{
+					Name: "a-stale-job",
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+					},
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return(storedJobSpecs, nil)
+			jobService.On("KeepOnly", namespaceSpec, []models.JobSpec(nil), mock2.Anything).Run(func(args mock2.Arguments) {
+				args.Get(2).(progress.Observer).Notify(&job.EventSavedJobDelete{Name: "a-stale-job"})
+			}).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+				JobName: "a-stale-job",
+				Message: (&job.EventSavedJobDelete{Name: "a-stale-job"}).String(),
+			}).Return(nil)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				new(progress.ObserverChain),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+		})
+		t.Run("should keep jobs missing from the request if reconcile is opt in and not asked for", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: "a-data-task",
+			}, nil)
+			storedJobSpecs := []models.JobSpec{
+				{
+					Name: "a-stale-job",
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+					},
+				},
+			}
+
+			// neither KeepOnly nor Sync are expected
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return(storedJobSpecs, nil)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				new(progress.ObserverChain),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.ReconcileOptIn = true
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("DeployJobSpecificationStream", func(t *testing.T) {
+		t.Run("should save jobs of each chunk and sync once stream is closed", func(t *testing.T) {
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			var jobProtos []*pb.JobSpecification
+			for _, name := range []string{"job-1", "job-2"} {
+				jobProto, _ := adapter.ToJobProto(models.JobSpec{
+					Name:     name,
+					Schedule: models.JobSpecSchedule{Interval: "@daily"},
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+					},
+				})
+				jobProtos = append(jobProtos, jobProto)
+			}
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return([]models.JobSpec{}, nil)
+			jobService.On("CreateAll", namespaceSpec, mock2.Anything).Return(nil).Twice()
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationStreamServer)
+			grpcRespStream.On("Recv").Return(&pb.DeployJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				Jobs:        jobProtos[:1],
+			}, nil).Once()
+			grpcRespStream.On("Recv").Return(&pb.DeployJobSpecificationRequest{
+				Jobs: jobProtos[1:],
+			}, nil).Once()
+			grpcRespStream.On("Recv").Return(nil, io.EOF).Once()
+			for _, name := range []string{"job-1", "job-2"} {
+				grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+					JobName: name,
+					Message: v1.JobSavedMessage,
+				}).Return(nil).Once()
+			}
+			grpcRespStream.On("Context").Return(context.Background())
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.DeployJobSpecificationStream(grpcRespStream)
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("DeployJobSpecificationAsync", func(t *testing.T) {
+		t.Run("should queue jobs for deployment and return deployment id", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			deploymentID := uuid.Must(uuid.NewRandom())
+			deployManager := new(mock.DeployManager)
+			deployManager.On("Deploy", models.DeployRequest{Namespace: namespaceSpec, Reconcile: true, RequestedBy: "alice"}).Return(deploymentID.String(), nil)
+			defer deployManager.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				deployManager,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(v1.RequestedByHeader, "alice"))
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Namespace: namespaceSpec.Name}
+			resp, err := runtimeServiceServer.DeployJobSpecificationAsync(ctx, &deployRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, deploymentID.String(), resp.DeploymentId)
+		})
+	})
+
+	t.Run("GetDeploymentStatus", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		deploymentID := uuid.Must(uuid.NewRandom())
+
+		t.Run("should return progress of deployment", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			deployManager := new(mock.DeployManager)
+			deployManager.On("GetStatus", deploymentID).Return(models.DeploymentSpec{
+				ID:        deploymentID,
+				Namespace: namespaceSpec,
+				Status:    models.DeploymentStatusInProgress,
+				Jobs: []models.JobDeployment{
+					{Name: "job-1", Status: models.JobDeploymentStatusDeployed},
+					{Name: "job-2", Status: models.JobDeploymentStatusPending},
+				},
+			}, nil)
+			defer deployManager.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				deployManager,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetDeploymentStatus(context.Background(), &pb.GetDeploymentStatusRequest{
+				ProjectName:  projectName,
+				DeploymentId: deploymentID.String(),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, models.DeploymentStatusInProgress, resp.Status)
+			assert.Equal(t, namespaceSpec.Name, resp.Namespace)
+			assert.Equal(t, 2, len(resp.Jobs))
+			assert.Equal(t, "job-1", resp.Jobs[0].JobName)
+			assert.Equal(t, models.JobDeploymentStatusDeployed, resp.Jobs[0].Status)
+		})
+		t.Run("should return not found if deployment belongs to other project", func(t *testing.T) {
+			otherProjectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "other-project",
+			}
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", otherProjectSpec.Name).Return(otherProjectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			deployManager := new(mock.DeployManager)
+			deployManager.On("GetStatus", deploymentID).Return(models.DeploymentSpec{
+				ID:        deploymentID,
+				Namespace: namespaceSpec,
+			}, nil)
+			defer deployManager.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				deployManager,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.GetDeploymentStatus(context.Background(), &pb.GetDeploymentStatusRequest{
+				ProjectName:  otherProjectSpec.Name,
+				DeploymentId: deploymentID.String(),
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("ListDeployments", func(t *testing.T) {
+		t.Run("should return deployments of project without job outcomes", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+			since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			createdAt := since.Add(time.Hour)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			deployManager := new(mock.DeployManager)
+			deployManager.On("ListDeployments", projectSpec, models.DeploymentFilter{
+				Namespace: namespaceSpec.Name,
+				Since:     since,
+				Limit:     10,
+			}).Return([]models.DeploymentSpec{
+				{
+					ID:          uuid.Must(uuid.NewRandom()),
+					Namespace:   namespaceSpec,
+					Status:      models.DeploymentStatusSuccess,
+					RequestedBy: "alice",
+					Jobs: []models.JobDeployment{
+						{Name: "job-1", Status: models.JobDeploymentStatusDeployed},
+						{Name: "job-2", Status: models.JobDeploymentStatusSkipped},
+					},
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt.Add(time.Minute),
+				},
+			}, nil)
+			defer deployManager.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				deployManager,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.ListDeployments(context.Background(), &pb.ListDeploymentsRequest{
+				ProjectName: projectSpec.Name,
+				Namespace:   namespaceSpec.Name,
+				Since:       timestamppb.New(since),
+				PageSize:    10,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.GetDeployments()))
+			deployment := resp.GetDeployments()[0]
+			assert.Equal(t, "alice", deployment.GetRequestedBy())
+			assert.Equal(t, int32(2), deployment.GetJobCount())
+			assert.Equal(t, time.Minute, deployment.GetDuration().AsDuration())
+			assert.Empty(t, deployment.GetJobs())
+		})
+	})
+
+	t.Run("GetDeployment", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		deploymentID := uuid.Must(uuid.NewRandom())
+
+		t.Run("should return deployment along with job outcomes", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			deployManager := new(mock.DeployManager)
+			deployManager.On("GetDeployment", projectSpec, deploymentID).Return(models.DeploymentSpec{
+				ID:      deploymentID,
+				Status:  models.DeploymentStatusFailed,
+				Message: "scheduler down",
+				Jobs: []models.JobDeployment{
+					{Name: "job-1", Status: models.JobDeploymentStatusReverted},
+				},
+			}, nil)
+			defer deployManager.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				deployManager,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetDeployment(context.Background(), &pb.GetDeploymentRequest{
+				ProjectName:  projectSpec.Name,
+				DeploymentId: deploymentID.String(),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, deploymentID.String(), resp.GetDeployment().GetDeploymentId())
+			assert.Equal(t, models.DeploymentStatusFailed, resp.GetDeployment().GetStatus())
+			assert.Equal(t, 1, len(resp.GetDeployment().GetJobs()))
+			assert.Equal(t, models.JobDeploymentStatusReverted, resp.GetDeployment().GetJobs()[0].GetStatus())
+		})
+		t.Run("should return not found for unknown deployment", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			deployManager := new(mock.DeployManager)
+			deployManager.On("GetDeployment", projectSpec, deploymentID).Return(models.DeploymentSpec{}, store.ErrResourceNotFound)
+			defer deployManager.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				deployManager,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.GetDeployment(context.Background(), &pb.GetDeploymentRequest{
+				ProjectName:  projectSpec.Name,
+				DeploymentId: deploymentID.String(),
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("ImportJobSpecifications", func(t *testing.T) {
+		jobContents := `version: 1
+name: a-data-job
+owner: optimus
+schedule:
+  start_date: "2020-12-02"
+  interval: '@daily'
+task:
+  name: a-data-task
+  config:
+    do: this
+  window:
+    size: 24h
+    offset: "0"
+    truncate_to: d
+`
+		archive := new(bytes.Buffer)
+		gzw := gzip.NewWriter(archive)
+		tw := tar.NewWriter(gzw)
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "a-data-job/job.yaml", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(jobContents))}))
+		_, err := tw.Write([]byte(jobContents))
+		assert.Nil(t, err)
+		assert.Nil(t, tw.Close())
+		assert.Nil(t, gzw.Close())
+
+		t.Run("should import jobs from archive", func(t *testing.T) {
+			Version := "1.0.1"
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			defer pluginRepo.AssertExpectations(t)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Create", mock2.MatchedBy(func(spec models.JobSpec) bool {
+				return spec.Name == "a-data-job"
+			}), namespaceSpec).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			archiveBytes := archive.Bytes()
+			grpcRespStream := new(mock.RuntimeService_ImportJobSpecificationsServer)
+			grpcRespStream.On("Recv").Return(&pb.ImportJobSpecificationsRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				Archive:     archiveBytes[:10],
+			}, nil).Once()
+			grpcRespStream.On("Recv").Return(&pb.ImportJobSpecificationsRequest{
+				Archive: archiveBytes[10:],
+			}, nil).Once()
+			grpcRespStream.On("Recv").Return(nil, io.EOF).Once()
+			grpcRespStream.On("Context").Return(context.Background())
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.ImportJobSpecifications(grpcRespStream)
+			assert.Nil(t, err)
+		})
+		t.Run("should fail if archive is malformed", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_ImportJobSpecificationsServer)
+			grpcRespStream.On("Recv").Return(&pb.ImportJobSpecificationsRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				Archive:     []byte("not an archive"),
+			}, nil).Once()
+			grpcRespStream.On("Recv").Return(nil, io.EOF).Once()
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.ImportJobSpecifications(grpcRespStream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			details := status.Convert(err).Details()
+			assert.Len(t, details, 2)
+			errInfo, ok := details[0].(*errdetails.ErrorInfo)
+			assert.True(t, ok)
+			assert.Equal(t, v1.ErrReasonInvalidArgument, errInfo.GetReason())
+			assert.Equal(t, v1.ErrorDomain, errInfo.GetDomain())
+			badRequest, ok := details[1].(*errdetails.BadRequest)
+			assert.True(t, ok)
+			assert.Equal(t, "archive", badRequest.GetFieldViolations()[0].GetField())
+		})
+	})
+
+	t.Run("ListJobSpecification", func(t *testing.T) {
+		projectName := "a-data-project"
+		taskName := "a-data-task"
+
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+
+		execUnit1 := new(mock.BasePlugin)
+		execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name: taskName,
+		}, nil)
+
+		newJobSpec := func(name string, labels map[string]string) models.JobSpec {
+			return models.JobSpec{
+				Name:   name,
+				Owner:  "optimus",
+				Labels: labels,
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{
+						Base: execUnit1,
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "query.sql",
+							Value: "select * from 1",
+						},
+					}),
+			}
+		}
+		jobSpecs := []models.JobSpec{
+			newJobSpec("job-c", map[string]string{"team": "data"}),
+			newJobSpec("job-a", map[string]string{"team": "data"}),
+			newJobSpec("job-d", map[string]string{"team": "infra"}),
+			newJobSpec("job-b", map[string]string{"team": "data"}),
+		}
+
+		newServer := func() *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return(jobSpecs, nil)
+
+			return v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+		}
+
+		t.Run("should list filtered jobs page by page", func(t *testing.T) {
+			runtimeServiceServer := newServer()
+			listRequest := pb.ListJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				PageSize:    2,
+				Filter: &pb.ListJobSpecificationRequest_Filter{
+					TaskName: taskName,
+					Labels:   map[string]string{"team": "data"},
+				},
+			}
+			resp, err := runtimeServiceServer.ListJobSpecification(context.Background(), &listRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(resp.Jobs))
+			assert.Equal(t, "job-a", resp.Jobs[0].Name)
+			assert.Equal(t, "job-b", resp.Jobs[1].Name)
+			assert.NotEmpty(t, resp.NextPageToken)
+
+			listRequest.PageToken = resp.NextPageToken
+			resp, err = runtimeServiceServer.ListJobSpecification(context.Background(), &listRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.Jobs))
+			assert.Equal(t, "job-c", resp.Jobs[0].Name)
+			assert.Empty(t, resp.NextPageToken)
+		})
+		t.Run("should only populate fields in read mask", func(t *testing.T) {
+			runtimeServiceServer := newServer()
+			listRequest := pb.ListJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				ReadMask:    &fieldmaskpb.FieldMask{Paths: []string{"name", "labels"}},
+			}
+			resp, err := runtimeServiceServer.ListJobSpecification(context.Background(), &listRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, 4, len(resp.Jobs))
+			for _, jobProto := range resp.Jobs {
+				assert.NotEmpty(t, jobProto.Name)
+				assert.NotEmpty(t, jobProto.Labels)
+				assert.Empty(t, jobProto.Assets)
+				assert.Empty(t, jobProto.Owner)
+				assert.Empty(t, jobProto.TaskName)
+			}
+		})
+		t.Run("should fail for invalid read mask", func(t *testing.T) {
+			runtimeServiceServer := newServer()
+			listRequest := pb.ListJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				ReadMask:    &fieldmaskpb.FieldMask{Paths: []string{"unknown_field"}},
+			}
+			_, err := runtimeServiceServer.ListJobSpecification(context.Background(), &listRequest)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should fail for invalid page token", func(t *testing.T) {
+			runtimeServiceServer := newServer()
+			listRequest := pb.ListJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				PageToken:   "%%%",
+			}
+			_, err := runtimeServiceServer.ListJobSpecification(context.Background(), &listRequest)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ReadJobSpecification", func(t *testing.T) {
+		t.Run("should read a job spec", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+			jobName1 := "a-data-job"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: jobName1,
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "0 2 * * *",
+					},
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+						Config: models.JobSpecConfigs{
+							{
+								Name:  "do",
+								Value: "this",
+							},
+						},
+					},
+					Assets: *models.JobAssets{}.New(
+						[]models.JobSpecAsset{
+							{
+								Name:  "query.sql",
+								Value: "select * from 1",
+							},
+						}),
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			allTasksRepo := new(mock.SupportedPluginRepo)
+			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
+			adapter := v1.NewAdapter(allTasksRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			jobSpecAdapted, _ := adapter.ToJobProto(jobSpecs[0])
+			deployRequest := pb.ReadJobSpecificationRequest{ProjectName: projectName, JobName: jobSpecs[0].Name, Namespace: namespaceSpec.Name}
+			jobSpecResp, err := runtimeServiceServer.ReadJobSpecification(context.Background(), &deployRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, jobSpecAdapted, jobSpecResp.Spec)
+			assert.Equal(t, "every day at 02:00 UTC", jobSpecResp.ScheduleDescription)
+		})
+	})
+
+	t.Run("ListProjectNamespaces", func(t *testing.T) {
+		t.Run("should read namespaces of a project", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			namespaceAdapted := adapter.ToNamespaceProto(namespaceSpec)
+			request := pb.ListProjectNamespacesRequest{ProjectName: projectName}
+			resp, err := runtimeServiceServer.ListProjectNamespaces(context.Background(), &request)
+			assert.Nil(t, err)
+			assert.Equal(t, []*pb.NamespaceSpecification{namespaceAdapted}, resp.GetNamespaces())
+		})
+		t.Run("should redact sensitive config for callers who can't read secrets", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					models.ProjectSensitiveConfigKeys: "DB_PASSWORD",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"BUCKET":      "gs://some_folder",
+					"API_TOKEN":   "token",
+					"DB_PASSWORD": "password",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.SensitiveConfig = models.ConfigKeyPatterns{"*_TOKEN"}
+			runtimeServiceServer.SecretReaders = secretReaders(false)
+
+			request := pb.ListProjectNamespacesRequest{ProjectName: projectName}
+			resp, err := runtimeServiceServer.ListProjectNamespaces(context.Background(), &request)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]string{
+				"BUCKET":      "gs://some_folder",
+				"API_TOKEN":   models.ConfigRedactedValue,
+				"DB_PASSWORD": models.ConfigRedactedValue,
+			}, resp.GetNamespaces()[0].GetConfig())
+
+			runtimeServiceServer.SecretReaders = secretReaders(true)
+			resp, err = runtimeServiceServer.ListProjectNamespaces(context.Background(), &request)
+			assert.Nil(t, err)
+			assert.Equal(t, namespaceSpec.Config, resp.GetNamespaces()[0].GetConfig())
+		})
+	})
+
+	t.Run("DeleteJobSpecification", func(t *testing.T) {
+		t.Run("should delete the job", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+			jobName1 := "a-data-job"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.BasePlugin)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: jobName1,
+					Task: models.JobSpecTask{
+						Unit: &models.Plugin{
+							Base: execUnit1,
+						},
+						Config: models.JobSpecConfigs{
+							{
+								Name:  "do",
+								Value: "this",
+							},
+						},
+					},
+					Assets: *models.JobAssets{}.New(
+						[]models.JobSpecAsset{
+							{
+								Name:  "query.sql",
+								Value: "select * from 1",
+							},
+						}),
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+				Base: execUnit1,
+			}, nil)
+			adapter := v1.NewAdapter(pluginRepo, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobSpec := jobSpecs[0]
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
+			jobService.On("Delete", mock2.Anything, namespaceSpec, jobSpec).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeleteJobSpecificationRequest{ProjectName: projectName, JobName: jobSpec.Name, Namespace: namespaceSpec.Name}
+			resp, err := runtimeServiceServer.DeleteJobSpecification(context.Background(), &deployRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, "job a-data-job has been deleted", resp.GetMessage())
+		})
+	})
+
+	t.Run("PauseJob", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "a-data-job",
+		}
+
+		t.Run("should pause the job", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpec.Name, namespaceSpec).Return(jobSpec, nil)
+			jobService.On("SetPaused", mock2.Anything, namespaceSpec, jobSpec, true).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			pauseRequest := pb.PauseJobRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, JobName: jobSpec.Name}
+			resp, err := runtimeServiceServer.PauseJob(context.Background(), &pauseRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, "job a-data-job has been paused", resp.GetMessage())
+		})
+		t.Run("should fail to resume a job which doesn't exist", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpec.Name, namespaceSpec).Return(models.JobSpec{}, store.ErrResourceNotFound)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resumeRequest := pb.ResumeJobRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, JobName: jobSpec.Name}
+			resp, err := runtimeServiceServer.ResumeJob(context.Background(), &resumeRequest)
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+		t.Run("should restore a deleted job", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Restore", context.Background(), namespaceSpec, jobSpec.Name).Return(jobSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			restoreRequest := pb.RestoreJobRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, JobName: jobSpec.Name}
+			resp, err := runtimeServiceServer.RestoreJob(context.Background(), &restoreRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, "job a-data-job has been restored", resp.GetMessage())
+		})
+		t.Run("should fail to restore a job which isn't deleted", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Restore", context.Background(), namespaceSpec, jobSpec.Name).Return(models.JobSpec{}, errors.Wrap(store.ErrResourceNotFound, "failed to restore spec: a-data-job"))
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			restoreRequest := pb.RestoreJobRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, JobName: jobSpec.Name}
+			resp, err := runtimeServiceServer.RestoreJob(context.Background(), &restoreRequest)
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("DeleteProject", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpecs := []models.NamespaceSpec{
+			{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			},
+			{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-2",
+				ProjectSpec: projectSpec,
+			},
+		}
+
+		t.Run("should remove jobs from scheduler and delete the project", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepository.On("Delete", projectSpec.Name).Return(nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return(namespaceSpecs, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpecs[0]).Return([]models.JobSpec{{Name: "a-data-job"}, {Name: "b-data-job"}}, nil)
+			jobService.On("GetAll", namespaceSpecs[1]).Return([]models.JobSpec{}, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeleteJobs", mock2.Anything, namespaceSpecs[0], []string{"a-data-job", "b-data-job"}, mock2.Anything).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.DeleteProject(context.Background(), &pb.DeleteProjectRequest{ProjectName: projectSpec.Name})
+			assert.Nil(t, err)
+			assert.Equal(t, "project a-data-project has been deleted", resp.GetMessage())
+		})
+		t.Run("should keep the project if its jobs fail to be removed from scheduler", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return(namespaceSpecs[:1], nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpecs[0]).Return([]models.JobSpec{{Name: "a-data-job"}}, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeleteJobs", mock2.Anything, namespaceSpecs[0], []string{"a-data-job"}, mock2.Anything).Return(errors.New("scheduler is down"))
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.DeleteProject(context.Background(), &pb.DeleteProjectRequest{ProjectName: projectSpec.Name})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.Internal, status.Code(err))
+		})
+	})
+
+	t.Run("RestoreProject", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+
+		t.Run("should restore the project and schedule its jobs again", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("Restore", projectSpec.Name).Return(nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RestoreProject(context.Background(), &pb.RestoreProjectRequest{ProjectName: projectSpec.Name})
+			assert.Nil(t, err)
+			assert.Equal(t, "project a-data-project has been restored", resp.GetMessage())
+		})
+		t.Run("should fail to restore a project which isn't deleted", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("Restore", projectSpec.Name).Return(store.ErrResourceNotFound)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RestoreProject(context.Background(), &pb.RestoreProjectRequest{ProjectName: projectSpec.Name})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("JobVersions", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		newRepoFactories := func(t *testing.T) (*mock.ProjectRepoFactory, *mock.NamespaceRepoFactory) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			t.Cleanup(func() { projectRepository.AssertExpectations(t) })
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			t.Cleanup(func() { namespaceRepository.AssertExpectations(t) })
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			return projectRepoFactory, namespaceRepoFact
+		}
+
+		t.Run("should return fields changed since revision", func(t *testing.T) {
+			projectRepoFactory, namespaceRepoFact := newRepoFactories(t)
+
+			basePlugin := new(mock.BasePlugin)
+			basePlugin.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: "bq2bq",
+			}, nil)
+			oldSpec := models.JobSpec{
+				Name:     "a-data-job",
+				Owner:    "optimus",
+				Revision: 1,
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{Base: basePlugin},
+				},
+			}
+			currentSpec := oldSpec
+			currentSpec.Owner = "odpf"
+			currentSpec.Revision = 2
+
+			jobService := new(mock.JobService)
+			jobService.On("GetVersion", namespaceSpec, oldSpec.Name, 1).Return(models.JobSpecVersion{Revision: 1, Spec: oldSpec}, nil)
+			jobService.On("GetByName", oldSpec.Name, namespaceSpec).Return(currentSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			diffRequest := pb.GetJobVersionDiffRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, JobName: oldSpec.Name, FromRevision: 1}
+			resp, err := runtimeServiceServer.GetJobVersionDiff(context.Background(), &diffRequest)
+			assert.Nil(t, err)
+			assert.Len(t, resp.GetChanges(), 1)
+			assert.Equal(t, "owner", resp.GetChanges()[0].GetField())
+			assert.Equal(t, "optimus", resp.GetChanges()[0].GetFromValue())
+			assert.Equal(t, "odpf", resp.GetChanges()[0].GetToValue())
+		})
+		t.Run("should rollback the job to revision", func(t *testing.T) {
+			projectRepoFactory, namespaceRepoFact := newRepoFactories(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Rollback", context.Background(), namespaceSpec, "a-data-job", 1).Return(models.JobSpec{Name: "a-data-job", Revision: 3}, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			rollbackRequest := pb.RollbackJobRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, JobName: "a-data-job", Revision: 1}
+			resp, err := runtimeServiceServer.RollbackJob(context.Background(), &rollbackRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, "job a-data-job has been rolled back to revision 1", resp.GetMessage())
+		})
+		t.Run("should fail to rollback to a revision which doesn't exist", func(t *testing.T) {
+			projectRepoFactory, namespaceRepoFact := newRepoFactories(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Rollback", context.Background(), namespaceSpec, "a-data-job", 7).Return(models.JobSpec{}, errors.Wrap(store.ErrResourceNotFound, "failed to retrieve revision 7 of job: a-data-job"))
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			rollbackRequest := pb.RollbackJobRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, JobName: "a-data-job", Revision: 7}
+			resp, err := runtimeServiceServer.RollbackJob(context.Background(), &rollbackRequest)
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("JobStatus", func(t *testing.T) {
+		t.Run("should return all job status via scheduler if valid inputs", func(t *testing.T) {
+			Version := "1.0.0"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			jobStatuses := []models.JobStatus{
+				{
+					ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
+					State:       "failed",
+				},
+				{
+					ScheduledAt: time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC),
+					State:       "success",
+				},
+			}
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetJobStatus", context.Background(), projectSpec, jobSpec.Name).Return(jobStatuses, nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				adapter,
+				nil,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			req := &pb.JobStatusRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			}
+			resp, err := runtimeServiceServer.JobStatus(context.Background(), req)
+			assert.Nil(t, err)
+			assert.Equal(t, len(jobStatuses), len(resp.Statuses))
+			for _, expectedStatus := range jobStatuses {
+				var found bool
+				for _, respVal := range resp.Statuses {
+					if expectedStatus.ScheduledAt.Equal(respVal.ScheduledAt.AsTime()) &&
+						expectedStatus.State.String() == respVal.State {
+						found = true
+						break
+					}
+				}
+				if !found {
+					assert.Fail(t, fmt.Sprintf("failed to find expected job status %v", expectedStatus))
+				}
+			}
+		})
+		t.Run("should return job status from run history if scheduler is unavailable", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetJobStatus", context.Background(), projectSpec, jobSpec.Name).
+				Return([]models.JobStatus{}, errors.New("airflow down"))
+			defer scheduler.AssertExpectations(t)
+
+			scheduledAt := time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC)
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", models.JobRunFilter{JobName: jobSpec.Name}).Return([]models.JobRun{
+				{
+					JobName:     jobSpec.Name,
+					Namespace:   namespaceSpec,
+					ScheduledAt: scheduledAt,
+					State:       models.JobStatusStateSuccess,
+				},
+			}, nil)
+			defer jobRunRepo.AssertExpectations(t)
+			jobRunRepoFactory := new(mock.JobRunRepoFactory)
+			jobRunRepoFactory.On("New", projectSpec).Return(jobRunRepo)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.JobRunRepoFactory = jobRunRepoFactory
+
+			resp, err := runtimeServiceServer.JobStatus(context.Background(), &pb.JobStatusRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.Statuses))
+			assert.Equal(t, scheduledAt, resp.Statuses[0].ScheduledAt.AsTime())
+			assert.Equal(t, models.JobStatusStateSuccess.String(), resp.Statuses[0].State)
+		})
+		t.Run("should return error if scheduler is unavailable and run history is not enabled", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, models.NamespaceSpec{}, nil)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetJobStatus", context.Background(), projectSpec, jobSpec.Name).
+				Return([]models.JobStatus{}, errors.New("airflow down"))
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.JobStatus(context.Background(), &pb.JobStatusRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Nil(t, resp)
+		})
+	})
+
+	t.Run("ClearJobRuns", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+		}
+		startDate := time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+		runs := []models.JobStatus{
+			{ScheduledAt: startDate, State: models.JobStatusStateFailed},
+			{ScheduledAt: endDate, State: models.JobStatusStateSuccess},
+		}
+
+		newServer := func(scheduler models.SchedulerUnit) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, models.NamespaceSpec{}, nil)
+
+			return v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+		}
+
+		t.Run("should clear runs of job between dates", func(t *testing.T) {
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetDagRunStatus", context.Background(), projectSpec, jobSpec.Name, startDate, endDate, 100).Return(runs, nil)
+			scheduler.On("Clear", context.Background(), projectSpec, jobSpec.Name, startDate, endDate).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
+			resp, err := newServer(scheduler).ClearJobRuns(context.Background(), &pb.ClearJobRunsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   timestamppb.New(startDate),
+				EndDate:     timestamppb.New(endDate),
+			})
+			assert.Nil(t, err)
+			assert.True(t, resp.GetSuccess())
+			assert.Equal(t, 2, len(resp.GetRuns()))
+			assert.Equal(t, startDate, resp.GetRuns()[0].GetScheduledAt().AsTime())
+			assert.Equal(t, models.JobStatusStateFailed.String(), resp.GetRuns()[0].GetState())
+		})
+		t.Run("should only list runs of job on dry run", func(t *testing.T) {
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetDagRunStatus", context.Background(), projectSpec, jobSpec.Name, startDate, startDate, 100).Return(runs[:1], nil)
+			defer scheduler.AssertExpectations(t)
+
+			resp, err := newServer(scheduler).ClearJobRuns(context.Background(), &pb.ClearJobRunsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   timestamppb.New(startDate),
+				DryRun:      true,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.GetRuns()))
+			scheduler.AssertNotCalled(t, "Clear", mock2.Anything, mock2.Anything, mock2.Anything,
+				mock2.Anything, mock2.Anything)
+		})
+		t.Run("should return error if end date is before start date", func(t *testing.T) {
+			resp, err := newServer(new(mock.Scheduler)).ClearJobRuns(context.Background(), &pb.ClearJobRunsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   timestamppb.New(endDate),
+				EndDate:     timestamppb.New(startDate),
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, resp)
+		})
+	})
+
+	t.Run("GetJobRuns", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+		newServer := func(jobRunRepoFactory v1.JobRunRepoFactory) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			server := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			if jobRunRepoFactory != nil {
+				server.JobRunRepoFactory = jobRunRepoFactory
+			}
+			return server
+		}
+		t.Run("should return runs matching filters along with counts of runs in each state", func(t *testing.T) {
+			startDate := time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC)
+			endDate := time.Date(2020, 11, 12, 0, 0, 0, 0, time.UTC)
+			startTime := time.Date(2020, 11, 11, 0, 1, 0, 0, time.UTC)
+			filter := models.JobRunFilter{
+				JobName:   "transform-tables",
+				StartDate: startDate,
+				EndDate:   endDate,
+			}
+			statesFilter := filter
+			statesFilter.States = []models.JobStatusState{models.JobStatusStateFailed}
+
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("CountByState", filter).Return(map[models.JobStatusState]int{
+				models.JobStatusStateSuccess: 2,
+				models.JobStatusStateFailed:  1,
+			}, nil)
+			jobRunRepo.On("GetAll", statesFilter).Return([]models.JobRun{
+				{
+					JobName:     "transform-tables",
+					Namespace:   namespaceSpec,
+					ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateFailed,
+					StartTime:   startTime,
+					Attempt:     2,
+				},
+			}, nil)
+			defer jobRunRepo.AssertExpectations(t)
+			jobRunRepoFactory := new(mock.JobRunRepoFactory)
+			jobRunRepoFactory.On("New", projectSpec).Return(jobRunRepo)
+
+			resp, err := newServer(jobRunRepoFactory).GetJobRuns(context.Background(), &pb.GetJobRunsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     "transform-tables",
+				States:      []string{"FAILED"},
+				StartDate:   timestamppb.New(startDate),
+				EndDate:     timestamppb.New(endDate),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]int64{"success": 2, "failed": 1}, resp.StateCounts)
+			assert.Equal(t, 1, len(resp.Runs))
+			assert.Equal(t, "game_jam", resp.Runs[0].Namespace)
+			assert.Equal(t, "failed", resp.Runs[0].State)
+			assert.Equal(t, startTime, resp.Runs[0].StartTime.AsTime())
+			assert.Nil(t, resp.Runs[0].EndTime)
+			assert.Equal(t, int32(2), resp.Runs[0].Attempt)
+		})
+		t.Run("should return error if end date is before start date", func(t *testing.T) {
+			jobRunRepoFactory := new(mock.JobRunRepoFactory)
+			resp, err := newServer(jobRunRepoFactory).GetJobRuns(context.Background(), &pb.GetJobRunsRequest{
+				ProjectName: projectSpec.Name,
+				StartDate:   timestamppb.New(time.Date(2020, 11, 12, 0, 0, 0, 0, time.UTC)),
+				EndDate:     timestamppb.New(time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC)),
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, resp)
+		})
+		t.Run("should return error if run history is not enabled", func(t *testing.T) {
+			resp, err := newServer(nil).GetJobRuns(context.Background(), &pb.GetJobRunsRequest{
+				ProjectName: projectSpec.Name,
+			})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+			assert.Nil(t, resp)
+		})
+	})
+
+	t.Run("RegisterJobEvent", func(t *testing.T) {
+		t.Run("should register the event if valid inputs", func(t *testing.T) {
+			Version := "1.0.0"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: "transform-tables",
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
+			defer jobService.AssertExpectations(t)
+
+			eventValues, _ := structpb.NewStruct(
+				map[string]interface{}{
+					"url": "http://example.io",
+				},
+			)
+			eventSvc := new(mock.EventService)
+			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpecs[0], models.JobEvent{
+				Type:  models.JobEventTypeFailure,
+				Value: eventValues.GetFields(),
+			}).Return(nil)
+			defer eventSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, eventSvc, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			req := &pb.RegisterJobEventRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpecs[0].Name,
+				Namespace:   namespaceSpec.Name,
+				Event: &pb.JobEvent{
+					Type:  pb.JobEvent_FAILURE,
+					Value: eventValues,
+				},
+			}
+			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), req)
+			assert.Nil(t, err)
+		})
+		t.Run("should register the event even if reporting it to lineage fails", func(t *testing.T) {
+			Version := "1.0.0"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: "transform-tables",
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
 			jobService := new(mock.JobService)
@@ -1215,48 +4361,732 @@ func TestRuntimeServiceServer(t *testing.T) {
 
 			eventValues, _ := structpb.NewStruct(
 				map[string]interface{}{
-					"url": "http://example.io",
+					"url": "http://example.io",
+				},
+			)
+			eventSvc := new(mock.EventService)
+			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpecs[0], models.JobEvent{
+				Type:  models.JobEventTypeFailure,
+				Value: eventValues.GetFields(),
+			}).Return(nil)
+			defer eventSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, eventSvc, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			lineage := &runLineage{err: errors.New("lineage api is down")}
+			runtimeServiceServer.RunLineage = lineage
+			req := &pb.RegisterJobEventRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpecs[0].Name,
+				Namespace:   namespaceSpec.Name,
+				Event: &pb.JobEvent{
+					Type:  pb.JobEvent_FAILURE,
+					Value: eventValues,
+				},
+			}
+			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), req)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobEvent{{
+				Type:  models.JobEventTypeFailure,
+				Value: eventValues.GetFields(),
+			}}, lineage.events)
+		})
+		t.Run("should record run of the job in run history on success or failure", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpec.Name, namespaceSpec).Return(jobSpec, nil)
+
+			eventValues, _ := structpb.NewStruct(
+				map[string]interface{}{
+					"scheduled_at": "2020-11-11T00:00:00Z",
+					"start_time":   "2020-11-11T00:01:00Z",
+					"attempt":      2,
+				},
+			)
+			eventSvc := new(mock.EventService)
+			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpec, models.JobEvent{
+				Type:  models.JobEventTypeFailure,
+				Value: eventValues.GetFields(),
+			}).Return(nil)
+
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			createdAt := time.Date(2020, 11, 11, 0, 5, 0, 0, time.UTC)
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", models.JobRunFilter{
+				JobName:   jobSpec.Name,
+				StartDate: scheduledAt,
+				EndDate:   scheduledAt,
+			}).Return([]models.JobRun{
+				{
+					JobName:     jobSpec.Name,
+					Namespace:   namespaceSpec,
+					ScheduledAt: scheduledAt,
+					State:       models.JobStatusStateRunning,
+					CreatedAt:   createdAt,
+				},
+			}, nil)
+			jobRunRepo.On("Save", mock2.MatchedBy(func(run models.JobRun) bool {
+				return run.JobName == jobSpec.Name && run.ScheduledAt.Equal(scheduledAt) &&
+					run.State == models.JobStatusStateFailed && run.Namespace.ID == namespaceSpec.ID &&
+					run.StartTime.Equal(time.Date(2020, 11, 11, 0, 1, 0, 0, time.UTC)) && run.EndTime.IsZero() &&
+					run.Attempt == 2 && run.CreatedAt.Equal(createdAt)
+			})).Return(nil)
+			defer jobRunRepo.AssertExpectations(t)
+			jobRunRepoFactory := new(mock.JobRunRepoFactory)
+			jobRunRepoFactory.On("New", projectSpec).Return(jobRunRepo)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, eventSvc, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.JobRunRepoFactory = jobRunRepoFactory
+			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), &pb.RegisterJobEventRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				Namespace:   namespaceSpec.Name,
+				Event: &pb.JobEvent{
+					Type:  pb.JobEvent_FAILURE,
+					Value: eventValues,
+				},
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should mark run of the job as running when it is retried", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpec.Name, namespaceSpec).Return(jobSpec, nil)
+
+			eventValues, _ := structpb.NewStruct(
+				map[string]interface{}{
+					"scheduled_at": "2020-11-11T00:00:00Z",
+					"start_time":   "2020-11-11T00:10:00Z",
+					"attempt":      2,
+				},
+			)
+			eventSvc := new(mock.EventService)
+			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpec, models.JobEvent{
+				Type:  models.JobEventTypeRetry,
+				Value: eventValues.GetFields(),
+			}).Return(nil)
+			defer eventSvc.AssertExpectations(t)
+
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			startTime := time.Date(2020, 11, 11, 0, 1, 0, 0, time.UTC)
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", models.JobRunFilter{
+				JobName:   jobSpec.Name,
+				StartDate: scheduledAt,
+				EndDate:   scheduledAt,
+			}).Return([]models.JobRun{
+				{
+					JobName:     jobSpec.Name,
+					Namespace:   namespaceSpec,
+					ScheduledAt: scheduledAt,
+					State:       models.JobStatusStateFailed,
+					StartTime:   startTime,
+					Attempt:     1,
+				},
+			}, nil)
+			jobRunRepo.On("Save", mock2.MatchedBy(func(run models.JobRun) bool {
+				return run.State == models.JobStatusStateRunning && run.StartTime.Equal(startTime) && run.Attempt == 2
+			})).Return(nil)
+			defer jobRunRepo.AssertExpectations(t)
+			jobRunRepoFactory := new(mock.JobRunRepoFactory)
+			jobRunRepoFactory.On("New", projectSpec).Return(jobRunRepo)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, eventSvc, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.JobRunRepoFactory = jobRunRepoFactory
+			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), &pb.RegisterJobEventRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				Namespace:   namespaceSpec.Name,
+				Event: &pb.JobEvent{
+					Type:  pb.JobEvent_RETRY,
+					Value: eventValues,
+				},
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should mark runs scheduler reported an sla miss for", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpec.Name, namespaceSpec).Return(jobSpec, nil)
+
+			eventValues, _ := structpb.NewStruct(
+				map[string]interface{}{
+					"slas": []interface{}{
+						map[string]interface{}{
+							"task_id":      "bq2bq",
+							"scheduled_at": "2020-11-11T00:00:00Z",
+						},
+						map[string]interface{}{
+							"task_id":      "hook",
+							"scheduled_at": "2020-11-11T00:00:00Z",
+						},
+					},
 				},
 			)
 			eventSvc := new(mock.EventService)
-			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpecs[0], models.JobEvent{
-				Type:  models.JobEventTypeFailure,
+			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpec, models.JobEvent{
+				Type:  models.JobEventTypeSLAMiss,
 				Value: eventValues.GetFields(),
 			}).Return(nil)
 			defer eventSvc.AssertExpectations(t)
 
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			startTime := time.Date(2020, 11, 11, 0, 1, 0, 0, time.UTC)
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", models.JobRunFilter{
+				JobName:   jobSpec.Name,
+				StartDate: scheduledAt,
+				EndDate:   scheduledAt,
+			}).Return([]models.JobRun{
+				{
+					JobName:     jobSpec.Name,
+					Namespace:   namespaceSpec,
+					ScheduledAt: scheduledAt,
+					State:       models.JobStatusStateFailed,
+					StartTime:   startTime,
+					Attempt:     1,
+				},
+			}, nil)
+			jobRunRepo.On("Save", mock2.MatchedBy(func(run models.JobRun) bool {
+				return run.State == models.JobStatusStateFailed && run.StartTime.Equal(startTime) && !run.SLAMissedAt.IsZero()
+			})).Return(nil).Once()
+			defer jobRunRepo.AssertExpectations(t)
+			jobRunRepoFactory := new(mock.JobRunRepoFactory)
+			jobRunRepoFactory.On("New", projectSpec).Return(jobRunRepo)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
+				"1.0.0",
 				jobService, eventSvc, nil,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				adapter,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.JobRunRepoFactory = jobRunRepoFactory
+			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), &pb.RegisterJobEventRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				Namespace:   namespaceSpec.Name,
+				Event: &pb.JobEvent{
+					Type:  pb.JobEvent_SLA_MISS,
+					Value: eventValues,
+				},
+			})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetLineage", func(t *testing.T) {
+		newServer := func() *v1.RuntimeServiceServer {
+			repo := memory.NewJobLineageRepository(memory.NewDB())
+			assert.Nil(t, repo.Save(models.JobLineage{
+				ProjectName:   "a-data-project",
+				NamespaceName: "dev-team-1",
+				JobName:       "a-data-job",
+				Destination:   "bigquery://a-data-project:sales.orders",
+				Dependencies:  []string{"bigquery://a-data-project:sales.raw"},
+			}))
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.JobLineage = job.NewLineageService(repo, 5)
+			return runtimeServiceServer
+		}
+		t.Run("should return graph of job", func(t *testing.T) {
+			resp, err := newServer().GetLineage(context.Background(), &pb.GetLineageRequest{
+				ProjectName: "a-data-project",
+				JobName:     "a-data-job",
+				Direction:   "upstream",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, []*pb.LineageNode{
+				{
+					Id:          "job:a-data-project/a-data-job",
+					Type:        "job",
+					ProjectName: "a-data-project",
+					Namespace:   "dev-team-1",
+					JobName:     "a-data-job",
+					Urn:         "bigquery://a-data-project:sales.orders",
+				},
+				{
+					Id:   "resource:bigquery://a-data-project:sales.raw",
+					Type: "resource",
+					Urn:  "bigquery://a-data-project:sales.raw",
+				},
+			}, resp.Nodes)
+			assert.Equal(t, []*pb.LineageEdge{
+				{Source: "resource:bigquery://a-data-project:sales.raw", Target: "job:a-data-project/a-data-job"},
+			}, resp.Edges)
+		})
+		t.Run("should fail for unknown direction", func(t *testing.T) {
+			_, err := newServer().GetLineage(context.Background(), &pb.GetLineageRequest{
+				ProjectName: "a-data-project",
+				JobName:     "a-data-job",
+				Direction:   "sideways",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should fail if lineage of job isn't recorded", func(t *testing.T) {
+			_, err := newServer().GetLineage(context.Background(), &pb.GetLineageRequest{
+				ProjectName: "a-data-project",
+				JobName:     "unknown-job",
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("GetWindow", func(t *testing.T) {
+		t.Run("should return the correct window date range", func(t *testing.T) {
+			Version := "1.0.1"
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			scheduledAtTimestamp := timestamppb.New(scheduledAt)
+			req := pb.GetWindowRequest{
+				ScheduledAt: scheduledAtTimestamp,
+				Size:        "24h",
+				Offset:      "24h",
+				TruncateTo:  "d",
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "2020-11-11T00:00:00Z", ptypes.TimestampString(resp.GetStart()))
+			assert.Equal(t, "2020-11-12T00:00:00Z", ptypes.TimestampString(resp.GetEnd()))
+		})
+		t.Run("should truncate window at day boundary of the requested timezone", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			// 2020-11-11 02:00 in Asia/Kolkata
+			scheduledAt := time.Date(2020, 11, 10, 20, 30, 0, 0, time.UTC)
+			req := pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(scheduledAt),
+				Size:        "24h",
+				Offset:      "0",
+				TruncateTo:  "d",
+				Timezone:    "Asia/Kolkata",
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "2020-11-09T18:30:00Z", ptypes.TimestampString(resp.GetStart()))
+			assert.Equal(t, "2020-11-10T18:30:00Z", ptypes.TimestampString(resp.GetEnd()))
+		})
+		t.Run("should return error if timezone is unknown", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			req := pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)),
+				Size:        "24h",
+				Offset:      "0",
+				TruncateTo:  "d",
+				Timezone:    "Mars/Olympus",
+			}
+			_, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should return error if any of the required fields in request is missing", func(t *testing.T) {
+			Version := "1.0.1"
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				nil, nil,
+				nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			scheduledAtTimestamp := timestamppb.New(scheduledAt)
+			req := pb.GetWindowRequest{
+				ScheduledAt: scheduledAtTimestamp,
+				Size:        "",
+				Offset:      "24h",
+				TruncateTo:  "d",
+			}
+			_, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Equal(t, "rpc error: code = InvalidArgument desc = window preset or size, offset and truncate_to must be provided", err.Error())
+		})
+		t.Run("should return the window of a preset", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			req := pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2021, 5, 11, 2, 0, 0, 0, time.UTC)),
+				Preset:      "last_quarter",
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "2021-01-01T00:00:00Z", ptypes.TimestampString(resp.GetStart()))
+			assert.Equal(t, "2021-03-31T00:00:00Z", ptypes.TimestampString(resp.GetEnd()))
+		})
+		t.Run("should compute window with the calendar of the project", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectCalendarHolidays: "2021-03-08",
+				},
+			}
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			// tuesday after a holiday on monday
+			req := pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2021, 3, 9, 2, 0, 0, 0, time.UTC)),
+				Preset:      "yesterday",
+				Modifier:    models.JobSpecTaskWindowModifierPreviousWorkingDay,
+				ProjectName: projectSpec.Name,
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "2021-03-05T00:00:00Z", ptypes.TimestampString(resp.GetStart()))
+			assert.Equal(t, "2021-03-06T00:00:00Z", ptypes.TimestampString(resp.GetEnd()))
+		})
+		t.Run("should return error if preset is unknown or window is invalid", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			_, err := runtimeServiceServer.GetWindow(context.Background(), &pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2021, 5, 11, 2, 0, 0, 0, time.UTC)),
+				Preset:      "last_decade",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			_, err = runtimeServiceServer.GetWindow(context.Background(), &pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2021, 5, 11, 2, 0, 0, 0, time.UTC)),
+				Size:        "24h",
+				Offset:      "0",
+				TruncateTo:  "y",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ListSupportedTasks", func(t *testing.T) {
+		t.Run("should return tasks sorted by name along with their questions", func(t *testing.T) {
+			execUnit1 := new(mock.BasePlugin)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:          "bq2bq",
+				Image:         "odpf/bq2bq:1.0.0",
+				PluginVersion: "1.0.0",
+				PluginType:    models.PluginTypeTask,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+			cliMod := new(mock.CLIMod)
+			cliMod.On("GetQuestions", context.Background(), models.GetQuestionsRequest{
+				PluginOptions: models.PluginOptions{DryRun: true},
+			}).Return(&models.GetQuestionsResponse{
+				Questions: models.PluginQuestions{
+					{Name: "PROJECT", Prompt: "Project ID"},
+				},
+			}, nil)
+			defer cliMod.AssertExpectations(t)
+			execUnit2 := new(mock.BasePlugin)
+			execUnit2.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:       "a-task",
+				Image:      "odpf/a-task:latest",
+				PluginType: models.PluginTypeTask,
+			}, nil)
+			defer execUnit2.AssertExpectations(t)
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetTasks").Return([]*models.Plugin{
+				{Base: execUnit1, CLIMod: cliMod},
+				{Base: execUnit2},
+			})
+			defer pluginRepo.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
+				pluginRepo,
 			)
-			req := &pb.RegisterJobEventRequest{
-				ProjectName: projectSpec.Name,
-				JobName:     jobSpecs[0].Name,
-				Namespace:   namespaceSpec.Name,
-				Event: &pb.JobEvent{
-					Type:  pb.JobEvent_FAILURE,
-					Value: eventValues,
-				},
-			}
-			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), req)
+			resp, err := runtimeServiceServer.ListSupportedTasks(context.Background(), &pb.ListSupportedTasksRequest{})
 			assert.Nil(t, err)
+			assert.Equal(t, 2, len(resp.GetTasks()))
+			assert.Equal(t, "a-task", resp.GetTasks()[0].GetName())
+			assert.Equal(t, "bq2bq", resp.GetTasks()[1].GetName())
+			assert.Equal(t, "odpf/bq2bq:1.0.0", resp.GetTasks()[1].GetImage())
+			assert.Equal(t, "PROJECT", resp.GetTasks()[1].GetQuestions()[0].GetName())
 		})
 	})
-
-	t.Run("GetWindow", func(t *testing.T) {
-		t.Run("should return the correct window date range", func(t *testing.T) {
-			Version := "1.0.1"
+	t.Run("ValidatePluginAnswers", func(t *testing.T) {
+		questions := models.PluginQuestions{
+			{Name: "PROJECT", Prompt: "Project ID", Validation: "^[a-z-]+$"},
+			{
+				Name:        "LOAD_METHOD",
+				Prompt:      "Load method",
+				Default:     "APPEND",
+				Multiselect: []string{"APPEND", "REPLACE"},
+				SubQuestions: []models.PluginSubQuestion{{
+					IfValue:   "REPLACE",
+					Questions: models.PluginQuestions{{Name: "PARTITION_FILTER", Prompt: "Partition filter"}},
+				}},
+			},
+		}
+		validateRequest := func(question models.PluginQuestion, value string) models.ValidateQuestionRequest {
+			return models.ValidateQuestionRequest{
+				PluginOptions: models.PluginOptions{DryRun: true},
+				Answer: models.PluginAnswer{
+					Question: question,
+					Value:    value,
+				},
+			}
+		}
+		newServer := func(cliMod *mock.CLIMod) (*v1.RuntimeServiceServer, func()) {
+			cliMod.On("GetQuestions", context.Background(), models.GetQuestionsRequest{
+				PluginOptions: models.PluginOptions{DryRun: true},
+			}).Return(&models.GetQuestionsResponse{Questions: questions}, nil)
+			pluginRepo := new(mock.SupportedPluginRepo)
+			pluginRepo.On("GetByName", "bq2bq").Return(&models.Plugin{Base: new(mock.BasePlugin), CLIMod: cliMod}, nil)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				nil, nil, nil,
+				"Version",
+				nil,
 				nil,
 				nil,
 				nil,
@@ -1264,45 +5094,57 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
-			)
-			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
-			scheduledAtTimestamp := timestamppb.New(scheduledAt)
-			req := pb.GetWindowRequest{
-				ScheduledAt: scheduledAtTimestamp,
-				Size:        "24h",
-				Offset:      "24h",
-				TruncateTo:  "d",
-			}
-			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
-			assert.Nil(t, err)
-
-			assert.Equal(t, "2020-11-11T00:00:00Z", ptypes.TimestampString(resp.GetStart()))
-			assert.Equal(t, "2020-11-12T00:00:00Z", ptypes.TimestampString(resp.GetEnd()))
-		})
-		t.Run("should return error if any of the required fields in request is missing", func(t *testing.T) {
-			Version := "1.0.1"
-
-			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				nil, nil,
-				nil, nil,
 				nil,
 				nil,
 				nil,
 				nil,
 				nil,
 				nil,
+				pluginRepo,
 			)
-			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
-			scheduledAtTimestamp := timestamppb.New(scheduledAt)
-			req := pb.GetWindowRequest{
-				ScheduledAt: scheduledAtTimestamp,
-				Size:        "",
-				Offset:      "24h",
-				TruncateTo:  "d",
+			return runtimeServiceServer, func() {
+				cliMod.AssertExpectations(t)
+				pluginRepo.AssertExpectations(t)
 			}
-			_, err := runtimeServiceServer.GetWindow(context.Background(), &req)
-			assert.Equal(t, "rpc error: code = InvalidArgument desc = window size, offset and truncate_to must be provided", err.Error())
+		}
+		t.Run("should succeed if answers are valid", func(t *testing.T) {
+			cliMod := new(mock.CLIMod)
+			cliMod.On("ValidateQuestion", context.Background(), validateRequest(questions[0], "example")).
+				Return(&models.ValidateQuestionResponse{Success: true}, nil)
+			cliMod.On("ValidateQuestion", context.Background(), validateRequest(questions[1], "REPLACE")).
+				Return(&models.ValidateQuestionResponse{Success: true}, nil)
+			cliMod.On("ValidateQuestion", context.Background(), validateRequest(questions[1].SubQuestions[0].Questions[0], "true")).
+				Return(&models.ValidateQuestionResponse{Success: true}, nil)
+			runtimeServiceServer, assertExpectations := newServer(cliMod)
+			defer assertExpectations()
+
+			resp, err := runtimeServiceServer.ValidatePluginAnswers(context.Background(), &pb.ValidatePluginAnswersRequest{
+				PluginName: "bq2bq",
+				Answers:    map[string]string{"PROJECT": "example", "LOAD_METHOD": "REPLACE", "PARTITION_FILTER": "true"},
+			})
+			assert.Nil(t, err)
+			assert.True(t, resp.GetSuccess())
+			assert.Equal(t, 0, len(resp.GetErrors()))
+		})
+		t.Run("should return errors of answers rejected by question or plugin", func(t *testing.T) {
+			cliMod := new(mock.CLIMod)
+			cliMod.On("ValidateQuestion", context.Background(), validateRequest(questions[1], "REPLACE")).
+				Return(&models.ValidateQuestionResponse{Success: true}, nil)
+			cliMod.On("ValidateQuestion", context.Background(), validateRequest(questions[1].SubQuestions[0].Questions[0], "")).
+				Return(&models.ValidateQuestionResponse{Error: "filter can't be empty"}, nil)
+			runtimeServiceServer, assertExpectations := newServer(cliMod)
+			defer assertExpectations()
+
+			resp, err := runtimeServiceServer.ValidatePluginAnswers(context.Background(), &pb.ValidatePluginAnswersRequest{
+				PluginName: "bq2bq",
+				Answers:    map[string]string{"PROJECT": "Example_1", "LOAD_METHOD": "REPLACE"},
+			})
+			assert.Nil(t, err)
+			assert.False(t, resp.GetSuccess())
+			assert.Equal(t, 2, len(resp.GetErrors()))
+			assert.Equal(t, "PROJECT", resp.GetErrors()[0].GetName())
+			assert.Equal(t, "PARTITION_FILTER", resp.GetErrors()[1].GetName())
+			assert.Equal(t, "filter can't be empty", resp.GetErrors()[1].GetMessage())
 		})
 	})
 
@@ -1415,6 +5257,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			req := pb.DumpJobSpecificationRequest{
@@ -1429,6 +5276,120 @@ func TestRuntimeServiceServer(t *testing.T) {
 		})
 	})
 
+	t.Run("CompileJob", func(t *testing.T) {
+		projectName := "a-data-project"
+		jobName := "a-data-job"
+		taskName := "a-data-task"
+
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+
+		execUnit := new(mock.BasePlugin)
+		execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name: taskName,
+		}, nil)
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", taskName).Return(&models.Plugin{
+			Base: execUnit,
+		}, nil)
+		adapter := v1.NewAdapter(pluginRepo, nil)
+
+		jobSpec := models.JobSpec{
+			Name: jobName,
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{
+					Base: execUnit,
+				},
+			},
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Interval:  "@hourly",
+			},
+		}
+		jobProto, _ := adapter.ToJobProto(jobSpec)
+		adaptedJobSpec, _ := adapter.FromJobProto(jobProto)
+
+		newServer := func(jobService models.JobService) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			return v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+		}
+
+		t.Run("should compile deployed job if no spec is given", func(t *testing.T) {
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobName, namespaceSpec).Return(jobSpec, nil)
+			jobService.On("Dump", namespaceSpec, jobSpec).Return(models.Job{Contents: []byte("deployed-dag")}, nil)
+			defer jobService.AssertExpectations(t)
+
+			resp, err := newServer(jobService).CompileJob(context.Background(), &pb.CompileJobRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, "deployed-dag", resp.GetContent())
+		})
+		t.Run("should compile given spec in place of deployed job", func(t *testing.T) {
+			jobService := new(mock.JobService)
+			jobService.On("Compile", namespaceSpec, adaptedJobSpec).Return(models.Job{Contents: []byte("local-dag")}, nil)
+			defer jobService.AssertExpectations(t)
+
+			resp, err := newServer(jobService).CompileJob(context.Background(), &pb.CompileJobRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				Job:         jobProto,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, "local-dag", resp.GetContent())
+		})
+		t.Run("should fail if name of given spec doesn't match", func(t *testing.T) {
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			_, err := newServer(jobService).CompileJob(context.Background(), &pb.CompileJobRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     "other-job",
+				Job:         jobProto,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
 	t.Run("CreateResource", func(t *testing.T) {
 		t.Run("should create datastore resource successfully", func(t *testing.T) {
 			projectName := "a-data-project"
@@ -1519,6 +5480,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			resp, err := runtimeServiceServer.CreateResource(context.Background(), &req)
@@ -1588,40 +5554,277 @@ func TestRuntimeServiceServer(t *testing.T) {
 
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
-			defer projectRepository.AssertExpectations(t)
-
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("UpdateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, dsRepo),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.UpdateResource(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+		})
+	})
+
+	t.Run("Backups", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		backupSpec := models.BackupSpec{
+			ID:           uuid.Must(uuid.NewRandom()),
+			ResourceName: "proj.sales.orders",
+			Datastore:    "bigquery",
+			Description:  "before replay",
+			Results: []models.BackupResult{
+				{ResourceName: "proj.sales.orders", URN: "bigquery://proj:optimus_backup.orders"},
+			},
+			CreatedAt: time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC),
+		}
+		backupProto := &pb.BackupSpec{
+			Id:            backupSpec.ID.String(),
+			ResourceName:  "proj.sales.orders",
+			DatastoreName: "bigquery",
+			Description:   "before replay",
+			CreatedAt:     timestamppb.New(backupSpec.CreatedAt),
+			Results: []*pb.BackupResult{
+				{ResourceName: "proj.sales.orders", Urn: "bigquery://proj:optimus_backup.orders"},
+			},
+		}
+		newServer := func(backups v1.ResourceBackups) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.Backups = backups
+			return runtimeServiceServer
+		}
+
+		t.Run("should backup resource and the ones downstream of it", func(t *testing.T) {
+			backupSvc := new(mock.BackupService)
+			defer backupSvc.AssertExpectations(t)
+			backupSvc.On("Backup", context.Background(), models.BackupRequest{
+				ResourceName: "proj.sales.orders",
+				Namespace:    namespaceSpec,
+				Datastore:    "bigquery",
+				Description:  "before replay",
+				Config:       map[string]string{models.BackupConfigTTL: "168h"},
+			}).Return(models.BackupResponse{
+				Backup:           backupSpec,
+				IgnoredResources: []string{"bigquery://other:reports.sales"},
+			}, nil)
+
+			resp, err := newServer(backupSvc).BackupResource(context.Background(), &pb.BackupResourceRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				ResourceName:  "proj.sales.orders",
+				Description:   "before replay",
+				Config:        map[string]string{models.BackupConfigTTL: "168h"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+			assert.Equal(t, backupProto, resp.GetBackup())
+			assert.Equal(t, []string{"bigquery://other:reports.sales"}, resp.GetIgnoredResources())
+		})
+		t.Run("should fail backup of resources datastore can't back up", func(t *testing.T) {
+			backupSvc := new(mock.BackupService)
+			backupSvc.On("Backup", context.Background(), mock2.Anything).Return(models.BackupResponse{}, models.ErrUnsupportedResource)
+
+			_, err := newServer(backupSvc).BackupResource(context.Background(), &pb.BackupResourceRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				ResourceName:  "proj.sales.orders_view",
+			})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+		t.Run("should list backups of datastore", func(t *testing.T) {
+			backupSvc := new(mock.BackupService)
+			defer backupSvc.AssertExpectations(t)
+			backupSvc.On("List", context.Background(), namespaceSpec, "bigquery").Return([]models.BackupSpec{backupSpec}, nil)
+
+			resp, err := newServer(backupSvc).ListBackups(context.Background(), &pb.ListBackupsRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, []*pb.BackupSpec{backupProto}, resp.GetBackups())
+		})
+		t.Run("should restore backup", func(t *testing.T) {
+			backupSvc := new(mock.BackupService)
+			defer backupSvc.AssertExpectations(t)
+			backupSvc.On("Restore", context.Background(), namespaceSpec, "bigquery", backupSpec.ID).Return(backupSpec, nil)
+
+			resp, err := newServer(backupSvc).RestoreBackup(context.Background(), &pb.RestoreBackupRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				Id:            backupSpec.ID.String(),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+			assert.Equal(t, backupProto, resp.GetBackup())
+		})
+		t.Run("should fail restore of unknown backup", func(t *testing.T) {
+			backupSvc := new(mock.BackupService)
+			backupSvc.On("Restore", context.Background(), namespaceSpec, "bigquery", backupSpec.ID).Return(models.BackupSpec{}, store.ErrResourceNotFound)
+
+			_, err := newServer(backupSvc).RestoreBackup(context.Background(), &pb.RestoreBackupRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				Id:            backupSpec.ID.String(),
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+		t.Run("should fail if backups aren't served", func(t *testing.T) {
+			_, err := newServer(nil).ListBackups(context.Background(), &pb.ListBackupsRequest{
+				ProjectName: projectName,
+			})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		})
+	})
+
+	t.Run("CheckResource", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		newServer := func(resourceSvc models.DatastoreService) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
-			defer projectRepoFactory.AssertExpectations(t)
-
-			resourceSvc := new(mock.DatastoreService)
-			resourceSvc.On("UpdateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).Return(nil)
-			defer resourceSvc.AssertExpectations(t)
 
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
-			defer namespaceRepository.AssertExpectations(t)
-
 			namespaceRepoFact := new(mock.NamespaceRepoFactory)
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
-			defer namespaceRepoFact.AssertExpectations(t)
 
-			runtimeServiceServer := v1.NewRuntimeServiceServer(
+			return v1.NewRuntimeServiceServer(
 				"Version",
 				nil, nil,
 				resourceSvc,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				v1.NewAdapter(nil, dsRepo),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
+		}
 
-			resp, err := runtimeServiceServer.UpdateResource(context.Background(), &req)
+		t.Run("should list drifts of resource from its spec", func(t *testing.T) {
+			resourceSvc := new(mock.DatastoreService)
+			defer resourceSvc.AssertExpectations(t)
+			resourceSvc.On("CheckResource", context.Background(), namespaceSpec, "bigquery", "proj.sales.orders").
+				Return(models.CheckResourceResponse{
+					Drifts: []models.ResourceDrift{
+						{Field: "schema.added", Declared: "STRING nullable"},
+						{Field: "schema.id.type", Declared: "STRING", Live: "INT64", Incompatible: true},
+					},
+				}, nil)
+
+			resp, err := newServer(resourceSvc).CheckResource(context.Background(), &pb.CheckResourceRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				ResourceName:  "proj.sales.orders",
+			})
 			assert.Nil(t, err)
-			assert.Equal(t, true, resp.GetSuccess())
+			assert.Equal(t, &pb.CheckResourceResponse{
+				Drifts: []*pb.ResourceDrift{
+					{Field: "schema.added", Declared: "STRING nullable"},
+					{Field: "schema.id.type", Declared: "STRING", Live: "INT64", Incompatible: true},
+				},
+				Incompatible: true,
+			}, resp)
+		})
+		t.Run("should fail precondition for resources datastore can't check", func(t *testing.T) {
+			resourceSvc := new(mock.DatastoreService)
+			defer resourceSvc.AssertExpectations(t)
+			resourceSvc.On("CheckResource", context.Background(), namespaceSpec, "bigquery", "proj.sales.orders_view").
+				Return(models.CheckResourceResponse{}, models.ErrUnsupportedResource)
+
+			_, err := newServer(resourceSvc).CheckResource(context.Background(), &pb.CheckResourceRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				ResourceName:  "proj.sales.orders_view",
+			})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 		})
 	})
 
@@ -1710,6 +5913,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1763,6 +5971,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1819,6 +6032,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1915,6 +6133,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1927,6 +6150,82 @@ func TestRuntimeServiceServer(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, randomUUID, replayResponse.Id)
 		})
+		t.Run("should list runs replay would clear without clearing them on dry run", func(t *testing.T) {
+			replayWorkerRequest := &models.ReplayWorkerRequest{
+				Job:     jobSpec,
+				Start:   startDate,
+				End:     endDate,
+				Project: projectSpec,
+			}
+			dependentJobSpec := models.JobSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-dependent-job",
+			}
+			rootNode := tree.NewTreeNode(jobSpec)
+			rootNode.Runs.Add(startDate)
+			rootNode.Runs.Add(endDate)
+			dependentNode := tree.NewTreeNode(dependentJobSpec)
+			dependentNode.Runs.Add(endDate)
+			rootNode.AddDependent(dependentNode)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobName, namespaceSpec).Return(jobSpec, nil)
+			jobService.On("ReplayDryRun", replayWorkerRequest).Return(rootNode, nil)
+			defer jobService.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+			adapter := v1.NewAdapter(nil, nil)
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil,
+				nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			replayRequest := pb.ReplayRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				StartDate:   startDate.Format(timeLayout),
+				EndDate:     endDate.Format(timeLayout),
+				DryRun:      true,
+			}
+			replayResponse, err := runtimeServiceServer.Replay(context.TODO(), &replayRequest)
+			assert.Nil(t, err)
+			assert.Empty(t, replayResponse.Id)
+			assert.Equal(t, int64(3), replayResponse.RunCount)
+			assert.Equal(t, 2, len(replayResponse.JobRuns))
+			assert.Equal(t, jobName, replayResponse.JobRuns[0].JobName)
+			assert.Equal(t, 2, len(replayResponse.JobRuns[0].Runs))
+			assert.Equal(t, dependentJobSpec.Name, replayResponse.JobRuns[1].JobName)
+			assert.Equal(t, endDate, replayResponse.JobRuns[1].Runs[0].AsTime())
+			jobService.AssertNotCalled(t, "Replay", context.TODO(), replayWorkerRequest)
+		})
 		t.Run("should failed when replay request is invalid", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
@@ -1956,6 +6255,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2011,6 +6315,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2048,6 +6357,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2096,6 +6410,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2151,6 +6470,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2163,6 +6487,9 @@ func TestRuntimeServiceServer(t *testing.T) {
 			assert.NotNil(t, err)
 			assert.Contains(t, err.Error(), job.ErrConflictedJobRun.Error())
 			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			errInfo, ok := status.Convert(err).Details()[0].(*errdetails.ErrorInfo)
+			assert.True(t, ok)
+			assert.Equal(t, v1.ErrReasonFailedPrecondition, errInfo.GetReason())
 			assert.Nil(t, replayResponse)
 		})
 		t.Run("should failed when request queue is full", func(t *testing.T) {
@@ -2207,6 +6534,11 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2222,4 +6554,166 @@ func TestRuntimeServiceServer(t *testing.T) {
 			assert.Nil(t, replayResponse)
 		})
 	})
+
+	t.Run("ReplayStatus", func(t *testing.T) {
+		projectName := "a-data-project"
+		jobName := "a-data-job"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: jobName,
+		}
+		replayID := uuid.Must(uuid.NewRandom())
+		newServer := func(jobService models.JobService) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.ReplayPollInterval = time.Millisecond
+			return runtimeServiceServer
+		}
+
+		t.Run("should stream status of replay whenever it changes till it ends", func(t *testing.T) {
+			cleared := models.ReplayMessage{Type: job.ReplayJobRunsCleared, Message: "cleared runs of a-data-job, 1/2 jobs"}
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+			jobService.On("GetByName", jobName, namespaceSpec).Return(jobSpec, nil)
+			jobService.On("GetReplay", jobSpec, replayID).Return(models.ReplaySpec{ID: replayID, Status: models.ReplayStatusAccepted}, nil).Once()
+			jobService.On("GetReplay", jobSpec, replayID).Return(models.ReplaySpec{ID: replayID, Status: models.ReplayStatusInProgress, Message: cleared}, nil).Twice()
+			jobService.On("GetReplay", jobSpec, replayID).Return(models.ReplaySpec{ID: replayID, Status: models.ReplayStatusSuccess}, nil).Once()
+
+			stream := new(mock.RuntimeService_ReplayStatusServer)
+			defer stream.AssertExpectations(t)
+			stream.On("Context").Return(context.Background())
+			stream.On("Send", &pb.ReplayStatusResponse{Id: replayID.String(), Status: models.ReplayStatusAccepted}).Return(nil).Once()
+			stream.On("Send", &pb.ReplayStatusResponse{
+				Id:          replayID.String(),
+				Status:      models.ReplayStatusInProgress,
+				MessageType: cleared.Type,
+				Message:     cleared.Message,
+			}).Return(nil).Once()
+			stream.On("Send", &pb.ReplayStatusResponse{Id: replayID.String(), Status: models.ReplayStatusSuccess}).Return(nil).Once()
+
+			err := newServer(jobService).ReplayStatus(&pb.ReplayStatusRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				Id:          replayID.String(),
+			}, stream)
+			assert.Nil(t, err)
+		})
+		t.Run("should fail if replay is not found", func(t *testing.T) {
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+			jobService.On("GetByName", jobName, namespaceSpec).Return(jobSpec, nil)
+			jobService.On("GetReplay", jobSpec, replayID).Return(models.ReplaySpec{}, store.ErrResourceNotFound)
+
+			err := newServer(jobService).ReplayStatus(&pb.ReplayStatusRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				Id:          replayID.String(),
+			}, new(mock.RuntimeService_ReplayStatusServer))
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("ListReplays", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		newServer := func(jobService models.JobService) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			return v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+		}
+
+		t.Run("should list replays of project", func(t *testing.T) {
+			startDate := time.Date(2020, 11, 25, 0, 0, 0, 0, time.UTC)
+			replaySpec := models.ReplaySpec{
+				ID:        uuid.Must(uuid.NewRandom()),
+				Job:       models.JobSpec{Name: "a-data-job"},
+				StartDate: startDate,
+				EndDate:   startDate.AddDate(0, 0, 3),
+				Status:    models.ReplayStatusFailed,
+				Message:   models.ReplayMessage{Type: "failed to clear", Message: "scheduler is unreachable"},
+				CreatedAt: startDate.AddDate(0, 1, 0),
+			}
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+			jobService.On("GetReplayList", projectSpec.ID).Return([]models.ReplaySpec{replaySpec}, nil)
+
+			resp, err := newServer(jobService).ListReplays(context.Background(), &pb.ListReplaysRequest{
+				ProjectName: projectName,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.Replays))
+			assert.Equal(t, replaySpec.ID.String(), resp.Replays[0].Id)
+			assert.Equal(t, "a-data-job", resp.Replays[0].JobName)
+			assert.Equal(t, models.ReplayStatusFailed, resp.Replays[0].Status)
+			assert.Equal(t, "scheduler is unreachable", resp.Replays[0].Message)
+			assert.Equal(t, startDate, resp.Replays[0].StartDate.AsTime())
+		})
+		t.Run("should return error if replays can't be listed", func(t *testing.T) {
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+			jobService.On("GetReplayList", projectSpec.ID).Return([]models.ReplaySpec{}, errors.New("connection refused"))
+
+			_, err := newServer(jobService).ListReplays(context.Background(), &pb.ListReplaysRequest{
+				ProjectName: projectName,
+			})
+			assert.Equal(t, codes.Internal, status.Code(err))
+		})
+	})
 }
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/odpf/optimus/models"
+)
+
+// SecretReaders decides if callers can read values of sensitive config
+type SecretReaders interface {
+	CanReadSecrets(ctx context.Context, projectName string) bool
+}
+
+func (sv *RuntimeServiceServer) canReadSecrets(ctx context.Context, projectName string) bool {
+	return sv.SecretReaders == nil || sv.SecretReaders.CanReadSecrets(ctx, projectName)
+}
+
+// redactConfig returns a copy of config with values of keys sensitive in
+// project replaced
+func (sv *RuntimeServiceServer) redactConfig(config map[string]string, project models.ProjectSpec) map[string]string {
+	if config == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(config))
+	for key, val := range config {
+		if project.IsSensitiveConfig(key, sv.SensitiveConfig) {
+			val = models.ConfigRedactedValue
+		}
+		redacted[key] = val
+	}
+	return redacted
+}
+
+// redactProject redacts sensitive config of project unless caller can
+// read secrets of it
+func (sv *RuntimeServiceServer) redactProject(ctx context.Context, project models.ProjectSpec) models.ProjectSpec {
+	if !sv.canReadSecrets(ctx, project.Name) {
+		project.Config = sv.redactConfig(project.Config, project)
+	}
+	return project
+}
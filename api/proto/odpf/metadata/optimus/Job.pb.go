@@ -7,6 +7,8 @@
 package optimus
 
 import (
+	duration "github.com/golang/protobuf/ptypes/duration"
+	_struct "github.com/golang/protobuf/ptypes/struct"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -73,20 +75,21 @@ type JobMetadata struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Urn            string               `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
-	Name           string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Tenant         string               `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
-	Version        int32                `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
-	Description    string               `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Labels         []*JobLabel          `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
-	Owner          string               `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
-	Task           *JobTask             `protobuf:"bytes,8,opt,name=task,proto3" json:"task,omitempty"`
-	Schedule       *JobSchedule         `protobuf:"bytes,9,opt,name=schedule,proto3" json:"schedule,omitempty"`
-	Behaviour      *JobBehavior         `protobuf:"bytes,10,opt,name=behaviour,proto3" json:"behaviour,omitempty"`
-	Hooks          []*JobHook           `protobuf:"bytes,11,rep,name=hooks,proto3" json:"hooks,omitempty"`
-	Dependencies   []*JobDependency     `protobuf:"bytes,12,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
-	Namespace      string               `protobuf:"bytes,13,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	EventTimestamp *timestamp.Timestamp `protobuf:"bytes,100,opt,name=event_timestamp,json=eventTimestamp,proto3" json:"event_timestamp,omitempty"`
+	Urn             string               `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
+	Name            string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Tenant          string               `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Version         int32                `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Description     string               `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Labels          []*JobLabel          `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	Owner           string               `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
+	Task            *JobTask             `protobuf:"bytes,8,opt,name=task,proto3" json:"task,omitempty"`
+	Schedule        *JobSchedule         `protobuf:"bytes,9,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Behaviour       *JobBehavior         `protobuf:"bytes,10,opt,name=behaviour,proto3" json:"behaviour,omitempty"`
+	Hooks           []*JobHook           `protobuf:"bytes,11,rep,name=hooks,proto3" json:"hooks,omitempty"`
+	Dependencies    []*JobDependency     `protobuf:"bytes,12,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	Namespace       string               `protobuf:"bytes,13,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	EventTimestamp  *timestamp.Timestamp `protobuf:"bytes,100,opt,name=event_timestamp,json=eventTimestamp,proto3" json:"event_timestamp,omitempty"`
+	SlaMissDuration *duration.Duration   `protobuf:"bytes,14,opt,name=sla_miss_duration,json=slaMissDuration,proto3" json:"sla_miss_duration,omitempty"`
 }
 
 func (x *JobMetadata) Reset() {
@@ -219,18 +222,28 @@ func (x *JobMetadata) GetEventTimestamp() *timestamp.Timestamp {
 	return nil
 }
 
+func (x *JobMetadata) GetSlaMissDuration() *duration.Duration {
+	if x != nil {
+		return x.SlaMissDuration
+	}
+	return nil
+}
+
 type JobTask struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Image       string           `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Description string           `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Destination string           `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
-	Config      []*JobTaskConfig `protobuf:"bytes,5,rep,name=config,proto3" json:"config,omitempty"`
-	Window      *JobTaskWindow   `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`
-	Priority    int32            `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	Name          string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image         string           `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Description   string           `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Destination   string           `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	Config        []*JobTaskConfig `protobuf:"bytes,5,rep,name=config,proto3" json:"config,omitempty"`
+	Window        *JobTaskWindow   `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`
+	Priority      int32            `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	Resource      *JobResource     `protobuf:"bytes,8,opt,name=resource,proto3" json:"resource,omitempty"`
+	PluginVersion string           `protobuf:"bytes,9,opt,name=plugin_version,json=pluginVersion,proto3" json:"plugin_version,omitempty"`
+	PinnedVersion string           `protobuf:"bytes,10,opt,name=pinned_version,json=pinnedVersion,proto3" json:"pinned_version,omitempty"`
 }
 
 func (x *JobTask) Reset() {
@@ -314,17 +327,41 @@ func (x *JobTask) GetPriority() int32 {
 	return 0
 }
 
+func (x *JobTask) GetResource() *JobResource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *JobTask) GetPluginVersion() string {
+	if x != nil {
+		return x.PluginVersion
+	}
+	return ""
+}
+
+func (x *JobTask) GetPinnedVersion() string {
+	if x != nil {
+		return x.PinnedVersion
+	}
+	return ""
+}
+
 type JobHook struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Image       string           `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Description string           `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Config      []*JobHookConfig `protobuf:"bytes,4,rep,name=config,proto3" json:"config,omitempty"`
-	Type        string           `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
-	DependsOn   []string         `protobuf:"bytes,6,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Name          string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image         string           `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Description   string           `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Config        []*JobHookConfig `protobuf:"bytes,4,rep,name=config,proto3" json:"config,omitempty"`
+	Type          string           `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	DependsOn     []string         `protobuf:"bytes,6,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Resource      *JobResource     `protobuf:"bytes,7,opt,name=resource,proto3" json:"resource,omitempty"`
+	PluginVersion string           `protobuf:"bytes,8,opt,name=plugin_version,json=pluginVersion,proto3" json:"plugin_version,omitempty"`
+	PinnedVersion string           `protobuf:"bytes,9,opt,name=pinned_version,json=pinnedVersion,proto3" json:"pinned_version,omitempty"`
 }
 
 func (x *JobHook) Reset() {
@@ -401,6 +438,82 @@ func (x *JobHook) GetDependsOn() []string {
 	return nil
 }
 
+func (x *JobHook) GetResource() *JobResource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *JobHook) GetPluginVersion() string {
+	if x != nil {
+		return x.PluginVersion
+	}
+	return ""
+}
+
+func (x *JobHook) GetPinnedVersion() string {
+	if x != nil {
+		return x.PinnedVersion
+	}
+	return ""
+}
+
+type JobResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request *JobResource_Config `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Limit   *JobResource_Config `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *JobResource) Reset() {
+	*x = JobResource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobResource) ProtoMessage() {}
+
+func (x *JobResource) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobResource.ProtoReflect.Descriptor instead.
+func (*JobResource) Descriptor() ([]byte, []int) {
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JobResource) GetRequest() *JobResource_Config {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *JobResource) GetLimit() *JobResource_Config {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
 type JobDependency struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -409,12 +522,13 @@ type JobDependency struct {
 	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
 	Job    string `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
 	Type   string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Url    string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
 }
 
 func (x *JobDependency) Reset() {
 	*x = JobDependency{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[4]
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -427,7 +541,7 @@ func (x *JobDependency) String() string {
 func (*JobDependency) ProtoMessage() {}
 
 func (x *JobDependency) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[4]
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,7 +554,7 @@ func (x *JobDependency) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobDependency.ProtoReflect.Descriptor instead.
 func (*JobDependency) Descriptor() ([]byte, []int) {
-	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{4}
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *JobDependency) GetTenant() string {
@@ -464,6 +578,13 @@ func (x *JobDependency) GetType() string {
 	return ""
 }
 
+func (x *JobDependency) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
 type JobTaskWindow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -477,7 +598,7 @@ type JobTaskWindow struct {
 func (x *JobTaskWindow) Reset() {
 	*x = JobTaskWindow{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[5]
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -490,7 +611,7 @@ func (x *JobTaskWindow) String() string {
 func (*JobTaskWindow) ProtoMessage() {}
 
 func (x *JobTaskWindow) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[5]
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -503,7 +624,7 @@ func (x *JobTaskWindow) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobTaskWindow.ProtoReflect.Descriptor instead.
 func (*JobTaskWindow) Descriptor() ([]byte, []int) {
-	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{5}
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *JobTaskWindow) GetSize() string {
@@ -540,7 +661,7 @@ type JobSchedule struct {
 func (x *JobSchedule) Reset() {
 	*x = JobSchedule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[6]
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -553,7 +674,7 @@ func (x *JobSchedule) String() string {
 func (*JobSchedule) ProtoMessage() {}
 
 func (x *JobSchedule) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[6]
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,7 +687,7 @@ func (x *JobSchedule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobSchedule.ProtoReflect.Descriptor instead.
 func (*JobSchedule) Descriptor() ([]byte, []int) {
-	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{6}
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *JobSchedule) GetStartDate() *timestamp.Timestamp {
@@ -595,14 +716,16 @@ type JobBehavior struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DependsOnPast bool `protobuf:"varint,1,opt,name=depends_on_past,json=dependsOnPast,proto3" json:"depends_on_past,omitempty"`
-	Catchup       bool `protobuf:"varint,2,opt,name=catchup,proto3" json:"catchup,omitempty"`
+	DependsOnPast bool                    `protobuf:"varint,1,opt,name=depends_on_past,json=dependsOnPast,proto3" json:"depends_on_past,omitempty"`
+	Catchup       bool                    `protobuf:"varint,2,opt,name=catchup,proto3" json:"catchup,omitempty"`
+	Retry         *JobBehavior_Retry      `protobuf:"bytes,3,opt,name=retry,proto3" json:"retry,omitempty"`
+	Notify        []*JobBehavior_Notifier `protobuf:"bytes,4,rep,name=notify,proto3" json:"notify,omitempty"`
 }
 
 func (x *JobBehavior) Reset() {
 	*x = JobBehavior{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[7]
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -615,7 +738,7 @@ func (x *JobBehavior) String() string {
 func (*JobBehavior) ProtoMessage() {}
 
 func (x *JobBehavior) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[7]
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +751,7 @@ func (x *JobBehavior) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobBehavior.ProtoReflect.Descriptor instead.
 func (*JobBehavior) Descriptor() ([]byte, []int) {
-	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{7}
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *JobBehavior) GetDependsOnPast() bool {
@@ -645,6 +768,20 @@ func (x *JobBehavior) GetCatchup() bool {
 	return false
 }
 
+func (x *JobBehavior) GetRetry() *JobBehavior_Retry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *JobBehavior) GetNotify() []*JobBehavior_Notifier {
+	if x != nil {
+		return x.Notify
+	}
+	return nil
+}
+
 type JobLabel struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -657,7 +794,7 @@ type JobLabel struct {
 func (x *JobLabel) Reset() {
 	*x = JobLabel{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[8]
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -670,7 +807,7 @@ func (x *JobLabel) String() string {
 func (*JobLabel) ProtoMessage() {}
 
 func (x *JobLabel) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[8]
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -683,7 +820,7 @@ func (x *JobLabel) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobLabel.ProtoReflect.Descriptor instead.
 func (*JobLabel) Descriptor() ([]byte, []int) {
-	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{8}
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *JobLabel) GetName() string {
@@ -712,7 +849,7 @@ type JobTaskConfig struct {
 func (x *JobTaskConfig) Reset() {
 	*x = JobTaskConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[9]
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -725,7 +862,7 @@ func (x *JobTaskConfig) String() string {
 func (*JobTaskConfig) ProtoMessage() {}
 
 func (x *JobTaskConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[9]
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -738,7 +875,7 @@ func (x *JobTaskConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobTaskConfig.ProtoReflect.Descriptor instead.
 func (*JobTaskConfig) Descriptor() ([]byte, []int) {
-	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{9}
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *JobTaskConfig) GetName() string {
@@ -767,7 +904,7 @@ type JobHookConfig struct {
 func (x *JobHookConfig) Reset() {
 	*x = JobHookConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[10]
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -780,7 +917,7 @@ func (x *JobHookConfig) String() string {
 func (*JobHookConfig) ProtoMessage() {}
 
 func (x *JobHookConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[10]
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -793,7 +930,7 @@ func (x *JobHookConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobHookConfig.ProtoReflect.Descriptor instead.
 func (*JobHookConfig) Descriptor() ([]byte, []int) {
-	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{10}
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *JobHookConfig) GetName() string {
@@ -810,6 +947,357 @@ func (x *JobHookConfig) GetValue() string {
 	return ""
 }
 
+// ResourceMetadataKey identifies metadata of a datastore resource
+type ResourceMetadataKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Urn string `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
+}
+
+func (x *ResourceMetadataKey) Reset() {
+	*x = ResourceMetadataKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceMetadataKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceMetadataKey) ProtoMessage() {}
+
+func (x *ResourceMetadataKey) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceMetadataKey.ProtoReflect.Descriptor instead.
+func (*ResourceMetadataKey) Descriptor() ([]byte, []int) {
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResourceMetadataKey) GetUrn() string {
+	if x != nil {
+		return x.Urn
+	}
+	return ""
+}
+
+// ResourceMetadata describes a resource managed in a datastore, e.g. a
+// bigquery dataset, table or view
+type ResourceMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Urn       string      `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
+	Name      string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Tenant    string      `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Namespace string      `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Datastore string      `protobuf:"bytes,5,opt,name=datastore,proto3" json:"datastore,omitempty"`
+	Type      string      `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	Version   int32       `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	Labels    []*JobLabel `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty"`
+	// spec of resource as written in its yaml
+	Spec           *_struct.Struct      `protobuf:"bytes,9,opt,name=spec,proto3" json:"spec,omitempty"`
+	EventTimestamp *timestamp.Timestamp `protobuf:"bytes,10,opt,name=event_timestamp,json=eventTimestamp,proto3" json:"event_timestamp,omitempty"`
+}
+
+func (x *ResourceMetadata) Reset() {
+	*x = ResourceMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceMetadata) ProtoMessage() {}
+
+func (x *ResourceMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceMetadata.ProtoReflect.Descriptor instead.
+func (*ResourceMetadata) Descriptor() ([]byte, []int) {
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResourceMetadata) GetUrn() string {
+	if x != nil {
+		return x.Urn
+	}
+	return ""
+}
+
+func (x *ResourceMetadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceMetadata) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *ResourceMetadata) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResourceMetadata) GetDatastore() string {
+	if x != nil {
+		return x.Datastore
+	}
+	return ""
+}
+
+func (x *ResourceMetadata) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ResourceMetadata) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ResourceMetadata) GetLabels() []*JobLabel {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ResourceMetadata) GetSpec() *_struct.Struct {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *ResourceMetadata) GetEventTimestamp() *timestamp.Timestamp {
+	if x != nil {
+		return x.EventTimestamp
+	}
+	return nil
+}
+
+type JobResource_Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cpu    string `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory string `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+}
+
+func (x *JobResource_Config) Reset() {
+	*x = JobResource_Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobResource_Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobResource_Config) ProtoMessage() {}
+
+func (x *JobResource_Config) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobResource_Config.ProtoReflect.Descriptor instead.
+func (*JobResource_Config) Descriptor() ([]byte, []int) {
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *JobResource_Config) GetCpu() string {
+	if x != nil {
+		return x.Cpu
+	}
+	return ""
+}
+
+func (x *JobResource_Config) GetMemory() string {
+	if x != nil {
+		return x.Memory
+	}
+	return ""
+}
+
+type JobBehavior_Retry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count              int32              `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Delay              *duration.Duration `protobuf:"bytes,2,opt,name=delay,proto3" json:"delay,omitempty"`
+	ExponentialBackoff bool               `protobuf:"varint,3,opt,name=exponential_backoff,json=exponentialBackoff,proto3" json:"exponential_backoff,omitempty"`
+}
+
+func (x *JobBehavior_Retry) Reset() {
+	*x = JobBehavior_Retry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobBehavior_Retry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobBehavior_Retry) ProtoMessage() {}
+
+func (x *JobBehavior_Retry) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobBehavior_Retry.ProtoReflect.Descriptor instead.
+func (*JobBehavior_Retry) Descriptor() ([]byte, []int) {
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *JobBehavior_Retry) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *JobBehavior_Retry) GetDelay() *duration.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+func (x *JobBehavior_Retry) GetExponentialBackoff() bool {
+	if x != nil {
+		return x.ExponentialBackoff
+	}
+	return false
+}
+
+type JobBehavior_Notifier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	On       string            `protobuf:"bytes,1,opt,name=on,proto3" json:"on,omitempty"`
+	Config   map[string]string `protobuf:"bytes,2,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Channels []string          `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *JobBehavior_Notifier) Reset() {
+	*x = JobBehavior_Notifier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobBehavior_Notifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobBehavior_Notifier) ProtoMessage() {}
+
+func (x *JobBehavior_Notifier) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_metadata_optimus_Job_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobBehavior_Notifier.ProtoReflect.Descriptor instead.
+func (*JobBehavior_Notifier) Descriptor() ([]byte, []int) {
+	return file_odpf_metadata_optimus_Job_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *JobBehavior_Notifier) GetOn() string {
+	if x != nil {
+		return x.On
+	}
+	return ""
+}
+
+func (x *JobBehavior_Notifier) GetConfig() map[string]string {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *JobBehavior_Notifier) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
 var File_odpf_metadata_optimus_Job_proto protoreflect.FileDescriptor
 
 var file_odpf_metadata_optimus_Job_proto_rawDesc = []byte{
@@ -818,120 +1306,214 @@ var file_odpf_metadata_optimus_Job_proto_rawDesc = []byte{
 	0x6f, 0x12, 0x15, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
 	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x22, 0x0a, 0x0e, 0x4a, 0x6f, 0x62,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75,
-	0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6e, 0x22, 0xef, 0x04,
-	0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a,
-	0x03, 0x75, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6e, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e,
-	0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
-	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62,
-	0x54, 0x61, 0x73, 0x6b, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x63,
-	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f,
-	0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74,
-	0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
-	0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x62, 0x65,
-	0x68, 0x61, 0x76, 0x69, 0x6f, 0x75, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
-	0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70,
-	0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f,
-	0x72, 0x52, 0x09, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x75, 0x72, 0x12, 0x34, 0x0a, 0x05,
-	0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x64,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x22, 0x0a, 0x0e, 0x4a, 0x6f, 0x62, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6e, 0x22, 0xb6, 0x05, 0x0a, 0x0b,
+	0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f,
+	0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x61,
+	0x73, 0x6b, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x64, 0x70,
+	0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x08,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x62, 0x65, 0x68, 0x61,
+	0x76, 0x69, 0x6f, 0x75, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x64,
 	0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69,
-	0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x52, 0x05, 0x68, 0x6f, 0x6f,
-	0x6b, 0x73, 0x12, 0x48, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69,
-	0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73,
-	0x2e, 0x4a, 0x6f, 0x62, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0c,
-	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
-	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0f, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x64, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
-	0x8f, 0x02, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69,
-	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
-	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x64, 0x70, 0x66,
+	0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52,
+	0x09, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x75, 0x72, 0x12, 0x34, 0x0a, 0x05, 0x68, 0x6f,
+	0x6f, 0x6b, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x64, 0x70, 0x66,
 	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75,
-	0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3c, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f,
-	0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d,
+	0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x52, 0x05, 0x68, 0x6f, 0x6f, 0x6b, 0x73,
+	0x12, 0x48, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a,
+	0x6f, 0x62, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0c, 0x64, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x11, 0x73, 0x6c, 0x61, 0x5f,
+	0x6d, 0x69, 0x73, 0x73, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f,
+	0x73, 0x6c, 0x61, 0x4d, 0x69, 0x73, 0x73, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x43, 0x0a, 0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0x9d, 0x03, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c,
+	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3c, 0x0a, 0x06,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f,
+	0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73,
+	0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
+	0x0e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0xd4, 0x02, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x48, 0x6f, 0x6f, 0x6b,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f,
+	0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x3e, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x69,
+	0x6e, 0x6e, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xc7, 0x01, 0x0a, 0x0b,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f,
+	0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3f, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x1a, 0x32, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x63,
+	0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x5f, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x44, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6a, 0x6f, 0x62,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x5c, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73,
+	0x6b, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x5f,
+	0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x54, 0x6f, 0x22, 0x9b, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12,
+	0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65,
+	0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x22, 0x9a, 0x04, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69,
+	0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x6e,
+	0x5f, 0x70, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x64, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x50, 0x61, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61,
+	0x74, 0x63, 0x68, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x61, 0x74,
+	0x63, 0x68, 0x75, 0x70, 0x12, 0x3e, 0x0a, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x42,
+	0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72,
+	0x65, 0x74, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62,
+	0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x52, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x1a, 0x7f, 0x0a, 0x05, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x2f, 0x0a, 0x13, 0x65, 0x78, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x65, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x1a, 0xc2, 0x01, 0x0a, 0x08, 0x4e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d,
 	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e,
-	0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52, 0x06, 0x77,
-	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
-	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
-	0x79, 0x22, 0xc6, 0x01, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x64, 0x70, 0x66,
-	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75,
-	0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64,
-	0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x09, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x22, 0x4d, 0x0a, 0x0d, 0x4a, 0x6f,
-	0x62, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74,
-	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e,
-	0x61, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x5c, 0x0a, 0x0d, 0x4a, 0x6f, 0x62,
-	0x54, 0x61, 0x73, 0x6b, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
-	0x74, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x75,
-	0x6e, 0x63, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x22, 0x9b, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x53,
-	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61,
-	0x74, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x4f, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x42, 0x65, 0x68, 0x61,
-	0x76, 0x69, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x5f,
-	0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x64,
-	0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x50, 0x61, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x63, 0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63,
-	0x61, 0x74, 0x63, 0x68, 0x75, 0x70, 0x22, 0x34, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x39, 0x0a, 0x0d,
-	0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x39, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x48, 0x6f,
-	0x6f, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x42, 0x53, 0x0a, 0x1f, 0x69, 0x6f, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x6e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70,
-	0x74, 0x69, 0x6d, 0x75, 0x73, 0x42, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x5a, 0x27,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x64, 0x70, 0x66, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2f,
-	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4a, 0x6f, 0x62, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x4e, 0x6f, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x34, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x39, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x73, 0x6b,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x39, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x27, 0x0a, 0x13, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b,
+	0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x6e, 0x22, 0xe5, 0x02, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x37, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x73, 0x70,
+	0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x43, 0x0a, 0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x53, 0x0a, 0x1f,
+	0x69, 0x6f, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x2e, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x42,
+	0x07, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x75, 0x73, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x64, 0x70, 0x66, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
+	0x2f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x75,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -946,39 +1528,60 @@ func file_odpf_metadata_optimus_Job_proto_rawDescGZIP() []byte {
 	return file_odpf_metadata_optimus_Job_proto_rawDescData
 }
 
-var file_odpf_metadata_optimus_Job_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_odpf_metadata_optimus_Job_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_odpf_metadata_optimus_Job_proto_goTypes = []interface{}{
-	(*JobMetadataKey)(nil),      // 0: odpf.metadata.optimus.JobMetadataKey
-	(*JobMetadata)(nil),         // 1: odpf.metadata.optimus.JobMetadata
-	(*JobTask)(nil),             // 2: odpf.metadata.optimus.JobTask
-	(*JobHook)(nil),             // 3: odpf.metadata.optimus.JobHook
-	(*JobDependency)(nil),       // 4: odpf.metadata.optimus.JobDependency
-	(*JobTaskWindow)(nil),       // 5: odpf.metadata.optimus.JobTaskWindow
-	(*JobSchedule)(nil),         // 6: odpf.metadata.optimus.JobSchedule
-	(*JobBehavior)(nil),         // 7: odpf.metadata.optimus.JobBehavior
-	(*JobLabel)(nil),            // 8: odpf.metadata.optimus.JobLabel
-	(*JobTaskConfig)(nil),       // 9: odpf.metadata.optimus.JobTaskConfig
-	(*JobHookConfig)(nil),       // 10: odpf.metadata.optimus.JobHookConfig
-	(*timestamp.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*JobMetadataKey)(nil),       // 0: odpf.metadata.optimus.JobMetadataKey
+	(*JobMetadata)(nil),          // 1: odpf.metadata.optimus.JobMetadata
+	(*JobTask)(nil),              // 2: odpf.metadata.optimus.JobTask
+	(*JobHook)(nil),              // 3: odpf.metadata.optimus.JobHook
+	(*JobResource)(nil),          // 4: odpf.metadata.optimus.JobResource
+	(*JobDependency)(nil),        // 5: odpf.metadata.optimus.JobDependency
+	(*JobTaskWindow)(nil),        // 6: odpf.metadata.optimus.JobTaskWindow
+	(*JobSchedule)(nil),          // 7: odpf.metadata.optimus.JobSchedule
+	(*JobBehavior)(nil),          // 8: odpf.metadata.optimus.JobBehavior
+	(*JobLabel)(nil),             // 9: odpf.metadata.optimus.JobLabel
+	(*JobTaskConfig)(nil),        // 10: odpf.metadata.optimus.JobTaskConfig
+	(*JobHookConfig)(nil),        // 11: odpf.metadata.optimus.JobHookConfig
+	(*ResourceMetadataKey)(nil),  // 12: odpf.metadata.optimus.ResourceMetadataKey
+	(*ResourceMetadata)(nil),     // 13: odpf.metadata.optimus.ResourceMetadata
+	(*JobResource_Config)(nil),   // 14: odpf.metadata.optimus.JobResource.Config
+	(*JobBehavior_Retry)(nil),    // 15: odpf.metadata.optimus.JobBehavior.Retry
+	(*JobBehavior_Notifier)(nil), // 16: odpf.metadata.optimus.JobBehavior.Notifier
+	nil,                          // 17: odpf.metadata.optimus.JobBehavior.Notifier.ConfigEntry
+	(*duration.Duration)(nil),    // 18: google.protobuf.Duration
+	(*timestamp.Timestamp)(nil),  // 19: google.protobuf.Timestamp
+	(*_struct.Struct)(nil),       // 20: google.protobuf.Struct
 }
 var file_odpf_metadata_optimus_Job_proto_depIdxs = []int32{
-	8,  // 0: odpf.metadata.optimus.JobMetadata.labels:type_name -> odpf.metadata.optimus.JobLabel
+	9,  // 0: odpf.metadata.optimus.JobMetadata.labels:type_name -> odpf.metadata.optimus.JobLabel
 	2,  // 1: odpf.metadata.optimus.JobMetadata.task:type_name -> odpf.metadata.optimus.JobTask
-	6,  // 2: odpf.metadata.optimus.JobMetadata.schedule:type_name -> odpf.metadata.optimus.JobSchedule
-	7,  // 3: odpf.metadata.optimus.JobMetadata.behaviour:type_name -> odpf.metadata.optimus.JobBehavior
+	7,  // 2: odpf.metadata.optimus.JobMetadata.schedule:type_name -> odpf.metadata.optimus.JobSchedule
+	8,  // 3: odpf.metadata.optimus.JobMetadata.behaviour:type_name -> odpf.metadata.optimus.JobBehavior
 	3,  // 4: odpf.metadata.optimus.JobMetadata.hooks:type_name -> odpf.metadata.optimus.JobHook
-	4,  // 5: odpf.metadata.optimus.JobMetadata.dependencies:type_name -> odpf.metadata.optimus.JobDependency
-	11, // 6: odpf.metadata.optimus.JobMetadata.event_timestamp:type_name -> google.protobuf.Timestamp
-	9,  // 7: odpf.metadata.optimus.JobTask.config:type_name -> odpf.metadata.optimus.JobTaskConfig
-	5,  // 8: odpf.metadata.optimus.JobTask.window:type_name -> odpf.metadata.optimus.JobTaskWindow
-	10, // 9: odpf.metadata.optimus.JobHook.config:type_name -> odpf.metadata.optimus.JobHookConfig
-	11, // 10: odpf.metadata.optimus.JobSchedule.start_date:type_name -> google.protobuf.Timestamp
-	11, // 11: odpf.metadata.optimus.JobSchedule.end_date:type_name -> google.protobuf.Timestamp
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	5,  // 5: odpf.metadata.optimus.JobMetadata.dependencies:type_name -> odpf.metadata.optimus.JobDependency
+	18, // 6: odpf.metadata.optimus.JobMetadata.sla_miss_duration:type_name -> google.protobuf.Duration
+	19, // 7: odpf.metadata.optimus.JobMetadata.event_timestamp:type_name -> google.protobuf.Timestamp
+	10, // 8: odpf.metadata.optimus.JobTask.config:type_name -> odpf.metadata.optimus.JobTaskConfig
+	6,  // 9: odpf.metadata.optimus.JobTask.window:type_name -> odpf.metadata.optimus.JobTaskWindow
+	4,  // 10: odpf.metadata.optimus.JobTask.resource:type_name -> odpf.metadata.optimus.JobResource
+	11, // 11: odpf.metadata.optimus.JobHook.config:type_name -> odpf.metadata.optimus.JobHookConfig
+	4,  // 12: odpf.metadata.optimus.JobHook.resource:type_name -> odpf.metadata.optimus.JobResource
+	14, // 13: odpf.metadata.optimus.JobResource.request:type_name -> odpf.metadata.optimus.JobResource.Config
+	14, // 14: odpf.metadata.optimus.JobResource.limit:type_name -> odpf.metadata.optimus.JobResource.Config
+	19, // 15: odpf.metadata.optimus.JobSchedule.start_date:type_name -> google.protobuf.Timestamp
+	19, // 16: odpf.metadata.optimus.JobSchedule.end_date:type_name -> google.protobuf.Timestamp
+	15, // 17: odpf.metadata.optimus.JobBehavior.retry:type_name -> odpf.metadata.optimus.JobBehavior.Retry
+	16, // 18: odpf.metadata.optimus.JobBehavior.notify:type_name -> odpf.metadata.optimus.JobBehavior.Notifier
+	9,  // 19: odpf.metadata.optimus.ResourceMetadata.labels:type_name -> odpf.metadata.optimus.JobLabel
+	20, // 20: odpf.metadata.optimus.ResourceMetadata.spec:type_name -> google.protobuf.Struct
+	19, // 21: odpf.metadata.optimus.ResourceMetadata.event_timestamp:type_name -> google.protobuf.Timestamp
+	18, // 22: odpf.metadata.optimus.JobBehavior.Retry.delay:type_name -> google.protobuf.Duration
+	17, // 23: odpf.metadata.optimus.JobBehavior.Notifier.config:type_name -> odpf.metadata.optimus.JobBehavior.Notifier.ConfigEntry
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_odpf_metadata_optimus_Job_proto_init() }
@@ -1036,7 +1639,7 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			}
 		}
 		file_odpf_metadata_optimus_Job_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobDependency); i {
+			switch v := v.(*JobResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1048,7 +1651,7 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			}
 		}
 		file_odpf_metadata_optimus_Job_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobTaskWindow); i {
+			switch v := v.(*JobDependency); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1060,7 +1663,7 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			}
 		}
 		file_odpf_metadata_optimus_Job_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobSchedule); i {
+			switch v := v.(*JobTaskWindow); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1072,7 +1675,7 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			}
 		}
 		file_odpf_metadata_optimus_Job_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobBehavior); i {
+			switch v := v.(*JobSchedule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1084,7 +1687,7 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			}
 		}
 		file_odpf_metadata_optimus_Job_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobLabel); i {
+			switch v := v.(*JobBehavior); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1096,7 +1699,7 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			}
 		}
 		file_odpf_metadata_optimus_Job_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobTaskConfig); i {
+			switch v := v.(*JobLabel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1108,6 +1711,18 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			}
 		}
 		file_odpf_metadata_optimus_Job_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobTaskConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_odpf_metadata_optimus_Job_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JobHookConfig); i {
 			case 0:
 				return &v.state
@@ -1119,6 +1734,66 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 				return nil
 			}
 		}
+		file_odpf_metadata_optimus_Job_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceMetadataKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_odpf_metadata_optimus_Job_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_odpf_metadata_optimus_Job_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobResource_Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_odpf_metadata_optimus_Job_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobBehavior_Retry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_odpf_metadata_optimus_Job_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobBehavior_Notifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1126,7 +1801,7 @@ func file_odpf_metadata_optimus_Job_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_odpf_metadata_optimus_Job_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -26,17 +26,56 @@ type RuntimeServiceClient interface {
 	// events
 	// State of the world request
 	DeployJobSpecification(ctx context.Context, in *DeployJobSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_DeployJobSpecificationClient, error)
+	// DeployJobSpecificationStream deploys jobs of a namespace streamed in
+	// chunks to stay under the maximum message size. Project, namespace and
+	// reconcile are only read from the first message of the stream. Jobs are
+	// saved and acknowledged as each chunk is received, the namespace is
+	// synced once the client closes its side of the stream
+	DeployJobSpecificationStream(ctx context.Context, opts ...grpc.CallOption) (RuntimeService_DeployJobSpecificationStreamClient, error)
+	// DeployJobSpecificationAsync queues jobs of a namespace for deployment
+	// and returns a deployment id right away, jobs are deployed in background
+	// and the progress can be polled with GetDeploymentStatus
+	DeployJobSpecificationAsync(ctx context.Context, in *DeployJobSpecificationRequest, opts ...grpc.CallOption) (*DeployJobSpecificationAsyncResponse, error)
+	// GetDeploymentStatus returns the progress of each job of an async deployment
+	GetDeploymentStatus(ctx context.Context, in *GetDeploymentStatusRequest, opts ...grpc.CallOption) (*GetDeploymentStatusResponse, error)
+	// ListDeployments returns history of finished deployments of a project
+	ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error)
+	// GetDeployment returns a finished deployment with outcome of each job
+	GetDeployment(ctx context.Context, in *GetDeploymentRequest, opts ...grpc.CallOption) (*GetDeploymentResponse, error)
+	// ImportJobSpecifications deploys jobs read from a gzipped tar archive of
+	// job specifications streamed in chunks, archive follows the same layout
+	// as a local job specification directory. Responses are same as of
+	// DeployJobSpecification
+	ImportJobSpecifications(ctx context.Context, opts ...grpc.CallOption) (RuntimeService_ImportJobSpecificationsClient, error)
 	// CreateJobSpecification registers a new job for a namespace which belongs to a project
 	CreateJobSpecification(ctx context.Context, in *CreateJobSpecificationRequest, opts ...grpc.CallOption) (*CreateJobSpecificationResponse, error)
 	// ReadJobSpecification reads a provided job spec of a namespace
 	ReadJobSpecification(ctx context.Context, in *ReadJobSpecificationRequest, opts ...grpc.CallOption) (*ReadJobSpecificationResponse, error)
 	// DeleteJobSpecification deletes a job spec of a namespace
 	DeleteJobSpecification(ctx context.Context, in *DeleteJobSpecificationRequest, opts ...grpc.CallOption) (*DeleteJobSpecificationResponse, error)
+	// PauseJob stops scheduling a job without removing its spec
+	PauseJob(ctx context.Context, in *PauseJobRequest, opts ...grpc.CallOption) (*PauseJobResponse, error)
+	// ResumeJob schedules a paused job again
+	ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (*ResumeJobResponse, error)
+	// RestoreJob brings back a deleted job till it is purged and schedules it again
+	RestoreJob(ctx context.Context, in *RestoreJobRequest, opts ...grpc.CallOption) (*RestoreJobResponse, error)
+	// ListJobVersions lists versions of a job saved on every deployment, latest first
+	ListJobVersions(ctx context.Context, in *ListJobVersionsRequest, opts ...grpc.CallOption) (*ListJobVersionsResponse, error)
+	// GetJobVersionDiff compares fields of a job between two of its versions
+	GetJobVersionDiff(ctx context.Context, in *GetJobVersionDiffRequest, opts ...grpc.CallOption) (*GetJobVersionDiffResponse, error)
+	// RollbackJob redeploys a job as it was at an earlier version
+	RollbackJob(ctx context.Context, in *RollbackJobRequest, opts ...grpc.CallOption) (*RollbackJobResponse, error)
+	// GetLineage walks jobs upstream and downstream of a job across projects
+	// through the resources they read and write, returning a graph of them
+	GetLineage(ctx context.Context, in *GetLineageRequest, opts ...grpc.CallOption) (*GetLineageResponse, error)
 	// ListJobSpecification returns list of jobs created in a project
 	ListJobSpecification(ctx context.Context, in *ListJobSpecificationRequest, opts ...grpc.CallOption) (*ListJobSpecificationResponse, error)
 	// DumpJobSpecification returns compiled representation of the job in a scheduler
 	// consumable form
 	DumpJobSpecification(ctx context.Context, in *DumpJobSpecificationRequest, opts ...grpc.CallOption) (*DumpJobSpecificationResponse, error)
+	// CompileJob returns the exact artifact scheduler would receive for a job,
+	// resolving its dependencies and priority against deployed jobs of the project
+	CompileJob(ctx context.Context, in *CompileJobRequest, opts ...grpc.CallOption) (*CompileJobResponse, error)
 	// CheckJobSpecification checks if a job specification is valid
 	CheckJobSpecification(ctx context.Context, in *CheckJobSpecificationRequest, opts ...grpc.CallOption) (*CheckJobSpecificationResponse, error)
 	// CheckJobSpecifications checks if the job specifications are valid
@@ -47,6 +86,26 @@ type RuntimeServiceClient interface {
 	RegisterProjectNamespace(ctx context.Context, in *RegisterProjectNamespaceRequest, opts ...grpc.CallOption) (*RegisterProjectNamespaceResponse, error)
 	// RegisterSecret creates a new secret of a project
 	RegisterSecret(ctx context.Context, in *RegisterSecretRequest, opts ...grpc.CallOption) (*RegisterSecretResponse, error)
+	// UpdateSecret replaces value of an existing secret of a project
+	UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*UpdateSecretResponse, error)
+	// ListSecretKeys returns names of the secrets of a project
+	ListSecretKeys(ctx context.Context, in *ListSecretKeysRequest, opts ...grpc.CallOption) (*ListSecretKeysResponse, error)
+	// CreateRoleBinding grants a role in a project or one of its namespaces
+	// to a subject, existing role of the subject in same scope is replaced
+	CreateRoleBinding(ctx context.Context, in *CreateRoleBindingRequest, opts ...grpc.CallOption) (*CreateRoleBindingResponse, error)
+	// DeleteRoleBinding revokes role of a subject in a project or namespace
+	DeleteRoleBinding(ctx context.Context, in *DeleteRoleBindingRequest, opts ...grpc.CallOption) (*DeleteRoleBindingResponse, error)
+	// ListRoleBindings returns roles granted in a project
+	ListRoleBindings(ctx context.Context, in *ListRoleBindingsRequest, opts ...grpc.CallOption) (*ListRoleBindingsResponse, error)
+	// ListAuditEvents returns recorded calls of mutating rpcs made for a project
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// RegisterMacro creates a macro usable in job templates of a project or
+	// one of its namespaces, existing macro with same name in same scope is replaced
+	RegisterMacro(ctx context.Context, in *RegisterMacroRequest, opts ...grpc.CallOption) (*RegisterMacroResponse, error)
+	// DeleteMacro removes a macro from a project or namespace
+	DeleteMacro(ctx context.Context, in *DeleteMacroRequest, opts ...grpc.CallOption) (*DeleteMacroResponse, error)
+	// ListMacros returns macros of a project and optionally of a namespace
+	ListMacros(ctx context.Context, in *ListMacrosRequest, opts ...grpc.CallOption) (*ListMacrosResponse, error)
 	// ListProjects returns list of registered projects and configurations
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
 	// ListProjectNamespaces returns list of namespaces of a project
@@ -56,11 +115,24 @@ type RuntimeServiceClient interface {
 	RegisterInstance(ctx context.Context, in *RegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error)
 	// JobStatus returns the current and past run status of jobs
 	JobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusResponse, error)
+	// ClearJobRuns clears runs of a job scheduled between two dates so scheduler
+	// runs them again
+	ClearJobRuns(ctx context.Context, in *ClearJobRunsRequest, opts ...grpc.CallOption) (*ClearJobRunsResponse, error)
+	// GetJobRuns reads run history of jobs of a project, oldest schedule first,
+	// along with the number of runs in each state
+	GetJobRuns(ctx context.Context, in *GetJobRunsRequest, opts ...grpc.CallOption) (*GetJobRunsResponse, error)
 	// RegisterJobEvent notifies optimus service about an event related to job
 	RegisterJobEvent(ctx context.Context, in *RegisterJobEventRequest, opts ...grpc.CallOption) (*RegisterJobEventResponse, error)
 	// GetWindow provides the start and end dates provided a scheduled date
 	// of the execution window
 	GetWindow(ctx context.Context, in *GetWindowRequest, opts ...grpc.CallOption) (*GetWindowResponse, error)
+	// ListSupportedTasks returns task plugins installed on the server
+	ListSupportedTasks(ctx context.Context, in *ListSupportedTasksRequest, opts ...grpc.CallOption) (*ListSupportedTasksResponse, error)
+	// ListSupportedHooks returns hook plugins installed on the server
+	ListSupportedHooks(ctx context.Context, in *ListSupportedHooksRequest, opts ...grpc.CallOption) (*ListSupportedHooksResponse, error)
+	// ValidatePluginAnswers checks answers to questions of a plugin, clients
+	// use it to validate inputs of a new job
+	ValidatePluginAnswers(ctx context.Context, in *ValidatePluginAnswersRequest, opts ...grpc.CallOption) (*ValidatePluginAnswersResponse, error)
 	// DeployResourceSpecification migrate all resource specifications of a datastore in project
 	// State of the world request
 	DeployResourceSpecification(ctx context.Context, in *DeployResourceSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_DeployResourceSpecificationClient, error)
@@ -69,9 +141,30 @@ type RuntimeServiceClient interface {
 	// Datastore CRUD
 	CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error)
 	ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error)
+	// CheckResource compares a resource as specified with the one living in
+	// datastore and lists how it drifted
+	CheckResource(ctx context.Context, in *CheckResourceRequest, opts ...grpc.CallOption) (*CheckResourceResponse, error)
 	UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UpdateResourceResponse, error)
+	// BackupResource copies a resource, and the resources downstream of it, to
+	// backups expiring after a ttl
+	BackupResource(ctx context.Context, in *BackupResourceRequest, opts ...grpc.CallOption) (*BackupResourceResponse, error)
+	// ListBackups lists backups of resources of a datastore, latest first
+	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
+	// RestoreBackup overwrites resources of a backup with their copies
+	RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
 	ReplayDryRun(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayDryRunResponse, error)
 	Replay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
+	// ListReplays lists active and finished replays of jobs of a project, latest first
+	ListReplays(ctx context.Context, in *ListReplaysRequest, opts ...grpc.CallOption) (*ListReplaysResponse, error)
+	// ReplayStatus streams status of a replay as its jobs are cleared, till
+	// the replay ends
+	ReplayStatus(ctx context.Context, in *ReplayStatusRequest, opts ...grpc.CallOption) (RuntimeService_ReplayStatusClient, error)
+	// DeleteProject soft deletes a project, its jobs are removed from scheduler and
+	// it can be restored till it is purged
+	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
+	// RestoreProject brings back a deleted project till it is purged and
+	// schedules its jobs again
+	RestoreProject(ctx context.Context, in *RestoreProjectRequest, opts ...grpc.CallOption) (*RestoreProjectResponse, error)
 }
 
 type runtimeServiceClient struct {
@@ -123,6 +216,104 @@ func (x *runtimeServiceDeployJobSpecificationClient) Recv() (*DeployJobSpecifica
 	return m, nil
 }
 
+func (c *runtimeServiceClient) DeployJobSpecificationStream(ctx context.Context, opts ...grpc.CallOption) (RuntimeService_DeployJobSpecificationStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[1], "/odpf.optimus.RuntimeService/DeployJobSpecificationStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceDeployJobSpecificationStreamClient{stream}
+	return x, nil
+}
+
+type RuntimeService_DeployJobSpecificationStreamClient interface {
+	Send(*DeployJobSpecificationRequest) error
+	Recv() (*DeployJobSpecificationResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceDeployJobSpecificationStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceDeployJobSpecificationStreamClient) Send(m *DeployJobSpecificationRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *runtimeServiceDeployJobSpecificationStreamClient) Recv() (*DeployJobSpecificationResponse, error) {
+	m := new(DeployJobSpecificationResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeServiceClient) DeployJobSpecificationAsync(ctx context.Context, in *DeployJobSpecificationRequest, opts ...grpc.CallOption) (*DeployJobSpecificationAsyncResponse, error) {
+	out := new(DeployJobSpecificationAsyncResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/DeployJobSpecificationAsync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetDeploymentStatus(ctx context.Context, in *GetDeploymentStatusRequest, opts ...grpc.CallOption) (*GetDeploymentStatusResponse, error) {
+	out := new(GetDeploymentStatusResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetDeploymentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error) {
+	out := new(ListDeploymentsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListDeployments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetDeployment(ctx context.Context, in *GetDeploymentRequest, opts ...grpc.CallOption) (*GetDeploymentResponse, error) {
+	out := new(GetDeploymentResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetDeployment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ImportJobSpecifications(ctx context.Context, opts ...grpc.CallOption) (RuntimeService_ImportJobSpecificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[2], "/odpf.optimus.RuntimeService/ImportJobSpecifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceImportJobSpecificationsClient{stream}
+	return x, nil
+}
+
+type RuntimeService_ImportJobSpecificationsClient interface {
+	Send(*ImportJobSpecificationsRequest) error
+	Recv() (*DeployJobSpecificationResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceImportJobSpecificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceImportJobSpecificationsClient) Send(m *ImportJobSpecificationsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *runtimeServiceImportJobSpecificationsClient) Recv() (*DeployJobSpecificationResponse, error) {
+	m := new(DeployJobSpecificationResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *runtimeServiceClient) CreateJobSpecification(ctx context.Context, in *CreateJobSpecificationRequest, opts ...grpc.CallOption) (*CreateJobSpecificationResponse, error) {
 	out := new(CreateJobSpecificationResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CreateJobSpecification", in, out, opts...)
@@ -150,6 +341,69 @@ func (c *runtimeServiceClient) DeleteJobSpecification(ctx context.Context, in *D
 	return out, nil
 }
 
+func (c *runtimeServiceClient) PauseJob(ctx context.Context, in *PauseJobRequest, opts ...grpc.CallOption) (*PauseJobResponse, error) {
+	out := new(PauseJobResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/PauseJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (*ResumeJobResponse, error) {
+	out := new(ResumeJobResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ResumeJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RestoreJob(ctx context.Context, in *RestoreJobRequest, opts ...grpc.CallOption) (*RestoreJobResponse, error) {
+	out := new(RestoreJobResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RestoreJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListJobVersions(ctx context.Context, in *ListJobVersionsRequest, opts ...grpc.CallOption) (*ListJobVersionsResponse, error) {
+	out := new(ListJobVersionsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListJobVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetJobVersionDiff(ctx context.Context, in *GetJobVersionDiffRequest, opts ...grpc.CallOption) (*GetJobVersionDiffResponse, error) {
+	out := new(GetJobVersionDiffResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetJobVersionDiff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RollbackJob(ctx context.Context, in *RollbackJobRequest, opts ...grpc.CallOption) (*RollbackJobResponse, error) {
+	out := new(RollbackJobResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RollbackJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetLineage(ctx context.Context, in *GetLineageRequest, opts ...grpc.CallOption) (*GetLineageResponse, error) {
+	out := new(GetLineageResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetLineage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) ListJobSpecification(ctx context.Context, in *ListJobSpecificationRequest, opts ...grpc.CallOption) (*ListJobSpecificationResponse, error) {
 	out := new(ListJobSpecificationResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListJobSpecification", in, out, opts...)
@@ -168,6 +422,15 @@ func (c *runtimeServiceClient) DumpJobSpecification(ctx context.Context, in *Dum
 	return out, nil
 }
 
+func (c *runtimeServiceClient) CompileJob(ctx context.Context, in *CompileJobRequest, opts ...grpc.CallOption) (*CompileJobResponse, error) {
+	out := new(CompileJobResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CompileJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) CheckJobSpecification(ctx context.Context, in *CheckJobSpecificationRequest, opts ...grpc.CallOption) (*CheckJobSpecificationResponse, error) {
 	out := new(CheckJobSpecificationResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CheckJobSpecification", in, out, opts...)
@@ -178,7 +441,7 @@ func (c *runtimeServiceClient) CheckJobSpecification(ctx context.Context, in *Ch
 }
 
 func (c *runtimeServiceClient) CheckJobSpecifications(ctx context.Context, in *CheckJobSpecificationsRequest, opts ...grpc.CallOption) (RuntimeService_CheckJobSpecificationsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[1], "/odpf.optimus.RuntimeService/CheckJobSpecifications", opts...)
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[3], "/odpf.optimus.RuntimeService/CheckJobSpecifications", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -236,6 +499,87 @@ func (c *runtimeServiceClient) RegisterSecret(ctx context.Context, in *RegisterS
 	return out, nil
 }
 
+func (c *runtimeServiceClient) UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*UpdateSecretResponse, error) {
+	out := new(UpdateSecretResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/UpdateSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListSecretKeys(ctx context.Context, in *ListSecretKeysRequest, opts ...grpc.CallOption) (*ListSecretKeysResponse, error) {
+	out := new(ListSecretKeysResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListSecretKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) CreateRoleBinding(ctx context.Context, in *CreateRoleBindingRequest, opts ...grpc.CallOption) (*CreateRoleBindingResponse, error) {
+	out := new(CreateRoleBindingResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CreateRoleBinding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) DeleteRoleBinding(ctx context.Context, in *DeleteRoleBindingRequest, opts ...grpc.CallOption) (*DeleteRoleBindingResponse, error) {
+	out := new(DeleteRoleBindingResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/DeleteRoleBinding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListRoleBindings(ctx context.Context, in *ListRoleBindingsRequest, opts ...grpc.CallOption) (*ListRoleBindingsResponse, error) {
+	out := new(ListRoleBindingsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListRoleBindings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RegisterMacro(ctx context.Context, in *RegisterMacroRequest, opts ...grpc.CallOption) (*RegisterMacroResponse, error) {
+	out := new(RegisterMacroResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RegisterMacro", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) DeleteMacro(ctx context.Context, in *DeleteMacroRequest, opts ...grpc.CallOption) (*DeleteMacroResponse, error) {
+	out := new(DeleteMacroResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/DeleteMacro", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListMacros(ctx context.Context, in *ListMacrosRequest, opts ...grpc.CallOption) (*ListMacrosResponse, error) {
+	out := new(ListMacrosResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListMacros", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
 	out := new(ListProjectsResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListProjects", in, out, opts...)
@@ -272,6 +616,24 @@ func (c *runtimeServiceClient) JobStatus(ctx context.Context, in *JobStatusReque
 	return out, nil
 }
 
+func (c *runtimeServiceClient) ClearJobRuns(ctx context.Context, in *ClearJobRunsRequest, opts ...grpc.CallOption) (*ClearJobRunsResponse, error) {
+	out := new(ClearJobRunsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ClearJobRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetJobRuns(ctx context.Context, in *GetJobRunsRequest, opts ...grpc.CallOption) (*GetJobRunsResponse, error) {
+	out := new(GetJobRunsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetJobRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) RegisterJobEvent(ctx context.Context, in *RegisterJobEventRequest, opts ...grpc.CallOption) (*RegisterJobEventResponse, error) {
 	out := new(RegisterJobEventResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RegisterJobEvent", in, out, opts...)
@@ -290,8 +652,35 @@ func (c *runtimeServiceClient) GetWindow(ctx context.Context, in *GetWindowReque
 	return out, nil
 }
 
+func (c *runtimeServiceClient) ListSupportedTasks(ctx context.Context, in *ListSupportedTasksRequest, opts ...grpc.CallOption) (*ListSupportedTasksResponse, error) {
+	out := new(ListSupportedTasksResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListSupportedTasks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListSupportedHooks(ctx context.Context, in *ListSupportedHooksRequest, opts ...grpc.CallOption) (*ListSupportedHooksResponse, error) {
+	out := new(ListSupportedHooksResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListSupportedHooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ValidatePluginAnswers(ctx context.Context, in *ValidatePluginAnswersRequest, opts ...grpc.CallOption) (*ValidatePluginAnswersResponse, error) {
+	out := new(ValidatePluginAnswersResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ValidatePluginAnswers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) DeployResourceSpecification(ctx context.Context, in *DeployResourceSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_DeployResourceSpecificationClient, error) {
-	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[2], "/odpf.optimus.RuntimeService/DeployResourceSpecification", opts...)
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[4], "/odpf.optimus.RuntimeService/DeployResourceSpecification", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -349,6 +738,15 @@ func (c *runtimeServiceClient) ReadResource(ctx context.Context, in *ReadResourc
 	return out, nil
 }
 
+func (c *runtimeServiceClient) CheckResource(ctx context.Context, in *CheckResourceRequest, opts ...grpc.CallOption) (*CheckResourceResponse, error) {
+	out := new(CheckResourceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CheckResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UpdateResourceResponse, error) {
 	out := new(UpdateResourceResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/UpdateResource", in, out, opts...)
@@ -358,6 +756,33 @@ func (c *runtimeServiceClient) UpdateResource(ctx context.Context, in *UpdateRes
 	return out, nil
 }
 
+func (c *runtimeServiceClient) BackupResource(ctx context.Context, in *BackupResourceRequest, opts ...grpc.CallOption) (*BackupResourceResponse, error) {
+	out := new(BackupResourceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/BackupResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RestoreBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) ReplayDryRun(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayDryRunResponse, error) {
 	out := new(ReplayDryRunResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ReplayDryRun", in, out, opts...)
@@ -376,39 +801,157 @@ func (c *runtimeServiceClient) Replay(ctx context.Context, in *ReplayRequest, op
 	return out, nil
 }
 
-// RuntimeServiceServer is the server API for RuntimeService service.
-// All implementations must embed UnimplementedRuntimeServiceServer
-// for forward compatibility
-type RuntimeServiceServer interface {
-	// server ping with version
-	Version(context.Context, *VersionRequest) (*VersionResponse, error)
-	// DeployJobSpecification schedules jobs for execution
-	// returns a stream of messages which can be used to track the progress
-	// of deployments. Message containing ack are status events other are progress
-	// events
-	// State of the world request
-	DeployJobSpecification(*DeployJobSpecificationRequest, RuntimeService_DeployJobSpecificationServer) error
-	// CreateJobSpecification registers a new job for a namespace which belongs to a project
-	CreateJobSpecification(context.Context, *CreateJobSpecificationRequest) (*CreateJobSpecificationResponse, error)
-	// ReadJobSpecification reads a provided job spec of a namespace
-	ReadJobSpecification(context.Context, *ReadJobSpecificationRequest) (*ReadJobSpecificationResponse, error)
-	// DeleteJobSpecification deletes a job spec of a namespace
-	DeleteJobSpecification(context.Context, *DeleteJobSpecificationRequest) (*DeleteJobSpecificationResponse, error)
-	// ListJobSpecification returns list of jobs created in a project
-	ListJobSpecification(context.Context, *ListJobSpecificationRequest) (*ListJobSpecificationResponse, error)
-	// DumpJobSpecification returns compiled representation of the job in a scheduler
-	// consumable form
-	DumpJobSpecification(context.Context, *DumpJobSpecificationRequest) (*DumpJobSpecificationResponse, error)
-	// CheckJobSpecification checks if a job specification is valid
-	CheckJobSpecification(context.Context, *CheckJobSpecificationRequest) (*CheckJobSpecificationResponse, error)
-	// CheckJobSpecifications checks if the job specifications are valid
-	CheckJobSpecifications(*CheckJobSpecificationsRequest, RuntimeService_CheckJobSpecificationsServer) error
-	// RegisterProject creates a new optimus project
-	RegisterProject(context.Context, *RegisterProjectRequest) (*RegisterProjectResponse, error)
-	// RegisterProjectNamespace creates a new namespace for a project
-	RegisterProjectNamespace(context.Context, *RegisterProjectNamespaceRequest) (*RegisterProjectNamespaceResponse, error)
-	// RegisterSecret creates a new secret of a project
+func (c *runtimeServiceClient) ListReplays(ctx context.Context, in *ListReplaysRequest, opts ...grpc.CallOption) (*ListReplaysResponse, error) {
+	out := new(ListReplaysResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListReplays", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ReplayStatus(ctx context.Context, in *ReplayStatusRequest, opts ...grpc.CallOption) (RuntimeService_ReplayStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[5], "/odpf.optimus.RuntimeService/ReplayStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceReplayStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RuntimeService_ReplayStatusClient interface {
+	Recv() (*ReplayStatusResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceReplayStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceReplayStatusClient) Recv() (*ReplayStatusResponse, error) {
+	m := new(ReplayStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error) {
+	out := new(DeleteProjectResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/DeleteProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RestoreProject(ctx context.Context, in *RestoreProjectRequest, opts ...grpc.CallOption) (*RestoreProjectResponse, error) {
+	out := new(RestoreProjectResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RestoreProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RuntimeServiceServer is the server API for RuntimeService service.
+// All implementations must embed UnimplementedRuntimeServiceServer
+// for forward compatibility
+type RuntimeServiceServer interface {
+	// server ping with version
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+	// DeployJobSpecification schedules jobs for execution
+	// returns a stream of messages which can be used to track the progress
+	// of deployments. Message containing ack are status events other are progress
+	// events
+	// State of the world request
+	DeployJobSpecification(*DeployJobSpecificationRequest, RuntimeService_DeployJobSpecificationServer) error
+	// DeployJobSpecificationStream deploys jobs of a namespace streamed in
+	// chunks to stay under the maximum message size. Project, namespace and
+	// reconcile are only read from the first message of the stream. Jobs are
+	// saved and acknowledged as each chunk is received, the namespace is
+	// synced once the client closes its side of the stream
+	DeployJobSpecificationStream(RuntimeService_DeployJobSpecificationStreamServer) error
+	// DeployJobSpecificationAsync queues jobs of a namespace for deployment
+	// and returns a deployment id right away, jobs are deployed in background
+	// and the progress can be polled with GetDeploymentStatus
+	DeployJobSpecificationAsync(context.Context, *DeployJobSpecificationRequest) (*DeployJobSpecificationAsyncResponse, error)
+	// GetDeploymentStatus returns the progress of each job of an async deployment
+	GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error)
+	// ListDeployments returns history of finished deployments of a project
+	ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error)
+	// GetDeployment returns a finished deployment with outcome of each job
+	GetDeployment(context.Context, *GetDeploymentRequest) (*GetDeploymentResponse, error)
+	// ImportJobSpecifications deploys jobs read from a gzipped tar archive of
+	// job specifications streamed in chunks, archive follows the same layout
+	// as a local job specification directory. Responses are same as of
+	// DeployJobSpecification
+	ImportJobSpecifications(RuntimeService_ImportJobSpecificationsServer) error
+	// CreateJobSpecification registers a new job for a namespace which belongs to a project
+	CreateJobSpecification(context.Context, *CreateJobSpecificationRequest) (*CreateJobSpecificationResponse, error)
+	// ReadJobSpecification reads a provided job spec of a namespace
+	ReadJobSpecification(context.Context, *ReadJobSpecificationRequest) (*ReadJobSpecificationResponse, error)
+	// DeleteJobSpecification deletes a job spec of a namespace
+	DeleteJobSpecification(context.Context, *DeleteJobSpecificationRequest) (*DeleteJobSpecificationResponse, error)
+	// PauseJob stops scheduling a job without removing its spec
+	PauseJob(context.Context, *PauseJobRequest) (*PauseJobResponse, error)
+	// ResumeJob schedules a paused job again
+	ResumeJob(context.Context, *ResumeJobRequest) (*ResumeJobResponse, error)
+	// RestoreJob brings back a deleted job till it is purged and schedules it again
+	RestoreJob(context.Context, *RestoreJobRequest) (*RestoreJobResponse, error)
+	// ListJobVersions lists versions of a job saved on every deployment, latest first
+	ListJobVersions(context.Context, *ListJobVersionsRequest) (*ListJobVersionsResponse, error)
+	// GetJobVersionDiff compares fields of a job between two of its versions
+	GetJobVersionDiff(context.Context, *GetJobVersionDiffRequest) (*GetJobVersionDiffResponse, error)
+	// RollbackJob redeploys a job as it was at an earlier version
+	RollbackJob(context.Context, *RollbackJobRequest) (*RollbackJobResponse, error)
+	// GetLineage walks jobs upstream and downstream of a job across projects
+	// through the resources they read and write, returning a graph of them
+	GetLineage(context.Context, *GetLineageRequest) (*GetLineageResponse, error)
+	// ListJobSpecification returns list of jobs created in a project
+	ListJobSpecification(context.Context, *ListJobSpecificationRequest) (*ListJobSpecificationResponse, error)
+	// DumpJobSpecification returns compiled representation of the job in a scheduler
+	// consumable form
+	DumpJobSpecification(context.Context, *DumpJobSpecificationRequest) (*DumpJobSpecificationResponse, error)
+	// CompileJob returns the exact artifact scheduler would receive for a job,
+	// resolving its dependencies and priority against deployed jobs of the project
+	CompileJob(context.Context, *CompileJobRequest) (*CompileJobResponse, error)
+	// CheckJobSpecification checks if a job specification is valid
+	CheckJobSpecification(context.Context, *CheckJobSpecificationRequest) (*CheckJobSpecificationResponse, error)
+	// CheckJobSpecifications checks if the job specifications are valid
+	CheckJobSpecifications(*CheckJobSpecificationsRequest, RuntimeService_CheckJobSpecificationsServer) error
+	// RegisterProject creates a new optimus project
+	RegisterProject(context.Context, *RegisterProjectRequest) (*RegisterProjectResponse, error)
+	// RegisterProjectNamespace creates a new namespace for a project
+	RegisterProjectNamespace(context.Context, *RegisterProjectNamespaceRequest) (*RegisterProjectNamespaceResponse, error)
+	// RegisterSecret creates a new secret of a project
 	RegisterSecret(context.Context, *RegisterSecretRequest) (*RegisterSecretResponse, error)
+	// UpdateSecret replaces value of an existing secret of a project
+	UpdateSecret(context.Context, *UpdateSecretRequest) (*UpdateSecretResponse, error)
+	// ListSecretKeys returns names of the secrets of a project
+	ListSecretKeys(context.Context, *ListSecretKeysRequest) (*ListSecretKeysResponse, error)
+	// CreateRoleBinding grants a role in a project or one of its namespaces
+	// to a subject, existing role of the subject in same scope is replaced
+	CreateRoleBinding(context.Context, *CreateRoleBindingRequest) (*CreateRoleBindingResponse, error)
+	// DeleteRoleBinding revokes role of a subject in a project or namespace
+	DeleteRoleBinding(context.Context, *DeleteRoleBindingRequest) (*DeleteRoleBindingResponse, error)
+	// ListRoleBindings returns roles granted in a project
+	ListRoleBindings(context.Context, *ListRoleBindingsRequest) (*ListRoleBindingsResponse, error)
+	// ListAuditEvents returns recorded calls of mutating rpcs made for a project
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// RegisterMacro creates a macro usable in job templates of a project or
+	// one of its namespaces, existing macro with same name in same scope is replaced
+	RegisterMacro(context.Context, *RegisterMacroRequest) (*RegisterMacroResponse, error)
+	// DeleteMacro removes a macro from a project or namespace
+	DeleteMacro(context.Context, *DeleteMacroRequest) (*DeleteMacroResponse, error)
+	// ListMacros returns macros of a project and optionally of a namespace
+	ListMacros(context.Context, *ListMacrosRequest) (*ListMacrosResponse, error)
 	// ListProjects returns list of registered projects and configurations
 	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
 	// ListProjectNamespaces returns list of namespaces of a project
@@ -418,11 +961,24 @@ type RuntimeServiceServer interface {
 	RegisterInstance(context.Context, *RegisterInstanceRequest) (*RegisterInstanceResponse, error)
 	// JobStatus returns the current and past run status of jobs
 	JobStatus(context.Context, *JobStatusRequest) (*JobStatusResponse, error)
+	// ClearJobRuns clears runs of a job scheduled between two dates so scheduler
+	// runs them again
+	ClearJobRuns(context.Context, *ClearJobRunsRequest) (*ClearJobRunsResponse, error)
+	// GetJobRuns reads run history of jobs of a project, oldest schedule first,
+	// along with the number of runs in each state
+	GetJobRuns(context.Context, *GetJobRunsRequest) (*GetJobRunsResponse, error)
 	// RegisterJobEvent notifies optimus service about an event related to job
 	RegisterJobEvent(context.Context, *RegisterJobEventRequest) (*RegisterJobEventResponse, error)
 	// GetWindow provides the start and end dates provided a scheduled date
 	// of the execution window
 	GetWindow(context.Context, *GetWindowRequest) (*GetWindowResponse, error)
+	// ListSupportedTasks returns task plugins installed on the server
+	ListSupportedTasks(context.Context, *ListSupportedTasksRequest) (*ListSupportedTasksResponse, error)
+	// ListSupportedHooks returns hook plugins installed on the server
+	ListSupportedHooks(context.Context, *ListSupportedHooksRequest) (*ListSupportedHooksResponse, error)
+	// ValidatePluginAnswers checks answers to questions of a plugin, clients
+	// use it to validate inputs of a new job
+	ValidatePluginAnswers(context.Context, *ValidatePluginAnswersRequest) (*ValidatePluginAnswersResponse, error)
 	// DeployResourceSpecification migrate all resource specifications of a datastore in project
 	// State of the world request
 	DeployResourceSpecification(*DeployResourceSpecificationRequest, RuntimeService_DeployResourceSpecificationServer) error
@@ -431,9 +987,30 @@ type RuntimeServiceServer interface {
 	// Datastore CRUD
 	CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error)
 	ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error)
+	// CheckResource compares a resource as specified with the one living in
+	// datastore and lists how it drifted
+	CheckResource(context.Context, *CheckResourceRequest) (*CheckResourceResponse, error)
 	UpdateResource(context.Context, *UpdateResourceRequest) (*UpdateResourceResponse, error)
+	// BackupResource copies a resource, and the resources downstream of it, to
+	// backups expiring after a ttl
+	BackupResource(context.Context, *BackupResourceRequest) (*BackupResourceResponse, error)
+	// ListBackups lists backups of resources of a datastore, latest first
+	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
+	// RestoreBackup overwrites resources of a backup with their copies
+	RestoreBackup(context.Context, *RestoreBackupRequest) (*RestoreBackupResponse, error)
 	ReplayDryRun(context.Context, *ReplayRequest) (*ReplayDryRunResponse, error)
 	Replay(context.Context, *ReplayRequest) (*ReplayResponse, error)
+	// ListReplays lists active and finished replays of jobs of a project, latest first
+	ListReplays(context.Context, *ListReplaysRequest) (*ListReplaysResponse, error)
+	// ReplayStatus streams status of a replay as its jobs are cleared, till
+	// the replay ends
+	ReplayStatus(*ReplayStatusRequest, RuntimeService_ReplayStatusServer) error
+	// DeleteProject soft deletes a project, its jobs are removed from scheduler and
+	// it can be restored till it is purged
+	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
+	// RestoreProject brings back a deleted project till it is purged and
+	// schedules its jobs again
+	RestoreProject(context.Context, *RestoreProjectRequest) (*RestoreProjectResponse, error)
 	mustEmbedUnimplementedRuntimeServiceServer()
 }
 
@@ -447,6 +1024,24 @@ func (UnimplementedRuntimeServiceServer) Version(context.Context, *VersionReques
 func (UnimplementedRuntimeServiceServer) DeployJobSpecification(*DeployJobSpecificationRequest, RuntimeService_DeployJobSpecificationServer) error {
 	return status.Errorf(codes.Unimplemented, "method DeployJobSpecification not implemented")
 }
+func (UnimplementedRuntimeServiceServer) DeployJobSpecificationStream(RuntimeService_DeployJobSpecificationStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DeployJobSpecificationStream not implemented")
+}
+func (UnimplementedRuntimeServiceServer) DeployJobSpecificationAsync(context.Context, *DeployJobSpecificationRequest) (*DeployJobSpecificationAsyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployJobSpecificationAsync not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeploymentStatus not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeployments not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetDeployment(context.Context, *GetDeploymentRequest) (*GetDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeployment not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ImportJobSpecifications(RuntimeService_ImportJobSpecificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportJobSpecifications not implemented")
+}
 func (UnimplementedRuntimeServiceServer) CreateJobSpecification(context.Context, *CreateJobSpecificationRequest) (*CreateJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateJobSpecification not implemented")
 }
@@ -456,12 +1051,36 @@ func (UnimplementedRuntimeServiceServer) ReadJobSpecification(context.Context, *
 func (UnimplementedRuntimeServiceServer) DeleteJobSpecification(context.Context, *DeleteJobSpecificationRequest) (*DeleteJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteJobSpecification not implemented")
 }
+func (UnimplementedRuntimeServiceServer) PauseJob(context.Context, *PauseJobRequest) (*PauseJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseJob not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ResumeJob(context.Context, *ResumeJobRequest) (*ResumeJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeJob not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RestoreJob(context.Context, *RestoreJobRequest) (*RestoreJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreJob not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListJobVersions(context.Context, *ListJobVersionsRequest) (*ListJobVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobVersions not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetJobVersionDiff(context.Context, *GetJobVersionDiffRequest) (*GetJobVersionDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobVersionDiff not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RollbackJob(context.Context, *RollbackJobRequest) (*RollbackJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackJob not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetLineage(context.Context, *GetLineageRequest) (*GetLineageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLineage not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ListJobSpecification(context.Context, *ListJobSpecificationRequest) (*ListJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListJobSpecification not implemented")
 }
 func (UnimplementedRuntimeServiceServer) DumpJobSpecification(context.Context, *DumpJobSpecificationRequest) (*DumpJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DumpJobSpecification not implemented")
 }
+func (UnimplementedRuntimeServiceServer) CompileJob(context.Context, *CompileJobRequest) (*CompileJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompileJob not implemented")
+}
 func (UnimplementedRuntimeServiceServer) CheckJobSpecification(context.Context, *CheckJobSpecificationRequest) (*CheckJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckJobSpecification not implemented")
 }
@@ -477,6 +1096,33 @@ func (UnimplementedRuntimeServiceServer) RegisterProjectNamespace(context.Contex
 func (UnimplementedRuntimeServiceServer) RegisterSecret(context.Context, *RegisterSecretRequest) (*RegisterSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterSecret not implemented")
 }
+func (UnimplementedRuntimeServiceServer) UpdateSecret(context.Context, *UpdateSecretRequest) (*UpdateSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSecret not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListSecretKeys(context.Context, *ListSecretKeysRequest) (*ListSecretKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecretKeys not implemented")
+}
+func (UnimplementedRuntimeServiceServer) CreateRoleBinding(context.Context, *CreateRoleBindingRequest) (*CreateRoleBindingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoleBinding not implemented")
+}
+func (UnimplementedRuntimeServiceServer) DeleteRoleBinding(context.Context, *DeleteRoleBindingRequest) (*DeleteRoleBindingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoleBinding not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListRoleBindings(context.Context, *ListRoleBindingsRequest) (*ListRoleBindingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleBindings not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RegisterMacro(context.Context, *RegisterMacroRequest) (*RegisterMacroResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterMacro not implemented")
+}
+func (UnimplementedRuntimeServiceServer) DeleteMacro(context.Context, *DeleteMacroRequest) (*DeleteMacroResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMacro not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListMacros(context.Context, *ListMacrosRequest) (*ListMacrosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMacros not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
 }
@@ -489,12 +1135,27 @@ func (UnimplementedRuntimeServiceServer) RegisterInstance(context.Context, *Regi
 func (UnimplementedRuntimeServiceServer) JobStatus(context.Context, *JobStatusRequest) (*JobStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JobStatus not implemented")
 }
+func (UnimplementedRuntimeServiceServer) ClearJobRuns(context.Context, *ClearJobRunsRequest) (*ClearJobRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearJobRuns not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetJobRuns(context.Context, *GetJobRunsRequest) (*GetJobRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobRuns not implemented")
+}
 func (UnimplementedRuntimeServiceServer) RegisterJobEvent(context.Context, *RegisterJobEventRequest) (*RegisterJobEventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterJobEvent not implemented")
 }
 func (UnimplementedRuntimeServiceServer) GetWindow(context.Context, *GetWindowRequest) (*GetWindowResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetWindow not implemented")
 }
+func (UnimplementedRuntimeServiceServer) ListSupportedTasks(context.Context, *ListSupportedTasksRequest) (*ListSupportedTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSupportedTasks not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListSupportedHooks(context.Context, *ListSupportedHooksRequest) (*ListSupportedHooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSupportedHooks not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ValidatePluginAnswers(context.Context, *ValidatePluginAnswersRequest) (*ValidatePluginAnswersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePluginAnswers not implemented")
+}
 func (UnimplementedRuntimeServiceServer) DeployResourceSpecification(*DeployResourceSpecificationRequest, RuntimeService_DeployResourceSpecificationServer) error {
 	return status.Errorf(codes.Unimplemented, "method DeployResourceSpecification not implemented")
 }
@@ -507,15 +1168,39 @@ func (UnimplementedRuntimeServiceServer) CreateResource(context.Context, *Create
 func (UnimplementedRuntimeServiceServer) ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadResource not implemented")
 }
+func (UnimplementedRuntimeServiceServer) CheckResource(context.Context, *CheckResourceRequest) (*CheckResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckResource not implemented")
+}
 func (UnimplementedRuntimeServiceServer) UpdateResource(context.Context, *UpdateResourceRequest) (*UpdateResourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateResource not implemented")
 }
+func (UnimplementedRuntimeServiceServer) BackupResource(context.Context, *BackupResourceRequest) (*BackupResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupResource not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackups not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RestoreBackup(context.Context, *RestoreBackupRequest) (*RestoreBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBackup not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ReplayDryRun(context.Context, *ReplayRequest) (*ReplayDryRunResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReplayDryRun not implemented")
 }
 func (UnimplementedRuntimeServiceServer) Replay(context.Context, *ReplayRequest) (*ReplayResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Replay not implemented")
 }
+func (UnimplementedRuntimeServiceServer) ListReplays(context.Context, *ListReplaysRequest) (*ListReplaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReplays not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ReplayStatus(*ReplayStatusRequest, RuntimeService_ReplayStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReplayStatus not implemented")
+}
+func (UnimplementedRuntimeServiceServer) DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RestoreProject(context.Context, *RestoreProjectRequest) (*RestoreProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreProject not implemented")
+}
 func (UnimplementedRuntimeServiceServer) mustEmbedUnimplementedRuntimeServiceServer() {}
 
 // UnsafeRuntimeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -568,6 +1253,130 @@ func (x *runtimeServiceDeployJobSpecificationServer) Send(m *DeployJobSpecificat
 	return x.ServerStream.SendMsg(m)
 }
 
+func _RuntimeService_DeployJobSpecificationStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RuntimeServiceServer).DeployJobSpecificationStream(&runtimeServiceDeployJobSpecificationStreamServer{stream})
+}
+
+type RuntimeService_DeployJobSpecificationStreamServer interface {
+	Send(*DeployJobSpecificationResponse) error
+	Recv() (*DeployJobSpecificationRequest, error)
+	grpc.ServerStream
+}
+
+type runtimeServiceDeployJobSpecificationStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceDeployJobSpecificationStreamServer) Send(m *DeployJobSpecificationResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *runtimeServiceDeployJobSpecificationStreamServer) Recv() (*DeployJobSpecificationRequest, error) {
+	m := new(DeployJobSpecificationRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _RuntimeService_DeployJobSpecificationAsync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployJobSpecificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).DeployJobSpecificationAsync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/DeployJobSpecificationAsync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).DeployJobSpecificationAsync(ctx, req.(*DeployJobSpecificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetDeploymentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeploymentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetDeploymentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetDeploymentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetDeploymentStatus(ctx, req.(*GetDeploymentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListDeployments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListDeployments(ctx, req.(*ListDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetDeployment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetDeployment(ctx, req.(*GetDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ImportJobSpecifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RuntimeServiceServer).ImportJobSpecifications(&runtimeServiceImportJobSpecificationsServer{stream})
+}
+
+type RuntimeService_ImportJobSpecificationsServer interface {
+	Send(*DeployJobSpecificationResponse) error
+	Recv() (*ImportJobSpecificationsRequest, error)
+	grpc.ServerStream
+}
+
+type runtimeServiceImportJobSpecificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceImportJobSpecificationsServer) Send(m *DeployJobSpecificationResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *runtimeServiceImportJobSpecificationsServer) Recv() (*ImportJobSpecificationsRequest, error) {
+	m := new(ImportJobSpecificationsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _RuntimeService_CreateJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateJobSpecificationRequest)
 	if err := dec(in); err != nil {
@@ -622,6 +1431,132 @@ func _RuntimeService_DeleteJobSpecification_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_PauseJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).PauseJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/PauseJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).PauseJob(ctx, req.(*PauseJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ResumeJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ResumeJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ResumeJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ResumeJob(ctx, req.(*ResumeJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RestoreJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RestoreJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RestoreJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RestoreJob(ctx, req.(*RestoreJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListJobVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListJobVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListJobVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListJobVersions(ctx, req.(*ListJobVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetJobVersionDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobVersionDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetJobVersionDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetJobVersionDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetJobVersionDiff(ctx, req.(*GetJobVersionDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RollbackJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RollbackJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RollbackJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RollbackJob(ctx, req.(*RollbackJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLineageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetLineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetLineage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetLineage(ctx, req.(*GetLineageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_ListJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListJobSpecificationRequest)
 	if err := dec(in); err != nil {
@@ -658,6 +1593,24 @@ func _RuntimeService_DumpJobSpecification_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_CompileJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompileJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).CompileJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/CompileJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).CompileJob(ctx, req.(*CompileJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_CheckJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckJobSpecificationRequest)
 	if err := dec(in); err != nil {
@@ -710,43 +1663,205 @@ func _RuntimeService_RegisterProject_Handler(srv interface{}, ctx context.Contex
 		FullMethod: "/odpf.optimus.RuntimeService/RegisterProject",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).RegisterProject(ctx, req.(*RegisterProjectRequest))
+		return srv.(RuntimeServiceServer).RegisterProject(ctx, req.(*RegisterProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RegisterProjectNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterProjectNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RegisterProjectNamespace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, req.(*RegisterProjectNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RegisterSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RegisterSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RegisterSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RegisterSecret(ctx, req.(*RegisterSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_UpdateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).UpdateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/UpdateSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).UpdateSecret(ctx, req.(*UpdateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListSecretKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListSecretKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListSecretKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListSecretKeys(ctx, req.(*ListSecretKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_CreateRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoleBindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).CreateRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/CreateRoleBinding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).CreateRoleBinding(ctx, req.(*CreateRoleBindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_DeleteRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleBindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).DeleteRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/DeleteRoleBinding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).DeleteRoleBinding(ctx, req.(*DeleteRoleBindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListRoleBindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleBindingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListRoleBindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListRoleBindings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListRoleBindings(ctx, req.(*ListRoleBindingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RegisterMacro_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterMacroRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RegisterMacro(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RegisterMacro",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RegisterMacro(ctx, req.(*RegisterMacroRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_RegisterProjectNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterProjectNamespaceRequest)
+func _RuntimeService_DeleteMacro_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMacroRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, in)
+		return srv.(RuntimeServiceServer).DeleteMacro(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/RegisterProjectNamespace",
+		FullMethod: "/odpf.optimus.RuntimeService/DeleteMacro",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, req.(*RegisterProjectNamespaceRequest))
+		return srv.(RuntimeServiceServer).DeleteMacro(ctx, req.(*DeleteMacroRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_RegisterSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterSecretRequest)
+func _RuntimeService_ListMacros_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMacrosRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).RegisterSecret(ctx, in)
+		return srv.(RuntimeServiceServer).ListMacros(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/RegisterSecret",
+		FullMethod: "/odpf.optimus.RuntimeService/ListMacros",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).RegisterSecret(ctx, req.(*RegisterSecretRequest))
+		return srv.(RuntimeServiceServer).ListMacros(ctx, req.(*ListMacrosRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -823,6 +1938,42 @@ func _RuntimeService_JobStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_ClearJobRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearJobRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ClearJobRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ClearJobRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ClearJobRuns(ctx, req.(*ClearJobRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetJobRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetJobRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetJobRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetJobRuns(ctx, req.(*GetJobRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_RegisterJobEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RegisterJobEventRequest)
 	if err := dec(in); err != nil {
@@ -859,6 +2010,60 @@ func _RuntimeService_GetWindow_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_ListSupportedTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSupportedTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListSupportedTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListSupportedTasks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListSupportedTasks(ctx, req.(*ListSupportedTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListSupportedHooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSupportedHooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListSupportedHooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListSupportedHooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListSupportedHooks(ctx, req.(*ListSupportedHooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ValidatePluginAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePluginAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ValidatePluginAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ValidatePluginAnswers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ValidatePluginAnswers(ctx, req.(*ValidatePluginAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_DeployResourceSpecification_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(DeployResourceSpecificationRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -934,6 +2139,24 @@ func _RuntimeService_ReadResource_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_CheckResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).CheckResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/CheckResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).CheckResource(ctx, req.(*CheckResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_UpdateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateResourceRequest)
 	if err := dec(in); err != nil {
@@ -952,6 +2175,60 @@ func _RuntimeService_UpdateResource_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_BackupResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).BackupResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/BackupResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).BackupResource(ctx, req.(*BackupResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListBackups(ctx, req.(*ListBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RestoreBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RestoreBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RestoreBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RestoreBackup(ctx, req.(*RestoreBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_ReplayDryRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReplayRequest)
 	if err := dec(in); err != nil {
@@ -988,6 +2265,81 @@ func _RuntimeService_Replay_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_ListReplays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReplaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListReplays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListReplays",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListReplays(ctx, req.(*ListReplaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ReplayStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplayStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServiceServer).ReplayStatus(m, &runtimeServiceReplayStatusServer{stream})
+}
+
+type RuntimeService_ReplayStatusServer interface {
+	Send(*ReplayStatusResponse) error
+	grpc.ServerStream
+}
+
+type runtimeServiceReplayStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceReplayStatusServer) Send(m *ReplayStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RuntimeService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/DeleteProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).DeleteProject(ctx, req.(*DeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RestoreProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RestoreProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RestoreProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RestoreProject(ctx, req.(*RestoreProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RuntimeService_ServiceDesc is the grpc.ServiceDesc for RuntimeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -999,6 +2351,22 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Version",
 			Handler:    _RuntimeService_Version_Handler,
 		},
+		{
+			MethodName: "DeployJobSpecificationAsync",
+			Handler:    _RuntimeService_DeployJobSpecificationAsync_Handler,
+		},
+		{
+			MethodName: "GetDeploymentStatus",
+			Handler:    _RuntimeService_GetDeploymentStatus_Handler,
+		},
+		{
+			MethodName: "ListDeployments",
+			Handler:    _RuntimeService_ListDeployments_Handler,
+		},
+		{
+			MethodName: "GetDeployment",
+			Handler:    _RuntimeService_GetDeployment_Handler,
+		},
 		{
 			MethodName: "CreateJobSpecification",
 			Handler:    _RuntimeService_CreateJobSpecification_Handler,
@@ -1011,6 +2379,34 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteJobSpecification",
 			Handler:    _RuntimeService_DeleteJobSpecification_Handler,
 		},
+		{
+			MethodName: "PauseJob",
+			Handler:    _RuntimeService_PauseJob_Handler,
+		},
+		{
+			MethodName: "ResumeJob",
+			Handler:    _RuntimeService_ResumeJob_Handler,
+		},
+		{
+			MethodName: "RestoreJob",
+			Handler:    _RuntimeService_RestoreJob_Handler,
+		},
+		{
+			MethodName: "ListJobVersions",
+			Handler:    _RuntimeService_ListJobVersions_Handler,
+		},
+		{
+			MethodName: "GetJobVersionDiff",
+			Handler:    _RuntimeService_GetJobVersionDiff_Handler,
+		},
+		{
+			MethodName: "RollbackJob",
+			Handler:    _RuntimeService_RollbackJob_Handler,
+		},
+		{
+			MethodName: "GetLineage",
+			Handler:    _RuntimeService_GetLineage_Handler,
+		},
 		{
 			MethodName: "ListJobSpecification",
 			Handler:    _RuntimeService_ListJobSpecification_Handler,
@@ -1019,6 +2415,10 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DumpJobSpecification",
 			Handler:    _RuntimeService_DumpJobSpecification_Handler,
 		},
+		{
+			MethodName: "CompileJob",
+			Handler:    _RuntimeService_CompileJob_Handler,
+		},
 		{
 			MethodName: "CheckJobSpecification",
 			Handler:    _RuntimeService_CheckJobSpecification_Handler,
@@ -1035,6 +2435,42 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterSecret",
 			Handler:    _RuntimeService_RegisterSecret_Handler,
 		},
+		{
+			MethodName: "UpdateSecret",
+			Handler:    _RuntimeService_UpdateSecret_Handler,
+		},
+		{
+			MethodName: "ListSecretKeys",
+			Handler:    _RuntimeService_ListSecretKeys_Handler,
+		},
+		{
+			MethodName: "CreateRoleBinding",
+			Handler:    _RuntimeService_CreateRoleBinding_Handler,
+		},
+		{
+			MethodName: "DeleteRoleBinding",
+			Handler:    _RuntimeService_DeleteRoleBinding_Handler,
+		},
+		{
+			MethodName: "ListRoleBindings",
+			Handler:    _RuntimeService_ListRoleBindings_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _RuntimeService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "RegisterMacro",
+			Handler:    _RuntimeService_RegisterMacro_Handler,
+		},
+		{
+			MethodName: "DeleteMacro",
+			Handler:    _RuntimeService_DeleteMacro_Handler,
+		},
+		{
+			MethodName: "ListMacros",
+			Handler:    _RuntimeService_ListMacros_Handler,
+		},
 		{
 			MethodName: "ListProjects",
 			Handler:    _RuntimeService_ListProjects_Handler,
@@ -1051,6 +2487,14 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "JobStatus",
 			Handler:    _RuntimeService_JobStatus_Handler,
 		},
+		{
+			MethodName: "ClearJobRuns",
+			Handler:    _RuntimeService_ClearJobRuns_Handler,
+		},
+		{
+			MethodName: "GetJobRuns",
+			Handler:    _RuntimeService_GetJobRuns_Handler,
+		},
 		{
 			MethodName: "RegisterJobEvent",
 			Handler:    _RuntimeService_RegisterJobEvent_Handler,
@@ -1059,6 +2503,18 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWindow",
 			Handler:    _RuntimeService_GetWindow_Handler,
 		},
+		{
+			MethodName: "ListSupportedTasks",
+			Handler:    _RuntimeService_ListSupportedTasks_Handler,
+		},
+		{
+			MethodName: "ListSupportedHooks",
+			Handler:    _RuntimeService_ListSupportedHooks_Handler,
+		},
+		{
+			MethodName: "ValidatePluginAnswers",
+			Handler:    _RuntimeService_ValidatePluginAnswers_Handler,
+		},
 		{
 			MethodName: "ListResourceSpecification",
 			Handler:    _RuntimeService_ListResourceSpecification_Handler,
@@ -1071,10 +2527,26 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadResource",
 			Handler:    _RuntimeService_ReadResource_Handler,
 		},
+		{
+			MethodName: "CheckResource",
+			Handler:    _RuntimeService_CheckResource_Handler,
+		},
 		{
 			MethodName: "UpdateResource",
 			Handler:    _RuntimeService_UpdateResource_Handler,
 		},
+		{
+			MethodName: "BackupResource",
+			Handler:    _RuntimeService_BackupResource_Handler,
+		},
+		{
+			MethodName: "ListBackups",
+			Handler:    _RuntimeService_ListBackups_Handler,
+		},
+		{
+			MethodName: "RestoreBackup",
+			Handler:    _RuntimeService_RestoreBackup_Handler,
+		},
 		{
 			MethodName: "ReplayDryRun",
 			Handler:    _RuntimeService_ReplayDryRun_Handler,
@@ -1083,6 +2555,18 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Replay",
 			Handler:    _RuntimeService_Replay_Handler,
 		},
+		{
+			MethodName: "ListReplays",
+			Handler:    _RuntimeService_ListReplays_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _RuntimeService_DeleteProject_Handler,
+		},
+		{
+			MethodName: "RestoreProject",
+			Handler:    _RuntimeService_RestoreProject_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1090,6 +2574,18 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RuntimeService_DeployJobSpecification_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DeployJobSpecificationStream",
+			Handler:       _RuntimeService_DeployJobSpecificationStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportJobSpecifications",
+			Handler:       _RuntimeService_ImportJobSpecifications_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "CheckJobSpecifications",
 			Handler:       _RuntimeService_CheckJobSpecifications_Handler,
@@ -1100,6 +2596,11 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RuntimeService_DeployResourceSpecification_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReplayStatus",
+			Handler:       _RuntimeService_ReplayStatus_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "odpf/optimus/runtime_service.proto",
 }
@@ -1,18 +1,22 @@
 package cmd
 
 import (
+	"github.com/odpf/optimus/config"
 	"github.com/odpf/optimus/models"
 	cli "github.com/spf13/cobra"
 )
 
 // adminCommand requests a resource from optimus
-func adminCommand(l logger, pluginRepo models.PluginRepository) *cli.Command {
+func adminCommand(l logger, conf config.Provider, pluginRepo models.PluginRepository) *cli.Command {
 	cmd := &cli.Command{
 		Use:   "admin",
 		Short: "administration commands, should not be used by user",
 	}
 	cmd.AddCommand(adminBuildCommand(l))
 	cmd.AddCommand(adminGetCommand(l, pluginRepo))
+	cmd.AddCommand(adminMigrateCommand(l, conf))
+	cmd.AddCommand(adminReencryptCommand(l, conf))
+	cmd.AddCommand(adminWaitCommand(l))
 	return cmd
 }
 
@@ -34,3 +38,13 @@ func adminGetCommand(l logger, pluginRepo models.PluginRepository) *cli.Command
 	cmd.AddCommand(adminGetStatusCommand(l))
 	return cmd
 }
+
+// adminWaitCommand waits for a resource
+func adminWaitCommand(l logger) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "wait",
+		Short: "Wait for dependencies of a job run",
+	}
+	cmd.AddCommand(adminWaitUpstreamCommand(l))
+	return cmd
+}
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/ext/scheduler/kubernetes"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/utils"
 	"github.com/pkg/errors"
@@ -42,7 +43,8 @@ func adminBuildInstanceCommand(l logger) *cli.Command {
 
 	cmd.Flags().StringVar(&assetOutputDir, "output-dir", "", "output directory for assets")
 	cmd.MarkFlagRequired("output-dir")
-	cmd.Flags().StringVar(&scheduledAt, "scheduled-at", "", "time at which the job was scheduled for execution")
+	cmd.Flags().StringVar(&scheduledAt, "scheduled-at", "", "time at which the job was scheduled for execution, "+
+		"derived from run of kubernetes cron job if empty")
 	cmd.MarkFlagRequired("scheduled-at")
 	cmd.Flags().StringVar(&runType, "type", "", "type of task, could be base/hook")
 	cmd.MarkFlagRequired("type")
@@ -73,8 +75,13 @@ func adminBuildInstanceCommand(l logger) *cli.Command {
 // Based on the response, it builds assets like query, env and config
 // for the Job Run which is saved into output files.
 func getInstanceBuildRequest(l logger, jobName, inputDirectory, host, projectName, scheduledAt, runType, runName string) (err error) {
-	jobScheduledTime, err := time.Parse(models.InstanceScheduledAtTimeLayout, scheduledAt)
-	if err != nil {
+	var jobScheduledTime time.Time
+	if cronJobRun := os.Getenv(kubernetes.CronJobRunEnv); scheduledAt == "" && cronJobRun != "" {
+		// cron jobs can't pass scheduled time of a run to its containers
+		if jobScheduledTime, err = kubernetes.RunScheduledAt(cronJobRun); err != nil {
+			return err
+		}
+	} else if jobScheduledTime, err = time.Parse(models.InstanceScheduledAtTimeLayout, scheduledAt); err != nil {
 		return errors.Wrapf(err, "invalid time format, please use %s", models.InstanceScheduledAtTimeLayout)
 	}
 	jobScheduledTimeProto := timestamppb.New(jobScheduledTime)
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"github.com/odpf/optimus/config"
+	"github.com/odpf/optimus/store/postgres"
+	"github.com/pkg/errors"
+	cli "github.com/spf13/cobra"
+)
+
+// adminMigrateCommand manages schema of the server database, uses serve
+// configuration to reach database directly
+func adminMigrateCommand(l logger, conf config.Provider) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "migrate",
+		Short: "Manage database schema of optimus server",
+	}
+	cmd.AddCommand(adminMigrateUpCommand(l, conf))
+	cmd.AddCommand(adminMigrateDownCommand(l, conf))
+	cmd.AddCommand(adminMigrateStatusCommand(l, conf))
+	return cmd
+}
+
+func adminMigrateUpCommand(l logger, conf config.Provider) *cli.Command {
+	var version uint
+	cmd := &cli.Command{
+		Use:     "up",
+		Short:   "Apply pending migrations, up to the latest unless a version is given",
+		Example: "optimus admin migrate up --version 24",
+	}
+	cmd.Flags().UintVar(&version, "version", 0, "migrate up to this version")
+	cmd.RunE = func(c *cli.Command, args []string) error {
+		dsn := conf.GetServe().DB.DSN
+		if version == 0 {
+			if err := postgres.Migrate(dsn); err != nil {
+				return err
+			}
+		} else if err := postgres.MigrateTo(dsn, version); err != nil {
+			return err
+		}
+		return printMigrationStatus(l, dsn)
+	}
+	return cmd
+}
+
+func adminMigrateDownCommand(l logger, conf config.Provider) *cli.Command {
+	var steps int
+	cmd := &cli.Command{
+		Use:     "down",
+		Short:   "Revert applied migrations, needed before running an older server build",
+		Example: "optimus admin migrate down --steps 2",
+	}
+	cmd.Flags().IntVar(&steps, "steps", 1, "number of migrations to revert")
+	cmd.RunE = func(c *cli.Command, args []string) error {
+		if steps < 1 {
+			return errors.New("steps should be at least 1")
+		}
+		dsn := conf.GetServe().DB.DSN
+		if err := postgres.Rollback(dsn, steps); err != nil {
+			return err
+		}
+		return printMigrationStatus(l, dsn)
+	}
+	return cmd
+}
+
+func adminMigrateStatusCommand(l logger, conf config.Provider) *cli.Command {
+	return &cli.Command{
+		Use:     "status",
+		Short:   "Print version of database schema against migrations of this build",
+		Example: "optimus admin migrate status",
+		RunE: func(c *cli.Command, args []string) error {
+			return printMigrationStatus(l, conf.GetServe().DB.DSN)
+		},
+	}
+}
+
+func printMigrationStatus(l logger, dsn string) error {
+	status, err := postgres.GetMigrationStatus(dsn)
+	if err != nil {
+		return err
+	}
+	l.Printf("schema version: %d, latest: %d\n", status.Version, status.Latest)
+	if status.Dirty {
+		l.Println(coloredError("schema is dirty, last migration failed midway and needs a manual fix"))
+	}
+	return nil
+}
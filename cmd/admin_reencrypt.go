@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/odpf/optimus/cmd/server"
+	"github.com/odpf/optimus/config"
+	cli "github.com/spf13/cobra"
+)
+
+// adminReencryptCommand seals project secrets and sensitive config again
+// using the configured master key, uses serve configuration to reach
+// database and key management service directly
+func adminReencryptCommand(l logger, conf config.Provider) *cli.Command {
+	return &cli.Command{
+		Use:     "reencrypt",
+		Short:   "Re-encrypt project secrets and sensitive config after rotating kms master key",
+		Example: "optimus admin reencrypt",
+		RunE: func(c *cli.Command, args []string) error {
+			if err := server.Reencrypt(conf); err != nil {
+				return err
+			}
+			l.Println("re-encrypted all projects")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/ext/scheduler/kubernetes"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	cli "github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var (
+	adminWaitUpstreamPokeInterval = time.Minute * 15
+	adminWaitUpstreamTimeout      = time.Hour * 15
+)
+
+// adminWaitUpstreamCommand blocks a run of a job deployed to kubernetes
+// scheduler until its upstream succeeds for the same schedule
+func adminWaitUpstreamCommand(l logger) *cli.Command {
+	var (
+		optimusHost  string
+		projectName  string
+		runName      string
+		pokeInterval time.Duration
+		timeout      time.Duration
+		optional     bool
+	)
+	cmd := &cli.Command{
+		Use:     "upstream",
+		Short:   "Wait for upstream job to succeed for a scheduled run",
+		Example: "optimus admin wait upstream sample_replace --project \"project-id\" --run sample-job-26824440",
+		Args:    cli.MinimumNArgs(1),
+	}
+	cmd.Flags().StringVar(&projectName, "project", "", "name of the tenant upstream belongs to")
+	cmd.MarkFlagRequired("project")
+	cmd.Flags().StringVar(&optimusHost, "host", "", "optimus service endpoint url")
+	cmd.MarkFlagRequired("host")
+	cmd.Flags().StringVar(&runName, "run", "", "name of kubernetes job created by cron job for the run")
+	cmd.MarkFlagRequired("run")
+	cmd.Flags().DurationVar(&pokeInterval, "poke-interval", adminWaitUpstreamPokeInterval, "how often to check upstream")
+	cmd.Flags().DurationVar(&timeout, "timeout", adminWaitUpstreamTimeout, "how long to wait for upstream")
+	cmd.Flags().BoolVar(&optional, "optional", false, "succeed even if upstream fails or times out")
+
+	cmd.RunE = func(c *cli.Command, args []string) error {
+		upstreamName := args[0]
+		scheduledAt, err := kubernetes.RunScheduledAt(runName)
+		if err != nil {
+			return err
+		}
+		l.Printf("waiting for upstream %s of project %s scheduled at %s\n", upstreamName, projectName, scheduledAt)
+
+		err = waitUpstreamRequest(l, upstreamName, optimusHost, projectName, scheduledAt, pokeInterval, timeout)
+		if err != nil && optional {
+			l.Printf("skipping optional upstream: %v\n", err)
+			return nil
+		}
+		return err
+	}
+	return cmd
+}
+
+// waitUpstreamRequest polls status of upstream until its latest run scheduled
+// at or before scheduledAt succeeds
+func waitUpstreamRequest(l logger, jobName, host, projectName string, scheduledAt time.Time,
+	pokeInterval, timeout time.Duration) (err error) {
+	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
+	defer dialCancel()
+
+	var conn *grpc.ClientConn
+	if conn, err = createConnection(dialTimeoutCtx, host); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			l.Println("can't reach optimus service, timing out")
+		}
+		return err
+	}
+	defer conn.Close()
+
+	runtime := pb.NewRuntimeServiceClient(conn)
+	deadline := time.Now().Add(timeout)
+	for {
+		statusCtx, cancel := context.WithTimeout(context.Background(), adminStatusTimeout)
+		jobStatusResponse, err := runtime.JobStatus(statusCtx, &pb.JobStatusRequest{
+			ProjectName: projectName,
+			JobName:     jobName,
+		})
+		cancel()
+		if err != nil {
+			l.Printf("failed to fetch status of upstream %s: %v\n", jobName, err)
+		} else {
+			var latest *pb.JobStatus
+			for _, status := range jobStatusResponse.GetStatuses() {
+				runAt := status.GetScheduledAt().AsTime()
+				if runAt.After(scheduledAt) {
+					continue
+				}
+				if latest == nil || runAt.After(latest.GetScheduledAt().AsTime()) {
+					latest = status
+				}
+			}
+			if latest != nil && latest.GetState() == models.JobStatusStateSuccess.String() {
+				l.Printf("upstream %s succeeded for %s\n", jobName, latest.GetScheduledAt().AsTime())
+				return nil
+			}
+		}
+
+		if time.Now().Add(pokeInterval).After(deadline) {
+			return errors.Errorf("timed out waiting for upstream %s of project %s", jobName, projectName)
+		}
+		l.Printf("upstream %s has not succeeded yet, checking again in %s\n", jobName, pokeInterval)
+		time.Sleep(pokeInterval)
+	}
+}
@@ -2,14 +2,20 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/spf13/afero"
 
 	"github.com/odpf/optimus/store/local"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/fatih/color"
 	"github.com/odpf/optimus/config"
@@ -36,6 +42,21 @@ var (
 	OptimusDialTimeout = time.Second * 2
 )
 
+const (
+	// AuthTokenEnv is the environment variable holding an api key or oidc
+	// token sent to optimus server with every call
+	AuthTokenEnv = "OPTIMUS_AUTH_TOKEN"
+
+	// TLSCAFileEnv is the environment variable holding path of pem encoded
+	// CAs server certificate is verified against, connections use tls if it
+	// or TLSCertFileEnv is set
+	TLSCAFileEnv = "OPTIMUS_TLS_CA_FILE"
+	// TLSCertFileEnv and TLSKeyFileEnv hold paths of the pem encoded client
+	// certificate and its key presented to servers requiring mutual tls
+	TLSCertFileEnv = "OPTIMUS_TLS_CERT_FILE"
+	TLSKeyFileEnv  = "OPTIMUS_TLS_KEY_FILE"
+)
+
 func programPrologue(ver string) string {
 	return fmt.Sprintf(prologueContents, ver)
 }
@@ -46,6 +67,7 @@ type JobSpecRepository interface {
 	Save(models.JobSpec) error
 	GetByName(string) (models.JobSpec, error)
 	GetAll() ([]models.JobSpec, error)
+	Validate() ([]local.ValidatedJobSpec, error)
 }
 
 // New constructs the 'root' command.
@@ -90,22 +112,27 @@ func New(
 	cmd.AddCommand(createCommand(l, jobSpecFs, datastoreSpecsFs, pluginRepo, dsRepo))
 	cmd.AddCommand(deployCommand(l, conf, jobSpecRepo, pluginRepo, dsRepo, datastoreSpecsFs))
 	cmd.AddCommand(renderCommand(l, conf.GetHost(), jobSpecRepo))
-	cmd.AddCommand(validateCommand(l, conf.GetHost(), pluginRepo, jobSpecRepo))
+	cmd.AddCommand(validateCommand(l, conf.GetHost(), conf.GetJob().Path, pluginRepo, jobSpecRepo))
 	cmd.AddCommand(optimusServeCommand(l, conf))
 	cmd.AddCommand(replayCommand(l, conf))
 
 	// admin specific commands
 	if conf.GetAdmin().Enabled {
-		cmd.AddCommand(adminCommand(l, pluginRepo))
+		cmd.AddCommand(adminCommand(l, conf, pluginRepo))
 	}
 
 	return cmd
 }
 
 func createConnection(ctx context.Context, host string) (*grpc.ClientConn, error) {
+	transportOpt, err := transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+
 	var opts []grpc.DialOption
 	opts = append(opts,
-		grpc.WithInsecure(),
+		transportOpt,
 		grpc.WithBlock(),
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallSendMsgSize(GRPCMaxClientSendSize),
@@ -113,6 +140,10 @@ func createConnection(ctx context.Context, host string) (*grpc.ClientConn, error
 		),
 	)
 
+	if token := os.Getenv(AuthTokenEnv); token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerTokenCredentials(token)))
+	}
+
 	conn, err := grpc.DialContext(ctx, host, opts...)
 	if err != nil {
 		return nil, err
@@ -120,3 +151,49 @@ func createConnection(ctx context.Context, host string) (*grpc.ClientConn, error
 
 	return conn, nil
 }
+
+// transportCredentials uses tls if a CA or client certificate is provided
+// through environment, system CAs are used if only client certificate is
+func transportCredentials() (grpc.DialOption, error) {
+	caFile, certFile, keyFile := os.Getenv(TLSCAFileEnv), os.Getenv(TLSCertFileEnv), os.Getenv(TLSKeyFileEnv)
+	if caFile == "" && certFile == "" {
+		return grpc.WithInsecure(), nil
+	}
+
+	tlsConf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read server CAs")
+		}
+		tlsConf.RootCAs = x509.NewCertPool()
+		if !tlsConf.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no pem encoded certificates found in %s", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConf)), nil
+}
+
+// bearerTokenCredentials sends the token in authorization header
+type bearerTokenCredentials string
+
+func (t bearerTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + string(t),
+	}, nil
+}
+
+// RequireTransportSecurity is false as optimus is often reached over an
+// insecure connection inside a private network
+func (t bearerTokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
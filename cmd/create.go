@@ -220,7 +220,7 @@ func createJobSurvey(jobSpecRepo JobSpecRepository, pluginRepo models.PluginRepo
 			Name: "window",
 			Prompt: &survey.Select{
 				Message: "Transformation window",
-				Options: []string{"hourly", "daily", "weekly", "monthly"},
+				Options: []string{"hourly", "daily", "weekly", "monthly", "quarterly"},
 				Default: "daily",
 				Help: `Time window for which transformation is consuming data,
 this effects runtime dependencies and template macros`,
@@ -289,24 +289,15 @@ this effects runtime dependencies and template macros`,
 		}
 	}
 
-	generateConfResponse, err := cliMod.DefaultConfig(context.Background(), models.DefaultConfigRequest{
-		Answers: userInputs,
-	})
+	defaultConfig, defaultAssets, err := executionTask.GenerateDefaults(context.Background(), userInputs, models.PluginOptions{})
 	if err != nil {
 		return jobInput, err
 	}
-	if generateConfResponse.Config != nil {
-		jobInput.Task.Config = local.JobSpecConfigToYamlSlice(generateConfResponse.Config.ToJobSpec())
-	}
-
-	genAssetResponse, err := cliMod.DefaultAssets(context.Background(), models.DefaultAssetsRequest{
-		Answers: userInputs,
-	})
-	if err != nil {
-		return jobInput, err
+	if len(defaultConfig) > 0 {
+		jobInput.Task.Config = local.JobSpecConfigToYamlSlice(defaultConfig)
 	}
-	if genAssetResponse.Assets != nil {
-		jobInput.Asset = genAssetResponse.Assets.ToJobSpec().ToMap()
+	if assets := defaultAssets.ToMap(); assets != nil {
+		jobInput.Asset = assets
 	}
 
 	return jobInput, nil
@@ -616,6 +607,12 @@ func getWindowParameters(winName string) local.JobTaskWindow {
 			Offset:     "0",
 			TruncateTo: "M",
 		}
+	case "quarterly":
+		return local.JobTaskWindow{
+			Size:       "2160h",
+			Offset:     "0",
+			TruncateTo: "Q",
+		}
 	}
 
 	//default
@@ -654,6 +651,9 @@ func AskCLISurveyQuestion(ques models.PluginQuestion, cliMod models.CommandLineM
 		if err != nil {
 			return err
 		}
+		if err := ques.IsValid(str); err != nil {
+			return err
+		}
 		resp, err := cliMod.ValidateQuestion(context.TODO(), models.ValidateQuestionRequest{
 			Answer: models.PluginAnswer{
 				Question: ques,
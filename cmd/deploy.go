@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"os/user"
 	"time"
 
 	"github.com/spf13/afero"
@@ -18,10 +20,15 @@ import (
 	"github.com/pkg/errors"
 	cli "github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
 	deploymentTimeout = time.Minute * 10
+
+	// deployJobsChunkSize is the number of jobs sent in a single message
+	// to stay under the maximum grpc message size
+	deployJobsChunkSize = 100
 )
 
 // deployCommand pushes current repo to optimus service
@@ -31,6 +38,8 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 	var namespace string
 	var ignoreJobs bool
 	var ignoreResources bool
+	var idempotencyKey string
+	var force bool
 
 	cmd := &cli.Command{
 		Use:   "deploy",
@@ -42,6 +51,8 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 	cmd.MarkFlagRequired("namespace")
 	cmd.Flags().BoolVar(&ignoreJobs, "ignore-jobs", false, "ignore deployment of jobs")
 	cmd.Flags().BoolVar(&ignoreResources, "ignore-resources", false, "ignore deployment of resources")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "unique key of the deployment, retried deployments with same key are not applied again")
+	cmd.Flags().BoolVar(&force, "force", false, "sync jobs with scheduler even if none changed, e.g. after project config or plugins are updated")
 
 	cmd.RunE = func(c *cli.Command, args []string) error {
 		l.Printf("deploying project %s for namespace %s at %s\nplease wait...\n", projectName, namespace, conf.GetHost())
@@ -52,7 +63,7 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 		}
 
 		if err := postDeploymentRequest(l, projectName, namespace, jobSpecRepo, conf, pluginRepo, datastoreRepo,
-			datastoreSpecFs, ignoreJobs, ignoreResources); err != nil {
+			datastoreSpecFs, ignoreJobs, ignoreResources, force, idempotencyKey); err != nil {
 			return err
 		}
 
@@ -66,7 +77,7 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 // postDeploymentRequest send a deployment request to service
 func postDeploymentRequest(l logger, projectName string, namespace string, jobSpecRepo JobSpecRepository,
 	conf config.Provider, pluginRepo models.PluginRepository, datastoreRepo models.DatastoreRepo, datastoreSpecFs map[string]afero.Fs,
-	ignoreJobDeployment, ignoreResources bool) (err error) {
+	ignoreJobDeployment, ignoreResources, force bool, idempotencyKey string) (err error) {
 	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
 	defer dialCancel()
 
@@ -81,12 +92,13 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 
 	deployTimeoutCtx, deployCancel := context.WithTimeout(context.Background(), deploymentTimeout)
 	defer deployCancel()
+	deployTimeoutCtx = withRequestedBy(deployTimeoutCtx)
 
 	runtime := pb.NewRuntimeServiceClient(conn)
 	adapt := v1handler.NewAdapter(pluginRepo, datastoreRepo)
 
 	// update project config if needed
-	registerResponse, err := runtime.RegisterProject(deployTimeoutCtx, &pb.RegisterProjectRequest{
+	registerResponse, err := runtime.RegisterProject(withIdempotencyKey(deployTimeoutCtx, idempotencyKey), &pb.RegisterProjectRequest{
 		Project: &pb.ProjectSpecification{
 			Name:   projectName,
 			Config: conf.GetProjectConfig().Global,
@@ -131,7 +143,12 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 			}
 
 			// send call
-			respStream, err := runtime.DeployResourceSpecification(deployTimeoutCtx, &pb.DeployResourceSpecificationRequest{
+			// each datastore is deployed with a separate call
+			resourceKey := idempotencyKey
+			if resourceKey != "" {
+				resourceKey = fmt.Sprintf("%s-%s", idempotencyKey, storeName)
+			}
+			respStream, err := runtime.DeployResourceSpecification(withIdempotencyKey(deployTimeoutCtx, resourceKey), &pb.DeployResourceSpecificationRequest{
 				Resources:     adaptedSpecs,
 				ProjectName:   projectName,
 				DatastoreName: storeName,
@@ -189,17 +206,19 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 			}
 			adaptedJobSpecs = append(adaptedJobSpecs, adaptJob)
 		}
-		respStream, err := runtime.DeployJobSpecification(deployTimeoutCtx, &pb.DeployJobSpecificationRequest{
-			Jobs:        adaptedJobSpecs,
-			ProjectName: projectName,
-			Namespace:   namespace,
-		})
+		respStream, err := runtime.DeployJobSpecificationStream(withIdempotencyKey(deployTimeoutCtx, idempotencyKey))
 		if err != nil {
 			if errors.Is(err, context.DeadlineExceeded) {
 				l.Println("deployment process took too long, timing out")
 			}
 			return errors.Wrapf(err, "deployement failed")
 		}
+		// jobs are sent in chunks while acks are being received, server
+		// may block on sending acks if they are not read
+		sendErrCh := make(chan error, 1)
+		go func() {
+			sendErrCh <- sendJobSpecChunks(respStream, projectName, namespace, force, adaptedJobSpecs)
+		}()
 
 		jobCounter := 0
 		totalJobs := len(jobSpecs)
@@ -217,12 +236,21 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 					return errors.Errorf("unable to deploy: %s %s", resp.GetJobName(), resp.GetMessage())
 				}
 				jobCounter++
+				if resp.GetMessage() == v1handler.JobSkippedUnchangedMessage {
+					l.Printf("%d/%d. %s %s\n", jobCounter, totalJobs, resp.GetJobName(), resp.GetMessage())
+					continue
+				}
 				l.Printf("%d/%d. %s successfully deployed\n", jobCounter, totalJobs, resp.GetJobName())
-			} else {
+			} else if resp.GetHeartbeat() != nil {
+				l.Printf("deployment in progress: %s\n", resp.GetMessage())
+			} else if resp.GetMessage() != v1handler.JobSavedMessage {
 				// ordinary progress event
 				l.Printf("info '%s': %s\n", resp.GetJobName(), resp.GetMessage())
 			}
 		}
+		if err := <-sendErrCh; err != nil {
+			return errors.Wrap(err, "failed to send jobs")
+		}
 		l.Println("deployed jobs")
 	} else {
 		l.Println("skipping job deployment")
@@ -231,3 +259,57 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 	l.Println("deployment completed successfully")
 	return nil
 }
+
+// sendJobSpecChunks streams jobs to the server in chunks of
+// deployJobsChunkSize, project, namespace and force are sent with the
+// first chunk
+func sendJobSpecChunks(stream pb.RuntimeService_DeployJobSpecificationStreamClient, projectName, namespace string,
+	force bool, jobSpecs []*pb.JobSpecification) error {
+	req := &pb.DeployJobSpecificationRequest{
+		ProjectName: projectName,
+		Namespace:   namespace,
+		Reconcile:   true,
+		Force:       force,
+	}
+	for {
+		chunkSize := deployJobsChunkSize
+		if len(jobSpecs) < chunkSize {
+			chunkSize = len(jobSpecs)
+		}
+		req.Jobs, jobSpecs = jobSpecs[:chunkSize], jobSpecs[chunkSize:]
+		if err := stream.Send(req); err != nil {
+			if err == io.EOF {
+				// server closed the stream, status is read by receiver
+				return nil
+			}
+			return err
+		}
+		if len(jobSpecs) == 0 {
+			break
+		}
+		req = &pb.DeployJobSpecificationRequest{}
+	}
+	return stream.CloseSend()
+}
+
+// withIdempotencyKey attaches the key to outgoing calls, retried calls with
+// the same key are not applied again by the server
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, v1handler.IdempotencyKeyHeader, key)
+}
+
+// withRequestedBy attaches the current user to outgoing calls, server keeps
+// it in deployment history
+func withRequestedBy(ctx context.Context) context.Context {
+	username := os.Getenv("USER")
+	if currentUser, err := user.Current(); err == nil {
+		username = currentUser.Username
+	}
+	if username == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, v1handler.RequestedByHeader, username)
+}
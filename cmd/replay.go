@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -21,6 +22,8 @@ import (
 
 var (
 	replayTimeout = time.Minute * 1
+	// replayDateFormat is the format replay dates are requested in
+	replayDateFormat = "2006-01-02"
 )
 
 type taskRunBlock struct {
@@ -66,6 +69,8 @@ func replayCommand(l logger, conf config.Provider) *cli.Command {
 		Long:  `Backfill etl job and all of its downstream dependencies`,
 	}
 	cmd.AddCommand(replayRunSubCommand(l, conf))
+	cmd.AddCommand(replayStatusSubCommand(l, conf))
+	cmd.AddCommand(replayListSubCommand(l, conf))
 	return cmd
 }
 
@@ -136,11 +141,135 @@ ReplayDryRun date ranges are inclusive.
 			return err
 		}
 		l.Printf("🚀 replay request created with id %s\n", replayId)
+		l.Printf("follow its progress with: optimus replay status %s %s -p %s -n %s\n", args[0], replayId, replayProject, namespace)
 		return nil
 	}
 	return reCmd
 }
 
+func replayListSubCommand(l logger, conf config.Provider) *cli.Command {
+	var replayProject string
+
+	listCmd := &cli.Command{
+		Use:     "list",
+		Short:   "list active and finished replays of a project",
+		Example: "optimus replay list -p project-name",
+		Args:    cli.NoArgs,
+	}
+	listCmd.Flags().StringVarP(&replayProject, "project", "p", "", "project name of optimus managed ocean repository")
+	listCmd.MarkFlagRequired("project")
+
+	listCmd.RunE = func(cmd *cli.Command, args []string) error {
+		dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
+		defer dialCancel()
+
+		conn, err := createConnection(dialTimeoutCtx, conf.GetHost())
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				l.Println("can't reach optimus service")
+			}
+			return err
+		}
+		defer conn.Close()
+
+		requestTimeout, requestCancel := context.WithTimeout(context.Background(), replayTimeout)
+		defer requestCancel()
+
+		runtime := pb.NewRuntimeServiceClient(conn)
+		listResponse, err := runtime.ListReplays(requestTimeout, &pb.ListReplaysRequest{
+			ProjectName: replayProject,
+		})
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				l.Println("listing replays took too long, timing out")
+			}
+			return errors.Wrapf(err, "request failed for project %s", replayProject)
+		}
+
+		table := tablewriter.NewWriter(l.Writer())
+		table.SetBorder(false)
+		table.SetHeader([]string{
+			"ID",
+			"Job",
+			"Start Date",
+			"End Date",
+			"Status",
+			"Created At",
+		})
+		for _, replay := range listResponse.Replays {
+			table.Append([]string{
+				replay.Id,
+				replay.JobName,
+				replay.StartDate.AsTime().Format(replayDateFormat),
+				replay.EndDate.AsTime().Format(replayDateFormat),
+				replay.Status,
+				replay.CreatedAt.AsTime().Format(time.RFC3339),
+			})
+		}
+		table.Render()
+		return nil
+	}
+	return listCmd
+}
+
+func replayStatusSubCommand(l logger, conf config.Provider) *cli.Command {
+	var (
+		replayProject string
+		namespace     string
+	)
+
+	statusCmd := &cli.Command{
+		Use:     "status",
+		Short:   "follow status of a replay till it ends",
+		Example: "optimus replay status optimus.dag.name 2fa4d31b-85b5-4a0e-9a4f-0b0c2e1b3a7e",
+		Args:    cli.ExactArgs(2),
+	}
+	statusCmd.Flags().StringVarP(&replayProject, "project", "p", "", "project name of optimus managed ocean repository")
+	statusCmd.MarkFlagRequired("project")
+	statusCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of deployee")
+	statusCmd.MarkFlagRequired("namespace")
+
+	statusCmd.RunE = func(cmd *cli.Command, args []string) error {
+		dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
+		defer dialCancel()
+
+		conn, err := createConnection(dialTimeoutCtx, conf.GetHost())
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				l.Println("can't reach optimus service")
+			}
+			return err
+		}
+		defer conn.Close()
+
+		runtime := pb.NewRuntimeServiceClient(conn)
+		stream, err := runtime.ReplayStatus(cmd.Context(), &pb.ReplayStatusRequest{
+			ProjectName: replayProject,
+			Namespace:   namespace,
+			JobName:     args[0],
+			Id:          args[1],
+		})
+		if err != nil {
+			return errors.Wrapf(err, "request failed for replay %s", args[1])
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to read status of replay %s", args[1])
+			}
+			if resp.GetMessage() != "" {
+				l.Printf("%s: %s\n", resp.GetStatus(), resp.GetMessage())
+				continue
+			}
+			l.Println(resp.GetStatus())
+		}
+	}
+	return statusCmd
+}
+
 func printReplayExecutionTree(l logger, projectName, namespace, jobName, startDate, endDate string, conf config.Provider) (err error) {
 	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
 	defer dialCancel()
@@ -196,8 +325,10 @@ func printReplayDryRunResponse(l logger, replayRequest *pb.ReplayRequest, replay
 	for _, block := range taskRerunsMap {
 		taskRerunsSorted.Add(block)
 	}
+	runCount := 0
 	for idx, rawBlock := range taskRerunsSorted.Values() {
 		runBlock := rawBlock.(taskRunBlock)
+		runCount += runBlock.runs.Size()
 		runTimes := []string{}
 		for _, runRaw := range runBlock.runs.Values() {
 			runTimes = append(runTimes, runRaw.(time.Time).String())
@@ -206,6 +337,7 @@ func printReplayDryRunResponse(l logger, replayRequest *pb.ReplayRequest, replay
 		table.Append([]string{fmt.Sprintf("%d", idx+1), runBlock.name, strings.Join(runTimes, "\n")})
 	}
 	table.Render()
+	l.Printf("%d runs of %d jobs would be cleared\n", runCount, len(taskRerunsMap))
 
 	//print tree
 	l.Println(coloredNotice("\nDEPENDENCY TREE"))
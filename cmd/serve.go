@@ -8,12 +8,30 @@ import (
 )
 
 func optimusServeCommand(l logger, conf config.Provider) *cli.Command {
+	var store string
 	c := &cli.Command{
 		Use:   "serve",
 		Short: "Starts optimus service",
+		Example: `optimus serve
+optimus serve --store=memory`,
 		RunE: func(c *cli.Command, args []string) error {
-			return server.Initialize(conf)
+			return server.Initialize(serveConfig{Provider: conf, store: store})
 		},
 	}
+	c.Flags().StringVar(&store, "store", "", "storage of specifications, postgres or memory, overrides "+config.KeyServeStore)
 	return c
 }
+
+// serveConfig overrides config with flags of serve command
+type serveConfig struct {
+	config.Provider
+	store string
+}
+
+func (c serveConfig) GetServe() config.ServerConfig {
+	serve := c.Provider.GetServe()
+	if c.store != "" {
+		serve.Store = c.store
+	}
+	return serve
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+
+	"github.com/odpf/optimus/config"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store/postgres"
+	"github.com/pkg/errors"
+)
+
+// Reencrypt seals secrets and sensitive config of all projects again with
+// the currently configured master key. It should be run after rotating or
+// switching master keys, keys used previously need to stay enabled till
+// it finishes. Values flagged sensitive after they were saved get
+// encrypted as well.
+func Reencrypt(conf config.Provider) error {
+	logger.Init(conf.GetLog().Level)
+
+	encrypter, err := newEncrypter(context.Background(), conf)
+	if err != nil {
+		return err
+	}
+	if encrypter == nil {
+		return errors.Errorf("encryption is not enabled, configure %s", config.KeyServeEncryptionKMSProvider)
+	}
+	if conf.GetServe().Encryption.KMSKey == "" {
+		return errors.Errorf("required config missing: %s", config.KeyServeEncryptionKMSKey)
+	}
+
+	dbConn, err := postgres.Connect(conf.GetServe().DB.DSN, conf.GetServe().DB.MaxIdleConnection, conf.GetServe().DB.MaxOpenConnection,
+		conf.GetServe().DB.ConnMaxLifetime, conf.GetServe().DB.StatementTimeout)
+	if err != nil {
+		return errors.Wrap(err, "postgres.Connect")
+	}
+	defer dbConn.Close()
+
+	appHash, err := models.NewApplicationSecret(conf.GetServe().AppKey)
+	if err != nil {
+		return errors.Wrap(err, "NewApplicationSecret")
+	}
+	vaultClient := newVaultClient(conf)
+	sealer, err := newSealer(conf, appHash)
+	if err != nil {
+		return err
+	}
+
+	projectRepo := (&projectRepoFactory{
+		db:        dbConn,
+		hash:      appHash,
+		vault:     vaultClient,
+		encrypter: encrypter,
+		sealer:    sealer,
+	}).New()
+	namespaceRepoFac := &namespaceRepoFactory{
+		db:        dbConn,
+		hash:      appHash,
+		vault:     vaultClient,
+		encrypter: encrypter,
+		sealer:    sealer,
+	}
+	secretRepoFac := &projectSecretRepoFactory{
+		db:        dbConn,
+		hash:      appHash,
+		vault:     vaultClient,
+		encrypter: encrypter,
+	}
+
+	// specs are read decrypted, saving them back seals them with the
+	// current master key
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to read projects")
+	}
+	for _, proj := range projects {
+		if err := projectRepo.Save(proj); err != nil {
+			return errors.Wrapf(err, "failed to re-encrypt config of project %s", proj.Name)
+		}
+
+		namespaceRepo := namespaceRepoFac.New(proj)
+		namespaces, err := namespaceRepo.GetAll()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read namespaces of project %s", proj.Name)
+		}
+		for _, namespace := range namespaces {
+			if err := namespaceRepo.Save(namespace); err != nil {
+				return errors.Wrapf(err, "failed to re-encrypt config of namespace %s", namespace.Name)
+			}
+		}
+
+		secretRepo := secretRepoFac.New(proj)
+		secrets, err := secretRepo.GetAll()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read secrets of project %s", proj.Name)
+		}
+		for _, secret := range secrets {
+			if err := secretRepo.Save(secret); err != nil {
+				return errors.Wrapf(err, "failed to re-encrypt secret %s of project %s", secret.Name, proj.Name)
+			}
+		}
+		logger.I("re-encrypted project ", proj.Name)
+	}
+	return nil
+}
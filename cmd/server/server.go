@@ -2,23 +2,38 @@ package server
 
 import (
 	"context"
+	"expvar"
 	"fmt"
+	"net"
 	"net/http"
+	"net/smtp"
 	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/odpf/optimus/ext/datahub"
+	"github.com/odpf/optimus/ext/kms"
+	"github.com/odpf/optimus/ext/lineage"
+	"github.com/odpf/optimus/ext/notify"
+	"github.com/odpf/optimus/ext/notify/email"
+	"github.com/odpf/optimus/ext/notify/pagerduty"
 	"github.com/odpf/optimus/ext/notify/slack"
+	"github.com/odpf/optimus/ext/notify/webhook"
 
 	"github.com/odpf/optimus/utils"
 
+	"github.com/odpf/optimus/ext/scheduler"
 	"github.com/odpf/optimus/ext/scheduler/airflow"
+	"github.com/odpf/optimus/ext/scheduler/argo"
+	"github.com/odpf/optimus/ext/scheduler/kubernetes"
+	"github.com/odpf/optimus/ext/scheduler/temporal"
 
 	"github.com/odpf/optimus/config"
 
@@ -37,10 +52,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/afero"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 
 	v1 "github.com/odpf/optimus/api/handler/v1"
 	v1handler "github.com/odpf/optimus/api/handler/v1"
@@ -54,8 +72,16 @@ import (
 	"github.com/odpf/optimus/models"
 	_ "github.com/odpf/optimus/plugin"
 	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/cache"
 	"github.com/odpf/optimus/store/gcs"
+	"github.com/odpf/optimus/store/git"
+	"github.com/odpf/optimus/store/local"
+	"github.com/odpf/optimus/store/memory"
 	"github.com/odpf/optimus/store/postgres"
+	"github.com/odpf/optimus/store/s3"
+	"github.com/odpf/optimus/store/sensitive"
+	"github.com/odpf/optimus/store/sqlite"
+	"github.com/odpf/optimus/store/vault"
 )
 
 var (
@@ -64,34 +90,169 @@ var (
 
 	shutdownWait = 30 * time.Second
 
+	// duration finished async deployments are kept for status lookups
+	deploymentRetention = 24 * time.Hour
+
+	// duration responses of calls made with an idempotency key are kept
+	// to be returned for retries
+	idempotencyKeyRetention = time.Hour
+
 	GRPCMaxRecvMsgSize = 45 << 20 // 45MB
+
+	// size of in memory buffer between http proxy and grpc server
+	gatewayBufferSize = 1 << 20 // 1MB
+
+	// network of in memory connections between http proxy and grpc server
+	gatewayNetwork = "bufconn"
+
+	// timeout of each request made to vault while reading or writing secrets
+	vaultRequestTimeout = 10 * time.Second
+
+	// timeout of each request posting job metadata to webhook
+	metadataWebhookTimeout = 10 * time.Second
+
+	// timeout of each request registering metadata schemas
+	schemaRegistryTimeout = 10 * time.Second
+
+	// timeout of each request emitting lineage of jobs to OpenLineage api
+	lineageEmitTimeout = 10 * time.Second
+	// timeout of each request pushing metadata of jobs to DataHub
+	datahubEmitTimeout = 10 * time.Second
+	// timeout of each request sending notifications to pagerduty and webhooks
+	notifyTimeout = 10 * time.Second
+
+	// timeout of each request made to other optimus servers while checking
+	// external dependencies of jobs
+	externalJobCheckTimeout = 10 * time.Second
+
+	// timeout for fetching provider configuration from oidc issuer
+	oidcDiscoveryTimeout = 10 * time.Second
+
+	// rate limit buckets of callers idle for this duration are dropped
+	rateLimitIdleTimeout = 10 * time.Minute
+)
+
+const (
+	storePostgres = "postgres"
+	storeMemory   = "memory"
+
+	// memStorageScheme keeps compiled jobs in memory of the server, it is
+	// only available with in-memory store
+	memStorageScheme = "mem"
+
+	secretBackendPostgres = "postgres"
+	secretBackendVault    = "vault"
+
+	kmsProviderGCP = "gcp"
+	kmsProviderAWS = "aws"
+
+	metadataWriterKafka   = "kafka"
+	metadataWriterWebhook = "webhook"
+	metadataWriterStdout  = "stdout"
+	metadataWriterFile    = "file"
+
+	kafkaFramingRaw      = "raw"
+	kafkaFramingRegistry = "registry"
+
+	// storeQueryStatsVar names stats of store queries served on /debug/vars
+	storeQueryStatsVar = "store_queries"
+
+	// resourceDriftStatsVar names stats of the latest drift check of
+	// resources served on /debug/vars
+	resourceDriftStatsVar = "resource_drift"
+
+	// runDurationStatsVar names stats of durations of job runs checked
+	// against their history served on /debug/vars
+	runDurationStatsVar = "run_durations"
 )
 
 // projectJobSpecRepoFactory stores raw specifications
 type projectJobSpecRepoFactory struct {
-	db *gorm.DB
+	db  *gorm.DB
+	mem *memory.DB
+
+	// opens sealed config of namespaces jobs are read along with when set
+	sealer *sensitive.Sealer
+
+	// decrypts config of namespaces and projects jobs are read along with
+	// when envelope encryption is enabled
+	encrypter *kms.Encrypter
 }
 
 func (fac *projectJobSpecRepoFactory) New(project models.ProjectSpec) store.ProjectJobSpecRepository {
-	return postgres.NewProjectJobSpecRepository(fac.db, project, postgres.NewAdapter(models.PluginRegistry))
+	var repo store.ProjectJobSpecRepository = postgres.NewProjectJobSpecRepository(fac.db, project, postgres.NewAdapter(models.PluginRegistry))
+	if fac.mem != nil {
+		repo = memory.NewProjectJobSpecRepository(fac.mem, project)
+	}
+	if fac.sealer != nil {
+		repo = sensitive.NewProjectJobSpecRepository(repo, fac.sealer)
+	}
+	if fac.encrypter != nil {
+		repo = kms.NewProjectJobSpecRepository(repo, fac.encrypter)
+	}
+	return repo
 }
 
 type replaySpecRepoRepository struct {
 	db             *gorm.DB
+	mem            *memory.DB
 	jobSpecRepoFac jobSpecRepoFactory
 }
 
 func (fac *replaySpecRepoRepository) New(job models.JobSpec) store.ReplaySpecRepository {
+	if fac.mem != nil {
+		return memory.NewReplayRepository(fac.mem, job)
+	}
 	return postgres.NewReplayRepository(fac.db, job, postgres.NewAdapter(models.PluginRegistry))
 }
 
+type deploymentRepoFactory struct {
+	db  *gorm.DB
+	mem *memory.DB
+}
+
+func (fac *deploymentRepoFactory) New(project models.ProjectSpec) store.DeploymentRepository {
+	if fac.mem != nil {
+		return memory.NewDeploymentRepository(fac.mem, project)
+	}
+	return postgres.NewDeploymentRepository(fac.db, project)
+}
+
+type backupRepoFactory struct {
+	db  *gorm.DB
+	mem *memory.DB
+}
+
+func (fac *backupRepoFactory) New(project models.ProjectSpec) store.BackupRepository {
+	if fac.mem != nil {
+		return memory.NewBackupRepository(fac.mem, project)
+	}
+	return postgres.NewBackupRepository(fac.db, project)
+}
+
+type jobRunRepoFactory struct {
+	db  *gorm.DB
+	mem *memory.DB
+}
+
+func (fac *jobRunRepoFactory) New(project models.ProjectSpec) store.JobRunRepository {
+	if fac.mem != nil {
+		return memory.NewJobRunRepository(fac.mem, project)
+	}
+	return postgres.NewJobRunRepository(fac.db, project)
+}
+
 // jobSpecRepoFactory stores raw specifications
 type jobSpecRepoFactory struct {
 	db                    *gorm.DB
+	mem                   *memory.DB
 	projectJobSpecRepoFac projectJobSpecRepoFactory
 }
 
 func (fac *jobSpecRepoFactory) New(namespace models.NamespaceSpec) job.SpecRepository {
+	if fac.mem != nil {
+		return memory.NewJobSpecRepository(fac.mem, namespace)
+	}
 	return postgres.NewJobSpecRepository(
 		fac.db,
 		namespace,
@@ -101,91 +262,375 @@ func (fac *jobSpecRepoFactory) New(namespace models.NamespaceSpec) job.SpecRepos
 }
 
 // jobRepoFactory stores compiled specifications that will be consumed by a
-// scheduler
+// scheduler, storage is picked by scheme of the storage path
 type jobRepoFactory struct {
-	schd models.SchedulerUnit
-}
+	gitPool *git.Pool
 
-func (fac *jobRepoFactory) New(ctx context.Context, proj models.ProjectSpec) (store.JobRepository, error) {
-	storagePath, ok := proj.Config[models.ProjectStoragePathKey]
-	if !ok {
-		return nil, errors.Errorf("%s not configured for project %s", models.ProjectStoragePathKey, proj.Name)
-	}
-	storageSecret, ok := proj.Secret.GetByName(models.ProjectSecretStorageKey)
-	if !ok {
-		return nil, errors.Errorf("%s secret not configured for project %s", models.ProjectSecretStorageKey, proj.Name)
-	}
+	// keeps jobs of mem:// storage paths, nil unless store is in memory
+	memFs afero.Fs
+}
 
+func (fac *jobRepoFactory) New(ctx context.Context, storagePath, storageSecret, jobsExtension string) (store.JobRepository, error) {
 	p, err := url.Parse(storagePath)
 	if err != nil {
 		return nil, err
 	}
 	switch p.Scheme {
 	case "gs":
-		storageClient, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(storageSecret)))
+		storageClient, err := newGCSClient(ctx, storageSecret)
+		if err != nil {
+			return nil, err
+		}
+		return gcs.NewJobRepository(p.Hostname(), p.Path, jobsExtension, storageClient), nil
+	case "s3":
+		s3Client, err := s3.NewClient(storageSecret)
 		if err != nil {
-			return nil, errors.Wrap(err, "error creating google storage client")
+			return nil, errors.Wrap(err, "error creating s3 client")
 		}
-		return gcs.NewJobRepository(p.Hostname(), filepath.Join(p.Path, fac.schd.GetJobsDir()), fac.schd.GetJobsExtension(), storageClient), nil
+		return s3.NewJobRepository(p.Hostname(), p.Path, jobsExtension, s3Client), nil
+	case "file":
+		return local.NewCompiledJobRepository(afero.NewOsFs(), p.Path, jobsExtension), nil
+	case memStorageScheme:
+		if fac.memFs == nil {
+			return nil, errors.Errorf("%s storage is only available with in-memory store", memStorageScheme)
+		}
+		return local.NewCompiledJobRepository(fac.memFs, p.Path, jobsExtension), nil
 	}
-	return nil, errors.Errorf("unsupported storage config %s in %s of project %s", storagePath, models.ProjectStoragePathKey, proj.Name)
+	if strings.HasPrefix(p.Scheme, git.SchemePrefix) {
+		repo, remote, err := gitRepoOf(ctx, fac.gitPool, storagePath, storageSecret)
+		if err != nil {
+			return nil, err
+		}
+		return git.NewJobRepository(repo, remote.Dir, jobsExtension), nil
+	}
+	return nil, errors.Errorf("unsupported storage config %s", storagePath)
 }
 
 type projectRepoFactory struct {
 	db   *gorm.DB
+	mem  *memory.DB
 	hash models.ApplicationKey
+
+	// resolves secret references when secrets are kept in vault
+	vault *vault.Client
+
+	// seals sensitive config when envelope encryption is enabled
+	encrypter *kms.Encrypter
+
+	// seals config matching sensitive key patterns with app key when set
+	sealer *sensitive.Sealer
+
+	// caches projects read by name when set
+	lookups *cache.Lookups
 }
 
 func (fac *projectRepoFactory) New() store.ProjectRepository {
-	return postgres.NewProjectRepository(fac.db, fac.hash)
+	var repo store.ProjectRepository = postgres.NewProjectRepository(fac.db, fac.hash)
+	if fac.mem != nil {
+		repo = memory.NewProjectRepository(fac.mem)
+	}
+	if fac.lookups != nil {
+		repo = cache.NewProjectRepository(repo, fac.lookups)
+	}
+	if fac.sealer != nil {
+		repo = sensitive.NewProjectRepository(repo, fac.sealer)
+	}
+	if fac.vault != nil {
+		repo = vault.NewProjectRepository(repo, vault.NewResolver(fac.vault))
+	}
+	if fac.encrypter != nil {
+		repo = kms.NewProjectRepository(repo, fac.encrypter)
+	}
+	return repo
 }
 
 type namespaceRepoFactory struct {
 	db   *gorm.DB
+	mem  *memory.DB
 	hash models.ApplicationKey
+
+	// resolves secret references when secrets are kept in vault
+	vault *vault.Client
+
+	// seals sensitive config when envelope encryption is enabled
+	encrypter *kms.Encrypter
+
+	// seals config matching sensitive key patterns with app key when set
+	sealer *sensitive.Sealer
+
+	// caches namespaces read by name when set
+	lookups *cache.Lookups
 }
 
 func (fac *namespaceRepoFactory) New(projectSpec models.ProjectSpec) store.NamespaceRepository {
-	return postgres.NewNamespaceRepository(fac.db, projectSpec, fac.hash)
+	var repo store.NamespaceRepository = postgres.NewNamespaceRepository(fac.db, projectSpec, fac.hash)
+	if fac.mem != nil {
+		repo = memory.NewNamespaceRepository(fac.mem, projectSpec)
+	}
+	if fac.lookups != nil {
+		repo = cache.NewNamespaceRepository(repo, projectSpec, fac.lookups)
+	}
+	if fac.sealer != nil {
+		repo = sensitive.NewNamespaceRepository(repo, fac.sealer)
+	}
+	if fac.vault != nil {
+		repo = vault.NewNamespaceRepository(repo, vault.NewResolver(fac.vault))
+	}
+	if fac.encrypter != nil {
+		repo = kms.NewNamespaceRepository(repo, projectSpec, fac.encrypter)
+	}
+	return repo
 }
 
 type projectSecretRepoFactory struct {
 	db   *gorm.DB
+	mem  *memory.DB
 	hash models.ApplicationKey
+
+	// when set, secret values are written to vault and only their
+	// references are kept in db
+	vault *vault.Client
+
+	// seals secret values when envelope encryption is enabled
+	encrypter *kms.Encrypter
+
+	// cached projects are invalidated as their secrets are saved
+	lookups *cache.Lookups
 }
 
 func (fac *projectSecretRepoFactory) New(spec models.ProjectSpec) store.ProjectSecretRepository {
-	return postgres.NewSecretRepository(fac.db, spec, fac.hash)
+	var repo store.ProjectSecretRepository = postgres.NewSecretRepository(fac.db, spec, fac.hash)
+	if fac.mem != nil {
+		repo = memory.NewSecretRepository(fac.mem, spec)
+	}
+	if fac.lookups != nil {
+		repo = cache.NewSecretRepository(repo, spec, fac.lookups)
+	}
+	if fac.vault != nil {
+		repo = vault.NewSecretRepository(repo, spec, fac.vault)
+	}
+	if fac.encrypter != nil {
+		repo = kms.NewSecretRepository(repo, fac.encrypter)
+	}
+	return repo
+}
+
+// newAuthenticator accepts static api keys and oidc tokens as configured
+func newAuthenticator(authConf config.AuthConfig) (*v1handler.Authenticator, error) {
+	apiKeys, err := parseAPIKeys(authConf.APIKeys)
+	if err != nil {
+		return nil, err
+	}
+	var verifier v1handler.TokenVerifier
+	if authConf.OIDCIssuer != "" {
+		discoverCtx, cancel := context.WithTimeout(context.Background(), oidcDiscoveryTimeout)
+		defer cancel()
+		if verifier, err = v1handler.NewOIDCVerifier(discoverCtx, authConf.OIDCIssuer, authConf.OIDCAudience); err != nil {
+			return nil, err
+		}
+	}
+	authenticator := v1handler.NewAuthenticator(apiKeys, verifier, v1handler.UnauthenticatedMethods)
+	authenticator.ClientCertIdentity = authConf.ClientCertIdentity
+	authenticator.GatewayNetwork = gatewayNetwork
+	return authenticator, nil
+}
+
+// splitList reads comma separated values
+func splitList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseMethodRateLimits reads comma separated <rpc>:<per_minute>[:<burst>]
+// limits into a map of full method name to its limit
+func parseMethodRateLimits(raw string) (map[string]v1handler.RateLimit, error) {
+	limits := map[string]v1handler.RateLimit{}
+	for _, entry := range strings.Split(raw, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+			return nil, errors.Errorf("%s should be comma separated <rpc>:<per_minute>[:<burst>] limits", config.KeyServeRateLimitMethods)
+		}
+		var limit v1handler.RateLimit
+		var err error
+		if limit.PerMinute, err = strconv.Atoi(parts[1]); err != nil {
+			return nil, errors.Wrapf(err, "invalid limit of %s in %s", parts[0], config.KeyServeRateLimitMethods)
+		}
+		if len(parts) == 3 {
+			if limit.Burst, err = strconv.Atoi(parts[2]); err != nil {
+				return nil, errors.Wrapf(err, "invalid burst of %s in %s", parts[0], config.KeyServeRateLimitMethods)
+			}
+		}
+		limits["/"+pb.RuntimeService_ServiceDesc.ServiceName+"/"+parts[0]] = limit
+	}
+	return limits, nil
+}
+
+// parseAPIKeys reads comma separated <name>:<key> pairs into a map of key
+// to its name
+func parseAPIKeys(raw string) (map[string]string, error) {
+	apiKeys := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("%s should be comma separated <name>:<key> pairs", config.KeyServeAuthAPIKeys)
+		}
+		apiKeys[parts[1]] = parts[0]
+	}
+	return apiKeys, nil
+}
+
+// newVaultClient returns a vault client if secrets are configured to be
+// kept in vault
+func newVaultClient(conf config.Provider) *vault.Client {
+	if conf.GetServe().Secret.Backend != secretBackendVault {
+		return nil
+	}
+	return vault.NewClient(
+		conf.GetServe().Secret.VaultAddress,
+		conf.GetServe().Secret.VaultToken,
+		conf.GetServe().Secret.VaultMount,
+		&http.Client{Timeout: vaultRequestTimeout},
+	)
+}
+
+// newEncrypter returns an envelope encrypter backed by the configured key
+// management service, nil if encryption is disabled. Config matching
+// sensitive key patterns is encrypted by it instead of the app key
+func newEncrypter(ctx context.Context, conf config.Provider) (*kms.Encrypter, error) {
+	encConf := conf.GetServe().Encryption
+	patterns, err := models.ParseConfigKeyPatterns(encConf.SensitiveConfigKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, config.KeyServeEncryptionSensitiveKeys)
+	}
+	var encrypter *kms.Encrypter
+	switch encConf.KMSProvider {
+	case "":
+		return nil, nil
+	case kmsProviderGCP:
+		client, err := kms.NewGCPClient(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gcp kms client")
+		}
+		encrypter = kms.NewEncrypter(kms.NewGCPKeyManager(client), encConf.KMSKey)
+	case kmsProviderAWS:
+		client, err := kms.NewAWSClient()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create aws kms client")
+		}
+		encrypter = kms.NewEncrypter(kms.NewAWSKeyManager(client), encConf.KMSKey)
+	default:
+		return nil, errors.Errorf("unsupported %s: %s", config.KeyServeEncryptionKMSProvider, encConf.KMSProvider)
+	}
+	encrypter.SensitiveConfig = patterns
+	return encrypter, nil
+}
+
+// newSealer returns a sealer of config matching sensitive key patterns,
+// nil if no pattern is configured. Once kms is configured the config is
+// encrypted by kms alone, the sealer only opens values it sealed earlier
+// till they are encrypted again
+func newSealer(conf config.Provider, hash models.ApplicationKey) (*sensitive.Sealer, error) {
+	patterns, err := models.ParseConfigKeyPatterns(conf.GetServe().Encryption.SensitiveConfigKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, config.KeyServeEncryptionSensitiveKeys)
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	if conf.GetServe().Encryption.KMSProvider != "" {
+		return sensitive.NewSealer(hash, nil), nil
+	}
+	return sensitive.NewSealer(hash, patterns), nil
+}
+
+type roleBindingRepoFactory struct {
+	db  *gorm.DB
+	mem *memory.DB
+}
+
+func (fac *roleBindingRepoFactory) New(spec models.ProjectSpec) store.RoleBindingRepository {
+	if fac.mem != nil {
+		return memory.NewRoleBindingRepository(fac.mem, spec)
+	}
+	return postgres.NewRoleBindingRepository(fac.db, spec)
+}
+
+type macroRepoFactory struct {
+	db  *gorm.DB
+	mem *memory.DB
+
+	// cached projects are invalidated as their macros are changed
+	lookups *cache.Lookups
+}
+
+func (fac *macroRepoFactory) New(spec models.ProjectSpec) store.MacroRepository {
+	var repo store.MacroRepository = postgres.NewMacroRepository(fac.db, spec)
+	if fac.mem != nil {
+		repo = memory.NewMacroRepository(fac.mem, spec)
+	}
+	if fac.lookups != nil {
+		repo = cache.NewMacroRepository(repo, spec, fac.lookups)
+	}
+	return repo
 }
 
 type instanceRepoFactory struct {
-	db *gorm.DB
+	db  *gorm.DB
+	mem *memory.DB
 }
 
 func (fac *instanceRepoFactory) New(spec models.JobSpec) store.InstanceSpecRepository {
+	if fac.mem != nil {
+		return memory.NewInstanceRepository(fac.mem, spec)
+	}
 	return postgres.NewInstanceRepository(fac.db, spec, postgres.NewAdapter(models.PluginRegistry))
 }
 
 // projectResourceSpecRepoFactory stores raw resource specifications at a project level
 type projectResourceSpecRepoFactory struct {
-	db *gorm.DB
+	db  *gorm.DB
+	mem *memory.DB
 }
 
 func (fac *projectResourceSpecRepoFactory) New(proj models.ProjectSpec, ds models.Datastorer) store.ProjectResourceSpecRepository {
+	if fac.mem != nil {
+		return memory.NewProjectResourceSpecRepository(fac.mem, proj, ds)
+	}
 	return postgres.NewProjectResourceSpecRepository(fac.db, proj, ds)
 }
 
 // resourceSpecRepoFactory stores raw resource specifications
 type resourceSpecRepoFactory struct {
 	db                         *gorm.DB
+	mem                        *memory.DB
 	projectResourceSpecRepoFac projectResourceSpecRepoFactory
 }
 
 func (fac *resourceSpecRepoFactory) New(namespace models.NamespaceSpec, ds models.Datastorer) store.ResourceSpecRepository {
+	if fac.mem != nil {
+		return memory.NewResourceSpecRepository(fac.mem, namespace, ds)
+	}
 	return postgres.NewResourceSpecRepository(fac.db, namespace, ds, fac.projectResourceSpecRepoFac.New(namespace.ProjectSpec, ds))
 }
 
+// objectWriterFactory writes shared artifacts of schedulers next to
+// compiled specifications
 type objectWriterFactory struct {
+	gitPool *git.Pool
+
+	// keeps objects of mem:// storage paths, nil unless store is in memory
+	memFs afero.Fs
 }
 
 func (o *objectWriterFactory) New(ctx context.Context, writerPath, writerSecret string) (store.ObjectWriter, error) {
@@ -196,26 +641,89 @@ func (o *objectWriterFactory) New(ctx context.Context, writerPath, writerSecret
 
 	switch p.Scheme {
 	case "gs":
-		gcsClient, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(writerSecret)))
+		gcsClient, err := newGCSClient(ctx, writerSecret)
 		if err != nil {
-			return nil, errors.Wrap(err, "error creating google storage client")
+			return nil, err
 		}
 		return &gcs.GcsObjectWriter{
 			Client: gcsClient,
 		}, nil
+	case "s3":
+		s3Client, err := s3.NewClient(writerSecret)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating s3 client")
+		}
+		return &s3.ObjectWriter{
+			Client: s3Client,
+		}, nil
+	case "file":
+		return local.NewObjectStore(afero.NewOsFs()), nil
+	case memStorageScheme:
+		if o.memFs == nil {
+			return nil, errors.Errorf("%s storage is only available with in-memory store", memStorageScheme)
+		}
+		return local.NewObjectStore(o.memFs), nil
+	}
+	if strings.HasPrefix(p.Scheme, git.SchemePrefix) {
+		repo, _, err := gitRepoOf(ctx, o.gitPool, writerPath, writerSecret)
+		if err != nil {
+			return nil, err
+		}
+		return &git.ObjectWriter{
+			Repo: repo,
+		}, nil
 	}
 	return nil, errors.Errorf("unsupported storage config %s", writerPath)
 }
 
+func newGCSClient(ctx context.Context, secret string) (*storage.Client, error) {
+	if secret == "" {
+		return nil, errors.Errorf("%s secret is required for google storage", models.ProjectSecretStorageKey)
+	}
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(secret)))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating google storage client")
+	}
+	return client, nil
+}
+
+// gitRepoOf returns working copy of the git repository of storage path
+func gitRepoOf(ctx context.Context, pool *git.Pool, storagePath, secret string) (*git.Repository, git.Remote, error) {
+	remote, err := git.ParseRemote(storagePath, secret)
+	if err != nil {
+		return nil, git.Remote{}, err
+	}
+	repo, err := pool.Get(ctx, remote)
+	if err != nil {
+		return nil, git.Remote{}, err
+	}
+	return repo, remote, nil
+}
+
 type metadataServiceFactory struct {
-	writer *meta.Writer
+	writer      models.MetadataWriter
+	lineage     *lineage.Emitter
+	datahub     *datahub.Emitter
+	lineageRepo store.JobLineageRepository
 }
 
 func (factory *metadataServiceFactory) New() models.MetadataService {
-	return meta.NewService(
-		factory.writer,
-		&meta.JobAdapter{},
-	)
+	services := meta.MultiService{
+		meta.NewLineageRecorder(factory.lineageRepo),
+	}
+	if factory.writer != nil {
+		services = append(services, meta.NewService(
+			factory.writer,
+			&meta.JobAdapter{},
+		))
+	}
+	if factory.lineage != nil {
+		services = append(services, factory.lineage)
+	}
+	if factory.datahub != nil {
+		services = append(services, factory.datahub)
+	}
+	return services
 }
 
 type pipelineLogObserver struct {
@@ -245,14 +753,123 @@ func checkRequiredConfigs(conf config.Provider) error {
 	if conf.GetServe().ReplayNumWorkers < 1 {
 		return errors.New(fmt.Sprintf("%s should be greater than 0", config.KeyServeReplayNumWorkers))
 	}
+	if conf.GetServe().DeployNumWorkers < 1 {
+		return errors.New(fmt.Sprintf("%s should be greater than 0", config.KeyServeDeployNumWorkers))
+	}
+	if conf.GetServe().DeployUploadConcurrency < 1 {
+		return errors.New(fmt.Sprintf("%s should be greater than 0", config.KeyServeDeployUploadConcurrency))
+	}
+	if conf.GetServe().DeployUploadBatchSize < 1 {
+		return errors.New(fmt.Sprintf("%s should be greater than 0", config.KeyServeDeployUploadBatchSize))
+	}
+	if conf.GetServe().DB.ConnMaxLifetime < 0 {
+		return errors.New(fmt.Sprintf("%s should not be negative", config.KeyServeDBConnLifetimeSecs))
+	}
+	if conf.GetServe().DB.StatementTimeout < 0 {
+		return errors.New(fmt.Sprintf("%s should not be negative", config.KeyServeDBStatementTimeoutMs))
+	}
+	switch conf.GetServe().Secret.Backend {
+	case secretBackendPostgres:
+	case secretBackendVault:
+		if conf.GetServe().Secret.VaultAddress == "" {
+			return errors.Wrap(errRequiredMissing, config.KeyServeSecretVaultAddress)
+		}
+		if conf.GetServe().Secret.VaultToken == "" {
+			return errors.Wrap(errRequiredMissing, config.KeyServeSecretVaultToken)
+		}
+	default:
+		return errors.Errorf("unsupported %s: %s", config.KeyServeSecretBackend, conf.GetServe().Secret.Backend)
+	}
+	switch conf.GetServe().Metadata.Writer {
+	case "", metadataWriterKafka, metadataWriterStdout:
+	case metadataWriterWebhook:
+		if conf.GetServe().Metadata.WebhookURL == "" {
+			return errors.Wrap(errRequiredMissing, config.KeyServeMetadataWebhookURL)
+		}
+	case metadataWriterFile:
+		if conf.GetServe().Metadata.FilePath == "" {
+			return errors.Wrap(errRequiredMissing, config.KeyServeMetadataFilePath)
+		}
+	default:
+		return errors.Errorf("unsupported %s: %s", config.KeyServeMetadataWriter, conf.GetServe().Metadata.Writer)
+	}
+	switch conf.GetServe().Metadata.KafkaFraming {
+	case "", kafkaFramingRaw:
+	case kafkaFramingRegistry:
+		if conf.GetServe().Metadata.RegistryURL == "" {
+			return errors.Wrap(errRequiredMissing, config.KeyServeMetadataSchemaRegistry)
+		}
+	default:
+		return errors.Errorf("unsupported %s: %s", config.KeyServeMetadataKafkaFraming, conf.GetServe().Metadata.KafkaFraming)
+	}
+	switch conf.GetServe().Metadata.KafkaPartitionKey {
+	case "", meta.PartitionKeyUrn, meta.PartitionKeyTenant:
+	default:
+		return errors.Errorf("unsupported %s: %s", config.KeyServeMetadataPartitionKey, conf.GetServe().Metadata.KafkaPartitionKey)
+	}
+	if conf.GetServe().Metadata.QueueSize < 0 {
+		return errors.New(fmt.Sprintf("%s should not be negative", config.KeyServeMetadataQueueSize))
+	}
+	if conf.GetServe().Metadata.RetryAttempts < 1 {
+		return errors.New(fmt.Sprintf("%s should be greater than 0", config.KeyServeMetadataRetryAttempts))
+	}
+	if conf.GetServe().Metadata.KafkaDLQTopic != "" && conf.GetServe().Metadata.KafkaBrokers == "" {
+		return errors.Wrap(errRequiredMissing, config.KeyServeMetadataKafkaBrokers)
+	}
+	if conf.GetServe().Metadata.KafkaResourceTopic != "" && conf.GetServe().Metadata.KafkaBrokers == "" {
+		return errors.Wrap(errRequiredMissing, config.KeyServeMetadataKafkaBrokers)
+	}
+	if lineageURL := conf.GetServe().Lineage.URL; lineageURL != "" {
+		if parsed, err := url.Parse(lineageURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return errors.New(fmt.Sprintf("%s should be an http(s) url", config.KeyServeLineageURL))
+		}
+	}
+	if datahubURL := conf.GetServe().Datahub.URL; datahubURL != "" {
+		if parsed, err := url.Parse(datahubURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return errors.New(fmt.Sprintf("%s should be an http(s) url", config.KeyServeDatahubURL))
+		}
+	}
+	if conf.GetServe().Lineage.MaxDepth < 1 {
+		return errors.New(fmt.Sprintf("%s should be greater than 0", config.KeyServeLineageMaxDepth))
+	}
+	if conf.GetServe().Encryption.KMSProvider != "" && conf.GetServe().Encryption.KMSKey == "" {
+		return errors.Wrap(errRequiredMissing, config.KeyServeEncryptionKMSKey)
+	}
+	if conf.GetServe().Auth.OIDCIssuer != "" && conf.GetServe().Auth.OIDCAudience == "" {
+		return errors.Wrap(errRequiredMissing, config.KeyServeAuthOIDCAudience)
+	}
+	if conf.GetServe().Auth.RBACEnabled && !conf.GetServe().Auth.Enabled() {
+		return errors.Errorf("%s needs authentication to be configured", config.KeyServeAuthRBACEnabled)
+	}
+	tlsConf := conf.GetServe().TLS
+	if (tlsConf.CertFile == "") != (tlsConf.KeyFile == "") {
+		return errors.Errorf("%s and %s should be set together", config.KeyServeTLSCertFile, config.KeyServeTLSKeyFile)
+	}
+	if tlsConf.ClientCAFile != "" && !tlsConf.Enabled() {
+		return errors.Errorf("%s needs %s to be configured", config.KeyServeTLSClientCAFile, config.KeyServeTLSCertFile)
+	}
+	if conf.GetServe().Auth.ClientCertIdentity && tlsConf.ClientCAFile == "" {
+		return errors.Errorf("%s needs %s to be configured", config.KeyServeAuthClientCertIdentity, config.KeyServeTLSClientCAFile)
+	}
+	if _, err := parseMethodRateLimits(conf.GetServe().RateLimit.Methods); err != nil {
+		return err
+	}
+	switch conf.GetServe().Store {
+	case storePostgres:
+	case storeMemory:
+		// nothing is persisted, db is not needed
+		return nil
+	default:
+		return errors.Errorf("unsupported %s: %s", config.KeyServeStore, conf.GetServe().Store)
+	}
 	if conf.GetServe().DB.DSN == "" {
 		return errors.Wrap(errRequiredMissing, "serve.db.dsn")
 	}
 	if parsed, err := url.Parse(conf.GetServe().DB.DSN); err != nil {
 		return errors.Wrap(err, "failed to parse serve.db.dsn")
 	} else {
-		if parsed.Scheme != "postgres" {
-			return errors.New("unsupported database scheme, use 'postgres'")
+		if parsed.Scheme != "postgres" && parsed.Scheme != sqlite.Scheme {
+			return errors.Errorf("unsupported database scheme, use 'postgres' or '%s'", sqlite.Scheme)
 		}
 	}
 	return nil
@@ -277,30 +894,65 @@ func Initialize(conf config.Provider) error {
 		log: log.WithField("reporter", "pipeline"),
 	}
 
-	// setup db
-	if err := postgres.Migrate(conf.GetServe().DB.DSN); err != nil {
-		return errors.Wrap(err, "postgres.Migrate")
-	}
-	dbConn, err := postgres.Connect(conf.GetServe().DB.DSN, conf.GetServe().DB.MaxIdleConnection, conf.GetServe().DB.MaxOpenConnection)
-	if err != nil {
-		return errors.Wrap(err, "postgres.Connect")
+	// setup db, schema has to match migrations of this build. With
+	// in-memory store repositories are backed by memDB instead and
+	// compiled jobs can be kept in memFs using mem:// storage paths
+	var dbConn *gorm.DB
+	var memDB *memory.DB
+	var memFs afero.Fs
+	if conf.GetServe().Store == storeMemory {
+		mainLog.Warn("using in-memory store, nothing will be kept after the server stops")
+		memDB = memory.NewDB()
+		memFs = afero.NewMemMapFs()
+	} else {
+		if !conf.GetServe().DB.ManualMigration {
+			if err := postgres.Migrate(conf.GetServe().DB.DSN); err != nil {
+				return errors.Wrap(err, "postgres.Migrate")
+			}
+		}
+		if err := postgres.CheckSchema(conf.GetServe().DB.DSN); err != nil {
+			return errors.Wrap(err, "postgres.CheckSchema, run optimus admin migrate")
+		}
+		var err error
+		dbConn, err = postgres.Connect(conf.GetServe().DB.DSN, conf.GetServe().DB.MaxIdleConnection, conf.GetServe().DB.MaxOpenConnection,
+			conf.GetServe().DB.ConnMaxLifetime, conf.GetServe().DB.StatementTimeout)
+		if err != nil {
+			return errors.Wrap(err, "postgres.Connect")
+		}
+
+		// stats of statements run by repositories are served on /debug/vars
+		queryStats := postgres.NewQueryStats()
+		expvar.Publish(storeQueryStatsVar, queryStats)
+		postgres.Instrument(dbConn, queryStats, conf.GetServe().DB.SlowQueryThreshold)
 	}
 
-	// init default scheduler
-	switch conf.GetScheduler().Name {
-	case "airflow":
-		models.Scheduler = airflow.NewScheduler(
-			&objectWriterFactory{},
+	// working copies of git repositories projects keep compiled
+	// specifications in, shared by schedulers
+	gitPool := git.NewPool(filepath.Join(os.TempDir(), "optimus", "git"))
+
+	// init schedulers, projects can pick one with their SCHEDULER_NAME
+	// config and fall back to the default scheduler
+	projectScheduler, err := scheduler.NewProjectScheduler(conf.GetScheduler().Name,
+		airflow.NewScheduler(
+			&objectWriterFactory{gitPool: gitPool, memFs: memFs},
 			&http.Client{},
-		)
-	case "airflow2":
-		models.Scheduler = airflow2.NewScheduler(
-			&objectWriterFactory{},
+			&jobRepoFactory{gitPool: gitPool, memFs: memFs},
+			conf.GetServe().IngressHost,
+		),
+		airflow2.NewScheduler(
+			&objectWriterFactory{gitPool: gitPool, memFs: memFs},
 			&http.Client{},
-		)
-	default:
-		return errors.Errorf("unsupported scheduler: %s", conf.GetScheduler().Name)
+			&jobRepoFactory{gitPool: gitPool, memFs: memFs},
+			conf.GetServe().IngressHost,
+		),
+		argo.NewScheduler(&http.Client{}, conf.GetServe().IngressHost),
+		kubernetes.NewScheduler(&http.Client{}, conf.GetServe().IngressHost),
+		temporal.NewScheduler(&http.Client{}, conf.GetServe().IngressHost),
+	)
+	if err != nil {
+		return err
 	}
+	models.Scheduler = projectScheduler
 
 	// used to encrypt secrets
 	appHash, err := models.NewApplicationSecret(conf.GetServe().AppKey)
@@ -308,11 +960,41 @@ func Initialize(conf config.Provider) error {
 		return errors.Wrap(err, "NewApplicationSecret")
 	}
 
+	// secret values are either kept encrypted in db or in vault
+	vaultClient := newVaultClient(conf)
+	if vaultClient != nil {
+		mainLog.Infof("project secrets will be stored in vault at %s", conf.GetServe().Secret.VaultAddress)
+	}
+	encrypter, err := newEncrypter(context.Background(), conf)
+	if err != nil {
+		return err
+	}
+	if encrypter != nil {
+		mainLog.Infof("envelope encryption is enabled using %s kms", conf.GetServe().Encryption.KMSProvider)
+	}
+
+	sealer, err := newSealer(conf, appHash)
+	if err != nil {
+		return err
+	}
+
+	// projects and namespaces looked up by every request are cached, the
+	// in-memory store has nothing to gain from it
+	var lookups *cache.Lookups
+	if conf.GetServe().LookupCacheTTLSecs > 0 && memDB == nil {
+		lookups = cache.NewLookups(conf.GetServe().LookupCacheTTLSecs)
+	}
+
 	// registered project store repository factory, its a wrapper over a storage
 	// interface
 	projectRepoFac := &projectRepoFactory{
-		db:   dbConn,
-		hash: appHash,
+		db:        dbConn,
+		mem:       memDB,
+		hash:      appHash,
+		vault:     vaultClient,
+		encrypter: encrypter,
+		sealer:    sealer,
+		lookups:   lookups,
 	}
 	registeredProjects, err := projectRepoFac.New().GetAll()
 	if err != nil {
@@ -325,7 +1007,7 @@ func Initialize(conf config.Provider) error {
 			defer cancel()
 
 			logger.I("bootstrapping project ", proj.Name)
-			if err := models.Scheduler.Bootstrap(bootstrapCtx, proj); err != nil {
+			if err := models.Scheduler.Bootstrap(bootstrapCtx, proj, progressObs); err != nil {
 				// Major ERROR, but we can't make this fatal
 				// other projects might be working fine though
 				logger.E(err)
@@ -335,24 +1017,45 @@ func Initialize(conf config.Provider) error {
 	}
 
 	projectSecretRepoFac := &projectSecretRepoFactory{
-		db:   dbConn,
-		hash: appHash,
+		db:        dbConn,
+		mem:       memDB,
+		hash:      appHash,
+		vault:     vaultClient,
+		encrypter: encrypter,
+		lookups:   lookups,
 	}
 	namespaceSpecRepoFac := &namespaceRepoFactory{
-		db:   dbConn,
-		hash: appHash,
+		db:        dbConn,
+		mem:       memDB,
+		hash:      appHash,
+		vault:     vaultClient,
+		encrypter: encrypter,
+		sealer:    sealer,
+		lookups:   lookups,
 	}
 	projectJobSpecRepoFac := projectJobSpecRepoFactory{
-		db: dbConn,
+		db:        dbConn,
+		mem:       memDB,
+		sealer:    sealer,
+		encrypter: encrypter,
+	}
+	roleBindingRepoFac := &roleBindingRepoFactory{
+		db:  dbConn,
+		mem: memDB,
+	}
+	var auditEventRepo store.AuditEventRepository = postgres.NewAuditEventRepository(dbConn)
+	if memDB != nil {
+		auditEventRepo = memory.NewAuditEventRepository(memDB)
 	}
 
 	// registered job store repository factory
 	jobSpecRepoFac := jobSpecRepoFactory{
 		db:                    dbConn,
+		mem:                   memDB,
 		projectJobSpecRepoFac: projectJobSpecRepoFac,
 	}
-	jobCompiler := job.NewCompiler(models.Scheduler.GetTemplate(), conf.GetServe().IngressHost)
-	dependencyResolver := job.NewDependencyResolver()
+	jobCompiler := scheduler.NewCompiler(projectScheduler, conf.GetServe().IngressHost)
+	dependencyResolver := job.NewDependencyResolver(job.NewExternalJobChecker(&http.Client{Timeout: externalJobCheckTimeout}))
 	priorityResolver := job.NewPriorityResolver()
 
 	// Logrus entry is used, allowing pre-definition of certain fields by the user.
@@ -365,47 +1068,124 @@ func Initialize(conf config.Provider) error {
 	grpc_logrus.ReplaceGrpcLogger(logrusEntry)
 
 	grpcAddr := fmt.Sprintf("%s:%d", conf.GetServe().Host, conf.GetServe().Port)
+	// retried mutating calls with the same idempotency key are not applied again
+	idempotencyCache := v1handler.NewIdempotencyCache(v1handler.IdempotentMethods, idempotencyKeyRetention)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpctags.UnaryServerInterceptor(grpctags.WithFieldExtractor(grpctags.CodeGenRequestFieldExtractor)),
+		grpc_logrus.UnaryServerInterceptor(logrusEntry, opts...),
+	}
+	var streamInterceptors []grpc.StreamServerInterceptor
+	var authorizer *v1handler.Authorizer
+	if conf.GetServe().Auth.Enabled() {
+		authenticator, err := newAuthenticator(conf.GetServe().Auth)
+		if err != nil {
+			return err
+		}
+		unaryInterceptors = append(unaryInterceptors, authenticator.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, authenticator.StreamServerInterceptor())
+		mainLog.Info("authentication of callers is enabled")
+
+		if conf.GetServe().Auth.RBACEnabled {
+			authorizer = v1handler.NewAuthorizer(
+				projectRepoFac,
+				roleBindingRepoFac,
+				v1handler.MethodRoles,
+				append(v1handler.UnauthenticatedMethods, v1handler.UnscopedMethods...),
+				splitList(conf.GetServe().Auth.Admins),
+			)
+			mainLog.Info("role based authorization of callers is enabled")
+		}
+	} else {
+		mainLog.Warn("authentication of callers is disabled, every caller is trusted")
+	}
+	if rateConf := conf.GetServe().RateLimit; rateConf.Enabled() {
+		methodLimits, err := parseMethodRateLimits(rateConf.Methods)
+		if err != nil {
+			return err
+		}
+		rateLimiter := v1handler.NewRateLimiter(v1handler.RateLimit{
+			PerMinute: rateConf.PerMinute,
+			Burst:     rateConf.Burst,
+		}, methodLimits, rateLimitIdleTimeout)
+		unaryInterceptors = append(unaryInterceptors, rateLimiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, rateLimiter.StreamServerInterceptor())
+		mainLog.Info("rate limiting of callers is enabled")
+	}
+	// mutating calls are audited before authorization to record denied calls too
+	auditor := v1handler.NewAuditor(auditEventRepo, v1handler.AuditedMethods)
+	unaryInterceptors = append(unaryInterceptors, auditor.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, auditor.StreamServerInterceptor())
+	if authorizer != nil {
+		unaryInterceptors = append(unaryInterceptors, authorizer.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, authorizer.StreamServerInterceptor())
+	}
+	unaryInterceptors = append(unaryInterceptors, idempotencyCache.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, idempotencyCache.StreamServerInterceptor())
+
 	grpcOpts := []grpc.ServerOption{
-		grpc_middleware.WithUnaryServerChain(
-			grpctags.UnaryServerInterceptor(grpctags.WithFieldExtractor(grpctags.CodeGenRequestFieldExtractor)),
-			grpc_logrus.UnaryServerInterceptor(logrusEntry, opts...),
-		),
+		grpc_middleware.WithUnaryServerChain(unaryInterceptors...),
+		grpc_middleware.WithStreamServerChain(streamInterceptors...),
 		grpc.MaxRecvMsgSize(GRPCMaxRecvMsgSize),
 	}
 	grpcServer := grpc.NewServer(grpcOpts...)
 	reflection.Register(grpcServer)
 
 	// prepare factory writer for metadata
-	var metaSvcFactory meta.MetaSvcFactory
-	kafkaWriter := NewKafkaWriter(conf.GetServe().Metadata.KafkaJobTopic, strings.Split(conf.GetServe().Metadata.KafkaBrokers, ","), conf.GetServe().Metadata.KafkaBatchSize)
-	mainLog.WithFields(logrus.Fields{
-		"topic":   conf.GetServe().Metadata.KafkaJobTopic,
-		"brokers": conf.GetServe().Metadata.KafkaBrokers,
-	}).Debug("kafka metadata writer config received")
-	if kafkaWriter != nil {
-		mainLog.Infof("job metadata publishing is enabled with brokers %s to topic %s", conf.GetServe().Metadata.KafkaBrokers, conf.GetServe().Metadata.KafkaJobTopic)
-		metaWriter := meta.NewWriter(kafkaWriter, conf.GetServe().Metadata.WriterBatchSize)
-		defer kafkaWriter.Close()
-		metaSvcFactory = &metadataServiceFactory{
-			writer: metaWriter,
-		}
+	metaWriter, err := newMetadataWriter(conf)
+	if err != nil {
+		return err
+	}
+	if metaWriter != nil {
+		defer metaWriter.Close()
 	} else {
 		mainLog.Info("job metadata publishing is disabled")
 	}
+	resourceMetaWriter, err := newResourceMetadataWriter(conf)
+	if err != nil {
+		return err
+	}
+	if resourceMetaWriter != nil {
+		defer resourceMetaWriter.Close()
+	}
+	var lineageEmitter *lineage.Emitter
+	if lineageURL := conf.GetServe().Lineage.URL; lineageURL != "" {
+		mainLog.Info("job lineage is emitted to ", lineageURL)
+		lineageEmitter = lineage.NewEmitter(lineageURL, conf.GetServe().Lineage.APIKey, &http.Client{Timeout: lineageEmitTimeout})
+	}
+	var jobLineageRepo store.JobLineageRepository = postgres.NewJobLineageRepository(dbConn)
+	if memDB != nil {
+		jobLineageRepo = memory.NewJobLineageRepository(memDB)
+	}
+	// jobs are pushed to DataHub of projects setting one even if the
+	// server doesn't
+	datahubEmitter := datahub.NewEmitter(conf.GetServe().Datahub.URL, conf.GetServe().Datahub.Token,
+		conf.GetServe().Datahub.Env, &meta.JobAdapter{}, &http.Client{Timeout: datahubEmitTimeout})
+	metaSvcFactory := &metadataServiceFactory{
+		writer:      metaWriter,
+		lineage:     lineageEmitter,
+		datahub:     datahubEmitter,
+		lineageRepo: jobLineageRepo,
+	}
 
 	projectResourceSpecRepoFac := projectResourceSpecRepoFactory{
-		db: dbConn,
+		db:  dbConn,
+		mem: memDB,
 	}
 	resourceSpecRepoFac := resourceSpecRepoFactory{
 		db:                         dbConn,
+		mem:                        memDB,
 		projectResourceSpecRepoFac: projectResourceSpecRepoFac,
 	}
 
 	replaySpecRepoFac := &replaySpecRepoRepository{
 		db:             dbConn,
+		mem:            memDB,
 		jobSpecRepoFac: jobSpecRepoFac,
 	}
-	replayWorker := job.NewReplayWorker(replaySpecRepoFac, models.Scheduler)
+	replayWorker := job.NewReplayWorker(replaySpecRepoFac, models.Scheduler, job.ReplayWorkerConfig{
+		Parallelism:  conf.GetServe().ReplayParallelism,
+		PollInterval: conf.GetServe().ReplayPollIntervalSecs,
+	})
 	replayManager := job.NewManager(replayWorker, replaySpecRepoFac, utils.NewUUIDProvider(), job.ReplayManagerConfig{
 		NumWorkers:    conf.GetServe().ReplayNumWorkers,
 		WorkerTimeout: conf.GetServe().ReplayWorkerTimeoutSecs,
@@ -414,33 +1194,71 @@ func Initialize(conf config.Provider) error {
 
 	notificationContext, cancelNotifiers := context.WithCancel(context.Background())
 	defer cancelNotifiers()
+	notifyConf := conf.GetServe().Notify
+	dispatcherConfig := notify.DispatcherConfig{
+		QueueSize:   notifyConf.QueueSize,
+		MaxAttempts: notifyConf.RetryAttempts,
+		Backoff:     notifyConf.RetryBackoffMs,
+	}
+	notifyErrHandler := func(err error) {
+		logger.E(err)
+	}
 	eventService := job.NewEventService(map[string]models.Notifier{
 		"slack": slack.NewNotifier(notificationContext, slackapi.APIURL,
 			slack.DefaultEventBatchInterval,
-			func(err error) {
-				logger.E(err)
-			},
+			notifyErrHandler,
 		),
+		"pagerduty": pagerduty.NewNotifier(notifyConf.PagerDutyURL, &http.Client{Timeout: notifyTimeout},
+			dispatcherConfig, notifyErrHandler),
+		"webhook": webhook.NewNotifier(&http.Client{Timeout: notifyTimeout}, dispatcherConfig, notifyErrHandler),
+		"email":   email.NewNotifier(smtp.SendMail, email.DefaultFlushInterval, notifyErrHandler),
 	})
 
+	jobSvc := job.NewService(
+		&jobSpecRepoFac,
+		models.Scheduler,
+		jobCompiler,
+		jobSpecAssetDump(),
+		dependencyResolver,
+		priorityResolver,
+		metaSvcFactory,
+		&projectJobSpecRepoFac,
+		replayManager,
+	)
+	jobSvc.RetirementGrace = conf.GetServe().JobRetirementGraceSecs
+	jobSvc.WarnDuplicateDestination = conf.GetServe().WarnDuplicateDestination
+	deployManager := job.NewDeploymentManager(jobSvc, &deploymentRepoFactory{
+		db:  dbConn,
+		mem: memDB,
+	}, utils.NewUUIDProvider(), job.DeployManagerConfig{
+		NumWorkers:    conf.GetServe().DeployNumWorkers,
+		WorkerTimeout: conf.GetServe().DeployWorkerTimeoutSecs,
+		QueueSize:     conf.GetServe().DeployQueueSize,
+		Retention:     deploymentRetention,
+	}, progressObs)
+	retirementSweeper := job.NewRetirementSweeper(jobSvc, projectRepoFac, namespaceSpecRepoFac, models.Scheduler, progressObs, conf.GetServe().JobRetirementGraceSecs, conf.GetServe().JobRetirementSweepSecs)
+	tombstoneSweeper := job.NewTombstoneSweeper(projectRepoFac, namespaceSpecRepoFac, &jobSpecRepoFac, conf.GetServe().DeletedJobRetentionSecs, conf.GetServe().DeletedJobSweepSecs)
+	jobRunRepoFac := &jobRunRepoFactory{
+		db:  dbConn,
+		mem: memDB,
+	}
+	runPoller := job.NewRunPoller(projectRepoFac, namespaceSpecRepoFac, jobRunRepoFac, models.Scheduler, conf.GetServe().JobRunPollSecs)
+	slaChecker := job.NewSLAChecker(jobSvc, projectRepoFac, namespaceSpecRepoFac, jobRunRepoFac, eventService, conf.GetServe().SLACheckSecs)
+	driftChecker := datastore.NewDriftChecker(projectRepoFac, namespaceSpecRepoFac, &resourceSpecRepoFac, models.DatastoreRegistry, progressObs, conf.GetServe().DriftCheckSecs)
+	expvar.Publish(resourceDriftStatsVar, driftChecker.Stats)
+
+	datastoreService := datastore.NewService(&resourceSpecRepoFac, models.DatastoreRegistry)
+	datastoreService.FailOnIncompatibleDrift = conf.GetServe().FailOnIncompatibleDrift
+	if resourceMetaWriter != nil {
+		datastoreService.Metadata = meta.NewResourceService(resourceMetaWriter, &meta.ResourceAdapter{})
+	}
+
 	// runtime service instance over grpc
-	pb.RegisterRuntimeServiceServer(grpcServer, v1handler.NewRuntimeServiceServer(
+	runtimeService := v1handler.NewRuntimeServiceServer(
 		config.Version,
-		job.NewService(
-			&jobSpecRepoFac,
-			&jobRepoFactory{
-				schd: models.Scheduler,
-			},
-			jobCompiler,
-			jobSpecAssetDump(),
-			dependencyResolver,
-			priorityResolver,
-			metaSvcFactory,
-			&projectJobSpecRepoFac,
-			replayManager,
-		),
+		jobSvc,
 		eventService,
-		datastore.NewService(&resourceSpecRepoFac, models.DatastoreRegistry),
+		datastoreService,
 		projectRepoFac,
 		namespaceSpecRepoFac,
 		projectSecretRepoFac,
@@ -448,7 +1266,8 @@ func Initialize(conf config.Provider) error {
 		progressObs,
 		instance.NewService(
 			&instanceRepoFactory{
-				db: dbConn,
+				db:  dbConn,
+				mem: memDB,
 			},
 			func() time.Time {
 				return time.Now().UTC()
@@ -456,18 +1275,67 @@ func Initialize(conf config.Provider) error {
 			instance.NewGoEngine(),
 		),
 		models.Scheduler,
-	))
+		deployManager,
+		roleBindingRepoFac,
+		auditEventRepo,
+		&macroRepoFactory{
+			db:      dbConn,
+			mem:     memDB,
+			lookups: lookups,
+		},
+		models.PluginRegistry,
+	)
+	runtimeService.UploadConcurrency = conf.GetServe().DeployUploadConcurrency
+	runtimeService.UploadBatchSize = conf.GetServe().DeployUploadBatchSize
+	runtimeService.ReconcileOptIn = conf.GetServe().DeployReconcileOptIn
+	runtimeService.JobRunRepoFactory = jobRunRepoFac
+	if runDurationConf := conf.GetServe().RunDuration; runDurationConf.AnomalyFactor > 0 {
+		runDurationChecker := job.NewRunDurationChecker(jobRunRepoFac, eventService, runDurationConf.AnomalyFactor,
+			runDurationConf.Window, runDurationConf.MinRuns)
+		expvar.Publish(runDurationStatsVar, runDurationChecker.Stats)
+		runtimeService.RunDurations = runDurationChecker
+	}
+	if runtimeService.SensitiveConfig, err = models.ParseConfigKeyPatterns(conf.GetServe().Encryption.SensitiveConfigKeys); err != nil {
+		return errors.Wrap(err, config.KeyServeEncryptionSensitiveKeys)
+	}
+	if authorizer != nil {
+		runtimeService.SecretReaders = authorizer
+	}
+	if lineageEmitter != nil {
+		runtimeService.RunLineage = lineageEmitter
+	}
+	runtimeService.JobLineage = job.NewLineageService(jobLineageRepo, conf.GetServe().Lineage.MaxDepth)
+	backupService := datastore.NewBackupService(&resourceSpecRepoFac, &projectResourceSpecRepoFac, &backupRepoFactory{
+		db:  dbConn,
+		mem: memDB,
+	}, models.DatastoreRegistry, utils.NewUUIDProvider())
+	backupService.Lineage = jobLineageRepo
+	runtimeService.Backups = backupService
+	pb.RegisterRuntimeServiceServer(grpcServer, runtimeService)
 
 	timeoutGrpcDialCtx, grpcDialCancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer grpcDialCancel()
 
+	// http proxy reaches grpc server in memory, which keeps it working when
+	// the server only accepts connections presenting client certificates
+	gatewayListener := bufconn.Listen(gatewayBufferSize)
+	go func() {
+		if err := grpcServer.Serve(gatewayListener); err != nil {
+			mainLog.Errorf("gateway grpc server error: %v", err)
+		}
+	}()
+
 	// prepare http proxy
 	gwmux := runtime.NewServeMux(
 		runtime.WithErrorHandler(runtime.DefaultHTTPErrorHandler),
+		runtime.WithMetadata(gatewayClientCertMetadata),
 	)
 	// gRPC dialup options to proxy http connections
 	grpcConn, err := grpc.DialContext(timeoutGrpcDialCtx, grpcAddr, []grpc.DialOption{
 		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return gatewayListener.Dial()
+		}),
 	}...)
 	if err != nil {
 		return errors.Wrap(err, "grpc.DialContext")
@@ -483,7 +1351,8 @@ func Initialize(conf config.Provider) error {
 	baseMux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "pong")
 	})
-	baseMux.Handle("/api/", http.StripPrefix("/api", gwmux))
+	baseMux.Handle("/api/", http.StripPrefix("/api", stripGatewayClientCert(gwmux)))
+	baseMux.Handle("/debug/vars", expvar.Handler())
 
 	srv := &http.Server{
 		Handler:      grpcHandlerFunc(grpcServer, baseMux),
@@ -493,10 +1362,43 @@ func Initialize(conf config.Provider) error {
 		IdleTimeout:  120 * time.Second,
 	}
 
+	var tlsCerts *tlsReloader
+	if conf.GetServe().TLS.Enabled() {
+		if tlsCerts, err = newTLSReloader(conf.GetServe().TLS); err != nil {
+			return err
+		}
+		srv.TLSConfig = tlsCerts.TLSConfig()
+		if conf.GetServe().TLS.ClientCAFile != "" {
+			mainLog.Info("mutual tls is enabled, clients need to present a certificate")
+		} else {
+			mainLog.Info("tls is enabled")
+		}
+
+		// certificates are read again on SIGHUP to rotate them without restart
+		reloadChan := make(chan os.Signal, 1)
+		signal.Notify(reloadChan, syscall.SIGHUP)
+		defer signal.Stop(reloadChan)
+		go func() {
+			for range reloadChan {
+				if err := tlsCerts.Reload(); err != nil {
+					mainLog.Errorf("failed to reload tls certificates, serving previous ones: %v", err)
+					continue
+				}
+				mainLog.Info("reloaded tls certificates")
+			}
+		}()
+	}
+
 	// run our server in a goroutine so that it doesn't block to wait for termination requests
 	go func() {
 		mainLog.Infoln("starting listening at ", grpcAddr)
-		if err := srv.ListenAndServe(); err != nil {
+		var err error
+		if tlsCerts != nil {
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil {
 			if err != http.ErrServerClosed {
 				mainLog.Fatalf("server error: %v\n", err)
 			}
@@ -516,6 +1418,24 @@ func Initialize(conf config.Provider) error {
 	if err = replayManager.Close(); err != nil {
 		terminalError = multierror.Append(terminalError, errors.Wrap(err, "replayManager.Close"))
 	}
+	if err = deployManager.Close(); err != nil {
+		terminalError = multierror.Append(terminalError, errors.Wrap(err, "deployManager.Close"))
+	}
+	if err = retirementSweeper.Close(); err != nil {
+		terminalError = multierror.Append(terminalError, errors.Wrap(err, "retirementSweeper.Close"))
+	}
+	if err = tombstoneSweeper.Close(); err != nil {
+		terminalError = multierror.Append(terminalError, errors.Wrap(err, "tombstoneSweeper.Close"))
+	}
+	if err = runPoller.Close(); err != nil {
+		terminalError = multierror.Append(terminalError, errors.Wrap(err, "runPoller.Close"))
+	}
+	if err = slaChecker.Close(); err != nil {
+		terminalError = multierror.Append(terminalError, errors.Wrap(err, "slaChecker.Close"))
+	}
+	if err = driftChecker.Close(); err != nil {
+		terminalError = multierror.Append(terminalError, errors.Wrap(err, "driftChecker.Close"))
+	}
 
 	// Create a deadline to wait for server
 	ctxProxy, cancelProxy := context.WithTimeout(context.Background(), shutdownWait)
@@ -555,6 +1475,174 @@ func grpcHandlerFunc(grpcServer *grpc.Server, otherHandler http.Handler) http.Ha
 	}), &http2.Server{})
 }
 
+// gatewayClientCertMetadata forwards the common name of client certificate
+// http server verified to grpc server, which can't see it over the in
+// memory connection of the gateway
+func gatewayClientCertMetadata(_ context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+	if commonName == "" {
+		return nil
+	}
+	return metadata.Pairs(v1handler.GatewayClientCertHeader, commonName)
+}
+
+// stripGatewayClientCert drops the client certificate header callers send
+// as gateway metadata, only the one verified by http server is forwarded
+func stripGatewayClientCert(next http.Handler) http.Handler {
+	header := http.CanonicalHeaderKey(runtime.MetadataHeaderPrefix + v1handler.GatewayClientCertHeader)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(header)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newMetadataWriter returns the configured writer of job metadata
+// publishing asynchronously, nil if metadata publishing is disabled
+func newMetadataWriter(conf config.Provider) (models.MetadataWriter, error) {
+	writer, err := newMetadataSink(conf)
+	if err != nil || writer == nil {
+		return nil, err
+	}
+	deadLetter, err := newMetadataDeadLetterWriter(conf)
+	if err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	metaConf := conf.GetServe().Metadata
+	return meta.NewAsyncWriter(writer, deadLetter, meta.AsyncWriterConfig{
+		BatchSize:     metaConf.WriterBatchSize,
+		QueueSize:     metaConf.QueueSize,
+		FlushInterval: metaConf.FlushIntervalMs,
+		MaxAttempts:   metaConf.RetryAttempts,
+		Backoff:       metaConf.RetryBackoffMs,
+	}), nil
+}
+
+// newResourceMetadataWriter returns the writer of datastore resource
+// metadata publishing asynchronously to its own kafka topic, nil if it
+// isn't configured. Dead letters are shared with job metadata
+func newResourceMetadataWriter(conf config.Provider) (models.MetadataWriter, error) {
+	metaConf := conf.GetServe().Metadata
+	if metaConf.KafkaResourceTopic == "" {
+		return nil, nil
+	}
+	kafkaWriter := NewKafkaWriter(metaConf.KafkaResourceTopic, strings.Split(metaConf.KafkaBrokers, ","), metaConf.KafkaBatchSize)
+	if kafkaWriter == nil {
+		return nil, errors.Wrap(errRequiredMissing, config.KeyServeMetadataKafkaBrokers)
+	}
+	logger.I(fmt.Sprintf("resource metadata publishing is enabled with brokers %s to topic %s", metaConf.KafkaBrokers, metaConf.KafkaResourceTopic))
+	var writer meta.RoutedWriter = newKafkaMetadataWriter(kafkaWriter, metaConf)
+	if metaConf.KafkaFraming == kafkaFramingRegistry {
+		ctx, cancel := context.WithTimeout(context.Background(), schemaRegistryTimeout)
+		defer cancel()
+		registry := meta.NewSchemaRegistry(metaConf.RegistryURL, &http.Client{Timeout: schemaRegistryTimeout})
+		registryWriter, err := meta.NewResourceRegistryWriter(ctx, writer, registry, metaConf.KafkaResourceTopic)
+		if err != nil {
+			writer.Close()
+			return nil, err
+		}
+		writer = registryWriter
+	}
+
+	deadLetter, err := newMetadataDeadLetterWriter(conf)
+	if err != nil {
+		writer.Close()
+		return nil, err
+	}
+	router := meta.NewRouter(writer, metaConf.KafkaResourceTopic, metaConf.KafkaPartitionKey)
+	return meta.NewAsyncWriter(router, deadLetter, meta.AsyncWriterConfig{
+		BatchSize:     metaConf.WriterBatchSize,
+		QueueSize:     metaConf.QueueSize,
+		FlushInterval: metaConf.FlushIntervalMs,
+		MaxAttempts:   metaConf.RetryAttempts,
+		Backoff:       metaConf.RetryBackoffMs,
+	}), nil
+}
+
+// newMetadataDeadLetterWriter returns the writer of metadata failing to be
+// published, nil if dead letters are not configured
+func newMetadataDeadLetterWriter(conf config.Provider) (models.MetadataWriter, error) {
+	metaConf := conf.GetServe().Metadata
+	if metaConf.DLQFilePath != "" {
+		logger.I("job metadata dead letters are written to file ", metaConf.DLQFilePath)
+		fileWriter, err := meta.NewFileWriter(metaConf.DLQFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return fileWriter, nil
+	}
+	if metaConf.KafkaDLQTopic != "" {
+		kafkaWriter := NewKafkaWriter(metaConf.KafkaDLQTopic, strings.Split(metaConf.KafkaBrokers, ","), metaConf.KafkaBatchSize)
+		if kafkaWriter == nil {
+			return nil, errors.Wrap(errRequiredMissing, config.KeyServeMetadataKafkaBrokers)
+		}
+		logger.I("job metadata dead letters are sent to topic ", metaConf.KafkaDLQTopic)
+		return meta.NewWriter(kafkaWriter, metaConf.WriterBatchSize), nil
+	}
+	return nil, nil
+}
+
+// newMetadataSink returns the writer metadata is published through
+func newMetadataSink(conf config.Provider) (meta.BufferedWriter, error) {
+	metaConf := conf.GetServe().Metadata
+	writer := metaConf.Writer
+	if writer == "" && metaConf.KafkaBrokers != "" {
+		writer = metadataWriterKafka
+	}
+	switch writer {
+	case "":
+		return nil, nil
+	case metadataWriterKafka:
+		kafkaWriter := NewKafkaWriter(metaConf.KafkaJobTopic, strings.Split(metaConf.KafkaBrokers, ","), metaConf.KafkaBatchSize)
+		if kafkaWriter == nil {
+			return nil, nil
+		}
+		logger.I(fmt.Sprintf("job metadata publishing is enabled with brokers %s to topic %s", metaConf.KafkaBrokers, metaConf.KafkaJobTopic))
+		metaWriter := newKafkaMetadataWriter(kafkaWriter, metaConf)
+		if metaConf.KafkaFraming != kafkaFramingRegistry {
+			return meta.NewRouter(metaWriter, metaConf.KafkaJobTopic, metaConf.KafkaPartitionKey), nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), schemaRegistryTimeout)
+		defer cancel()
+		registry := meta.NewSchemaRegistry(metaConf.RegistryURL, &http.Client{Timeout: schemaRegistryTimeout})
+		registryWriter, err := meta.NewRegistryWriter(ctx, metaWriter, registry, metaConf.KafkaJobTopic)
+		if err != nil {
+			metaWriter.Close()
+			return nil, err
+		}
+		logger.I("job metadata is framed with schemas registered at ", metaConf.RegistryURL)
+		return meta.NewRouter(registryWriter, metaConf.KafkaJobTopic, metaConf.KafkaPartitionKey), nil
+	case metadataWriterWebhook:
+		logger.I("job metadata publishing is enabled to webhook ", metaConf.WebhookURL)
+		return meta.NewWebhookWriter(metaConf.WebhookURL, &http.Client{Timeout: metadataWebhookTimeout}, metaConf.WriterBatchSize), nil
+	case metadataWriterStdout:
+		logger.I("job metadata publishing is enabled to stdout")
+		return meta.NewStreamWriter(os.Stdout), nil
+	case metadataWriterFile:
+		logger.I("job metadata publishing is enabled to file ", metaConf.FilePath)
+		fileWriter, err := meta.NewFileWriter(metaConf.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		return fileWriter, nil
+	}
+	return nil, errors.Errorf("unsupported %s: %s", config.KeyServeMetadataWriter, writer)
+}
+
+// newKafkaMetadataWriter returns a writer of metadata through client, it
+// creates clients of topics of projects as metadata is routed to them
+func newKafkaMetadataWriter(client meta.KafkaWriter, metaConf config.MetadataConfig) *meta.Writer {
+	writer := meta.NewWriter(client, metaConf.WriterBatchSize)
+	writer.TopicClient = func(topic string) meta.KafkaWriter {
+		return NewKafkaWriter(topic, strings.Split(metaConf.KafkaBrokers, ","), metaConf.KafkaBatchSize)
+	}
+	return writer
+}
+
 // NewKafkaWriter creates a new kafka client that will be used for meta publishing
 func NewKafkaWriter(topic string, brokers []string, batchSize int) *kafka.Writer {
 	// check if metadata publisher is disabled
@@ -566,5 +1654,6 @@ func NewKafkaWriter(topic string, brokers []string, batchSize int) *kafka.Writer
 		Topic:     topic,
 		Brokers:   brokers,
 		BatchSize: batchSize,
+		Balancer:  &meta.PartitionKeyBalancer{},
 	})
 }
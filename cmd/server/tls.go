@@ -0,0 +1,83 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"sync"
+
+	"github.com/odpf/optimus/config"
+	"github.com/pkg/errors"
+)
+
+// tlsReloader serves the certificate and client CAs read from files, they
+// are read again on Reload so certificates can be rotated without restart
+type tlsReloader struct {
+	conf config.TLSConfig
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// Reload reads the files again, previous certificates are kept being
+// served if any of them is invalid
+func (r *tlsReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.conf.CertFile, r.conf.KeyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load server certificate")
+	}
+
+	var clientCAs *x509.CertPool
+	if r.conf.ClientCAFile != "" {
+		caPEM, err := ioutil.ReadFile(r.conf.ClientCAFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read client CAs")
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return errors.Errorf("no pem encoded certificates found in %s", r.conf.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert, r.clientCAs = &cert, clientCAs
+	return nil
+}
+
+// TLSConfig returns a config resolving certificates on every handshake
+func (r *tlsReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			conf := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2", "http/1.1"},
+				Certificates: []tls.Certificate{*r.cert},
+			}
+			if r.clientCAs != nil {
+				conf.ClientCAs = r.clientCAs
+				conf.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return conf, nil
+		},
+	}
+}
+
+func newTLSReloader(conf config.TLSConfig) (*tlsReloader, error) {
+	reloader := &tlsReloader{conf: conf}
+	if err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
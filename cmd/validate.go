@@ -2,42 +2,48 @@ package cmd
 
 import (
 	"context"
-	"fmt"
-	"io"
+	"path/filepath"
 	"time"
 
 	v1handler "github.com/odpf/optimus/api/handler/v1"
 
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store/local"
 
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 	"github.com/pkg/errors"
 	cli "github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	validateTimeout = time.Minute * 3
 )
 
-func validateCommand(l logger, host string, pluginRepo models.PluginRepository, jobSpecRepo JobSpecRepository) *cli.Command {
+func validateCommand(l logger, host, jobsPath string, pluginRepo models.PluginRepository, jobSpecRepo JobSpecRepository) *cli.Command {
 	cmd := &cli.Command{
 		Use:   "validate",
 		Short: "check if specifications are valid for deployment",
 	}
 	if jobSpecRepo != nil {
-		cmd.AddCommand(validateJobCommand(l, host, pluginRepo, jobSpecRepo))
+		cmd.AddCommand(validateJobCommand(l, host, jobsPath, pluginRepo, jobSpecRepo))
 	}
 	return cmd
 }
 
-func validateJobCommand(l logger, host string, pluginRepo models.PluginRepository, jobSpecRepo JobSpecRepository) *cli.Command {
+func validateJobCommand(l logger, host, jobsPath string, pluginRepo models.PluginRepository, jobSpecRepo JobSpecRepository) *cli.Command {
 	var projectName string
 	var namespace string
 	cmd := &cli.Command{
-		Use:     "job",
-		Short:   "run basic checks on all jobs",
-		Example: "optimus validate job",
+		Use:   "job",
+		Short: "check all job specifications against their schema and optimus server",
+		Long: `Check all job specifications against their schema and then with optimus server.
+Issues of every job are printed along with the file and line they are found at,
+command fails if any job is invalid so it can be used in CI`,
+		Example: "optimus validate job --project a-data-project --namespace game_jam",
 	}
 	cmd.Flags().StringVar(&projectName, "project", "", "name of the project")
 	cmd.MarkFlagRequired("project")
@@ -46,24 +52,55 @@ func validateJobCommand(l logger, host string, pluginRepo models.PluginRepositor
 
 	cmd.RunE = func(c *cli.Command, args []string) error {
 		start := time.Now()
-		jobSpecs, err := jobSpecRepo.GetAll()
+		validatedSpecs, err := jobSpecRepo.Validate()
 		if err != nil {
 			return err
 		}
-		if err := validateJobSpecificationRequest(l, projectName, namespace, pluginRepo, jobSpecs, host); err != nil {
+		if err := checkJobSpecificationRequest(l, projectName, namespace, pluginRepo, validatedSpecs, host); err != nil {
 			return err
 		}
-		l.Println("jobs successfully validated")
-		l.Printf("validated in %s\n", time.Since(start).String())
 
+		invalid := 0
+		for _, validated := range validatedSpecs {
+			name := validated.Name
+			if name == "" {
+				name = filepath.Join(jobsPath, validated.File)
+			}
+			if len(validated.Issues) == 0 {
+				l.Printf("%s: %s\n", name, coloredSuccess("valid"))
+				continue
+			}
+			invalid++
+			l.Printf("%s: %s\n", name, coloredError("invalid"))
+			for _, issue := range validated.Issues {
+				issue.File = filepath.Join(jobsPath, issue.File)
+				l.Printf("  %s\n", issue)
+			}
+		}
+		l.Printf("validated in %s\n", time.Since(start).String())
+		if invalid > 0 {
+			return errors.Errorf("%d of %d job specifications are invalid", invalid, len(validatedSpecs))
+		}
+		l.Println("jobs successfully validated")
 		return nil
 	}
 
 	return cmd
 }
 
-func validateJobSpecificationRequest(l logger, projectName string, namespace string,
-	pluginRepo models.PluginRepository, jobSpecs []models.JobSpec, host string) (err error) {
+// checkJobSpecificationRequest checks specs valid locally with optimus
+// server one by one, issues server finds are added to their spec
+func checkJobSpecificationRequest(l logger, projectName string, namespace string,
+	pluginRepo models.PluginRepository, validatedSpecs []local.ValidatedJobSpec, host string) (err error) {
+	validLocally := 0
+	for _, validated := range validatedSpecs {
+		if len(validated.Issues) == 0 {
+			validLocally++
+		}
+	}
+	if validLocally == 0 {
+		return nil
+	}
 	adapt := v1handler.NewAdapter(pluginRepo, models.DatastoreRegistry)
 
 	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
@@ -72,67 +109,76 @@ func validateJobSpecificationRequest(l logger, projectName string, namespace str
 	var conn *grpc.ClientConn
 	if conn, err = createConnection(dialTimeoutCtx, host); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			l.Println("can't reach optimus service")
+			l.Println(coloredError("can't reach optimus service"))
 		}
 		return err
 	}
 	defer conn.Close()
 
-	dumpTimeoutCtx, dumpCancel := context.WithTimeout(context.Background(), validateTimeout)
-	defer dumpCancel()
-
-	adaptedJobSpecs := []*pb.JobSpecification{}
-	for _, spec := range jobSpecs {
-		adaptJob, err := adapt.ToJobProto(spec)
-		if err != nil {
-			return errors.Wrapf(err, "failed to serialize: %s", spec.Name)
-		}
-		adaptedJobSpecs = append(adaptedJobSpecs, adaptJob)
-	}
+	checkTimeoutCtx, checkCancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer checkCancel()
 
 	l.Println("validating please wait...")
-
 	runtime := pb.NewRuntimeServiceClient(conn)
-	respStream, err := runtime.CheckJobSpecifications(dumpTimeoutCtx, &pb.CheckJobSpecificationsRequest{
-		ProjectName: projectName,
-		Jobs:        adaptedJobSpecs,
-		Namespace:   namespace,
-	})
-	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
+	for i, validated := range validatedSpecs {
+		if len(validated.Issues) > 0 {
+			continue
+		}
+		adaptJob, err := adapt.ToJobProto(validated.Spec)
+		if err != nil {
+			validatedSpecs[i].Issues = append(validated.Issues, local.JobSpecIssue{
+				File:    validated.File,
+				Message: errors.Wrap(err, "failed to serialize").Error(),
+			})
+			continue
+		}
+		_, err = runtime.CheckJobSpecification(checkTimeoutCtx, &pb.CheckJobSpecificationRequest{
+			ProjectName: projectName,
+			Namespace:   namespace,
+			Job:         adaptJob,
+		})
+		if err == nil {
+			continue
+		}
+		st := status.Convert(err)
+		switch st.Code() {
+		case codes.Unavailable, codes.Unauthenticated, codes.PermissionDenied, codes.Unimplemented:
+			return errors.Wrapf(err, "validate request failed")
+		case codes.DeadlineExceeded:
 			l.Println("validate process took too long, timing out")
+			return errors.Wrapf(err, "validate request failed")
 		}
-		return errors.Wrapf(err, "validate request failed")
+		validatedSpecs[i].Issues = append(validated.Issues, serverJobSpecIssues(validated.File, st)...)
 	}
+	return nil
+}
 
-	jobCounter := 0
-	totalJobs := len(jobSpecs)
-	totalErrors := []string{}
-	for {
-		resp, err := respStream.Recv()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return errors.Wrapf(err, "failed to receive check ack")
+// serverJobSpecIssues turns a failed check of a spec into issues, one for
+// each field violation sent along by server
+func serverJobSpecIssues(file string, st *status.Status) []local.JobSpecIssue {
+	var issues []local.JobSpecIssue
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
 		}
-		if resp.Ack {
-			// ack for the job spec
-			if !resp.GetSuccess() {
-				totalErrors = append(totalErrors, fmt.Sprintf("unable to check: %s, %s\n", resp.GetJobName(), resp.GetMessage()))
+		for _, violation := range badRequest.GetFieldViolations() {
+			message := violation.GetDescription()
+			if message == "" {
+				message = st.Message()
 			}
-			jobCounter++
-			l.Printf("%d/%d. %s successfully checked\n", jobCounter, totalJobs, resp.GetJobName())
-		} else {
-			// ordinary progress event
-			l.Printf("info '%s': %s\n", resp.GetJobName(), resp.GetMessage())
+			issues = append(issues, local.JobSpecIssue{
+				File:    file,
+				Field:   violation.GetField(),
+				Message: message,
+			})
 		}
 	}
-	if len(totalErrors) > 0 {
-		l.Println("errors:")
-		for i, reqErr := range totalErrors {
-			l.Printf("%d. %s", i, reqErr)
-		}
+	if len(issues) == 0 {
+		issues = append(issues, local.JobSpecIssue{
+			File:    file,
+			Message: st.Message(),
+		})
 	}
-	return nil
+	return issues
 }
@@ -53,14 +53,46 @@ func InitOptimus() (*Optimus, error) {
 		KeyLogLevel:                     "info",
 		KeyServePort:                    9100,
 		KeyServeHost:                    "0.0.0.0",
+		KeyServeStore:                   "postgres",
 		KeyServeDBMaxOpenConnection:     10,
 		KeyServeDBMaxIdleConnection:     5,
+		KeyServeDBSlowQueryMs:           500,
+		KeyServeDBConnLifetimeSecs:      1800,
+		KeyServeDBStatementTimeoutMs:    60000,
 		KeyServeMetadataKafkaJobTopic:   "resource_optimus_job_log",
 		KeyServeMetadataKafkaBatchSize:  50,
+		KeyServeMetadataPartitionKey:    "urn",
 		KeyServeMetadataWriterBatchSize: 50,
+		KeyServeMetadataQueueSize:       1000,
+		KeyServeMetadataFlushIntervalMs: 1000,
+		KeyServeMetadataRetryAttempts:   5,
+		KeyServeMetadataRetryBackoffMs:  500,
 		KeySchedulerName:                "airflow2",
+		KeyServeLineageMaxDepth:         5,
+		KeyServeDatahubEnv:              "PROD",
+		KeyServeNotifyQueueSize:         1000,
+		KeyServeNotifyRetryAttempts:     3,
+		KeyServeNotifyRetryBackoffMs:    1000,
+		KeyServeNotifyPagerDutyURL:      "https://events.pagerduty.com/v2/enqueue",
+		KeyServeRunDurationFactor:       3,
+		KeyServeRunDurationWindow:       20,
+		KeyServeRunDurationMinRuns:      5,
 		KeyServeReplayNumWorkers:        1,
 		KeyServeReplayWorkerTimeoutSecs: 120,
+		KeyServeReplayPollIntervalSecs:  60,
+		KeyServeDeployNumWorkers:        1,
+		KeyServeDeployWorkerTimeoutSecs: 1800,
+		KeyServeDeployQueueSize:         16,
+		KeyServeDeployUploadConcurrency: 8,
+		KeyServeDeployUploadBatchSize:   100,
+		KeyServeJobRetirementSweepSecs:  3600,
+		KeyServeDeletedJobRetentionSecs: 30 * 24 * 3600,
+		KeyServeDeletedJobSweepSecs:     3600,
+		KeyServeJobRunPollSecs:          300,
+		KeyServeDriftCheckSecs:          6 * 3600,
+		KeyServeLookupCacheTTLSecs:      30,
+		KeyServeSecretBackend:           "postgres",
+		KeyServeSecretVaultMount:        "secret",
 	}, "."), nil); err != nil {
 		return nil, errors.Wrap(err, "k.Load: error loading config defaults")
 	}
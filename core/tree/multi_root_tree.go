@@ -1,6 +1,11 @@
 package tree
 
-import "github.com/pkg/errors"
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
 
 var (
 	// ErrCyclicDependencyEncountered is triggered a tree has a cyclic dependency
@@ -45,13 +50,22 @@ func (t *MultiRootTree) GetNodeByName(dagName string) (*TreeNode, bool) {
 	return value, ok
 }
 
-// IsCyclic - detects if there are any cycles in the tree
+// IsCyclic - detects if there are any cycles in the tree, error contains
+// the cycle as a chain of nodes each followed by its dependent
+// e.g. a → b → c → a
 func (t *MultiRootTree) IsCyclic() error {
+	// nodes are visited in order of names to always report the same cycle
+	var nodeNames []string
+	for name := range t.dataMap {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+
 	visitedMap := make(map[string]bool)
-	for _, node := range t.dataMap {
-		if _, visited := visitedMap[node.GetName()]; !visited {
+	for _, name := range nodeNames {
+		if _, visited := visitedMap[name]; !visited {
 			pathMap := make(map[string]bool)
-			err := t.hasCycle(node, visitedMap, pathMap)
+			err := t.hasCycle(t.dataMap[name], visitedMap, pathMap, nil)
 			if err != nil {
 				return err
 			}
@@ -61,17 +75,18 @@ func (t *MultiRootTree) IsCyclic() error {
 }
 
 // runs a DFS on a given tree using visitor pattern
-func (t *MultiRootTree) hasCycle(root *TreeNode, visited, pathMap map[string]bool) error {
+func (t *MultiRootTree) hasCycle(root *TreeNode, visited, pathMap map[string]bool, path []string) error {
 	_, isNodeVisited := visited[root.GetName()]
 	if !isNodeVisited || !visited[root.GetName()] {
 		pathMap[root.GetName()] = true
 		visited[root.GetName()] = true
+		path = append(path, root.GetName())
 		var cyclicErr error
 		for _, child := range root.Dependents {
 			n, _ := t.GetNodeByName(child.GetName())
 			_, isChildVisited := visited[child.GetName()]
 			if !isChildVisited || !visited[child.GetName()] {
-				cyclicErr = t.hasCycle(n, visited, pathMap)
+				cyclicErr = t.hasCycle(n, visited, pathMap, path)
 			}
 			if cyclicErr != nil {
 				return cyclicErr
@@ -79,7 +94,7 @@ func (t *MultiRootTree) hasCycle(root *TreeNode, visited, pathMap map[string]boo
 
 			_, childAlreadyInPath := pathMap[child.GetName()] // 1 -> 2 -> 1
 			if childAlreadyInPath && pathMap[child.GetName()] {
-				cyclicErr = errors.Wrap(ErrCyclicDependencyEncountered, root.GetName())
+				cyclicErr = errors.Wrap(ErrCyclicDependencyEncountered, cyclePath(path, child.GetName()))
 			}
 			if cyclicErr != nil {
 				return cyclicErr
@@ -90,6 +105,17 @@ func (t *MultiRootTree) hasCycle(root *TreeNode, visited, pathMap map[string]boo
 	return nil
 }
 
+// cyclePath joins the nodes of path starting from the one closing the cycle
+func cyclePath(path []string, closingNode string) string {
+	for idx, name := range path {
+		if name == closingNode {
+			path = path[idx:]
+			break
+		}
+	}
+	return strings.Join(path, " → ") + " → " + closingNode
+}
+
 // NewMultiRootTree returns an instance of multi root dag tree
 func NewMultiRootTree() *MultiRootTree {
 	return &MultiRootTree{
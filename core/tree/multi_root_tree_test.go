@@ -54,6 +54,31 @@ func TestMultiRootDagTree(t *testing.T) {
 			assert.NotNil(t, err)
 			assert.Contains(t, err.Error(), "cycle dependency")
 		})
+		t.Run("should report the nodes of cycle in error", func(t *testing.T) {
+			treeNode1 := tree.NewTreeNode(models.JobSpec{
+				Name: "job1",
+			})
+			treeNode2 := tree.NewTreeNode(models.JobSpec{
+				Name: "job2",
+			})
+			treeNode3 := tree.NewTreeNode(models.JobSpec{
+				Name: "job3",
+			})
+			treeNode4 := tree.NewTreeNode(models.JobSpec{
+				Name: "job4",
+			})
+			multiRootTree := tree.NewMultiRootTree()
+			multiRootTree.AddNode(treeNode1)
+			multiRootTree.AddNode(treeNode2)
+			multiRootTree.AddNode(treeNode3)
+			multiRootTree.AddNode(treeNode4)
+			treeNode1.AddDependent(treeNode2)
+			treeNode2.AddDependent(treeNode3)
+			treeNode3.AddDependent(treeNode4)
+			treeNode4.AddDependent(treeNode2)
+			err := multiRootTree.IsCyclic()
+			assert.EqualError(t, err, "job2 → job3 → job4 → job2: a cycle dependency encountered in the tree")
+		})
 		t.Run("should not return error if not cyclic", func(t *testing.T) {
 			treeNode1 := tree.NewTreeNode(models.JobSpec{
 				Name: "job1",
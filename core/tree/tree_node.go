@@ -29,6 +29,41 @@ func (t *TreeNode) GetAllNodes() []*TreeNode {
 	return allNodes
 }
 
+// GetAllNodesInTopologicalOrder returns each node of tree starting from
+// current node once, after every node it is a dependent of
+func (t *TreeNode) GetAllNodesInTopologicalOrder() []*TreeNode {
+	// count parents of nodes reachable from current node
+	parentCount := map[*TreeNode]int{}
+	visited := map[*TreeNode]bool{t: true}
+	nodesQueue := []*TreeNode{t}
+	for len(nodesQueue) != 0 {
+		topNode := nodesQueue[0]
+		nodesQueue = nodesQueue[1:]
+		for _, dependent := range topNode.Dependents {
+			parentCount[dependent]++
+			if !visited[dependent] {
+				visited[dependent] = true
+				nodesQueue = append(nodesQueue, dependent)
+			}
+		}
+	}
+
+	allNodes := make([]*TreeNode, 0, len(visited))
+	nodesQueue = []*TreeNode{t}
+	for len(nodesQueue) != 0 {
+		topNode := nodesQueue[0]
+		nodesQueue = nodesQueue[1:]
+		allNodes = append(allNodes, topNode)
+		for _, dependent := range topNode.Dependents {
+			parentCount[dependent]--
+			if parentCount[dependent] == 0 {
+				nodesQueue = append(nodesQueue, dependent)
+			}
+		}
+	}
+	return allNodes
+}
+
 func (t *TreeNode) GetName() string {
 	return t.Data.GetName()
 }
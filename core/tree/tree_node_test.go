@@ -50,4 +50,20 @@ func TestDagNode(t *testing.T) {
 		assert.Equal(t, "job-level-1", allNodes[1].Data.GetName())
 		assert.Equal(t, "job-level-2", allNodes[2].Data.GetName())
 	})
+	t.Run("GetAllNodesInTopologicalOrder", func(t *testing.T) {
+		root := tree.NewTreeNode(models.JobSpec{Name: "job-root"})
+		direct := tree.NewTreeNode(models.JobSpec{Name: "job-direct"})
+		indirect := tree.NewTreeNode(models.JobSpec{Name: "job-indirect"})
+		last := tree.NewTreeNode(models.JobSpec{Name: "job-last"})
+		// indirect depends on root and on direct, which comes later in level order
+		root.AddDependent(indirect).AddDependent(direct)
+		direct.AddDependent(indirect)
+		indirect.AddDependent(last)
+
+		var names []string
+		for _, node := range root.GetAllNodesInTopologicalOrder() {
+			names = append(names, node.GetName())
+		}
+		assert.Equal(t, []string{"job-root", "job-direct", "job-indirect", "job-last"}, names)
+	})
 }
@@ -0,0 +1,198 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/utils"
+	"github.com/pkg/errors"
+)
+
+type BackupRepoFactory interface {
+	New(project models.ProjectSpec) store.BackupRepository
+}
+
+// BackupService backs up resources of datastores, along with the
+// resources downstream of them, and restores them from recorded backups
+type BackupService struct {
+	resourceRepoFactory        ResourceSpecRepoFactory
+	projectResourceRepoFactory ProjectResourceSpecRepoFactory
+	backupRepoFactory          BackupRepoFactory
+	dsRepo                     models.DatastoreRepo
+	uuidProvider               utils.UUIDProvider
+
+	// Lineage resolves resources written by jobs reading backed up ones,
+	// only the requested resource is backed up if not set
+	Lineage store.JobLineageRepository
+	Now     func() time.Time
+}
+
+// Backup backs up resource of request and, unless downstream is ignored,
+// the resources of project written by jobs reading it transitively.
+// Downstream resources datastore can't back up, e.g. views, are ignored
+func (srv *BackupService) Backup(ctx context.Context, request models.BackupRequest) (models.BackupResponse, error) {
+	ds, err := srv.dsRepo.GetByName(request.Datastore)
+	if err != nil {
+		return models.BackupResponse{}, err
+	}
+	resourceSpec, err := srv.resourceRepoFactory.New(request.Namespace, ds).GetByName(request.ResourceName)
+	if err != nil {
+		return models.BackupResponse{}, err
+	}
+
+	resourceSpecs := []models.ResourceSpec{resourceSpec}
+	var ignored []string
+	if !request.IgnoreDownstream && srv.Lineage != nil {
+		downstream, unknown, err := srv.downstream(request.Namespace.ProjectSpec, ds, resourceSpec)
+		if err != nil {
+			return models.BackupResponse{}, err
+		}
+		resourceSpecs = append(resourceSpecs, downstream...)
+		ignored = append(ignored, unknown...)
+	}
+
+	id, err := srv.uuidProvider.NewUUID()
+	if err != nil {
+		return models.BackupResponse{}, err
+	}
+	backup := models.BackupSpec{
+		ID:           id,
+		ResourceName: resourceSpec.Name,
+		Datastore:    ds.Name(),
+		Description:  request.Description,
+		Config:       request.Config,
+		CreatedAt:    srv.Now().UTC(),
+	}
+	if request.DryRun {
+		for _, spec := range resourceSpecs {
+			backup.Results = append(backup.Results, models.BackupResult{ResourceName: spec.Name})
+		}
+		return models.BackupResponse{Backup: backup, IgnoredResources: ignored}, nil
+	}
+
+	for i, spec := range resourceSpecs {
+		resp, err := ds.BackupResource(ctx, models.BackupResourceRequest{
+			Resource:   spec,
+			Project:    request.Namespace.ProjectSpec,
+			Backup:     backup,
+			BackupTime: backup.CreatedAt,
+		})
+		if err != nil {
+			if i > 0 && errors.Is(err, models.ErrUnsupportedResource) {
+				ignored = append(ignored, spec.Name)
+				continue
+			}
+			return models.BackupResponse{}, errors.Wrapf(err, "failed to backup %s", spec.Name)
+		}
+		backup.Results = append(backup.Results, models.BackupResult{
+			ResourceName: spec.Name,
+			URN:          resp.URN,
+		})
+	}
+	if err := srv.backupRepoFactory.New(request.Namespace.ProjectSpec).Save(backup); err != nil {
+		return models.BackupResponse{}, errors.Wrap(err, "failed to record backup")
+	}
+	return models.BackupResponse{Backup: backup, IgnoredResources: ignored}, nil
+}
+
+// downstream walks lineage from resource to the resources written by
+// jobs reading it, returning the ones of project and the urns of the rest
+func (srv *BackupService) downstream(project models.ProjectSpec, ds models.Datastorer, resourceSpec models.ResourceSpec) ([]models.ResourceSpec, []string, error) {
+	projectSpecs, err := srv.projectResourceRepoFactory.New(project, ds).GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	specByURN := map[string]models.ResourceSpec{}
+	for _, spec := range projectSpecs {
+		if urn, err := ds.ResourceURN(spec); err == nil {
+			specByURN[urn] = spec
+		}
+	}
+	rootURN, err := ds.ResourceURN(resourceSpec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resourceSpecs []models.ResourceSpec
+	var unknown []string
+	visited := map[string]bool{rootURN: true}
+	queue := []string{rootURN}
+	for len(queue) > 0 {
+		urn := queue[0]
+		queue = queue[1:]
+		lineages, err := srv.Lineage.GetByDependency(urn)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read jobs reading %s", urn)
+		}
+		for _, lineage := range lineages {
+			if lineage.Destination == "" || visited[lineage.Destination] {
+				continue
+			}
+			visited[lineage.Destination] = true
+			queue = append(queue, lineage.Destination)
+			if spec, ok := specByURN[lineage.Destination]; ok {
+				resourceSpecs = append(resourceSpecs, spec)
+			} else {
+				unknown = append(unknown, lineage.Destination)
+			}
+		}
+	}
+	return resourceSpecs, unknown, nil
+}
+
+// List returns backups of resources of datastore in project of namespace,
+// latest first
+func (srv *BackupService) List(ctx context.Context, namespace models.NamespaceSpec, datastoreName string) ([]models.BackupSpec, error) {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return nil, err
+	}
+	return srv.backupRepoFactory.New(namespace.ProjectSpec).GetAll(ds.Name())
+}
+
+// Restore overwrites the resources of backup with their copies
+func (srv *BackupService) Restore(ctx context.Context, namespace models.NamespaceSpec, datastoreName string, id uuid.UUID) (models.BackupSpec, error) {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return models.BackupSpec{}, err
+	}
+	backup, err := srv.backupRepoFactory.New(namespace.ProjectSpec).GetByID(id)
+	if err != nil {
+		return models.BackupSpec{}, err
+	}
+	if backup.Datastore != ds.Name() {
+		return models.BackupSpec{}, fmt.Errorf("backup %s is not of datastore %s", id, ds.Name())
+	}
+
+	projectResourceRepo := srv.projectResourceRepoFactory.New(namespace.ProjectSpec, ds)
+	for _, result := range backup.Results {
+		resourceSpec, _, err := projectResourceRepo.GetByName(result.ResourceName)
+		if err != nil {
+			return models.BackupSpec{}, errors.Wrapf(err, "failed to read resource %s", result.ResourceName)
+		}
+		if err := ds.RestoreResource(ctx, models.RestoreResourceRequest{
+			Resource: resourceSpec,
+			Project:  namespace.ProjectSpec,
+			URN:      result.URN,
+		}); err != nil {
+			return models.BackupSpec{}, errors.Wrapf(err, "failed to restore %s", result.ResourceName)
+		}
+	}
+	return backup, nil
+}
+
+func NewBackupService(resourceRepoFactory ResourceSpecRepoFactory, projectResourceRepoFactory ProjectResourceSpecRepoFactory,
+	backupRepoFactory BackupRepoFactory, dsRepo models.DatastoreRepo, uuidProvider utils.UUIDProvider) *BackupService {
+	return &BackupService{
+		resourceRepoFactory:        resourceRepoFactory,
+		projectResourceRepoFactory: projectResourceRepoFactory,
+		backupRepoFactory:          backupRepoFactory,
+		dsRepo:                     dsRepo,
+		uuidProvider:               uuidProvider,
+		Now:                        time.Now,
+	}
+}
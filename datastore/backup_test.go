@@ -0,0 +1,208 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/datastore"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store/memory"
+)
+
+func TestBackupService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	backupID := uuid.Must(uuid.NewRandom())
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "a-data-project",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	orders := models.ResourceSpec{Name: "proj.sales.orders", Type: models.ResourceTypeTable}
+	dailyOrders := models.ResourceSpec{Name: "proj.sales.daily_orders", Type: models.ResourceTypeTable}
+	ordersView := models.ResourceSpec{Name: "proj.sales.orders_view", Type: models.ResourceTypeView}
+
+	newDatastorer := func() *mock.Datastorer {
+		datastorer := new(mock.Datastorer)
+		datastorer.On("Name").Return("bq")
+		return datastorer
+	}
+	// expectURNs sets urns of resources, orders is read by jobs writing
+	// daily orders, orders view and, through daily orders, a table of
+	// another project
+	expectURNs := func(datastorer *mock.Datastorer) {
+		datastorer.On("ResourceURN", orders).Return("bigquery://proj:sales.orders", nil)
+		datastorer.On("ResourceURN", dailyOrders).Return("bigquery://proj:sales.daily_orders", nil)
+		datastorer.On("ResourceURN", ordersView).Return("bigquery://proj:sales.orders_view", nil)
+	}
+	newLineage := func() *memory.DB {
+		db := memory.NewDB()
+		repo := memory.NewJobLineageRepository(db)
+		for _, lineage := range []models.JobLineage{
+			{ProjectName: projectSpec.Name, JobName: "daily-orders", Destination: "bigquery://proj:sales.daily_orders", Dependencies: []string{"bigquery://proj:sales.orders"}},
+			{ProjectName: projectSpec.Name, JobName: "orders-view", Destination: "bigquery://proj:sales.orders_view", Dependencies: []string{"bigquery://proj:sales.orders"}},
+			{ProjectName: "other-project", JobName: "report", Destination: "bigquery://other:reports.sales", Dependencies: []string{"bigquery://proj:sales.daily_orders"}},
+		} {
+			assert.Nil(t, repo.Save(lineage))
+		}
+		return db
+	}
+	newService := func(datastorer *mock.Datastorer, backupRepo *mock.BackupRepository) *datastore.BackupService {
+		dsRepo := new(mock.SupportedDatastoreRepo)
+		dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+
+		resourceRepo := new(mock.ResourceSpecRepository)
+		resourceRepo.On("GetByName", orders.Name).Return(orders, nil)
+		resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+		resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+
+		projectResourceRepo := new(mock.ProjectResourceSpecRepository)
+		projectResourceRepo.On("GetAll").Return([]models.ResourceSpec{orders, dailyOrders, ordersView}, nil)
+		projectResourceRepo.On("GetByName", orders.Name).Return(orders, namespaceSpec, nil)
+		projectResourceRepo.On("GetByName", dailyOrders.Name).Return(dailyOrders, namespaceSpec, nil)
+		projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
+		projectResourceRepoFac.On("New", projectSpec, datastorer).Return(projectResourceRepo)
+
+		backupRepoFac := new(mock.BackupRepoFactory)
+		backupRepoFac.On("New", projectSpec).Return(backupRepo)
+
+		uuidProvider := new(mock.UUIDProvider)
+		uuidProvider.On("NewUUID").Return(backupID, nil)
+
+		service := datastore.NewBackupService(resourceRepoFac, projectResourceRepoFac, backupRepoFac, dsRepo, uuidProvider)
+		service.Lineage = memory.NewJobLineageRepository(newLineage())
+		service.Now = func() time.Time { return now }
+		return service
+	}
+	newBackupRequest := func(spec models.ResourceSpec) models.BackupResourceRequest {
+		return models.BackupResourceRequest{
+			Resource: spec,
+			Project:  projectSpec,
+			Backup: models.BackupSpec{
+				ID:           backupID,
+				ResourceName: orders.Name,
+				Datastore:    "bq",
+				Description:  "before replay",
+				CreatedAt:    now,
+			},
+			BackupTime: now,
+		}
+	}
+
+	t.Run("Backup", func(t *testing.T) {
+		t.Run("should backup resource and the tables downstream of it", func(t *testing.T) {
+			datastorer := newDatastorer()
+			defer datastorer.AssertExpectations(t)
+			expectURNs(datastorer)
+			datastorer.On("BackupResource", ctx, newBackupRequest(orders)).Return(models.BackupResourceResponse{URN: "bigquery://proj:optimus_backup.orders"}, nil)
+			datastorer.On("BackupResource", ctx, newBackupRequest(dailyOrders)).Return(models.BackupResourceResponse{URN: "bigquery://proj:optimus_backup.daily_orders"}, nil)
+			datastorer.On("BackupResource", ctx, newBackupRequest(ordersView)).Return(models.BackupResourceResponse{}, errors.Wrap(models.ErrUnsupportedResource, "view"))
+
+			expected := models.BackupSpec{
+				ID:           backupID,
+				ResourceName: orders.Name,
+				Datastore:    "bq",
+				Description:  "before replay",
+				Results: []models.BackupResult{
+					{ResourceName: orders.Name, URN: "bigquery://proj:optimus_backup.orders"},
+					{ResourceName: dailyOrders.Name, URN: "bigquery://proj:optimus_backup.daily_orders"},
+				},
+				CreatedAt: now,
+			}
+			backupRepo := new(mock.BackupRepository)
+			defer backupRepo.AssertExpectations(t)
+			backupRepo.On("Save", expected).Return(nil)
+
+			resp, err := newService(datastorer, backupRepo).Backup(ctx, models.BackupRequest{
+				ResourceName: orders.Name,
+				Namespace:    namespaceSpec,
+				Datastore:    "bq",
+				Description:  "before replay",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, expected, resp.Backup)
+			assert.ElementsMatch(t, []string{"bigquery://other:reports.sales", ordersView.Name}, resp.IgnoredResources)
+		})
+		t.Run("should only resolve resources to be backed up on dry run", func(t *testing.T) {
+			datastorer := newDatastorer()
+			expectURNs(datastorer)
+			backupRepo := new(mock.BackupRepository)
+			defer backupRepo.AssertExpectations(t)
+
+			resp, err := newService(datastorer, backupRepo).Backup(ctx, models.BackupRequest{
+				ResourceName: orders.Name,
+				Namespace:    namespaceSpec,
+				Datastore:    "bq",
+				DryRun:       true,
+			})
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, []models.BackupResult{
+				{ResourceName: orders.Name},
+				{ResourceName: dailyOrders.Name},
+				{ResourceName: ordersView.Name},
+			}, resp.Backup.Results)
+		})
+		t.Run("should fail if resource can't be backed up", func(t *testing.T) {
+			datastorer := newDatastorer()
+			datastorer.On("BackupResource", ctx, newBackupRequest(orders)).Return(models.BackupResourceResponse{}, models.ErrUnsupportedResource)
+			backupRepo := new(mock.BackupRepository)
+			defer backupRepo.AssertExpectations(t)
+
+			_, err := newService(datastorer, backupRepo).Backup(ctx, models.BackupRequest{
+				ResourceName:     orders.Name,
+				Namespace:        namespaceSpec,
+				Datastore:        "bq",
+				IgnoreDownstream: true,
+			})
+			assert.True(t, errors.Is(err, models.ErrUnsupportedResource))
+		})
+	})
+	t.Run("Restore", func(t *testing.T) {
+		backup := models.BackupSpec{
+			ID:           backupID,
+			ResourceName: orders.Name,
+			Datastore:    "bq",
+			Results: []models.BackupResult{
+				{ResourceName: orders.Name, URN: "bigquery://proj:optimus_backup.orders"},
+				{ResourceName: dailyOrders.Name, URN: "bigquery://proj:optimus_backup.daily_orders"},
+			},
+		}
+		t.Run("should restore resources of backup", func(t *testing.T) {
+			datastorer := newDatastorer()
+			defer datastorer.AssertExpectations(t)
+			for i, spec := range []models.ResourceSpec{orders, dailyOrders} {
+				datastorer.On("RestoreResource", ctx, models.RestoreResourceRequest{
+					Resource: spec,
+					Project:  projectSpec,
+					URN:      backup.Results[i].URN,
+				}).Return(nil)
+			}
+			backupRepo := new(mock.BackupRepository)
+			backupRepo.On("GetByID", backupID).Return(backup, nil)
+
+			restored, err := newService(datastorer, backupRepo).Restore(ctx, namespaceSpec, "bq", backupID)
+			assert.Nil(t, err)
+			assert.Equal(t, backup, restored)
+		})
+		t.Run("should fail if backup is of another datastore", func(t *testing.T) {
+			datastorer := newDatastorer()
+			backupRepo := new(mock.BackupRepository)
+			other := backup
+			other.Datastore = "gcs"
+			backupRepo.On("GetByID", backupID).Return(other, nil)
+
+			_, err := newService(datastorer, backupRepo).Restore(ctx, namespaceSpec, "bq", backupID)
+			assert.NotNil(t, err)
+		})
+	})
+}
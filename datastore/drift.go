@@ -0,0 +1,223 @@
+package datastore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type ProjectRepoFactory interface {
+	New() store.ProjectRepository
+}
+
+type NamespaceRepoFactory interface {
+	New(spec models.ProjectSpec) store.NamespaceRepository
+}
+
+// DriftStat is the number of resources of a datastore found drifted by
+// the latest check
+type DriftStat struct {
+	Checked      int64 `json:"checked"`
+	Drifted      int64 `json:"drifted"`
+	Incompatible int64 `json:"incompatible"`
+	Failed       int64 `json:"failed"`
+}
+
+// DriftStats keeps stats of the latest check keyed by datastore, stats are
+// exported as json through expvar
+type DriftStats struct {
+	mu    sync.Mutex
+	stats map[string]DriftStat
+}
+
+// Get returns stats of datastore
+func (s *DriftStats) Get(datastoreName string) DriftStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats[datastoreName]
+}
+
+func (s *DriftStats) set(stats map[string]DriftStat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = stats
+}
+
+// String renders stats as json, it makes DriftStats an expvar.Var
+func (s *DriftStats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := json.Marshal(s.stats)
+	if err != nil {
+		return "{}"
+	}
+	return string(raw)
+}
+
+func NewDriftStats() *DriftStats {
+	return &DriftStats{
+		stats: map[string]DriftStat{},
+	}
+}
+
+// DriftChecker periodically compares resources of every namespace with the
+// ones living in their datastores, and reports the drifted resources
+type DriftChecker struct {
+	wg   sync.WaitGroup
+	done chan struct{}
+
+	projectRepoFactory   ProjectRepoFactory
+	namespaceRepoFactory NamespaceRepoFactory
+	resourceRepoFactory  ResourceSpecRepoFactory
+	dsRepo               models.DatastoreRepo
+	progressObserver     progress.Observer
+
+	interval time.Duration
+
+	Stats *DriftStats
+}
+
+// Check checks resources of every namespace, resources datastores can't
+// check are skipped
+func (c *DriftChecker) Check(ctx context.Context) error {
+	projects, err := c.projectRepoFactory.New().GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch projects")
+	}
+
+	stats := map[string]DriftStat{}
+	var checkErrors error
+	for _, projectSpec := range projects {
+		namespaces, err := c.namespaceRepoFactory.New(projectSpec).GetAll()
+		if err != nil {
+			checkErrors = multierror.Append(checkErrors, errors.Wrapf(err, "failed to fetch namespaces of %s", projectSpec.Name))
+			continue
+		}
+		for _, namespace := range namespaces {
+			for _, ds := range c.dsRepo.GetAll() {
+				stat := stats[ds.Name()]
+				if err := c.checkNamespace(ctx, namespace, ds, &stat); err != nil {
+					checkErrors = multierror.Append(checkErrors, errors.Wrapf(err, "failed to check %s resources of %s/%s",
+						ds.Name(), projectSpec.Name, namespace.Name))
+				}
+				stats[ds.Name()] = stat
+			}
+		}
+	}
+	c.Stats.set(stats)
+	return checkErrors
+}
+
+func (c *DriftChecker) checkNamespace(ctx context.Context, namespace models.NamespaceSpec, ds models.Datastorer, stat *DriftStat) error {
+	resourceSpecs, err := c.resourceRepoFactory.New(namespace, ds).GetAll()
+	if err != nil {
+		return err
+	}
+
+	var checkErrors error
+	for _, resourceSpec := range resourceSpecs {
+		resp, err := ds.CheckResource(ctx, models.CheckResourceRequest{
+			Resource: resourceSpec,
+			Project:  namespace.ProjectSpec,
+		})
+		if err != nil {
+			if errors.Is(err, models.ErrUnsupportedResource) {
+				continue
+			}
+			stat.Failed++
+			checkErrors = multierror.Append(checkErrors, errors.Wrapf(err, "failed to check %s", resourceSpec.Name))
+			continue
+		}
+
+		stat.Checked++
+		if len(resp.Drifts) == 0 {
+			continue
+		}
+		stat.Drifted++
+		if resp.Incompatible() {
+			stat.Incompatible++
+		}
+		c.progressObserver.Notify(&EventResourceDrifted{
+			Namespace: namespace,
+			Spec:      resourceSpec,
+			Drifts:    resp.Drifts,
+		})
+	}
+	return checkErrors
+}
+
+func (c *DriftChecker) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.Check(context.Background()); err != nil {
+				logger.E(errors.Wrap(err, "failed to check resource drift"))
+			}
+		}
+	}
+}
+
+// Close stops checking and waits for a running check to finish
+func (c *DriftChecker) Close() error {
+	close(c.done)
+	c.wg.Wait()
+	return nil
+}
+
+// NewDriftChecker constructs a checker reporting drifted resources to
+// progressObserver, checks run every interval and are not scheduled if it
+// is not set
+func NewDriftChecker(projectRepoFactory ProjectRepoFactory, namespaceRepoFactory NamespaceRepoFactory,
+	resourceRepoFactory ResourceSpecRepoFactory, dsRepo models.DatastoreRepo, progressObserver progress.Observer,
+	interval time.Duration) *DriftChecker {
+	checker := &DriftChecker{
+		done:                 make(chan struct{}),
+		projectRepoFactory:   projectRepoFactory,
+		namespaceRepoFactory: namespaceRepoFactory,
+		resourceRepoFactory:  resourceRepoFactory,
+		dsRepo:               dsRepo,
+		progressObserver:     progressObserver,
+		interval:             interval,
+		Stats:                NewDriftStats(),
+	}
+	if interval > 0 {
+		checker.wg.Add(1)
+		go checker.run()
+	}
+	return checker
+}
+
+// EventResourceDrifted represents a resource whose live state differs from
+// its spec
+type EventResourceDrifted struct {
+	Namespace models.NamespaceSpec
+	Spec      models.ResourceSpec
+	Drifts    []models.ResourceDrift
+}
+
+func (e *EventResourceDrifted) String() string {
+	incompatible := 0
+	for _, drift := range e.Drifts {
+		if drift.Incompatible {
+			incompatible++
+		}
+	}
+	return fmt.Sprintf("drifted: %s of %s/%s, %d fields differ from spec and %d can't be deployed",
+		e.Spec.Name, e.Namespace.ProjectSpec.Name, e.Namespace.Name, len(e.Drifts), incompatible)
+}
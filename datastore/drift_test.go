@@ -0,0 +1,104 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/datastore"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriftChecker(t *testing.T) {
+	ctx := context.Background()
+	projectSpec := models.ProjectSpec{
+		Name: "proj",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+
+	newRepoFactories := func() (*mock.ProjectRepoFactory, *mock.NamespaceRepoFactory) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetAll").Return([]models.ProjectSpec{projectSpec}, nil)
+		projectRepoFac := new(mock.ProjectRepoFactory)
+		projectRepoFac.On("New").Return(projectRepo)
+
+		namespaceRepo := new(mock.NamespaceRepository)
+		namespaceRepo.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+		namespaceRepoFac := new(mock.NamespaceRepoFactory)
+		namespaceRepoFac.On("New", projectSpec).Return(namespaceRepo)
+		return projectRepoFac, namespaceRepoFac
+	}
+
+	t.Run("Check", func(t *testing.T) {
+		t.Run("should report drifted resources and keep stats of the check", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+			datastorer := new(mock.Datastorer)
+			defer datastorer.AssertExpectations(t)
+			datastorer.On("Name").Return("bq")
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetAll").Return([]models.Datastorer{datastorer})
+
+			driftedSpec := models.ResourceSpec{Name: "proj.datas.drifted", Type: models.ResourceTypeTable, Datastore: datastorer}
+			syncedSpec := models.ResourceSpec{Name: "proj.datas.synced", Type: models.ResourceTypeTable, Datastore: datastorer}
+			viewSpec := models.ResourceSpec{Name: "proj.datas.view", Type: models.ResourceTypeView, Datastore: datastorer}
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetAll").Return([]models.ResourceSpec{driftedSpec, syncedSpec, viewSpec}, nil)
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+
+			drifts := []models.ResourceDrift{{Field: "schema.id.type", Declared: "STRING", Live: "INT64", Incompatible: true}}
+			datastorer.On("CheckResource", ctx, models.CheckResourceRequest{Resource: driftedSpec, Project: projectSpec}).
+				Return(models.CheckResourceResponse{Drifts: drifts}, nil)
+			datastorer.On("CheckResource", ctx, models.CheckResourceRequest{Resource: syncedSpec, Project: projectSpec}).
+				Return(models.CheckResourceResponse{}, nil)
+			datastorer.On("CheckResource", ctx, models.CheckResourceRequest{Resource: viewSpec, Project: projectSpec}).
+				Return(models.CheckResourceResponse{}, models.ErrUnsupportedResource)
+
+			obs := new(mock.PipelineLogObserver)
+			defer obs.AssertExpectations(t)
+			obs.On("Notify", &datastore.EventResourceDrifted{
+				Namespace: namespaceSpec,
+				Spec:      driftedSpec,
+				Drifts:    drifts,
+			}).Return()
+
+			checker := datastore.NewDriftChecker(projectRepoFac, namespaceRepoFac, resourceRepoFac, dsRepo, obs, 0)
+			defer checker.Close()
+			err := checker.Check(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, datastore.DriftStat{Checked: 2, Drifted: 1, Incompatible: 1}, checker.Stats.Get("bq"))
+		})
+		t.Run("should keep checking other resources if one fails", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq")
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetAll").Return([]models.Datastorer{datastorer})
+
+			failingSpec := models.ResourceSpec{Name: "proj.datas.failing", Type: models.ResourceTypeTable, Datastore: datastorer}
+			syncedSpec := models.ResourceSpec{Name: "proj.datas.synced", Type: models.ResourceTypeTable, Datastore: datastorer}
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetAll").Return([]models.ResourceSpec{failingSpec, syncedSpec}, nil)
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+
+			datastorer.On("CheckResource", ctx, models.CheckResourceRequest{Resource: failingSpec, Project: projectSpec}).
+				Return(models.CheckResourceResponse{}, errors.New("permission denied"))
+			datastorer.On("CheckResource", ctx, models.CheckResourceRequest{Resource: syncedSpec, Project: projectSpec}).
+				Return(models.CheckResourceResponse{}, nil)
+
+			checker := datastore.NewDriftChecker(projectRepoFac, namespaceRepoFac, resourceRepoFac, dsRepo, new(mock.PipelineLogObserver), 0)
+			defer checker.Close()
+			err := checker.Check(ctx)
+			assert.NotNil(t, err)
+			assert.Equal(t, datastore.DriftStat{Checked: 1, Failed: 1}, checker.Stats.Get("bq"))
+		})
+	})
+}
@@ -3,8 +3,10 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/odpf/optimus/core/progress"
+	"github.com/pkg/errors"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/kushsharma/parallel"
@@ -30,6 +32,14 @@ type ProjectResourceSpecRepoFactory interface {
 type Service struct {
 	resourceRepoFactory ResourceSpecRepoFactory
 	dsRepo              models.DatastoreRepo
+
+	// Metadata publishes metadata of resources as they are deployed and
+	// deleted, publishing is disabled if not set
+	Metadata models.ResourceMetadataService
+
+	// FailOnIncompatibleDrift fails updates of resources whose live state
+	// drifted from the spec in a way deploying it can't reconcile
+	FailOnIncompatibleDrift bool
 }
 
 func (srv Service) GetAll(namespace models.NamespaceSpec, datastoreName string) ([]models.ResourceSpec, error) {
@@ -58,15 +68,24 @@ func (srv Service) CreateResource(ctx context.Context, namespace models.Namespac
 				Spec: currentSpec,
 				Err:  err,
 			})
-			return nil, err
+			if err != nil {
+				return nil, err
+			}
+			return currentSpec, nil
 		})
 	}
 
 	var errorSet error
+	var deployedSpecs []models.ResourceSpec
 	for _, result := range runner.Run() {
 		if result.Err != nil {
 			errorSet = multierror.Append(errorSet, result.Err)
+			continue
 		}
+		deployedSpecs = append(deployedSpecs, result.Val.(models.ResourceSpec))
+	}
+	if err := srv.publishMetadata(namespace, deployedSpecs, obs); err != nil {
+		errorSet = multierror.Append(errorSet, err)
 	}
 	return errorSet
 }
@@ -81,6 +100,14 @@ func (srv Service) UpdateResource(ctx context.Context, namespace models.Namespac
 				return nil, err
 			}
 
+			if err := srv.checkDrift(ctx, namespace, currentSpec); err != nil {
+				srv.notifyProgress(obs, &EventResourceUpdated{
+					Spec: currentSpec,
+					Err:  err,
+				})
+				return nil, err
+			}
+
 			err := currentSpec.Datastore.UpdateResource(ctx, models.UpdateResourceRequest{
 				Resource: currentSpec,
 				Project:  namespace.ProjectSpec,
@@ -89,15 +116,24 @@ func (srv Service) UpdateResource(ctx context.Context, namespace models.Namespac
 				Spec: currentSpec,
 				Err:  err,
 			})
-			return nil, err
+			if err != nil {
+				return nil, err
+			}
+			return currentSpec, nil
 		})
 	}
 
 	var errorSet error
+	var deployedSpecs []models.ResourceSpec
 	for _, result := range runner.Run() {
 		if result.Err != nil {
 			errorSet = multierror.Append(errorSet, result.Err)
+			continue
 		}
+		deployedSpecs = append(deployedSpecs, result.Val.(models.ResourceSpec))
+	}
+	if err := srv.publishMetadata(namespace, deployedSpecs, obs); err != nil {
+		errorSet = multierror.Append(errorSet, err)
 	}
 	return errorSet
 }
@@ -142,7 +178,64 @@ func (srv Service) DeleteResource(ctx context.Context, namespace models.Namespac
 		return err
 	}
 
-	return repo.Delete(name)
+	if err := repo.Delete(name); err != nil {
+		return err
+	}
+	if srv.Metadata == nil {
+		return nil
+	}
+	return srv.Metadata.Tombstone(namespace, []models.ResourceSpec{resourceSpec}, nil)
+}
+
+func (srv Service) CheckResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string) (models.CheckResourceResponse, error) {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return models.CheckResourceResponse{}, err
+	}
+	resourceSpec, err := srv.resourceRepoFactory.New(namespace, ds).GetByName(name)
+	if err != nil {
+		return models.CheckResourceResponse{}, err
+	}
+	return resourceSpec.Datastore.CheckResource(ctx, models.CheckResourceRequest{
+		Resource: resourceSpec,
+		Project:  namespace.ProjectSpec,
+	})
+}
+
+// checkDrift fails if the resource about to be updated drifted incompatibly
+// from its spec, resources which can't be checked are updated as is
+func (srv Service) checkDrift(ctx context.Context, namespace models.NamespaceSpec, resourceSpec models.ResourceSpec) error {
+	if !srv.FailOnIncompatibleDrift {
+		return nil
+	}
+	resp, err := resourceSpec.Datastore.CheckResource(ctx, models.CheckResourceRequest{
+		Resource: resourceSpec,
+		Project:  namespace.ProjectSpec,
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrUnsupportedResource) {
+			return nil
+		}
+		return err
+	}
+	if !resp.Incompatible() {
+		return nil
+	}
+	var fields []string
+	for _, drift := range resp.Drifts {
+		if drift.Incompatible {
+			fields = append(fields, drift.Field)
+		}
+	}
+	return errors.Wrap(models.ErrIncompatibleDrift, strings.Join(fields, ", "))
+}
+
+// publishMetadata publishes metadata of resources deployed to datastores
+func (srv Service) publishMetadata(namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec, obs progress.Observer) error {
+	if srv.Metadata == nil || len(resourceSpecs) == 0 {
+		return nil
+	}
+	return srv.Metadata.Publish(namespace, resourceSpecs, obs)
 }
 
 func (srv *Service) notifyProgress(po progress.Observer, event progress.Event) {
@@ -149,6 +149,53 @@ func TestService(t *testing.T) {
 			err := service.CreateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
 			assert.NotNil(t, err)
 		})
+		t.Run("should publish metadata of the resources created in datastore", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			resourceSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.batas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			datastorer.On("CreateResource", context.TODO(), models.CreateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec1,
+			}).Return(nil)
+			datastorer.On("CreateResource", context.TODO(), models.CreateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec2,
+			}).Return(errors.New("dataset already exists"))
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec1).Return(nil)
+			resourceRepo.On("Save", resourceSpec2).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			metaSvc := new(mock.ResourceMetaService)
+			metaSvc.On("Publish", namespaceSpec, []models.ResourceSpec{resourceSpec1}, nil).Return(nil)
+			defer metaSvc.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, dsRepo)
+			service.Metadata = metaSvc
+			err := service.CreateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "dataset already exists")
+		})
 	})
 	t.Run("UpdateResource", func(t *testing.T) {
 		t.Run("should successfully call datastore update resource individually for reach resource and save in persistent repository", func(t *testing.T) {
@@ -235,6 +282,81 @@ func TestService(t *testing.T) {
 			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
 			assert.NotNil(t, err)
 		})
+		t.Run("should not update resource drifted incompatibly if drift fails updates", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.table",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			datastorer.On("CheckResource", context.TODO(), models.CheckResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec1,
+			}).Return(models.CheckResourceResponse{
+				Drifts: []models.ResourceDrift{
+					{Field: "schema.note.description", Declared: "free text"},
+					{Field: "schema.id.type", Declared: "STRING", Live: "INT64", Incompatible: true},
+				},
+			}, nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec1).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, dsRepo)
+			service.FailOnIncompatibleDrift = true
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1}, nil)
+			assert.True(t, errors.Is(err, models.ErrIncompatibleDrift))
+			assert.Contains(t, err.Error(), "schema.id.type")
+		})
+	})
+	t.Run("CheckResource", func(t *testing.T) {
+		t.Run("should compare resource of persistent repository with the one in datastore", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.table",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			checkResp := models.CheckResourceResponse{
+				Drifts: []models.ResourceDrift{{Field: "schema.added", Declared: "STRING nullable"}},
+			}
+			datastorer.On("CheckResource", context.TODO(), models.CheckResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec1,
+			}).Return(checkResp, nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, dsRepo)
+			resp, err := service.CheckResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, checkResp, resp)
+			assert.False(t, resp.Incompatible())
+		})
 	})
 	t.Run("ReadResource", func(t *testing.T) {
 		t.Run("should successfully call datastore read operation by reading from persistent repository", func(t *testing.T) {
@@ -339,6 +461,43 @@ func TestService(t *testing.T) {
 			err := service.DeleteResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
 			assert.Nil(t, err)
 		})
+		t.Run("should publish tombstone of metadata of the deleted resource", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			datastorer.On("DeleteResource", context.TODO(), models.DeleteResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec1,
+			}).Return(nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
+			resourceRepo.On("Delete", resourceSpec1.Name).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			metaSvc := new(mock.ResourceMetaService)
+			metaSvc.On("Tombstone", namespaceSpec, []models.ResourceSpec{resourceSpec1}, nil).Return(nil)
+			defer metaSvc.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, dsRepo)
+			service.Metadata = metaSvc
+			err := service.DeleteResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
+			assert.Nil(t, err)
+		})
 		t.Run("should not call delete in datastore if failed to delete from repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
 			defer datastorer.AssertExpectations(t)
@@ -30,6 +30,8 @@ module.exports = {
         "guides/create-bigquery-dataset",
         "guides/create-bigquery-table",
         "guides/create-bigquery-view",
+        "guides/create-bigquery-external-table",
+        "guides/backup-bigquery-resources",
         "guides/organising-specifications",
         "guides/optimus-serve",
         "guides/task-bq2bq"
@@ -0,0 +1,108 @@
+package datahub
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// orchestrator jobs are reported as in urns of data flows
+	orchestrator = "optimus"
+
+	entityTypeDataFlow = "dataFlow"
+	entityTypeDataJob  = "dataJob"
+
+	changeTypeUpsert = "UPSERT"
+
+	aspectContentType = "application/json"
+
+	ownershipTypeDataOwner = "DATAOWNER"
+
+	// actor aspects are last modified by
+	actorUrn = "urn:li:corpuser:optimus"
+)
+
+// MetadataChangeProposal upserts a single aspect of an entity
+type MetadataChangeProposal struct {
+	EntityType string        `json:"entityType"`
+	EntityUrn  string        `json:"entityUrn"`
+	ChangeType string        `json:"changeType"`
+	AspectName string        `json:"aspectName"`
+	Aspect     GenericAspect `json:"aspect"`
+}
+
+// GenericAspect carries an aspect serialized as json in value
+type GenericAspect struct {
+	Value       string `json:"value"`
+	ContentType string `json:"contentType"`
+}
+
+type DataFlowInfo struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description,omitempty"`
+	Project          string            `json:"project,omitempty"`
+	CustomProperties map[string]string `json:"customProperties"`
+}
+
+type DataJobInfo struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description,omitempty"`
+	Type             map[string]string `json:"type"`
+	CustomProperties map[string]string `json:"customProperties"`
+}
+
+// DataJobInputOutput is the lineage of a job, datasets it reads and writes
+// and upstream jobs it depends on
+type DataJobInputOutput struct {
+	InputDatasets  []string `json:"inputDatasets"`
+	OutputDatasets []string `json:"outputDatasets"`
+	InputDatajobs  []string `json:"inputDatajobs,omitempty"`
+}
+
+type Ownership struct {
+	Owners       []Owner    `json:"owners"`
+	LastModified AuditStamp `json:"lastModified"`
+}
+
+type Owner struct {
+	Owner string `json:"owner"`
+	Type  string `json:"type"`
+}
+
+type AuditStamp struct {
+	Time  int64  `json:"time"`
+	Actor string `json:"actor"`
+}
+
+// Status soft deletes an entity when removed is set
+type Status struct {
+	Removed bool `json:"removed"`
+}
+
+// DataFlowUrn identifies the flow of a job, each job of optimus is
+// scheduled on its own so it is a flow of a single data job
+func DataFlowUrn(project, job, cluster string) string {
+	return fmt.Sprintf("urn:li:dataFlow:(%s,%s.%s,%s)", orchestrator, project, job, strings.ToLower(cluster))
+}
+
+// DataJobUrn identifies a job within its flow
+func DataJobUrn(project, job, cluster string) string {
+	return fmt.Sprintf("urn:li:dataJob:(%s,%s)", DataFlowUrn(project, job, cluster), job)
+}
+
+// DatasetUrn converts names generated by plugins, e.g.
+// bigquery://project:dataset.table, to urns of datasets of the platform
+// named by the scheme. Names without a scheme are datasets of optimus
+func DatasetUrn(name, env string) string {
+	platform, path := orchestrator, name
+	if parts := strings.SplitN(name, "://", 2); len(parts) == 2 {
+		platform, path = parts[0], parts[1]
+	}
+	path = strings.ReplaceAll(path, ":", ".")
+	return fmt.Sprintf("urn:li:dataset:(urn:li:dataPlatform:%s,%s,%s)", platform, path, strings.ToUpper(env))
+}
+
+// CorpUserUrn identifies owners of jobs
+func CorpUserUrn(owner string) string {
+	return fmt.Sprintf("urn:li:corpuser:%s", owner)
+}
@@ -0,0 +1,319 @@
+// Package datahub pushes metadata of jobs to DataHub through the rest api
+// of its metadata service. Each job is a data flow holding a single data
+// job, inputs and outputs of the data job are the dependencies and
+// destination generated by its task plugin.
+package datahub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProjectDatahubURLKey is the DataHub metadata service jobs of a
+	// project are pushed to, server default is used if not set
+	ProjectDatahubURLKey = "DATAHUB_URL"
+	// ProjectDatahubEnvKey is the environment datasets of a project belong
+	// to, e.g. PROD or DEV, server default is used if not set
+	ProjectDatahubEnvKey = "DATAHUB_ENV"
+	// ProjectDatahubTokenSecret is the secret of a project sent as bearer
+	// token to its DataHub, server default is used if not set
+	ProjectDatahubTokenSecret = "DATAHUB_TOKEN"
+
+	// endpoint of the metadata service proposals are posted to
+	ingestProposalPath = "/aspects?action=ingestProposal"
+
+	restliProtocolVersion = "2.0.0"
+
+	// DefaultEnv is the environment of datasets if neither the server nor
+	// the project sets one
+	DefaultEnv = "PROD"
+)
+
+// HTTPClient is used to post proposals to DataHub
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Emitter pushes metadata of jobs to DataHub, projects can point to
+// their own DataHub with ProjectDatahubURLKey. Jobs of projects without a
+// DataHub are skipped
+type Emitter struct {
+	url     string
+	token   string
+	env     string
+	client  HTTPClient
+	adapter models.JobMetadataAdapter
+
+	Now func() time.Time
+}
+
+// entityAspect is an aspect of an entity to be proposed
+type entityAspect struct {
+	entityType string
+	entityUrn  string
+	name       string
+	aspect     interface{}
+}
+
+// target is the DataHub jobs of a project are pushed to
+type target struct {
+	url   string
+	token string
+	env   string
+}
+
+// Publish upserts flows and data jobs of jobs deployed to namespace, it
+// makes Emitter a models.MetadataService
+func (e *Emitter) Publish(namespace models.NamespaceSpec, jobSpecs []models.JobSpec, _ progress.Observer) error {
+	dest, ok := e.target(namespace.ProjectSpec)
+	if !ok {
+		return nil
+	}
+	for _, jobSpec := range jobSpecs {
+		jobMetadata, err := e.adapter.FromJobSpec(namespace, jobSpec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build metadata of job %s", jobSpec.Name)
+		}
+		dependencies, err := e.dependencies(context.Background(), namespace, jobSpec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate dependencies of job %s", jobSpec.Name)
+		}
+		proposals, err := e.proposals(dest, jobMetadata, dependencies)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build aspects of job %s", jobSpec.Name)
+		}
+		for _, proposal := range proposals {
+			if err := e.post(context.Background(), dest, proposal); err != nil {
+				return errors.Wrapf(err, "failed to push %s of job %s to datahub", proposal.AspectName, jobSpec.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Tombstone soft deletes flows and data jobs of deleted jobs, they are
+// restored as jobs are published again
+func (e *Emitter) Tombstone(namespace models.NamespaceSpec, jobNames []string, _ progress.Observer) error {
+	dest, ok := e.target(namespace.ProjectSpec)
+	if !ok {
+		return nil
+	}
+	projectName := namespace.ProjectSpec.Name
+	for _, jobName := range jobNames {
+		for _, entity := range []entityAspect{
+			{entityTypeDataJob, DataJobUrn(projectName, jobName, dest.env), "status", Status{Removed: true}},
+			{entityTypeDataFlow, DataFlowUrn(projectName, jobName, dest.env), "status", Status{Removed: true}},
+		} {
+			proposal, err := newProposal(entity.entityType, entity.entityUrn, entity.name, entity.aspect)
+			if err != nil {
+				return err
+			}
+			if err := e.post(context.Background(), dest, proposal); err != nil {
+				return errors.Wrapf(err, "failed to remove job %s from datahub", jobName)
+			}
+		}
+	}
+	return nil
+}
+
+// proposals maps metadata of a job to aspects of its flow and data job
+func (e *Emitter) proposals(dest target, jobMetadata *models.JobMetadata, dependencies []string) ([]MetadataChangeProposal, error) {
+	flowUrn := DataFlowUrn(jobMetadata.Tenant, jobMetadata.Name, dest.env)
+	jobUrn := DataJobUrn(jobMetadata.Tenant, jobMetadata.Name, dest.env)
+	properties := customProperties(jobMetadata)
+
+	inputOutput := DataJobInputOutput{
+		InputDatasets:  []string{},
+		OutputDatasets: []string{},
+	}
+	for _, dependency := range dependencies {
+		inputOutput.InputDatasets = append(inputOutput.InputDatasets, DatasetUrn(dependency, dest.env))
+	}
+	if jobMetadata.Task.Destination != "" {
+		inputOutput.OutputDatasets = append(inputOutput.OutputDatasets, DatasetUrn(jobMetadata.Task.Destination, dest.env))
+	}
+	for _, dependency := range jobMetadata.Dependencies {
+		// http and external dependencies aren't jobs known to datahub
+		if dependency.Tenant == "" || dependency.URL != "" {
+			continue
+		}
+		inputOutput.InputDatajobs = append(inputOutput.InputDatajobs, DataJobUrn(dependency.Tenant, dependency.Job, dest.env))
+	}
+
+	aspects := []entityAspect{
+		{entityTypeDataFlow, flowUrn, "dataFlowInfo", DataFlowInfo{
+			Name:             jobMetadata.Name,
+			Description:      jobMetadata.Description,
+			Project:          jobMetadata.Tenant,
+			CustomProperties: properties,
+		}},
+		{entityTypeDataFlow, flowUrn, "status", Status{}},
+		{entityTypeDataJob, jobUrn, "dataJobInfo", DataJobInfo{
+			Name:             jobMetadata.Name,
+			Description:      jobMetadata.Description,
+			Type:             map[string]string{"string": jobMetadata.Task.Name},
+			CustomProperties: properties,
+		}},
+		{entityTypeDataJob, jobUrn, "dataJobInputOutput", inputOutput},
+		{entityTypeDataJob, jobUrn, "status", Status{}},
+	}
+	if jobMetadata.Owner != "" {
+		ownership := Ownership{
+			Owners: []Owner{{Owner: CorpUserUrn(jobMetadata.Owner), Type: ownershipTypeDataOwner}},
+			LastModified: AuditStamp{
+				Time:  e.Now().UnixNano() / int64(time.Millisecond),
+				Actor: actorUrn,
+			},
+		}
+		aspects = append(aspects, entityAspect{entityTypeDataFlow, flowUrn, "ownership", ownership})
+	}
+
+	var proposals []MetadataChangeProposal
+	for _, aspect := range aspects {
+		proposal, err := newProposal(aspect.entityType, aspect.entityUrn, aspect.name, aspect.aspect)
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals, nil
+}
+
+// dependencies returns names of datasets job reads as generated by its
+// task plugin, jobs of plugins which can't generate them have none
+func (e *Emitter) dependencies(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec) ([]string, error) {
+	if jobSpec.Task.Unit == nil || jobSpec.Task.Unit.DependencyMod == nil {
+		return nil, nil
+	}
+	resp, err := jobSpec.Task.Unit.DependencyMod.GenerateDependencies(ctx, models.GenerateDependenciesRequest{
+		Config:  models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+		Assets:  models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+		Project: namespace.ProjectSpec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Dependencies, nil
+}
+
+// target resolves the DataHub of project, settings of project take
+// precedence over the server ones
+func (e *Emitter) target(project models.ProjectSpec) (target, bool) {
+	dest := target{
+		url:   e.url,
+		token: e.token,
+		env:   e.env,
+	}
+	if url, ok := project.Config[ProjectDatahubURLKey]; ok && url != "" {
+		dest.url = strings.TrimSuffix(url, "/")
+	}
+	if env, ok := project.Config[ProjectDatahubEnvKey]; ok && env != "" {
+		dest.env = env
+	}
+	if token, ok := project.Secret.GetByName(ProjectDatahubTokenSecret); ok && token != "" {
+		dest.token = token
+	}
+	if dest.env == "" {
+		dest.env = DefaultEnv
+	}
+	return dest, dest.url != ""
+}
+
+func (e *Emitter) post(ctx context.Context, dest target, proposal MetadataChangeProposal) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"proposal": proposal,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dest.url+ingestProposalPath, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-RestLi-Protocol-Version", restliProtocolVersion)
+	if dest.token != "" {
+		req.Header.Set("Authorization", "Bearer "+dest.token)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected response: %s", resp.Status)
+	}
+	return nil
+}
+
+func newProposal(entityType, entityUrn, aspectName string, aspect interface{}) (MetadataChangeProposal, error) {
+	value, err := json.Marshal(aspect)
+	if err != nil {
+		return MetadataChangeProposal{}, errors.Wrapf(err, "failed to serialize %s of %s", aspectName, entityUrn)
+	}
+	return MetadataChangeProposal{
+		EntityType: entityType,
+		EntityUrn:  entityUrn,
+		ChangeType: changeTypeUpsert,
+		AspectName: aspectName,
+		Aspect: GenericAspect{
+			Value:       string(value),
+			ContentType: aspectContentType,
+		},
+	}, nil
+}
+
+// customProperties describes how a job is run, config of tasks is left
+// out as it may hold credentials
+func customProperties(jobMetadata *models.JobMetadata) map[string]string {
+	properties := map[string]string{
+		"namespace":    jobMetadata.Namespace,
+		"version":      fmt.Sprintf("%d", jobMetadata.Version),
+		"task":         jobMetadata.Task.Name,
+		"task_version": jobMetadata.Task.Version,
+		"interval":     jobMetadata.Schedule.Interval,
+	}
+	if !jobMetadata.Schedule.StartDate.IsZero() {
+		properties["start_date"] = jobMetadata.Schedule.StartDate.Format(time.RFC3339)
+	}
+	if jobMetadata.Schedule.EndDate != nil {
+		properties["end_date"] = jobMetadata.Schedule.EndDate.Format(time.RFC3339)
+	}
+	var hooks []string
+	for _, hook := range jobMetadata.Hooks {
+		hooks = append(hooks, hook.Name)
+	}
+	if len(hooks) > 0 {
+		sort.Strings(hooks)
+		properties["hooks"] = strings.Join(hooks, ",")
+	}
+	for name, value := range jobMetadata.Labels {
+		properties["label."+name] = value
+	}
+	return properties
+}
+
+// NewEmitter returns an emitter pushing jobs to the DataHub at url by
+// default, url may be empty if only some projects set their own
+func NewEmitter(url, token, env string, adapter models.JobMetadataAdapter, client HTTPClient) *Emitter {
+	return &Emitter{
+		url:     strings.TrimSuffix(url, "/"),
+		token:   token,
+		env:     env,
+		client:  client,
+		adapter: adapter,
+		Now:     time.Now,
+	}
+}
@@ -0,0 +1,158 @@
+package datahub_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/datahub"
+	"github.com/odpf/optimus/meta"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmitter(t *testing.T) {
+	now := time.Date(2021, 7, 12, 8, 0, 0, 0, time.UTC)
+
+	newNamespaceSpec := func(url string) models.NamespaceSpec {
+		return models.NamespaceSpec{
+			Name: "dev-team-1",
+			ProjectSpec: models.ProjectSpec{
+				Name: "t-optimus",
+				Config: map[string]string{
+					datahub.ProjectDatahubURLKey: url,
+				},
+				Secret: models.ProjectSecrets{
+					{Name: datahub.ProjectDatahubTokenSecret, Value: "secret"},
+				},
+			},
+		}
+	}
+	newJobSpec := func(projectSpec models.ProjectSpec) models.JobSpec {
+		execUnit := new(mock.BasePlugin)
+		execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:          "bq2bq",
+			PluginVersion: "0.1.0",
+		}, nil)
+		depMod := new(mock.DependencyResolverMod)
+		jobSpec := models.JobSpec{
+			Name:        "transform-tables",
+			Owner:       "data-team",
+			Description: "aggregates orders daily",
+			Version:     1,
+			Labels:      map[string]string{"domain": "sales"},
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Interval:  "0 2 * * *",
+			},
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{Base: execUnit, DependencyMod: depMod},
+			},
+			Assets: *models.JobAssets{}.New([]models.JobSpecAsset{
+				{Name: "query.sql", Value: "select * from orders"},
+			}),
+		}
+		config := models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config)
+		assets := models.PluginAssets{}.FromJobSpec(jobSpec.Assets)
+		depMod.On("GenerateDestination", context.TODO(), models.GenerateDestinationRequest{
+			Config: config,
+			Assets: assets,
+		}).Return(&models.GenerateDestinationResponse{
+			Destination: "bigquery://proj:sales.daily_orders",
+		}, nil)
+		depMod.On("GenerateDependencies", context.Background(), models.GenerateDependenciesRequest{
+			Config:  config,
+			Assets:  assets,
+			Project: projectSpec,
+		}).Return(&models.GenerateDependenciesResponse{
+			Dependencies: []string{"bigquery://proj:sales.orders"},
+		}, nil)
+		return jobSpec
+	}
+	// newServer returns a DataHub recording aspects of proposals by name
+	newServer := func(t *testing.T, aspects map[string]map[string]interface{}) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/aspects", r.URL.Path)
+			assert.Equal(t, "ingestProposal", r.URL.Query().Get("action"))
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			var body struct {
+				Proposal datahub.MetadataChangeProposal `json:"proposal"`
+			}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "UPSERT", body.Proposal.ChangeType)
+			var aspect map[string]interface{}
+			assert.Nil(t, json.Unmarshal([]byte(body.Proposal.Aspect.Value), &aspect))
+			aspects[body.Proposal.EntityUrn+"#"+body.Proposal.AspectName] = aspect
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+	flowUrn := "urn:li:dataFlow:(optimus,t-optimus.transform-tables,prod)"
+	jobUrn := "urn:li:dataJob:(urn:li:dataFlow:(optimus,t-optimus.transform-tables,prod),transform-tables)"
+
+	t.Run("should push flow and data job of deployed jobs to datahub of project", func(t *testing.T) {
+		aspects := map[string]map[string]interface{}{}
+		srv := newServer(t, aspects)
+		defer srv.Close()
+
+		emitter := datahub.NewEmitter("", "", "PROD", &meta.JobAdapter{}, srv.Client())
+		emitter.Now = func() time.Time { return now }
+		namespaceSpec := newNamespaceSpec(srv.URL)
+		err := emitter.Publish(namespaceSpec, []models.JobSpec{newJobSpec(namespaceSpec.ProjectSpec)}, nil)
+		assert.Nil(t, err)
+
+		assert.Len(t, aspects, 6)
+		assert.Equal(t, "transform-tables", aspects[flowUrn+"#dataFlowInfo"]["name"])
+		assert.Equal(t, "t-optimus", aspects[flowUrn+"#dataFlowInfo"]["project"])
+		assert.Equal(t, map[string]interface{}{"string": "bq2bq"}, aspects[jobUrn+"#dataJobInfo"]["type"])
+		properties := aspects[jobUrn+"#dataJobInfo"]["customProperties"].(map[string]interface{})
+		assert.Equal(t, "dev-team-1", properties["namespace"])
+		assert.Equal(t, "0 2 * * *", properties["interval"])
+		assert.Equal(t, "sales", properties["label.domain"])
+		assert.Equal(t, map[string]interface{}{
+			"inputDatasets":  []interface{}{"urn:li:dataset:(urn:li:dataPlatform:bigquery,proj.sales.orders,PROD)"},
+			"outputDatasets": []interface{}{"urn:li:dataset:(urn:li:dataPlatform:bigquery,proj.sales.daily_orders,PROD)"},
+		}, aspects[jobUrn+"#dataJobInputOutput"])
+		assert.Equal(t, false, aspects[jobUrn+"#status"]["removed"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"owner": "urn:li:corpuser:data-team", "type": "DATAOWNER"},
+		}, aspects[flowUrn+"#ownership"]["owners"])
+	})
+	t.Run("should soft delete flow and data job of deleted jobs", func(t *testing.T) {
+		aspects := map[string]map[string]interface{}{}
+		srv := newServer(t, aspects)
+		defer srv.Close()
+
+		emitter := datahub.NewEmitter("", "", "PROD", &meta.JobAdapter{}, srv.Client())
+		err := emitter.Tombstone(newNamespaceSpec(srv.URL), []string{"transform-tables"}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]map[string]interface{}{
+			flowUrn + "#status": {"removed": true},
+			jobUrn + "#status":  {"removed": true},
+		}, aspects)
+	})
+	t.Run("should skip jobs of projects without datahub", func(t *testing.T) {
+		emitter := datahub.NewEmitter("", "", "PROD", &meta.JobAdapter{}, http.DefaultClient)
+		namespaceSpec := newNamespaceSpec("")
+		assert.Nil(t, emitter.Publish(namespaceSpec, []models.JobSpec{newJobSpec(namespaceSpec.ProjectSpec)}, nil))
+	})
+	t.Run("should fail if datahub rejects proposals", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		emitter := datahub.NewEmitter(srv.URL, "secret", "PROD", &meta.JobAdapter{}, srv.Client())
+		err := emitter.Tombstone(newNamespaceSpec(""), []string{"transform-tables"}, nil)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "401 Unauthorized")
+	})
+}
+
+func TestDatasetUrn(t *testing.T) {
+	assert.Equal(t, "urn:li:dataset:(urn:li:dataPlatform:bigquery,proj.sales.orders,DEV)", datahub.DatasetUrn("bigquery://proj:sales.orders", "dev"))
+	assert.Equal(t, "urn:li:dataset:(urn:li:dataPlatform:optimus,orders,PROD)", datahub.DatasetUrn("orders", "PROD"))
+}
@@ -122,6 +122,11 @@ func bqExternalDataConfigTo(es BQExternalSource) (*bqapi.ExternalDataConfig, err
 	case bqapi.GoogleSheets:
 		option = bqGoogleSheetsOptionsTo(es.Config)
 		sourceType = bqapi.GoogleSheets
+	case bqapi.CSV:
+		option = bqCSVOptionsTo(es.Config)
+		sourceType = bqapi.CSV
+	case bqapi.JSON, bqapi.Avro, bqapi.Parquet:
+		sourceType = bqapi.DataFormat(strings.ToUpper(es.SourceType))
 	default:
 		return &bqapi.ExternalDataConfig{}, fmt.Errorf("Source format not yet implemented %s", es.SourceType)
 	}
@@ -129,8 +134,18 @@ func bqExternalDataConfigTo(es BQExternalSource) (*bqapi.ExternalDataConfig, err
 	externalConfig := &bqapi.ExternalDataConfig{
 		SourceFormat: sourceType,
 		SourceURIs:   es.SourceURIs,
+		AutoDetect:   es.Autodetect,
 		Options:      option,
 	}
+	if val, ok := es.Config["compression"]; ok {
+		externalConfig.Compression = bqapi.Compression(strings.ToUpper(val.(string)))
+	}
+	if val, ok := es.Config["ignore_unknown_values"]; ok {
+		externalConfig.IgnoreUnknownValues = val.(bool)
+	}
+	if val, ok := es.Config["max_bad_records"]; ok {
+		externalConfig.MaxBadRecords = int64(val.(float64))
+	}
 	return externalConfig, nil
 }
 
@@ -140,18 +155,82 @@ func bqExternalDataConfigFrom(c *bqapi.ExternalDataConfig) (*BQExternalSource, e
 	switch c.SourceFormat {
 	case bqapi.GoogleSheets:
 		option = bqGoogleSheetsOptionsFrom(c.Options.(*bqapi.GoogleSheetsOptions))
+	case bqapi.CSV:
+		option = bqCSVOptionsFrom(c.Options.(*bqapi.CSVOptions))
+	case bqapi.JSON, bqapi.Avro, bqapi.Parquet:
+		option = make(map[string]interface{})
 	default:
 		return &BQExternalSource{}, fmt.Errorf("Source format not yet implemented %s", c.SourceFormat)
 	}
+	if c.Compression != "" && c.Compression != bqapi.None {
+		option["compression"] = string(c.Compression)
+	}
+	if c.IgnoreUnknownValues {
+		option["ignore_unknown_values"] = true
+	}
+	if c.MaxBadRecords != 0 {
+		option["max_bad_records"] = float64(c.MaxBadRecords)
+	}
 
 	externalDataConfig := &BQExternalSource{
 		SourceType: string(c.SourceFormat),
 		SourceURIs: c.SourceURIs,
+		Autodetect: c.AutoDetect,
 		Config:     option,
 	}
 	return externalDataConfig, nil
 }
 
+func bqCSVOptionsTo(m map[string]interface{}) *bqapi.CSVOptions {
+	opt := &bqapi.CSVOptions{}
+	if val, ok := m["skip_leading_rows"]; ok {
+		opt.SkipLeadingRows = int64(val.(float64))
+	}
+	if val, ok := m["field_delimiter"]; ok {
+		opt.FieldDelimiter = val.(string)
+	}
+	if val, ok := m["quote"]; ok {
+		opt.Quote = val.(string)
+		// an empty quote disables quoting instead of falling back to "
+		opt.ForceZeroQuote = opt.Quote == ""
+	}
+	if val, ok := m["encoding"]; ok {
+		opt.Encoding = bqapi.Encoding(strings.ToUpper(val.(string)))
+	}
+	if val, ok := m["allow_jagged_rows"]; ok {
+		opt.AllowJaggedRows = val.(bool)
+	}
+	if val, ok := m["allow_quoted_newlines"]; ok {
+		opt.AllowQuotedNewlines = val.(bool)
+	}
+	return opt
+}
+
+func bqCSVOptionsFrom(opt *bqapi.CSVOptions) map[string]interface{} {
+	resultMap := make(map[string]interface{})
+
+	if opt.SkipLeadingRows != 0 {
+		// Map value of int has to be converted to float because of using interface{}
+		resultMap["skip_leading_rows"] = float64(opt.SkipLeadingRows)
+	}
+	if opt.FieldDelimiter != "" {
+		resultMap["field_delimiter"] = opt.FieldDelimiter
+	}
+	if opt.Quote != "" || opt.ForceZeroQuote {
+		resultMap["quote"] = opt.Quote
+	}
+	if opt.Encoding != "" {
+		resultMap["encoding"] = string(opt.Encoding)
+	}
+	if opt.AllowJaggedRows {
+		resultMap["allow_jagged_rows"] = true
+	}
+	if opt.AllowQuotedNewlines {
+		resultMap["allow_quoted_newlines"] = true
+	}
+	return resultMap
+}
+
 func bqFieldModeFrom(fm fieldMode) string {
 	if fm.repeated {
 		return "repeated"
@@ -228,6 +307,9 @@ func bqCreateTableMetaAdapter(t BQTable) (meta *bqapi.TableMetadata, err error)
 	}
 
 	if t.Metadata.Source != nil {
+		if err := t.Metadata.Source.validate(t.Metadata.Schema); err != nil {
+			return nil, err
+		}
 		meta.ExternalDataConfig, err = bqExternalDataConfigTo(*t.Metadata.Source)
 		if err != nil {
 			return nil, err
@@ -90,6 +90,38 @@ func TestAdapter(t *testing.T) {
 		assert.Equal(t, &externalDataSource, externalSourceResult)
 	})
 
+	t.Run("should convert from and to BQ ExternalDataConfig of csv files successfully", func(t *testing.T) {
+		externalDataSource := BQExternalSource{
+			SourceType: string(ExternalTableTypeCSV),
+			SourceURIs: []string{"gs://bucket/orders/*.csv.gz"},
+			Autodetect: true,
+			Config: map[string]interface{}{
+				"skip_leading_rows": 1.0,
+				"field_delimiter":   "|",
+				"compression":       "GZIP",
+				"max_bad_records":   10.0,
+			},
+		}
+		expectedBQExternalDataConfig := &bigquery.ExternalDataConfig{
+			SourceFormat:  bigquery.CSV,
+			SourceURIs:    []string{"gs://bucket/orders/*.csv.gz"},
+			AutoDetect:    true,
+			Compression:   bigquery.Gzip,
+			MaxBadRecords: 10,
+			Options: &bigquery.CSVOptions{
+				SkipLeadingRows: 1,
+				FieldDelimiter:  "|",
+			},
+		}
+		bQExternalDataConfigResult, err := bqExternalDataConfigTo(externalDataSource)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedBQExternalDataConfig, bQExternalDataConfigResult)
+
+		externalSourceResult, err := bqExternalDataConfigFrom(bQExternalDataConfigResult)
+		assert.Nil(t, err)
+		assert.Equal(t, &externalDataSource, externalSourceResult)
+	})
+
 	t.Run("should convert from and to BQ TimePartitioning successfully", func(t *testing.T) {
 		partitionField := "partition-field"
 		partitionExpiryInHours := int64(720)
@@ -288,6 +320,36 @@ func TestAdapter(t *testing.T) {
 			assert.Nil(t, actualTableMetadata)
 			assert.NotNil(t, err)
 		})
+		t.Run("should return error when external source is invalid", func(t *testing.T) {
+			testCases := []struct {
+				source BQExternalSource
+				err    string
+			}{
+				{
+					source: BQExternalSource{SourceType: string(ExternalTableTypeParquet)},
+					err:    "external source PARQUET requires at least one uri",
+				},
+				{
+					source: BQExternalSource{SourceType: string(ExternalTableTypeCSV), SourceURIs: []string{"gs://bucket/orders.csv"}},
+					err:    "external source CSV requires a schema or autodetect",
+				},
+				{
+					source: BQExternalSource{SourceType: string(ExternalTableTypeAvro), SourceURIs: []string{"https://example.io/orders.avro"}},
+					err:    "uri https://example.io/orders.avro of external source AVRO should be in gs://",
+				},
+			}
+			for _, tc := range testCases {
+				source := tc.source
+				actualTableMetadata, err := bqCreateTableMetaAdapter(BQTable{
+					Project:  "project",
+					Dataset:  "dataset",
+					Table:    "table",
+					Metadata: BQTableMetadata{Source: &source},
+				})
+				assert.Nil(t, actualTableMetadata)
+				assert.EqualError(t, err, tc.err)
+			}
+		})
 	})
 	t.Run("bqUpdateTableMetaAdapter", func(t *testing.T) {
 		t.Run("should convert to BQ TableMetadata", func(t *testing.T) {
@@ -0,0 +1,134 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	bqapi "cloud.google.com/go/bigquery"
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultBackupDataset = "optimus_backup"
+	defaultBackupPrefix  = "backup"
+	defaultBackupTTL     = 720 * time.Hour
+)
+
+// tableURNRegex parses urns of tables, e.g. bigquery://project:dataset.table
+var tableURNRegex = regexp.MustCompile(`^bigquery://([^:]+):([^.]+)\.(.+)$`)
+
+// backupConfig reads dataset, prefix and ttl of backups from config,
+// defaults are used for keys not set
+func backupConfig(config map[string]string) (dataset, prefix string, ttl time.Duration, err error) {
+	dataset, prefix, ttl = defaultBackupDataset, defaultBackupPrefix, defaultBackupTTL
+	if val := config[models.BackupConfigDataset]; val != "" {
+		dataset = val
+	}
+	if val := config[models.BackupConfigPrefix]; val != "" {
+		prefix = val
+	}
+	if val := config[models.BackupConfigTTL]; val != "" {
+		if ttl, err = time.ParseDuration(val); err != nil {
+			return "", "", 0, errors.Wrapf(err, "invalid backup %s %s", models.BackupConfigTTL, val)
+		}
+	}
+	if !validDatasetName.MatchString(dataset) {
+		return "", "", 0, fmt.Errorf("invalid backup dataset name (must match %q)", validDatasetName.String())
+	}
+	return dataset, prefix, ttl, nil
+}
+
+// backupTable copies table to the backup dataset of its project, named
+// after the table and the backup. The copy expires once ttl of backup
+// passes
+func backupTable(ctx context.Context, request models.BackupResourceRequest, client bqiface.Client) (models.BackupResourceResponse, error) {
+	bqTable, ok := request.Resource.Spec.(BQTable)
+	if !ok {
+		return models.BackupResourceResponse{}, errors.New("failed to read table spec for bigquery")
+	}
+	datasetName, prefix, ttl, err := backupConfig(request.Backup.Config)
+	if err != nil {
+		return models.BackupResourceResponse{}, err
+	}
+
+	backupDataset := client.DatasetInProject(bqTable.Project, datasetName)
+	if err := ensureDataset(ctx, backupDataset, BQDataset{
+		Project:  bqTable.Project,
+		Dataset:  datasetName,
+		Metadata: BQDatasetMetadata{},
+	}, false); err != nil {
+		return models.BackupResourceResponse{}, errors.Wrapf(err, "failed to create backup dataset %s", datasetName)
+	}
+
+	backup := BQTable{
+		Project: bqTable.Project,
+		Dataset: datasetName,
+		Table: fmt.Sprintf("%s_%s_%s_%s", prefix, bqTable.Dataset, bqTable.Table,
+			strings.ReplaceAll(request.Backup.ID.String(), "-", "")),
+	}
+	source := client.DatasetInProject(bqTable.Project, bqTable.Dataset).Table(bqTable.Table)
+	destination := backupDataset.Table(backup.Table)
+	if err := copyTable(ctx, source, destination, bqapi.WriteEmpty); err != nil {
+		return models.BackupResourceResponse{}, errors.Wrapf(err, "failed to copy table %s", bqTable.FullyQualifiedName())
+	}
+
+	// blind write, the copy was just created
+	if _, err := destination.Update(ctx, bqapi.TableMetadataToUpdate{
+		ExpirationTime: request.BackupTime.Add(ttl),
+	}, ""); err != nil {
+		return models.BackupResourceResponse{}, errors.Wrapf(err, "failed to set expiry of backup %s", backup.FullyQualifiedName())
+	}
+	return models.BackupResourceResponse{
+		URN: tableURN(backup),
+	}, nil
+}
+
+// restoreTable overwrites table with the backup at urn
+func restoreTable(ctx context.Context, request models.RestoreResourceRequest, client bqiface.Client) error {
+	bqTable, ok := request.Resource.Spec.(BQTable)
+	if !ok {
+		return errors.New("failed to read table spec for bigquery")
+	}
+	parsed := tableURNRegex.FindStringSubmatch(request.URN)
+	if len(parsed) < 4 {
+		return fmt.Errorf("invalid backup urn %s", request.URN)
+	}
+
+	source := client.DatasetInProject(parsed[1], parsed[2]).Table(parsed[3])
+	destination := client.DatasetInProject(bqTable.Project, bqTable.Dataset).Table(bqTable.Table)
+	if err := copyTable(ctx, source, destination, bqapi.WriteTruncate); err != nil {
+		return errors.Wrapf(err, "failed to restore table %s", bqTable.FullyQualifiedName())
+	}
+	return nil
+}
+
+// copyTable copies source to destination and waits for the copy job
+func copyTable(ctx context.Context, source, destination bqiface.Table, disposition bqapi.TableWriteDisposition) error {
+	copier := destination.CopierFrom(source)
+	copier.SetCopyConfig(bqiface.CopyConfig{
+		CopyConfig: bqapi.CopyConfig{
+			CreateDisposition: bqapi.CreateIfNeeded,
+			WriteDisposition:  disposition,
+		},
+		Srcs: []bqiface.Table{source},
+		Dst:  destination,
+	})
+	job, err := copier.Run(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	return status.Err()
+}
+
+func tableURN(t BQTable) string {
+	return fmt.Sprintf("bigquery://%s", t.FullyQualifiedName())
+}
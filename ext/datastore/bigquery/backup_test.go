@@ -0,0 +1,136 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/google/uuid"
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackup(t *testing.T) {
+	testingContext := context.Background()
+	backupID := uuid.MustParse("0c6aa5e8-2a0d-4b1c-a4b5-8a1a3c9b8f2e")
+	backupTime := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	bQResource := BQTable{
+		Project: "project",
+		Dataset: "dataset",
+		Table:   "table",
+	}
+	resourceSpec := models.ResourceSpec{
+		Name: "project:dataset.table",
+		Type: models.ResourceTypeTable,
+		Spec: bQResource,
+	}
+
+	t.Run("backupTable", func(t *testing.T) {
+		t.Run("should copy table to backup dataset and set its expiry", func(t *testing.T) {
+			client := new(BqClientMock)
+			defer client.AssertExpectations(t)
+			backupDataset := new(BqDatasetMock)
+			defer backupDataset.AssertExpectations(t)
+			sourceDataset := new(BqDatasetMock)
+			defer sourceDataset.AssertExpectations(t)
+			source := new(BqTableMock)
+			destination := new(BqTableMock)
+			defer destination.AssertExpectations(t)
+			copier := new(BqCopierMock)
+			defer copier.AssertExpectations(t)
+			job := new(BqJobMock)
+			defer job.AssertExpectations(t)
+
+			backupName := "backup_dataset_table_0c6aa5e82a0d4b1ca4b58a1a3c9b8f2e"
+			client.On("DatasetInProject", "project", "optimus_backup").Return(backupDataset)
+			client.On("DatasetInProject", "project", "dataset").Return(sourceDataset)
+			backupDataset.On("Metadata", testingContext).Return(&bqiface.DatasetMetadata{}, nil)
+			backupDataset.On("Table", backupName).Return(destination)
+			sourceDataset.On("Table", "table").Return(source)
+			destination.On("CopierFrom", []bqiface.Table{source}).Return(copier)
+			copier.On("SetCopyConfig", mock.AnythingOfType("bqiface.CopyConfig")).Return()
+			copier.On("Run", testingContext).Return(job, nil)
+			job.On("Wait", testingContext).Return(&bigquery.JobStatus{}, nil)
+			destination.On("Update", testingContext, bigquery.TableMetadataToUpdate{
+				ExpirationTime: backupTime.Add(24 * time.Hour),
+			}, "").Return(&bigquery.TableMetadata{}, nil)
+
+			resp, err := backupTable(testingContext, models.BackupResourceRequest{
+				Resource: resourceSpec,
+				Backup: models.BackupSpec{
+					ID:     backupID,
+					Config: map[string]string{models.BackupConfigTTL: "24h"},
+				},
+				BackupTime: backupTime,
+			}, client)
+			assert.Nil(t, err)
+			assert.Equal(t, "bigquery://project:optimus_backup."+backupName, resp.URN)
+		})
+		t.Run("should fail on invalid ttl", func(t *testing.T) {
+			_, err := backupTable(testingContext, models.BackupResourceRequest{
+				Resource: resourceSpec,
+				Backup: models.BackupSpec{
+					ID:     backupID,
+					Config: map[string]string{models.BackupConfigTTL: "a month"},
+				},
+			}, new(BqClientMock))
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("restoreTable", func(t *testing.T) {
+		t.Run("should overwrite table with backup", func(t *testing.T) {
+			client := new(BqClientMock)
+			defer client.AssertExpectations(t)
+			backupDataset := new(BqDatasetMock)
+			sourceDataset := new(BqDatasetMock)
+			backup := new(BqTableMock)
+			destination := new(BqTableMock)
+			defer destination.AssertExpectations(t)
+			copier := new(BqCopierMock)
+			defer copier.AssertExpectations(t)
+			job := new(BqJobMock)
+
+			client.On("DatasetInProject", "project", "optimus_backup").Return(backupDataset)
+			client.On("DatasetInProject", "project", "dataset").Return(sourceDataset)
+			backupDataset.On("Table", "backup_dataset_table").Return(backup)
+			sourceDataset.On("Table", "table").Return(destination)
+			destination.On("CopierFrom", []bqiface.Table{backup}).Return(copier)
+			copier.On("SetCopyConfig", bqiface.CopyConfig{
+				CopyConfig: bigquery.CopyConfig{
+					CreateDisposition: bigquery.CreateIfNeeded,
+					WriteDisposition:  bigquery.WriteTruncate,
+				},
+				Srcs: []bqiface.Table{backup},
+				Dst:  destination,
+			}).Return()
+			copier.On("Run", testingContext).Return(job, nil)
+			job.On("Wait", testingContext).Return(&bigquery.JobStatus{}, nil)
+
+			err := restoreTable(testingContext, models.RestoreResourceRequest{
+				Resource: resourceSpec,
+				URN:      "bigquery://project:optimus_backup.backup_dataset_table",
+			}, client)
+			assert.Nil(t, err)
+		})
+		t.Run("should fail on invalid urn", func(t *testing.T) {
+			err := restoreTable(testingContext, models.RestoreResourceRequest{
+				Resource: resourceSpec,
+				URN:      "gs://bucket/table",
+			}, new(BqClientMock))
+			assert.NotNil(t, err)
+		})
+	})
+}
+
+func TestResourceURN(t *testing.T) {
+	urn, err := This.ResourceURN(models.ResourceSpec{Spec: BQTable{Project: "p", Dataset: "d", Table: "t"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "bigquery://p:d.t", urn)
+
+	urn, err = This.ResourceURN(models.ResourceSpec{Spec: BQDataset{Project: "p", Dataset: "d"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "bigquery://p:d", urn)
+}
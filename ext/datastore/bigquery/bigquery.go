@@ -132,6 +132,14 @@ func (b *BigQuery) ReadResource(ctx context.Context, request models.ReadResource
 		return models.ReadResourceResponse{
 			Resource: info,
 		}, nil
+	case models.ResourceTypeExternalTable:
+		info, err := getTable(ctx, request.Resource, client)
+		if err != nil {
+			return models.ReadResourceResponse{}, err
+		}
+		return models.ReadResourceResponse{
+			Resource: info,
+		}, nil
 	}
 	return models.ReadResourceResponse{}, fmt.Errorf("unsupported resource type %s", request.Resource.Type)
 }
@@ -154,10 +162,75 @@ func (b *BigQuery) DeleteResource(ctx context.Context, request models.DeleteReso
 		return deleteTable(ctx, request.Resource, client)
 	case models.ResourceTypeDataset:
 		return deleteDataset(ctx, request.Resource, client)
+	case models.ResourceTypeExternalTable:
+		return deleteTable(ctx, request.Resource, client)
 	}
 	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
 }
 
+func (b BigQuery) ResourceURN(resource models.ResourceSpec) (string, error) {
+	switch spec := resource.Spec.(type) {
+	case BQTable:
+		return tableURN(spec), nil
+	case BQDataset:
+		return fmt.Sprintf("bigquery://%s:%s", spec.Project, spec.Dataset), nil
+	}
+	return "", fmt.Errorf("failed to read spec of %s for bigquery", resource.Name)
+}
+
+func (b *BigQuery) BackupResource(ctx context.Context, request models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	// only data of tables is copied, the rest can be deployed again
+	if request.Resource.Type != models.ResourceTypeTable {
+		return models.BackupResourceResponse{}, errors.Wrapf(models.ErrUnsupportedResource, "backup of %s %s", request.Resource.Type, request.Resource.Name)
+	}
+
+	svcAcc, ok := request.Project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return models.BackupResourceResponse{}, errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, b.Name()))
+	}
+
+	client, err := b.ClientFac.New(ctx, svcAcc)
+	if err != nil {
+		return models.BackupResourceResponse{}, err
+	}
+	return backupTable(ctx, request, client)
+}
+
+func (b *BigQuery) RestoreResource(ctx context.Context, request models.RestoreResourceRequest) error {
+	if request.Resource.Type != models.ResourceTypeTable {
+		return errors.Wrapf(models.ErrUnsupportedResource, "restore of %s %s", request.Resource.Type, request.Resource.Name)
+	}
+
+	svcAcc, ok := request.Project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, b.Name()))
+	}
+
+	client, err := b.ClientFac.New(ctx, svcAcc)
+	if err != nil {
+		return err
+	}
+	return restoreTable(ctx, request, client)
+}
+
+func (b *BigQuery) CheckResource(ctx context.Context, request models.CheckResourceRequest) (models.CheckResourceResponse, error) {
+	// views and external tables take their schema from query and source
+	if request.Resource.Type != models.ResourceTypeTable {
+		return models.CheckResourceResponse{}, errors.Wrapf(models.ErrUnsupportedResource, "check of %s %s", request.Resource.Type, request.Resource.Name)
+	}
+
+	svcAcc, ok := request.Project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return models.CheckResourceResponse{}, errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, b.Name()))
+	}
+
+	client, err := b.ClientFac.New(ctx, svcAcc)
+	if err != nil {
+		return models.CheckResourceResponse{}, err
+	}
+	return checkTable(ctx, request.Resource, client)
+}
+
 func init() {
 	if err := models.DatastoreRegistry.Add(This); err != nil {
 		panic(err)
@@ -0,0 +1,129 @@
+package bigquery
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+)
+
+const defaultFieldMode = "nullable"
+
+// fieldTypeAliases maps legacy sql names of types to their standard sql names
+var fieldTypeAliases = map[string]string{
+	"INTEGER": "INT64",
+	"FLOAT":   "FLOAT64",
+	"BOOLEAN": "BOOL",
+	"RECORD":  "STRUCT",
+}
+
+// checkTable compares schema of table in spec with the live one, a table
+// not created yet has no drift
+func checkTable(ctx context.Context, resourceSpec models.ResourceSpec, client bqiface.Client) (models.CheckResourceResponse, error) {
+	bqTable, ok := resourceSpec.Spec.(BQTable)
+	if !ok {
+		return models.CheckResourceResponse{}, errors.New("failed to read table spec for bigquery")
+	}
+
+	table := client.DatasetInProject(bqTable.Project, bqTable.Dataset).Table(bqTable.Table)
+	tableMeta, err := table.Metadata(ctx)
+	if err != nil {
+		if metaErr, ok := err.(*googleapi.Error); ok && metaErr.Code == http.StatusNotFound {
+			return models.CheckResourceResponse{}, nil
+		}
+		return models.CheckResourceResponse{}, err
+	}
+	liveSchema, err := bqSchemaFrom(tableMeta.Schema)
+	if err != nil {
+		return models.CheckResourceResponse{}, err
+	}
+	return models.CheckResourceResponse{
+		Drifts: schemaDrifts("schema", bqTable.Metadata.Schema, liveSchema),
+	}, nil
+}
+
+// schemaDrifts lists differences of live schema from the declared one.
+// Fields only declared and relaxed modes can be applied by updating the
+// table, while fields only live, changed types or tightened modes can't be
+func schemaDrifts(path string, declared, live BQSchema) []models.ResourceDrift {
+	var drifts []models.ResourceDrift
+	liveFields := map[string]BQField{}
+	for _, field := range live {
+		liveFields[strings.ToLower(field.Name)] = field
+	}
+
+	for _, declaredField := range declared {
+		fieldPath := path + "." + declaredField.Name
+		declaredMode := fieldMode(declaredField)
+		liveField, ok := liveFields[strings.ToLower(declaredField.Name)]
+		if !ok {
+			drifts = append(drifts, models.ResourceDrift{
+				Field:    fieldPath,
+				Declared: fieldType(declaredField) + " " + declaredMode,
+				// bigquery only adds nullable or repeated columns to tables
+				Incompatible: declaredMode == "required",
+			})
+			continue
+		}
+		delete(liveFields, strings.ToLower(declaredField.Name))
+
+		if fieldType(declaredField) != fieldType(liveField) {
+			drifts = append(drifts, models.ResourceDrift{
+				Field:        fieldPath + ".type",
+				Declared:     fieldType(declaredField),
+				Live:         fieldType(liveField),
+				Incompatible: true,
+			})
+		}
+		if liveMode := fieldMode(liveField); declaredMode != liveMode {
+			drifts = append(drifts, models.ResourceDrift{
+				Field:        fieldPath + ".mode",
+				Declared:     declaredMode,
+				Live:         liveMode,
+				Incompatible: !(liveMode == "required" && declaredMode == defaultFieldMode),
+			})
+		}
+		if declaredField.Description != liveField.Description {
+			drifts = append(drifts, models.ResourceDrift{
+				Field:    fieldPath + ".description",
+				Declared: declaredField.Description,
+				Live:     liveField.Description,
+			})
+		}
+		if len(declaredField.Schema) > 0 || len(liveField.Schema) > 0 {
+			drifts = append(drifts, schemaDrifts(fieldPath, declaredField.Schema, liveField.Schema)...)
+		}
+	}
+
+	// columns can't be dropped from tables by updating them
+	for _, liveField := range live {
+		if _, ok := liveFields[strings.ToLower(liveField.Name)]; !ok {
+			continue
+		}
+		drifts = append(drifts, models.ResourceDrift{
+			Field:        path + "." + liveField.Name,
+			Live:         fieldType(liveField) + " " + fieldMode(liveField),
+			Incompatible: true,
+		})
+	}
+	return drifts
+}
+
+func fieldType(field BQField) string {
+	typ := strings.ToUpper(field.Type)
+	if alias, ok := fieldTypeAliases[typ]; ok {
+		return alias
+	}
+	return typ
+}
+
+func fieldMode(field BQField) string {
+	if field.Mode == "" {
+		return defaultFieldMode
+	}
+	return strings.ToLower(field.Mode)
+}
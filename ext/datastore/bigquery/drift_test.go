@@ -0,0 +1,112 @@
+package bigquery
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestDrift(t *testing.T) {
+	testingContext := context.Background()
+
+	t.Run("schemaDrifts", func(t *testing.T) {
+		t.Run("should not report drift of same schema written differently", func(t *testing.T) {
+			declared := BQSchema{
+				{Name: "id", Type: "integer", Mode: "required"},
+				{Name: "attributes", Type: "record", Schema: BQSchema{
+					{Name: "enabled", Type: "bool"},
+				}},
+			}
+			live := BQSchema{
+				{Name: "id", Type: "INT64", Mode: "REQUIRED"},
+				{Name: "attributes", Type: "STRUCT", Mode: "nullable", Schema: BQSchema{
+					{Name: "enabled", Type: "BOOLEAN", Mode: "nullable"},
+				}},
+			}
+			assert.Empty(t, schemaDrifts("schema", declared, live))
+		})
+		t.Run("should tell drifts which can be applied from the ones which can't", func(t *testing.T) {
+			declared := BQSchema{
+				{Name: "id", Type: "string", Mode: "nullable"},
+				{Name: "name", Type: "string", Mode: "required"},
+				{Name: "note", Type: "string", Description: "free text"},
+				{Name: "added", Type: "string"},
+				{Name: "attributes", Type: "record", Schema: BQSchema{
+					{Name: "enabled", Type: "bool", Mode: "required"},
+				}},
+			}
+			live := BQSchema{
+				{Name: "id", Type: "string", Mode: "required"},
+				{Name: "name", Type: "integer", Mode: "nullable"},
+				{Name: "note", Type: "string"},
+				{Name: "attributes", Type: "record", Schema: BQSchema{
+					{Name: "enabled", Type: "bool"},
+				}},
+				{Name: "removed", Type: "string"},
+			}
+			assert.Equal(t, []models.ResourceDrift{
+				{Field: "schema.id.mode", Declared: "nullable", Live: "required"},
+				{Field: "schema.name.type", Declared: "STRING", Live: "INT64", Incompatible: true},
+				{Field: "schema.name.mode", Declared: "required", Live: "nullable", Incompatible: true},
+				{Field: "schema.note.description", Declared: "free text"},
+				{Field: "schema.added", Declared: "STRING nullable"},
+				{Field: "schema.attributes.enabled.mode", Declared: "required", Live: "nullable", Incompatible: true},
+				{Field: "schema.removed", Live: "STRING nullable", Incompatible: true},
+			}, schemaDrifts("schema", declared, live))
+		})
+	})
+	t.Run("checkTable", func(t *testing.T) {
+		resourceSpec := models.ResourceSpec{
+			Name: "project:dataset.table",
+			Type: models.ResourceTypeTable,
+			Spec: BQTable{
+				Project: "project",
+				Dataset: "dataset",
+				Table:   "table",
+				Metadata: BQTableMetadata{
+					Schema: BQSchema{{Name: "id", Type: "string"}},
+				},
+			},
+		}
+
+		t.Run("should compare declared schema with the live one", func(t *testing.T) {
+			client := new(BqClientMock)
+			defer client.AssertExpectations(t)
+			dataset := new(BqDatasetMock)
+			defer dataset.AssertExpectations(t)
+			table := new(BqTableMock)
+			defer table.AssertExpectations(t)
+
+			client.On("DatasetInProject", "project", "dataset").Return(dataset)
+			dataset.On("Table", "table").Return(table)
+			table.On("Metadata", testingContext).Return(&bigquery.TableMetadata{
+				Schema: bigquery.Schema{{Name: "id", Type: bigquery.IntegerFieldType}},
+			}, nil)
+
+			resp, err := checkTable(testingContext, resourceSpec, client)
+			assert.Nil(t, err)
+			assert.True(t, resp.Incompatible())
+			assert.Equal(t, []models.ResourceDrift{
+				{Field: "schema.id.type", Declared: "STRING", Live: "INT64", Incompatible: true},
+			}, resp.Drifts)
+		})
+		t.Run("should not report drift of table not created yet", func(t *testing.T) {
+			client := new(BqClientMock)
+			dataset := new(BqDatasetMock)
+			table := new(BqTableMock)
+
+			client.On("DatasetInProject", "project", "dataset").Return(dataset)
+			dataset.On("Table", "table").Return(table)
+			table.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), &googleapi.Error{Code: http.StatusNotFound})
+
+			resp, err := checkTable(testingContext, resourceSpec, client)
+			assert.Nil(t, err)
+			assert.Empty(t, resp.Drifts)
+		})
+	})
+}
@@ -10,6 +10,15 @@ import (
 
 const (
 	ExternalTableTypeGoogleSheets ExternalTableType = "GOOGLE_SHEETS"
+
+	// file formats of external tables over objects in gcs
+	ExternalTableTypeCSV     ExternalTableType = "CSV"
+	ExternalTableTypeJSON    ExternalTableType = "NEWLINE_DELIMITED_JSON"
+	ExternalTableTypeAvro    ExternalTableType = "AVRO"
+	ExternalTableTypeParquet ExternalTableType = "PARQUET"
+
+	// gcsURIPrefix is the scheme uris of file sources are in
+	gcsURIPrefix = "gs://"
 )
 
 type ExternalTableType string
@@ -18,13 +27,43 @@ type ExternalTableType string
 type BQExternalSource struct {
 	SourceType string `yaml:"type,omitempty" structs:"type"`
 
-	// External Table URI string for the referenced spreadsheets
+	// External Table URI string for the referenced spreadsheets, or gs://
+	// uris of files, wildcards are allowed in object names
 	SourceURIs []string `yaml:"uris,omitempty" structs:"uris,omitempty"`
 
+	// Autodetect lets bigquery infer schema of CSV and JSON sources, table
+	// needs a schema of its own otherwise
+	Autodetect bool `yaml:"autodetect,omitempty" structs:"autodetect,omitempty"`
+
 	// Additional configs for CSV, GoogleSheets, Bigtable, and Parquet formats.
 	Config map[string]interface{} `yaml:"config,omitempty" structs:"config"`
 }
 
+// validate checks source can back a table of schema
+func (s BQExternalSource) validate(schema BQSchema) error {
+	if len(s.SourceURIs) == 0 {
+		return fmt.Errorf("external source %s requires at least one uri", s.SourceType)
+	}
+	switch ExternalTableType(strings.ToUpper(s.SourceType)) {
+	case ExternalTableTypeGoogleSheets:
+		return nil
+	case ExternalTableTypeCSV, ExternalTableTypeJSON:
+		if !s.Autodetect && len(schema) == 0 {
+			return fmt.Errorf("external source %s requires a schema or autodetect", s.SourceType)
+		}
+	case ExternalTableTypeAvro, ExternalTableTypeParquet:
+		// files of self describing formats carry their schema
+	default:
+		return fmt.Errorf("Source format not yet implemented %s", s.SourceType)
+	}
+	for _, uri := range s.SourceURIs {
+		if !strings.HasPrefix(uri, gcsURIPrefix) {
+			return fmt.Errorf("uri %s of external source %s should be in %s", uri, s.SourceType, gcsURIPrefix)
+		}
+	}
+	return nil
+}
+
 type externalTableSpec struct{}
 
 func (s externalTableSpec) Adapter() models.DatastoreSpecAdapter {
@@ -65,6 +104,9 @@ func extractTableSourceFromProtoStruct(protoVal *structpb.Value) *BQExternalSour
 		}
 		sInfo.SourceURIs = sourceURIs
 	}
+	if f, ok := protoVal.GetStructValue().Fields["autodetect"]; ok {
+		sInfo.Autodetect = f.GetBoolValue()
+	}
 	if f, ok := protoVal.GetStructValue().Fields["config"]; ok {
 		sInfo.Config = f.GetStructValue().AsMap()
 	}
@@ -176,4 +176,33 @@ func TestExternalTable(t *testing.T) {
 			assert.NotNil(t, err)
 		})
 	})
+	t.Run("getTable", func(t *testing.T) {
+		t.Run("should retrieve source of BQ external table", func(t *testing.T) {
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+
+			resourceSpec := models.ResourceSpec{
+				Spec: bQResource,
+				Type: models.ResourceTypeExternalTable,
+			}
+			datasetMetadata := bqiface.DatasetMetadata{
+				DatasetMetadata: bigquery.DatasetMetadata{},
+			}
+
+			bQDatasetHandle := new(BqDatasetMock)
+			defer bQDatasetHandle.AssertExpectations(t)
+
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			bQClient.On("DatasetInProject", bQResource.Project, bQResource.Dataset).Return(bQDatasetHandle)
+			bQDatasetHandle.On("Metadata", testingContext).Return(&datasetMetadata, nil)
+			bQDatasetHandle.On("Table", bQResource.Table).Return(bQTable)
+			bQTable.On("Metadata", testingContext).Return(createTableMeta, nil)
+
+			actualResourceSpec, err := getTable(testingContext, resourceSpec, bQClient)
+			assert.Nil(t, err)
+			assert.Equal(t, testingSource, actualResourceSpec.Spec.(BQTable).Metadata.Source)
+		})
+	})
 }
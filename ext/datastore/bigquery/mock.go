@@ -161,6 +161,30 @@ func (table *BqTableMock) Uploader() bqiface.Uploader {
 	panic("not implemented")
 }
 
+type BqCopierMock struct {
+	mock.Mock
+	bqiface.Copier
+}
+
+func (copier *BqCopierMock) SetCopyConfig(c bqiface.CopyConfig) {
+	copier.Called(c)
+}
+
+func (copier *BqCopierMock) Run(ctx context.Context) (bqiface.Job, error) {
+	args := copier.Called(ctx)
+	return args.Get(0).(bqiface.Job), args.Error(1)
+}
+
+type BqJobMock struct {
+	mock.Mock
+	bqiface.Job
+}
+
+func (job *BqJobMock) Wait(ctx context.Context) (*bigquery.JobStatus, error) {
+	args := job.Called(ctx)
+	return args.Get(0).(*bigquery.JobStatus), args.Error(1)
+}
+
 type BQClientFactoryMock struct {
 	mock.Mock
 }
@@ -189,3 +213,19 @@ func (b *BigQueryMock) ReadResource(ctx context.Context, request models.ReadReso
 func (b *BigQueryMock) DeleteResource(ctx context.Context, request models.DeleteResourceRequest) error {
 	panic("not implemented")
 }
+
+func (b *BigQueryMock) ResourceURN(resource models.ResourceSpec) (string, error) {
+	panic("not implemented")
+}
+
+func (b *BigQueryMock) BackupResource(ctx context.Context, request models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	panic("not implemented")
+}
+
+func (b *BigQueryMock) RestoreResource(ctx context.Context, request models.RestoreResourceRequest) error {
+	panic("not implemented")
+}
+
+func (b *BigQueryMock) CheckResource(ctx context.Context, request models.CheckResourceRequest) (models.CheckResourceResponse, error) {
+	panic("not implemented")
+}
@@ -99,6 +99,15 @@ func getTable(ctx context.Context, resourceSpec models.ResourceSpec, client bqif
 		Location:    tableMeta.Location,
 	}
 
+	// if table is backed by an external source
+	if tableMeta.ExternalDataConfig != nil {
+		source, err := bqExternalDataConfigFrom(tableMeta.ExternalDataConfig)
+		if err != nil {
+			return models.ResourceSpec{}, err
+		}
+		bqResource.Metadata.Source = source
+	}
+
 	// if table is partitioned
 	if tableMeta.TimePartitioning != nil {
 		bqResource.Metadata.Partition = bqPartitioningFrom(tableMeta.TimePartitioning)
@@ -0,0 +1,56 @@
+package kms
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awskms "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+)
+
+// awsKeyManager uses AWS KMS customer master keys, key id can be a key
+// id, key arn or an alias
+type awsKeyManager struct {
+	client kmsiface.KMSAPI
+}
+
+func (km *awsKeyManager) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	resp, err := km.client.EncryptWithContext(ctx, &awskms.EncryptInput{
+		KeyId:     aws.String(keyID),
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.CiphertextBlob, nil
+}
+
+func (km *awsKeyManager) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	resp, err := km.client.DecryptWithContext(ctx, &awskms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+func NewAWSKeyManager(client kmsiface.KMSAPI) *awsKeyManager {
+	return &awsKeyManager{
+		client: client,
+	}
+}
+
+// NewAWSClient creates a kms client using credentials and region from
+// the default aws credential chain
+func NewAWSClient() (*awskms.KMS, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return awskms.New(sess), nil
+}
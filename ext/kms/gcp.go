@@ -0,0 +1,54 @@
+package kms
+
+import (
+	"context"
+
+	gcpkms "cloud.google.com/go/kms/apiv1"
+	"github.com/googleapis/gax-go/v2"
+	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
+)
+
+type GCPClient interface {
+	Encrypt(ctx context.Context, req *kmspb.EncryptRequest, opts ...gax.CallOption) (*kmspb.EncryptResponse, error)
+	Decrypt(ctx context.Context, req *kmspb.DecryptRequest, opts ...gax.CallOption) (*kmspb.DecryptResponse, error)
+}
+
+// gcpKeyManager uses Google Cloud KMS crypto keys as master keys, key id
+// is the crypto key resource name
+// e.g.: projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>
+type gcpKeyManager struct {
+	client GCPClient
+}
+
+func (km *gcpKeyManager) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	resp, err := km.client.Encrypt(ctx, &kmspb.EncryptRequest{
+		Name:      keyID,
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ciphertext, nil
+}
+
+func (km *gcpKeyManager) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	resp, err := km.client.Decrypt(ctx, &kmspb.DecryptRequest{
+		Name:       keyID,
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+func NewGCPKeyManager(client GCPClient) *gcpKeyManager {
+	return &gcpKeyManager{
+		client: client,
+	}
+}
+
+// NewGCPClient creates a kms client using application default credentials
+func NewGCPClient(ctx context.Context) (*gcpkms.KeyManagementClient, error) {
+	return gcpkms.NewKeyManagementClient(ctx)
+}
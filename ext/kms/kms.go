@@ -0,0 +1,122 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/gtank/cryptopasta"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EnvelopePrefix marks values encrypted using envelope encryption
+	EnvelopePrefix = "enc:"
+
+	dataKeySize = 32
+)
+
+// KeyManager wraps and unwraps data encryption keys with a master key
+// kept in a key management service, the service is expected to encrypt
+// using the primary version of master key and pick the right version
+// while decrypting, which allows rotating master keys without
+// re-encrypting every value at once
+type KeyManager interface {
+	Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// envelope holds a value encrypted with a random data key along with
+// the data key wrapped by master key
+type envelope struct {
+	// master key used to wrap data key
+	KeyID string `json:"key"`
+
+	// wrapped data key
+	DataKey []byte `json:"dek"`
+
+	// value encrypted with data key
+	Data []byte `json:"data"`
+}
+
+// Encrypter seals values using envelope encryption, each value gets its
+// own data key which is wrapped using the configured master key
+type Encrypter struct {
+	keyManager KeyManager
+	keyID      string
+
+	// SensitiveConfig are patterns of config keys encrypted along with the
+	// ones projects flag as sensitive
+	SensitiveConfig models.ConfigKeyPatterns
+}
+
+// Encrypt returns envelope of value as a string safe to be stored
+func (e *Encrypter) Encrypt(ctx context.Context, value string) (string, error) {
+	dataKey := cryptopasta.NewEncryptionKey()
+	cipher, err := cryptopasta.Encrypt([]byte(value), dataKey)
+	if err != nil {
+		return "", err
+	}
+	wrappedKey, err := e.keyManager.Encrypt(ctx, e.keyID, dataKey[:])
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to wrap data key with %s", e.keyID)
+	}
+
+	raw, err := json.Marshal(envelope{
+		KeyID:   e.keyID,
+		DataKey: wrappedKey,
+		Data:    cipher,
+	})
+	if err != nil {
+		return "", err
+	}
+	return EnvelopePrefix + base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// Decrypt opens an envelope, values which are not encrypted are returned
+// as is so that existing data keeps working till it is re-encrypted
+func (e *Encrypter) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EnvelopePrefix))
+	if err != nil {
+		return "", errors.Wrap(err, "malformed envelope")
+	}
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return "", errors.Wrap(err, "malformed envelope")
+	}
+
+	// envelope knows the master key it was sealed with, values encrypted
+	// with an older master key can still be read after switching keys
+	plainKey, err := e.keyManager.Decrypt(ctx, env.KeyID, env.DataKey)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to unwrap data key with %s", env.KeyID)
+	}
+	if len(plainKey) != dataKeySize {
+		return "", errors.Errorf("invalid data key size %d", len(plainKey))
+	}
+	dataKey := &[dataKeySize]byte{}
+	copy(dataKey[:], plainKey)
+
+	cleartext, err := cryptopasta.Decrypt(env.Data, dataKey)
+	if err != nil {
+		return "", err
+	}
+	return string(cleartext), nil
+}
+
+// IsEncrypted checks if value is sealed in an envelope
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, EnvelopePrefix)
+}
+
+func NewEncrypter(keyManager KeyManager, keyID string) *Encrypter {
+	return &Encrypter{
+		keyManager: keyManager,
+		keyID:      keyID,
+	}
+}
@@ -0,0 +1,212 @@
+package kms_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/odpf/optimus/ext/kms"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+)
+
+// fakeKeyManager wraps keys by prefixing them with the master key id
+type fakeKeyManager struct {
+	disabled map[string]bool
+}
+
+func (km *fakeKeyManager) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	if km.disabled[keyID] {
+		return nil, errors.Errorf("key %s is disabled", keyID)
+	}
+	return append([]byte(keyID+"|"), plaintext...), nil
+}
+
+func (km *fakeKeyManager) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	if km.disabled[keyID] {
+		return nil, errors.Errorf("key %s is disabled", keyID)
+	}
+	if !strings.HasPrefix(string(ciphertext), keyID+"|") {
+		return nil, errors.New("ciphertext was not wrapped with this key")
+	}
+	return ciphertext[len(keyID)+1:], nil
+}
+
+func TestKMS(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Encrypter", func(t *testing.T) {
+		t.Run("should encrypt and decrypt value", func(t *testing.T) {
+			enc := kms.NewEncrypter(&fakeKeyManager{}, "key-1")
+			sealed, err := enc.Encrypt(ctx, "super secret")
+			assert.Nil(t, err)
+			assert.True(t, kms.IsEncrypted(sealed))
+			assert.NotContains(t, sealed, "super secret")
+
+			opened, err := enc.Decrypt(ctx, sealed)
+			assert.Nil(t, err)
+			assert.Equal(t, "super secret", opened)
+		})
+		t.Run("should decrypt values sealed with a previous master key", func(t *testing.T) {
+			km := &fakeKeyManager{}
+			sealed, err := kms.NewEncrypter(km, "key-1").Encrypt(ctx, "super secret")
+			assert.Nil(t, err)
+
+			opened, err := kms.NewEncrypter(km, "key-2").Decrypt(ctx, sealed)
+			assert.Nil(t, err)
+			assert.Equal(t, "super secret", opened)
+		})
+		t.Run("should return plain values as is", func(t *testing.T) {
+			opened, err := kms.NewEncrypter(&fakeKeyManager{}, "key-1").Decrypt(ctx, "plain")
+			assert.Nil(t, err)
+			assert.Equal(t, "plain", opened)
+		})
+		t.Run("should fail when master key is not usable", func(t *testing.T) {
+			km := &fakeKeyManager{}
+			sealed, err := kms.NewEncrypter(km, "key-1").Encrypt(ctx, "super secret")
+			assert.Nil(t, err)
+
+			km.disabled = map[string]bool{"key-1": true}
+			_, err = kms.NewEncrypter(km, "key-2").Decrypt(ctx, sealed)
+			assert.NotNil(t, err)
+		})
+		t.Run("should fail for malformed envelopes", func(t *testing.T) {
+			_, err := kms.NewEncrypter(&fakeKeyManager{}, "key-1").Decrypt(ctx, kms.EnvelopePrefix+"###")
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("ProjectRepository", func(t *testing.T) {
+		enc := kms.NewEncrypter(&fakeKeyManager{}, "key-1")
+
+		t.Run("should only encrypt config flagged as sensitive", func(t *testing.T) {
+			projRepo := new(mock.ProjectRepository)
+			defer projRepo.AssertExpectations(t)
+
+			var saved models.ProjectSpec
+			projRepo.On("Save", tmock.AnythingOfType("models.ProjectSpec")).Run(func(args tmock.Arguments) {
+				saved = args.Get(0).(models.ProjectSpec)
+			}).Return(nil)
+
+			spec := models.ProjectSpec{
+				Name: "t-optimus",
+				Config: map[string]string{
+					models.ProjectSensitiveConfigKeys: "API_TOKEN",
+					"API_TOKEN":                       "token",
+					"BUCKET":                          "gs://bucket",
+				},
+			}
+			repo := kms.NewProjectRepository(projRepo, enc)
+			assert.Nil(t, repo.Save(spec))
+			assert.True(t, kms.IsEncrypted(saved.Config["API_TOKEN"]))
+			assert.Equal(t, "gs://bucket", saved.Config["BUCKET"])
+			// spec of caller is left untouched
+			assert.Equal(t, "token", spec.Config["API_TOKEN"])
+
+			projRepo.On("GetByName", "t-optimus").Return(saved, nil)
+			fetched, err := repo.GetByName("t-optimus")
+			assert.Nil(t, err)
+			assert.Equal(t, spec.Config, fetched.Config)
+		})
+		t.Run("should encrypt config matching sensitive config patterns", func(t *testing.T) {
+			projRepo := new(mock.ProjectRepository)
+			defer projRepo.AssertExpectations(t)
+
+			var saved models.ProjectSpec
+			projRepo.On("Save", tmock.AnythingOfType("models.ProjectSpec")).Run(func(args tmock.Arguments) {
+				saved = args.Get(0).(models.ProjectSpec)
+			}).Return(nil)
+
+			patternEnc := kms.NewEncrypter(&fakeKeyManager{}, "key-1")
+			patternEnc.SensitiveConfig = models.ConfigKeyPatterns{"*_TOKEN"}
+			repo := kms.NewProjectRepository(projRepo, patternEnc)
+			assert.Nil(t, repo.Save(models.ProjectSpec{
+				Name: "t-optimus",
+				Config: map[string]string{
+					"API_TOKEN": "token",
+					"BUCKET":    "gs://bucket",
+				},
+			}))
+			assert.True(t, kms.IsEncrypted(saved.Config["API_TOKEN"]))
+			assert.Equal(t, "gs://bucket", saved.Config["BUCKET"])
+		})
+		t.Run("should decrypt project secrets", func(t *testing.T) {
+			sealed, err := enc.Encrypt(ctx, "gcs-key")
+			assert.Nil(t, err)
+
+			projRepo := new(mock.ProjectRepository)
+			defer projRepo.AssertExpectations(t)
+			projRepo.On("GetAll").Return([]models.ProjectSpec{
+				{
+					Name: "t-optimus",
+					Secret: models.ProjectSecrets{
+						{Name: models.ProjectSecretStorageKey, Value: sealed},
+					},
+				},
+			}, nil)
+
+			specs, err := kms.NewProjectRepository(projRepo, enc).GetAll()
+			assert.Nil(t, err)
+			value, ok := specs[0].Secret.GetByName(models.ProjectSecretStorageKey)
+			assert.True(t, ok)
+			assert.Equal(t, "gcs-key", value)
+		})
+	})
+	t.Run("ProjectJobSpecRepository", func(t *testing.T) {
+		enc := kms.NewEncrypter(&fakeKeyManager{}, "key-1")
+		sealed, err := enc.Encrypt(ctx, "token")
+		assert.Nil(t, err)
+		projectSpec := models.ProjectSpec{
+			Name:   "t-optimus",
+			Config: map[string]string{"API_TOKEN": sealed, "BUCKET": "gs://bucket"},
+		}
+
+		t.Run("should decrypt config of namespace and project of job read by name", func(t *testing.T) {
+			jobSpecRepo := new(mock.ProjectJobSpecRepository)
+			defer jobSpecRepo.AssertExpectations(t)
+			jobSpecRepo.On("GetByName", "job-1").Return(models.JobSpec{Name: "job-1"}, models.NamespaceSpec{
+				Name:        "team-a",
+				Config:      map[string]string{"DB_PASSWORD": sealed},
+				ProjectSpec: projectSpec,
+			}, nil)
+
+			_, namespaceSpec, err := kms.NewProjectJobSpecRepository(jobSpecRepo, enc).GetByName("job-1")
+			assert.Nil(t, err)
+			assert.Equal(t, "token", namespaceSpec.Config["DB_PASSWORD"])
+			assert.Equal(t, "token", namespaceSpec.ProjectSpec.Config["API_TOKEN"])
+			assert.Equal(t, "gs://bucket", namespaceSpec.ProjectSpec.Config["BUCKET"])
+		})
+		t.Run("should decrypt config of project of job read by destination", func(t *testing.T) {
+			jobSpecRepo := new(mock.ProjectJobSpecRepository)
+			defer jobSpecRepo.AssertExpectations(t)
+			jobSpecRepo.On("GetByDestination", "project.dataset.table").Return(models.JobSpec{Name: "job-1"}, projectSpec, nil)
+
+			_, fetched, err := kms.NewProjectJobSpecRepository(jobSpecRepo, enc).GetByDestination("project.dataset.table")
+			assert.Nil(t, err)
+			assert.Equal(t, "token", fetched.Config["API_TOKEN"])
+		})
+	})
+	t.Run("SecretRepository", func(t *testing.T) {
+		t.Run("should store sealed secret values", func(t *testing.T) {
+			enc := kms.NewEncrypter(&fakeKeyManager{}, "key-1")
+			secretRepo := new(mock.ProjectSecretRepository)
+			defer secretRepo.AssertExpectations(t)
+
+			var saved models.ProjectSecretItem
+			secretRepo.On("Save", tmock.AnythingOfType("models.ProjectSecretItem")).Run(func(args tmock.Arguments) {
+				saved = args.Get(0).(models.ProjectSecretItem)
+			}).Return(nil)
+
+			repo := kms.NewSecretRepository(secretRepo, enc)
+			assert.Nil(t, repo.Save(models.ProjectSecretItem{Name: "STORAGE", Value: "gcs-key"}))
+			assert.True(t, kms.IsEncrypted(saved.Value))
+
+			secretRepo.On("GetByName", "STORAGE").Return(saved, nil)
+			item, err := repo.GetByName("STORAGE")
+			assert.Nil(t, err)
+			assert.Equal(t, "gcs-key", item.Value)
+		})
+	})
+}
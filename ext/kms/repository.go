@@ -0,0 +1,244 @@
+package kms
+
+import (
+	"context"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+// encryptConfig seals values of keys project flags as sensitive or which
+// match sensitive config patterns, values already sealed are kept as is
+func (e *Encrypter) encryptConfig(ctx context.Context, config map[string]string, project models.ProjectSpec) (map[string]string, error) {
+	sealed := make(map[string]string, len(config))
+	for key, val := range config {
+		sealed[key] = val
+		if IsEncrypted(val) || !project.IsSensitiveConfig(key, e.SensitiveConfig) {
+			continue
+		}
+		encrypted, err := e.Encrypt(ctx, val)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encrypt config %s", key)
+		}
+		sealed[key] = encrypted
+	}
+	return sealed, nil
+}
+
+// decryptConfig opens all sealed values in config
+func (e *Encrypter) decryptConfig(ctx context.Context, config map[string]string) (map[string]string, error) {
+	opened := make(map[string]string, len(config))
+	for key, val := range config {
+		decrypted, err := e.Decrypt(ctx, val)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt config %s", key)
+		}
+		opened[key] = decrypted
+	}
+	return opened, nil
+}
+
+func (e *Encrypter) decryptSecrets(ctx context.Context, secrets models.ProjectSecrets) (models.ProjectSecrets, error) {
+	opened := models.ProjectSecrets{}
+	for _, item := range secrets {
+		decrypted, err := e.Decrypt(ctx, item.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt secret %s", item.Name)
+		}
+		item.Value = decrypted
+		opened = append(opened, item)
+	}
+	return opened, nil
+}
+
+func (e *Encrypter) decryptProject(ctx context.Context, spec models.ProjectSpec) (models.ProjectSpec, error) {
+	var err error
+	if spec.Config, err = e.decryptConfig(ctx, spec.Config); err != nil {
+		return models.ProjectSpec{}, err
+	}
+	if spec.Secret, err = e.decryptSecrets(ctx, spec.Secret); err != nil {
+		return models.ProjectSpec{}, err
+	}
+	return spec, nil
+}
+
+// projectRepository encrypts sensitive config of projects
+type projectRepository struct {
+	store.ProjectRepository
+	encrypter *Encrypter
+}
+
+func (repo *projectRepository) Save(spec models.ProjectSpec) error {
+	var err error
+	if spec.Config, err = repo.encrypter.encryptConfig(context.Background(), spec.Config, spec); err != nil {
+		return err
+	}
+	return repo.ProjectRepository.Save(spec)
+}
+
+func (repo *projectRepository) GetByName(name string) (models.ProjectSpec, error) {
+	spec, err := repo.ProjectRepository.GetByName(name)
+	if err != nil {
+		return models.ProjectSpec{}, err
+	}
+	return repo.encrypter.decryptProject(context.Background(), spec)
+}
+
+func (repo *projectRepository) GetAll() ([]models.ProjectSpec, error) {
+	specs, err := repo.ProjectRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for idx := range specs {
+		if specs[idx], err = repo.encrypter.decryptProject(context.Background(), specs[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return specs, nil
+}
+
+func NewProjectRepository(repo store.ProjectRepository, encrypter *Encrypter) *projectRepository {
+	return &projectRepository{
+		ProjectRepository: repo,
+		encrypter:         encrypter,
+	}
+}
+
+// namespaceRepository encrypts namespace config overriding sensitive
+// config of its project
+type namespaceRepository struct {
+	store.NamespaceRepository
+	project   models.ProjectSpec
+	encrypter *Encrypter
+}
+
+func (repo *namespaceRepository) Save(spec models.NamespaceSpec) error {
+	var err error
+	if spec.Config, err = repo.encrypter.encryptConfig(context.Background(), spec.Config, repo.project); err != nil {
+		return err
+	}
+	return repo.NamespaceRepository.Save(spec)
+}
+
+func (repo *namespaceRepository) GetByName(name string) (models.NamespaceSpec, error) {
+	spec, err := repo.NamespaceRepository.GetByName(name)
+	if err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	return repo.decrypt(spec)
+}
+
+func (repo *namespaceRepository) GetAll() ([]models.NamespaceSpec, error) {
+	specs, err := repo.NamespaceRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for idx := range specs {
+		if specs[idx], err = repo.decrypt(specs[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return specs, nil
+}
+
+func (repo *namespaceRepository) decrypt(spec models.NamespaceSpec) (models.NamespaceSpec, error) {
+	var err error
+	if spec.Config, err = repo.encrypter.decryptConfig(context.Background(), spec.Config); err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	if spec.ProjectSpec, err = repo.encrypter.decryptProject(context.Background(), spec.ProjectSpec); err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	return spec, nil
+}
+
+func NewNamespaceRepository(repo store.NamespaceRepository, project models.ProjectSpec, encrypter *Encrypter) *namespaceRepository {
+	return &namespaceRepository{
+		NamespaceRepository: repo,
+		project:             project,
+		encrypter:           encrypter,
+	}
+}
+
+// projectJobSpecRepository decrypts config of namespaces and projects jobs
+// are read along with
+type projectJobSpecRepository struct {
+	store.ProjectJobSpecRepository
+	encrypter *Encrypter
+}
+
+func (repo *projectJobSpecRepository) GetByName(name string) (models.JobSpec, models.NamespaceSpec, error) {
+	jobSpec, namespaceSpec, err := repo.ProjectJobSpecRepository.GetByName(name)
+	if err != nil {
+		return models.JobSpec{}, models.NamespaceSpec{}, err
+	}
+	if namespaceSpec.Config, err = repo.encrypter.decryptConfig(context.Background(), namespaceSpec.Config); err != nil {
+		return models.JobSpec{}, models.NamespaceSpec{}, err
+	}
+	if namespaceSpec.ProjectSpec, err = repo.encrypter.decryptProject(context.Background(), namespaceSpec.ProjectSpec); err != nil {
+		return models.JobSpec{}, models.NamespaceSpec{}, err
+	}
+	return jobSpec, namespaceSpec, nil
+}
+
+func (repo *projectJobSpecRepository) GetByDestination(destination string) (models.JobSpec, models.ProjectSpec, error) {
+	jobSpec, projectSpec, err := repo.ProjectJobSpecRepository.GetByDestination(destination)
+	if err != nil {
+		return models.JobSpec{}, models.ProjectSpec{}, err
+	}
+	if projectSpec, err = repo.encrypter.decryptProject(context.Background(), projectSpec); err != nil {
+		return models.JobSpec{}, models.ProjectSpec{}, err
+	}
+	return jobSpec, projectSpec, nil
+}
+
+func NewProjectJobSpecRepository(repo store.ProjectJobSpecRepository, encrypter *Encrypter) *projectJobSpecRepository {
+	return &projectJobSpecRepository{
+		ProjectJobSpecRepository: repo,
+		encrypter:                encrypter,
+	}
+}
+
+// secretRepository encrypts secret values before handing them to
+// underlying repository
+type secretRepository struct {
+	store.ProjectSecretRepository
+	encrypter *Encrypter
+}
+
+func (repo *secretRepository) Save(item models.ProjectSecretItem) error {
+	encrypted, err := repo.encrypter.Encrypt(context.Background(), item.Value)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encrypt secret %s", item.Name)
+	}
+	item.Value = encrypted
+	return repo.ProjectSecretRepository.Save(item)
+}
+
+func (repo *secretRepository) GetByName(name string) (models.ProjectSecretItem, error) {
+	item, err := repo.ProjectSecretRepository.GetByName(name)
+	if err != nil {
+		return models.ProjectSecretItem{}, err
+	}
+	opened, err := repo.encrypter.decryptSecrets(context.Background(), models.ProjectSecrets{item})
+	if err != nil {
+		return models.ProjectSecretItem{}, err
+	}
+	return opened[0], nil
+}
+
+func (repo *secretRepository) GetAll() ([]models.ProjectSecretItem, error) {
+	items, err := repo.ProjectSecretRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return repo.encrypter.decryptSecrets(context.Background(), items)
+}
+
+func NewSecretRepository(repo store.ProjectSecretRepository, encrypter *Encrypter) *secretRepository {
+	return &secretRepository{
+		ProjectSecretRepository: repo,
+		encrypter:               encrypter,
+	}
+}
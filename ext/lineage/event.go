@@ -0,0 +1,148 @@
+package lineage
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// Producer identifies optimus as the source of events
+	Producer = "https://github.com/odpf/optimus"
+
+	runEventSchemaURL = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/RunEvent"
+	jobEventSchemaURL = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/JobEvent"
+
+	sqlFacetSchemaURL           = "https://openlineage.io/spec/facets/1-0-0/SQLJobFacet.json#/$defs/SQLJobFacet"
+	documentationFacetSchemaURL = "https://openlineage.io/spec/facets/1-0-0/DocumentationJobFacet.json#/$defs/DocumentationJobFacet"
+	ownershipFacetSchemaURL     = "https://openlineage.io/spec/facets/1-0-0/OwnershipJobFacet.json#/$defs/OwnershipJobFacet"
+	nominalTimeFacetSchemaURL   = "https://openlineage.io/spec/facets/1-0-0/NominalTimeRunFacet.json#/$defs/NominalTimeRunFacet"
+	errorFacetSchemaURL         = "https://openlineage.io/spec/facets/1-0-0/ErrorMessageRunFacet.json#/$defs/ErrorMessageRunFacet"
+	scheduleFacetSchemaURL      = Producer + "/lineage/ScheduleJobFacet.json"
+
+	// datasetNamespaceDefault is the namespace of datasets generated by
+	// plugins without a scheme
+	datasetNamespaceDefault = "optimus"
+)
+
+// RunState is the transition of a run an event is emitted for
+type RunState string
+
+const (
+	RunStateStart    RunState = "START"
+	RunStateComplete RunState = "COMPLETE"
+	RunStateFail     RunState = "FAIL"
+)
+
+// RunEvent reports a state transition of a job run
+type RunEvent struct {
+	EventType RunState  `json:"eventType"`
+	EventTime time.Time `json:"eventTime"`
+	Run       Run       `json:"run"`
+	Job       Job       `json:"job"`
+	Inputs    []Dataset `json:"inputs"`
+	Outputs   []Dataset `json:"outputs"`
+	Producer  string    `json:"producer"`
+	SchemaURL string    `json:"schemaURL"`
+}
+
+// JobEvent reports the definition of a job as it is deployed
+type JobEvent struct {
+	EventTime time.Time `json:"eventTime"`
+	Job       Job       `json:"job"`
+	Inputs    []Dataset `json:"inputs"`
+	Outputs   []Dataset `json:"outputs"`
+	Producer  string    `json:"producer"`
+	SchemaURL string    `json:"schemaURL"`
+}
+
+type Run struct {
+	RunID  string    `json:"runId"`
+	Facets RunFacets `json:"facets,omitempty"`
+}
+
+type RunFacets struct {
+	NominalTime  *NominalTimeFacet  `json:"nominalTime,omitempty"`
+	ErrorMessage *ErrorMessageFacet `json:"errorMessage,omitempty"`
+}
+
+type Job struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Facets    JobFacets `json:"facets,omitempty"`
+}
+
+type JobFacets struct {
+	SQL           *SQLFacet           `json:"sql,omitempty"`
+	Documentation *DocumentationFacet `json:"documentation,omitempty"`
+	Ownership     *OwnershipFacet     `json:"ownership,omitempty"`
+	Schedule      *ScheduleFacet      `json:"optimus_schedule,omitempty"`
+}
+
+type Dataset struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// BaseFacet holds fields every facet carries
+type BaseFacet struct {
+	Producer  string `json:"_producer"`
+	SchemaURL string `json:"_schemaURL"`
+}
+
+type SQLFacet struct {
+	BaseFacet
+	Query string `json:"query"`
+}
+
+type DocumentationFacet struct {
+	BaseFacet
+	Description string `json:"description"`
+}
+
+type OwnershipFacet struct {
+	BaseFacet
+	Owners []Owner `json:"owners"`
+}
+
+type Owner struct {
+	Name string `json:"name"`
+}
+
+// ScheduleFacet is a custom facet describing when runs of a job are
+// scheduled
+type ScheduleFacet struct {
+	BaseFacet
+	Interval  string     `json:"interval"`
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	Timezone  string     `json:"timezone,omitempty"`
+}
+
+type NominalTimeFacet struct {
+	BaseFacet
+	NominalStartTime time.Time `json:"nominalStartTime"`
+}
+
+type ErrorMessageFacet struct {
+	BaseFacet
+	Message             string `json:"message"`
+	ProgrammingLanguage string `json:"programmingLanguage"`
+}
+
+func newBaseFacet(schemaURL string) BaseFacet {
+	return BaseFacet{
+		Producer:  Producer,
+		SchemaURL: schemaURL,
+	}
+}
+
+// newDataset splits names generated by plugins, e.g.
+// bigquery://project:dataset.table, into the datastore as namespace and
+// the rest as name of the dataset
+func newDataset(name string) Dataset {
+	parts := strings.SplitN(name, "://", 2)
+	if len(parts) != 2 {
+		return Dataset{Namespace: datasetNamespaceDefault, Name: name}
+	}
+	return Dataset{Namespace: parts[0], Name: parts[1]}
+}
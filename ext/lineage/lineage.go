@@ -0,0 +1,266 @@
+// Package lineage emits OpenLineage events of jobs, a JobEvent as a job
+// is deployed and RunEvents as its runs start and finish. Datasets a job
+// reads and writes are the dependencies and destination generated by its
+// task plugin.
+package lineage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// endpoint of the OpenLineage api events are posted to
+	lineagePath = "/api/v1/lineage"
+
+	// sqlAssetName is the asset of a job reported as its sql facet
+	sqlAssetName = "query.sql"
+
+	// layout of scheduled_at sent in events by the scheduler
+	eventScheduledAtLayout = "2006-01-02T15:04:05Z"
+
+	errorProgrammingLanguage = "python"
+)
+
+// HTTPClient is used to post events to the OpenLineage endpoint
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Emitter posts lineage of jobs to an OpenLineage endpoint
+type Emitter struct {
+	url    string
+	apiKey string
+	client HTTPClient
+
+	Now func() time.Time
+}
+
+// Publish emits a JobEvent for each job deployed to namespace, it makes
+// Emitter a models.MetadataService
+func (e *Emitter) Publish(namespace models.NamespaceSpec, jobSpecs []models.JobSpec, _ progress.Observer) error {
+	for _, jobSpec := range jobSpecs {
+		inputs, outputs, err := e.datasets(context.Background(), namespace, jobSpec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate datasets of job %s", jobSpec.Name)
+		}
+		if err := e.post(context.Background(), JobEvent{
+			EventTime: e.Now().UTC(),
+			Job:       e.job(namespace, jobSpec),
+			Inputs:    inputs,
+			Outputs:   outputs,
+			Producer:  Producer,
+			SchemaURL: jobEventSchemaURL,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to emit lineage of job %s", jobSpec.Name)
+		}
+	}
+	return nil
+}
+
+// Tombstone is a noop, OpenLineage has no event retiring a job, it stays
+// as of its last event
+func (e *Emitter) Tombstone(_ models.NamespaceSpec, _ []string, _ progress.Observer) error {
+	return nil
+}
+
+// EmitRunStarted emits a START RunEvent for run of job scheduled at
+func (e *Emitter) EmitRunStarted(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec, scheduledAt time.Time) error {
+	return e.emitRun(ctx, namespace, jobSpec, RunStateStart, scheduledAt, "")
+}
+
+// EmitRunEvent emits a COMPLETE or FAIL RunEvent for events posted by the
+// scheduler as a run finishes, other events are ignored. Run is identified
+// by scheduled_at sent along with the event
+func (e *Emitter) EmitRunEvent(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec, evt models.JobEvent) error {
+	var state RunState
+	switch evt.Type {
+	case models.JobEventTypeSuccess:
+		state = RunStateComplete
+	case models.JobEventTypeFailure:
+		state = RunStateFail
+	default:
+		return nil
+	}
+
+	scheduledAtValue, ok := evt.Value["scheduled_at"]
+	if !ok || scheduledAtValue.GetStringValue() == "" {
+		return errors.Errorf("missing scheduled_at in %s event of job %s", evt.Type, jobSpec.Name)
+	}
+	scheduledAt, err := time.Parse(eventScheduledAtLayout, scheduledAtValue.GetStringValue())
+	if err != nil {
+		return errors.Wrapf(err, "invalid scheduled_at in %s event of job %s", evt.Type, jobSpec.Name)
+	}
+	var message string
+	if messageValue, ok := evt.Value["message"]; ok {
+		message = messageValue.GetStringValue()
+	}
+	return e.emitRun(ctx, namespace, jobSpec, state, scheduledAt, message)
+}
+
+func (e *Emitter) emitRun(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec,
+	state RunState, scheduledAt time.Time, message string) error {
+	inputs, outputs, err := e.datasets(ctx, namespace, jobSpec)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate datasets of job %s", jobSpec.Name)
+	}
+
+	run := Run{
+		RunID: RunID(namespace.ProjectSpec.Name, jobSpec.Name, scheduledAt),
+		Facets: RunFacets{
+			NominalTime: &NominalTimeFacet{
+				BaseFacet:        newBaseFacet(nominalTimeFacetSchemaURL),
+				NominalStartTime: scheduledAt.UTC(),
+			},
+		},
+	}
+	if state == RunStateFail && message != "" {
+		run.Facets.ErrorMessage = &ErrorMessageFacet{
+			BaseFacet:           newBaseFacet(errorFacetSchemaURL),
+			Message:             message,
+			ProgrammingLanguage: errorProgrammingLanguage,
+		}
+	}
+
+	if err := e.post(ctx, RunEvent{
+		EventType: state,
+		EventTime: e.Now().UTC(),
+		Run:       run,
+		Job:       e.job(namespace, jobSpec),
+		Inputs:    inputs,
+		Outputs:   outputs,
+		Producer:  Producer,
+		SchemaURL: runEventSchemaURL,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to emit lineage of run of job %s", jobSpec.Name)
+	}
+	return nil
+}
+
+// job describes jobSpec, jobs are namespaced by their project
+func (e *Emitter) job(namespace models.NamespaceSpec, jobSpec models.JobSpec) Job {
+	facets := JobFacets{
+		Schedule: &ScheduleFacet{
+			BaseFacet: newBaseFacet(scheduleFacetSchemaURL),
+			Interval:  jobSpec.Schedule.Interval,
+			EndDate:   jobSpec.Schedule.EndDate,
+			Timezone:  jobSpec.Schedule.Timezone,
+		},
+	}
+	if !jobSpec.Schedule.StartDate.IsZero() {
+		startDate := jobSpec.Schedule.StartDate
+		facets.Schedule.StartDate = &startDate
+	}
+	if query, err := jobSpec.Assets.GetByName(sqlAssetName); err == nil {
+		facets.SQL = &SQLFacet{
+			BaseFacet: newBaseFacet(sqlFacetSchemaURL),
+			Query:     query.Value,
+		}
+	}
+	if jobSpec.Description != "" {
+		facets.Documentation = &DocumentationFacet{
+			BaseFacet:   newBaseFacet(documentationFacetSchemaURL),
+			Description: jobSpec.Description,
+		}
+	}
+	if jobSpec.Owner != "" {
+		facets.Ownership = &OwnershipFacet{
+			BaseFacet: newBaseFacet(ownershipFacetSchemaURL),
+			Owners:    []Owner{{Name: jobSpec.Owner}},
+		}
+	}
+	return Job{
+		Namespace: namespace.ProjectSpec.Name,
+		Name:      jobSpec.Name,
+		Facets:    facets,
+	}
+}
+
+// datasets returns datasets read and written by job as generated by its
+// task plugin, jobs of plugins which can't generate them have none
+func (e *Emitter) datasets(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec) ([]Dataset, []Dataset, error) {
+	inputs, outputs := []Dataset{}, []Dataset{}
+	if jobSpec.Task.Unit == nil || jobSpec.Task.Unit.DependencyMod == nil {
+		return inputs, outputs, nil
+	}
+	config := models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config)
+	assets := models.PluginAssets{}.FromJobSpec(jobSpec.Assets)
+
+	dependencies, err := jobSpec.Task.Unit.DependencyMod.GenerateDependencies(ctx, models.GenerateDependenciesRequest{
+		Config:  config,
+		Assets:  assets,
+		Project: namespace.ProjectSpec,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, dependency := range dependencies.Dependencies {
+		inputs = append(inputs, newDataset(dependency))
+	}
+
+	destination, err := jobSpec.Task.Unit.DependencyMod.GenerateDestination(ctx, models.GenerateDestinationRequest{
+		Config:  config,
+		Assets:  assets,
+		Project: namespace.ProjectSpec,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if destination.Destination != "" {
+		outputs = append(outputs, newDataset(destination.Destination))
+	}
+	return inputs, outputs, nil
+}
+
+func (e *Emitter) post(ctx context.Context, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url+lineagePath, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected response: %s", resp.Status)
+	}
+	return nil
+}
+
+// RunID identifies the run of job scheduled at, events of the same run
+// carry the same id as runs are registered and finish on different calls
+func RunID(project, job string, scheduledAt time.Time) string {
+	name := fmt.Sprintf("%s/%s/%s", project, job, scheduledAt.UTC().Format(time.RFC3339))
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+// NewEmitter returns an emitter posting events to the OpenLineage api at
+// url, apiKey is sent as bearer token if set
+func NewEmitter(url, apiKey string, client HTTPClient) *Emitter {
+	return &Emitter{
+		url:    strings.TrimSuffix(url, "/"),
+		apiKey: apiKey,
+		client: client,
+		Now:    time.Now,
+	}
+}
@@ -0,0 +1,159 @@
+package lineage_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/lineage"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestEmitter(t *testing.T) {
+	now := time.Date(2021, 7, 12, 8, 0, 0, 0, time.UTC)
+	scheduledAt := time.Date(2021, 7, 12, 7, 40, 0, 0, time.UTC)
+
+	namespaceSpec := models.NamespaceSpec{
+		Name:        "dev-team-1",
+		ProjectSpec: models.ProjectSpec{Name: "t-optimus"},
+	}
+	newJobSpec := func(depMod models.DependencyResolverMod) models.JobSpec {
+		return models.JobSpec{
+			Name:        "transform-tables",
+			Owner:       "data-team",
+			Description: "aggregates orders daily",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Interval:  "0 2 * * *",
+			},
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{DependencyMod: depMod},
+			},
+			Assets: *models.JobAssets{}.New([]models.JobSpecAsset{
+				{Name: "query.sql", Value: "select * from orders"},
+			}),
+		}
+	}
+	newDependencyMod := func(jobSpec models.JobSpec) *mock.DependencyResolverMod {
+		depMod := new(mock.DependencyResolverMod)
+		depMod.On("GenerateDependencies", context.Background(), models.GenerateDependenciesRequest{
+			Config:  models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+			Assets:  models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+			Project: namespaceSpec.ProjectSpec,
+		}).Return(&models.GenerateDependenciesResponse{
+			Dependencies: []string{"bigquery://proj:sales.orders"},
+		}, nil)
+		depMod.On("GenerateDestination", context.Background(), models.GenerateDestinationRequest{
+			Config:  models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+			Assets:  models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+			Project: namespaceSpec.ProjectSpec,
+		}).Return(&models.GenerateDestinationResponse{
+			Destination: "bigquery://proj:sales.daily_orders",
+		}, nil)
+		return depMod
+	}
+	// newServer returns an OpenLineage api recording posted events
+	newServer := func(t *testing.T, events *[]map[string]interface{}) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/lineage", r.URL.Path)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			var event map[string]interface{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&event))
+			*events = append(*events, event)
+			w.WriteHeader(http.StatusCreated)
+		}))
+	}
+
+	t.Run("should emit a job event with facets and datasets of deployed jobs", func(t *testing.T) {
+		var events []map[string]interface{}
+		srv := newServer(t, &events)
+		defer srv.Close()
+
+		depMod := newDependencyMod(newJobSpec(nil))
+		defer depMod.AssertExpectations(t)
+
+		emitter := lineage.NewEmitter(srv.URL+"/", "secret", srv.Client())
+		emitter.Now = func() time.Time { return now }
+		assert.Nil(t, emitter.Publish(namespaceSpec, []models.JobSpec{newJobSpec(depMod)}, nil))
+
+		assert.Len(t, events, 1)
+		event := events[0]
+		assert.Equal(t, "2021-07-12T08:00:00Z", event["eventTime"])
+		assert.Equal(t, lineage.Producer, event["producer"])
+		assert.Nil(t, event["eventType"])
+
+		job := event["job"].(map[string]interface{})
+		assert.Equal(t, "t-optimus", job["namespace"])
+		assert.Equal(t, "transform-tables", job["name"])
+		facets := job["facets"].(map[string]interface{})
+		assert.Equal(t, "select * from orders", facets["sql"].(map[string]interface{})["query"])
+		assert.Equal(t, "aggregates orders daily", facets["documentation"].(map[string]interface{})["description"])
+		assert.Equal(t, "0 2 * * *", facets["optimus_schedule"].(map[string]interface{})["interval"])
+		assert.Equal(t, "2021-01-01T00:00:00Z", facets["optimus_schedule"].(map[string]interface{})["startDate"])
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"namespace": "bigquery", "name": "proj:sales.orders"},
+		}, event["inputs"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"namespace": "bigquery", "name": "proj:sales.daily_orders"},
+		}, event["outputs"])
+	})
+	t.Run("should emit run events of the same run as it starts and fails", func(t *testing.T) {
+		var events []map[string]interface{}
+		srv := newServer(t, &events)
+		defer srv.Close()
+
+		depMod := newDependencyMod(newJobSpec(nil))
+		defer depMod.AssertExpectations(t)
+		jobSpec := newJobSpec(depMod)
+
+		emitter := lineage.NewEmitter(srv.URL, "secret", srv.Client())
+		emitter.Now = func() time.Time { return now }
+		assert.Nil(t, emitter.EmitRunStarted(context.Background(), namespaceSpec, jobSpec, scheduledAt))
+
+		eventValues, _ := structpb.NewStruct(map[string]interface{}{
+			"scheduled_at": "2021-07-12T07:40:00Z",
+			"message":      "table not found",
+		})
+		assert.Nil(t, emitter.EmitRunEvent(context.Background(), namespaceSpec, jobSpec, models.JobEvent{
+			Type:  models.JobEventTypeFailure,
+			Value: eventValues.GetFields(),
+		}))
+
+		assert.Len(t, events, 2)
+		runID := lineage.RunID("t-optimus", "transform-tables", scheduledAt)
+		for _, event := range events {
+			run := event["run"].(map[string]interface{})
+			assert.Equal(t, runID, run["runId"])
+			nominalTime := run["facets"].(map[string]interface{})["nominalTime"].(map[string]interface{})
+			assert.Equal(t, "2021-07-12T07:40:00Z", nominalTime["nominalStartTime"])
+		}
+		assert.Equal(t, "START", events[0]["eventType"])
+		assert.Equal(t, "FAIL", events[1]["eventType"])
+		errorMessage := events[1]["run"].(map[string]interface{})["facets"].(map[string]interface{})["errorMessage"]
+		assert.Equal(t, "table not found", errorMessage.(map[string]interface{})["message"])
+	})
+	t.Run("should ignore events which don't finish a run", func(t *testing.T) {
+		emitter := lineage.NewEmitter("http://localhost:1", "", http.DefaultClient)
+		assert.Nil(t, emitter.EmitRunEvent(context.Background(), namespaceSpec, newJobSpec(nil), models.JobEvent{
+			Type: models.JobEventTypeSLAMiss,
+		}))
+	})
+	t.Run("should fail if the api rejects the event", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		emitter := lineage.NewEmitter(srv.URL, "", srv.Client())
+		err := emitter.EmitRunStarted(context.Background(), namespaceSpec, newJobSpec(nil), scheduledAt)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "401 Unauthorized")
+	})
+}
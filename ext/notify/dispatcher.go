@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxRetryBackoff caps the wait between attempts of a delivery
+	maxRetryBackoff = 30 * time.Second
+
+	// maxErrorBodySize is how much of a failed response is kept in errors
+	maxErrorBodySize = 512
+)
+
+var ErrDispatcherClosed = errors.New("notification dispatcher is closed")
+
+// HTTPClient is used to deliver notifications
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Delivery is a json payload posted to url
+type Delivery struct {
+	URL     string
+	Headers map[string]string
+	Payload []byte
+	// Description names the delivery in errors, it should not carry secrets
+	Description string
+}
+
+type DispatcherConfig struct {
+	// QueueSize is the number of deliveries waiting to be sent, sending
+	// fails while the queue is full
+	QueueSize int
+	// MaxAttempts is the number of times a delivery is tried to be sent
+	// before it is dropped
+	MaxAttempts int
+	// Backoff is the wait after the first failed attempt, it doubles with
+	// every attempt
+	Backoff time.Duration
+}
+
+// Dispatcher posts deliveries from a worker so notifying doesn't hold up
+// scheduler callbacks. Deliveries failing with network errors, 429 or 5xx
+// responses are retried with exponential backoff, other failures and
+// deliveries which run out of attempts are reported to the error handler
+type Dispatcher struct {
+	client     HTTPClient
+	config     DispatcherConfig
+	errHandler func(error)
+
+	queue     chan Delivery
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// Send queues delivery to be posted
+func (d *Dispatcher) Send(delivery Delivery) error {
+	select {
+	case <-d.stop:
+		return ErrDispatcherClosed
+	default:
+	}
+
+	select {
+	case d.queue <- delivery:
+		return nil
+	default:
+		return errors.Errorf("notification queue is full, dropping %s", delivery.Description)
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+	for {
+		select {
+		case delivery := <-d.queue:
+			d.deliver(delivery)
+		case <-d.stop:
+			// deliver what was queued before closing
+			for {
+				select {
+				case delivery := <-d.queue:
+					d.deliver(delivery)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(delivery Delivery) {
+	retryable, err := d.post(delivery)
+	backoff := d.config.Backoff
+	for attempt := 1; err != nil && retryable && attempt < d.config.MaxAttempts; attempt++ {
+		select {
+		case <-time.After(backoff):
+		case <-d.stop:
+			// retry right away while draining
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+		retryable, err = d.post(delivery)
+	}
+	if err != nil {
+		d.errHandler(errors.Wrapf(err, "failed to send %s", delivery.Description))
+	}
+}
+
+// post sends delivery once, returns if a failure is worth retrying
+func (d *Dispatcher) post(delivery Delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range delivery.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return false, nil
+	}
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("unexpected response %s: %s", resp.Status, string(body))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+// Close stops accepting deliveries and waits for queued ones to be sent
+func (d *Dispatcher) Close() error {
+	d.closeOnce.Do(func() {
+		close(d.stop)
+	})
+	d.wg.Wait()
+	return nil
+}
+
+func NewDispatcher(client HTTPClient, config DispatcherConfig, errHandler func(error)) *Dispatcher {
+	if config.QueueSize < 1 {
+		config.QueueSize = 1
+	}
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	d := &Dispatcher{
+		client:     client,
+		config:     config,
+		errHandler: errHandler,
+		queue:      make(chan Delivery, config.QueueSize),
+		stop:       make(chan struct{}),
+	}
+	d.wg.Add(1)
+	go d.run()
+	return d
+}
@@ -0,0 +1,273 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/odpf/optimus/ext/notify"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProjectSMTPHostKey is the smtp server emails of a project are sent
+	// through, email notifications fail if not set
+	ProjectSMTPHostKey = "SMTP_HOST"
+	// ProjectSMTPPortKey is the port of smtp server - default 587
+	ProjectSMTPPortKey = "SMTP_PORT"
+	// ProjectSMTPFromKey is the sender address of emails of a project
+	ProjectSMTPFromKey = "SMTP_FROM"
+	// ProjectSMTPUsernameKey is the user emails are sent as, emails are
+	// sent without authentication if not set
+	ProjectSMTPUsernameKey = "SMTP_USERNAME"
+	// ProjectSMTPPasswordSecret is the secret of a project holding the
+	// password of smtp user
+	ProjectSMTPPasswordSecret = "NOTIFY_SMTP_PASSWORD"
+	// ProjectDigestIntervalKey turns on digest mode for a project, events
+	// sent to a recipient are collected for the interval, e.g. 15m, and sent
+	// as a single email
+	ProjectDigestIntervalKey = "NOTIFY_EMAIL_DIGEST_INTERVAL"
+
+	// DefaultFlushInterval is how often queued emails are looked at, emails
+	// not in digest mode wait for it at most
+	DefaultFlushInterval = 10 * time.Second
+
+	defaultSMTPPort = "587"
+
+	maxSubjectLength = 120
+)
+
+// SendMailFunc sends an email, smtp.SendMail is used by the server
+type SendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+type smtpConfig struct {
+	host     string
+	port     string
+	from     string
+	username string
+	password string
+}
+
+type batchKey struct {
+	project   string
+	recipient string
+}
+
+type batch struct {
+	smtp     smtpConfig
+	since    time.Time
+	due      time.Time
+	digest   bool
+	messages []string
+}
+
+// Notifier emails events of jobs to recipients declared as routes of their
+// channels, e.g. email://data-eng@example.io. Events are sent through smtp
+// server of project; in digest mode events of a project are collected per
+// recipient and sent together once the digest interval passes, which keeps
+// a burst of failures from flooding inboxes
+type Notifier struct {
+	sendMail      SendMailFunc
+	flushInterval time.Duration
+	errHandler    func(error)
+
+	mu      sync.Mutex
+	batches map[batchKey]*batch
+
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+
+	Now func() time.Time
+}
+
+func (n *Notifier) Notify(ctx context.Context, attr models.NotifyAttrs) error {
+	recipient, err := mail.ParseAddress(strings.TrimSpace(attr.Route))
+	if err != nil {
+		return errors.Wrapf(err, "invalid email recipient %s", attr.Route)
+	}
+	project := attr.Namespace.ProjectSpec
+	config, err := projectSMTPConfig(project)
+	if err != nil {
+		return err
+	}
+	var digestInterval time.Duration
+	if value, ok := project.Config[ProjectDigestIntervalKey]; ok && value != "" {
+		if digestInterval, err = time.ParseDuration(value); err != nil || digestInterval < 0 {
+			return errors.Errorf("invalid %s of project %s: %s", ProjectDigestIntervalKey, project.Name, value)
+		}
+	}
+	message, err := notify.Render(attr)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	select {
+	case <-n.stop:
+		return errors.New("email notifier is closed")
+	default:
+	}
+	key := batchKey{project: project.Name, recipient: recipient.Address}
+	b, ok := n.batches[key]
+	if !ok {
+		now := n.Now()
+		b = &batch{
+			since:  now,
+			due:    now.Add(digestInterval),
+			digest: digestInterval > 0,
+		}
+		n.batches[key] = b
+	}
+	b.smtp = config
+	b.messages = append(b.messages, message)
+	return nil
+}
+
+func projectSMTPConfig(project models.ProjectSpec) (smtpConfig, error) {
+	config := smtpConfig{
+		host:     project.Config[ProjectSMTPHostKey],
+		port:     project.Config[ProjectSMTPPortKey],
+		from:     project.Config[ProjectSMTPFromKey],
+		username: project.Config[ProjectSMTPUsernameKey],
+	}
+	if config.host == "" || config.from == "" {
+		return smtpConfig{}, errors.Errorf("project %s needs %s and %s config to send emails", project.Name,
+			ProjectSMTPHostKey, ProjectSMTPFromKey)
+	}
+	if _, err := mail.ParseAddress(config.from); err != nil {
+		return smtpConfig{}, errors.Wrapf(err, "invalid %s of project %s", ProjectSMTPFromKey, project.Name)
+	}
+	if config.port == "" {
+		config.port = defaultSMTPPort
+	}
+	if password, ok := project.Secret.GetByName(ProjectSMTPPasswordSecret); ok {
+		config.password = password
+	}
+	return config, nil
+}
+
+func (n *Notifier) run() {
+	defer n.wg.Done()
+	ticker := time.NewTicker(n.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			n.flush(false)
+		case <-n.stop:
+			n.flush(true)
+			return
+		}
+	}
+}
+
+// flush sends batches which are due, or all of them if forced
+func (n *Notifier) flush(force bool) {
+	n.mu.Lock()
+	now := n.Now()
+	due := map[batchKey]*batch{}
+	for key, b := range n.batches {
+		if force || !now.Before(b.due) {
+			due[key] = b
+			delete(n.batches, key)
+		}
+	}
+	n.mu.Unlock()
+
+	for key, b := range due {
+		if err := n.send(key, b); err != nil {
+			n.errHandler(errors.Wrapf(err, "failed to email %d events of project %s", len(b.messages), key.project))
+		}
+	}
+}
+
+func (n *Notifier) send(key batchKey, b *batch) error {
+	subject := headerValue(b.messages[0])
+	if len(b.messages) > 1 || b.digest {
+		subject = fmt.Sprintf("[optimus] %d events of jobs of project %s", len(b.messages), key.project)
+	}
+	if len(subject) > maxSubjectLength {
+		subject = subject[:maxSubjectLength]
+	}
+
+	var body strings.Builder
+	if b.digest {
+		fmt.Fprintf(&body, "Events of jobs of project %s since %s\r\n\r\n", key.project, b.since.UTC().Format(time.RFC3339))
+	}
+	for _, message := range b.messages {
+		body.WriteString(strings.ReplaceAll(message, "\n", "\r\n"))
+		body.WriteString("\r\n\r\n")
+	}
+
+	headers := map[string]string{
+		"From":         b.smtp.from,
+		"To":           key.recipient,
+		"Subject":      subject,
+		"MIME-Version": "1.0",
+		"Content-Type": "text/plain; charset=UTF-8",
+	}
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var msg strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&msg, "%s: %s\r\n", name, headers[name])
+	}
+	msg.WriteString("\r\n")
+	msg.WriteString(body.String())
+
+	var auth smtp.Auth
+	if b.smtp.username != "" {
+		auth = smtp.PlainAuth("", b.smtp.username, b.smtp.password, b.smtp.host)
+	}
+	from, _ := mail.ParseAddress(b.smtp.from)
+	return n.sendMail(net.JoinHostPort(b.smtp.host, b.smtp.port), auth, from.Address, []string{key.recipient},
+		[]byte(msg.String()))
+}
+
+// headerValue keeps the first line of value so it can't inject headers
+func headerValue(value string) string {
+	if i := strings.IndexAny(value, "\r\n"); i >= 0 {
+		value = value[:i]
+	}
+	return value
+}
+
+// Close stops the notifier after emailing every queued event, digests are
+// sent early
+func (n *Notifier) Close() error {
+	n.closeOnce.Do(func() {
+		n.mu.Lock()
+		close(n.stop)
+		n.mu.Unlock()
+	})
+	n.wg.Wait()
+	return nil
+}
+
+// NewNotifier returns a notifier sending emails with sendMail, queued
+// emails are looked at every flush interval
+func NewNotifier(sendMail SendMailFunc, flushInterval time.Duration, errHandler func(error)) *Notifier {
+	n := &Notifier{
+		sendMail:      sendMail,
+		flushInterval: flushInterval,
+		errHandler:    errHandler,
+		batches:       map[batchKey]*batch{},
+		stop:          make(chan struct{}),
+		Now:           time.Now,
+	}
+	n.wg.Add(1)
+	go n.run()
+	return n
+}
@@ -0,0 +1,109 @@
+package email_test
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/notify/email"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+type mailer struct {
+	sent []sentMail
+}
+
+func (m *mailer) SendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	m.sent = append(m.sent, sentMail{addr: addr, from: from, to: to, msg: string(msg)})
+	return nil
+}
+
+func TestEmail(t *testing.T) {
+	eventValues, _ := structpb.NewStruct(map[string]interface{}{
+		"scheduled_at": "2021-07-12T07:40:00Z",
+	})
+	newAttr := func(config map[string]string, jobName string) models.NotifyAttrs {
+		return models.NotifyAttrs{
+			Namespace: models.NamespaceSpec{
+				Name: "game_jam",
+				ProjectSpec: models.ProjectSpec{
+					Name:   "a-data-project",
+					Config: config,
+				},
+			},
+			JobSpec: models.JobSpec{Name: jobName},
+			JobEvent: models.JobEvent{
+				Type:  models.JobEventTypeFailure,
+				Value: eventValues.GetFields(),
+			},
+			Route: "data-eng@example.io",
+		}
+	}
+	smtpConfig := map[string]string{
+		email.ProjectSMTPHostKey: "smtp.example.io",
+		email.ProjectSMTPFromKey: "Optimus <optimus@example.io>",
+	}
+
+	t.Run("should email each event through smtp server of project", func(t *testing.T) {
+		m := &mailer{}
+		notifier := email.NewNotifier(m.SendMail, time.Hour, func(err error) {
+			assert.Nil(t, err)
+		})
+		assert.Nil(t, notifier.Notify(context.Background(), newAttr(smtpConfig, "transform-tables")))
+		assert.Nil(t, notifier.Close())
+
+		assert.Equal(t, 1, len(m.sent))
+		assert.Equal(t, "smtp.example.io:587", m.sent[0].addr)
+		assert.Equal(t, "optimus@example.io", m.sent[0].from)
+		assert.Equal(t, []string{"data-eng@example.io"}, m.sent[0].to)
+		assert.Contains(t, m.sent[0].msg, "Subject: [a-data-project/game_jam] job transform-tables failed "+
+			"for run scheduled at 2021-07-12T07:40:00Z\r\n")
+	})
+	t.Run("should email events of project together in digest mode", func(t *testing.T) {
+		config := map[string]string{
+			email.ProjectDigestIntervalKey: "15m",
+		}
+		for key, value := range smtpConfig {
+			config[key] = value
+		}
+		m := &mailer{}
+		notifier := email.NewNotifier(m.SendMail, time.Hour, func(err error) {
+			assert.Nil(t, err)
+		})
+		assert.Nil(t, notifier.Notify(context.Background(), newAttr(config, "transform-tables")))
+		assert.Nil(t, notifier.Notify(context.Background(), newAttr(config, "load-tables")))
+		assert.Nil(t, notifier.Close())
+
+		assert.Equal(t, 1, len(m.sent))
+		assert.Contains(t, m.sent[0].msg, "Subject: [optimus] 2 events of jobs of project a-data-project\r\n")
+		assert.Contains(t, m.sent[0].msg, "job transform-tables failed")
+		assert.Contains(t, m.sent[0].msg, "job load-tables failed")
+	})
+	t.Run("should return error if project has no smtp config", func(t *testing.T) {
+		notifier := email.NewNotifier((&mailer{}).SendMail, time.Hour, func(err error) {})
+		defer notifier.Close()
+
+		err := notifier.Notify(context.Background(), newAttr(map[string]string{}, "transform-tables"))
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), email.ProjectSMTPHostKey))
+	})
+	t.Run("should return error if recipient is not an email address", func(t *testing.T) {
+		notifier := email.NewNotifier((&mailer{}).SendMail, time.Hour, func(err error) {})
+		defer notifier.Close()
+
+		attr := newAttr(smtpConfig, "transform-tables")
+		attr.Route = "#optimus-devs"
+		assert.NotNil(t, notifier.Notify(context.Background(), attr))
+	})
+}
@@ -1,3 +1,107 @@
+// Package notify holds what notifiers of job events share, rendering of
+// messages from templates declared in notify config of jobs and delivery of
+// them over http with retries. Notifiers of each channel live in their own
+// package, e.g. slack, pagerduty and webhook
 package notify
 
-import _ "github.com/odpf/optimus/ext/notify/slack"
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TemplateConfigKey is the notify config holding the template of
+	// messages sent for the event, e.g.:
+	// template: "{{.Job}} failed, see {{.LogURL}}"
+	TemplateConfigKey = "template"
+
+	// DefaultTemplate renders messages of events which don't configure a
+	// template
+	DefaultTemplate = `[{{.Project}}/{{.Namespace}}] job {{.Job}} {{.Title}}` +
+		`{{with .ScheduledAt}} for run scheduled at {{.}}{{end}}{{with .Message}}: {{.}}{{end}}`
+)
+
+var eventTitles = map[models.JobEventType]string{
+	models.JobEventTypeSLAMiss: "missed its SLA",
+	models.JobEventTypeFailure: "failed",
+	models.JobEventTypeRetry:   "is being retried",
+	models.JobEventTypeSuccess: "succeeded",
+	models.JobEventTypeStart:   "started",
+	models.JobEventTypeSlowRun: "ran slower than usual",
+}
+
+// MessageData is what templates of messages are rendered with
+type MessageData struct {
+	Project   string
+	Namespace string
+	Job       string
+	Owner     string
+	// Event is the type of event, e.g. failure, sla_miss
+	Event string
+	// Title describes the event in a few words, e.g. failed
+	Title       string
+	ScheduledAt string
+	TaskID      string
+	LogURL      string
+	// Message is the failure message sent by scheduler, exception is used
+	// if scheduler didn't send one
+	Message string
+	// Value has every value sent by scheduler along with the event
+	Value map[string]interface{}
+}
+
+// NewMessageData collects what templates can use from the event
+func NewMessageData(attr models.NotifyAttrs) MessageData {
+	data := MessageData{
+		Project:   attr.Namespace.ProjectSpec.Name,
+		Namespace: attr.Namespace.Name,
+		Job:       attr.JobSpec.Name,
+		Owner:     attr.JobSpec.Owner,
+		Event:     string(attr.JobEvent.Type),
+		Title:     string(attr.JobEvent.Type),
+		Value:     map[string]interface{}{},
+	}
+	if title, ok := eventTitles[attr.JobEvent.Type]; ok {
+		data.Title = title
+	}
+	for key, value := range attr.JobEvent.Value {
+		data.Value[key] = value.AsInterface()
+	}
+	data.ScheduledAt = stringValue(data.Value, "scheduled_at")
+	data.TaskID = stringValue(data.Value, "task_id")
+	data.LogURL = stringValue(data.Value, "log_url")
+	data.Message = stringValue(data.Value, "message")
+	if data.Message == "" {
+		data.Message = stringValue(data.Value, "exception")
+	}
+	return data
+}
+
+// Render renders the message of event with the template configured on the
+// notifier of job, DefaultTemplate is used if none is configured
+func Render(attr models.NotifyAttrs) (string, error) {
+	text := DefaultTemplate
+	if configured, ok := attr.Config[TemplateConfigKey]; ok && strings.TrimSpace(configured) != "" {
+		text = configured
+	}
+	tmpl, err := template.New("notify").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid notify template of job %s", attr.JobSpec.Name)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, NewMessageData(attr)); err != nil {
+		return "", errors.Wrapf(err, "failed to render notify template of job %s", attr.JobSpec.Name)
+	}
+	return buf.String(), nil
+}
+
+func stringValue(values map[string]interface{}, key string) string {
+	if value, ok := values[key].(string); ok {
+		return value
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package notify_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/notify"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRender(t *testing.T) {
+	eventValues, _ := structpb.NewStruct(map[string]interface{}{
+		"scheduled_at": "2021-07-12T07:40:00Z",
+		"log_url":      "http://airflow.example.io/log",
+		"exception":    "task exited with 1",
+	})
+	attr := models.NotifyAttrs{
+		Namespace: models.NamespaceSpec{
+			Name:        "game_jam",
+			ProjectSpec: models.ProjectSpec{Name: "a-data-project"},
+		},
+		JobSpec: models.JobSpec{Name: "transform-tables"},
+		JobEvent: models.JobEvent{
+			Type:  models.JobEventTypeFailure,
+			Value: eventValues.GetFields(),
+		},
+	}
+	t.Run("should render default template if job has none", func(t *testing.T) {
+		message, err := notify.Render(attr)
+		assert.Nil(t, err)
+		assert.Equal(t, "[a-data-project/game_jam] job transform-tables failed for run scheduled at "+
+			"2021-07-12T07:40:00Z: task exited with 1", message)
+	})
+	t.Run("should render template configured on notifier of job", func(t *testing.T) {
+		localAttr := attr
+		localAttr.Config = map[string]string{
+			notify.TemplateConfigKey: "{{.Job}} {{.Event}}, see {{.LogURL}} {{.Value.scheduled_at}}",
+		}
+		message, err := notify.Render(localAttr)
+		assert.Nil(t, err)
+		assert.Equal(t, "transform-tables failure, see http://airflow.example.io/log 2021-07-12T07:40:00Z", message)
+	})
+	t.Run("should return error if template is invalid", func(t *testing.T) {
+		localAttr := attr
+		localAttr.Config = map[string]string{
+			notify.TemplateConfigKey: "{{.Job",
+		}
+		_, err := notify.Render(localAttr)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Run("should retry deliveries failing with server errors", func(t *testing.T) {
+		var mu sync.Mutex
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		var errs []error
+		dispatcher := notify.NewDispatcher(server.Client(), notify.DispatcherConfig{
+			QueueSize:   1,
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+		}, func(err error) {
+			errs = append(errs, err)
+		})
+		assert.Nil(t, dispatcher.Send(notify.Delivery{
+			URL:     server.URL,
+			Headers: map[string]string{"Authorization": "Bearer token"},
+			Payload: []byte(`{}`),
+		}))
+		assert.Nil(t, dispatcher.Close())
+		assert.Equal(t, 3, calls)
+		assert.Empty(t, errs)
+	})
+	t.Run("should not retry deliveries rejected by client errors", func(t *testing.T) {
+		var mu sync.Mutex
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		var errs []error
+		dispatcher := notify.NewDispatcher(server.Client(), notify.DispatcherConfig{
+			QueueSize:   1,
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+		}, func(err error) {
+			errs = append(errs, err)
+		})
+		assert.Nil(t, dispatcher.Send(notify.Delivery{URL: server.URL, Payload: []byte(`{}`)}))
+		assert.Nil(t, dispatcher.Close())
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 1, len(errs))
+	})
+	t.Run("should fail to send once closed", func(t *testing.T) {
+		dispatcher := notify.NewDispatcher(http.DefaultClient, notify.DispatcherConfig{}, func(err error) {})
+		assert.Nil(t, dispatcher.Close())
+		assert.Equal(t, notify.ErrDispatcherClosed, dispatcher.Send(notify.Delivery{}))
+	})
+}
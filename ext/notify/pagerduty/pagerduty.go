@@ -0,0 +1,129 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/odpf/optimus/ext/notify"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultEventsURL is the PagerDuty Events API v2 endpoint
+	DefaultEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+	// SeverityConfigKey is the notify config overriding severity of
+	// incidents, one of critical, error, warning, info
+	SeverityConfigKey = "severity"
+
+	// summaries longer than this are rejected by PagerDuty
+	maxSummaryLength = 1024
+)
+
+var validSeverities = map[string]bool{
+	"critical": true,
+	"error":    true,
+	"warning":  true,
+	"info":     true,
+}
+
+// Event is a trigger event of PagerDuty Events API v2
+type Event struct {
+	RoutingKey  string       `json:"routing_key"`
+	EventAction string       `json:"event_action"`
+	DedupKey    string       `json:"dedup_key"`
+	Payload     EventPayload `json:"payload"`
+	Links       []EventLink  `json:"links,omitempty"`
+}
+
+type EventPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Component     string                 `json:"component"`
+	Group         string                 `json:"group"`
+	Class         string                 `json:"class"`
+	CustomDetails map[string]interface{} `json:"custom_details"`
+}
+
+type EventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// Notifier triggers PagerDuty incidents for events of jobs, route of
+// channels is the integration routing key, e.g. pagerduty://<routing key>.
+// Events of the same run are deduplicated into one incident
+type Notifier struct {
+	eventsURL  string
+	dispatcher *notify.Dispatcher
+}
+
+func (n *Notifier) Notify(ctx context.Context, attr models.NotifyAttrs) error {
+	routingKey := strings.TrimSpace(attr.Route)
+	if routingKey == "" {
+		return errors.New("missing routing key of pagerduty integration")
+	}
+	severity := "error"
+	if attr.JobEvent.Type == models.JobEventTypeSLAMiss {
+		severity = "warning"
+	}
+	if configured, ok := attr.Config[SeverityConfigKey]; ok && configured != "" {
+		if !validSeverities[configured] {
+			return errors.Errorf("invalid pagerduty severity %s of job %s", configured, attr.JobSpec.Name)
+		}
+		severity = configured
+	}
+
+	summary, err := notify.Render(attr)
+	if err != nil {
+		return err
+	}
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength]
+	}
+	data := notify.NewMessageData(attr)
+	event := Event{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		DedupKey:    fmt.Sprintf("optimus/%s/%s/%s/%s", data.Project, data.Job, data.Event, data.ScheduledAt),
+		Payload: EventPayload{
+			Summary:       summary,
+			Source:        fmt.Sprintf("optimus/%s/%s", data.Project, data.Namespace),
+			Severity:      severity,
+			Component:     data.Job,
+			Group:         data.Namespace,
+			Class:         data.Event,
+			CustomDetails: data.Value,
+		},
+	}
+	if data.LogURL != "" {
+		event.Links = append(event.Links, EventLink{Href: data.LogURL, Text: "View log"})
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return n.dispatcher.Send(notify.Delivery{
+		URL:         n.eventsURL,
+		Payload:     payload,
+		Description: fmt.Sprintf("%s event of job %s to pagerduty", data.Event, data.Job),
+	})
+}
+
+// Close waits for queued events to be sent
+func (n *Notifier) Close() error {
+	return n.dispatcher.Close()
+}
+
+// NewNotifier returns a notifier sending events to eventsURL, usually
+// DefaultEventsURL
+func NewNotifier(eventsURL string, client notify.HTTPClient, config notify.DispatcherConfig, errHandler func(error)) *Notifier {
+	return &Notifier{
+		eventsURL:  eventsURL,
+		dispatcher: notify.NewDispatcher(client, config, errHandler),
+	}
+}
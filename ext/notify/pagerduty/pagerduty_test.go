@@ -0,0 +1,78 @@
+package pagerduty_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/notify"
+	"github.com/odpf/optimus/ext/notify/pagerduty"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestPagerDuty(t *testing.T) {
+	eventValues, _ := structpb.NewStruct(map[string]interface{}{
+		"scheduled_at": "2021-07-12T07:40:00Z",
+		"log_url":      "http://airflow.example.io/log",
+	})
+	attr := models.NotifyAttrs{
+		Namespace: models.NamespaceSpec{
+			Name:        "game_jam",
+			ProjectSpec: models.ProjectSpec{Name: "a-data-project"},
+		},
+		JobSpec: models.JobSpec{Name: "transform-tables"},
+		JobEvent: models.JobEvent{
+			Type:  models.JobEventTypeFailure,
+			Value: eventValues.GetFields(),
+		},
+		Route: "r0ut1ngk3y",
+	}
+	t.Run("should trigger an incident deduplicated by run of job", func(t *testing.T) {
+		var events []pagerduty.Event
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event pagerduty.Event
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&event))
+			events = append(events, event)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		notifier := pagerduty.NewNotifier(server.URL, server.Client(), notify.DispatcherConfig{
+			QueueSize:   1,
+			MaxAttempts: 1,
+			Backoff:     time.Millisecond,
+		}, func(err error) {
+			assert.Nil(t, err)
+		})
+		localAttr := attr
+		localAttr.Config = map[string]string{
+			pagerduty.SeverityConfigKey: "critical",
+		}
+		assert.Nil(t, notifier.Notify(context.Background(), localAttr))
+		assert.Nil(t, notifier.Close())
+
+		assert.Equal(t, 1, len(events))
+		assert.Equal(t, "r0ut1ngk3y", events[0].RoutingKey)
+		assert.Equal(t, "trigger", events[0].EventAction)
+		assert.Equal(t, "optimus/a-data-project/transform-tables/failure/2021-07-12T07:40:00Z", events[0].DedupKey)
+		assert.Equal(t, "critical", events[0].Payload.Severity)
+		assert.Equal(t, "[a-data-project/game_jam] job transform-tables failed for run scheduled at 2021-07-12T07:40:00Z",
+			events[0].Payload.Summary)
+		assert.Equal(t, []pagerduty.EventLink{{Href: "http://airflow.example.io/log", Text: "View log"}}, events[0].Links)
+	})
+	t.Run("should return error if severity is invalid", func(t *testing.T) {
+		notifier := pagerduty.NewNotifier("http://localhost", http.DefaultClient, notify.DispatcherConfig{}, func(err error) {})
+		defer notifier.Close()
+
+		localAttr := attr
+		localAttr.Config = map[string]string{
+			pagerduty.SeverityConfigKey: "urgent",
+		}
+		assert.NotNil(t, notifier.Notify(context.Background(), localAttr))
+	})
+}
@@ -164,9 +164,13 @@ func buildMessageBlocks(events []event) []api.Block {
 					}
 				}
 			}
-		case models.JobEventTypeFailure:
+		case models.JobEventTypeFailure, models.JobEventTypeRetry:
+			title := "Failure"
+			if evt.meta.Type == models.JobEventTypeRetry {
+				title = "Retry"
+			}
 			heading := api.NewTextBlockObject("plain_text",
-				fmt.Sprintf("[Job] Failure | %s/%s", evt.projectName, evt.namespaceName), true, false)
+				fmt.Sprintf("[Job] %s | %s/%s", title, evt.projectName, evt.namespaceName), true, false)
 			blocks = append(blocks, api.NewHeaderBlock(heading))
 
 			if scheduledAt, ok := evt.meta.Value["scheduled_at"]; ok && scheduledAt.GetStringValue() != "" {
@@ -178,6 +182,23 @@ func buildMessageBlocks(events []event) []api.Block {
 			if taskID, ok := evt.meta.Value["task_id"]; ok && taskID.GetStringValue() != "" {
 				fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Task ID:*\n%s", taskID.GetStringValue()), false, false))
 			}
+			if attempt, ok := evt.meta.Value["attempt"]; ok && evt.meta.Type == models.JobEventTypeRetry {
+				fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Attempt:*\n%d", int(attempt.GetNumberValue())), false, false))
+			}
+		case models.JobEventTypeSlowRun:
+			heading := api.NewTextBlockObject("plain_text",
+				fmt.Sprintf("[Job] Slow Run | %s/%s", evt.projectName, evt.namespaceName), true, false)
+			blocks = append(blocks, api.NewHeaderBlock(heading))
+
+			if scheduledAt, ok := evt.meta.Value["scheduled_at"]; ok && scheduledAt.GetStringValue() != "" {
+				fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Scheduled At:*\n%s", scheduledAt.GetStringValue()), false, false))
+			}
+			if duration, ok := evt.meta.Value["duration"]; ok && duration.GetStringValue() != "" {
+				fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Duration:*\n%s", duration.GetStringValue()), false, false))
+			}
+			if median, ok := evt.meta.Value["median"]; ok && median.GetStringValue() != "" {
+				fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Usual Duration:*\n%s", median.GetStringValue()), false, false))
+			}
 		default:
 			// unknown event
 			continue
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/odpf/optimus/ext/notify"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TokenSecretName is the project secret sent as bearer token to
+	// webhooks, nothing is sent if project doesn't have it
+	TokenSecretName = "NOTIFY_WEBHOOK"
+)
+
+// Payload is posted as json to webhooks of jobs
+type Payload struct {
+	Project   string `json:"project"`
+	Namespace string `json:"namespace"`
+	Job       string `json:"job"`
+	Owner     string `json:"owner"`
+	Event     string `json:"event"`
+	// Message is rendered from the template of notifier
+	Message string                 `json:"message"`
+	Value   map[string]interface{} `json:"value"`
+}
+
+// Notifier posts events to webhooks declared as routes of channels of
+// jobs, e.g. webhook://https://example.io/hooks/optimus
+type Notifier struct {
+	dispatcher *notify.Dispatcher
+}
+
+func (n *Notifier) Notify(ctx context.Context, attr models.NotifyAttrs) error {
+	hookURL, err := url.Parse(attr.Route)
+	if err != nil || (hookURL.Scheme != "http" && hookURL.Scheme != "https") || hookURL.Host == "" {
+		return errors.Errorf("invalid webhook %s, it should be an http or https url", attr.Route)
+	}
+
+	message, err := notify.Render(attr)
+	if err != nil {
+		return err
+	}
+	data := notify.NewMessageData(attr)
+	payload, err := json.Marshal(Payload{
+		Project:   data.Project,
+		Namespace: data.Namespace,
+		Job:       data.Job,
+		Owner:     data.Owner,
+		Event:     data.Event,
+		Message:   message,
+		Value:     data.Value,
+	})
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	if token, ok := attr.Namespace.ProjectSpec.Secret.GetByName(TokenSecretName); ok {
+		headers["Authorization"] = "Bearer " + token
+	}
+	return n.dispatcher.Send(notify.Delivery{
+		URL:         hookURL.String(),
+		Headers:     headers,
+		Payload:     payload,
+		Description: fmt.Sprintf("%s event of job %s to webhook %s", data.Event, data.Job, hookURL.Host),
+	})
+}
+
+// Close waits for queued events to be posted
+func (n *Notifier) Close() error {
+	return n.dispatcher.Close()
+}
+
+func NewNotifier(client notify.HTTPClient, config notify.DispatcherConfig, errHandler func(error)) *Notifier {
+	return &Notifier{
+		dispatcher: notify.NewDispatcher(client, config, errHandler),
+	}
+}
@@ -0,0 +1,84 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/notify"
+	"github.com/odpf/optimus/ext/notify/webhook"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestWebhook(t *testing.T) {
+	eventValues, _ := structpb.NewStruct(map[string]interface{}{
+		"scheduled_at": "2021-07-12T07:40:00Z",
+	})
+	attr := models.NotifyAttrs{
+		Namespace: models.NamespaceSpec{
+			Name: "game_jam",
+			ProjectSpec: models.ProjectSpec{
+				Name: "a-data-project",
+				Secret: models.ProjectSecrets{
+					{Name: webhook.TokenSecretName, Value: "s3cr3t"},
+				},
+			},
+		},
+		JobSpec: models.JobSpec{Name: "transform-tables", Owner: "data-eng"},
+		JobEvent: models.JobEvent{
+			Type:  models.JobEventTypeSLAMiss,
+			Value: eventValues.GetFields(),
+		},
+		Config: map[string]string{
+			notify.TemplateConfigKey: "{{.Job}} {{.Title}}",
+		},
+	}
+	t.Run("should post event with message rendered from template", func(t *testing.T) {
+		var payloads []webhook.Payload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/hooks/optimus", r.URL.Path)
+			assert.Equal(t, "Bearer s3cr3t", r.Header.Get("Authorization"))
+			var payload webhook.Payload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+			payloads = append(payloads, payload)
+		}))
+		defer server.Close()
+
+		notifier := webhook.NewNotifier(server.Client(), notify.DispatcherConfig{
+			QueueSize:   1,
+			MaxAttempts: 1,
+			Backoff:     time.Millisecond,
+		}, func(err error) {
+			assert.Nil(t, err)
+		})
+		localAttr := attr
+		localAttr.Route = server.URL + "/hooks/optimus"
+		assert.Nil(t, notifier.Notify(context.Background(), localAttr))
+		assert.Nil(t, notifier.Close())
+
+		assert.Equal(t, []webhook.Payload{{
+			Project:   "a-data-project",
+			Namespace: "game_jam",
+			Job:       "transform-tables",
+			Owner:     "data-eng",
+			Event:     "sla_miss",
+			Message:   "transform-tables missed its SLA",
+			Value: map[string]interface{}{
+				"scheduled_at": "2021-07-12T07:40:00Z",
+			},
+		}}, payloads)
+	})
+	t.Run("should return error if route is not an http url", func(t *testing.T) {
+		notifier := webhook.NewNotifier(http.DefaultClient, notify.DispatcherConfig{}, func(err error) {})
+		defer notifier.Close()
+
+		localAttr := attr
+		localAttr.Route = "#optimus-devs"
+		assert.NotNil(t, notifier.Notify(context.Background(), localAttr))
+	})
+}
@@ -15,6 +15,9 @@ import (
 
 	_ "embed"
 
+	"github.com/kushsharma/parallel"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
 	"github.com/pkg/errors"
@@ -28,6 +31,9 @@ var resBaseDAG []byte
 
 const (
 	baseLibFileName = "__lib.py"
+	baseDAGFileName = "base_dag.py"
+	initFileName    = "__init__.py"
+	templatesDir    = "templates"
 	dagStatusURL    = "api/experimental/dags/%s/dag_runs"
 	dagRunClearURL  = "clear&dag_id=%s&start_date=%s&end_date=%s"
 )
@@ -40,15 +46,26 @@ type ObjectWriterFactory interface {
 	New(ctx context.Context, writerPath, writerSecret string) (store.ObjectWriter, error)
 }
 
+// JobRepoFactory provides storage of compiled jobs at the given path
+type JobRepoFactory interface {
+	New(ctx context.Context, storagePath, storageSecret, jobsExtension string) (store.JobRepository, error)
+}
+
 type scheduler struct {
 	objWriterFac ObjectWriterFactory
 	httpClient   HTTPClient
+	jobRepoFac   JobRepoFactory
+	compiler     models.JobCompiler
 }
 
-func NewScheduler(ow ObjectWriterFactory, httpClient HTTPClient) *scheduler {
+// NewScheduler constructs a scheduler deploying dags compiled with links
+// to optimus reachable at hostname
+func NewScheduler(ow ObjectWriterFactory, httpClient HTTPClient, jobRepoFac JobRepoFactory, hostname string) *scheduler {
 	return &scheduler{
 		objWriterFac: ow,
 		httpClient:   httpClient,
+		jobRepoFac:   jobRepoFac,
+		compiler:     job.NewCompiler(resBaseDAG, hostname),
 	}
 }
 
@@ -68,17 +85,14 @@ func (a *scheduler) GetTemplate() []byte {
 	return resBaseDAG
 }
 
-func (a *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec) error {
-	storagePath, ok := proj.Config[models.ProjectStoragePathKey]
-	if !ok {
-		return errors.Errorf("%s config not configured for project %s", models.ProjectStoragePathKey, proj.Name)
-	}
-	storageSecret, ok := proj.Secret.GetByName(models.ProjectSecretStorageKey)
-	if !ok {
-		return errors.Errorf("%s secret not configured for project %s", models.ProjectSecretStorageKey, proj.Name)
+// Bootstrap uploads artifacts shared by all dags of a project to its
+// storage, replacing the ones uploaded by older versions
+func (a *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec, obs progress.Observer) error {
+	bucket, prefix, err := bucketOf(proj)
+	if err != nil {
+		return err
 	}
-
-	p, err := url.Parse(storagePath)
+	storagePath, storageSecret, err := storageOf(proj)
 	if err != nil {
 		return err
 	}
@@ -86,12 +100,42 @@ func (a *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec) erro
 	if err != nil {
 		return errors.Errorf("object writer failed for %s", proj.Name)
 	}
-	return a.migrateLibFileToWriter(ctx, objectWriter, p.Hostname(), filepath.Join(strings.Trim(p.Path, "/"), a.GetJobsDir(), baseLibFileName))
+
+	for _, art := range a.sharedArtifacts() {
+		err := writeObject(ctx, objectWriter, bucket, filepath.Join(prefix, art.path), art.content)
+		notify(obs, &job.EventSchedulerArtifactUpload{Project: proj.Name, Name: art.path, Err: err})
+		if err != nil {
+			return errors.Wrapf(err, "failed to upload %s for %s", art.path, proj.Name)
+		}
+	}
+	return nil
 }
 
-func (a *scheduler) migrateLibFileToWriter(ctx context.Context, objWriter store.ObjectWriter, bucket, objDir string) (err error) {
-	// copy to fs
-	dst, err := objWriter.NewWriter(ctx, bucket, objDir)
+// ValidateProject checks storage of project is supported and points to a
+// bucket if the storage needs one
+func (a *scheduler) ValidateProject(proj models.ProjectSpec) error {
+	_, _, err := bucketOf(proj)
+	return err
+}
+
+type artifact struct {
+	path    string
+	content []byte
+}
+
+// sharedArtifacts returns files dags of a project depend on, paths are
+// relative to storage of project. Base dag is kept outside of dags dir
+// only for reference as it is not a valid dag itself
+func (a *scheduler) sharedArtifacts() []artifact {
+	return []artifact{
+		{path: filepath.Join(a.GetJobsDir(), baseLibFileName), content: resSharedLib},
+		{path: filepath.Join(a.GetJobsDir(), initFileName)},
+		{path: filepath.Join(templatesDir, baseDAGFileName), content: resBaseDAG},
+	}
+}
+
+func writeObject(ctx context.Context, objWriter store.ObjectWriter, bucket, objPath string, content []byte) (err error) {
+	dst, err := objWriter.NewWriter(ctx, bucket, objPath)
 	if err != nil {
 		return err
 	}
@@ -105,10 +149,82 @@ func (a *scheduler) migrateLibFileToWriter(ctx context.Context, objWriter store.
 		}
 	}()
 
-	_, err = io.Copy(dst, bytes.NewBuffer(resSharedLib))
+	_, err = io.Copy(dst, bytes.NewBuffer(content))
 	return
 }
 
+func (a *scheduler) DeployJobs(ctx context.Context, namespace models.NamespaceSpec, jobs []models.JobSpec,
+	obs progress.Observer) error {
+	jobRepo, err := a.jobRepoFor(ctx, namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+
+	runner := parallel.NewRunner(parallel.WithTicket(job.ConcurrentTicketPerSec))
+	for _, jobSpec := range jobs {
+		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				compiledJob, err := a.compiler.Compile(namespace, currentSpec)
+				if err != nil {
+					return nil, err
+				}
+				notify(obs, &job.EventJobSpecCompile{
+					Name: currentSpec.Name,
+				})
+				return nil, jobRepo.Save(ctx, compiledJob)
+			}
+		}(jobSpec))
+	}
+
+	for runIdx, state := range runner.Run() {
+		notify(obs, &job.EventJobUpload{
+			Job: jobs[runIdx],
+			Err: state.Err,
+		})
+	}
+	return nil
+}
+
+func (a *scheduler) DeleteJobs(ctx context.Context, namespace models.NamespaceSpec, jobNames []string,
+	obs progress.Observer) error {
+	if len(jobNames) == 0 {
+		return nil
+	}
+	jobRepo, err := a.jobRepoFor(ctx, namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+	for _, jobName := range jobNames {
+		if err := jobRepo.Delete(ctx, namespace, jobName); err != nil {
+			return err
+		}
+		notify(obs, &job.EventJobRemoteDelete{Name: jobName})
+	}
+	return nil
+}
+
+func (a *scheduler) ListJobs(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	jobRepo, err := a.jobRepoFor(ctx, namespace.ProjectSpec)
+	if err != nil {
+		return nil, err
+	}
+	return jobRepo.ListNames(ctx, namespace)
+}
+
+// jobRepoFor provides storage of compiled dags in jobs dir of project
+func (a *scheduler) jobRepoFor(ctx context.Context, proj models.ProjectSpec) (store.JobRepository, error) {
+	storagePath, storageSecret, err := storageOf(proj)
+	if err != nil {
+		return nil, err
+	}
+	p, err := url.Parse(storagePath)
+	if err != nil {
+		return nil, err
+	}
+	p.Path = filepath.Join(p.Path, a.GetJobsDir())
+	return a.jobRepoFac.New(ctx, p.String(), storageSecret, a.GetJobsExtension())
+}
+
 func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus,
 	error) {
 	schdHost, ok := projSpec.Config[models.ProjectSchedulerHost]
@@ -239,3 +355,57 @@ func (a *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.Project
 
 	return requestedJobStatus, nil
 }
+
+// storageSchemes are storages dags can be kept in, mapped to whether their
+// paths need a bucket. Git storages name the transport after the prefix,
+// mem storage is only available with in-memory store of the server
+var storageSchemes = map[string]bool{
+	"gs":        true,
+	"s3":        true,
+	"file":      false,
+	"mem":       false,
+	"git+https": true,
+	"git+http":  true,
+	"git+ssh":   true,
+	"git+file":  false,
+}
+
+// bucketOf parses bucket and path inside it from storage config of project
+func bucketOf(proj models.ProjectSpec) (string, string, error) {
+	storagePath, ok := proj.Config[models.ProjectStoragePathKey]
+	if !ok {
+		return "", "", errors.Errorf("%s config not configured for project %s", models.ProjectStoragePathKey, proj.Name)
+	}
+	p, err := url.Parse(storagePath)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid %s config for project %s", models.ProjectStoragePathKey, proj.Name)
+	}
+	needsBucket, ok := storageSchemes[p.Scheme]
+	if !ok {
+		return "", "", errors.Errorf("unsupported %s config for project %s, scheme should be one of gs, s3, file, mem, git+<transport>: %s",
+			models.ProjectStoragePathKey, proj.Name, storagePath)
+	}
+	if needsBucket && p.Hostname() == "" {
+		return "", "", errors.Errorf("%s config for project %s should be of the form <scheme>://<bucket>/<path>: %s",
+			models.ProjectStoragePathKey, proj.Name, storagePath)
+	}
+	return p.Hostname(), strings.Trim(p.Path, "/"), nil
+}
+
+// storageOf returns path and secret of storage where project dags are kept,
+// secret is empty for storages using credentials of the server
+func storageOf(proj models.ProjectSpec) (string, string, error) {
+	storagePath, ok := proj.Config[models.ProjectStoragePathKey]
+	if !ok {
+		return "", "", errors.Errorf("%s config not configured for project %s", models.ProjectStoragePathKey, proj.Name)
+	}
+	storageSecret, _ := proj.Secret.GetByName(models.ProjectSecretStorageKey)
+	return storagePath, storageSecret, nil
+}
+
+func notify(obs progress.Observer, evt progress.Event) {
+	if obs == nil {
+		return
+	}
+	obs.Notify(evt)
+}
@@ -3,7 +3,6 @@ package airflow_test
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"testing"
@@ -44,7 +43,7 @@ func (m *MockedObjectWriterFactory) New(ctx context.Context, path, writerSecret
 func TestAirflow(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Bootstrap", func(t *testing.T) {
-		t.Run("should successfully bootstrap for gcs buckets", func(t *testing.T) {
+		t.Run("should successfully upload shared artifacts for gcs buckets", func(t *testing.T) {
 			var out bytes.Buffer
 			wc := new(mocked.WriteCloser)
 			defer wc.AssertExpectations(t)
@@ -59,11 +58,16 @@ func TestAirflow(t *testing.T) {
 			defer owf.AssertExpectations(t)
 
 			bucket := "mybucket"
-			objectPath := fmt.Sprintf("hello/%s/%s", "dags", "__lib.py")
-			ow.On("NewWriter", ctx, bucket, objectPath).Return(wc, nil)
+			ow.On("NewWriter", ctx, bucket, "hello/dags/__lib.py").Return(wc, nil)
+			ow.On("NewWriter", ctx, bucket, "hello/dags/__init__.py").Return(wc, nil)
+			ow.On("NewWriter", ctx, bucket, "hello/templates/base_dag.py").Return(wc, nil)
 
-			air := airflow.NewScheduler(owf, nil)
-			err := air.Bootstrap(context.Background(), models.ProjectSpec{
+			obs := new(mocked.PipelineLogObserver)
+			obs.On("Notify", mock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			air := airflow.NewScheduler(owf, nil, nil, "")
+			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name: "proj-name",
 				Config: map[string]string{
 					models.ProjectStoragePathKey: "gs://mybucket/hello",
@@ -74,24 +78,70 @@ func TestAirflow(t *testing.T) {
 						Value: "test-secret",
 					},
 				},
-			})
+			}, obs)
 			assert.Nil(t, err)
+			obs.AssertCalled(t, "Notify", &job.EventSchedulerArtifactUpload{Project: "proj-name", Name: "dags/__lib.py"})
+			obs.AssertNumberOfCalls(t, "Notify", 3)
 		})
 		t.Run("should fail if no storage config is set", func(t *testing.T) {
-			air := airflow.NewScheduler(nil, nil)
+			air := airflow.NewScheduler(nil, nil, nil, "")
 			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name:   "proj-name",
 				Config: map[string]string{},
-			})
+			}, nil)
 			assert.NotNil(t, err)
 		})
 		t.Run("should fail for unsupported storage interfaces", func(t *testing.T) {
-			air := airflow.NewScheduler(nil, nil)
+			air := airflow.NewScheduler(nil, nil, nil, "")
 			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name: "proj-name",
 				Config: map[string]string{
 					models.ProjectStoragePathKey: "xxx://mybucket/dags",
 				},
+			}, nil)
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("ValidateProject", func(t *testing.T) {
+		t.Run("should accept storage paths pointing to a bucket", func(t *testing.T) {
+			air := airflow.NewScheduler(nil, nil, nil, "")
+			err := air.ValidateProject(models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://mybucket/hello",
+				},
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should accept local and git storage paths without a bucket", func(t *testing.T) {
+			air := airflow.NewScheduler(nil, nil, nil, "")
+			for _, storagePath := range []string{"file:///var/optimus/hello", "git+file:///srv/dags.git/hello"} {
+				err := air.ValidateProject(models.ProjectSpec{
+					Name: "proj-name",
+					Config: map[string]string{
+						models.ProjectStoragePathKey: storagePath,
+					},
+				})
+				assert.Nil(t, err)
+			}
+		})
+		t.Run("should fail for unsupported storages", func(t *testing.T) {
+			air := airflow.NewScheduler(nil, nil, nil, "")
+			err := air.ValidateProject(models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "xxx://mybucket/hello",
+				},
+			})
+			assert.NotNil(t, err)
+		})
+		t.Run("should fail for storage paths without a bucket", func(t *testing.T) {
+			air := airflow.NewScheduler(nil, nil, nil, "")
+			err := air.ValidateProject(models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "mybucket/hello",
+				},
 			})
 			assert.NotNil(t, err)
 		})
@@ -132,7 +182,7 @@ func TestAirflow(t *testing.T) {
 				},
 			}
 
-			air := airflow.NewScheduler(nil, client)
+			air := airflow.NewScheduler(nil, client, nil, "")
 			status, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -155,7 +205,7 @@ func TestAirflow(t *testing.T) {
 				},
 			}
 
-			air := airflow.NewScheduler(nil, client)
+			air := airflow.NewScheduler(nil, client, nil, "")
 			status, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -191,7 +241,7 @@ func TestAirflow(t *testing.T) {
 				},
 			}
 
-			air := airflow.NewScheduler(nil, client)
+			air := airflow.NewScheduler(nil, client, nil, "")
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -213,7 +263,7 @@ func TestAirflow(t *testing.T) {
 				},
 			}
 
-			air := airflow.NewScheduler(nil, client)
+			air := airflow.NewScheduler(nil, client, nil, "")
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -276,7 +326,7 @@ func TestAirflow(t *testing.T) {
 				},
 			}
 
-			air := airflow.NewScheduler(nil, client)
+			air := airflow.NewScheduler(nil, client, nil, "")
 			status, err := air.GetDagRunStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -320,7 +370,7 @@ func TestAirflow(t *testing.T) {
 				},
 			}
 
-			air := airflow.NewScheduler(nil, client)
+			air := airflow.NewScheduler(nil, client, nil, "")
 			status, err := air.GetDagRunStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -3,6 +3,8 @@
 from typing import Any, Callable, Dict, Optional
 from datetime import datetime, timedelta, timezone
 
+import pendulum
+
 from airflow.models import DAG, Variable, DagRun, DagModel, TaskInstance, BaseOperator, XCom, XCOM_RETURN_KEY
 from airflow.kubernetes.secret import Secret
 from airflow.configuration import conf
@@ -189,6 +191,10 @@ wait_foo__dash__inter__dash__dep__dash__job = CrossTenantDependencySensor(
 wait_foo__dash__intra__dash__dep__dash__job >> transformation_bq
 wait_foo__dash__inter__dash__dep__dash__job >> transformation_bq
 
+# upstream sensors -> pre hooks, they prepare inputs of base transformation task
+wait_foo__dash__intra__dash__dep__dash__job >> hook_transporter
+wait_foo__dash__inter__dash__dep__dash__job >> hook_transporter
+
 # set inter-dependencies between task and hooks
 hook_transporter >> transformation_bq
 transformation_bq >> hook_predator
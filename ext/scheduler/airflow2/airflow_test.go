@@ -45,7 +45,7 @@ func (m *MockedObjectWriterFactory) New(ctx context.Context, path, writerSecret
 func TestAirflow2(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Bootstrap", func(t *testing.T) {
-		t.Run("should successfully bootstrap for gcs buckets", func(t *testing.T) {
+		t.Run("should successfully upload shared artifacts for gcs buckets", func(t *testing.T) {
 			var out bytes.Buffer
 			wc := new(mocked.WriteCloser)
 			defer wc.AssertExpectations(t)
@@ -60,11 +60,16 @@ func TestAirflow2(t *testing.T) {
 			defer owf.AssertExpectations(t)
 
 			bucket := "mybucket"
-			objectPath := fmt.Sprintf("hello/%s/%s", "dags", "__lib.py")
-			ow.On("NewWriter", ctx, bucket, objectPath).Return(wc, nil)
+			ow.On("NewWriter", ctx, bucket, "hello/dags/__lib.py").Return(wc, nil)
+			ow.On("NewWriter", ctx, bucket, "hello/dags/__init__.py").Return(wc, nil)
+			ow.On("NewWriter", ctx, bucket, "hello/templates/base_dag.py").Return(wc, nil)
 
-			air := airflow2.NewScheduler(owf, nil)
-			err := air.Bootstrap(context.Background(), models.ProjectSpec{
+			obs := new(mocked.PipelineLogObserver)
+			obs.On("Notify", mock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			air := airflow2.NewScheduler(owf, nil, nil, "")
+			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name: "proj-name",
 				Config: map[string]string{
 					models.ProjectStoragePathKey: "gs://mybucket/hello",
@@ -75,28 +80,175 @@ func TestAirflow2(t *testing.T) {
 						Value: "test-secret",
 					},
 				},
-			})
+			}, obs)
 			assert.Nil(t, err)
+			obs.AssertCalled(t, "Notify", &job.EventSchedulerArtifactUpload{Project: "proj-name", Name: "dags/__lib.py"})
+			obs.AssertNumberOfCalls(t, "Notify", 3)
 		})
 		t.Run("should fail if no storage config is set", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil, "")
 			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name:   "proj-name",
 				Config: map[string]string{},
-			})
+			}, nil)
 			assert.NotNil(t, err)
 		})
 		t.Run("should fail for unsupported storage interfaces", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil, "")
 			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name: "proj-name",
 				Config: map[string]string{
 					models.ProjectStoragePathKey: "xxx://mybucket/dags",
 				},
+			}, nil)
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("ValidateProject", func(t *testing.T) {
+		t.Run("should accept storage paths pointing to a bucket", func(t *testing.T) {
+			air := airflow2.NewScheduler(nil, nil, nil, "")
+			err := air.ValidateProject(models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://mybucket/hello",
+				},
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should accept local and git storage paths without a bucket", func(t *testing.T) {
+			air := airflow2.NewScheduler(nil, nil, nil, "")
+			for _, storagePath := range []string{"file:///var/optimus/hello", "git+file:///srv/dags.git/hello"} {
+				err := air.ValidateProject(models.ProjectSpec{
+					Name: "proj-name",
+					Config: map[string]string{
+						models.ProjectStoragePathKey: storagePath,
+					},
+				})
+				assert.Nil(t, err)
+			}
+		})
+		t.Run("should fail for unsupported storages", func(t *testing.T) {
+			air := airflow2.NewScheduler(nil, nil, nil, "")
+			err := air.ValidateProject(models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "xxx://mybucket/hello",
+				},
+			})
+			assert.NotNil(t, err)
+		})
+		t.Run("should fail for storage paths without a bucket", func(t *testing.T) {
+			air := airflow2.NewScheduler(nil, nil, nil, "")
+			err := air.ValidateProject(models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "mybucket/hello",
+				},
 			})
 			assert.NotNil(t, err)
 		})
 	})
+	t.Run("DeployJobs", func(t *testing.T) {
+		execUnit := new(mocked.BasePlugin)
+		execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:  "bq",
+			Image: "example.io/namespace/image:latest",
+		}, nil)
+		namespaceSpec := models.NamespaceSpec{
+			Name: "namespace-1",
+			ProjectSpec: models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://mybucket/hello",
+				},
+				Secret: []models.ProjectSecretItem{
+					{
+						Name:  models.ProjectSecretStorageKey,
+						Value: "test-secret",
+					},
+				},
+			},
+		}
+		jobSpec := models.JobSpec{
+			Name:  "job-1",
+			Owner: "mee@mee",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+				Interval:  "* * * * *",
+			},
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{Base: execUnit},
+			},
+		}
+
+		t.Run("should compile and upload jobs to dags dir of project storage", func(t *testing.T) {
+			jobRepo := new(mocked.JobRepository)
+			jobRepo.On("Save", ctx, mock.MatchedBy(func(compiled models.Job) bool {
+				return compiled.Name == jobSpec.Name && len(compiled.Contents) > 0
+			})).Return(nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mocked.JobRepoFactory)
+			jobRepoFac.On("New", ctx, "gs://mybucket/hello/dags", "test-secret", ".py").Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			obs := new(mocked.PipelineLogObserver)
+			obs.On("Notify", mock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			air := airflow2.NewScheduler(nil, nil, jobRepoFac, "http://optimus.example.io")
+			err := air.DeployJobs(ctx, namespaceSpec, []models.JobSpec{jobSpec}, obs)
+			assert.Nil(t, err)
+			obs.AssertCalled(t, "Notify", &job.EventJobUpload{Job: jobSpec})
+		})
+		t.Run("should fail if no storage config is set", func(t *testing.T) {
+			air := airflow2.NewScheduler(nil, nil, nil, "")
+			err := air.DeployJobs(ctx, models.NamespaceSpec{
+				ProjectSpec: models.ProjectSpec{Name: "proj-name"},
+			}, []models.JobSpec{jobSpec}, nil)
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("DeleteJobs", func(t *testing.T) {
+		namespaceSpec := models.NamespaceSpec{
+			Name: "namespace-1",
+			ProjectSpec: models.ProjectSpec{
+				Name: "proj-name",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://mybucket/hello",
+				},
+				Secret: []models.ProjectSecretItem{
+					{
+						Name:  models.ProjectSecretStorageKey,
+						Value: "test-secret",
+					},
+				},
+			},
+		}
+		t.Run("should delete deployed jobs from project storage", func(t *testing.T) {
+			jobRepo := new(mocked.JobRepository)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"job-1", "job-2"}, nil)
+			jobRepo.On("Delete", ctx, namespaceSpec, "job-2").Return(nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mocked.JobRepoFactory)
+			jobRepoFac.On("New", ctx, "gs://mybucket/hello/dags", "test-secret", ".py").Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			air := airflow2.NewScheduler(nil, nil, jobRepoFac, "")
+			deployed, err := air.ListJobs(ctx, namespaceSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-1", "job-2"}, deployed)
+			assert.Nil(t, air.DeleteJobs(ctx, namespaceSpec, []string{"job-2"}, nil))
+		})
+		t.Run("should not look for storage when there is nothing to delete", func(t *testing.T) {
+			jobRepoFac := new(mocked.JobRepoFactory)
+			defer jobRepoFac.AssertExpectations(t)
+
+			air := airflow2.NewScheduler(nil, nil, jobRepoFac, "")
+			assert.Nil(t, air.DeleteJobs(ctx, namespaceSpec, []string{}, nil))
+		})
+	})
 	t.Run("GetJobStatus", func(t *testing.T) {
 		host := "http://airflow.example.io"
 
@@ -136,7 +288,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, client, nil, "")
 			status, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -166,7 +318,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, client, nil, "")
 			status, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -184,7 +336,7 @@ func TestAirflow2(t *testing.T) {
 			assert.Len(t, status, 0)
 		})
 		t.Run("should fail if not scheduler secret registered", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil, "")
 			_, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -213,7 +365,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, client, nil, "")
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -241,7 +393,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, client, nil, "")
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -258,7 +410,7 @@ func TestAirflow2(t *testing.T) {
 			assert.NotNil(t, err)
 		})
 		t.Run("should fail if not scheduler secret registered", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil, "")
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -340,7 +492,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, client, nil, "")
 			status, err := air.GetDagRunStatus(ctx, projectSpec, jobName, startDateTime, endDateTime, batchSize)
 
 			assert.Nil(t, err)
@@ -420,7 +572,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, client, nil, "")
 			status, err := air.GetDagRunStatus(ctx, projectSpec, jobName, startDateTime, endDateTime, batchSize)
 
 			assert.Nil(t, err)
@@ -438,7 +590,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, client, nil, "")
 			status, err := air.GetDagRunStatus(ctx, projectSpec, jobName, startDateTime, endDateTime, batchSize)
 
 			assert.NotNil(t, err)
@@ -2,6 +2,7 @@ package airflow2
 
 import (
 	_ "embed"
+	"strings"
 	"testing"
 	"time"
 
@@ -182,7 +183,7 @@ func TestCompiler(t *testing.T) {
 
 	t.Run("Compile", func(t *testing.T) {
 		t.Run("should compile basic template without any error", func(t *testing.T) {
-			scheduler := NewScheduler(nil, nil)
+			scheduler := NewScheduler(nil, nil, nil, "")
 			com := job.NewCompiler(
 				scheduler.GetTemplate(),
 				"http://airflow.example.io",
@@ -191,5 +192,261 @@ func TestCompiler(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, string(CompiledTemplate), string(job.Contents))
 		})
+		t.Run("should compile schedule dates in timezone of job", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localSpec := spec
+			localSpec.Schedule.Timezone = "Asia/Kolkata"
+			job, err := com.Compile(namespaceSpec, localSpec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+    "start_date": pendulum.parse("2000-11-11T00:00:00", tz="Asia/Kolkata"),
+    "end_date": pendulum.parse("2020-11-11T00:00:00", tz="Asia/Kolkata"),
+    "on_failure_callback": optimus_failure_notify,`)
+		})
+		t.Run("should compile optional dependencies as soft failing sensors", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localSpec := spec
+			localSpec.Dependencies = map[string]models.JobSpecDependency{
+				"destination1": {Job: &depSpecIntra, Project: &projSpec, Type: models.JobSpecDependencyTypeIntra, Optional: true},
+				"destination2": {Job: &depSpecInter, Project: &externalProjSpec, Type: models.JobSpecDependencyTypeInter},
+			}
+			job, err := com.Compile(namespaceSpec, localSpec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+    timeout = SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    soft_fail = True,
+    skip_on_upstream_failure = True,
+    dag=dag
+)`)
+			assert.Equal(t, 1, strings.Count(string(job.Contents), "soft_fail"))
+			assert.Contains(t, string(job.Contents), `
+    trigger_rule="none_failed",`)
+		})
+		t.Run("should compile wait settings of dependencies in sensors", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localSpec := spec
+			localSpec.Dependencies = map[string]models.JobSpecDependency{
+				"destination1": {Job: &depSpecIntra, Project: &projSpec, Type: models.JobSpecDependencyTypeIntra,
+					Timeout: 2 * time.Hour, PokeInterval: 5 * time.Minute},
+				"destination2": {Job: &depSpecInter, Project: &externalProjSpec, Type: models.JobSpecDependencyTypeInter,
+					Timeout: 30 * time.Minute},
+			}
+			job, err := com.Compile(namespaceSpec, localSpec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+    poke_interval = 300,
+    timeout = 7200,`)
+			assert.Contains(t, string(job.Contents), `
+    poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
+    timeout=1800,`)
+		})
+		t.Run("should compile http dependencies into http sensors", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localSpec := spec
+			localSpec.HTTPDependencies = []models.JobSpecHTTPDependency{
+				{
+					Name:          "billing-export",
+					URL:           "https://billing.example.io/exports/{{ ds }}",
+					Headers:       map[string]string{"Authorization": "Bearer token"},
+					JSONPath:      "$.status",
+					ExpectedValue: "done",
+				},
+			}
+			job, err := com.Compile(namespaceSpec, localSpec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+from __lib import HttpDependencySensor`)
+			assert.Contains(t, string(job.Contents), `
+wait_http_billing__dash__export = HttpDependencySensor(
+    url="https://billing.example.io/exports/{{ ds }}",
+    headers={"Authorization":"Bearer token"},
+    expected_status=200,
+    json_path="$.status",
+    expected_value="done",
+    mode="reschedule",`)
+			assert.Contains(t, string(job.Contents), `
+    task_id="wait_http_billing-export",`)
+			assert.Contains(t, string(job.Contents), `
+wait_http_billing__dash__export >> transformation_bq`)
+		})
+		t.Run("should compile external dependencies into sensors on their optimus server", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localSpec := spec
+			localSpec.ExternalDependencies = []models.JobSpecExternalDependency{
+				{Host: "https://optimus.partner.example.io", ProjectName: "partner-project", JobName: "billing.export"},
+			}
+			job, err := com.Compile(namespaceSpec, localSpec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+wait_external_partner__dash__project__billing__dot__export = CrossTenantDependencySensor(
+    optimus_hostname="https://optimus.partner.example.io",
+    optimus_project="partner-project",
+    optimus_job="billing.export",`)
+			assert.Contains(t, string(job.Contents), `
+    task_id="wait_external_partner-project-billing.export",`)
+			assert.Contains(t, string(job.Contents), `
+wait_external_partner__dash__project__billing__dot__export >> transformation_bq`)
+		})
+		t.Run("should not compile trigger rule of task without optional dependencies", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			job, err := com.Compile(namespaceSpec, spec)
+			assert.Nil(t, err)
+			assert.NotContains(t, string(job.Contents), "none_failed")
+		})
+		t.Run("should compile runtime env and labels of job", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localSpec := spec
+			localSpec.Runtime = models.JobSpecRuntime{
+				Env: map[string]string{"REGION": "asia", "LOG_LEVEL": "debug"},
+				Labels: map[string]string{
+					models.JobRuntimeLabelPool:  "bigquery",
+					models.JobRuntimeLabelQueue: "heavy",
+				},
+			}
+			job, err := com.Compile(namespaceSpec, localSpec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+    "weight_rule": WeightRule.ABSOLUTE,
+    "pool": "bigquery",
+    "queue": "heavy"
+}`)
+			// env is set on task and every hook
+			assert.Equal(t, 1+len(localSpec.Hooks), strings.Count(string(job.Contents), `
+        k8s.V1EnvVar(name="SCHEDULED_AT",value='{{ next_execution_date }}'),
+        k8s.V1EnvVar(name="LOG_LEVEL",value="debug"),
+        k8s.V1EnvVar(name="REGION",value="asia"),`))
+		})
+		t.Run("should compile pool of namespace config if job has none", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localNamespace := namespaceSpec
+			localNamespace.Config = map[string]string{"SCHEDULER_POOL": "shared"}
+			job, err := com.Compile(localNamespace, spec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+    "weight_rule": WeightRule.ABSOLUTE,
+    "pool": "shared"
+}`)
+		})
+		t.Run("should compile resources of task and hooks", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			localSpec := spec
+			localSpec.Task.Resource = models.JobSpecResource{
+				Request: models.JobSpecResourceConfig{CPU: "500m", Memory: "2Gi"},
+				Limit:   models.JobSpecResourceConfig{Memory: "4Gi"},
+			}
+			localHook := hook1
+			localHook.Resource = models.JobSpecResource{
+				Limit: models.JobSpecResourceConfig{CPU: "1"},
+			}
+			localSpec.Hooks = []models.JobSpecHook{localHook}
+			job, err := com.Compile(namespaceSpec, localSpec)
+			assert.Nil(t, err)
+			assert.Contains(t, string(job.Contents), `
+    resources=k8s.V1ResourceRequirements(
+        requests={"cpu":"500m","memory":"2Gi"},
+        limits={"memory":"4Gi"},
+    ),
+    sla=timedelta(seconds=7200),`)
+			assert.Contains(t, string(job.Contents), `
+    ],
+    resources=k8s.V1ResourceRequirements(
+        requests={},
+        limits={"cpu":"1"},
+    ),
+    reattach_on_restart=True`)
+		})
+		t.Run("should compile sensor waiting on trigger of job", func(t *testing.T) {
+			scheduler := NewScheduler(nil, nil, nil, "")
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://airflow.example.io",
+			)
+			triggers := []struct {
+				Trigger  models.JobSpecTrigger
+				Expected string
+			}{
+				{
+					Trigger: models.JobSpecTrigger{
+						Type:   models.JobTriggerTypeGCS,
+						Config: map[string]string{"BUCKET": "landing", "OBJECT": "events/{{ ds }}/_SUCCESS"},
+					},
+					Expected: `
+trigger_event = GCSObjectExistenceSensor(
+    bucket="landing",
+    object="events/{{ ds }}/_SUCCESS",
+    mode="reschedule",`,
+				},
+				{
+					Trigger: models.JobSpecTrigger{
+						Type:   models.JobTriggerTypeKafka,
+						Config: map[string]string{"BROKERS": "localhost:9092", "TOPIC": "orders"},
+					},
+					Expected: `
+trigger_event = KafkaMessageSensor(
+    brokers="localhost:9092",
+    topic="orders",
+    consumer_group="optimus-foo-project-foo",
+    message_key="",
+    mode="reschedule",`,
+				},
+				{
+					Trigger: models.JobSpecTrigger{
+						Type:   models.JobTriggerTypeJob,
+						Config: map[string]string{"JOB": "upstream-job"},
+					},
+					Expected: `
+trigger_event = CrossTenantDependencySensor(
+    optimus_hostname="http://airflow.example.io",
+    optimus_project="foo-project",
+    optimus_job="upstream-job",
+    mode="reschedule",`,
+				},
+			}
+			for _, tcase := range triggers {
+				trigger := tcase.Trigger
+				localSpec := spec
+				localSpec.Schedule.Trigger = &trigger
+				job, err := com.Compile(namespaceSpec, localSpec)
+				assert.Nil(t, err)
+				assert.Contains(t, string(job.Contents), tcase.Expected)
+				assert.Contains(t, string(job.Contents), "\ntrigger_event >> transformation_bq\n")
+			}
+		})
 	})
 }
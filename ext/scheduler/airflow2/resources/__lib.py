@@ -9,7 +9,7 @@ import requests
 from airflow.providers.cncf.kubernetes.operators.kubernetes_pod import KubernetesPodOperator
 from airflow.providers.cncf.kubernetes.utils import pod_launcher
 from airflow.providers.slack.operators.slack import SlackAPIPostOperator
-from airflow.exceptions import AirflowException
+from airflow.exceptions import AirflowException, AirflowSkipException
 from airflow.hooks.base import BaseHook
 from airflow.kubernetes import kube_client
 from airflow.models import (XCOM_RETURN_KEY, DagModel,
@@ -126,6 +126,9 @@ class SuperExternalTaskSensor(BaseSensorOperator):
         iterations of upstream dag in provided window. All of them needs to be
         successful for this sensor to complete. Defaults to a day of window(24)
     :type window_size: str
+    :param skip_on_upstream_failure: skip instead of waiting if any upstream
+        run in window has failed, used for optional dependencies
+    :type skip_on_upstream_failure: bool
     """
 
     @apply_defaults
@@ -135,6 +138,7 @@ class SuperExternalTaskSensor(BaseSensorOperator):
                  window_offset: str,
                  window_truncate_to: str,
                  optimus_hostname: str,
+                 skip_on_upstream_failure: bool = False,
                  *args,
                  **kwargs):
 
@@ -152,6 +156,7 @@ class SuperExternalTaskSensor(BaseSensorOperator):
         self.window_offset = window_offset
         self.window_truncate_to = window_truncate_to
         self.allowed_upstream_states = [State.SUCCESS]
+        self.skip_on_upstream_failure = skip_on_upstream_failure
         self._optimus_client = OptimusAPIClient(optimus_hostname)
 
         super(SuperExternalTaskSensor, self).__init__(*args, **kwargs)
@@ -201,6 +206,10 @@ class SuperExternalTaskSensor(BaseSensorOperator):
         missing_upstream_executions = set(expected_upstream_executions) - set(actual_upstream_executions)
         if len(missing_upstream_executions) > 0:
             self.log.info("missing upstream executions : {}".format(missing_upstream_executions))
+            if self.skip_on_upstream_failure and self._has_failed_upstream_executions(window_start, window_end, session):
+                raise AirflowSkipException("upstream '{}' failed dated between {} and {}(inclusive), skipping "
+                                           "optional dependency".format(self.upstream_dag, window_start.isoformat(),
+                                                                       window_end.isoformat()))
             self.log.warning(
                 "unable to find enough DagRun instances for upstream '{}' dated between {} and {}(inclusive), rescheduling sensor"
                     .format(self.upstream_dag, window_start.isoformat(), window_end.isoformat()))
@@ -208,6 +217,16 @@ class SuperExternalTaskSensor(BaseSensorOperator):
 
         return True
 
+    def _has_failed_upstream_executions(self, window_start, window_end, session) -> bool:
+        failed_upstream_execution = session.query(DagRun.execution_date).filter(
+            DagRun.dag_id == self.upstream_dag,
+            DagRun.execution_date > window_start.replace(tzinfo=utc),
+            DagRun.execution_date <= window_end.replace(tzinfo=utc),
+            DagRun.external_trigger == False,
+            DagRun.state == State.FAILED
+        ).first()
+        return failed_upstream_execution is not None
+
     def generate_window(self, execution_date, window_size, window_offset, window_truncate_to):
         format_rfc3339 = "%Y-%m-%dT%H:%M:%SZ"
         execution_date_str = execution_date.strftime(format_rfc3339)
@@ -247,13 +266,14 @@ class OptimusAPIClient:
         return response.json()
 
     def get_task_window(self, scheduled_at: str, window_size: str, window_offset: str,
-                        window_truncate_upto: str) -> dict:
-        url = '{optimus_host}/api/v1/window?scheduledAt={scheduled_at}&size={window_size}&offset={window_offset}&truncate_to={window_truncate_upto}'.format(
+                        window_truncate_upto: str, window_timezone: str = "") -> dict:
+        url = '{optimus_host}/api/v1/window?scheduledAt={scheduled_at}&size={window_size}&offset={window_offset}&truncate_to={window_truncate_upto}&timezone={window_timezone}'.format(
             optimus_host=self.host,
             scheduled_at=scheduled_at,
             window_size=window_size,
             window_offset=window_offset,
             window_truncate_upto=window_truncate_upto,
+            window_timezone=window_timezone,
         )
         response = requests.get(url)
         self._raise_error_if_request_failed(response)
@@ -294,10 +314,12 @@ class OptimusAPIClient:
 
 
 class JobSpecTaskWindow:
-    def __init__(self, size: str, offset: str, truncate_to: str, optimus_client: OptimusAPIClient):
+    def __init__(self, size: str, offset: str, truncate_to: str, optimus_client: OptimusAPIClient,
+                 timezone: str = ""):
         self.size = size
         self.offset = offset
         self.truncate_to = truncate_to
+        self.timezone = timezone
         self._optimus_client = optimus_client
 
     def get(self, scheduled_at: str) -> (datetime, datetime):
@@ -311,7 +333,8 @@ class JobSpecTaskWindow:
         return datetime.strptime(timestamp, "%Y-%m-%dT%H:%M:%SZ")
 
     def _fetch_task_window(self, scheduled_at: str) -> dict:
-        return self._optimus_client.get_task_window(scheduled_at, self.size, self.offset, self.truncate_to)
+        return self._optimus_client.get_task_window(scheduled_at, self.size, self.offset, self.truncate_to,
+                                                    self.timezone)
 
 
 class CrossTenantDependencySensor(BaseSensorOperator):
@@ -340,7 +363,7 @@ class CrossTenantDependencySensor(BaseSensorOperator):
 
         # ignore offset
         task_window = JobSpecTaskWindow(job_metadata['job']['windowSize'], 0, job_metadata['job']['windowTruncateTo'],
-                                        self._optimus_client)
+                                        self._optimus_client, job_metadata['job'].get('timezone', ""))
         window_start, window_end = task_window.get(execution_date_str)
 
         expected_upstream_executions = self._get_expected_upstream_executions(cron_schedule, window_start, window_end)
@@ -387,6 +410,107 @@ class CrossTenantDependencySensor(BaseSensorOperator):
             return datetime.strptime(timestamp, self.TIMESTAMP_MS_FORMAT)
 
 
+class KafkaMessageSensor(BaseSensorOperator):
+    """
+    Waits for a message to arrive on a kafka topic, messages are consumed
+    with a consumer group per dag so each message triggers a single run
+    """
+
+    @apply_defaults
+    def __init__(
+            self,
+            brokers: str,
+            topic: str,
+            consumer_group: str,
+            message_key: str = "",
+            **kwargs) -> None:
+        super().__init__(**kwargs)
+        self.brokers = brokers
+        self.topic = topic
+        self.consumer_group = consumer_group
+        self.message_key = message_key
+
+    def poke(self, context):
+        # imported lazily, only deployments using kafka triggers need the client
+        from kafka import KafkaConsumer
+
+        consumer = KafkaConsumer(
+            self.topic,
+            bootstrap_servers=self.brokers.split(","),
+            group_id=self.consumer_group,
+            enable_auto_commit=False,
+            consumer_timeout_ms=10 * 1000,
+        )
+        try:
+            for message in consumer:
+                key = message.key.decode("utf-8") if message.key is not None else ""
+                if self.message_key and key != self.message_key:
+                    continue
+                self.log.info("received message on topic {} partition {} offset {}".format(
+                    message.topic, message.partition, message.offset))
+                consumer.commit()
+                return True
+        finally:
+            consumer.close()
+        self.log.info("no message received on topic {}, rescheduling sensor".format(self.topic))
+        return False
+
+
+class HttpDependencySensor(BaseSensorOperator):
+    """
+    Waits for an endpoint outside optimus to respond with expected status
+    and, if json_path is set, with expected_value at the dot separated
+    path of json response body
+    """
+    template_fields = ('url', 'headers')
+
+    @apply_defaults
+    def __init__(
+            self,
+            url: str,
+            headers: dict = None,
+            expected_status: int = 200,
+            json_path: str = "",
+            expected_value: str = "",
+            **kwargs) -> None:
+        super().__init__(**kwargs)
+        self.url = url
+        self.headers = headers or {}
+        self.expected_status = expected_status
+        self.json_path = json_path
+        self.expected_value = expected_value
+
+    def poke(self, context):
+        try:
+            response = requests.get(self.url, headers=self.headers, timeout=60)
+        except requests.exceptions.RequestException as e:
+            self.log.warning("unable to reach {}: {}, rescheduling sensor".format(self.url, e))
+            return False
+        if response.status_code != self.expected_status:
+            self.log.info("{} responded with status {}, expected {}, rescheduling sensor".format(
+                self.url, response.status_code, self.expected_status))
+            return False
+        if not self.json_path:
+            return True
+
+        value = response.json()
+        for key in self.json_path.lstrip("$").strip(".").split("."):
+            if isinstance(value, list) and key.isdigit() and int(key) < len(value):
+                value = value[int(key)]
+            elif isinstance(value, dict) and key in value:
+                value = value[key]
+            else:
+                self.log.info("{} not found in response of {}, rescheduling sensor".format(self.json_path, self.url))
+                return False
+        if isinstance(value, bool):
+            value = str(value).lower()
+        if self.expected_value and str(value) != self.expected_value:
+            self.log.info("{} of {} response is {}, expected {}, rescheduling sensor".format(
+                self.json_path, self.url, value, self.expected_value))
+            return False
+        return True
+
+
 def optimus_failure_notify(context):
     params = context.get("params")
     optimus_client = OptimusAPIClient(params["optimus_hostname"])
@@ -426,8 +550,11 @@ def optimus_failure_notify(context):
         "duration": str(context.get('task_instance').duration),
         "message": failure_message,
         "exception": str(context.get('exception')),
-        "scheduled_at": current_execution_date.strftime("%Y-%m-%dT%H:%M:%SZ")
+        "scheduled_at": current_execution_date.strftime("%Y-%m-%dT%H:%M:%SZ"),
+        "attempt": context.get('task_instance').try_number,
     }
+    if context.get('task_instance').start_date:
+        message["start_time"] = context.get('task_instance').start_date.strftime("%Y-%m-%dT%H:%M:%SZ")
     event = {
         "type": "FAILURE",
         "value": message,
@@ -438,6 +565,52 @@ def optimus_failure_notify(context):
     return
 
 
+def _run_event_message(context) -> dict:
+    task_instance = context.get('task_instance')
+    message = {
+        "run_id": context.get('run_id'),
+        "scheduled_at": context.get('execution_date').strftime("%Y-%m-%dT%H:%M:%SZ"),
+    }
+    if task_instance:
+        message["task_id"] = task_instance.task_id
+        message["log_url"] = task_instance.log_url
+        message["attempt"] = task_instance.try_number
+        if task_instance.start_date:
+            message["start_time"] = task_instance.start_date.strftime("%Y-%m-%dT%H:%M:%SZ")
+    return message
+
+
+def _post_run_event(context, event_type: str, message: dict):
+    params = context.get("params")
+    optimus_client = OptimusAPIClient(params["optimus_hostname"])
+    event = {
+        "type": event_type,
+        "value": message,
+    }
+    resp = optimus_client.notify_event(params["project_name"], params["namespace"], params["job_name"], event)
+    print("posted event ", params, event, resp)
+
+
+def optimus_start_notify(context):
+    _post_run_event(context, "START", _run_event_message(context))
+
+
+def optimus_retry_notify(context):
+    message = _run_event_message(context)
+    message["exception"] = str(context.get('exception'))
+    _post_run_event(context, "RETRY", message)
+
+
+def optimus_success_notify(context):
+    message = _run_event_message(context)
+    dag_run = context.get('dag_run')
+    if dag_run and dag_run.start_date:
+        message["start_time"] = dag_run.start_date.strftime("%Y-%m-%dT%H:%M:%SZ")
+    if dag_run and dag_run.end_date:
+        message["end_time"] = dag_run.end_date.strftime("%Y-%m-%dT%H:%M:%SZ")
+    _post_run_event(context, "SUCCESS", message)
+
+
 def optimus_sla_miss_notify(dag, task_list, blocking_task_list, slas, blocking_tis):
     params = dag.params
     optimus_client = OptimusAPIClient(params["optimus_hostname"])
@@ -3,20 +3,23 @@
 from typing import Any, Callable, Dict, Optional
 from datetime import datetime, timedelta, timezone
 
+import pendulum
+
 from airflow.models import DAG, Variable, DagRun, DagModel, TaskInstance, BaseOperator, XCom, XCOM_RETURN_KEY
 from airflow.kubernetes.secret import Secret
 from airflow.configuration import conf
 from airflow.utils.weight_rule import WeightRule
 from kubernetes.client import models as k8s
 
-from __lib import optimus_failure_notify, optimus_sla_miss_notify, SuperKubernetesPodOperator, \
-    SuperExternalTaskSensor, CrossTenantDependencySensor
+from __lib import optimus_failure_notify, optimus_sla_miss_notify, optimus_start_notify, optimus_retry_notify, \
+    optimus_success_notify, SuperKubernetesPodOperator, SuperExternalTaskSensor, CrossTenantDependencySensor
 
 SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS = int(Variable.get("sensor_poke_interval_in_secs", default_var=15 * 60))
 SENSOR_DEFAULT_TIMEOUT_IN_SECS = int(Variable.get("sensor_timeout_in_secs", default_var=15 * 60 * 60))
 DAG_RETRIES = int(Variable.get("dag_retries", default_var=3))
 DAG_RETRY_DELAY = int(Variable.get("dag_retry_delay_in_secs", default_var=5 * 60))
 
+{{ $pool := index .Job.Runtime.Labels "pool" | default (index .Config "SCHEDULER_POOL") -}}
 default_args = {
     "params": {
         "project_name": {{.Namespace.ProjectSpec.Name | quote}},
@@ -30,10 +33,19 @@ default_args = {
     "retry_delay": {{ if gt .Job.Behavior.Retry.Delay.Nanoseconds 0 -}} timedelta(seconds={{.Job.Behavior.Retry.Delay.Seconds}}) {{- else -}} timedelta(seconds=DAG_RETRY_DELAY) {{- end}},
     "retry_exponential_backoff": {{if .Job.Behavior.Retry.ExponentialBackoff -}}True{{- else -}}False{{- end -}},
     "priority_weight": {{.Job.Task.Priority}},
+    {{if .Job.Schedule.Timezone -}}
+    "start_date": pendulum.parse({{ .Job.Schedule.StartDate.Format "2006-01-02T15:04:05" | quote }}, tz={{ .Job.Schedule.Timezone | quote }}),
+    {{if .Job.Schedule.EndDate -}}"end_date": pendulum.parse({{ .Job.Schedule.EndDate.Format "2006-01-02T15:04:05" | quote}}, tz={{ .Job.Schedule.Timezone | quote }}),{{- else -}}{{- end}}
+    {{- else -}}
     "start_date": datetime.strptime({{ .Job.Schedule.StartDate.Format "2006-01-02T15:04:05" | quote }}, "%Y-%m-%dT%H:%M:%S"),
     {{if .Job.Schedule.EndDate -}}"end_date": datetime.strptime({{ .Job.Schedule.EndDate.Format "2006-01-02T15:04:05" | quote}},"%Y-%m-%dT%H:%M:%S"),{{- else -}}{{- end}}
+    {{- end}}
     "on_failure_callback": optimus_failure_notify,
-    "weight_rule": WeightRule.ABSOLUTE
+    "on_execute_callback": optimus_start_notify,
+    "on_retry_callback": optimus_retry_notify,
+    "weight_rule": WeightRule.ABSOLUTE{{ if $pool }},
+    "pool": {{ $pool | quote }}{{ end }}{{ with index .Job.Runtime.Labels "queue" }},
+    "queue": {{ . | quote }}{{ end }}
 }
 
 dag = DAG(
@@ -41,6 +53,7 @@ dag = DAG(
     default_args=default_args,
     schedule_interval={{.Job.Schedule.Interval | quote}},
     sla_miss_callback=optimus_sla_miss_notify,
+    on_success_callback=optimus_success_notify,
     catchup = {{ if .Job.Behavior.CatchUp -}} True{{- else -}} False {{- end }}
 )
 
@@ -66,6 +79,10 @@ transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__
     is_delete_operator_pod=True,
     do_xcom_push=False,
     secrets=[{{ if ne $baseTaskSchema.SecretPath "" -}} transformation_secret {{- end }}],
+{{- if .Job.HasOptionalDependencies }}
+    # optional upstream sensors are skipped if upstream fails or times out
+    trigger_rule="none_failed",
+{{- end }}
     env_vars = [
         k8s.V1EnvVar(name="JOB_NAME",value='{{.Job.Name}}'),
         k8s.V1EnvVar(name="OPTIMUS_HOSTNAME",value='{{.Hostname}}'),
@@ -76,7 +93,16 @@ transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__
         k8s.V1EnvVar(name="INSTANCE_TYPE",value='{{$.InstanceTypeTask}}'),
         k8s.V1EnvVar(name="INSTANCE_NAME",value='{{$baseTaskSchema.Name}}'),
         k8s.V1EnvVar(name="SCHEDULED_AT",value='{{ "{{ next_execution_date }}" }}'),
+{{- range $name, $value := $.Job.Runtime.Env }}
+        k8s.V1EnvVar(name={{ $name | quote }},value={{ $value | quote }}),
+{{- end }}
     ],
+{{- with .Job.Task.Resource }}{{ if not .IsEmpty }}
+    resources=k8s.V1ResourceRequirements(
+        requests={{ .Request.ToMap | toJson }},
+        limits={{ .Limit.ToMap | toJson }},
+    ),
+{{- end }}{{ end }}
 {{- if gt .SLAMissDurationInSec 0 }}
     sla=timedelta(seconds={{ .SLAMissDurationInSec }}),
 {{- end }}
@@ -119,11 +145,23 @@ hook_{{$hookSchema.Name | replace "-" "__dash__"}} = SuperKubernetesPodOperator(
         k8s.V1EnvVar(name="INSTANCE_TYPE",value='{{$.InstanceTypeHook}}'),
         k8s.V1EnvVar(name="INSTANCE_NAME",value='{{$hookSchema.Name}}'),
         k8s.V1EnvVar(name="SCHEDULED_AT",value='{{ "{{ next_execution_date }}" }}'),
+{{- range $name, $value := $.Job.Runtime.Env }}
+        k8s.V1EnvVar(name={{ $name | quote }},value={{ $value | quote }}),
+{{- end }}
         # rest of the env vars are pulled from the container by making a GRPC call to optimus
     ],
+{{- with $t.Resource }}{{ if not .IsEmpty }}
+    resources=k8s.V1ResourceRequirements(
+        requests={{ .Request.ToMap | toJson }},
+        limits={{ .Limit.ToMap | toJson }},
+    ),
+{{- end }}{{ end }}
     {{ if eq $hookSchema.HookType $.HookTypeFail -}}
         trigger_rule="one_failed",
     {{ end -}}
+    {{ if and (eq $hookSchema.HookType $.HookTypePre) $.Job.HasOptionalDependencies -}}
+        trigger_rule="none_failed",
+    {{ end -}}
     reattach_on_restart=True
 )
 {{- end }}
@@ -143,8 +181,12 @@ wait_{{$dependency.Job.Name | replace "-" "__dash__" | replace "." "__dot__"}} =
     window_truncate_to = {{$baseWindow.TruncateTo | quote}},
     optimus_hostname = "{{$.Hostname}}",
     task_id = "wait_{{$dependency.Job.Name | trunc 200}}-{{$dependencySchema.Name}}",
-    poke_interval = SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
-    timeout = SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    poke_interval = {{ if gt $dependency.PokeInterval.Nanoseconds 0 -}} {{ $dependency.PokeInterval.Seconds }} {{- else -}} SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS {{- end }},
+    timeout = {{ if gt $dependency.Timeout.Nanoseconds 0 -}} {{ $dependency.Timeout.Seconds }} {{- else -}} SENSOR_DEFAULT_TIMEOUT_IN_SECS {{- end }},
+{{- if $dependency.Optional }}
+    soft_fail = True,
+    skip_on_upstream_failure = True,
+{{- end }}
     dag=dag
 )
 {{- end -}}
@@ -154,9 +196,89 @@ wait_{{$dependency.Job.Name | replace "-" "__dash__" | replace "." "__dot__"}} =
     optimus_hostname="{{$.Hostname}}",
     optimus_project="{{$dependency.Project.Name}}",
     optimus_job="{{$dependency.Job.Name}}",
+    poke_interval={{ if gt $dependency.PokeInterval.Nanoseconds 0 -}} {{ $dependency.PokeInterval.Seconds }} {{- else -}} SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS {{- end }},
+    timeout={{ if gt $dependency.Timeout.Nanoseconds 0 -}} {{ $dependency.Timeout.Seconds }} {{- else -}} SENSOR_DEFAULT_TIMEOUT_IN_SECS {{- end }},
+    task_id="wait_{{$dependency.Job.Name | trunc 200}}-{{$dependencySchema.Name}}",
+{{- if $dependency.Optional }}
+    soft_fail=True,
+{{- end }}
+    dag=dag
+)
+{{- end -}}
+{{- end}}
+{{- if $.Job.HTTPDependencies }}
+
+from __lib import HttpDependencySensor
+{{- end }}
+{{- range $_, $httpDependency := $.Job.HTTPDependencies }}
+
+wait_http_{{ $httpDependency.Name | replace "-" "__dash__" | replace "." "__dot__" }} = HttpDependencySensor(
+    url={{ $httpDependency.URL | quote }},
+    headers={{ with $httpDependency.Headers }}{{ toJson . }}{{ else }}{}{{ end }},
+    expected_status={{ $httpDependency.ExpectedStatus | default 200 }},
+    json_path={{ $httpDependency.JSONPath | quote }},
+    expected_value={{ $httpDependency.ExpectedValue | quote }},
+    mode="reschedule",
     poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
     timeout=SENSOR_DEFAULT_TIMEOUT_IN_SECS,
-    task_id="wait_{{$dependency.Job.Name | trunc 200}}-{{$dependencySchema.Name}}",
+    task_id="wait_http_{{ $httpDependency.Name | trunc 200 }}",
+    dag=dag
+)
+{{- end }}
+{{- range $_, $externalDependency := $.Job.ExternalDependencies }}
+
+wait_external_{{ $externalDependency.ProjectName | replace "-" "__dash__" | replace "." "__dot__" }}__{{ $externalDependency.JobName | replace "-" "__dash__" | replace "." "__dot__" }} = CrossTenantDependencySensor(
+    optimus_hostname={{ $externalDependency.Host | quote }},
+    optimus_project={{ $externalDependency.ProjectName | quote }},
+    optimus_job={{ $externalDependency.JobName | quote }},
+    poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
+    timeout=SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    task_id="wait_external_{{ $externalDependency.ProjectName }}-{{ $externalDependency.JobName | trunc 200 }}",
+    dag=dag
+)
+{{- end }}
+{{- with $trigger := .Job.Schedule.Trigger }}
+
+# wait for the event triggering this run
+{{- if eq $trigger.Type $.JobTriggerTypeGCS }}
+from airflow.providers.google.cloud.sensors.gcs import GCSObjectExistenceSensor
+
+trigger_event = GCSObjectExistenceSensor(
+    bucket={{ index $trigger.Config "BUCKET" | quote }},
+    object={{ index $trigger.Config "OBJECT" | quote }},
+    mode="reschedule",
+    poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
+    timeout=SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    task_id="trigger_event",
+    dag=dag
+)
+{{- end -}}
+
+{{- if eq $trigger.Type $.JobTriggerTypeKafka }}
+from __lib import KafkaMessageSensor
+
+trigger_event = KafkaMessageSensor(
+    brokers={{ index $trigger.Config "BROKERS" | quote }},
+    topic={{ index $trigger.Config "TOPIC" | quote }},
+    consumer_group={{ printf "optimus-%s-%s" $.Namespace.ProjectSpec.Name $.Job.Name | quote }},
+    message_key={{ index $trigger.Config "KEY" | quote }},
+    mode="reschedule",
+    poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
+    timeout=SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    task_id="trigger_event",
+    dag=dag
+)
+{{- end -}}
+
+{{- if eq $trigger.Type $.JobTriggerTypeJob }}
+trigger_event = CrossTenantDependencySensor(
+    optimus_hostname="{{$.Hostname}}",
+    optimus_project={{ index $trigger.Config "PROJECT" | default $.Namespace.ProjectSpec.Name | quote }},
+    optimus_job={{ index $trigger.Config "JOB" | quote }},
+    mode="reschedule",
+    poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
+    timeout=SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    task_id="trigger_event",
     dag=dag
 )
 {{- end -}}
@@ -169,6 +291,34 @@ wait_{{$dependency.Job.Name | replace "-" "__dash__" | replace "." "__dot__"}} =
 {{- range $i, $t := $.Job.Dependencies }}
 wait_{{ $t.Job.Name | replace "-" "__dash__" | replace "." "__dot__" }} >> transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__dot__"}}
 {{- end}}
+{{- range $_, $httpDependency := $.Job.HTTPDependencies }}
+wait_http_{{ $httpDependency.Name | replace "-" "__dash__" | replace "." "__dot__" }} >> transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__dot__"}}
+{{- end}}
+{{- range $_, $externalDependency := $.Job.ExternalDependencies }}
+wait_external_{{ $externalDependency.ProjectName | replace "-" "__dash__" | replace "." "__dot__" }}__{{ $externalDependency.JobName | replace "-" "__dash__" | replace "." "__dot__" }} >> transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__dot__"}}
+{{- end}}
+{{- if .Job.Schedule.Trigger }}
+trigger_event >> transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__dot__"}}
+{{- end}}
+
+# upstream sensors -> pre hooks, they prepare inputs of base transformation task
+{{- range $_, $task := .Job.Hooks }}
+{{- $hookSchema := $task.Unit.Info }}
+{{- if eq $hookSchema.HookType $.HookTypePre }}
+{{- range $i, $t := $.Job.Dependencies }}
+wait_{{ $t.Job.Name | replace "-" "__dash__" | replace "." "__dot__" }} >> hook_{{$hookSchema.Name | replace "-" "__dash__"}}
+{{- end}}
+{{- range $_, $httpDependency := $.Job.HTTPDependencies }}
+wait_http_{{ $httpDependency.Name | replace "-" "__dash__" | replace "." "__dot__" }} >> hook_{{$hookSchema.Name | replace "-" "__dash__"}}
+{{- end}}
+{{- range $_, $externalDependency := $.Job.ExternalDependencies }}
+wait_external_{{ $externalDependency.ProjectName | replace "-" "__dash__" | replace "." "__dot__" }}__{{ $externalDependency.JobName | replace "-" "__dash__" | replace "." "__dot__" }} >> hook_{{$hookSchema.Name | replace "-" "__dash__"}}
+{{- end}}
+{{- if $.Job.Schedule.Trigger }}
+trigger_event >> hook_{{$hookSchema.Name | replace "-" "__dash__"}}
+{{- end}}
+{{- end }}
+{{- end }}
 
 # set inter-dependencies between task and hooks
 {{- range $_, $task := .Job.Hooks }}
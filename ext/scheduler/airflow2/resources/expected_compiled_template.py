@@ -3,14 +3,16 @@
 from typing import Any, Callable, Dict, Optional
 from datetime import datetime, timedelta, timezone
 
+import pendulum
+
 from airflow.models import DAG, Variable, DagRun, DagModel, TaskInstance, BaseOperator, XCom, XCOM_RETURN_KEY
 from airflow.kubernetes.secret import Secret
 from airflow.configuration import conf
 from airflow.utils.weight_rule import WeightRule
 from kubernetes.client import models as k8s
 
-from __lib import optimus_failure_notify, optimus_sla_miss_notify, SuperKubernetesPodOperator, \
-    SuperExternalTaskSensor, CrossTenantDependencySensor
+from __lib import optimus_failure_notify, optimus_sla_miss_notify, optimus_start_notify, optimus_retry_notify, \
+    optimus_success_notify, SuperKubernetesPodOperator, SuperExternalTaskSensor, CrossTenantDependencySensor
 
 SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS = int(Variable.get("sensor_poke_interval_in_secs", default_var=15 * 60))
 SENSOR_DEFAULT_TIMEOUT_IN_SECS = int(Variable.get("sensor_timeout_in_secs", default_var=15 * 60 * 60))
@@ -33,6 +35,8 @@ default_args = {
     "start_date": datetime.strptime("2000-11-11T00:00:00", "%Y-%m-%dT%H:%M:%S"),
     "end_date": datetime.strptime("2020-11-11T00:00:00","%Y-%m-%dT%H:%M:%S"),
     "on_failure_callback": optimus_failure_notify,
+    "on_execute_callback": optimus_start_notify,
+    "on_retry_callback": optimus_retry_notify,
     "weight_rule": WeightRule.ABSOLUTE
 }
 
@@ -41,6 +45,7 @@ dag = DAG(
     default_args=default_args,
     schedule_interval="* * * * *",
     sla_miss_callback=optimus_sla_miss_notify,
+    on_success_callback=optimus_success_notify,
     catchup = True
 )
 
@@ -206,6 +211,10 @@ wait_foo__dash__inter__dash__dep__dash__job = CrossTenantDependencySensor(
 wait_foo__dash__intra__dash__dep__dash__job >> transformation_bq
 wait_foo__dash__inter__dash__dep__dash__job >> transformation_bq
 
+# upstream sensors -> pre hooks, they prepare inputs of base transformation task
+wait_foo__dash__intra__dash__dep__dash__job >> hook_transporter
+wait_foo__dash__inter__dash__dep__dash__job >> hook_transporter
+
 # set inter-dependencies between task and hooks
 hook_transporter >> transformation_bq
 transformation_bq >> hook_predator
@@ -0,0 +1,386 @@
+package argo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kushsharma/parallel"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+
+	_ "embed"
+)
+
+//go:embed resources/cron_workflow.yaml
+var resCronWorkflow []byte
+
+const (
+	// ProjectArgoNamespaceKey is the kubernetes namespace cron workflows of a
+	// project are applied in, name of project is used if not set
+	ProjectArgoNamespaceKey = "ARGO_NAMESPACE"
+
+	cronWorkflowsURL = "%s/apis/argoproj.io/v1alpha1/namespaces/%s/cronworkflows"
+	workflowsURL     = "%s/apis/argoproj.io/v1alpha1/namespaces/%s/workflows"
+	fieldManager     = "optimus"
+
+	namespaceLabel          = "optimus.io/namespace"
+	jobAnnotation           = "optimus.io/job"
+	cronWorkflowLabel       = "workflows.argoproj.io/cron-workflow"
+	scheduledTimeAnnotation = "workflows.argoproj.io/scheduled-time"
+
+	phaseSucceeded = "Succeeded"
+	phaseFailed    = "Failed"
+	phaseError     = "Error"
+)
+
+var (
+	// ErrClearNotSupported is returned as argo can't rerun workflows
+	// through kubernetes api
+	ErrClearNotSupported = errors.New("clearing job runs is not supported by argo scheduler")
+
+	resourceNameReplacer = strings.NewReplacer("_", "-", ".", "-")
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// scheduler deploys jobs as argo CronWorkflows through kubernetes api server
+// provided at models.ProjectSchedulerHost, authenticated with the bearer
+// token kept in models.ProjectSchedulerAuth secret
+type scheduler struct {
+	httpClient HTTPClient
+	compiler   models.JobCompiler
+}
+
+// NewScheduler constructs a scheduler deploying cron workflows compiled with
+// links to optimus reachable at hostname
+func NewScheduler(httpClient HTTPClient, hostname string) *scheduler {
+	return &scheduler{
+		httpClient: httpClient,
+		compiler:   job.NewCompiler(resCronWorkflow, hostname),
+	}
+}
+
+func (a *scheduler) GetName() string {
+	return "argo"
+}
+
+// GetJobsDir is empty as cron workflows are applied to the cluster
+func (a *scheduler) GetJobsDir() string {
+	return ""
+}
+
+func (a *scheduler) GetJobsExtension() string {
+	return ".yaml"
+}
+
+func (a *scheduler) GetTemplate() []byte {
+	return resCronWorkflow
+}
+
+// Bootstrap only verifies the cluster of project is configured, workflows
+// need nothing shared to be deployed beforehand
+func (a *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec, obs progress.Observer) error {
+	_, err := clusterOf(proj)
+	return err
+}
+
+// ValidateProject checks host of project is a http url
+func (a *scheduler) ValidateProject(proj models.ProjectSpec) error {
+	host, ok := proj.Config[models.ProjectSchedulerHost]
+	if !ok {
+		return errors.Errorf("%s config not configured for project %s", models.ProjectSchedulerHost, proj.Name)
+	}
+	if u, err := url.Parse(host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("%s config for project %s should be a http url: %s", models.ProjectSchedulerHost, proj.Name, host)
+	}
+	return nil
+}
+
+func (a *scheduler) DeployJobs(ctx context.Context, namespace models.NamespaceSpec, jobs []models.JobSpec,
+	obs progress.Observer) error {
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+
+	runner := parallel.NewRunner(parallel.WithTicket(job.ConcurrentTicketPerSec))
+	for _, jobSpec := range jobs {
+		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				compiledJob, err := a.compiler.Compile(namespace, currentSpec)
+				if err != nil {
+					return nil, err
+				}
+				notify(obs, &job.EventJobSpecCompile{
+					Name: currentSpec.Name,
+				})
+
+				// server side apply creates the cron workflow or replaces
+				// fields optimus owns in the existing one
+				applyURL := fmt.Sprintf("%s/%s?fieldManager=%s&force=true",
+					cluster.cronWorkflowsURL(), resourceName(currentSpec.Name), fieldManager)
+				return nil, a.do(ctx, cluster, http.MethodPatch, applyURL, "application/apply-patch+yaml",
+					bytes.NewReader(compiledJob.Contents), nil)
+			}
+		}(jobSpec))
+	}
+
+	for runIdx, state := range runner.Run() {
+		notify(obs, &job.EventJobUpload{
+			Job: jobs[runIdx],
+			Err: state.Err,
+		})
+	}
+	return nil
+}
+
+func (a *scheduler) DeleteJobs(ctx context.Context, namespace models.NamespaceSpec, jobNames []string,
+	obs progress.Observer) error {
+	if len(jobNames) == 0 {
+		return nil
+	}
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+	for _, jobName := range jobNames {
+		deleteURL := fmt.Sprintf("%s/%s", cluster.cronWorkflowsURL(), resourceName(jobName))
+		if err := a.do(ctx, cluster, http.MethodDelete, deleteURL, "", nil, nil); err != nil &&
+			!errors.Is(err, models.ErrNoSuchJob) {
+			return err
+		}
+		notify(obs, &job.EventJobRemoteDelete{Name: jobName})
+	}
+	return nil
+}
+
+func (a *scheduler) ListJobs(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	listURL := fmt.Sprintf("%s?labelSelector=%s", cluster.cronWorkflowsURL(),
+		url.QueryEscape(fmt.Sprintf("%s=%s", namespaceLabel, resourceName(namespace.Name))))
+	var cronWorkflows resourceList
+	if err := a.do(ctx, cluster, http.MethodGet, listURL, "", nil, &cronWorkflows); err != nil {
+		return nil, err
+	}
+
+	var jobNames []string
+	for _, item := range cronWorkflows.Items {
+		if jobName, ok := item.Metadata.Annotations[jobAnnotation]; ok {
+			jobNames = append(jobNames, jobName)
+		}
+	}
+	return jobNames, nil
+}
+
+func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus,
+	error) {
+	return a.listRuns(ctx, projSpec, jobName, 0, func(models.JobStatus) bool {
+		return true
+	})
+}
+
+// Clear is not supported, workflows need to be resubmitted with argo server
+func (a *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
+	return ErrClearNotSupported
+}
+
+func (a *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
+	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
+	return a.listRuns(ctx, projSpec, jobName, batchSize, func(status models.JobStatus) bool {
+		return !status.ScheduledAt.Before(startDate) && !status.ScheduledAt.After(endDate)
+	})
+}
+
+// listRuns pages through workflows created by cron workflow of job, batchSize
+// limits workflows fetched in a call and 0 fetches all at once
+func (a *scheduler) listRuns(ctx context.Context, projSpec models.ProjectSpec, jobName string, batchSize int,
+	filter func(models.JobStatus) bool) ([]models.JobStatus, error) {
+	cluster, err := clusterOf(projSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []models.JobStatus
+	continueToken := ""
+	for {
+		query := url.Values{}
+		query.Set("labelSelector", fmt.Sprintf("%s=%s", cronWorkflowLabel, resourceName(jobName)))
+		if batchSize > 0 {
+			query.Set("limit", strconv.Itoa(batchSize))
+		}
+		if continueToken != "" {
+			query.Set("continue", continueToken)
+		}
+
+		var workflows resourceList
+		if err := a.do(ctx, cluster, http.MethodGet, fmt.Sprintf("%s?%s", cluster.workflowsURL(), query.Encode()),
+			"", nil, &workflows); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch runs of job %s", jobName)
+		}
+		for _, item := range workflows.Items {
+			status, err := toJobStatus(item)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid run of job %s", jobName)
+			}
+			if filter(status) {
+				statuses = append(statuses, status)
+			}
+		}
+
+		continueToken = workflows.Metadata.Continue
+		if continueToken == "" {
+			break
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ScheduledAt.Before(statuses[j].ScheduledAt)
+	})
+	return statuses, nil
+}
+
+func (a *scheduler) do(ctx context.Context, cluster cluster, method, reqURL, contentType string, body io.Reader,
+	out interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", reqURL)
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cluster.token))
+	request.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := a.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", reqURL)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response of %s", reqURL)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.Wrap(models.ErrNoSuchJob, string(respBody))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("%s %s failed with status %d: %s", method, reqURL, resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// cluster is the kubernetes namespace where workflows of a project run
+type cluster struct {
+	host      string
+	token     string
+	namespace string
+}
+
+func (c cluster) cronWorkflowsURL() string {
+	return fmt.Sprintf(cronWorkflowsURL, c.host, c.namespace)
+}
+
+func (c cluster) workflowsURL() string {
+	return fmt.Sprintf(workflowsURL, c.host, c.namespace)
+}
+
+func clusterOf(proj models.ProjectSpec) (cluster, error) {
+	host, ok := proj.Config[models.ProjectSchedulerHost]
+	if !ok {
+		return cluster{}, errors.Errorf("%s config not configured for project %s", models.ProjectSchedulerHost, proj.Name)
+	}
+	token, ok := proj.Secret.GetByName(models.ProjectSchedulerAuth)
+	if !ok {
+		return cluster{}, errors.Errorf("%s secret not configured for project %s", models.ProjectSchedulerAuth, proj.Name)
+	}
+	namespace, ok := proj.Config[ProjectArgoNamespaceKey]
+	if !ok || namespace == "" {
+		namespace = resourceName(proj.Name)
+	}
+	return cluster{
+		host:      strings.TrimSuffix(host, "/"),
+		token:     token,
+		namespace: namespace,
+	}, nil
+}
+
+// resourceName converts names to ones allowed for kubernetes resources, it
+// needs to match how the cron workflow template names resources
+func resourceName(name string) string {
+	return resourceNameReplacer.Replace(strings.ToLower(name))
+}
+
+type resourceList struct {
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+	Items []resource `json:"items"`
+}
+
+type resource struct {
+	Metadata struct {
+		Name              string            `json:"name"`
+		CreationTimestamp time.Time         `json:"creationTimestamp"`
+		Annotations       map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// toJobStatus maps a workflow to status of the run it was scheduled for,
+// workflows created by hand have no scheduled time and use their creation
+func toJobStatus(workflow resource) (models.JobStatus, error) {
+	scheduledAt := workflow.Metadata.CreationTimestamp
+	if value, ok := workflow.Metadata.Annotations[scheduledTimeAnnotation]; ok {
+		var err error
+		if scheduledAt, err = time.Parse(time.RFC3339, value); err != nil {
+			return models.JobStatus{}, errors.Wrapf(err, "failed to parse scheduled time of %s", workflow.Metadata.Name)
+		}
+	}
+
+	var state models.JobStatusState
+	switch workflow.Status.Phase {
+	case phaseSucceeded:
+		state = models.JobStatusStateSuccess
+	case phaseFailed, phaseError:
+		state = models.JobStatusStateFailed
+	default:
+		// pending and running workflows, as well as ones not yet picked
+		// by the controller
+		state = models.JobStatusStateRunning
+	}
+	return models.JobStatus{
+		ScheduledAt: scheduledAt,
+		State:       state,
+	}, nil
+}
+
+func notify(obs progress.Observer, evt progress.Event) {
+	if obs == nil {
+		return
+	}
+	obs.Notify(evt)
+}
@@ -0,0 +1,243 @@
+package argo_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/scheduler/argo"
+	"github.com/odpf/optimus/job"
+	mocked "github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHTTPClient struct {
+	DoFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.DoFunc(req)
+}
+
+func respond(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+func TestArgo(t *testing.T) {
+	ctx := context.Background()
+	projectSpec := models.ProjectSpec{
+		Name: "proj_name",
+		Config: map[string]string{
+			models.ProjectSchedulerHost: "https://k8s.example.io/",
+		},
+		Secret: []models.ProjectSecretItem{
+			{
+				Name:  models.ProjectSchedulerAuth,
+				Value: "token",
+			},
+		},
+	}
+	namespaceSpec := models.NamespaceSpec{
+		Name:        "team_a",
+		ProjectSpec: projectSpec,
+	}
+
+	t.Run("DeployJobs", func(t *testing.T) {
+		execUnit := new(mocked.BasePlugin)
+		execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:       "bq2bq",
+			Image:      "example.io/namespace/image:latest",
+			SecretPath: "/opt/optimus/secrets/auth.json",
+		}, nil)
+		hookUnit := new(mocked.BasePlugin)
+		hookUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:     "predator",
+			HookType: models.HookTypePost,
+			Image:    "example.io/namespace/predator-image:latest",
+		}, nil)
+		jobSpec := models.JobSpec{
+			Name:  "foo.bar_job",
+			Owner: "mee@mee",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+				Interval:  "0 2 * * *",
+			},
+			Task: models.JobSpecTask{
+				Unit:     &models.Plugin{Base: execUnit},
+				Priority: 2000,
+			},
+			Hooks: []models.JobSpecHook{
+				{Unit: &models.Plugin{Base: hookUnit}},
+			},
+		}
+
+		t.Run("should apply compiled cron workflows to namespace of project", func(t *testing.T) {
+			var applied *http.Request
+			var manifest string
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body, _ := ioutil.ReadAll(req.Body)
+					applied, manifest = req, string(body)
+					return respond(http.StatusOK, "{}"), nil
+				},
+			}
+
+			obs := new(mocked.PipelineLogObserver)
+			obs.On("Notify", mock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			scheduler := argo.NewScheduler(client, "http://optimus.example.io")
+			err := scheduler.DeployJobs(ctx, namespaceSpec, []models.JobSpec{jobSpec}, obs)
+			assert.Nil(t, err)
+			obs.AssertCalled(t, "Notify", &job.EventJobUpload{Job: jobSpec})
+
+			assert.Equal(t, http.MethodPatch, applied.Method)
+			assert.Equal(t, "https://k8s.example.io/apis/argoproj.io/v1alpha1/namespaces/proj-name/cronworkflows/foo-bar-job?fieldManager=optimus&force=true",
+				applied.URL.String())
+			assert.Equal(t, "application/apply-patch+yaml", applied.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", applied.Header.Get("Authorization"))
+
+			assert.Contains(t, manifest, "kind: CronWorkflow")
+			assert.Contains(t, manifest, "name: foo-bar-job\n")
+			assert.Contains(t, manifest, `optimus.io/job: "foo.bar_job"`)
+			assert.Contains(t, manifest, `schedule: "0 2 * * *"`)
+			assert.Contains(t, manifest, `image: "example.io/namespace/image:latest"`)
+			assert.Contains(t, manifest, "secretName: optimus-task-bq2bq")
+			assert.Contains(t, manifest, `{name: SCHEDULED_AT, value: "{{workflow.scheduledTime}}"}`)
+			assert.Contains(t, manifest, "template: hook-predator\n          dependencies: [\"task\"]")
+		})
+		t.Run("should report jobs failed to apply", func(t *testing.T) {
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return respond(http.StatusForbidden, "forbidden"), nil
+				},
+			}
+
+			obs := new(mocked.PipelineLogObserver)
+			obs.On("Notify", mock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			scheduler := argo.NewScheduler(client, "http://optimus.example.io")
+			err := scheduler.DeployJobs(ctx, namespaceSpec, []models.JobSpec{jobSpec}, obs)
+			assert.Nil(t, err)
+			obs.AssertCalled(t, "Notify", mock.MatchedBy(func(evt interface{}) bool {
+				upload, ok := evt.(*job.EventJobUpload)
+				return ok && upload.Err != nil
+			}))
+		})
+		t.Run("should fail if scheduler host is not configured", func(t *testing.T) {
+			scheduler := argo.NewScheduler(nil, "")
+			err := scheduler.DeployJobs(ctx, models.NamespaceSpec{
+				ProjectSpec: models.ProjectSpec{Name: "proj"},
+			}, []models.JobSpec{jobSpec}, nil)
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("ListJobs", func(t *testing.T) {
+		t.Run("should return jobs of namespace deployed as cron workflows", func(t *testing.T) {
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "optimus.io/namespace=team-a", req.URL.Query().Get("labelSelector"))
+					return respond(http.StatusOK, `{"items": [
+						{"metadata": {"name": "foo-bar-job", "annotations": {"optimus.io/job": "foo.bar_job"}}},
+						{"metadata": {"name": "job-2", "annotations": {"optimus.io/job": "job-2"}}}
+					]}`), nil
+				},
+			}
+
+			scheduler := argo.NewScheduler(client, "")
+			jobNames, err := scheduler.ListJobs(ctx, namespaceSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"foo.bar_job", "job-2"}, jobNames)
+		})
+	})
+	t.Run("DeleteJobs", func(t *testing.T) {
+		t.Run("should delete cron workflows ignoring ones already removed", func(t *testing.T) {
+			var deleted []string
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodDelete, req.Method)
+					deleted = append(deleted, req.URL.Path)
+					if len(deleted) == 2 {
+						return respond(http.StatusNotFound, "not found"), nil
+					}
+					return respond(http.StatusOK, "{}"), nil
+				},
+			}
+
+			scheduler := argo.NewScheduler(client, "")
+			err := scheduler.DeleteJobs(ctx, namespaceSpec, []string{"foo.bar_job", "job-2"}, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{
+				"/apis/argoproj.io/v1alpha1/namespaces/proj-name/cronworkflows/foo-bar-job",
+				"/apis/argoproj.io/v1alpha1/namespaces/proj-name/cronworkflows/job-2",
+			}, deleted)
+		})
+	})
+	t.Run("GetJobStatus", func(t *testing.T) {
+		t.Run("should map workflow phases to job statuses", func(t *testing.T) {
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "workflows.argoproj.io/cron-workflow=foo-bar-job", req.URL.Query().Get("labelSelector"))
+					return respond(http.StatusOK, `{"metadata": {}, "items": [
+						{"metadata": {"name": "w-3", "annotations": {"workflows.argoproj.io/scheduled-time": "2021-01-03T02:00:00Z"}}, "status": {"phase": "Running"}},
+						{"metadata": {"name": "w-1", "annotations": {"workflows.argoproj.io/scheduled-time": "2021-01-01T02:00:00Z"}}, "status": {"phase": "Succeeded"}},
+						{"metadata": {"name": "w-2", "annotations": {"workflows.argoproj.io/scheduled-time": "2021-01-02T02:00:00Z"}}, "status": {"phase": "Error"}}
+					]}`), nil
+				},
+			}
+
+			scheduler := argo.NewScheduler(client, "")
+			statuses, err := scheduler.GetJobStatus(ctx, projectSpec, "foo.bar_job")
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobStatus{
+				{ScheduledAt: time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateSuccess},
+				{ScheduledAt: time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateFailed},
+				{ScheduledAt: time.Date(2021, 1, 3, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateRunning},
+			}, statuses)
+		})
+	})
+	t.Run("GetDagRunStatus", func(t *testing.T) {
+		t.Run("should page through runs within dates", func(t *testing.T) {
+			var calls int
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					calls++
+					assert.Equal(t, "1", req.URL.Query().Get("limit"))
+					if req.URL.Query().Get("continue") == "" {
+						return respond(http.StatusOK, `{"metadata": {"continue": "next"}, "items": [
+							{"metadata": {"name": "w-1", "annotations": {"workflows.argoproj.io/scheduled-time": "2021-01-01T02:00:00Z"}}, "status": {"phase": "Succeeded"}}
+						]}`), nil
+					}
+					return respond(http.StatusOK, `{"metadata": {}, "items": [
+						{"metadata": {"name": "w-2", "annotations": {"workflows.argoproj.io/scheduled-time": "2021-01-05T02:00:00Z"}}, "status": {"phase": "Failed"}}
+					]}`), nil
+				},
+			}
+
+			scheduler := argo.NewScheduler(client, "")
+			statuses, err := scheduler.GetDagRunStatus(ctx, projectSpec, "foo.bar_job",
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), 1)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, calls)
+			assert.Equal(t, []models.JobStatus{
+				{ScheduledAt: time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateSuccess},
+			}, statuses)
+		})
+	})
+	t.Run("Clear", func(t *testing.T) {
+		t.Run("should not be supported", func(t *testing.T) {
+			scheduler := argo.NewScheduler(nil, "")
+			err := scheduler.Clear(ctx, projectSpec, "foo.bar_job", time.Now(), time.Now())
+			assert.Equal(t, argo.ErrClearNotSupported, err)
+		})
+	})
+}
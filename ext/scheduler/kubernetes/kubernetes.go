@@ -0,0 +1,401 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kushsharma/parallel"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+
+	_ "embed"
+)
+
+//go:embed resources/cron_job.yaml
+var resCronJob []byte
+
+const (
+	// ProjectKubernetesNamespaceKey is the kubernetes namespace cron jobs of a
+	// project are applied in, name of project is used if not set
+	ProjectKubernetesNamespaceKey = "KUBERNETES_NAMESPACE"
+
+	// CronJobRunEnv holds name of the kubernetes job a cron job created for
+	// a run, containers derive scheduled time of the run from it
+	CronJobRunEnv = "CRONJOB_RUN"
+
+	cronJobsURL  = "%s/apis/batch/v1/namespaces/%s/cronjobs"
+	jobsURL      = "%s/apis/batch/v1/namespaces/%s/jobs"
+	fieldManager = "optimus"
+
+	namespaceLabel = "optimus.io/namespace"
+	jobAnnotation  = "optimus.io/job"
+	cronJobLabel   = "optimus.io/cron-job"
+
+	conditionComplete = "Complete"
+	conditionFailed   = "Failed"
+)
+
+var (
+	// ErrClearNotSupported is returned as kubernetes jobs can't be rerun
+	// for a past schedule
+	ErrClearNotSupported = errors.New("clearing job runs is not supported by kubernetes scheduler")
+
+	resourceNameReplacer = strings.NewReplacer("_", "-", ".", "-")
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// scheduler deploys jobs as kubernetes CronJobs through the api server
+// provided at models.ProjectSchedulerHost, authenticated with the bearer
+// token kept in models.ProjectSchedulerAuth secret. Upstream sensors, pre
+// hooks and task run as init containers of the pod one after another and
+// post hooks as its containers once all of them succeed
+type scheduler struct {
+	httpClient HTTPClient
+	compiler   models.JobCompiler
+}
+
+// NewScheduler constructs a scheduler deploying cron jobs compiled with
+// links to optimus reachable at hostname
+func NewScheduler(httpClient HTTPClient, hostname string) *scheduler {
+	return &scheduler{
+		httpClient: httpClient,
+		compiler:   job.NewCompiler(resCronJob, hostname),
+	}
+}
+
+func (s *scheduler) GetName() string {
+	return "kubernetes"
+}
+
+// GetJobsDir is empty as cron jobs are applied to the cluster
+func (s *scheduler) GetJobsDir() string {
+	return ""
+}
+
+func (s *scheduler) GetJobsExtension() string {
+	return ".yaml"
+}
+
+func (s *scheduler) GetTemplate() []byte {
+	return resCronJob
+}
+
+// Bootstrap only verifies the cluster of project is configured
+func (s *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec, obs progress.Observer) error {
+	_, err := clusterOf(proj)
+	return err
+}
+
+// ValidateProject checks host of project is a http url
+func (s *scheduler) ValidateProject(proj models.ProjectSpec) error {
+	host, ok := proj.Config[models.ProjectSchedulerHost]
+	if !ok {
+		return errors.Errorf("%s config not configured for project %s", models.ProjectSchedulerHost, proj.Name)
+	}
+	if u, err := url.Parse(host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("%s config for project %s should be a http url: %s", models.ProjectSchedulerHost, proj.Name, host)
+	}
+	return nil
+}
+
+func (s *scheduler) DeployJobs(ctx context.Context, namespace models.NamespaceSpec, jobs []models.JobSpec,
+	obs progress.Observer) error {
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+
+	runner := parallel.NewRunner(parallel.WithTicket(job.ConcurrentTicketPerSec))
+	for _, jobSpec := range jobs {
+		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				compiledJob, err := s.compiler.Compile(namespace, currentSpec)
+				if err != nil {
+					return nil, err
+				}
+				notify(obs, &job.EventJobSpecCompile{
+					Name: currentSpec.Name,
+				})
+
+				applyURL := fmt.Sprintf("%s/%s?fieldManager=%s&force=true",
+					cluster.cronJobsURL(), resourceName(currentSpec.Name), fieldManager)
+				return nil, s.do(ctx, cluster, http.MethodPatch, applyURL, "application/apply-patch+yaml",
+					bytes.NewReader(compiledJob.Contents), nil)
+			}
+		}(jobSpec))
+	}
+
+	for runIdx, state := range runner.Run() {
+		notify(obs, &job.EventJobUpload{
+			Job: jobs[runIdx],
+			Err: state.Err,
+		})
+	}
+	return nil
+}
+
+func (s *scheduler) DeleteJobs(ctx context.Context, namespace models.NamespaceSpec, jobNames []string,
+	obs progress.Observer) error {
+	if len(jobNames) == 0 {
+		return nil
+	}
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+	for _, jobName := range jobNames {
+		// runs are removed along with the cron job
+		deleteURL := fmt.Sprintf("%s/%s?propagationPolicy=Background", cluster.cronJobsURL(), resourceName(jobName))
+		if err := s.do(ctx, cluster, http.MethodDelete, deleteURL, "", nil, nil); err != nil &&
+			!errors.Is(err, models.ErrNoSuchJob) {
+			return err
+		}
+		notify(obs, &job.EventJobRemoteDelete{Name: jobName})
+	}
+	return nil
+}
+
+func (s *scheduler) ListJobs(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	listURL := fmt.Sprintf("%s?labelSelector=%s", cluster.cronJobsURL(),
+		url.QueryEscape(fmt.Sprintf("%s=%s", namespaceLabel, resourceName(namespace.Name))))
+	var cronJobs resourceList
+	if err := s.do(ctx, cluster, http.MethodGet, listURL, "", nil, &cronJobs); err != nil {
+		return nil, err
+	}
+
+	var jobNames []string
+	for _, item := range cronJobs.Items {
+		if jobName, ok := item.Metadata.Annotations[jobAnnotation]; ok {
+			jobNames = append(jobNames, jobName)
+		}
+	}
+	return jobNames, nil
+}
+
+func (s *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus,
+	error) {
+	return s.listRuns(ctx, projSpec, jobName, 0, func(models.JobStatus) bool {
+		return true
+	})
+}
+
+// Clear is not supported, cron jobs only create runs for upcoming schedules
+func (s *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
+	return ErrClearNotSupported
+}
+
+func (s *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
+	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
+	return s.listRuns(ctx, projSpec, jobName, batchSize, func(status models.JobStatus) bool {
+		return !status.ScheduledAt.Before(startDate) && !status.ScheduledAt.After(endDate)
+	})
+}
+
+// listRuns pages through kubernetes jobs created by cron job of job, batchSize
+// limits jobs fetched in a call and 0 fetches all at once
+func (s *scheduler) listRuns(ctx context.Context, projSpec models.ProjectSpec, jobName string, batchSize int,
+	filter func(models.JobStatus) bool) ([]models.JobStatus, error) {
+	cluster, err := clusterOf(projSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []models.JobStatus
+	continueToken := ""
+	for {
+		query := url.Values{}
+		query.Set("labelSelector", fmt.Sprintf("%s=%s", cronJobLabel, resourceName(jobName)))
+		if batchSize > 0 {
+			query.Set("limit", strconv.Itoa(batchSize))
+		}
+		if continueToken != "" {
+			query.Set("continue", continueToken)
+		}
+
+		var runs resourceList
+		if err := s.do(ctx, cluster, http.MethodGet, fmt.Sprintf("%s?%s", cluster.jobsURL(), query.Encode()),
+			"", nil, &runs); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch runs of job %s", jobName)
+		}
+		for _, item := range runs.Items {
+			status := toJobStatus(item)
+			if filter(status) {
+				statuses = append(statuses, status)
+			}
+		}
+
+		continueToken = runs.Metadata.Continue
+		if continueToken == "" {
+			break
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ScheduledAt.Before(statuses[j].ScheduledAt)
+	})
+	return statuses, nil
+}
+
+func (s *scheduler) do(ctx context.Context, cluster cluster, method, reqURL, contentType string, body io.Reader,
+	out interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", reqURL)
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cluster.token))
+	request.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", reqURL)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response of %s", reqURL)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.Wrap(models.ErrNoSuchJob, string(respBody))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("%s %s failed with status %d: %s", method, reqURL, resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// cluster is the kubernetes namespace where jobs of a project run
+type cluster struct {
+	host      string
+	token     string
+	namespace string
+}
+
+func (c cluster) cronJobsURL() string {
+	return fmt.Sprintf(cronJobsURL, c.host, c.namespace)
+}
+
+func (c cluster) jobsURL() string {
+	return fmt.Sprintf(jobsURL, c.host, c.namespace)
+}
+
+func clusterOf(proj models.ProjectSpec) (cluster, error) {
+	host, ok := proj.Config[models.ProjectSchedulerHost]
+	if !ok {
+		return cluster{}, errors.Errorf("%s config not configured for project %s", models.ProjectSchedulerHost, proj.Name)
+	}
+	token, ok := proj.Secret.GetByName(models.ProjectSchedulerAuth)
+	if !ok {
+		return cluster{}, errors.Errorf("%s secret not configured for project %s", models.ProjectSchedulerAuth, proj.Name)
+	}
+	namespace, ok := proj.Config[ProjectKubernetesNamespaceKey]
+	if !ok || namespace == "" {
+		namespace = resourceName(proj.Name)
+	}
+	return cluster{
+		host:      strings.TrimSuffix(host, "/"),
+		token:     token,
+		namespace: namespace,
+	}, nil
+}
+
+// resourceName converts names to ones allowed for kubernetes resources, it
+// needs to match how the cron job template names resources
+func resourceName(name string) string {
+	return resourceNameReplacer.Replace(strings.ToLower(name))
+}
+
+// RunScheduledAt parses the time a kubernetes job was scheduled for by its
+// cron job, which suffixes names of jobs it creates with minutes since epoch
+func RunScheduledAt(runName string) (time.Time, error) {
+	idx := strings.LastIndex(runName, "-")
+	if idx < 0 {
+		return time.Time{}, errors.Errorf("%s is not a job created by a cron job", runName)
+	}
+	minutes, err := strconv.ParseInt(runName[idx+1:], 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "%s is not a job created by a cron job", runName)
+	}
+	return time.Unix(minutes*60, 0).UTC(), nil
+}
+
+type resourceList struct {
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+	Items []resource `json:"items"`
+}
+
+type resource struct {
+	Metadata struct {
+		Name              string            `json:"name"`
+		CreationTimestamp time.Time         `json:"creationTimestamp"`
+		Annotations       map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Status struct {
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// toJobStatus maps a kubernetes job to status of the run it was scheduled
+// for, jobs created by hand use their creation time
+func toJobStatus(run resource) models.JobStatus {
+	scheduledAt, err := RunScheduledAt(run.Metadata.Name)
+	if err != nil {
+		scheduledAt = run.Metadata.CreationTimestamp
+	}
+
+	// jobs without a finished condition are pending or still running
+	state := models.JobStatusStateRunning
+	for _, condition := range run.Status.Conditions {
+		if condition.Status != "True" {
+			continue
+		}
+		switch condition.Type {
+		case conditionComplete:
+			state = models.JobStatusStateSuccess
+		case conditionFailed:
+			state = models.JobStatusStateFailed
+		}
+	}
+	return models.JobStatus{
+		ScheduledAt: scheduledAt,
+		State:       state,
+	}
+}
+
+func notify(obs progress.Observer, evt progress.Event) {
+	if obs == nil {
+		return
+	}
+	obs.Notify(evt)
+}
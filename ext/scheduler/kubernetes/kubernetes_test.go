@@ -0,0 +1,181 @@
+package kubernetes_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/scheduler/kubernetes"
+	"github.com/odpf/optimus/job"
+	mocked "github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHTTPClient struct {
+	DoFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.DoFunc(req)
+}
+
+func respond(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+func TestKubernetes(t *testing.T) {
+	ctx := context.Background()
+	projectSpec := models.ProjectSpec{
+		Name: "proj_name",
+		Config: map[string]string{
+			models.ProjectSchedulerHost:              "https://k8s.example.io",
+			kubernetes.ProjectKubernetesNamespaceKey: "optimus-jobs",
+		},
+		Secret: []models.ProjectSecretItem{
+			{
+				Name:  models.ProjectSchedulerAuth,
+				Value: "token",
+			},
+		},
+	}
+	namespaceSpec := models.NamespaceSpec{
+		Name:        "team_a",
+		ProjectSpec: projectSpec,
+	}
+
+	t.Run("DeployJobs", func(t *testing.T) {
+		execUnit := new(mocked.BasePlugin)
+		execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:  "bq2bq",
+			Image: "example.io/namespace/image:latest",
+		}, nil)
+		preHookUnit := new(mocked.BasePlugin)
+		preHookUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:     "transporter",
+			HookType: models.HookTypePre,
+			Image:    "example.io/namespace/transporter-image:latest",
+		}, nil)
+		postHookUnit := new(mocked.BasePlugin)
+		postHookUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:     "predator",
+			HookType: models.HookTypePost,
+			Image:    "example.io/namespace/predator-image:latest",
+		}, nil)
+		upstreamSpec := models.JobSpec{
+			Name: "upstream_job",
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{Base: execUnit},
+			},
+		}
+		jobSpec := models.JobSpec{
+			Name:  "foo.bar_job",
+			Owner: "mee@mee",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+				Interval:  "0 2 * * *",
+			},
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{Base: execUnit},
+			},
+			Dependencies: map[string]models.JobSpecDependency{
+				"upstream_job": {
+					Job:          &upstreamSpec,
+					Type:         models.JobSpecDependencyTypeIntra,
+					PokeInterval: time.Minute,
+				},
+			},
+			Hooks: []models.JobSpecHook{
+				{Unit: &models.Plugin{Base: preHookUnit}},
+				{Unit: &models.Plugin{Base: postHookUnit}},
+			},
+		}
+
+		t.Run("should apply compiled cron jobs running sensors, pre hooks and task as init containers", func(t *testing.T) {
+			var applied *http.Request
+			var manifest string
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body, _ := ioutil.ReadAll(req.Body)
+					applied, manifest = req, string(body)
+					return respond(http.StatusOK, "{}"), nil
+				},
+			}
+
+			obs := new(mocked.PipelineLogObserver)
+			obs.On("Notify", mock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			scheduler := kubernetes.NewScheduler(client, "optimus.example.io:80")
+			err := scheduler.DeployJobs(ctx, namespaceSpec, []models.JobSpec{jobSpec}, obs)
+			assert.Nil(t, err)
+			obs.AssertCalled(t, "Notify", &job.EventJobUpload{Job: jobSpec})
+
+			assert.Equal(t, http.MethodPatch, applied.Method)
+			assert.Equal(t, "https://k8s.example.io/apis/batch/v1/namespaces/optimus-jobs/cronjobs/foo-bar-job?fieldManager=optimus&force=true",
+				applied.URL.String())
+			assert.Equal(t, "Bearer token", applied.Header.Get("Authorization"))
+
+			assert.Contains(t, manifest, "kind: CronJob")
+			assert.Contains(t, manifest, `schedule: "0 2 * * *"`)
+			assert.Contains(t, manifest, "- --project=proj_name\n            - --host=optimus.example.io:80\n            - --run=$(CRONJOB_RUN)\n            - --poke-interval=1m0s")
+
+			waitIdx := bytes.Index([]byte(manifest), []byte("- name: wait-upstream-job"))
+			preHookIdx := bytes.Index([]byte(manifest), []byte("- name: hook-transporter"))
+			taskIdx := bytes.Index([]byte(manifest), []byte("- name: task"))
+			containersIdx := bytes.Index([]byte(manifest), []byte("containers:\n          - name: hook-predator"))
+			assert.True(t, waitIdx > 0 && waitIdx < preHookIdx && preHookIdx < taskIdx && taskIdx < containersIdx)
+		})
+	})
+	t.Run("GetJobStatus", func(t *testing.T) {
+		t.Run("should map job conditions to job statuses", func(t *testing.T) {
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "optimus.io/cron-job=foo-bar-job", req.URL.Query().Get("labelSelector"))
+					return respond(http.StatusOK, `{"metadata": {}, "items": [
+						{"metadata": {"name": "foo-bar-job-26827320"}, "status": {}},
+						{"metadata": {"name": "foo-bar-job-26824440"}, "status": {"conditions": [{"type": "Complete", "status": "True"}]}},
+						{"metadata": {"name": "foo-bar-job-26825880"}, "status": {"conditions": [{"type": "Failed", "status": "True"}]}}
+					]}`), nil
+				},
+			}
+
+			scheduler := kubernetes.NewScheduler(client, "")
+			statuses, err := scheduler.GetJobStatus(ctx, projectSpec, "foo.bar_job")
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobStatus{
+				{ScheduledAt: time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateSuccess},
+				{ScheduledAt: time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateFailed},
+				{ScheduledAt: time.Date(2021, 1, 3, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateRunning},
+			}, statuses)
+		})
+	})
+	t.Run("Clear", func(t *testing.T) {
+		t.Run("should not be supported", func(t *testing.T) {
+			scheduler := kubernetes.NewScheduler(nil, "")
+			err := scheduler.Clear(ctx, projectSpec, "foo.bar_job", time.Now(), time.Now())
+			assert.Equal(t, kubernetes.ErrClearNotSupported, err)
+		})
+	})
+}
+
+func TestRunScheduledAt(t *testing.T) {
+	t.Run("should parse scheduled time from name of job created by cron job", func(t *testing.T) {
+		scheduledAt, err := kubernetes.RunScheduledAt("foo-bar-job-26824440")
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC), scheduledAt)
+	})
+	t.Run("should fail for jobs not created by cron job", func(t *testing.T) {
+		_, err := kubernetes.RunScheduledAt("manual")
+		assert.NotNil(t, err)
+		_, err = kubernetes.RunScheduledAt("foo-manual")
+		assert.NotNil(t, err)
+	})
+}
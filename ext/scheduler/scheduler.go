@@ -1 +1,159 @@
 package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+// ErrUnsupportedScheduler is returned when a project is configured with
+// a scheduler which is not registered
+var ErrUnsupportedScheduler = errors.New("unsupported scheduler")
+
+// ProjectScheduler routes operations of a project to the scheduler named in
+// its models.ProjectSchedulerName config, projects without one use the
+// default scheduler. This lets projects migrate e.g. from airflow to airflow2
+// one at a time with the same server
+type ProjectScheduler struct {
+	defaultScheduler models.SchedulerUnit
+	schedulers       map[string]models.SchedulerUnit
+}
+
+// For returns the scheduler of a project
+func (s *ProjectScheduler) For(proj models.ProjectSpec) (models.SchedulerUnit, error) {
+	name, ok := proj.Config[models.ProjectSchedulerName]
+	if !ok || name == "" {
+		return s.defaultScheduler, nil
+	}
+	schd, ok := s.schedulers[name]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnsupportedScheduler, "%s configured for project %s", name, proj.Name)
+	}
+	return schd, nil
+}
+
+func (s *ProjectScheduler) GetName() string {
+	return s.defaultScheduler.GetName()
+}
+
+func (s *ProjectScheduler) GetTemplate() []byte {
+	return s.defaultScheduler.GetTemplate()
+}
+
+func (s *ProjectScheduler) GetJobsDir() string {
+	return s.defaultScheduler.GetJobsDir()
+}
+
+func (s *ProjectScheduler) GetJobsExtension() string {
+	return s.defaultScheduler.GetJobsExtension()
+}
+
+func (s *ProjectScheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec, obs progress.Observer) error {
+	schd, err := s.For(proj)
+	if err != nil {
+		return err
+	}
+	return schd.Bootstrap(ctx, proj, obs)
+}
+
+func (s *ProjectScheduler) ValidateProject(proj models.ProjectSpec) error {
+	schd, err := s.For(proj)
+	if err != nil {
+		return err
+	}
+	return schd.ValidateProject(proj)
+}
+
+func (s *ProjectScheduler) DeployJobs(ctx context.Context, namespace models.NamespaceSpec, jobs []models.JobSpec,
+	obs progress.Observer) error {
+	schd, err := s.For(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+	return schd.DeployJobs(ctx, namespace, jobs, obs)
+}
+
+func (s *ProjectScheduler) DeleteJobs(ctx context.Context, namespace models.NamespaceSpec, jobNames []string,
+	obs progress.Observer) error {
+	schd, err := s.For(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+	return schd.DeleteJobs(ctx, namespace, jobNames, obs)
+}
+
+func (s *ProjectScheduler) ListJobs(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	schd, err := s.For(namespace.ProjectSpec)
+	if err != nil {
+		return nil, err
+	}
+	return schd.ListJobs(ctx, namespace)
+}
+
+func (s *ProjectScheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus, error) {
+	schd, err := s.For(projSpec)
+	if err != nil {
+		return nil, err
+	}
+	return schd.GetJobStatus(ctx, projSpec, jobName)
+}
+
+func (s *ProjectScheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
+	schd, err := s.For(projSpec)
+	if err != nil {
+		return err
+	}
+	return schd.Clear(ctx, projSpec, jobName, startDate, endDate)
+}
+
+func (s *ProjectScheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
+	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
+	schd, err := s.For(projSpec)
+	if err != nil {
+		return nil, err
+	}
+	return schd.GetDagRunStatus(ctx, projSpec, jobName, startDate, endDate, batchSize)
+}
+
+// NewProjectScheduler constructs a scheduler routing projects among the given
+// schedulers by their name, defaultName is used for projects not choosing one
+func NewProjectScheduler(defaultName string, schedulers ...models.SchedulerUnit) (*ProjectScheduler, error) {
+	s := &ProjectScheduler{
+		schedulers: map[string]models.SchedulerUnit{},
+	}
+	for _, schd := range schedulers {
+		s.schedulers[schd.GetName()] = schd
+	}
+	defaultScheduler, ok := s.schedulers[defaultName]
+	if !ok {
+		return nil, errors.Wrap(ErrUnsupportedScheduler, defaultName)
+	}
+	s.defaultScheduler = defaultScheduler
+	return s, nil
+}
+
+// Compiler compiles jobs to the template of the scheduler of their project
+type Compiler struct {
+	scheduler *ProjectScheduler
+	hostname  string
+}
+
+func (c *Compiler) Compile(namespace models.NamespaceSpec, jobSpec models.JobSpec) (models.Job, error) {
+	schd, err := c.scheduler.For(namespace.ProjectSpec)
+	if err != nil {
+		return models.Job{}, err
+	}
+	return job.NewCompiler(schd.GetTemplate(), c.hostname).Compile(namespace, jobSpec)
+}
+
+// NewCompiler constructs a compiler linking jobs to optimus reachable at hostname
+func NewCompiler(scheduler *ProjectScheduler, hostname string) *Compiler {
+	return &Compiler{
+		scheduler: scheduler,
+		hostname:  hostname,
+	}
+}
@@ -0,0 +1,59 @@
+package scheduler_test
+
+import (
+	"testing"
+
+	"github.com/odpf/optimus/ext/scheduler"
+	"github.com/odpf/optimus/ext/scheduler/airflow"
+	"github.com/odpf/optimus/ext/scheduler/airflow2"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectScheduler(t *testing.T) {
+	newScheduler := func(defaultName string) (*scheduler.ProjectScheduler, error) {
+		return scheduler.NewProjectScheduler(defaultName,
+			airflow.NewScheduler(nil, nil, nil, ""),
+			airflow2.NewScheduler(nil, nil, nil, ""),
+		)
+	}
+
+	t.Run("should use scheduler configured for project", func(t *testing.T) {
+		projectScheduler, err := newScheduler("airflow")
+		assert.Nil(t, err)
+
+		schd, err := projectScheduler.For(models.ProjectSpec{
+			Name: "proj",
+			Config: map[string]string{
+				models.ProjectSchedulerName: "airflow2",
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "airflow2", schd.GetName())
+	})
+	t.Run("should fall back to default scheduler", func(t *testing.T) {
+		projectScheduler, err := newScheduler("airflow")
+		assert.Nil(t, err)
+
+		schd, err := projectScheduler.For(models.ProjectSpec{Name: "proj"})
+		assert.Nil(t, err)
+		assert.Equal(t, "airflow", schd.GetName())
+		assert.Equal(t, "airflow", projectScheduler.GetName())
+	})
+	t.Run("should fail for schedulers which are not registered", func(t *testing.T) {
+		projectScheduler, err := newScheduler("airflow2")
+		assert.Nil(t, err)
+
+		_, err = projectScheduler.For(models.ProjectSpec{
+			Name: "proj",
+			Config: map[string]string{
+				models.ProjectSchedulerName: "oozie",
+			},
+		})
+		assert.True(t, errors.Is(err, scheduler.ErrUnsupportedScheduler))
+
+		_, err = newScheduler("oozie")
+		assert.True(t, errors.Is(err, scheduler.ErrUnsupportedScheduler))
+	})
+}
@@ -0,0 +1,471 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kushsharma/parallel"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+
+	_ "embed"
+)
+
+//go:embed resources/schedule.json
+var resSchedule []byte
+
+const (
+	// ProjectTemporalNamespaceKey is the temporal namespace schedules of a
+	// project are registered in, name of project is used if not set
+	ProjectTemporalNamespaceKey = "TEMPORAL_NAMESPACE"
+
+	// ProjectTemporalTaskQueueKey is the task queue workers executing
+	// OptimusJobRun workflows of a project poll, optimus if not set
+	ProjectTemporalTaskQueueKey = "TEMPORAL_TASK_QUEUE"
+
+	schedulesURL = "%s/api/v1/namespaces/%s/schedules"
+	workflowsURL = "%s/api/v1/namespaces/%s/workflows"
+
+	scheduledByIDAttribute   = "TemporalScheduledById"
+	scheduledStartAttribute  = "TemporalScheduledStartTime"
+	statusRunning            = "WORKFLOW_EXECUTION_STATUS_RUNNING"
+	statusCompleted          = "WORKFLOW_EXECUTION_STATUS_COMPLETED"
+	statusContinuedAsNew     = "WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW"
+	overlapPolicyAllowAll    = "SCHEDULE_OVERLAP_POLICY_ALLOW_ALL"
+	scheduleIDSeparator      = ":"
+	scheduleTimestampLayout  = time.RFC3339
+	defaultListSchedulesSize = 100
+)
+
+// errAlreadyExists is returned when creating a schedule registered before
+var errAlreadyExists = errors.New("already exists")
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// scheduler registers every job as a temporal schedule through the http
+// api of temporal frontend provided at models.ProjectSchedulerHost. Each
+// schedule starts an OptimusJobRun workflow whose input lists task and
+// hooks as activities in the order they run, workers polling the task
+// queue of project execute them with durable retries
+type scheduler struct {
+	httpClient HTTPClient
+	compiler   models.JobCompiler
+}
+
+// NewScheduler constructs a scheduler registering schedules compiled with
+// links to optimus reachable at hostname
+func NewScheduler(httpClient HTTPClient, hostname string) *scheduler {
+	return &scheduler{
+		httpClient: httpClient,
+		compiler:   job.NewCompiler(resSchedule, hostname),
+	}
+}
+
+func (s *scheduler) GetName() string {
+	return "temporal"
+}
+
+// GetJobsDir is empty as schedules are registered with temporal
+func (s *scheduler) GetJobsDir() string {
+	return ""
+}
+
+func (s *scheduler) GetJobsExtension() string {
+	return ".json"
+}
+
+func (s *scheduler) GetTemplate() []byte {
+	return resSchedule
+}
+
+// Bootstrap only verifies temporal of project is configured
+func (s *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec, obs progress.Observer) error {
+	_, err := clusterOf(proj)
+	return err
+}
+
+// ValidateProject checks host of project is a http url
+func (s *scheduler) ValidateProject(proj models.ProjectSpec) error {
+	host, ok := proj.Config[models.ProjectSchedulerHost]
+	if !ok {
+		return errors.Errorf("%s config not configured for project %s", models.ProjectSchedulerHost, proj.Name)
+	}
+	if u, err := url.Parse(host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("%s config for project %s should be a http url: %s", models.ProjectSchedulerHost, proj.Name, host)
+	}
+	return nil
+}
+
+func (s *scheduler) DeployJobs(ctx context.Context, namespace models.NamespaceSpec, jobs []models.JobSpec,
+	obs progress.Observer) error {
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+
+	runner := parallel.NewRunner(parallel.WithTicket(job.ConcurrentTicketPerSec))
+	for _, jobSpec := range jobs {
+		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				compiledJob, err := s.compiler.Compile(namespace, currentSpec)
+				if err != nil {
+					return nil, err
+				}
+				notify(obs, &job.EventJobSpecCompile{
+					Name: currentSpec.Name,
+				})
+				return nil, s.upsertSchedule(ctx, cluster, scheduleID(namespace.Name, currentSpec.Name), compiledJob.Contents)
+			}
+		}(jobSpec))
+	}
+
+	for runIdx, state := range runner.Run() {
+		notify(obs, &job.EventJobUpload{
+			Job: jobs[runIdx],
+			Err: state.Err,
+		})
+	}
+	return nil
+}
+
+// upsertSchedule creates the schedule or updates it if already registered
+func (s *scheduler) upsertSchedule(ctx context.Context, cluster cluster, id string, schedule []byte) error {
+	err := s.do(ctx, cluster, http.MethodPost, cluster.scheduleURL(id), schedule, nil)
+	if !errors.Is(err, errAlreadyExists) {
+		return err
+	}
+	return s.do(ctx, cluster, http.MethodPost, cluster.scheduleURL(id)+"/update", schedule, nil)
+}
+
+func (s *scheduler) DeleteJobs(ctx context.Context, namespace models.NamespaceSpec, jobNames []string,
+	obs progress.Observer) error {
+	if len(jobNames) == 0 {
+		return nil
+	}
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return err
+	}
+	for _, jobName := range jobNames {
+		if err := s.do(ctx, cluster, http.MethodDelete, cluster.scheduleURL(scheduleID(namespace.Name, jobName)), nil, nil); err != nil &&
+			!errors.Is(err, models.ErrNoSuchJob) {
+			return err
+		}
+		notify(obs, &job.EventJobRemoteDelete{Name: jobName})
+	}
+	return nil
+}
+
+func (s *scheduler) ListJobs(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	cluster, err := clusterOf(namespace.ProjectSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := namespace.Name + scheduleIDSeparator
+	var jobNames []string
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("maximumPageSize", strconv.Itoa(defaultListSchedulesSize))
+		if pageToken != "" {
+			query.Set("nextPageToken", pageToken)
+		}
+
+		var schedules scheduleList
+		if err := s.do(ctx, cluster, http.MethodGet, fmt.Sprintf("%s?%s", cluster.schedulesURL(), query.Encode()),
+			nil, &schedules); err != nil {
+			return nil, err
+		}
+		for _, schedule := range schedules.Schedules {
+			if strings.HasPrefix(schedule.ScheduleID, prefix) {
+				jobNames = append(jobNames, strings.TrimPrefix(schedule.ScheduleID, prefix))
+			}
+		}
+
+		pageToken = schedules.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+	return jobNames, nil
+}
+
+func (s *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus,
+	error) {
+	return s.listRuns(ctx, projSpec, jobName, 0, func(models.JobStatus) bool {
+		return true
+	})
+}
+
+// Clear backfills the schedule of job between dates, starting runs for all
+// its schedules even if they ran already
+func (s *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
+	cluster, err := clusterOf(projSpec)
+	if err != nil {
+		return err
+	}
+	namespaceName, err := s.namespaceOf(ctx, cluster, jobName)
+	if err != nil {
+		return err
+	}
+
+	// backfill range excludes start time
+	patch, err := json.Marshal(map[string]interface{}{
+		"patch": map[string]interface{}{
+			"backfillRequest": []map[string]interface{}{
+				{
+					"startTime":     startDate.Add(-time.Second).UTC().Format(scheduleTimestampLayout),
+					"endTime":       endDate.UTC().Format(scheduleTimestampLayout),
+					"overlapPolicy": overlapPolicyAllowAll,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return s.do(ctx, cluster, http.MethodPost, cluster.scheduleURL(scheduleID(namespaceName, jobName))+"/patch",
+		patch, nil)
+}
+
+func (s *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
+	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
+	return s.listRuns(ctx, projSpec, jobName, batchSize, func(status models.JobStatus) bool {
+		return !status.ScheduledAt.Before(startDate) && !status.ScheduledAt.After(endDate)
+	})
+}
+
+// namespaceOf finds the optimus namespace job is registered with as
+// schedules are identified by both
+func (s *scheduler) namespaceOf(ctx context.Context, cluster cluster, jobName string) (string, error) {
+	suffix := scheduleIDSeparator + jobName
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("maximumPageSize", strconv.Itoa(defaultListSchedulesSize))
+		if pageToken != "" {
+			query.Set("nextPageToken", pageToken)
+		}
+
+		var schedules scheduleList
+		if err := s.do(ctx, cluster, http.MethodGet, fmt.Sprintf("%s?%s", cluster.schedulesURL(), query.Encode()),
+			nil, &schedules); err != nil {
+			return "", err
+		}
+		for _, schedule := range schedules.Schedules {
+			if strings.HasSuffix(schedule.ScheduleID, suffix) {
+				return strings.TrimSuffix(schedule.ScheduleID, suffix), nil
+			}
+		}
+
+		pageToken = schedules.NextPageToken
+		if pageToken == "" {
+			return "", errors.Wrapf(models.ErrNoSuchJob, "schedule of %s", jobName)
+		}
+	}
+}
+
+// listRuns pages through workflows started by schedule of job, batchSize
+// limits workflows fetched in a call and 0 uses the server default
+func (s *scheduler) listRuns(ctx context.Context, projSpec models.ProjectSpec, jobName string, batchSize int,
+	filter func(models.JobStatus) bool) ([]models.JobStatus, error) {
+	cluster, err := clusterOf(projSpec)
+	if err != nil {
+		return nil, err
+	}
+	namespaceName, err := s.namespaceOf(ctx, cluster, jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []models.JobStatus
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("query", fmt.Sprintf("%s = '%s'", scheduledByIDAttribute, scheduleID(namespaceName, jobName)))
+		if batchSize > 0 {
+			query.Set("pageSize", strconv.Itoa(batchSize))
+		}
+		if pageToken != "" {
+			query.Set("nextPageToken", pageToken)
+		}
+
+		var runs executionList
+		if err := s.do(ctx, cluster, http.MethodGet, fmt.Sprintf("%s?%s", cluster.workflowsURL(), query.Encode()),
+			nil, &runs); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch runs of job %s", jobName)
+		}
+		for _, execution := range runs.Executions {
+			status, err := toJobStatus(execution)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid run of job %s", jobName)
+			}
+			if filter(status) {
+				statuses = append(statuses, status)
+			}
+		}
+
+		pageToken = runs.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ScheduledAt.Before(statuses[j].ScheduledAt)
+	})
+	return statuses, nil
+}
+
+func (s *scheduler) do(ctx context.Context, cluster cluster, method, reqURL string, body []byte, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", reqURL)
+	}
+	if cluster.token != "" {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cluster.token))
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", reqURL)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response of %s", reqURL)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.Wrap(models.ErrNoSuchJob, string(respBody))
+	case resp.StatusCode == http.StatusConflict:
+		return errors.Wrap(errAlreadyExists, string(respBody))
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return errors.Errorf("%s %s failed with status %d: %s", method, reqURL, resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// cluster is the temporal namespace where schedules of a project run
+type cluster struct {
+	host      string
+	token     string
+	namespace string
+}
+
+func (c cluster) schedulesURL() string {
+	return fmt.Sprintf(schedulesURL, c.host, url.PathEscape(c.namespace))
+}
+
+func (c cluster) scheduleURL(id string) string {
+	return fmt.Sprintf("%s/%s", c.schedulesURL(), url.PathEscape(id))
+}
+
+func (c cluster) workflowsURL() string {
+	return fmt.Sprintf(workflowsURL, c.host, url.PathEscape(c.namespace))
+}
+
+// clusterOf resolves temporal of project, the auth secret is optional as
+// temporal is often reached without one within a private network
+func clusterOf(proj models.ProjectSpec) (cluster, error) {
+	host, ok := proj.Config[models.ProjectSchedulerHost]
+	if !ok {
+		return cluster{}, errors.Errorf("%s config not configured for project %s", models.ProjectSchedulerHost, proj.Name)
+	}
+	token, _ := proj.Secret.GetByName(models.ProjectSchedulerAuth)
+	namespace, ok := proj.Config[ProjectTemporalNamespaceKey]
+	if !ok || namespace == "" {
+		namespace = proj.Name
+	}
+	return cluster{
+		host:      strings.TrimSuffix(host, "/"),
+		token:     token,
+		namespace: namespace,
+	}, nil
+}
+
+// scheduleID identifies schedule of a job, names of optimus namespaces
+// don't contain the separator while names of jobs may
+func scheduleID(namespaceName, jobName string) string {
+	return namespaceName + scheduleIDSeparator + jobName
+}
+
+type scheduleList struct {
+	Schedules []struct {
+		ScheduleID string `json:"scheduleId"`
+	} `json:"schedules"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+type executionList struct {
+	Executions    []execution `json:"executions"`
+	NextPageToken string      `json:"nextPageToken"`
+}
+
+type execution struct {
+	Execution struct {
+		WorkflowID string `json:"workflowId"`
+	} `json:"execution"`
+	StartTime        time.Time `json:"startTime"`
+	Status           string    `json:"status"`
+	SearchAttributes struct {
+		IndexedFields map[string]json.RawMessage `json:"indexedFields"`
+	} `json:"searchAttributes"`
+}
+
+// toJobStatus maps a workflow execution to status of the run it was scheduled
+// for, executions started by hand use their start time
+func toJobStatus(run execution) (models.JobStatus, error) {
+	scheduledAt := run.StartTime
+	if raw, ok := run.SearchAttributes.IndexedFields[scheduledStartAttribute]; ok {
+		if err := json.Unmarshal(raw, &scheduledAt); err != nil {
+			return models.JobStatus{}, errors.Wrapf(err, "failed to parse scheduled time of %s", run.Execution.WorkflowID)
+		}
+	}
+
+	var state models.JobStatusState
+	switch run.Status {
+	case statusCompleted, statusContinuedAsNew:
+		state = models.JobStatusStateSuccess
+	case statusRunning:
+		state = models.JobStatusStateRunning
+	default:
+		// failed, canceled, terminated and timed out executions
+		state = models.JobStatusStateFailed
+	}
+	return models.JobStatus{
+		ScheduledAt: scheduledAt,
+		State:       state,
+	}, nil
+}
+
+func notify(obs progress.Observer, evt progress.Event) {
+	if obs == nil {
+		return
+	}
+	obs.Notify(evt)
+}
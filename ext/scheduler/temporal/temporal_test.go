@@ -0,0 +1,246 @@
+package temporal_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/ext/scheduler/temporal"
+	"github.com/odpf/optimus/job"
+	mocked "github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHTTPClient struct {
+	DoFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.DoFunc(req)
+}
+
+func respond(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+func TestTemporal(t *testing.T) {
+	ctx := context.Background()
+	projectSpec := models.ProjectSpec{
+		Name: "proj",
+		Config: map[string]string{
+			models.ProjectSchedulerHost:          "http://temporal.example.io:7243/",
+			temporal.ProjectTemporalNamespaceKey: "optimus",
+			temporal.ProjectTemporalTaskQueueKey: "optimus-jobs",
+		},
+	}
+	namespaceSpec := models.NamespaceSpec{
+		Name:        "team_a",
+		ProjectSpec: projectSpec,
+	}
+	schedules := `{"schedules": [{"scheduleId": "team_a:foo.bar_job"}, {"scheduleId": "team_b:job-2"}]}`
+
+	t.Run("DeployJobs", func(t *testing.T) {
+		execUnit := new(mocked.BasePlugin)
+		execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:  "bq2bq",
+			Image: "example.io/namespace/image:latest",
+		}, nil)
+		preHookUnit := new(mocked.BasePlugin)
+		preHookUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:     "transporter",
+			HookType: models.HookTypePre,
+			Image:    "example.io/namespace/transporter-image:latest",
+		}, nil)
+		postHookUnit := new(mocked.BasePlugin)
+		postHookUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:     "predator",
+			HookType: models.HookTypePost,
+			Image:    "example.io/namespace/predator-image:latest",
+		}, nil)
+		jobSpec := models.JobSpec{
+			Name:  "foo.bar_job",
+			Owner: "mee@mee",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+				Interval:  "0 2 * * *",
+			},
+			Behavior: models.JobSpecBehavior{
+				Retry: models.JobSpecBehaviorRetry{
+					Count: 2,
+					Delay: time.Minute,
+				},
+			},
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{Base: execUnit},
+			},
+			Hooks: []models.JobSpecHook{
+				{Unit: &models.Plugin{Base: postHookUnit}},
+				{Unit: &models.Plugin{Base: preHookUnit}},
+			},
+		}
+
+		t.Run("should register schedules starting job run workflows with task and hooks as activities", func(t *testing.T) {
+			var requests []string
+			var schedule struct {
+				Schedule struct {
+					Spec struct {
+						CronString []string `json:"cronString"`
+					} `json:"spec"`
+					Action struct {
+						StartWorkflow struct {
+							WorkflowType struct {
+								Name string `json:"name"`
+							} `json:"workflowType"`
+							TaskQueue struct {
+								Name string `json:"name"`
+							} `json:"taskQueue"`
+							Input []struct {
+								Job        string `json:"job"`
+								Activities []struct {
+									Name        string            `json:"name"`
+									Stage       string            `json:"stage"`
+									Env         map[string]string `json:"env"`
+									RetryPolicy *struct {
+										MaximumAttempts int    `json:"maximumAttempts"`
+										InitialInterval string `json:"initialInterval"`
+									} `json:"retryPolicy"`
+								} `json:"activities"`
+							} `json:"input"`
+						} `json:"startWorkflow"`
+					} `json:"action"`
+				} `json:"schedule"`
+			}
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.Method+" "+req.URL.String())
+					body, _ := ioutil.ReadAll(req.Body)
+					assert.Nil(t, json.Unmarshal(body, &schedule))
+					if len(requests) == 1 {
+						return respond(http.StatusConflict, `{"message": "schedule already exists"}`), nil
+					}
+					return respond(http.StatusOK, "{}"), nil
+				},
+			}
+
+			obs := new(mocked.PipelineLogObserver)
+			obs.On("Notify", mock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			scheduler := temporal.NewScheduler(client, "optimus.example.io:80")
+			err := scheduler.DeployJobs(ctx, namespaceSpec, []models.JobSpec{jobSpec}, obs)
+			assert.Nil(t, err)
+			obs.AssertCalled(t, "Notify", &job.EventJobUpload{Job: jobSpec})
+
+			assert.Equal(t, []string{
+				"POST http://temporal.example.io:7243/api/v1/namespaces/optimus/schedules/team_a:foo.bar_job",
+				"POST http://temporal.example.io:7243/api/v1/namespaces/optimus/schedules/team_a:foo.bar_job/update",
+			}, requests)
+
+			action := schedule.Schedule.Action.StartWorkflow
+			assert.Equal(t, []string{"0 2 * * *"}, schedule.Schedule.Spec.CronString)
+			assert.Equal(t, "OptimusJobRun", action.WorkflowType.Name)
+			assert.Equal(t, "optimus-jobs", action.TaskQueue.Name)
+			assert.Len(t, action.Input, 1)
+			assert.Equal(t, "foo.bar_job", action.Input[0].Job)
+
+			activities := action.Input[0].Activities
+			assert.Len(t, activities, 3)
+			assert.Equal(t, "transporter", activities[0].Name)
+			assert.Equal(t, "pre", activities[0].Stage)
+			assert.Equal(t, "bq2bq", activities[1].Name)
+			assert.Equal(t, "task", activities[1].Stage)
+			assert.Equal(t, "optimus.example.io:80", activities[1].Env["OPTIMUS_HOSTNAME"])
+			assert.Equal(t, 3, activities[1].RetryPolicy.MaximumAttempts)
+			assert.Equal(t, "60s", activities[1].RetryPolicy.InitialInterval)
+			assert.Equal(t, "predator", activities[2].Name)
+			assert.Equal(t, "post", activities[2].Stage)
+		})
+	})
+	t.Run("ListJobs", func(t *testing.T) {
+		t.Run("should return jobs with schedules registered for namespace", func(t *testing.T) {
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return respond(http.StatusOK, schedules), nil
+				},
+			}
+
+			scheduler := temporal.NewScheduler(client, "")
+			jobNames, err := scheduler.ListJobs(ctx, namespaceSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"foo.bar_job"}, jobNames)
+		})
+	})
+	t.Run("GetJobStatus", func(t *testing.T) {
+		t.Run("should map workflow execution statuses to job statuses", func(t *testing.T) {
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path == "/api/v1/namespaces/optimus/schedules" {
+						return respond(http.StatusOK, schedules), nil
+					}
+					assert.Equal(t, "TemporalScheduledById = 'team_a:foo.bar_job'", req.URL.Query().Get("query"))
+					return respond(http.StatusOK, `{"executions": [
+						{"status": "WORKFLOW_EXECUTION_STATUS_RUNNING", "searchAttributes": {"indexedFields": {"TemporalScheduledStartTime": "2021-01-03T02:00:00Z"}}},
+						{"status": "WORKFLOW_EXECUTION_STATUS_COMPLETED", "searchAttributes": {"indexedFields": {"TemporalScheduledStartTime": "2021-01-01T02:00:00Z"}}},
+						{"status": "WORKFLOW_EXECUTION_STATUS_TIMED_OUT", "searchAttributes": {"indexedFields": {"TemporalScheduledStartTime": "2021-01-02T02:00:00Z"}}}
+					]}`), nil
+				},
+			}
+
+			scheduler := temporal.NewScheduler(client, "")
+			statuses, err := scheduler.GetJobStatus(ctx, projectSpec, "foo.bar_job")
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobStatus{
+				{ScheduledAt: time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateSuccess},
+				{ScheduledAt: time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateFailed},
+				{ScheduledAt: time.Date(2021, 1, 3, 2, 0, 0, 0, time.UTC), State: models.JobStatusStateRunning},
+			}, statuses)
+		})
+	})
+	t.Run("Clear", func(t *testing.T) {
+		t.Run("should backfill schedule of job between dates", func(t *testing.T) {
+			var patch string
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodGet {
+						return respond(http.StatusOK, schedules), nil
+					}
+					assert.Equal(t, "/api/v1/namespaces/optimus/schedules/team_a:foo.bar_job/patch", req.URL.Path)
+					body, _ := ioutil.ReadAll(req.Body)
+					patch = string(body)
+					return respond(http.StatusOK, "{}"), nil
+				},
+			}
+
+			scheduler := temporal.NewScheduler(client, "")
+			err := scheduler.Clear(ctx, projectSpec, "foo.bar_job",
+				time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC), time.Date(2021, 1, 3, 2, 0, 0, 0, time.UTC))
+			assert.Nil(t, err)
+			assert.JSONEq(t, `{"patch": {"backfillRequest": [{
+				"startTime": "2021-01-01T01:59:59Z",
+				"endTime": "2021-01-03T02:00:00Z",
+				"overlapPolicy": "SCHEDULE_OVERLAP_POLICY_ALLOW_ALL"
+			}]}}`, patch)
+		})
+		t.Run("should fail for jobs without schedule", func(t *testing.T) {
+			client := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return respond(http.StatusOK, schedules), nil
+				},
+			}
+
+			scheduler := temporal.NewScheduler(client, "")
+			err := scheduler.Clear(ctx, projectSpec, "unknown", time.Now(), time.Now())
+			assert.True(t, errors.Is(err, models.ErrNoSuchJob))
+		})
+	})
+}
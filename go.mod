@@ -6,6 +6,8 @@ require (
 	cloud.google.com/go/storage v1.10.0
 	github.com/AlecAivazis/survey/v2 v2.2.7
 	github.com/Masterminds/sprig/v3 v3.2.2
+	github.com/aws/aws-sdk-go v1.33.19
+	github.com/coreos/go-oidc/v3 v3.0.0
 	github.com/dustinkirkland/golang-petname v0.0.0-20191129215211-8e5a1ed0cff0
 	github.com/emirpasic/gods v1.12.0
 	github.com/fatih/color v1.7.0
@@ -14,6 +16,7 @@ require (
 	github.com/golang-migrate/migrate/v4 v4.14.1
 	github.com/golang/protobuf v1.5.2
 	github.com/google/uuid v1.1.2
+	github.com/googleapis/gax-go/v2 v2.0.5
 	github.com/googleapis/google-cloud-go-testing v0.0.0-20200911160855-bcd43fbb19e8
 	github.com/gorilla/mux v1.7.4
 	github.com/grpc-ecosystem/go-grpc-middleware v1.1.0
@@ -24,7 +27,7 @@ require (
 	github.com/hashicorp/go-plugin v1.4.1
 	github.com/hashicorp/go-version v1.1.0
 	github.com/huandu/xstrings v1.3.2 // indirect
-	github.com/jhump/protoreflect v1.8.1 // indirect
+	github.com/jhump/protoreflect v1.8.1
 	github.com/jinzhu/gorm v1.9.16
 	github.com/klauspost/compress v1.11.7 // indirect
 	github.com/knadh/koanf v1.1.0
@@ -44,6 +47,7 @@ require (
 	github.com/xlab/treeprint v1.1.0
 	golang.org/x/net v0.0.0-20210405180319-a5a99cb37ef4
 	golang.org/x/oauth2 v0.0.0-20210402161424-2e8d93401602
+	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
 	google.golang.org/api v0.44.0
 	google.golang.org/genproto v0.0.0-20210602131652-f16073e35f0c
 	google.golang.org/grpc v1.38.0
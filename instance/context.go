@@ -32,7 +32,8 @@ var (
 // transformed before they can work as inputs. Input could be through
 // environment variables or as a file.
 // It exposes .proj, .inst, .task variable names containing configs that can be
-// used in job specification
+// used in job specification, configs can also read project secrets using .secret
+// and macros of the project and namespace are available as .macro
 type ContextManager struct {
 	namespace models.NamespaceSpec
 	jobSpec   models.JobSpec
@@ -57,8 +58,20 @@ func (fm *ContextManager) Generate(
 	projectInstanceContext["proj"] = projRawConfig
 	projectInstanceContext["inst"] = instanceEnvMap
 
+	// namespace macros take precedence over project macros
+	macros, err := fm.namespace.ProjectSpec.Macros.Resolve(fm.namespace.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	projectInstanceContext["macro"] = macros
+
+	// secrets are only available for templating configs, assets are
+	// compiled without them to avoid leaking secrets in rendered files
+	configContext := MergeInterfaceMapToInterface(projectInstanceContext, nil)
+	configContext["secret"] = fm.getSecretMap()
+
 	// prepare configs
-	envMap, err = fm.generateEnvs(runName, runType, projectInstanceContext)
+	envMap, err = fm.generateEnvs(runName, runType, configContext)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,20 +84,30 @@ func (fm *ContextManager) Generate(
 	}
 
 	// do the same for asset files
-	// check if task needs to override the compilation behaviour
-	compiledAssetResponse, err := fm.jobSpec.Task.Unit.CLIMod.CompileAssets(context.Background(), models.CompileAssetsRequest{
-		Window:           fm.jobSpec.Task.Window,
-		Config:           models.PluginConfigs{}.FromJobSpec(fm.jobSpec.Task.Config),
-		Assets:           models.PluginAssets{}.FromJobSpec(fm.jobSpec.Assets),
-		InstanceSchedule: instanceSpec.ScheduledAt,
-		InstanceData:     instanceSpec.Data,
-	})
-	if err != nil {
-		return nil, nil, err
+	// check if task needs to override the compilation behaviour, plugins
+	// get window and instance data like DSTART and DEND of this run
+	assets := fm.jobSpec.Assets.ToMap()
+	if fm.jobSpec.Task.Unit.CLIMod != nil {
+		compiledAssetResponse, err := fm.jobSpec.Task.Unit.CLIMod.CompileAssets(context.Background(), models.CompileAssetsRequest{
+			Window:           fm.jobSpec.Task.Window,
+			Config:           models.PluginConfigs{}.FromJobSpec(fm.jobSpec.Task.Config),
+			Assets:           models.PluginAssets{}.FromJobSpec(fm.jobSpec.Assets),
+			InstanceSchedule: instanceSpec.ScheduledAt,
+			InstanceData:     instanceSpec.Data,
+		})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to compile assets of task %s", fm.jobSpec.Task.Unit.Info().Name)
+		}
+		assets = compiledAssetResponse.Assets.ToJobSpec().ToMap()
 	}
 
 	// append job spec assets to list of files need to write
-	fileMap = MergeStringMap(instanceFileMap, compiledAssetResponse.Assets.ToJobSpec().ToMap())
+	fileMap = MergeStringMap(instanceFileMap, assets)
+	for name, content := range fileMap {
+		if err := checkMacroRefs(content, macros); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to compile asset %s", name)
+		}
+	}
 	if fileMap, err = fm.engine.CompileFiles(fileMap, projectInstanceContext); err != nil {
 		return
 	}
@@ -150,6 +173,10 @@ func (fm *ContextManager) compileTemplates(templateValueMap, templateContext map
 		if !ok {
 			continue
 		}
+		macros, _ := templateContext["macro"].(map[string]interface{})
+		if err := checkMacroRefs(valString, macros); err != nil {
+			return nil, errors.Wrapf(err, "failed to compile config %s", key)
+		}
 		compiledValue, err := fm.engine.CompileString(valString, templateContext)
 		if err != nil {
 			return nil, err
@@ -175,6 +202,14 @@ func (fm *ContextManager) getNamespaceConfigMap() map[string]string {
 	return configMap
 }
 
+func (fm *ContextManager) getSecretMap() map[string]string {
+	secretMap := map[string]string{}
+	for _, secret := range fm.namespace.ProjectSpec.Secret {
+		secretMap[secret.Name] = secret.Value
+	}
+	return secretMap
+}
+
 func (fm *ContextManager) getInstanceData(instanceSpec models.InstanceSpec) (map[string]interface{}, map[string]string) {
 	envMap := map[string]interface{}{}
 	fileMap := map[string]string{}
@@ -279,7 +314,7 @@ func DumpAssets(jobSpec models.JobSpec, scheduledAt time.Time, engine models.Tem
 
 	assetsToDump := jobSpec.Assets.ToMap()
 
-	if allowOverride {
+	if allowOverride && jobSpec.Task.Unit.CLIMod != nil {
 		// check if task needs to override the compilation behaviour
 		compiledAssetResponse, err := jobSpec.Task.Unit.CLIMod.CompileAssets(context.TODO(), models.CompileAssetsRequest{
 			Window:           jobSpec.Task.Window,
@@ -303,10 +338,13 @@ func DumpAssets(jobSpec models.JobSpec, scheduledAt time.Time, engine models.Tem
 		assetsToDump = compiledAssetResponse.Assets.ToJobSpec().ToMap()
 	}
 
+	// window is truncated at day boundaries of the job timezone
+	localScheduledAt := scheduledAt.In(jobSpec.Schedule.Location())
+
 	// compile again if needed
 	templates, err := engine.CompileFiles(assetsToDump, map[string]interface{}{
-		ConfigKeyDstart:        jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
-		ConfigKeyDend:          jobSpec.Task.Window.GetEnd(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+		ConfigKeyDstart:        jobSpec.Task.Window.GetStart(localScheduledAt).UTC().Format(models.InstanceScheduledAtTimeLayout),
+		ConfigKeyDend:          jobSpec.Task.Window.GetEnd(localScheduledAt).UTC().Format(models.InstanceScheduledAtTimeLayout),
 		ConfigKeyExecutionTime: scheduledAt.Format(models.InstanceScheduledAtTimeLayout),
 		ConfigKeyDestination:   jobDestination,
 	})
@@ -2,6 +2,7 @@ package instance_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -116,7 +117,7 @@ func TestContextManager(t *testing.T) {
 				},
 			}
 
-			cliMod.On("CompileAssets", context.TODO(), models.CompileAssetsRequest{
+			cliMod.On("CompileAssets", context.Background(), models.CompileAssetsRequest{
 				Window:           jobSpec.Task.Window,
 				Config:           models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
 				Assets:           models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
@@ -270,7 +271,7 @@ func TestContextManager(t *testing.T) {
 					},
 				},
 			}
-			cliMod.On("CompileAssets", context.TODO(), models.CompileAssetsRequest{
+			cliMod.On("CompileAssets", context.Background(), models.CompileAssetsRequest{
 				Window:           jobSpec.Task.Window,
 				Config:           models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
 				Assets:           models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
@@ -405,7 +406,7 @@ func TestContextManager(t *testing.T) {
 				},
 			}
 
-			cliMod.On("CompileAssets", context.TODO(), models.CompileAssetsRequest{
+			cliMod.On("CompileAssets", context.Background(), models.CompileAssetsRequest{
 				Window:           jobSpec.Task.Window,
 				Config:           models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
 				Assets:           models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
@@ -435,5 +436,170 @@ func TestContextManager(t *testing.T) {
 				fileMap["query.sql"],
 			)
 		})
+		t.Run("should make project secrets available to task configs", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "humara-projectSpec",
+				Secret: models.ProjectSecrets{
+					{Name: "DB_PASSWORD", Value: "super-secret"},
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			cliMod := new(mock.CLIMod)
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{CLIMod: cliMod},
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "PASSWORD",
+							Value: "{{.secret.DB_PASSWORD}}",
+						},
+					},
+				},
+			}
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
+			}
+			cliMod.On("CompileAssets", context.Background(), models.CompileAssetsRequest{
+				Config:           models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+				Assets:           models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+				InstanceSchedule: instanceSpec.ScheduledAt,
+			}).Return(&models.CompileAssetsResponse{}, nil)
+
+			envMap, _, err := instance.NewContextManager(namespaceSpec, jobSpec, instance.NewGoEngine()).Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "super-secret", envMap["PASSWORD"])
+		})
+		t.Run("should compile assets without plugin if task has no cli mod", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "namespace-1",
+				ProjectSpec: models.ProjectSpec{
+					ID:   uuid.Must(uuid.NewRandom()),
+					Name: "humara-projectSpec",
+				},
+			}
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{Base: new(mock.BasePlugin)},
+				},
+				Assets: *models.JobAssets{}.New([]models.JobSpecAsset{
+					{
+						Name:  "query.sql",
+						Value: "select * from table where ts < '{{.DEND}}'",
+					},
+				}),
+			}
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
+				Data: []models.InstanceSpecData{
+					{
+						Name:  instance.ConfigKeyDend,
+						Value: "2020-11-11T00:00:00Z",
+						Type:  models.InstanceDataTypeEnv,
+					},
+				},
+			}
+
+			_, fileMap, err := instance.NewContextManager(namespaceSpec, jobSpec, instance.NewGoEngine()).Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "select * from table where ts < '2020-11-11T00:00:00Z'", fileMap["query.sql"])
+		})
+		t.Run("should resolve macros with namespace macros taking precedence", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "humara-projectSpec",
+				Macros: models.ProjectMacros{
+					{Name: "dataset", Default: "common"},
+					{Name: "row_limit", Type: models.MacroTypeInt, Default: "100"},
+					{Name: "dataset", Default: "playground", Namespace: "namespace-1"},
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			cliMod := new(mock.CLIMod)
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{CLIMod: cliMod},
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "DATASET",
+							Value: "{{.macro.dataset}}",
+						},
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "query.sql",
+							Value: "select * from {{.macro.dataset}}.orders limit {{.macro.row_limit}}",
+						},
+					},
+				),
+			}
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
+			}
+			cliMod.On("CompileAssets", context.Background(), models.CompileAssetsRequest{
+				Config:           models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+				Assets:           models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+				InstanceSchedule: instanceSpec.ScheduledAt,
+			}).Return(&models.CompileAssetsResponse{
+				Assets: models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+			}, nil)
+
+			envMap, fileMap, err := instance.NewContextManager(namespaceSpec, jobSpec, instance.NewGoEngine()).Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "playground", envMap["DATASET"])
+			assert.Equal(t, "select * from playground.orders limit 100", fileMap["query.sql"])
+		})
+		t.Run("should fail for undefined macros", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "namespace-1",
+				ProjectSpec: models.ProjectSpec{
+					ID:   uuid.Must(uuid.NewRandom()),
+					Name: "humara-projectSpec",
+					Macros: models.ProjectMacros{
+						{Name: "dataset", Default: "playground", Namespace: "namespace-2"},
+					},
+				},
+			}
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{CLIMod: new(mock.CLIMod)},
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "DATASET",
+							Value: "{{.macro.dataset}}",
+						},
+					},
+				},
+			}
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
+			}
+
+			_, _, err := instance.NewContextManager(namespaceSpec, jobSpec, instance.NewGoEngine()).Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.True(t, errors.Is(err, models.ErrUndefinedMacro))
+			assert.Contains(t, err.Error(), "undefined macro dataset")
+		})
 	})
 }
@@ -4,9 +4,6 @@ import (
 	"bytes"
 	"strings"
 	"text/template"
-	"time"
-
-	"github.com/odpf/optimus/models"
 
 	"github.com/Masterminds/sprig/v3"
 )
@@ -26,7 +23,7 @@ func (e *GoEngine) CompileFiles(files map[string]string, context map[string]inte
 	var err error
 	rendered := map[string]string{}
 	// prepare template list
-	root := template.New("base").Funcs(e.baseFns)
+	root := template.New("base").Funcs(e.baseFns).Funcs(Macros())
 	for name, content := range files {
 		root, err = root.New(name).Parse(content)
 		if err != nil {
@@ -51,7 +48,7 @@ func (e *GoEngine) CompileFiles(files map[string]string, context map[string]inte
 }
 
 func (e *GoEngine) CompileString(input string, context map[string]interface{}) (string, error) {
-	tmpl, err := template.New("optimus_go_engine").Funcs(e.baseFns).Parse(input)
+	tmpl, err := template.New("optimus_go_engine").Funcs(e.baseFns).Funcs(Macros()).Parse(input)
 	if err != nil {
 		return "", err
 	}
@@ -71,15 +68,8 @@ func shouldIgnoreFile(name string) bool {
 	return false
 }
 
+// init prepares sprig functions, registered macros are added at compilation
+// so macros contributed by plugins later are available as well
 func (e *GoEngine) init() {
 	e.baseFns = sprig.TxtFuncMap()
-	e.baseFns["Date"] = goDateFn
-}
-
-func goDateFn(timeStr string) (string, error) {
-	t, err := time.Parse(models.InstanceScheduledAtTimeLayout, timeStr)
-	if err != nil {
-		return "", err
-	}
-	return t.Format(models.JobDatetimeLayout), nil
 }
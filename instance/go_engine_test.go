@@ -38,6 +38,14 @@ func TestGoEngine(t *testing.T) {
 					"event_timestamp > {{ .DSTART | Date }} AND event_timestamp <= {{ Date .DEND }}",
 					"event_timestamp > 2021-02-10 AND event_timestamp <= 2021-02-11",
 				},
+				{
+					"event_timestamp > \"{{ date_add .DSTART \"-1d\" }}\" AND event_timestamp <= \"{{ date_add .DEND \"-90m\" }}\"",
+					"event_timestamp > \"2021-02-09T10:00:00Z\" AND event_timestamp <= \"2021-02-11T08:30:00Z\"",
+				},
+				{
+					"partition >= {{ date_format .DSTART \"20060102\" }} AND month = \"{{ date_trunc .DEND \"M\" | Date }}\"",
+					"partition >= 20210210 AND month = \"2021-02-01\"",
+				},
 			}
 
 			for _, testCase := range testCases {
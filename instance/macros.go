@@ -0,0 +1,154 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/odpf/optimus/models"
+)
+
+var (
+	ErrMacroAlreadyRegistered = errors.New("macro already registered")
+
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+	// macros are functions usable in job assets, task and hook configs along
+	// with values like DSTART, DEND, EXECUTION_TIME and JOB_DESTINATION
+	macrosMu sync.RWMutex
+	macros   = template.FuncMap{
+		"Date":        goDateFn,
+		"date_add":    dateAddFn,
+		"date_format": dateFormatFn,
+		"date_trunc":  dateTruncFn,
+	}
+
+	// templateBlockRegex matches actions of go templates and tags or
+	// expressions of jinja templates
+	templateBlockRegex = regexp.MustCompile(`(?s)\{[{%].*?[}%]\}`)
+	// macroRefRegex matches references to project macros inside a block, as
+	// .macro.NAME in go templates and macro.NAME in jinja templates
+	macroRefRegex = regexp.MustCompile(`\bmacro\.([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// RegisterMacro makes fn available to templates as name, plugins use it to
+// contribute their own macros. fn should return a value and optionally an
+// error as the second value, a macro can't be registered twice
+func RegisterMacro(name string, fn interface{}) error {
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return fmt.Errorf("macro %s should be a function", name)
+	}
+	if fnType.NumOut() == 0 || fnType.NumOut() > 2 || (fnType.NumOut() == 2 && fnType.Out(1) != errorType) {
+		return fmt.Errorf("macro %s should return a value and optionally an error", name)
+	}
+
+	macrosMu.Lock()
+	defer macrosMu.Unlock()
+	if _, ok := macros[name]; ok {
+		return fmt.Errorf("%w: %s", ErrMacroAlreadyRegistered, name)
+	}
+	macros[name] = fn
+	return nil
+}
+
+// Macros returns all the registered macros
+func Macros() template.FuncMap {
+	macrosMu.RLock()
+	defer macrosMu.RUnlock()
+	fns := template.FuncMap{}
+	for name, fn := range macros {
+		fns[name] = fn
+	}
+	return fns
+}
+
+// checkMacroRefs fails if template refers to a project macro which is not
+// defined, templates would otherwise silently render it as an empty value
+func checkMacroRefs(tmpl string, defined map[string]interface{}) error {
+	for _, block := range templateBlockRegex.FindAllString(tmpl, -1) {
+		for _, ref := range macroRefRegex.FindAllStringSubmatch(block, -1) {
+			if _, ok := defined[ref[1]]; !ok {
+				return fmt.Errorf("%w %s", models.ErrUndefinedMacro, ref[1])
+			}
+		}
+	}
+	return nil
+}
+
+func parseMacroTime(timeStr string) (time.Time, error) {
+	return time.Parse(models.InstanceScheduledAtTimeLayout, timeStr)
+}
+
+func goDateFn(timeStr string) (string, error) {
+	t, err := parseMacroTime(timeStr)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(models.JobDatetimeLayout), nil
+}
+
+// dateAddFn shifts time by a duration like -2h30m, days and months can be
+// used as 7d and 1M
+func dateAddFn(timeStr, duration string) (string, error) {
+	t, err := parseMacroTime(timeStr)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(duration, "d") || strings.HasSuffix(duration, "M") {
+		count, err := strconv.Atoi(duration[:len(duration)-1])
+		if err != nil {
+			return "", fmt.Errorf("invalid duration %s: %w", duration, err)
+		}
+		if strings.HasSuffix(duration, "d") {
+			t = t.AddDate(0, 0, count)
+		} else {
+			t = t.AddDate(0, count, 0)
+		}
+		return t.Format(models.InstanceScheduledAtTimeLayout), nil
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return "", err
+	}
+	return t.Add(d).Format(models.InstanceScheduledAtTimeLayout), nil
+}
+
+// dateFormatFn formats time using a go layout, e.g. 2006/01/02
+func dateFormatFn(timeStr, layout string) (string, error) {
+	t, err := parseMacroTime(timeStr)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+// dateTruncFn truncates time to the start of its hour, day, week starting
+// on sunday, month or year
+func dateTruncFn(timeStr, unit string) (string, error) {
+	t, err := parseMacroTime(timeStr)
+	if err != nil {
+		return "", err
+	}
+	switch unit {
+	case "h":
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case "d":
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case "w":
+		t = time.Date(t.Year(), t.Month(), t.Day()-int(t.Weekday()), 0, 0, 0, 0, t.Location())
+	case "M":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case "Y":
+		t = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return "", fmt.Errorf("invalid unit %s, possible values: h/d/w/M/Y", unit)
+	}
+	return t.Format(models.InstanceScheduledAtTimeLayout), nil
+}
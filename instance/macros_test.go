@@ -0,0 +1,34 @@
+package instance_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/instance"
+)
+
+func TestMacros(t *testing.T) {
+	t.Run("should make registered macros available to templates", func(t *testing.T) {
+		err := instance.RegisterMacro("test_upper_macro", strings.ToUpper)
+		assert.Nil(t, err)
+		assert.Contains(t, instance.Macros(), "test_upper_macro")
+
+		compiled, err := instance.NewGoEngine().CompileString(`{{ test_upper_macro .JOB_DESTINATION }}`, map[string]interface{}{
+			"JOB_DESTINATION": "project.dataset.table",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "PROJECT.DATASET.TABLE", compiled)
+	})
+	t.Run("should fail to register a macro twice", func(t *testing.T) {
+		err := instance.RegisterMacro("date_add", strings.ToUpper)
+		assert.True(t, errors.Is(err, instance.ErrMacroAlreadyRegistered))
+	})
+	t.Run("should fail to register macros that aren't usable in templates", func(t *testing.T) {
+		assert.NotNil(t, instance.RegisterMacro("test_not_a_func", "value"))
+		assert.NotNil(t, instance.RegisterMacro("test_no_return", func(string) {}))
+		assert.NotNil(t, instance.RegisterMacro("test_bad_return", func(string) (string, string) { return "", "" }))
+	})
+}
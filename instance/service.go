@@ -90,6 +90,9 @@ func (s *Service) PrepInstance(jobSpec models.JobSpec, scheduledAt time.Time) (m
 		jobDestination = jobDestinationResponse.Destination
 	}
 
+	// window is truncated at day boundaries of the job timezone
+	localScheduledAt := scheduledAt.In(jobSpec.Schedule.Location())
+
 	return models.InstanceSpec{
 		Job:         jobSpec,
 		ScheduledAt: scheduledAt,
@@ -104,12 +107,12 @@ func (s *Service) PrepInstance(jobSpec models.JobSpec, scheduledAt time.Time) (m
 			},
 			{
 				Name:  ConfigKeyDstart,
-				Value: jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+				Value: jobSpec.Task.Window.GetStart(localScheduledAt).UTC().Format(models.InstanceScheduledAtTimeLayout),
 				Type:  models.InstanceDataTypeEnv,
 			},
 			{
 				Name:  ConfigKeyDend,
-				Value: jobSpec.Task.Window.GetEnd(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+				Value: jobSpec.Task.Window.GetEnd(localScheduledAt).UTC().Format(models.InstanceScheduledAtTimeLayout),
 				Type:  models.InstanceDataTypeEnv,
 			},
 			{
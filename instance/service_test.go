@@ -264,5 +264,30 @@ func TestService(t *testing.T) {
 			assert.Nil(t, err)
 			assert.NotEqual(t, prep1.Data, prep2.Data)
 		})
+		t.Run("should truncate window at day boundary of job timezone", func(t *testing.T) {
+			localJobSpec := jobSpec
+			localJobSpec.Schedule.Timezone = "Asia/Kolkata"
+			localJobSpec.Task.Window = models.JobSpecTaskWindow{
+				Size:       24 * time.Hour,
+				Offset:     0,
+				TruncateTo: "d",
+			}
+			// 2020-11-11 02:00 in Asia/Kolkata
+			scheduledAt := time.Date(2020, 11, 10, 20, 30, 0, 0, time.UTC)
+			srv := instance.NewService(nil, mockedTimeFunc, nil)
+
+			prep, err := srv.PrepInstance(localJobSpec, scheduledAt)
+			assert.Nil(t, err)
+			assert.Contains(t, prep.Data, models.InstanceSpecData{
+				Name:  instance.ConfigKeyDstart,
+				Value: "2020-11-09T18:30:00Z",
+				Type:  models.InstanceDataTypeEnv,
+			})
+			assert.Contains(t, prep.Data, models.InstanceSpecData{
+				Name:  instance.ConfigKeyDend,
+				Value: "2020-11-10T18:30:00Z",
+				Type:  models.InstanceDataTypeEnv,
+			})
+		})
 	})
 }
@@ -3,7 +3,6 @@ package job
 import (
 	"bytes"
 	"text/template"
-	"time"
 
 	"github.com/odpf/optimus/config"
 
@@ -35,25 +34,23 @@ func (com *Compiler) Compile(namespaceSpec models.NamespaceSpec, jobSpec models.
 		return models.Job{}, err
 	}
 
-	var slaMissDurationInSec int64
-	for _, notify := range jobSpec.Behavior.Notify {
-		if notify.On == models.JobEventTypeSLAMiss {
-			if _, ok := notify.Config["duration"]; !ok {
-				continue
-			}
+	slaMissDuration, err := jobSpec.Behavior.SLAMissDuration()
+	if err != nil {
+		return models.Job{}, err
+	}
 
-			dur, err := time.ParseDuration(notify.Config["duration"])
-			if err != nil {
-				return models.Job{}, errors.Wrapf(err, "failed to parse sla_miss duration %s", notify.Config["duration"])
-			}
-			slaMissDurationInSec = int64(dur.Seconds())
-		}
+	// configs of job override namespace configs which override
+	// project configs
+	jobConfigs := namespaceSpec.MergedConfig()
+	for _, jobConfig := range jobSpec.Task.Config {
+		jobConfigs[jobConfig.Name] = jobConfig.Value
 	}
 
 	var buf bytes.Buffer
 	if err = tmpl.Execute(&buf, struct {
 		Namespace                  models.NamespaceSpec
 		Job                        models.JobSpec
+		Config                     map[string]string
 		Hostname                   string
 		HookTypePre                string
 		HookTypePost               string
@@ -63,11 +60,15 @@ func (com *Compiler) Compile(namespaceSpec models.NamespaceSpec, jobSpec models.
 		JobSpecDependencyTypeIntra string
 		JobSpecDependencyTypeInter string
 		JobSpecDependencyTypeExtra string
+		JobTriggerTypeGCS          string
+		JobTriggerTypeKafka        string
+		JobTriggerTypeJob          string
 		SLAMissDurationInSec       int64
 		Version                    string
 	}{
 		Namespace:                  namespaceSpec,
 		Job:                        jobSpec,
+		Config:                     jobConfigs,
 		Hostname:                   com.hostname,
 		HookTypePre:                string(models.HookTypePre),
 		HookTypePost:               string(models.HookTypePost),
@@ -77,7 +78,10 @@ func (com *Compiler) Compile(namespaceSpec models.NamespaceSpec, jobSpec models.
 		JobSpecDependencyTypeIntra: string(models.JobSpecDependencyTypeIntra),
 		JobSpecDependencyTypeInter: string(models.JobSpecDependencyTypeInter),
 		JobSpecDependencyTypeExtra: string(models.JobSpecDependencyTypeExtra),
-		SLAMissDurationInSec:       slaMissDurationInSec,
+		JobTriggerTypeGCS:          string(models.JobTriggerTypeGCS),
+		JobTriggerTypeKafka:        string(models.JobTriggerTypeKafka),
+		JobTriggerTypeJob:          string(models.JobTriggerTypeJob),
+		SLAMissDurationInSec:       int64(slaMissDuration.Seconds()),
 		Version:                    config.Version,
 	}); err != nil {
 		return models.Job{}, errors.Wrap(err, "failed to templatize job")
@@ -78,6 +78,30 @@ func TestCompiler(t *testing.T) {
 			assert.Equal(t, dag.Contents, []byte("content = foo"))
 			assert.Nil(t, err)
 		})
+		t.Run("should override project config with namespace and job config", func(t *testing.T) {
+			tempNamespaceSpec := namespaceSpec
+			tempNamespaceSpec.ProjectSpec.Config = map[string]string{
+				"SCHEDULER_POOL": "project-pool",
+				"BUCKET":         "project-bucket",
+				"REGION":         "project-region",
+			}
+			tempNamespaceSpec.Config = map[string]string{
+				"SCHEDULER_POOL": "namespace-pool",
+				"BUCKET":         "namespace-bucket",
+			}
+			tempSpec := spec
+			tempSpec.Task.Config = models.JobSpecConfigs{
+				{Name: "SCHEDULER_POOL", Value: "job-pool"},
+			}
+			com := job.NewCompiler(
+				[]byte("{{.Config.SCHEDULER_POOL}} {{.Config.BUCKET}} {{.Config.REGION}}"),
+				"",
+			)
+			dag, err := com.Compile(tempNamespaceSpec, tempSpec)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "job-pool namespace-bucket project-region", string(dag.Contents))
+		})
 		t.Run("should return error if failed to read template", func(t *testing.T) {
 			com := job.NewCompiler(
 				[]byte(""),
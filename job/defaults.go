@@ -0,0 +1,71 @@
+package job
+
+import (
+	"context"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+// ApplyTaskDefaults fills config and assets the task plugin scaffolds for a
+// new job which are missing in job spec, answers to questions of plugin are
+// read from task config and fall back to their defaults
+func ApplyTaskDefaults(ctx context.Context, jobSpec models.JobSpec) (models.JobSpec, error) {
+	unit := jobSpec.Task.Unit
+	if unit == nil || unit.CLIMod == nil {
+		return jobSpec, nil
+	}
+
+	questionsResp, err := unit.CLIMod.GetQuestions(ctx, models.GetQuestionsRequest{
+		JobName:       jobSpec.Name,
+		PluginOptions: models.PluginOptions{DryRun: true},
+	})
+	if err != nil {
+		return jobSpec, errors.Wrapf(err, "failed to get questions of task %s", unit.Info().Name)
+	}
+	answers := answersFromConfig(questionsResp.Questions, jobSpec.Task.Config)
+
+	defaultConfig, defaultAssets, err := unit.GenerateDefaults(ctx, answers, models.PluginOptions{DryRun: true})
+	if err != nil {
+		return jobSpec, errors.Wrapf(err, "failed to generate defaults of task %s", unit.Info().Name)
+	}
+
+	config := append(models.JobSpecConfigs{}, jobSpec.Task.Config...)
+	for _, item := range defaultConfig {
+		if _, ok := config.Get(item.Name); !ok {
+			config = append(config, item)
+		}
+	}
+	jobSpec.Task.Config = config
+
+	assets := append([]models.JobSpecAsset{}, jobSpec.Assets.GetAll()...)
+	for _, asset := range defaultAssets.GetAll() {
+		if _, err := jobSpec.Assets.GetByName(asset.Name); err != nil {
+			assets = append(assets, asset)
+		}
+	}
+	jobSpec.Assets = *models.JobAssets{}.New(assets)
+	return jobSpec, nil
+}
+
+// answersFromConfig answers questions with values of task config, sub
+// questions are answered only if their condition matches
+func answersFromConfig(questions models.PluginQuestions, config models.JobSpecConfigs) models.PluginAnswers {
+	answers := models.PluginAnswers{}
+	for _, question := range questions {
+		value, ok := config.Get(question.Name)
+		if !ok {
+			value = question.Default
+		}
+		answers = append(answers, models.PluginAnswer{
+			Question: question,
+			Value:    value,
+		})
+		for _, subQuestion := range question.SubQuestions {
+			if value == subQuestion.IfValue {
+				answers = append(answers, answersFromConfig(subQuestion.Questions, config)...)
+			}
+		}
+	}
+	return answers
+}
@@ -0,0 +1,66 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTaskDefaults(t *testing.T) {
+	ctx := context.Background()
+	options := models.PluginOptions{DryRun: true}
+	question := models.PluginQuestion{Name: "PROJECT", Prompt: "Project ID", Default: "example"}
+
+	t.Run("should add config and assets of plugin missing in job", func(t *testing.T) {
+		cliMod := new(mock.CLIMod)
+		defer cliMod.AssertExpectations(t)
+		answers := models.PluginAnswers{{Question: question, Value: "sample"}}
+		cliMod.On("GetQuestions", ctx, models.GetQuestionsRequest{JobName: "job-1", PluginOptions: options}).
+			Return(&models.GetQuestionsResponse{Questions: models.PluginQuestions{question}}, nil)
+		cliMod.On("DefaultConfig", ctx, models.DefaultConfigRequest{PluginOptions: options, Answers: answers}).
+			Return(&models.DefaultConfigResponse{Config: models.PluginConfigs{
+				{Name: "PROJECT", Value: "sample"},
+				{Name: "LOAD_METHOD", Value: "APPEND"},
+			}}, nil)
+		cliMod.On("DefaultAssets", ctx, models.DefaultAssetsRequest{PluginOptions: options, Answers: answers}).
+			Return(&models.DefaultAssetsResponse{Assets: models.PluginAssets{
+				{Name: "query.sql", Value: "select 1"},
+				{Name: "schema.json", Value: "[]"},
+			}}, nil)
+
+		jobSpec := models.JobSpec{
+			Name: "job-1",
+			Task: models.JobSpecTask{
+				Unit:   &models.Plugin{Base: new(mock.BasePlugin), CLIMod: cliMod},
+				Config: models.JobSpecConfigs{{Name: "PROJECT", Value: "sample"}},
+			},
+			Assets: *models.JobAssets{}.New([]models.JobSpecAsset{{Name: "query.sql", Value: "select * from table"}}),
+		}
+		withDefaults, err := job.ApplyTaskDefaults(ctx, jobSpec)
+		assert.Nil(t, err)
+		assert.Equal(t, models.JobSpecConfigs{
+			{Name: "PROJECT", Value: "sample"},
+			{Name: "LOAD_METHOD", Value: "APPEND"},
+		}, withDefaults.Task.Config)
+		assert.Equal(t, map[string]string{
+			"query.sql":   "select * from table",
+			"schema.json": "[]",
+		}, withDefaults.Assets.ToMap())
+		assert.Equal(t, 1, len(jobSpec.Task.Config))
+	})
+	t.Run("should keep job as is if plugin has no cli mod", func(t *testing.T) {
+		jobSpec := models.JobSpec{
+			Name: "job-1",
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{Base: new(mock.BasePlugin)},
+			},
+		}
+		withDefaults, err := job.ApplyTaskDefaults(ctx, jobSpec)
+		assert.Nil(t, err)
+		assert.Equal(t, jobSpec, withDefaults)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/core/tree"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
 	"github.com/pkg/errors"
@@ -11,19 +12,28 @@ import (
 
 var (
 	ErrUnknownDependency            = errors.New("unknown local dependency")
+	ErrInvalidHookDependency        = errors.New("hook can't depend on a hook running after it")
 	UnknownRuntimeDependencyMessage = "could not find registered destination '%s' during compiling dependencies for the provided job '%s', " +
 		"please check if the source is correct, " +
 		"if it is and want this to be ignored as dependency, " +
 		"check docs how this can be done in used transformation task"
 )
 
-type dependencyResolver struct{}
+type dependencyResolver struct {
+	externalJobChecker ExternalJobChecker
+}
 
 // Resolve resolves all kind of dependencies (inter/intra project, static deps) of a given JobSpec
 func (r *dependencyResolver) Resolve(projectSpec models.ProjectSpec, projectJobSpecRepo store.ProjectJobSpecRepository,
 	jobSpec models.JobSpec, observer progress.Observer) (models.JobSpec, error) {
+	// resolve resources job waits for to the jobs writing them
+	jobSpec, err := r.resolveResourceDependencies(jobSpec, projectSpec, projectJobSpecRepo, observer)
+	if err != nil {
+		return models.JobSpec{}, err
+	}
+
 	// resolve inter/intra dependencies inferred by optimus
-	jobSpec, err := r.resolveInferredDependencies(jobSpec, projectSpec, projectJobSpecRepo, observer)
+	jobSpec, err = r.resolveInferredDependencies(jobSpec, projectSpec, projectJobSpecRepo, observer)
 	if err != nil {
 		return models.JobSpec{}, err
 	}
@@ -34,6 +44,11 @@ func (r *dependencyResolver) Resolve(projectSpec models.ProjectSpec, projectJobS
 		return models.JobSpec{}, err
 	}
 
+	// verify dependencies on jobs of other optimus servers
+	if err := r.resolveExternalDependencies(jobSpec); err != nil {
+		return models.JobSpec{}, err
+	}
+
 	// resolve inter hook dependencies
 	jobSpec, err = r.resolveHookDependencies(jobSpec)
 	if err != nil {
@@ -60,7 +75,13 @@ func (r *dependencyResolver) resolveInferredDependencies(jobSpec models.JobSpec,
 	}
 
 	// get job spec of these destinations and append to current jobSpec
+	seen := map[string]bool{}
 	for _, depDestination := range jobDependencies {
+		if seen[depDestination] {
+			continue
+		}
+		seen[depDestination] = true
+
 		depSpec, depProj, err := projectJobSpecRepo.GetByDestination(depDestination)
 		if err != nil {
 			if err == store.ErrResourceNotFound {
@@ -70,9 +91,16 @@ func (r *dependencyResolver) resolveInferredDependencies(jobSpec models.JobSpec,
 			}
 			return jobSpec, errors.Wrap(err, "runtime dependency evaluation failed")
 		}
+		// a job reading from its own destination is not a dependency
+		if depSpec.Name == jobSpec.Name && depProj.Name == projectSpec.Name {
+			continue
+		}
 
 		// determine the type of dependency
-		dep := models.JobSpecDependency{Job: &depSpec, Project: &depProj}
+		// static dependency on the same job decides how it is waited for
+		dep := jobSpec.Dependencies[depSpec.Name]
+		dep.Job = &depSpec
+		dep.Project = &depProj
 		dep.Type = r.getJobSpecDependencyType(dep, projectSpec.Name)
 		jobSpec.Dependencies[depSpec.Name] = dep
 	}
@@ -80,6 +108,40 @@ func (r *dependencyResolver) resolveInferredDependencies(jobSpec models.JobSpec,
 	return jobSpec, nil
 }
 
+// resolveResourceDependencies adds a dependency on the job writing each
+// resource job waits for, across projects. Static dependencies on the same
+// job decide how it is waited for
+func (r *dependencyResolver) resolveResourceDependencies(jobSpec models.JobSpec, projectSpec models.ProjectSpec,
+	projectJobSpecRepo store.ProjectJobSpecRepository, observer progress.Observer) (models.JobSpec, error) {
+	if len(jobSpec.ResourceDependencies) > 0 && jobSpec.Dependencies == nil {
+		jobSpec.Dependencies = map[string]models.JobSpecDependency{}
+	}
+	for _, resourceDep := range jobSpec.ResourceDependencies {
+		depSpec, depProj, err := projectJobSpecRepo.GetByDestination(resourceDep.URN)
+		if err != nil {
+			if err == store.ErrResourceNotFound {
+				// resource may not be written by a job yet
+				r.notifyProgress(observer, &EventJobSpecUnknownDependencyUsed{Job: jobSpec.Name, Dependency: resourceDep.URN})
+				continue
+			}
+			return jobSpec, errors.Wrapf(err, "failed to resolve resource dependency %s", resourceDep.URN)
+		}
+		if depSpec.Name == jobSpec.Name && depProj.Name == projectSpec.Name {
+			continue
+		}
+
+		dep, ok := jobSpec.Dependencies[depSpec.Name]
+		if !ok {
+			dep = resourceDep.Dependency()
+		}
+		dep.Job = &depSpec
+		dep.Project = &depProj
+		dep.Type = r.getJobSpecDependencyType(dep, projectSpec.Name)
+		jobSpec.Dependencies[depSpec.Name] = dep
+	}
+	return jobSpec, nil
+}
+
 func (r *dependencyResolver) getJobSpecDependencyType(dependency models.JobSpecDependency, currentJobSpecProject string) models.JobSpecDependencyType {
 	if dependency.Project.Name == currentJobSpecProject {
 		return models.JobSpecDependencyTypeIntra
@@ -106,22 +168,92 @@ func (r *dependencyResolver) resolveStaticDependencies(jobSpec models.JobSpec, p
 	return jobSpec, nil
 }
 
+// external dependencies can't be resolved to a spec model, they are only
+// checked to be deployed on their optimus server
+func (r *dependencyResolver) resolveExternalDependencies(jobSpec models.JobSpec) error {
+	for _, dependency := range jobSpec.ExternalDependencies {
+		if err := r.externalJobChecker.Check(context.TODO(), dependency); err != nil {
+			return errors.Wrapf(err, "failed to resolve external dependency %s for job %s", dependency, jobSpec.Name)
+		}
+	}
+	return nil
+}
+
+// hookStages orders hook types by when they run around the task of a job,
+// pre hooks run before the task, post hooks after it and fail hooks last
+var hookStages = map[models.HookType]int{
+	models.HookTypePre:  0,
+	models.HookTypePost: 1,
+	models.HookTypeFail: 2,
+}
+
 // hooks can be dependent on each other inside a job spec, this will populate
-// the local array that points to its dependent hook
+// the local array that points to its dependent hook and order hooks so that
+// each hook comes after the hooks it depends on
 func (r *dependencyResolver) resolveHookDependencies(jobSpec models.JobSpec) (models.JobSpec, error) {
+	hookTree := tree.NewMultiRootTree()
+	for _, jobHook := range jobSpec.Hooks {
+		hookTree.AddNode(tree.NewTreeNode(models.JobSpec{Name: jobHook.Unit.Info().Name}))
+	}
+
 	for hookIdx, jobHook := range jobSpec.Hooks {
 		jobHook.DependsOn = nil
-		for _, depends := range jobHook.Unit.Info().DependsOn {
+		hookSchema := jobHook.Unit.Info()
+		for _, depends := range hookSchema.DependsOn {
 			dependentHook, err := jobSpec.GetHookByName(depends)
-			if err == nil {
-				jobHook.DependsOn = append(jobHook.DependsOn, &dependentHook)
+			if err != nil {
+				// hooks can depend on hooks which are not used by the job
+				continue
+			}
+			dependentSchema := dependentHook.Unit.Info()
+			if hookStages[dependentSchema.HookType] > hookStages[hookSchema.HookType] {
+				return models.JobSpec{}, errors.Wrapf(ErrInvalidHookDependency, "%s hook %s depends on %s hook %s in job %s",
+					hookSchema.HookType, hookSchema.Name, dependentSchema.HookType, dependentSchema.Name, jobSpec.Name)
 			}
+			jobHook.DependsOn = append(jobHook.DependsOn, &dependentHook)
+
+			parentNode, _ := hookTree.GetNodeByName(dependentSchema.Name)
+			node, _ := hookTree.GetNodeByName(hookSchema.Name)
+			parentNode.AddDependent(node)
 		}
 		jobSpec.Hooks[hookIdx] = jobHook
 	}
+	if err := hookTree.IsCyclic(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "failed to resolve hooks of job %s", jobSpec.Name)
+	}
+
+	jobSpec.Hooks = orderHooks(jobSpec.Hooks)
 	return jobSpec, nil
 }
 
+// orderHooks places each hook after the hooks it depends on, otherwise hooks
+// keep the order they are declared in
+func orderHooks(hooks []models.JobSpecHook) []models.JobSpecHook {
+	hooksByName := map[string]models.JobSpecHook{}
+	for _, hook := range hooks {
+		hooksByName[hook.Unit.Info().Name] = hook
+	}
+
+	placed := map[string]bool{}
+	ordered := make([]models.JobSpecHook, 0, len(hooks))
+	var place func(hook models.JobSpecHook)
+	place = func(hook models.JobSpecHook) {
+		name := hook.Unit.Info().Name
+		if placed[name] {
+			return
+		}
+		placed[name] = true
+		for _, dependentHook := range hook.DependsOn {
+			place(hooksByName[dependentHook.Unit.Info().Name])
+		}
+		ordered = append(ordered, hook)
+	}
+	for _, hook := range hooks {
+		place(hook)
+	}
+	return ordered
+}
+
 func (r *dependencyResolver) notifyProgress(observer progress.Observer, e progress.Event) {
 	if observer == nil {
 		return
@@ -130,6 +262,8 @@ func (r *dependencyResolver) notifyProgress(observer progress.Observer, e progre
 }
 
 // NewDependencyResolver creates a new instance of Resolver
-func NewDependencyResolver() *dependencyResolver {
-	return &dependencyResolver{}
+func NewDependencyResolver(externalJobChecker ExternalJobChecker) *dependencyResolver {
+	return &dependencyResolver{
+		externalJobChecker: externalJobChecker,
+	}
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -116,7 +117,7 @@ func TestDependencyResolver(t *testing.T) {
 				DependsOn: []string{"hook1"},
 			}, nil)
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			resolvedJobSpec1, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
 			assert.Nil(t, err)
 			resolvedJobSpec2, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec2, nil)
@@ -208,7 +209,7 @@ func TestDependencyResolver(t *testing.T) {
 			}, nil)
 			execUnit.On("GenerateDependencies", context.TODO(), unitData2).Return(&models.GenerateDependenciesResponse{}, nil)
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			resolvedJobSpec1, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
 			assert.Nil(t, err)
 			resolvedJobSpec2, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec2, nil)
@@ -221,6 +222,48 @@ func TestDependencyResolver(t *testing.T) {
 			assert.Equal(t, map[string]models.JobSpecDependency{}, resolvedJobSpec2.Dependencies)
 		})
 
+		t.Run("should resolve resource dependencies to jobs writing them", func(t *testing.T) {
+			execUnit := new(mock.DependencyResolverMod)
+			defer execUnit.AssertExpectations(t)
+
+			otherProjectSpec := models.ProjectSpec{Name: "other-project"}
+			upstreamSpec := models.JobSpec{Name: "orders-daily"}
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "sales-report",
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{DependencyMod: execUnit},
+				},
+				ResourceDependencies: []models.JobSpecResourceDependency{
+					{URN: "bigquery://other:sales.orders", Optional: true, Timeout: time.Hour},
+					{URN: "bigquery://proj:raw.events"},
+				},
+			}
+
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			jobSpecRepository.On("GetByDestination", "bigquery://other:sales.orders").Return(upstreamSpec, otherProjectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://proj:raw.events").Return(models.JobSpec{}, models.ProjectSpec{}, store.ErrResourceNotFound)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			execUnit.On("GenerateDependencies", context.TODO(), models.GenerateDependenciesRequest{
+				Config:  models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+				Assets:  models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+				Project: projectSpec,
+			}).Return(&models.GenerateDependenciesResponse{}, nil)
+
+			resolver := job.NewDependencyResolver(nil)
+			resolvedJobSpec, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]models.JobSpecDependency{
+				upstreamSpec.Name: {
+					Job:      &upstreamSpec,
+					Project:  &otherProjectSpec,
+					Type:     models.JobSpecDependencyTypeInter,
+					Optional: true,
+					Timeout:  time.Hour,
+				},
+			}, resolvedJobSpec.Dependencies)
+		})
 		t.Run("should fail if GetByDestination fails", func(t *testing.T) {
 			execUnit := new(mock.DependencyResolverMod)
 			defer execUnit.AssertExpectations(t)
@@ -272,7 +315,7 @@ func TestDependencyResolver(t *testing.T) {
 			execUnit.On("GenerateDependencies", context.Background(), unitData).Return(
 				&models.GenerateDependenciesResponse{Dependencies: []string{"project.dataset.table2_destination"}}, nil)
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			resolvedJobSpec1, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
 
 			assert.Error(t, errors.Wrapf(errors.New("random error"), job.UnknownRuntimeDependencyMessage,
@@ -311,7 +354,7 @@ func TestDependencyResolver(t *testing.T) {
 			unitData := models.GenerateDependenciesRequest{Config: models.PluginConfigs{}.FromJobSpec(jobSpec1.Task.Config), Assets: models.PluginAssets{}.FromJobSpec(jobSpec1.Assets), Project: projectSpec}
 			execUnit.On("GenerateDependencies", context.Background(), unitData).Return(&models.GenerateDependenciesResponse{}, errors.New("random error"))
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			resolvedJobSpec1, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
 
 			assert.Equal(t, "random error", err.Error())
@@ -350,7 +393,7 @@ func TestDependencyResolver(t *testing.T) {
 			execUnit.On("GenerateDependencies", context.Background(), unitData).Return(&models.GenerateDependenciesResponse{
 				Dependencies: []string{"project.dataset.table3_destination"}}, nil)
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			_, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
 			assert.Error(t, errors.Wrapf(errors.New("spec not found"), job.UnknownRuntimeDependencyMessage,
 				"project.dataset.table3_destination", jobSpec1.Name),
@@ -410,7 +453,7 @@ func TestDependencyResolver(t *testing.T) {
 				Dependencies: []string{"project.dataset.table1_destination"},
 			}, nil)
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			_, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec2, nil)
 			assert.Equal(t, "unknown local dependency for job static_dep: spec not found", err.Error())
 		})
@@ -497,7 +540,7 @@ func TestDependencyResolver(t *testing.T) {
 			}, nil)
 			execUnit.On("GenerateDependencies", context.Background(), unitData2).Return(&models.GenerateDependenciesResponse{}, nil)
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			resolvedJobSpec1, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
 			assert.Nil(t, err)
 			resolvedJobSpec2, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec2, nil)
@@ -624,7 +667,7 @@ func TestDependencyResolver(t *testing.T) {
 			}, nil)
 			execUnit.On("GenerateDependencies", context.Background(), unitData2).Return(&models.GenerateDependenciesResponse{}, nil)
 
-			resolver := job.NewDependencyResolver()
+			resolver := job.NewDependencyResolver(nil)
 			resolvedJobSpec1, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
 			assert.Nil(t, err)
 			resolvedJobSpec2, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec2, nil)
@@ -636,5 +679,170 @@ func TestDependencyResolver(t *testing.T) {
 			assert.Equal(t, models.JobSpecDependency{Job: &jobSpec3, Project: &projectSpec, Type: models.JobSpecDependencyTypeIntra}, resolvedJobSpec1.Dependencies[jobSpec3.Name])
 			assert.Equal(t, map[string]models.JobSpecDependency{}, resolvedJobSpec2.Dependencies)
 		})
+		t.Run("it should skip repeated and self referencing runtime dependencies", func(t *testing.T) {
+			execUnit := new(mock.DependencyResolverMod)
+			defer execUnit.AssertExpectations(t)
+
+			jobSpec1 := models.JobSpec{
+				Version: 1,
+				Name:    "test1",
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{DependencyMod: execUnit},
+				},
+				Dependencies: make(map[string]models.JobSpecDependency),
+			}
+			jobSpec2 := models.JobSpec{
+				Version:      1,
+				Name:         "test2",
+				Dependencies: make(map[string]models.JobSpecDependency),
+			}
+
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			jobSpecRepository.On("GetByDestination", "project.dataset.table1_destination").Return(jobSpec1, projectSpec, nil).Once()
+			jobSpecRepository.On("GetByDestination", "project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil).Once()
+			defer jobSpecRepository.AssertExpectations(t)
+
+			unitData := models.GenerateDependenciesRequest{Config: models.PluginConfigs{}.FromJobSpec(jobSpec1.Task.Config), Assets: models.PluginAssets{}.FromJobSpec(jobSpec1.Assets), Project: projectSpec}
+			execUnit.On("GenerateDependencies", context.TODO(), unitData).Return(&models.GenerateDependenciesResponse{Dependencies: []string{
+				"project.dataset.table2_destination", "project.dataset.table1_destination", "project.dataset.table2_destination",
+			}}, nil)
+
+			resolver := job.NewDependencyResolver(nil)
+			resolvedJobSpec1, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]models.JobSpecDependency{
+				jobSpec2.Name: {Job: &jobSpec2, Project: &projectSpec, Type: models.JobSpecDependencyTypeIntra},
+			}, resolvedJobSpec1.Dependencies)
+		})
+
+		t.Run("should verify external dependencies on their optimus server", func(t *testing.T) {
+			externalDependency := models.JobSpecExternalDependency{
+				Host:        "optimus.partner.example.io",
+				ProjectName: "partner-project",
+				JobName:     "billing-export",
+			}
+			jobSpec := models.JobSpec{
+				Name:                 "test1",
+				Task:                 models.JobSpecTask{Unit: &models.Plugin{Base: new(mock.BasePlugin)}},
+				Dependencies:         map[string]models.JobSpecDependency{},
+				ExternalDependencies: []models.JobSpecExternalDependency{externalDependency},
+			}
+
+			externalJobChecker := new(mock.ExternalJobChecker)
+			externalJobChecker.On("Check", context.TODO(), externalDependency).Return(nil)
+			defer externalJobChecker.AssertExpectations(t)
+
+			resolver := job.NewDependencyResolver(externalJobChecker)
+			resolvedJobSpec, err := resolver.Resolve(projectSpec, nil, jobSpec, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, jobSpec.ExternalDependencies, resolvedJobSpec.ExternalDependencies)
+		})
+		t.Run("should fail if external dependency is not deployed on its optimus server", func(t *testing.T) {
+			externalDependency := models.JobSpecExternalDependency{
+				Host:        "optimus.partner.example.io",
+				ProjectName: "partner-project",
+				JobName:     "billing-export",
+			}
+			jobSpec := models.JobSpec{
+				Name:                 "test1",
+				Task:                 models.JobSpecTask{Unit: &models.Plugin{Base: new(mock.BasePlugin)}},
+				Dependencies:         map[string]models.JobSpecDependency{},
+				ExternalDependencies: []models.JobSpecExternalDependency{externalDependency},
+			}
+
+			externalJobChecker := new(mock.ExternalJobChecker)
+			externalJobChecker.On("Check", context.TODO(), externalDependency).Return(job.ErrUnknownExternalDependency)
+			defer externalJobChecker.AssertExpectations(t)
+
+			resolver := job.NewDependencyResolver(externalJobChecker)
+			_, err := resolver.Resolve(projectSpec, nil, jobSpec, nil)
+			assert.EqualError(t, err, "failed to resolve external dependency "+
+				"optimus.partner.example.io/partner-project/billing-export for job test1: unknown external dependency")
+		})
+		t.Run("should order hooks after the hooks they depend on", func(t *testing.T) {
+			auditHook := new(mock.BasePlugin)
+			auditHook.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:      "audit",
+				HookType:  models.HookTypePost,
+				DependsOn: []string{"transporter", "unused"},
+			}, nil)
+			transporterHook := new(mock.BasePlugin)
+			transporterHook.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:     "transporter",
+				HookType: models.HookTypePost,
+			}, nil)
+			jobSpec := models.JobSpec{
+				Name:         "test1",
+				Task:         models.JobSpecTask{Unit: &models.Plugin{Base: new(mock.BasePlugin)}},
+				Dependencies: map[string]models.JobSpecDependency{},
+				Hooks: []models.JobSpecHook{
+					{Unit: &models.Plugin{Base: auditHook}},
+					{Unit: &models.Plugin{Base: transporterHook}},
+				},
+			}
+
+			resolver := job.NewDependencyResolver(nil)
+			resolvedJobSpec, err := resolver.Resolve(projectSpec, nil, jobSpec, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, "transporter", resolvedJobSpec.Hooks[0].Unit.Info().Name)
+			assert.Equal(t, "audit", resolvedJobSpec.Hooks[1].Unit.Info().Name)
+			assert.Equal(t, []*models.JobSpecHook{&resolvedJobSpec.Hooks[0]}, resolvedJobSpec.Hooks[1].DependsOn)
+		})
+		t.Run("should fail if a hook depends on a hook running after it", func(t *testing.T) {
+			prepareHook := new(mock.BasePlugin)
+			prepareHook.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:      "prepare",
+				HookType:  models.HookTypePre,
+				DependsOn: []string{"transporter"},
+			}, nil)
+			transporterHook := new(mock.BasePlugin)
+			transporterHook.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:     "transporter",
+				HookType: models.HookTypePost,
+			}, nil)
+			jobSpec := models.JobSpec{
+				Name:         "test1",
+				Task:         models.JobSpecTask{Unit: &models.Plugin{Base: new(mock.BasePlugin)}},
+				Dependencies: map[string]models.JobSpecDependency{},
+				Hooks: []models.JobSpecHook{
+					{Unit: &models.Plugin{Base: prepareHook}},
+					{Unit: &models.Plugin{Base: transporterHook}},
+				},
+			}
+
+			resolver := job.NewDependencyResolver(nil)
+			_, err := resolver.Resolve(projectSpec, nil, jobSpec, nil)
+			assert.True(t, errors.Is(err, job.ErrInvalidHookDependency))
+			assert.EqualError(t, err, "pre hook prepare depends on post hook transporter in job test1: "+
+				"hook can't depend on a hook running after it")
+		})
+		t.Run("should fail for cyclic dependencies between hooks", func(t *testing.T) {
+			auditHook := new(mock.BasePlugin)
+			auditHook.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:      "audit",
+				HookType:  models.HookTypePost,
+				DependsOn: []string{"transporter"},
+			}, nil)
+			transporterHook := new(mock.BasePlugin)
+			transporterHook.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name:      "transporter",
+				HookType:  models.HookTypePost,
+				DependsOn: []string{"audit"},
+			}, nil)
+			jobSpec := models.JobSpec{
+				Name:         "test1",
+				Task:         models.JobSpecTask{Unit: &models.Plugin{Base: new(mock.BasePlugin)}},
+				Dependencies: map[string]models.JobSpecDependency{},
+				Hooks: []models.JobSpecHook{
+					{Unit: &models.Plugin{Base: auditHook}},
+					{Unit: &models.Plugin{Base: transporterHook}},
+				},
+			}
+
+			resolver := job.NewDependencyResolver(nil)
+			_, err := resolver.Resolve(projectSpec, nil, jobSpec, nil)
+			assert.EqualError(t, err, "failed to resolve hooks of job test1: "+
+				"audit → transporter → audit: a cycle dependency encountered in the tree")
+		})
 	})
 }
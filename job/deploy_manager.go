@@ -0,0 +1,321 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/utils"
+	"github.com/pkg/errors"
+)
+
+type DeployManagerConfig struct {
+	NumWorkers    int
+	WorkerTimeout time.Duration
+	// QueueSize is the number of deployments which can wait for a worker
+	QueueSize int
+	// Retention is the duration finished deployments are kept for status
+	// lookups, finished deployments are never evicted if not set
+	Retention time.Duration
+}
+
+type DeployManager interface {
+	// Deploy queues jobs of a namespace for deployment, if reconcile is set
+	// jobs of the namespace missing in the request are deleted
+	Deploy(req models.DeployRequest) (string, error)
+	GetStatus(uuid.UUID) (models.DeploymentSpec, error)
+
+	// Record adds a deployment performed outside the manager to history
+	Record(deployment models.DeploymentSpec) error
+	GetDeployment(projectSpec models.ProjectSpec, id uuid.UUID) (models.DeploymentSpec, error)
+	ListDeployments(projectSpec models.ProjectSpec, filter models.DeploymentFilter) ([]models.DeploymentSpec, error)
+}
+
+// DeploymentManager deploys jobs of a namespace asynchronously.
+// Requests are buffered in a fixed size queue and processed by a pool of
+// workers, progress of each deployment can be queried using its id.
+// Deployments are kept in history once accepted and when finished
+type DeploymentManager struct {
+	wg sync.WaitGroup
+	mu sync.RWMutex
+
+	jobSvc            models.JobService
+	deploymentRepoFac DeploymentRepoFactory
+	uuidProvider      utils.UUIDProvider
+	progressObserver  progress.Observer
+	config            DeployManagerConfig
+
+	requestQ    chan *models.DeployRequest
+	deployments map[uuid.UUID]*models.DeploymentSpec
+
+	Now func() time.Time
+}
+
+// Deploy queues the jobs for deployment, returns a deployment id that
+// can be used to query its status
+func (m *DeploymentManager) Deploy(req models.DeployRequest) (string, error) {
+	uuidOb, err := m.uuidProvider.NewUUID()
+	if err != nil {
+		return "", err
+	}
+	req.ID = uuidOb
+
+	jobs := make([]models.JobDeployment, len(req.Jobs))
+	for idx, jobSpec := range req.Jobs {
+		jobs[idx] = models.JobDeployment{
+			Name:   jobSpec.Name,
+			Status: models.JobDeploymentStatusPending,
+		}
+	}
+	now := m.Now()
+	deployment := &models.DeploymentSpec{
+		ID:          uuidOb,
+		Namespace:   req.Namespace,
+		Status:      models.DeploymentStatusAccepted,
+		RequestedBy: req.RequestedBy,
+		Jobs:        jobs,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := m.save(*deployment); err != nil {
+		return "", errors.Wrap(err, "failed to record deployment")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictFinishedDeployments(now)
+
+	// try sending the request down the queue, if full return error
+	// indicating that we don't have capacity at the moment
+	select {
+	case m.requestQ <- &req:
+		m.deployments[uuidOb] = deployment
+		return uuidOb.String(), nil
+	default:
+		deployment.Status = models.DeploymentStatusFailed
+		deployment.Message = ErrRequestQueueFull.Error()
+		if err := m.save(*deployment); err != nil {
+			logger.E(errors.Wrapf(err, "failed to record deployment %s", uuidOb))
+		}
+		return "", ErrRequestQueueFull
+	}
+}
+
+// Record adds a deployment performed outside the manager to history, an id
+// is assigned to the deployment if not set
+func (m *DeploymentManager) Record(deployment models.DeploymentSpec) error {
+	if deployment.ID == uuid.Nil {
+		uuidOb, err := m.uuidProvider.NewUUID()
+		if err != nil {
+			return err
+		}
+		deployment.ID = uuidOb
+	}
+	return m.save(deployment)
+}
+
+// GetDeployment returns a deployment of the project, progress of
+// deployments still tracked by the manager is read from memory
+func (m *DeploymentManager) GetDeployment(projectSpec models.ProjectSpec, id uuid.UUID) (models.DeploymentSpec, error) {
+	if deployment, err := m.GetStatus(id); err == nil {
+		if deployment.Namespace.ProjectSpec.Name != projectSpec.Name {
+			return models.DeploymentSpec{}, store.ErrResourceNotFound
+		}
+		return deployment, nil
+	}
+	return m.deploymentRepoFac.New(projectSpec).GetByID(id)
+}
+
+// ListDeployments returns deployments of the project, most recent first
+func (m *DeploymentManager) ListDeployments(projectSpec models.ProjectSpec, filter models.DeploymentFilter) ([]models.DeploymentSpec, error) {
+	return m.deploymentRepoFac.New(projectSpec).GetAll(filter)
+}
+
+func (m *DeploymentManager) save(deployment models.DeploymentSpec) error {
+	return m.deploymentRepoFac.New(deployment.Namespace.ProjectSpec).Save(deployment)
+}
+
+// GetStatus returns a snapshot of the deployment progress
+func (m *DeploymentManager) GetStatus(id uuid.UUID) (models.DeploymentSpec, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	deployment, ok := m.deployments[id]
+	if !ok {
+		return models.DeploymentSpec{}, store.ErrResourceNotFound
+	}
+	snapshot := *deployment
+	snapshot.Jobs = append([]models.JobDeployment(nil), deployment.Jobs...)
+	return snapshot, nil
+}
+
+func (m *DeploymentManager) evictFinishedDeployments(now time.Time) {
+	if m.config.Retention <= 0 {
+		return
+	}
+	for id, deployment := range m.deployments {
+		if deployment.IsFinished() && now.Sub(deployment.UpdatedAt) > m.config.Retention {
+			delete(m.deployments, id)
+		}
+	}
+}
+
+func (m *DeploymentManager) update(id uuid.UUID, fn func(deployment *models.DeploymentSpec)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if deployment, ok := m.deployments[id]; ok {
+		fn(deployment)
+		deployment.UpdatedAt = m.Now()
+	}
+}
+
+func (m *DeploymentManager) updateStatus(id uuid.UUID, status, message string) {
+	m.update(id, func(deployment *models.DeploymentSpec) {
+		deployment.Status = status
+		deployment.Message = message
+	})
+}
+
+func (m *DeploymentManager) updateJobStatus(id uuid.UUID, jobName, status, message string) {
+	m.update(id, func(deployment *models.DeploymentSpec) {
+		for idx := range deployment.Jobs {
+			if deployment.Jobs[idx].Name == jobName {
+				deployment.Jobs[idx].Status = status
+				deployment.Jobs[idx].Message = message
+				return
+			}
+		}
+	})
+}
+
+func (m *DeploymentManager) process(ctx context.Context, req *models.DeployRequest) error {
+	m.updateStatus(req.ID, models.DeploymentStatusInProgress, "")
+
+	storedJobSpecs, err := m.jobSvc.GetAll(req.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve jobs")
+	}
+
+	observers := new(progress.ObserverChain)
+	if m.progressObserver != nil {
+		observers.Join(m.progressObserver)
+	}
+	observers.Join(&deploymentObserver{
+		manager: m,
+		id:      req.ID,
+	})
+
+	// changes are reverted if any of the jobs fail to deploy
+	deployTx := NewDeployTransaction(m.jobSvc, req.Namespace, storedJobSpecs)
+	if err := m.deploy(ctx, deployTx, req, observers); err != nil {
+		if _, rollbackErr := deployTx.Rollback(context.Background(), observers); rollbackErr != nil {
+			return errors.Wrapf(err, "failed to rollback deployment: %s", rollbackErr.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+func (m *DeploymentManager) deploy(ctx context.Context, deployTx *DeployTransaction, req *models.DeployRequest,
+	observers progress.Observer) error {
+	for _, jobSpec := range req.Jobs {
+		if err := deployTx.Create(jobSpec); err != nil {
+			m.updateJobStatus(req.ID, jobSpec.Name, models.JobDeploymentStatusFailed, err.Error())
+			return errors.Wrapf(err, "failed to save %s", jobSpec.Name)
+		}
+	}
+
+	if req.Reconcile {
+		// delete specs not sent for deployment from internal repository
+		if err := deployTx.KeepOnly(req.Jobs, observers); err != nil {
+			return errors.Wrap(err, "failed to delete jobs")
+		}
+	}
+	if err := deployTx.Commit(ctx, observers); err != nil {
+		return errors.Wrap(err, "failed to sync jobs")
+	}
+	return nil
+}
+
+// start a worker goroutine that deploys requests in background
+func (m *DeploymentManager) spawnServiceWorker() {
+	defer m.wg.Done()
+
+	for req := range m.requestQ {
+		logger.I(fmt.Sprintf("worker picked up the deployment %s", req.ID))
+		ctx, cancelCtx := context.WithTimeout(context.Background(), m.config.WorkerTimeout)
+		if err := m.process(ctx, req); err != nil {
+			logger.E(errors.Wrapf(err, "worker failed to process deployment %s", req.ID))
+			m.updateStatus(req.ID, models.DeploymentStatusFailed, err.Error())
+		} else {
+			m.updateStatus(req.ID, models.DeploymentStatusSuccess, "")
+		}
+		cancelCtx()
+
+		if deployment, err := m.GetStatus(req.ID); err == nil {
+			if err := m.save(deployment); err != nil {
+				logger.E(errors.Wrapf(err, "failed to record deployment %s", req.ID))
+			}
+		}
+	}
+}
+
+// Close stops consuming any new request and waits for queued
+// deployments to finish
+func (m *DeploymentManager) Close() error {
+	if m.requestQ != nil {
+		close(m.requestQ)
+	}
+	m.wg.Wait()
+	return nil
+}
+
+func (m *DeploymentManager) Init() {
+	logger.I("starting deployment workers")
+	for i := 0; i < m.config.NumWorkers; i++ {
+		m.wg.Add(1)
+		go m.spawnServiceWorker()
+	}
+}
+
+// NewDeploymentManager constructs a new instance of DeploymentManager
+func NewDeploymentManager(jobSvc models.JobService, deploymentRepoFac DeploymentRepoFactory, uuidProvider utils.UUIDProvider,
+	config DeployManagerConfig, progressObserver progress.Observer) *DeploymentManager {
+	mgr := &DeploymentManager{
+		jobSvc:            jobSvc,
+		deploymentRepoFac: deploymentRepoFac,
+		uuidProvider:      uuidProvider,
+		progressObserver:  progressObserver,
+		config:            config,
+		requestQ:          make(chan *models.DeployRequest, config.QueueSize),
+		deployments:       make(map[uuid.UUID]*models.DeploymentSpec),
+		Now:               time.Now,
+	}
+	mgr.Init()
+	return mgr
+}
+
+// deploymentObserver records per job progress of a deployment
+type deploymentObserver struct {
+	manager *DeploymentManager
+	id      uuid.UUID
+}
+
+func (obs *deploymentObserver) Notify(e progress.Event) {
+	switch evt := e.(type) {
+	case *EventJobUpload:
+		if evt.Err != nil {
+			obs.manager.updateJobStatus(obs.id, evt.Job.Name, models.JobDeploymentStatusFailed, evt.Err.Error())
+			return
+		}
+		obs.manager.updateJobStatus(obs.id, evt.Job.Name, models.JobDeploymentStatusDeployed, "")
+	case *EventJobSpecRevert:
+		obs.manager.updateJobStatus(obs.id, evt.Name, models.JobDeploymentStatusReverted, "")
+	}
+}
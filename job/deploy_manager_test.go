@@ -0,0 +1,294 @@
+package job_test
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
+)
+
+func TestDeploymentManager(t *testing.T) {
+	logger.InitWithWriter(logger.DEBUG, ioutil.Discard)
+
+	namespaceSpec := models.NamespaceSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "dev-team-1",
+		ProjectSpec: models.ProjectSpec{
+			Name: "proj",
+		},
+	}
+	jobSpecs := []models.JobSpec{
+		{Name: "job-1"},
+		{Name: "job-2"},
+	}
+
+	newDeploymentRepoFac := func() (*mock.DeploymentRepoFactory, *mock.DeploymentRepository) {
+		deploymentRepo := new(mock.DeploymentRepository)
+		deploymentRepoFac := new(mock.DeploymentRepoFactory)
+		deploymentRepoFac.On("New", namespaceSpec.ProjectSpec).Return(deploymentRepo)
+		return deploymentRepoFac, deploymentRepo
+	}
+
+	waitToFinish := func(t *testing.T, manager *job.DeploymentManager, id uuid.UUID) models.DeploymentSpec {
+		var deployment models.DeploymentSpec
+		assert.Eventually(t, func() bool {
+			var err error
+			deployment, err = manager.GetStatus(id)
+			assert.Nil(t, err)
+			return deployment.IsFinished()
+		}, time.Second*5, time.Millisecond*10)
+		return deployment
+	}
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Run("should deploy jobs in background and track progress of each job", func(t *testing.T) {
+			deploymentID := uuid.Must(uuid.NewRandom())
+			uuidProvider := new(mock.UUIDProvider)
+			uuidProvider.On("NewUUID").Return(deploymentID, nil)
+			defer uuidProvider.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return([]models.JobSpec{}, nil)
+			jobSvc.On("Create", jobSpecs[0], namespaceSpec).Return(nil)
+			jobSvc.On("Create", jobSpecs[1], namespaceSpec).Return(nil)
+			jobSvc.On("KeepOnly", namespaceSpec, jobSpecs, mock2.Anything).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+				obs := args.Get(2).(progress.Observer)
+				obs.Notify(&job.EventJobUpload{Job: jobSpecs[0]})
+				obs.Notify(&job.EventJobUpload{Job: jobSpecs[1]})
+			}).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			recorded := make(chan struct{})
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deploymentRepo.On("Save", mock2.MatchedBy(func(d models.DeploymentSpec) bool {
+				return d.ID == deploymentID && d.Status == models.DeploymentStatusAccepted && d.RequestedBy == "alice"
+			})).Return(nil).Once()
+			deploymentRepo.On("Save", mock2.MatchedBy(func(d models.DeploymentSpec) bool {
+				return d.ID == deploymentID && d.Status == models.DeploymentStatusSuccess
+			})).Run(func(args mock2.Arguments) {
+				close(recorded)
+			}).Return(nil).Once()
+
+			manager := job.NewDeploymentManager(jobSvc, deploymentRepoFac, uuidProvider, job.DeployManagerConfig{
+				NumWorkers:    1,
+				WorkerTimeout: time.Minute,
+				QueueSize:     1,
+			}, nil)
+			defer manager.Close()
+
+			id, err := manager.Deploy(models.DeployRequest{Namespace: namespaceSpec, Jobs: jobSpecs, Reconcile: true, RequestedBy: "alice"})
+			assert.Nil(t, err)
+			assert.Equal(t, deploymentID.String(), id)
+
+			deployment := waitToFinish(t, manager, deploymentID)
+			assert.Equal(t, models.DeploymentStatusSuccess, deployment.Status)
+			assert.Equal(t, namespaceSpec.Name, deployment.Namespace.Name)
+			assert.Equal(t, "alice", deployment.RequestedBy)
+			assert.Equal(t, 2, len(deployment.Jobs))
+			for _, jobDeployment := range deployment.Jobs {
+				assert.Equal(t, models.JobDeploymentStatusDeployed, jobDeployment.Status)
+			}
+
+			// finished deployment is kept in history
+			select {
+			case <-recorded:
+			case <-time.After(time.Second * 5):
+				t.Error("finished deployment was not recorded")
+			}
+		})
+		t.Run("should not delete jobs missing from the request if not reconciling", func(t *testing.T) {
+			deploymentID := uuid.Must(uuid.NewRandom())
+			uuidProvider := new(mock.UUIDProvider)
+			uuidProvider.On("NewUUID").Return(deploymentID, nil)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return([]models.JobSpec{}, nil)
+			jobSvc.On("Create", jobSpecs[0], namespaceSpec).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+				obs := args.Get(2).(progress.Observer)
+				obs.Notify(&job.EventJobUpload{Job: jobSpecs[0]})
+			}).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deploymentRepo.On("Save", mock2.Anything).Return(nil)
+
+			manager := job.NewDeploymentManager(jobSvc, deploymentRepoFac, uuidProvider, job.DeployManagerConfig{
+				NumWorkers:    1,
+				WorkerTimeout: time.Minute,
+				QueueSize:     1,
+			}, nil)
+			defer manager.Close()
+
+			_, err := manager.Deploy(models.DeployRequest{Namespace: namespaceSpec, Jobs: jobSpecs[:1]})
+			assert.Nil(t, err)
+
+			deployment := waitToFinish(t, manager, deploymentID)
+			assert.Equal(t, models.DeploymentStatusSuccess, deployment.Status)
+			jobSvc.AssertNotCalled(t, "KeepOnly", mock2.Anything, mock2.Anything, mock2.Anything)
+		})
+		t.Run("should mark deployment and job as failed if job fails to save", func(t *testing.T) {
+			deploymentID := uuid.Must(uuid.NewRandom())
+			uuidProvider := new(mock.UUIDProvider)
+			uuidProvider.On("NewUUID").Return(deploymentID, nil)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return([]models.JobSpec{}, nil)
+			jobSvc.On("Create", jobSpecs[0], namespaceSpec).Return(errors.New("invalid spec"))
+			defer jobSvc.AssertExpectations(t)
+
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deploymentRepo.On("Save", mock2.Anything).Return(nil)
+
+			manager := job.NewDeploymentManager(jobSvc, deploymentRepoFac, uuidProvider, job.DeployManagerConfig{
+				NumWorkers:    1,
+				WorkerTimeout: time.Minute,
+				QueueSize:     1,
+			}, nil)
+			defer manager.Close()
+
+			_, err := manager.Deploy(models.DeployRequest{Namespace: namespaceSpec, Jobs: jobSpecs, Reconcile: true})
+			assert.Nil(t, err)
+
+			deployment := waitToFinish(t, manager, deploymentID)
+			assert.Equal(t, models.DeploymentStatusFailed, deployment.Status)
+			assert.Contains(t, deployment.Message, "invalid spec")
+			assert.Equal(t, models.JobDeploymentStatusFailed, deployment.Jobs[0].Status)
+			assert.Equal(t, models.JobDeploymentStatusPending, deployment.Jobs[1].Status)
+		})
+		t.Run("should revert saved jobs if sync fails", func(t *testing.T) {
+			deploymentID := uuid.Must(uuid.NewRandom())
+			uuidProvider := new(mock.UUIDProvider)
+			uuidProvider.On("NewUUID").Return(deploymentID, nil)
+
+			storedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "old-owner"},
+			}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return(storedJobSpecs, nil)
+			jobSvc.On("Create", jobSpecs[0], namespaceSpec).Return(nil)
+			jobSvc.On("Create", jobSpecs[1], namespaceSpec).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(errors.New("scheduler down")).Once()
+			// restore stored job and delete the added one
+			jobSvc.On("Create", storedJobSpecs[0], namespaceSpec).Return(nil)
+			jobSvc.On("KeepOnly", namespaceSpec, storedJobSpecs, nil).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, nil).Return(nil).Once()
+			defer jobSvc.AssertExpectations(t)
+
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deploymentRepo.On("Save", mock2.Anything).Return(nil)
+
+			manager := job.NewDeploymentManager(jobSvc, deploymentRepoFac, uuidProvider, job.DeployManagerConfig{
+				NumWorkers:    1,
+				WorkerTimeout: time.Minute,
+				QueueSize:     1,
+			}, nil)
+			defer manager.Close()
+
+			_, err := manager.Deploy(models.DeployRequest{Namespace: namespaceSpec, Jobs: jobSpecs})
+			assert.Nil(t, err)
+
+			deployment := waitToFinish(t, manager, deploymentID)
+			assert.Equal(t, models.DeploymentStatusFailed, deployment.Status)
+			assert.Contains(t, deployment.Message, "scheduler down")
+			for _, jobDeployment := range deployment.Jobs {
+				assert.Equal(t, models.JobDeploymentStatusReverted, jobDeployment.Status)
+			}
+		})
+		t.Run("should fail when request queue is full", func(t *testing.T) {
+			uuidProvider := new(mock.UUIDProvider)
+			uuidProvider.On("NewUUID").Return(uuid.Must(uuid.NewRandom()), nil)
+
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deploymentRepo.On("Save", mock2.Anything).Return(nil)
+
+			// no workers to consume the queue
+			manager := job.NewDeploymentManager(nil, deploymentRepoFac, uuidProvider, job.DeployManagerConfig{
+				NumWorkers: 0,
+				QueueSize:  1,
+			}, nil)
+
+			_, err := manager.Deploy(models.DeployRequest{Namespace: namespaceSpec, Jobs: jobSpecs, Reconcile: true})
+			assert.Nil(t, err)
+			_, err = manager.Deploy(models.DeployRequest{Namespace: namespaceSpec, Jobs: jobSpecs, Reconcile: true})
+			assert.Equal(t, job.ErrRequestQueueFull, err)
+		})
+	})
+	t.Run("Record", func(t *testing.T) {
+		t.Run("should assign an id and save deployment in history", func(t *testing.T) {
+			deploymentID := uuid.Must(uuid.NewRandom())
+			uuidProvider := new(mock.UUIDProvider)
+			uuidProvider.On("NewUUID").Return(deploymentID, nil)
+
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deployment := models.DeploymentSpec{
+				Namespace:   namespaceSpec,
+				Status:      models.DeploymentStatusSuccess,
+				RequestedBy: "alice",
+			}
+			recorded := deployment
+			recorded.ID = deploymentID
+			deploymentRepo.On("Save", recorded).Return(nil)
+			defer deploymentRepo.AssertExpectations(t)
+
+			manager := job.NewDeploymentManager(nil, deploymentRepoFac, uuidProvider, job.DeployManagerConfig{}, nil)
+			defer manager.Close()
+
+			assert.Nil(t, manager.Record(deployment))
+		})
+	})
+	t.Run("GetDeployment", func(t *testing.T) {
+		t.Run("should read finished deployment from history", func(t *testing.T) {
+			deploymentID := uuid.Must(uuid.NewRandom())
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deploymentRepo.On("GetByID", deploymentID).Return(models.DeploymentSpec{
+				ID:     deploymentID,
+				Status: models.DeploymentStatusFailed,
+			}, nil)
+			defer deploymentRepo.AssertExpectations(t)
+
+			manager := job.NewDeploymentManager(nil, deploymentRepoFac, nil, job.DeployManagerConfig{}, nil)
+			defer manager.Close()
+
+			deployment, err := manager.GetDeployment(namespaceSpec.ProjectSpec, deploymentID)
+			assert.Nil(t, err)
+			assert.Equal(t, models.DeploymentStatusFailed, deployment.Status)
+		})
+	})
+	t.Run("ListDeployments", func(t *testing.T) {
+		t.Run("should read deployments of project from history", func(t *testing.T) {
+			filter := models.DeploymentFilter{Namespace: namespaceSpec.Name, Limit: 10}
+			deploymentRepoFac, deploymentRepo := newDeploymentRepoFac()
+			deploymentRepo.On("GetAll", filter).Return([]models.DeploymentSpec{{Status: models.DeploymentStatusSuccess}}, nil)
+			defer deploymentRepo.AssertExpectations(t)
+
+			manager := job.NewDeploymentManager(nil, deploymentRepoFac, nil, job.DeployManagerConfig{}, nil)
+			defer manager.Close()
+
+			deployments, err := manager.ListDeployments(namespaceSpec.ProjectSpec, filter)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(deployments))
+		})
+	})
+	t.Run("GetStatus", func(t *testing.T) {
+		t.Run("should return not found for unknown deployment", func(t *testing.T) {
+			manager := job.NewDeploymentManager(nil, nil, nil, job.DeployManagerConfig{}, nil)
+			defer manager.Close()
+
+			_, err := manager.GetStatus(uuid.Must(uuid.NewRandom()))
+			assert.Equal(t, store.ErrResourceNotFound, err)
+		})
+	})
+}
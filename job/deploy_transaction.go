@@ -0,0 +1,173 @@
+package job
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+// DeployTransaction tracks job specifications of a namespace changed during
+// a deployment. Changes are applied to the store as they are made and the
+// scheduler is updated on Commit, if any step fails Rollback restores the
+// specifications present before the deployment started
+type DeployTransaction struct {
+	mu sync.Mutex
+
+	jobSvc    models.JobService
+	namespace models.NamespaceSpec
+
+	// stored specs of the namespace before deployment
+	stored map[string]models.JobSpec
+	// names of the specs modified by the deployment
+	changed map[string]bool
+	// revisions of the specs saved by the deployment
+	saved map[string]int
+}
+
+// Create saves the job specification as part of the deployment. Specs
+// without a revision are expected to be at the revision stored before the
+// deployment started, saving fails if another deployment changed them since
+func (tx *DeployTransaction) Create(jobSpec models.JobSpec) error {
+	jobSpec = tx.withStoredRevision(jobSpec)
+	if err := tx.jobSvc.Create(tx.namespace, jobSpec); err != nil {
+		return err
+	}
+	tx.markSaved(jobSpec)
+	return nil
+}
+
+// CreateAll saves a batch of job specifications as part of the deployment
+// in a single transaction, revisions are checked same as Create
+func (tx *DeployTransaction) CreateAll(jobSpecs []models.JobSpec) error {
+	toSave := make([]models.JobSpec, len(jobSpecs))
+	for idx, jobSpec := range jobSpecs {
+		toSave[idx] = tx.withStoredRevision(jobSpec)
+	}
+	if err := tx.jobSvc.CreateAll(tx.namespace, toSave); err != nil {
+		return err
+	}
+	for _, jobSpec := range toSave {
+		tx.markSaved(jobSpec)
+	}
+	return nil
+}
+
+func (tx *DeployTransaction) withStoredRevision(jobSpec models.JobSpec) models.JobSpec {
+	if storedSpec, ok := tx.stored[jobSpec.Name]; ok && jobSpec.Revision == 0 {
+		jobSpec.Revision = storedSpec.Revision
+	}
+	return jobSpec
+}
+
+func (tx *DeployTransaction) markSaved(jobSpec models.JobSpec) {
+	tx.markChanged(jobSpec.Name)
+	if jobSpec.Revision != 0 {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		tx.saved[jobSpec.Name] = jobSpec.Revision + 1
+	}
+}
+
+// KeepOnly deletes specs of the namespace not part of the deployment
+func (tx *DeployTransaction) KeepOnly(specsToKeep []models.JobSpec, obs progress.Observer) error {
+	observers := new(progress.ObserverChain)
+	if obs != nil {
+		observers.Join(obs)
+	}
+	observers.Join(tx)
+	return tx.jobSvc.KeepOnly(tx.namespace, specsToKeep, observers)
+}
+
+// Commit compiles the specs of namespace and uploads them to the scheduler
+func (tx *DeployTransaction) Commit(ctx context.Context, obs progress.Observer) error {
+	return tx.jobSvc.Sync(ctx, tx.namespace, obs)
+}
+
+// Rollback restores the specs modified by the deployment to their stored
+// version, specs added by the deployment are deleted. Scheduler is synced
+// with the restored specs, each reverted job is notified to the observer
+func (tx *DeployTransaction) Rollback(ctx context.Context, obs progress.Observer) ([]string, error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	var reverted []string
+	for name := range tx.changed {
+		reverted = append(reverted, name)
+	}
+	sort.Strings(reverted)
+	if len(reverted) == 0 {
+		return nil, nil
+	}
+
+	var err error
+	for _, name := range reverted {
+		storedSpec, ok := tx.stored[name]
+		if !ok {
+			// added by the deployment, deleted below
+			continue
+		}
+		// restored over the revision saved by the deployment, specs deleted
+		// by it are created again regardless of their revision
+		storedSpec.Revision = tx.saved[name]
+		if createErr := tx.jobSvc.Create(tx.namespace, storedSpec); createErr != nil {
+			err = multierror.Append(err, errors.Wrapf(createErr, "failed to restore %s", name))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var storedSpecs []models.JobSpec
+	for _, storedSpec := range tx.stored {
+		storedSpecs = append(storedSpecs, storedSpec)
+	}
+	if err := tx.jobSvc.KeepOnly(tx.namespace, storedSpecs, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to delete jobs added by deployment")
+	}
+	if err := tx.jobSvc.Sync(ctx, tx.namespace, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to sync restored jobs")
+	}
+
+	if obs != nil {
+		for _, name := range reverted {
+			obs.Notify(&EventJobSpecRevert{Name: name})
+		}
+	}
+	tx.changed = map[string]bool{}
+	tx.saved = map[string]int{}
+	return reverted, nil
+}
+
+// Notify records specs deleted by the deployment
+func (tx *DeployTransaction) Notify(e progress.Event) {
+	if evt, ok := e.(*EventSavedJobDelete); ok {
+		tx.markChanged(evt.Name)
+	}
+}
+
+func (tx *DeployTransaction) markChanged(name string) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.changed[name] = true
+}
+
+// NewDeployTransaction starts tracking changes to the namespace,
+// storedSpecs are the specs of the namespace before deployment
+func NewDeployTransaction(jobSvc models.JobService, namespace models.NamespaceSpec, storedSpecs []models.JobSpec) *DeployTransaction {
+	stored := make(map[string]models.JobSpec, len(storedSpecs))
+	for _, jobSpec := range storedSpecs {
+		stored[jobSpec.Name] = jobSpec
+	}
+	return &DeployTransaction{
+		jobSvc:    jobSvc,
+		namespace: namespace,
+		stored:    stored,
+		changed:   map[string]bool{},
+		saved:     map[string]int{},
+	}
+}
@@ -0,0 +1,201 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
+)
+
+type revertObserver struct {
+	reverted []string
+}
+
+func (obs *revertObserver) Notify(e progress.Event) {
+	if evt, ok := e.(*job.EventJobSpecRevert); ok {
+		obs.reverted = append(obs.reverted, evt.Name)
+	}
+}
+
+func TestDeployTransaction(t *testing.T) {
+	namespaceSpec := models.NamespaceSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "dev-team-1",
+		ProjectSpec: models.ProjectSpec{
+			Name: "proj",
+		},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		t.Run("should save jobs at the revision stored before deployment", func(t *testing.T) {
+			storedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "old-owner", Revision: 3},
+			}
+			expectedJobSpec := models.JobSpec{Name: "job-1", Owner: "new-owner", Revision: 3}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("Create", expectedJobSpec, namespaceSpec).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, storedJobSpecs)
+			assert.Nil(t, deployTx.Create(models.JobSpec{Name: "job-1", Owner: "new-owner"}))
+		})
+		t.Run("should not revert jobs which failed to save on conflict", func(t *testing.T) {
+			storedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "old-owner", Revision: 3},
+			}
+			modifiedJobSpec := models.JobSpec{Name: "job-1", Owner: "new-owner", Revision: 2}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("Create", modifiedJobSpec, namespaceSpec).Return(store.ErrRevisionConflict)
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, storedJobSpecs)
+			err := deployTx.Create(modifiedJobSpec)
+			assert.True(t, errors.Is(err, store.ErrRevisionConflict))
+
+			reverted, err := deployTx.Rollback(context.Background(), nil)
+			assert.Nil(t, err)
+			assert.Empty(t, reverted)
+		})
+	})
+	t.Run("CreateAll", func(t *testing.T) {
+		t.Run("should save batch of jobs and revert them on rollback", func(t *testing.T) {
+			storedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "old-owner", Revision: 3},
+			}
+			savedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "new-owner", Revision: 3},
+				{Name: "job-2"},
+			}
+			restoredJobSpec := models.JobSpec{Name: "job-1", Owner: "old-owner", Revision: 4}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("CreateAll", namespaceSpec, savedJobSpecs).Return(nil)
+			jobSvc.On("Create", restoredJobSpec, namespaceSpec).Return(nil)
+			jobSvc.On("KeepOnly", namespaceSpec, storedJobSpecs, nil).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, nil).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, storedJobSpecs)
+			assert.Nil(t, deployTx.CreateAll([]models.JobSpec{
+				{Name: "job-1", Owner: "new-owner"},
+				{Name: "job-2"},
+			}))
+
+			reverted, err := deployTx.Rollback(context.Background(), nil)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-1", "job-2"}, reverted)
+		})
+		t.Run("should not revert any job of a batch which failed to save", func(t *testing.T) {
+			jobSpecs := []models.JobSpec{{Name: "job-1"}, {Name: "job-2"}}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("CreateAll", namespaceSpec, jobSpecs).Return(errors.New("db down"))
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, nil)
+			assert.NotNil(t, deployTx.CreateAll(jobSpecs))
+
+			reverted, err := deployTx.Rollback(context.Background(), nil)
+			assert.Nil(t, err)
+			assert.Empty(t, reverted)
+		})
+	})
+	t.Run("Rollback", func(t *testing.T) {
+		t.Run("should restore jobs over the revision saved by deployment", func(t *testing.T) {
+			storedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "old-owner", Revision: 3},
+			}
+			modifiedJobSpec := models.JobSpec{Name: "job-1", Owner: "new-owner", Revision: 3}
+			restoredJobSpec := models.JobSpec{Name: "job-1", Owner: "old-owner", Revision: 4}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("Create", modifiedJobSpec, namespaceSpec).Return(nil)
+			jobSvc.On("Create", restoredJobSpec, namespaceSpec).Return(nil)
+			jobSvc.On("KeepOnly", namespaceSpec, storedJobSpecs, nil).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, nil).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, storedJobSpecs)
+			assert.Nil(t, deployTx.Create(modifiedJobSpec))
+
+			reverted, err := deployTx.Rollback(context.Background(), nil)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-1"}, reverted)
+		})
+		t.Run("should restore modified and deleted jobs and delete added jobs", func(t *testing.T) {
+			storedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "old-owner"},
+				{Name: "job-2"},
+				{Name: "job-3"},
+			}
+			modifiedJobSpec := models.JobSpec{Name: "job-1", Owner: "new-owner"}
+			addedJobSpec := models.JobSpec{Name: "job-4"}
+			jobsToKeep := []models.JobSpec{modifiedJobSpec, storedJobSpecs[1], addedJobSpec}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("Create", modifiedJobSpec, namespaceSpec).Return(nil)
+			jobSvc.On("Create", addedJobSpec, namespaceSpec).Return(nil)
+			jobSvc.On("KeepOnly", namespaceSpec, jobsToKeep, mock2.Anything).Run(func(args mock2.Arguments) {
+				args.Get(2).(progress.Observer).Notify(&job.EventSavedJobDelete{Name: "job-3"})
+			}).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(errors.New("scheduler down")).Once()
+
+			jobSvc.On("Create", storedJobSpecs[0], namespaceSpec).Return(nil)
+			jobSvc.On("Create", storedJobSpecs[2], namespaceSpec).Return(nil)
+			jobSvc.On("KeepOnly", namespaceSpec, mock2.Anything, nil).Return(nil)
+			jobSvc.On("Sync", mock2.Anything, namespaceSpec, nil).Return(nil).Once()
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, storedJobSpecs)
+			assert.Nil(t, deployTx.Create(modifiedJobSpec))
+			assert.Nil(t, deployTx.Create(addedJobSpec))
+			assert.Nil(t, deployTx.KeepOnly(jobsToKeep, nil))
+			assert.NotNil(t, deployTx.Commit(context.Background(), nil))
+
+			obs := new(revertObserver)
+			reverted, err := deployTx.Rollback(context.Background(), obs)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-1", "job-3", "job-4"}, reverted)
+			assert.Equal(t, reverted, obs.reverted)
+		})
+		t.Run("should not touch store if nothing was changed", func(t *testing.T) {
+			jobSvc := new(mock.JobService)
+			jobSvc.On("Create", models.JobSpec{Name: "job-1"}, namespaceSpec).Return(errors.New("invalid spec"))
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, nil)
+			assert.NotNil(t, deployTx.Create(models.JobSpec{Name: "job-1"}))
+
+			reverted, err := deployTx.Rollback(context.Background(), nil)
+			assert.Nil(t, err)
+			assert.Empty(t, reverted)
+		})
+		t.Run("should return error if stored job can not be restored", func(t *testing.T) {
+			storedJobSpecs := []models.JobSpec{
+				{Name: "job-1", Owner: "old-owner"},
+			}
+			modifiedJobSpec := models.JobSpec{Name: "job-1", Owner: "new-owner"}
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("Create", modifiedJobSpec, namespaceSpec).Return(nil)
+			jobSvc.On("Create", storedJobSpecs[0], namespaceSpec).Return(errors.New("db down"))
+			defer jobSvc.AssertExpectations(t)
+
+			deployTx := job.NewDeployTransaction(jobSvc, namespaceSpec, storedJobSpecs)
+			assert.Nil(t, deployTx.Create(modifiedJobSpec))
+
+			_, err := deployTx.Rollback(context.Background(), nil)
+			assert.Contains(t, err.Error(), "failed to restore job-1")
+		})
+	})
+}
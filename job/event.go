@@ -24,7 +24,12 @@ func (e *eventService) Register(ctx context.Context, namespace models.NamespaceS
 	for _, notify := range jobSpec.Behavior.Notify {
 		if notify.On == evt.Type {
 			for _, channel := range notify.Channels {
-				chanParts := strings.Split(channel, "://")
+				// routes can be urls themselves, e.g. webhook://https://example.io
+				chanParts := strings.SplitN(channel, "://", 2)
+				if len(chanParts) != 2 {
+					err = multierror.Append(err, errors.Errorf("invalid notification channel %s", channel))
+					continue
+				}
 				scheme := chanParts[0]
 				route := chanParts[1]
 
@@ -35,6 +40,7 @@ func (e *eventService) Register(ctx context.Context, namespace models.NamespaceS
 						JobSpec:   jobSpec,
 						JobEvent:  evt,
 						Route:     route,
+						Config:    notify.Config,
 					}); currErr != nil {
 						log.E(currErr)
 						err = multierror.Append(err, errors.Wrapf(currErr, "notifyChannel.Notify: %s", channel))
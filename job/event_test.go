@@ -70,6 +70,54 @@ func TestEventService(t *testing.T) {
 		err := evtService.Register(context.Background(), namespaceSpec, jobSpec, je)
 		assert.Nil(t, err)
 	})
+	t.Run("should pass routes holding urls and config of notifier to notifiers", func(t *testing.T) {
+		namespaceSpec := models.NamespaceSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "game_jam",
+			ProjectSpec: models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			},
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+			Behavior: models.JobSpecBehavior{
+				Notify: []models.JobSpecNotifier{
+					{
+						On: models.JobEventTypeFailure,
+						Config: map[string]string{
+							"template": "{{.Job}} failed",
+						},
+						Channels: []string{
+							"webhook://https://hooks.example.io/optimus",
+						},
+					},
+				},
+			},
+		}
+		je := models.JobEvent{
+			Type:  models.JobEventTypeFailure,
+			Value: eventValues.Fields,
+		}
+
+		notifier := new(mock.Notifier)
+		notifier.On("Notify", context.Background(), models.NotifyAttrs{
+			Namespace: namespaceSpec,
+			JobSpec:   jobSpec,
+			JobEvent:  je,
+			Route:     "https://hooks.example.io/optimus",
+			Config: map[string]string{
+				"template": "{{.Job}} failed",
+			},
+		}).Return(nil)
+		defer notifier.AssertExpectations(t)
+
+		evtService := job.NewEventService(map[string]models.Notifier{
+			"webhook": notifier,
+		})
+		err := evtService.Register(context.Background(), namespaceSpec, jobSpec, je)
+		assert.Nil(t, err)
+	})
 	t.Run("should ignore notify events for unknown schemes", func(t *testing.T) {
 		projectSpec := models.ProjectSpec{
 			ID:   uuid.Must(uuid.NewRandom()),
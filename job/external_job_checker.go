@@ -0,0 +1,64 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+var ErrUnknownExternalDependency = errors.New("unknown external dependency")
+
+// HTTPClient sends requests to runtime api of other optimus servers
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ExternalJobChecker verifies a job is deployed on another optimus server
+type ExternalJobChecker interface {
+	Check(ctx context.Context, dependency models.JobSpecExternalDependency) error
+}
+
+type externalJobChecker struct {
+	httpClient HTTPClient
+}
+
+// Check asks for status of the job, same as sensors waiting on it do, which
+// is not found unless job is deployed in project of the server
+func (c *externalJobChecker) Check(ctx context.Context, dependency models.JobSpecExternalDependency) error {
+	host := strings.TrimSuffix(dependency.Host, "/")
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	statusURL := fmt.Sprintf("%s/api/v1/project/%s/job/%s/status", host,
+		url.PathEscape(dependency.ProjectName), url.PathEscape(dependency.JobName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, statusURL, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %s", statusURL)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to reach optimus server %s", dependency.Host)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return ErrUnknownExternalDependency
+	}
+	return errors.Errorf("optimus server %s responded with status %d", dependency.Host, resp.StatusCode)
+}
+
+// NewExternalJobChecker creates a checker using runtime api of optimus servers
+func NewExternalJobChecker(httpClient HTTPClient) *externalJobChecker {
+	return &externalJobChecker{
+		httpClient: httpClient,
+	}
+}
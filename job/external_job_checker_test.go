@@ -0,0 +1,55 @@
+package job_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalJobChecker(t *testing.T) {
+	t.Run("Check", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v1/project/partner-project/job/billing-export/status":
+				w.WriteHeader(http.StatusOK)
+			case "/api/v1/project/partner-project/job/unknown-job/status":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+		defer server.Close()
+
+		checker := job.NewExternalJobChecker(server.Client())
+
+		t.Run("should succeed if job is deployed on optimus server", func(t *testing.T) {
+			err := checker.Check(context.Background(), models.JobSpecExternalDependency{
+				Host:        server.URL,
+				ProjectName: "partner-project",
+				JobName:     "billing-export",
+			})
+			assert.Nil(t, err)
+		})
+		t.Run("should fail if job is not deployed on optimus server", func(t *testing.T) {
+			err := checker.Check(context.Background(), models.JobSpecExternalDependency{
+				Host:        server.URL,
+				ProjectName: "partner-project",
+				JobName:     "unknown-job",
+			})
+			assert.Equal(t, job.ErrUnknownExternalDependency, err)
+		})
+		t.Run("should fail if optimus server responds with an error", func(t *testing.T) {
+			err := checker.Check(context.Background(), models.JobSpecExternalDependency{
+				Host:        server.URL,
+				ProjectName: "other-project",
+				JobName:     "billing-export",
+			})
+			assert.EqualError(t, err, "optimus server "+server.URL+" responded with status 500")
+		})
+	})
+}
@@ -1,11 +1,28 @@
 package job
 
-import "github.com/odpf/optimus/models"
+import (
+	"time"
+
+	"github.com/odpf/optimus/models"
+)
 
 // SpecRepository represents a storage interface for Job specifications at a namespace level
 type SpecRepository interface {
 	Save(models.JobSpec) error
+	// SaveAll saves specs in a single transaction, none of them are saved
+	// if any fails
+	SaveAll([]models.JobSpec) error
 	GetByName(string) (models.JobSpec, error)
 	GetAll() ([]models.JobSpec, error)
+	// Delete keeps the spec as deleted till it is purged
 	Delete(string) error
+	// Restore brings back a deleted spec, store.ErrResourceNotFound is
+	// returned if there is none
+	Restore(string) error
+	// Purge permanently removes specs deleted before the given time
+	Purge(time.Time) ([]string, error)
+	// GetVersions lists every saved version of a spec, latest first
+	GetVersions(string) ([]models.JobSpecVersion, error)
+	// GetVersion fetches a spec as it was saved at a revision
+	GetVersion(string, int) (models.JobSpecVersion, error)
 }
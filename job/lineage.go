@@ -0,0 +1,195 @@
+package job
+
+import (
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+// DefaultLineageDepth is the hops of jobs walked when depth isn't asked for
+const DefaultLineageDepth = 1
+
+// LineageService builds lineage graphs of jobs from the destinations and
+// dependencies recorded as their metadata is published. Jobs connect
+// through the urns of resources they read and write, across projects
+type LineageService struct {
+	repo store.JobLineageRepository
+
+	// maxDepth caps the hops of jobs walked from a job
+	maxDepth int
+}
+
+// GetLineage walks jobs from the job in direction up to depth hops of
+// jobs, resources in between are nodes of the graph too
+func (srv *LineageService) GetLineage(projectName, jobName string, direction models.LineageDirection, depth int) (models.LineageGraph, error) {
+	root, err := srv.repo.GetByJob(projectName, jobName)
+	if err != nil {
+		return models.LineageGraph{}, errors.Wrapf(err, "failed to find lineage of job %s", jobName)
+	}
+	if depth <= 0 {
+		depth = DefaultLineageDepth
+	}
+	if srv.maxDepth > 0 && depth > srv.maxDepth {
+		depth = srv.maxDepth
+	}
+
+	graph := newLineageGraphBuilder()
+	graph.addJob(root)
+	if direction == models.LineageDirectionUpstream || direction == models.LineageDirectionBoth {
+		if err := srv.walk(graph, root, depth, srv.upstream); err != nil {
+			return models.LineageGraph{}, err
+		}
+	}
+	if direction == models.LineageDirectionDownstream || direction == models.LineageDirectionBoth {
+		if err := srv.walk(graph, root, depth, srv.downstream); err != nil {
+			return models.LineageGraph{}, err
+		}
+	}
+	return graph.build(), nil
+}
+
+// walk visits jobs breadth first from root, step adds the resources and
+// jobs a hop away from a job and returns the jobs
+func (srv *LineageService) walk(graph *lineageGraphBuilder, root models.JobLineage, depth int,
+	step func(*lineageGraphBuilder, models.JobLineage) ([]models.JobLineage, error)) error {
+	visited := map[string]bool{lineageJobID(root): true}
+	frontier := []models.JobLineage{root}
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		var next []models.JobLineage
+		for _, lineage := range frontier {
+			jobs, err := step(graph, lineage)
+			if err != nil {
+				return err
+			}
+			for _, job := range jobs {
+				if id := lineageJobID(job); !visited[id] {
+					visited[id] = true
+					next = append(next, job)
+				}
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// upstream adds resources job reads and the jobs writing them
+func (srv *LineageService) upstream(graph *lineageGraphBuilder, lineage models.JobLineage) ([]models.JobLineage, error) {
+	var producers []models.JobLineage
+	for _, urn := range lineage.Dependencies {
+		graph.addResource(urn)
+		graph.addEdge(lineageResourceID(urn), lineageJobID(lineage))
+
+		jobs, err := srv.repo.GetByDestination(urn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find jobs writing %s", urn)
+		}
+		for _, job := range jobs {
+			if lineageJobID(job) == lineageJobID(lineage) {
+				continue
+			}
+			graph.addJob(job)
+			graph.addEdge(lineageJobID(job), lineageResourceID(urn))
+			producers = append(producers, job)
+		}
+	}
+	return producers, nil
+}
+
+// downstream adds the resource job writes and the jobs reading it
+func (srv *LineageService) downstream(graph *lineageGraphBuilder, lineage models.JobLineage) ([]models.JobLineage, error) {
+	if lineage.Destination == "" {
+		return nil, nil
+	}
+	graph.addResource(lineage.Destination)
+	graph.addEdge(lineageJobID(lineage), lineageResourceID(lineage.Destination))
+
+	jobs, err := srv.repo.GetByDependency(lineage.Destination)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find jobs reading %s", lineage.Destination)
+	}
+	var consumers []models.JobLineage
+	for _, job := range jobs {
+		if lineageJobID(job) == lineageJobID(lineage) {
+			continue
+		}
+		graph.addJob(job)
+		graph.addEdge(lineageResourceID(lineage.Destination), lineageJobID(job))
+		consumers = append(consumers, job)
+	}
+	return consumers, nil
+}
+
+// lineageGraphBuilder keeps nodes and edges in the order they are found,
+// each of them once
+type lineageGraphBuilder struct {
+	graph models.LineageGraph
+	nodes map[string]bool
+	edges map[models.LineageEdge]bool
+}
+
+func (b *lineageGraphBuilder) addJob(lineage models.JobLineage) {
+	id := lineageJobID(lineage)
+	if b.nodes[id] {
+		return
+	}
+	b.nodes[id] = true
+	b.graph.Nodes = append(b.graph.Nodes, models.LineageNode{
+		ID:            id,
+		Type:          models.LineageNodeTypeJob,
+		ProjectName:   lineage.ProjectName,
+		NamespaceName: lineage.NamespaceName,
+		JobName:       lineage.JobName,
+		Urn:           lineage.Destination,
+	})
+}
+
+func (b *lineageGraphBuilder) addResource(urn string) {
+	id := lineageResourceID(urn)
+	if b.nodes[id] {
+		return
+	}
+	b.nodes[id] = true
+	b.graph.Nodes = append(b.graph.Nodes, models.LineageNode{
+		ID:   id,
+		Type: models.LineageNodeTypeResource,
+		Urn:  urn,
+	})
+}
+
+func (b *lineageGraphBuilder) addEdge(source, target string) {
+	edge := models.LineageEdge{Source: source, Target: target}
+	if b.edges[edge] {
+		return
+	}
+	b.edges[edge] = true
+	b.graph.Edges = append(b.graph.Edges, edge)
+}
+
+func (b *lineageGraphBuilder) build() models.LineageGraph {
+	return b.graph
+}
+
+func newLineageGraphBuilder() *lineageGraphBuilder {
+	return &lineageGraphBuilder{
+		nodes: map[string]bool{},
+		edges: map[models.LineageEdge]bool{},
+	}
+}
+
+func lineageJobID(lineage models.JobLineage) string {
+	return "job:" + lineage.ProjectName + "/" + lineage.JobName
+}
+
+func lineageResourceID(urn string) string {
+	return "resource:" + urn
+}
+
+// NewLineageService returns a service walking lineage in repo, walks are
+// capped to maxDepth hops of jobs if it is positive
+func NewLineageService(repo store.JobLineageRepository, maxDepth int) *LineageService {
+	return &LineageService{
+		repo:     repo,
+		maxDepth: maxDepth,
+	}
+}
@@ -0,0 +1,89 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineageService(t *testing.T) {
+	// raw -> ingest (proj-a) -> clean -> transform (proj-a) -> mart -> report (proj-b)
+	newRepo := func() store.JobLineageRepository {
+		repo := memory.NewJobLineageRepository(memory.NewDB())
+		for _, lineage := range []models.JobLineage{
+			{ProjectName: "proj-a", NamespaceName: "ns-1", JobName: "ingest", Destination: "bigquery://proj-a:sales.clean", Dependencies: []string{"bigquery://proj-a:sales.raw"}},
+			{ProjectName: "proj-a", NamespaceName: "ns-1", JobName: "transform", Destination: "bigquery://proj-a:sales.mart", Dependencies: []string{"bigquery://proj-a:sales.clean"}},
+			{ProjectName: "proj-b", NamespaceName: "ns-2", JobName: "report", Destination: "bigquery://proj-b:bi.report", Dependencies: []string{"bigquery://proj-a:sales.mart"}},
+		} {
+			assert.Nil(t, repo.Save(lineage))
+		}
+		return repo
+	}
+	nodeIDs := func(graph models.LineageGraph) []string {
+		var ids []string
+		for _, node := range graph.Nodes {
+			ids = append(ids, node.ID)
+		}
+		return ids
+	}
+
+	t.Run("should walk upstream to producers of dependencies", func(t *testing.T) {
+		srv := job.NewLineageService(newRepo(), 5)
+		graph, err := srv.GetLineage("proj-b", "report", models.LineageDirectionUpstream, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"job:proj-b/report",
+			"resource:bigquery://proj-a:sales.mart",
+			"job:proj-a/transform",
+			"resource:bigquery://proj-a:sales.clean",
+			"job:proj-a/ingest",
+		}, nodeIDs(graph))
+		assert.Equal(t, []models.LineageEdge{
+			{Source: "resource:bigquery://proj-a:sales.mart", Target: "job:proj-b/report"},
+			{Source: "job:proj-a/transform", Target: "resource:bigquery://proj-a:sales.mart"},
+			{Source: "resource:bigquery://proj-a:sales.clean", Target: "job:proj-a/transform"},
+			{Source: "job:proj-a/ingest", Target: "resource:bigquery://proj-a:sales.clean"},
+		}, graph.Edges)
+		assert.Equal(t, models.LineageNode{
+			ID:            "job:proj-a/transform",
+			Type:          models.LineageNodeTypeJob,
+			ProjectName:   "proj-a",
+			NamespaceName: "ns-1",
+			JobName:       "transform",
+			Urn:           "bigquery://proj-a:sales.mart",
+		}, graph.Nodes[2])
+	})
+	t.Run("should walk downstream to consumers of destination", func(t *testing.T) {
+		srv := job.NewLineageService(newRepo(), 5)
+		graph, err := srv.GetLineage("proj-a", "ingest", models.LineageDirectionDownstream, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"job:proj-a/ingest",
+			"resource:bigquery://proj-a:sales.clean",
+			"job:proj-a/transform",
+		}, nodeIDs(graph))
+	})
+	t.Run("should walk both ways capped to max depth", func(t *testing.T) {
+		srv := job.NewLineageService(newRepo(), 1)
+		graph, err := srv.GetLineage("proj-a", "transform", models.LineageDirectionBoth, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"job:proj-a/transform",
+			"resource:bigquery://proj-a:sales.clean",
+			"job:proj-a/ingest",
+			"resource:bigquery://proj-a:sales.mart",
+			"job:proj-b/report",
+		}, nodeIDs(graph))
+		assert.Len(t, graph.Edges, 4)
+	})
+	t.Run("should fail if lineage of job isn't recorded", func(t *testing.T) {
+		srv := job.NewLineageService(newRepo(), 5)
+		_, err := srv.GetLineage("proj-a", "unknown", models.LineageDirectionBoth, 1)
+		assert.True(t, errors.Is(err, store.ErrResourceNotFound))
+	})
+}
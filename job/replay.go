@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/odpf/optimus/core/cron"
 	"github.com/odpf/optimus/core/tree"
 	"github.com/odpf/optimus/models"
@@ -55,6 +56,14 @@ func (srv *Service) Replay(ctx context.Context, replayRequest *models.ReplayWork
 	return replayUUID, nil
 }
 
+func (srv *Service) GetReplay(jobSpec models.JobSpec, replayID uuid.UUID) (models.ReplaySpec, error) {
+	return srv.replayManager.GetReplay(jobSpec, replayID)
+}
+
+func (srv *Service) GetReplayList(projectID uuid.UUID) ([]models.ReplaySpec, error) {
+	return srv.replayManager.GetReplayList(projectID)
+}
+
 // prepareTree creates a execution tree for replay operation
 func prepareTree(replayRequest *models.ReplayWorkerRequest) (*tree.TreeNode, error) {
 	replayJobSpec, found := replayRequest.JobSpecMap[replayRequest.Job.Name]
@@ -38,6 +38,8 @@ type ReplayManagerConfig struct {
 type ReplayManager interface {
 	Init()
 	Replay(context.Context, *models.ReplayWorkerRequest) (string, error)
+	GetReplay(models.JobSpec, uuid.UUID) (models.ReplaySpec, error)
+	GetReplayList(projectID uuid.UUID) ([]models.ReplaySpec, error)
 }
 
 // Manager for replaying operation(s).
@@ -109,6 +111,24 @@ func (m *Manager) Replay(ctx context.Context, reqInput *models.ReplayWorkerReque
 	}
 }
 
+// GetReplay returns the replay of job by its id, it is updated by workers
+// as runs of each job of the replay are cleared
+func (m *Manager) GetReplay(jobSpec models.JobSpec, replayID uuid.UUID) (models.ReplaySpec, error) {
+	return m.replaySpecRepoFac.New(jobSpec).GetByID(replayID)
+}
+
+// GetReplayList returns replays of jobs of a project, latest first
+func (m *Manager) GetReplayList(projectID uuid.UUID) ([]models.ReplaySpec, error) {
+	replays, err := m.replaySpecRepoFac.New(models.JobSpec{}).GetByProjectID(projectID)
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return []models.ReplaySpec{}, nil
+		}
+		return nil, err
+	}
+	return replays, nil
+}
+
 func (m *Manager) validate(ctx context.Context, replaySpecRepo store.ReplaySpecRepository, reqInput *models.ReplayWorkerRequest) error {
 	reqReplayTree, err := prepareTree(reqInput)
 	if err != nil {
@@ -177,6 +197,11 @@ func (m *Manager) validateRunningInstance(ctx context.Context, reqReplayNodes []
 func validateReplayJobsConflict(activeReplaySpecs []models.ReplaySpec, reqInput *models.ReplayWorkerRequest,
 	reqReplayNodes []*tree.TreeNode) error {
 	for _, activeSpec := range activeReplaySpecs {
+		// active replays of jobs outside the project of request can't
+		// clear runs of its jobs
+		if _, ok := reqInput.JobSpecMap[activeSpec.Job.Name]; !ok {
+			continue
+		}
 		activeReplayWorkerRequest := &models.ReplayWorkerRequest{
 			ID:         activeSpec.ID,
 			Job:        activeSpec.Job,
@@ -190,7 +215,9 @@ func validateReplayJobsConflict(activeReplaySpecs []models.ReplaySpec, reqInput
 			return err
 		}
 		activeNodes := activeTree.GetAllNodes()
-		return checkAnyConflictedDags(activeNodes, reqReplayNodes)
+		if err := checkAnyConflictedDags(activeNodes, reqReplayNodes); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -241,6 +241,52 @@ func TestReplayManager(t *testing.T) {
 			_, err := replayManager.Replay(ctx, replayRequest)
 			assert.Equal(t, err, job.ErrConflictedJobRun)
 		})
+		t.Run("should check every active replay of the project for conflicts", func(t *testing.T) {
+			otherStartDate, _ := time.Parse(job.ReplayDateFormat, "2021-01-01")
+			otherEndDate, _ := time.Parse(job.ReplayDateFormat, "2021-02-01")
+			activeReplaySpec := []models.ReplaySpec{
+				{
+					ID:        uuid.Must(uuid.NewRandom()),
+					Job:       models.JobSpec{ID: uuid.Must(uuid.NewRandom()), Name: "job-of-other-project", Schedule: schedule},
+					StartDate: startDate,
+					EndDate:   endDate,
+					Status:    models.ReplayStatusInProgress,
+				},
+				{
+					ID:        uuid.Must(uuid.NewRandom()),
+					Job:       jobSpec,
+					StartDate: otherStartDate,
+					EndDate:   otherEndDate,
+					Status:    models.ReplayStatusInProgress,
+				},
+				{
+					ID:        uuid.Must(uuid.NewRandom()),
+					Job:       jobSpec,
+					StartDate: startDate,
+					EndDate:   endDate,
+					Status:    models.ReplayStatusAccepted,
+				},
+			}
+
+			replayRepository := new(mock.ReplayRepository)
+			defer replayRepository.AssertExpectations(t)
+			replayRepository.On("GetByStatus", job.ReplayStatusToValidate).Return([]models.ReplaySpec{}, store.ErrResourceNotFound).Once()
+			replayRepository.On("GetByStatus", job.ReplayStatusToValidate).Return(activeReplaySpec, nil)
+
+			replaySpecRepoFac := new(mock.ReplaySpecRepoFactory)
+			defer replaySpecRepoFac.AssertExpectations(t)
+			replaySpecRepoFac.On("New", models.JobSpec{}).Return(replayRepository)
+			replaySpecRepoFac.On("New", replayRequest.Job).Return(replayRepository)
+
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
+			scheduler.On("GetDagRunStatus", ctx, replayRequest.Project, jobSpec.Name, startDate, reqBatchEndDate, reqBatchSize).Return([]models.JobStatus{}, nil)
+
+			replayManager := job.NewManager(nil, replaySpecRepoFac, nil, replayManagerConfig, scheduler)
+
+			_, err := replayManager.Replay(ctx, replayRequest)
+			assert.Equal(t, job.ErrConflictedJobRun, err)
+		})
 		t.Run("should pass replay validation when no conflicting dag found", func(t *testing.T) {
 			activeReplayUUID := uuid.Must(uuid.NewRandom())
 			activeJobSpec := jobSpec2
@@ -474,4 +520,48 @@ func TestReplayManager(t *testing.T) {
 			assert.Equal(t, errMessage, err.Error())
 		})
 	})
+	t.Run("GetReplayList", func(t *testing.T) {
+		replayManagerConfig := job.ReplayManagerConfig{
+			NumWorkers:    0,
+			WorkerTimeout: 1000,
+		}
+		projectID := uuid.Must(uuid.NewRandom())
+		t.Run("should return replays of the project", func(t *testing.T) {
+			replaySpecs := []models.ReplaySpec{
+				{
+					ID:     uuid.Must(uuid.NewRandom()),
+					Job:    models.JobSpec{Name: "job-name"},
+					Status: models.ReplayStatusSuccess,
+				},
+			}
+			replayRepository := new(mock.ReplayRepository)
+			defer replayRepository.AssertExpectations(t)
+			replayRepository.On("GetByStatus", job.ReplayStatusToValidate).Return([]models.ReplaySpec{}, store.ErrResourceNotFound).Once()
+			replayRepository.On("GetByProjectID", projectID).Return(replaySpecs, nil)
+
+			replaySpecRepoFac := new(mock.ReplaySpecRepoFactory)
+			defer replaySpecRepoFac.AssertExpectations(t)
+			replaySpecRepoFac.On("New", models.JobSpec{}).Return(replayRepository)
+
+			replayManager := job.NewManager(nil, replaySpecRepoFac, nil, replayManagerConfig, nil)
+			replays, err := replayManager.GetReplayList(projectID)
+			assert.Nil(t, err)
+			assert.Equal(t, replaySpecs, replays)
+		})
+		t.Run("should return no replays if project has none", func(t *testing.T) {
+			replayRepository := new(mock.ReplayRepository)
+			defer replayRepository.AssertExpectations(t)
+			replayRepository.On("GetByStatus", job.ReplayStatusToValidate).Return([]models.ReplaySpec{}, store.ErrResourceNotFound).Once()
+			replayRepository.On("GetByProjectID", projectID).Return([]models.ReplaySpec{}, store.ErrResourceNotFound)
+
+			replaySpecRepoFac := new(mock.ReplaySpecRepoFactory)
+			defer replaySpecRepoFac.AssertExpectations(t)
+			replaySpecRepoFac.On("New", models.JobSpec{}).Return(replayRepository)
+
+			replayManager := job.NewManager(nil, replaySpecRepoFac, nil, replayManagerConfig, nil)
+			replays, err := replayManager.GetReplayList(projectID)
+			assert.Nil(t, err)
+			assert.Empty(t, replays)
+		})
+	})
 }
@@ -3,18 +3,45 @@ package job
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/odpf/optimus/core/logger"
 
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/pkg/errors"
 )
 
 const (
 	AirflowClearDagRunFailed = "failed to clear airflow dag run"
+	// ReplayJobRunsCleared is the type of message of replays in progress,
+	// updated as runs of each job are cleared
+	ReplayJobRunsCleared = "job runs cleared"
+	// ReplayRunsThrottled is the type of message of replays waiting for
+	// cleared runs to finish before clearing more of them
+	ReplayRunsThrottled = "waiting for cleared runs"
+	// ReplayRunsWaitFailed is the type of message of replays failed while
+	// waiting for cleared runs to finish
+	ReplayRunsWaitFailed = "failed to wait for cleared runs"
+
+	// replayRunStatusBatchSize is the page size status of cleared runs are
+	// read in
+	replayRunStatusBatchSize  = 100
+	defaultReplayPollInterval = time.Minute
 )
 
+// ReplayWorkerConfig throttles runs a replay worker has the scheduler rerun
+type ReplayWorkerConfig struct {
+	// Parallelism is the number of runs of a job cleared at once, more runs
+	// are cleared only after they finish. Runs are not throttled if it
+	// is not set, projects can override it with ProjectReplayParallelism
+	Parallelism int
+	// PollInterval is how often status of cleared runs is read while
+	// waiting for them to finish
+	PollInterval time.Duration
+}
+
 type ReplayWorker interface {
 	Process(context.Context, *models.ReplayWorkerRequest) error
 }
@@ -22,6 +49,7 @@ type ReplayWorker interface {
 type replayWorker struct {
 	replaySpecRepoFac ReplaySpecRepoFactory
 	scheduler         models.SchedulerUnit
+	config            ReplayWorkerConfig
 }
 
 func (w *replayWorker) Process(ctx context.Context, input *models.ReplayWorkerRequest) (err error) {
@@ -36,20 +64,57 @@ func (w *replayWorker) Process(ctx context.Context, input *models.ReplayWorkerRe
 		return err
 	}
 
-	replayDagsMap := replayTree.GetAllNodes()
-	for _, treeNode := range replayDagsMap {
+	// runs of jobs are cleared after the ones of jobs they depend on, so
+	// the scheduler reruns them in order
+	replayDags := replayTree.GetAllNodesInTopologicalOrder()
+	parallelism := w.parallelism(input.Project)
+	lastNode := -1
+	for i, treeNode := range replayDags {
+		if treeNode.Runs.Size() > 0 {
+			lastNode = i
+		}
+	}
+	for i, treeNode := range replayDags {
+		if treeNode.Runs.Size() == 0 {
+			continue
+		}
 		runTimes := treeNode.Runs.Values()
-		startTime := runTimes[0].(time.Time)
-		endTime := runTimes[treeNode.Runs.Size()-1].(time.Time)
-		if err = w.scheduler.Clear(ctx, input.Project, treeNode.GetName(), startTime, endTime); err != nil {
-			err = errors.Wrapf(err, "error while clearing dag runs for job %s", treeNode.GetName())
-			logger.W(fmt.Sprintf("error while running replay %s: %s", input.ID.String(), err.Error()))
-			if updateStatusErr := replaySpecRepo.UpdateStatus(input.ID, models.ReplayStatusFailed, models.ReplayMessage{
-				Type:    AirflowClearDagRunFailed,
-				Message: err.Error(),
-			}); updateStatusErr != nil {
-				return updateStatusErr
+		batchSize := len(runTimes)
+		if parallelism > 0 && parallelism < batchSize {
+			batchSize = parallelism
+		}
+		for batchStart := 0; batchStart < len(runTimes); batchStart += batchSize {
+			batchEnd := batchStart + batchSize
+			if batchEnd > len(runTimes) {
+				batchEnd = len(runTimes)
+			}
+			startTime := runTimes[batchStart].(time.Time)
+			endTime := runTimes[batchEnd-1].(time.Time)
+			if err = w.scheduler.Clear(ctx, input.Project, treeNode.GetName(), startTime, endTime); err != nil {
+				err = errors.Wrapf(err, "error while clearing dag runs for job %s", treeNode.GetName())
+				return w.fail(replaySpecRepo, input, AirflowClearDagRunFailed, err)
 			}
+
+			// runs are left to finish before clearing more, the last ones
+			// of replay are not waited for
+			if parallelism <= 0 || (i == lastNode && batchEnd == len(runTimes)) {
+				continue
+			}
+			if err = replaySpecRepo.UpdateStatus(input.ID, models.ReplayStatusInProgress, models.ReplayMessage{
+				Type:    ReplayRunsThrottled,
+				Message: fmt.Sprintf("cleared %d/%d runs of %s, waiting for them to finish", batchEnd, len(runTimes), treeNode.GetName()),
+			}); err != nil {
+				return err
+			}
+			if err = w.waitForRuns(ctx, input.Project, treeNode.GetName(), startTime, endTime); err != nil {
+				err = errors.Wrapf(err, "error while waiting for dag runs of job %s", treeNode.GetName())
+				return w.fail(replaySpecRepo, input, ReplayRunsWaitFailed, err)
+			}
+		}
+		if err = replaySpecRepo.UpdateStatus(input.ID, models.ReplayStatusInProgress, models.ReplayMessage{
+			Type:    ReplayJobRunsCleared,
+			Message: fmt.Sprintf("cleared runs of %s, %d/%d jobs", treeNode.GetName(), i+1, len(replayDags)),
+		}); err != nil {
 			return err
 		}
 	}
@@ -61,6 +126,66 @@ func (w *replayWorker) Process(ctx context.Context, input *models.ReplayWorkerRe
 	return nil
 }
 
-func NewReplayWorker(replaySpecRepoFac ReplaySpecRepoFactory, scheduler models.SchedulerUnit) *replayWorker {
-	return &replayWorker{replaySpecRepoFac: replaySpecRepoFac, scheduler: scheduler}
+func (w *replayWorker) fail(replaySpecRepo store.ReplaySpecRepository, input *models.ReplayWorkerRequest, messageType string, err error) error {
+	logger.W(fmt.Sprintf("error while running replay %s: %s", input.ID.String(), err.Error()))
+	if updateStatusErr := replaySpecRepo.UpdateStatus(input.ID, models.ReplayStatusFailed, models.ReplayMessage{
+		Type:    messageType,
+		Message: err.Error(),
+	}); updateStatusErr != nil {
+		return updateStatusErr
+	}
+	return err
+}
+
+// parallelism returns the number of runs of a job replays of project clear
+// at once, it is not limited if zero
+func (w *replayWorker) parallelism(project models.ProjectSpec) int {
+	value, ok := project.Config[models.ProjectReplayParallelism]
+	if !ok {
+		return w.config.Parallelism
+	}
+	parallelism, err := strconv.Atoi(value)
+	if err != nil || parallelism < 0 {
+		logger.W(fmt.Sprintf("ignoring invalid %s of project %s: %s", models.ProjectReplayParallelism, project.Name, value))
+		return w.config.Parallelism
+	}
+	return parallelism
+}
+
+// waitForRuns blocks till runs of job scheduled between start and end,
+// both inclusive, are finished
+func (w *replayWorker) waitForRuns(ctx context.Context, project models.ProjectSpec, jobName string, start, end time.Time) error {
+	pollInterval := w.config.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultReplayPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		jobStatuses, err := w.scheduler.GetDagRunStatus(ctx, project, jobName, start, end, replayRunStatusBatchSize)
+		if err != nil {
+			return err
+		}
+		finished := true
+		for _, jobStatus := range jobStatuses {
+			if jobStatus.State != models.JobStatusStateSuccess && jobStatus.State != models.JobStatusStateFailed {
+				finished = false
+				break
+			}
+		}
+		if finished {
+			return nil
+		}
+	}
+}
+
+func NewReplayWorker(replaySpecRepoFac ReplaySpecRepoFactory, scheduler models.SchedulerUnit, config ReplayWorkerConfig) *replayWorker {
+	return &replayWorker{replaySpecRepoFac: replaySpecRepoFac, scheduler: scheduler, config: config}
 }
@@ -2,6 +2,7 @@ package job_test
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/odpf/optimus/models"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
 )
 
 func TestReplayWorker(t *testing.T) {
@@ -55,7 +57,7 @@ func TestReplayWorker(t *testing.T) {
 			defer replaySpecRepoFac.AssertExpectations(t)
 			replaySpecRepoFac.On("New", replayRequest.Job).Return(replayRepository)
 
-			worker := job.NewReplayWorker(replaySpecRepoFac, nil)
+			worker := job.NewReplayWorker(replaySpecRepoFac, nil, job.ReplayWorkerConfig{})
 			err := worker.Process(ctx, replayRequest)
 			assert.NotNil(t, err)
 			assert.Equal(t, errMessage, err.Error())
@@ -81,7 +83,7 @@ func TestReplayWorker(t *testing.T) {
 			errorMessage := "scheduler clear error"
 			scheduler.On("Clear", ctx, replayRequest.Project, "job-name", dagRunStartTime, dagRunEndTime).Return(errors.New(errorMessage))
 
-			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler)
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{})
 			err := worker.Process(ctx, replayRequest)
 			assert.NotNil(t, err)
 			assert.Contains(t, err.Error(), errorMessage)
@@ -108,7 +110,7 @@ func TestReplayWorker(t *testing.T) {
 			errorMessage := "scheduler clear error"
 			scheduler.On("Clear", ctx, replayRequest.Project, "job-name", dagRunStartTime, dagRunEndTime).Return(errors.New(errorMessage))
 
-			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler)
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{})
 			err := worker.Process(ctx, replayRequest)
 			assert.NotNil(t, err)
 			assert.Contains(t, err.Error(), updateStatusErr.Error())
@@ -128,8 +130,12 @@ func TestReplayWorker(t *testing.T) {
 			scheduler := new(mock.Scheduler)
 			defer scheduler.AssertExpectations(t)
 			scheduler.On("Clear", ctx, replayRequest.Project, "job-name", dagRunStartTime, dagRunEndTime).Return(nil)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{
+				Type:    job.ReplayJobRunsCleared,
+				Message: "cleared runs of job-name, 1/1 jobs",
+			}).Return(nil)
 
-			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler)
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{})
 			err := worker.Process(ctx, replayRequest)
 			assert.NotNil(t, err)
 			assert.Contains(t, err.Error(), updateSuccessStatusErr.Error())
@@ -147,11 +153,161 @@ func TestReplayWorker(t *testing.T) {
 			scheduler := new(mock.Scheduler)
 			defer scheduler.AssertExpectations(t)
 			scheduler.On("Clear", ctx, replayRequest.Project, "job-name", dagRunStartTime, dagRunEndTime).Return(nil)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{
+				Type:    job.ReplayJobRunsCleared,
+				Message: "cleared runs of job-name, 1/1 jobs",
+			}).Return(nil)
 
-			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler)
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{})
 			err := worker.Process(ctx, replayRequest)
 			assert.Nil(t, err)
 		})
+		t.Run("should clear runs of jobs after the ones they depend on", func(t *testing.T) {
+			ctx := context.Background()
+			oneDayTaskWindow := models.JobSpecTask{
+				Window: models.JobSpecTaskWindow{
+					Size: time.Hour * 24,
+				},
+			}
+			directSpec := models.JobSpec{
+				Name:     "job-direct",
+				Schedule: jobSpec.Schedule,
+				Task:     oneDayTaskWindow,
+				Dependencies: map[string]models.JobSpecDependency{
+					"job-name": {Job: &jobSpec, Type: models.JobSpecDependencyTypeIntra},
+				},
+			}
+			indirectSpec := models.JobSpec{
+				Name:     "job-indirect",
+				Schedule: jobSpec.Schedule,
+				Task:     oneDayTaskWindow,
+				Dependencies: map[string]models.JobSpecDependency{
+					"job-name":   {Job: &jobSpec, Type: models.JobSpecDependencyTypeIntra},
+					"job-direct": {Job: &directSpec, Type: models.JobSpecDependencyTypeIntra},
+				},
+			}
+			treeRequest := &models.ReplayWorkerRequest{
+				ID:      currUUID,
+				Job:     jobSpec,
+				Start:   startDate,
+				End:     endDate,
+				Project: replayRequest.Project,
+				JobSpecMap: map[string]models.JobSpec{
+					"job-name":     jobSpec,
+					"job-direct":   directSpec,
+					"job-indirect": indirectSpec,
+				},
+			}
+
+			replayRepository := new(mock.ReplayRepository)
+			defer replayRepository.AssertExpectations(t)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{}).Return(nil)
+			for i, name := range []string{"job-name", "job-direct", "job-indirect"} {
+				replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{
+					Type:    job.ReplayJobRunsCleared,
+					Message: fmt.Sprintf("cleared runs of %s, %d/3 jobs", name, i+1),
+				}).Return(nil).Once()
+			}
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusSuccess, models.ReplayMessage{}).Return(nil)
+
+			replaySpecRepoFac := new(mock.ReplaySpecRepoFactory)
+			replaySpecRepoFac.On("New", jobSpec).Return(replayRepository)
+
+			var cleared []string
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
+			scheduler.On("Clear", ctx, replayRequest.Project, mock2.AnythingOfType("string"), dagRunStartTime, dagRunEndTime).
+				Run(func(args mock2.Arguments) {
+					cleared = append(cleared, args.String(2))
+				}).Return(nil)
+
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{})
+			err := worker.Process(ctx, treeRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-name", "job-direct", "job-indirect"}, cleared)
+		})
+		t.Run("should clear runs in batches of project parallelism and wait for each to finish", func(t *testing.T) {
+			ctx := context.Background()
+			throttledRequest := *replayRequest
+			throttledRequest.Project = models.ProjectSpec{
+				Name: "project-name",
+				Config: map[string]string{
+					models.ProjectReplayParallelism: "2",
+				},
+			}
+			day := time.Hour * 24
+
+			replayRepository := new(mock.ReplayRepository)
+			defer replayRepository.AssertExpectations(t)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{}).Return(nil)
+			for _, cleared := range []int{2, 4} {
+				replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{
+					Type:    job.ReplayRunsThrottled,
+					Message: fmt.Sprintf("cleared %d/5 runs of job-name, waiting for them to finish", cleared),
+				}).Return(nil).Once()
+			}
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{
+				Type:    job.ReplayJobRunsCleared,
+				Message: "cleared runs of job-name, 1/1 jobs",
+			}).Return(nil)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusSuccess, models.ReplayMessage{}).Return(nil)
+
+			replaySpecRepoFac := new(mock.ReplaySpecRepoFactory)
+			defer replaySpecRepoFac.AssertExpectations(t)
+			replaySpecRepoFac.On("New", replayRequest.Job).Return(replayRepository)
+
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
+			scheduler.On("Clear", ctx, throttledRequest.Project, "job-name", dagRunStartTime, dagRunStartTime.Add(day)).Return(nil).Once()
+			scheduler.On("GetDagRunStatus", ctx, throttledRequest.Project, "job-name", dagRunStartTime, dagRunStartTime.Add(day), 100).Return([]models.JobStatus{
+				{ScheduledAt: dagRunStartTime, State: models.JobStatusStateSuccess},
+				{ScheduledAt: dagRunStartTime.Add(day), State: models.JobStatusStateRunning},
+			}, nil).Once()
+			scheduler.On("GetDagRunStatus", ctx, throttledRequest.Project, "job-name", dagRunStartTime, dagRunStartTime.Add(day), 100).Return([]models.JobStatus{
+				{ScheduledAt: dagRunStartTime, State: models.JobStatusStateSuccess},
+				{ScheduledAt: dagRunStartTime.Add(day), State: models.JobStatusStateFailed},
+			}, nil).Once()
+			scheduler.On("Clear", ctx, throttledRequest.Project, "job-name", dagRunStartTime.Add(day*2), dagRunStartTime.Add(day*3)).Return(nil).Once()
+			scheduler.On("GetDagRunStatus", ctx, throttledRequest.Project, "job-name", dagRunStartTime.Add(day*2), dagRunStartTime.Add(day*3), 100).Return([]models.JobStatus{
+				{ScheduledAt: dagRunStartTime.Add(day * 2), State: models.JobStatusStateSuccess},
+				{ScheduledAt: dagRunStartTime.Add(day * 3), State: models.JobStatusStateSuccess},
+			}, nil).Once()
+			scheduler.On("Clear", ctx, throttledRequest.Project, "job-name", dagRunEndTime, dagRunEndTime).Return(nil).Once()
+
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{PollInterval: time.Millisecond})
+			err := worker.Process(ctx, &throttledRequest)
+			assert.Nil(t, err)
+		})
+		t.Run("should fail replay if status of cleared runs can't be read", func(t *testing.T) {
+			ctx := context.Background()
+			day := time.Hour * 24
+
+			replayRepository := new(mock.ReplayRepository)
+			defer replayRepository.AssertExpectations(t)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{}).Return(nil)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{
+				Type:    job.ReplayRunsThrottled,
+				Message: "cleared 3/5 runs of job-name, waiting for them to finish",
+			}).Return(nil)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusFailed, models.ReplayMessage{
+				Type:    job.ReplayRunsWaitFailed,
+				Message: "error while waiting for dag runs of job job-name: scheduler is unreachable",
+			}).Return(nil)
+
+			replaySpecRepoFac := new(mock.ReplaySpecRepoFactory)
+			defer replaySpecRepoFac.AssertExpectations(t)
+			replaySpecRepoFac.On("New", replayRequest.Job).Return(replayRepository)
+
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
+			scheduler.On("Clear", ctx, replayRequest.Project, "job-name", dagRunStartTime, dagRunStartTime.Add(day*2)).Return(nil)
+			scheduler.On("GetDagRunStatus", ctx, replayRequest.Project, "job-name", dagRunStartTime, dagRunStartTime.Add(day*2), 100).
+				Return([]models.JobStatus{}, errors.New("scheduler is unreachable"))
+
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{Parallelism: 3, PollInterval: time.Millisecond})
+			err := worker.Process(ctx, replayRequest)
+			assert.NotNil(t, err)
+		})
 		t.Run("should throw an error when prepareTree throws an error", func(t *testing.T) {
 			replayRequest.JobSpecMap = make(map[string]models.JobSpec)
 			ctx := context.Background()
@@ -166,7 +322,7 @@ func TestReplayWorker(t *testing.T) {
 			scheduler := new(mock.Scheduler)
 			defer scheduler.AssertExpectations(t)
 
-			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler)
+			worker := job.NewReplayWorker(replaySpecRepoFac, scheduler, job.ReplayWorkerConfig{})
 			err := worker.Process(ctx, replayRequest)
 			assert.NotNil(t, err)
 		})
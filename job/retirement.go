@@ -0,0 +1,137 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+// RetirementSweeper periodically looks for jobs whose schedule has ended
+// but are still deployed to the scheduler, and syncs their namespaces so
+// the retired jobs are removed from it
+type RetirementSweeper struct {
+	wg   sync.WaitGroup
+	done chan struct{}
+
+	jobSvc               models.JobService
+	projectRepoFactory   ProjectRepoFactory
+	namespaceRepoFactory NamespaceRepoFactory
+	scheduler            models.SchedulerUnit
+	progressObserver     progress.Observer
+
+	// grace is how long jobs keep being scheduled after their end date
+	grace    time.Duration
+	interval time.Duration
+
+	Now func() time.Time
+}
+
+// Sweep syncs every namespace having retired jobs still deployed
+func (s *RetirementSweeper) Sweep(ctx context.Context) error {
+	projects, err := s.projectRepoFactory.New().GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch projects")
+	}
+
+	var sweepErrors error
+	for _, projectSpec := range projects {
+		namespaces, err := s.namespaceRepoFactory.New(projectSpec).GetAll()
+		if err != nil {
+			sweepErrors = multierror.Append(sweepErrors, errors.Wrapf(err, "failed to fetch namespaces of %s", projectSpec.Name))
+			continue
+		}
+		for _, namespace := range namespaces {
+			if err := s.sweepNamespace(ctx, namespace); err != nil {
+				sweepErrors = multierror.Append(sweepErrors, errors.Wrapf(err, "failed to retire jobs of %s/%s",
+					projectSpec.Name, namespace.Name))
+			}
+		}
+	}
+	return sweepErrors
+}
+
+func (s *RetirementSweeper) sweepNamespace(ctx context.Context, namespace models.NamespaceSpec) error {
+	jobSpecs, err := s.jobSvc.GetAll(namespace)
+	if err != nil {
+		return err
+	}
+	retiredAt := s.Now().Add(-s.grace)
+	var retired []string
+	for _, jobSpec := range jobSpecs {
+		if jobSpec.Schedule.HasEnded(retiredAt) {
+			retired = append(retired, jobSpec.Name)
+		}
+	}
+	if len(retired) == 0 {
+		return nil
+	}
+
+	deployedNames, err := s.scheduler.ListJobs(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	// retired jobs already removed from scheduler need no sync
+	deployedRetired := setSubstract(retired, setSubstract(retired, deployedNames))
+	if len(deployedRetired) == 0 {
+		return nil
+	}
+
+	logger.I(fmt.Sprintf("retiring jobs %v of %s/%s", deployedRetired, namespace.ProjectSpec.Name, namespace.Name))
+	return s.jobSvc.Sync(ctx, namespace, s.progressObserver)
+}
+
+func (s *RetirementSweeper) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+			if err := s.Sweep(ctx); err != nil {
+				logger.E(errors.Wrap(err, "failed to sweep retired jobs"))
+			}
+			cancel()
+		}
+	}
+}
+
+// Close stops sweeping and waits for a running sweep to finish
+func (s *RetirementSweeper) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
+
+// NewRetirementSweeper constructs a sweeper retiring jobs grace duration after
+// their end date, sweeps run every interval and are not scheduled if it is not set
+func NewRetirementSweeper(jobSvc models.JobService, projectRepoFactory ProjectRepoFactory,
+	namespaceRepoFactory NamespaceRepoFactory, scheduler models.SchedulerUnit, progressObserver progress.Observer,
+	grace, interval time.Duration) *RetirementSweeper {
+	sweeper := &RetirementSweeper{
+		done:                 make(chan struct{}),
+		jobSvc:               jobSvc,
+		projectRepoFactory:   projectRepoFactory,
+		namespaceRepoFactory: namespaceRepoFactory,
+		scheduler:            scheduler,
+		progressObserver:     progressObserver,
+		grace:                grace,
+		interval:             interval,
+		Now:                  time.Now,
+	}
+	if interval > 0 {
+		sweeper.wg.Add(1)
+		go sweeper.run()
+	}
+	return sweeper
+}
@@ -0,0 +1,125 @@
+package job_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetirementSweeper(t *testing.T) {
+	logger.InitWithWriter(logger.DEBUG, ioutil.Discard)
+	ctx := context.Background()
+
+	projectSpec := models.ProjectSpec{
+		Name: "proj",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	endDate := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobSpecs := []models.JobSpec{
+		{
+			Name: "active",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+				Interval:  "@daily",
+			},
+		},
+		{
+			Name: "ended",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+				EndDate:   &endDate,
+				Interval:  "@daily",
+			},
+		},
+	}
+
+	newRepoFactories := func() (*mock.ProjectRepoFactory, *mock.NamespaceRepoFactory) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetAll").Return([]models.ProjectSpec{projectSpec}, nil)
+		projectRepoFac := new(mock.ProjectRepoFactory)
+		projectRepoFac.On("New").Return(projectRepo)
+
+		namespaceRepo := new(mock.NamespaceRepository)
+		namespaceRepo.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+		namespaceRepoFac := new(mock.NamespaceRepoFactory)
+		namespaceRepoFac.On("New", projectSpec).Return(namespaceRepo)
+		return projectRepoFac, namespaceRepoFac
+	}
+
+	t.Run("Sweep", func(t *testing.T) {
+		t.Run("should sync namespaces with retired jobs still deployed", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"active", "ended"}, nil)
+			defer scheduler.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return(jobSpecs, nil)
+			jobSvc.On("Sync", ctx, namespaceSpec, nil).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			sweeper := job.NewRetirementSweeper(jobSvc, projectRepoFac, namespaceRepoFac, scheduler, nil, 24*time.Hour, 0)
+			sweeper.Now = func() time.Time { return endDate.Add(36 * time.Hour) }
+			assert.Nil(t, sweeper.Sweep(ctx))
+			assert.Nil(t, sweeper.Close())
+		})
+		t.Run("should not sync namespaces when retired jobs are already removed", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"active"}, nil)
+			defer scheduler.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return(jobSpecs, nil)
+			defer jobSvc.AssertExpectations(t)
+
+			sweeper := job.NewRetirementSweeper(jobSvc, projectRepoFac, namespaceRepoFac, scheduler, nil, 24*time.Hour, 0)
+			sweeper.Now = func() time.Time { return endDate.Add(36 * time.Hour) }
+			assert.Nil(t, sweeper.Sweep(ctx))
+		})
+		t.Run("should not look for deployed jobs within retirement grace", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return(jobSpecs, nil)
+			defer jobSvc.AssertExpectations(t)
+
+			sweeper := job.NewRetirementSweeper(jobSvc, projectRepoFac, namespaceRepoFac, scheduler, nil, 24*time.Hour, 0)
+			sweeper.Now = func() time.Time { return endDate.Add(12 * time.Hour) }
+			assert.Nil(t, sweeper.Sweep(ctx))
+		})
+		t.Run("should return error when a namespace fails to sync", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"ended"}, nil)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetAll", namespaceSpec).Return(jobSpecs, nil)
+			jobSvc.On("Sync", ctx, namespaceSpec, nil).Return(errors.New("scheduler unavailable"))
+
+			sweeper := job.NewRetirementSweeper(jobSvc, projectRepoFac, namespaceRepoFac, scheduler, nil, 24*time.Hour, 0)
+			sweeper.Now = func() time.Time { return endDate.Add(36 * time.Hour) }
+			err := sweeper.Sweep(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "scheduler unavailable")
+		})
+	})
+}
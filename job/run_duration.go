@@ -0,0 +1,165 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// RunDurationStat is the number of successful runs of a project checked
+// against their job's history, and the ones found slow
+type RunDurationStat struct {
+	Checked int64 `json:"checked"`
+	Slow    int64 `json:"slow"`
+}
+
+// RunDurationStats keeps stats of run durations keyed by project, stats are
+// exported as json through expvar
+type RunDurationStats struct {
+	mu    sync.Mutex
+	stats map[string]RunDurationStat
+}
+
+// Get returns stats of project
+func (s *RunDurationStats) Get(projectName string) RunDurationStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats[projectName]
+}
+
+func (s *RunDurationStats) add(projectName string, slow bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stat := s.stats[projectName]
+	stat.Checked++
+	if slow {
+		stat.Slow++
+	}
+	s.stats[projectName] = stat
+}
+
+// String renders stats as json, it makes RunDurationStats an expvar.Var
+func (s *RunDurationStats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := json.Marshal(s.stats)
+	if err != nil {
+		return "{}"
+	}
+	return string(raw)
+}
+
+func NewRunDurationStats() *RunDurationStats {
+	return &RunDurationStats{
+		stats: map[string]RunDurationStat{},
+	}
+}
+
+// RunDurationChecker compares the duration of successful runs with the
+// median of previous successful runs of their job, and raises a slow_run
+// event for runs taking factor times longer. It lets owners catch
+// regressions, e.g. from data growth or a bad query, before slas are missed
+type RunDurationChecker struct {
+	jobRunRepoFactory JobRunRepoFactory
+	eventService      JobEventService
+
+	// factor is how many times the median a run may take
+	factor float64
+	// window is the number of latest runs the median is taken over
+	window int
+	// minRuns is the least number of previous runs needed for a median
+	minRuns int
+
+	Stats *RunDurationStats
+}
+
+// Check raises a slow_run event if run took unusually long, runs which
+// haven't succeeded or don't know their start and end are skipped
+func (c *RunDurationChecker) Check(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec,
+	run models.JobRun) error {
+	if c.factor <= 0 || run.State != models.JobStatusStateSuccess || run.StartTime.IsZero() || !run.EndTime.After(run.StartTime) {
+		return nil
+	}
+
+	previousRuns, err := c.jobRunRepoFactory.New(namespace.ProjectSpec).GetAll(models.JobRunFilter{
+		JobName: jobSpec.Name,
+		EndDate: run.ScheduledAt.Add(-time.Second),
+		States:  []models.JobStatusState{models.JobStatusStateSuccess},
+		Limit:   c.window,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch previous runs")
+	}
+	var durations []time.Duration
+	for _, previousRun := range previousRuns {
+		if previousRun.StartTime.IsZero() || !previousRun.EndTime.After(previousRun.StartTime) {
+			continue
+		}
+		durations = append(durations, previousRun.EndTime.Sub(previousRun.StartTime))
+	}
+	if len(durations) == 0 || len(durations) < c.minRuns {
+		return nil
+	}
+
+	duration := run.EndTime.Sub(run.StartTime)
+	median := medianDuration(durations)
+	slow := float64(duration) > c.factor*float64(median)
+	c.Stats.add(namespace.ProjectSpec.Name, slow)
+	if !slow {
+		return nil
+	}
+
+	logger.W(fmt.Sprintf("run of job %s scheduled at %s took %s, over %g times the median %s of its previous runs",
+		jobSpec.Name, run.ScheduledAt.UTC().Format(time.RFC3339), duration, c.factor, median))
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"scheduled_at": run.ScheduledAt.UTC().Format(slaEventTimeLayout),
+		"duration":     duration.String(),
+		"median":       median.String(),
+		"factor":       c.factor,
+		"runs":         len(durations),
+	})
+	if err != nil {
+		return err
+	}
+	return c.eventService.Register(ctx, namespace, jobSpec, models.JobEvent{
+		Type:  models.JobEventTypeSlowRun,
+		Value: value.GetFields(),
+	})
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// NewRunDurationChecker constructs a checker raising slow_run events for
+// runs taking over factor times the median of the latest window successful
+// runs of their job, jobs with less than minRuns of them are not checked.
+// Runs are not checked if factor is not set
+func NewRunDurationChecker(jobRunRepoFactory JobRunRepoFactory, eventService JobEventService,
+	factor float64, window, minRuns int) *RunDurationChecker {
+	return &RunDurationChecker{
+		jobRunRepoFactory: jobRunRepoFactory,
+		eventService:      eventService,
+		factor:            factor,
+		window:            window,
+		minRuns:           minRuns,
+		Stats:             NewRunDurationStats(),
+	}
+}
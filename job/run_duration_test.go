@@ -0,0 +1,119 @@
+package job_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
+)
+
+func TestRunDurationChecker(t *testing.T) {
+	logger.InitWithWriter(logger.DEBUG, ioutil.Discard)
+	ctx := context.Background()
+
+	projectSpec := models.ProjectSpec{
+		Name: "proj",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	jobSpec := models.JobSpec{
+		Name: "job-1",
+	}
+	scheduledAt := time.Date(2021, 1, 10, 2, 0, 0, 0, time.UTC)
+	historyFilter := models.JobRunFilter{
+		JobName: jobSpec.Name,
+		EndDate: scheduledAt.Add(-time.Second),
+		States:  []models.JobStatusState{models.JobStatusStateSuccess},
+		Limit:   20,
+	}
+	// previous runs took 10, 20 and 30 minutes
+	var history []models.JobRun
+	for i, minutes := range []int{30, 10, 20} {
+		previousScheduledAt := scheduledAt.Add(-time.Duration(3-i) * 24 * time.Hour)
+		history = append(history, models.JobRun{
+			JobName:     jobSpec.Name,
+			ScheduledAt: previousScheduledAt,
+			State:       models.JobStatusStateSuccess,
+			StartTime:   previousScheduledAt,
+			EndTime:     previousScheduledAt.Add(time.Duration(minutes) * time.Minute),
+		})
+	}
+	newRun := func(duration time.Duration) models.JobRun {
+		return models.JobRun{
+			JobName:     jobSpec.Name,
+			ScheduledAt: scheduledAt,
+			State:       models.JobStatusStateSuccess,
+			StartTime:   scheduledAt,
+			EndTime:     scheduledAt.Add(duration),
+		}
+	}
+	newChecker := func(jobRunRepo *mock.JobRunRepository, eventService *mock.EventService, minRuns int) *job.RunDurationChecker {
+		jobRunRepoFac := new(mock.JobRunRepoFactory)
+		jobRunRepoFac.On("New", projectSpec).Return(jobRunRepo)
+		return job.NewRunDurationChecker(jobRunRepoFac, eventService, 3, 20, minRuns)
+	}
+
+	t.Run("Check", func(t *testing.T) {
+		t.Run("should raise slow run event for runs taking over factor times the median", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", historyFilter).Return(history, nil)
+			defer jobRunRepo.AssertExpectations(t)
+
+			eventService := new(mock.EventService)
+			eventService.On("Register", ctx, namespaceSpec, jobSpec, mock2.MatchedBy(func(evt models.JobEvent) bool {
+				return evt.Type == models.JobEventTypeSlowRun &&
+					evt.Value["scheduled_at"].GetStringValue() == "2021-01-10T02:00:00Z" &&
+					evt.Value["duration"].GetStringValue() == "1h1m0s" &&
+					evt.Value["median"].GetStringValue() == "20m0s" &&
+					evt.Value["factor"].GetNumberValue() == 3
+			})).Return(nil).Once()
+			defer eventService.AssertExpectations(t)
+
+			checker := newChecker(jobRunRepo, eventService, 3)
+			assert.Nil(t, checker.Check(ctx, namespaceSpec, jobSpec, newRun(61*time.Minute)))
+			assert.Equal(t, job.RunDurationStat{Checked: 1, Slow: 1}, checker.Stats.Get(projectSpec.Name))
+		})
+		t.Run("should not raise slow run event for runs within factor times the median", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", historyFilter).Return(history, nil)
+			eventService := new(mock.EventService)
+			defer eventService.AssertExpectations(t)
+
+			checker := newChecker(jobRunRepo, eventService, 3)
+			assert.Nil(t, checker.Check(ctx, namespaceSpec, jobSpec, newRun(time.Hour)))
+			assert.Equal(t, job.RunDurationStat{Checked: 1}, checker.Stats.Get(projectSpec.Name))
+		})
+		t.Run("should not check runs of jobs without enough history", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", historyFilter).Return(history, nil)
+			eventService := new(mock.EventService)
+			defer eventService.AssertExpectations(t)
+
+			checker := newChecker(jobRunRepo, eventService, 5)
+			assert.Nil(t, checker.Check(ctx, namespaceSpec, jobSpec, newRun(10*time.Hour)))
+			assert.Equal(t, job.RunDurationStat{}, checker.Stats.Get(projectSpec.Name))
+		})
+		t.Run("should skip runs which did not succeed", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			defer jobRunRepo.AssertExpectations(t)
+			eventService := new(mock.EventService)
+			defer eventService.AssertExpectations(t)
+
+			run := newRun(10 * time.Hour)
+			run.State = models.JobStatusStateFailed
+			checker := newChecker(jobRunRepo, eventService, 3)
+			assert.Nil(t, checker.Check(ctx, namespaceSpec, jobSpec, run))
+		})
+	})
+}
@@ -0,0 +1,170 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+// RunPoller periodically pulls states of runs of jobs deployed to the
+// scheduler and keeps them as run history, so runs can be read even when
+// the scheduler is unavailable
+type RunPoller struct {
+	wg   sync.WaitGroup
+	done chan struct{}
+
+	projectRepoFactory   ProjectRepoFactory
+	namespaceRepoFactory NamespaceRepoFactory
+	jobRunRepoFactory    JobRunRepoFactory
+	scheduler            models.SchedulerUnit
+
+	interval time.Duration
+
+	Now func() time.Time
+}
+
+// Poll records runs of every deployed job which are new or whose state
+// changed since the last poll
+func (p *RunPoller) Poll(ctx context.Context) error {
+	projects, err := p.projectRepoFactory.New().GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch projects")
+	}
+
+	var pollErrors error
+	for _, projectSpec := range projects {
+		namespaces, err := p.namespaceRepoFactory.New(projectSpec).GetAll()
+		if err != nil {
+			pollErrors = multierror.Append(pollErrors, errors.Wrapf(err, "failed to fetch namespaces of %s", projectSpec.Name))
+			continue
+		}
+		jobRunRepo := p.jobRunRepoFactory.New(projectSpec)
+		for _, namespace := range namespaces {
+			if err := p.pollNamespace(ctx, jobRunRepo, namespace); err != nil {
+				pollErrors = multierror.Append(pollErrors, errors.Wrapf(err, "failed to poll runs of %s/%s",
+					projectSpec.Name, namespace.Name))
+			}
+		}
+	}
+	return pollErrors
+}
+
+func (p *RunPoller) pollNamespace(ctx context.Context, jobRunRepo store.JobRunRepository, namespace models.NamespaceSpec) error {
+	deployedNames, err := p.scheduler.ListJobs(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	var pollErrors error
+	for _, jobName := range deployedNames {
+		if err := p.pollJob(ctx, jobRunRepo, namespace, jobName); err != nil {
+			pollErrors = multierror.Append(pollErrors, errors.Wrapf(err, "failed to poll runs of job %s", jobName))
+		}
+	}
+	return pollErrors
+}
+
+func (p *RunPoller) pollJob(ctx context.Context, jobRunRepo store.JobRunRepository, namespace models.NamespaceSpec,
+	jobName string) error {
+	statuses, err := p.scheduler.GetJobStatus(ctx, namespace.ProjectSpec, jobName)
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	// only history overlapping with runs known to scheduler can change
+	oldest := statuses[0].ScheduledAt
+	for _, status := range statuses {
+		if status.ScheduledAt.Before(oldest) {
+			oldest = status.ScheduledAt
+		}
+	}
+	knownRuns, err := jobRunRepo.GetAll(models.JobRunFilter{
+		JobName:   jobName,
+		StartDate: oldest,
+	})
+	if err != nil {
+		return err
+	}
+	known := map[int64]models.JobRun{}
+	for _, run := range knownRuns {
+		known[run.ScheduledAt.Unix()] = run
+	}
+
+	now := p.Now()
+	for _, status := range statuses {
+		run, ok := known[status.ScheduledAt.Unix()]
+		if ok && run.State == status.State && run.Namespace.ID == namespace.ID {
+			continue
+		}
+		if !ok {
+			run = models.JobRun{
+				JobName:     jobName,
+				ScheduledAt: status.ScheduledAt,
+				CreatedAt:   now,
+			}
+		}
+		run.Namespace = namespace
+		run.State = status.State
+		run.UpdatedAt = now
+		if err := jobRunRepo.Save(run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *RunPoller) run() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+			if err := p.Poll(ctx); err != nil {
+				logger.E(errors.Wrap(err, "failed to poll job runs"))
+			}
+			cancel()
+		}
+	}
+}
+
+// Close stops polling and waits for a running poll to finish
+func (p *RunPoller) Close() error {
+	close(p.done)
+	p.wg.Wait()
+	return nil
+}
+
+// NewRunPoller constructs a poller recording runs of deployed jobs in their
+// project's run history, polls run every interval and are not scheduled if
+// it is not set
+func NewRunPoller(projectRepoFactory ProjectRepoFactory, namespaceRepoFactory NamespaceRepoFactory,
+	jobRunRepoFactory JobRunRepoFactory, scheduler models.SchedulerUnit, interval time.Duration) *RunPoller {
+	poller := &RunPoller{
+		done:                 make(chan struct{}),
+		projectRepoFactory:   projectRepoFactory,
+		namespaceRepoFactory: namespaceRepoFactory,
+		jobRunRepoFactory:    jobRunRepoFactory,
+		scheduler:            scheduler,
+		interval:             interval,
+		Now:                  time.Now,
+	}
+	if interval > 0 {
+		poller.wg.Add(1)
+		go poller.run()
+	}
+	return poller
+}
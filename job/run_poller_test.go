@@ -0,0 +1,135 @@
+package job_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunPoller(t *testing.T) {
+	logger.InitWithWriter(logger.DEBUG, ioutil.Discard)
+	ctx := context.Background()
+
+	projectSpec := models.ProjectSpec{
+		Name: "proj",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	scheduledAt := time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC)
+	polledAt := time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	newRepoFactories := func() (*mock.ProjectRepoFactory, *mock.NamespaceRepoFactory) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetAll").Return([]models.ProjectSpec{projectSpec}, nil)
+		projectRepoFac := new(mock.ProjectRepoFactory)
+		projectRepoFac.On("New").Return(projectRepo)
+
+		namespaceRepo := new(mock.NamespaceRepository)
+		namespaceRepo.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+		namespaceRepoFac := new(mock.NamespaceRepoFactory)
+		namespaceRepoFac.On("New", projectSpec).Return(namespaceRepo)
+		return projectRepoFac, namespaceRepoFac
+	}
+
+	t.Run("Poll", func(t *testing.T) {
+		t.Run("should save runs of deployed jobs which are new or changed state", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"job-1"}, nil)
+			scheduler.On("GetJobStatus", ctx, projectSpec, "job-1").Return([]models.JobStatus{
+				{ScheduledAt: scheduledAt, State: models.JobStatusStateSuccess},
+				{ScheduledAt: scheduledAt.Add(time.Hour * 24), State: models.JobStatusStateSuccess},
+				{ScheduledAt: scheduledAt.Add(time.Hour * 48), State: models.JobStatusStateRunning},
+			}, nil)
+			defer scheduler.AssertExpectations(t)
+
+			unchanged := models.JobRun{
+				ID:          uuid.Must(uuid.NewRandom()),
+				JobName:     "job-1",
+				Namespace:   namespaceSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.JobStatusStateSuccess,
+			}
+			changed := models.JobRun{
+				ID:          uuid.Must(uuid.NewRandom()),
+				JobName:     "job-1",
+				Namespace:   namespaceSpec,
+				ScheduledAt: scheduledAt.Add(time.Hour * 24),
+				State:       models.JobStatusStateRunning,
+			}
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", models.JobRunFilter{JobName: "job-1", StartDate: scheduledAt}).
+				Return([]models.JobRun{unchanged, changed}, nil)
+			succeeded := changed
+			succeeded.State = models.JobStatusStateSuccess
+			succeeded.UpdatedAt = polledAt
+			jobRunRepo.On("Save", succeeded).Return(nil)
+			jobRunRepo.On("Save", models.JobRun{
+				JobName:     "job-1",
+				Namespace:   namespaceSpec,
+				ScheduledAt: scheduledAt.Add(time.Hour * 48),
+				State:       models.JobStatusStateRunning,
+				CreatedAt:   polledAt,
+				UpdatedAt:   polledAt,
+			}).Return(nil)
+			defer jobRunRepo.AssertExpectations(t)
+
+			jobRunRepoFac := new(mock.JobRunRepoFactory)
+			jobRunRepoFac.On("New", projectSpec).Return(jobRunRepo)
+			defer jobRunRepoFac.AssertExpectations(t)
+
+			poller := job.NewRunPoller(projectRepoFac, namespaceRepoFac, jobRunRepoFac, scheduler, 0)
+			poller.Now = func() time.Time { return polledAt }
+			assert.Nil(t, poller.Poll(ctx))
+			jobRunRepo.AssertNumberOfCalls(t, "Save", 2)
+			assert.Nil(t, poller.Close())
+		})
+		t.Run("should keep polling other jobs when scheduler fails for a job", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories()
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"job-1", "job-2"}, nil)
+			scheduler.On("GetJobStatus", ctx, projectSpec, "job-1").Return([]models.JobStatus{}, errors.New("airflow down"))
+			scheduler.On("GetJobStatus", ctx, projectSpec, "job-2").Return([]models.JobStatus{
+				{ScheduledAt: scheduledAt, State: models.JobStatusStateFailed},
+			}, nil)
+			defer scheduler.AssertExpectations(t)
+
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", models.JobRunFilter{JobName: "job-2", StartDate: scheduledAt}).
+				Return([]models.JobRun{}, nil)
+			jobRunRepo.On("Save", models.JobRun{
+				JobName:     "job-2",
+				Namespace:   namespaceSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.JobStatusStateFailed,
+				CreatedAt:   polledAt,
+				UpdatedAt:   polledAt,
+			}).Return(nil)
+			defer jobRunRepo.AssertExpectations(t)
+
+			jobRunRepoFac := new(mock.JobRunRepoFactory)
+			jobRunRepoFac.On("New", projectSpec).Return(jobRunRepo)
+
+			poller := job.NewRunPoller(projectRepoFac, namespaceRepoFac, jobRunRepoFac, scheduler, 0)
+			poller.Now = func() time.Time { return polledAt }
+			err := poller.Poll(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "airflow down")
+			assert.Nil(t, poller.Close())
+		})
+	})
+}
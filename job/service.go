@@ -3,6 +3,7 @@ package job
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,9 +11,11 @@ import (
 
 	"github.com/kushsharma/parallel"
 	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/core/tree"
 	"github.com/odpf/optimus/meta"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/utils"
 	"github.com/pkg/errors"
 )
 
@@ -24,6 +27,11 @@ const (
 	ConcurrentLimit        = 600
 )
 
+var (
+	// ErrDuplicateDestination is thrown when multiple jobs write to the same destination
+	ErrDuplicateDestination = errors.New("duplicate job destination")
+)
+
 type AssetCompiler func(jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error)
 
 // DependencyResolver compiles static and runtime dependencies
@@ -42,40 +50,61 @@ type ProjectJobSpecRepoFactory interface {
 	New(proj models.ProjectSpec) store.ProjectJobSpecRepository
 }
 
+// ProjectRepoFactory is used to read registered projects
+type ProjectRepoFactory interface {
+	New() store.ProjectRepository
+}
+
 // NamespaceRepoFactory is used to store job specs
 type NamespaceRepoFactory interface {
 	New(spec models.ProjectSpec) store.NamespaceRepository
 }
 
-// JobRepoFactory is used to store compiled jobs
-type JobRepoFactory interface {
-	New(context.Context, models.ProjectSpec) (store.JobRepository, error)
-}
-
 // ReplaySpecRepoFactory is used to manage replay spec objects from store
 type ReplaySpecRepoFactory interface {
 	New(jobSpec models.JobSpec) store.ReplaySpecRepository
 }
 
+// DeploymentRepoFactory is used to manage deployment history of a project
+type DeploymentRepoFactory interface {
+	New(projectSpec models.ProjectSpec) store.DeploymentRepository
+}
+
+// JobRunRepoFactory is used to manage run history of jobs of a project
+type JobRunRepoFactory interface {
+	New(projectSpec models.ProjectSpec) store.JobRunRepository
+}
+
 // Service compiles all jobs with its dependencies, priority and
 // and other properties. Finally, it syncs the jobs with corresponding
 // store
 type Service struct {
 	jobSpecRepoFactory        SpecRepoFactory
 	compiler                  models.JobCompiler
-	jobRepoFactory            JobRepoFactory
+	scheduler                 models.SchedulerUnit
 	dependencyResolver        DependencyResolver
 	priorityResolver          PriorityResolver
 	metaSvcFactory            meta.MetaSvcFactory
 	projectJobSpecRepoFactory ProjectJobSpecRepoFactory
 	replayManager             ReplayManager
 
+	// RetirementGrace is how long jobs keep being scheduled after
+	// their end date before they are retired on sync
+	RetirementGrace time.Duration
+
+	// WarnDuplicateDestination only notifies about jobs writing to the
+	// same destination instead of failing check and sync
+	WarnDuplicateDestination bool
+
 	Now           func() time.Time
 	assetCompiler AssetCompiler
 }
 
 // Create constructs a Job for a namespace and commits it to the store
 func (srv *Service) Create(namespace models.NamespaceSpec, spec models.JobSpec) error {
+	if err := ValidateSchedule(spec); err != nil {
+		return err
+	}
 	jobRepo := srv.jobSpecRepoFactory.New(namespace)
 	if err := jobRepo.Save(spec); err != nil {
 		return errors.Wrapf(err, "failed to save job: %s", spec.Name)
@@ -83,6 +112,35 @@ func (srv *Service) Create(namespace models.NamespaceSpec, spec models.JobSpec)
 	return nil
 }
 
+// CreateAll saves multiple jobs in a single transaction, none of the jobs
+// are saved if any of them is invalid or fails to save
+func (srv *Service) CreateAll(namespace models.NamespaceSpec, specs []models.JobSpec) error {
+	for _, spec := range specs {
+		if err := ValidateSchedule(spec); err != nil {
+			return err
+		}
+	}
+	jobRepo := srv.jobSpecRepoFactory.New(namespace)
+	if err := jobRepo.SaveAll(specs); err != nil {
+		return errors.Wrapf(err, "failed to save %d jobs", len(specs))
+	}
+	return nil
+}
+
+// ValidateSchedule checks interval and trigger of the job schedule, jobs
+// are checked before they are saved
+func ValidateSchedule(spec models.JobSpec) error {
+	if err := utils.CronIntervalValidator(spec.Schedule.Interval, ""); err != nil {
+		return errors.Wrapf(err, "invalid schedule interval of job %s", spec.Name)
+	}
+	if spec.Schedule.Trigger != nil {
+		if err := spec.Schedule.Trigger.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid schedule trigger of job %s", spec.Name)
+		}
+	}
+	return nil
+}
+
 // GetByName fetches a Job by name for a specific namespace
 func (srv *Service) GetByName(name string, namespace models.NamespaceSpec) (models.JobSpec, error) {
 	jobSpec, err := srv.jobSpecRepoFactory.New(namespace).GetByName(name)
@@ -116,9 +174,44 @@ func (srv *Service) Dump(namespace models.NamespaceSpec, jobSpec models.JobSpec)
 	if err != nil {
 		return models.Job{}, err
 	}
+	return srv.compileResolved(namespace, jobSpecs, jobSpec.Name)
+}
+
+// Compile returns the Job scheduler would receive if jobSpec was deployed,
+// jobSpec takes place of the stored job with same name if there is one
+func (srv *Service) Compile(namespace models.NamespaceSpec, jobSpec models.JobSpec) (models.Job, error) {
+	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(namespace.ProjectSpec)
+	jobSpecs, err := srv.GetDependencyResolvedSpecs(namespace.ProjectSpec, projectJobSpecRepo, nil)
+	if err != nil {
+		return models.Job{}, err
+	}
 
+	if jobSpec.Assets, err = srv.assetCompiler(jobSpec, srv.Now()); err != nil {
+		return models.Job{}, errors.Wrap(err, "asset compilation")
+	}
+	resolvedJobSpec, err := srv.dependencyResolver.Resolve(namespace.ProjectSpec, projectJobSpecRepo, jobSpec, nil)
+	if err != nil {
+		return models.Job{}, errors.Wrapf(err, "failed to resolve dependency for %s", jobSpec.Name)
+	}
+
+	replaced := false
+	for idx, jSpec := range jobSpecs {
+		if jSpec.Name == resolvedJobSpec.Name {
+			jobSpecs[idx] = resolvedJobSpec
+			replaced = true
+		}
+	}
+	if !replaced {
+		jobSpecs = append(jobSpecs, resolvedJobSpec)
+	}
+	return srv.compileResolved(namespace, jobSpecs, resolvedJobSpec.Name)
+}
+
+// compileResolved resolves priorities of dependency resolved jobSpecs of a
+// project and compiles the one named jobName
+func (srv *Service) compileResolved(namespace models.NamespaceSpec, jobSpecs []models.JobSpec, jobName string) (models.Job, error) {
 	// resolve priority of all jobSpecs
-	jobSpecs, err = srv.priorityResolver.Resolve(jobSpecs)
+	jobSpecs, err := srv.priorityResolver.Resolve(jobSpecs)
 	if err != nil {
 		return models.Job{}, err
 	}
@@ -127,12 +220,12 @@ func (srv *Service) Dump(namespace models.NamespaceSpec, jobSpec models.JobSpec)
 	// we can simply do the comparison by name
 	var resolvedJobSpec models.JobSpec
 	for _, jSpec := range jobSpecs {
-		if jSpec.Name == jobSpec.Name {
+		if jSpec.Name == jobName {
 			resolvedJobSpec = jSpec
 		}
 	}
 	if resolvedJobSpec.Name == "" {
-		return models.Job{}, errors.Errorf("missing job during compile %s", jobSpec.Name)
+		return models.Job{}, errors.Errorf("missing job during compile %s", jobName)
 	}
 
 	compiledJob, err := srv.compiler.Compile(namespace, resolvedJobSpec)
@@ -144,6 +237,7 @@ func (srv *Service) Dump(namespace models.NamespaceSpec, jobSpec models.JobSpec)
 
 // Check if job specifications are valid
 func (srv *Service) Check(namespace models.NamespaceSpec, jobSpecs []models.JobSpec, obs progress.Observer) (err error) {
+	staticDependencies := map[string][]string{}
 	for i, jSpec := range jobSpecs {
 		// compile assets
 		if jobSpecs[i].Assets, err = srv.assetCompiler(jSpec, srv.Now()); err != nil {
@@ -151,6 +245,9 @@ func (srv *Service) Check(namespace models.NamespaceSpec, jobSpecs []models.JobS
 		}
 
 		// remove manual dependencies as they needs to be resolved
+		for depName := range jSpec.Dependencies {
+			staticDependencies[jSpec.Name] = append(staticDependencies[jSpec.Name], depName)
+		}
 		jobSpecs[i].Dependencies = map[string]models.JobSpecDependency{}
 	}
 
@@ -158,21 +255,60 @@ func (srv *Service) Check(namespace models.NamespaceSpec, jobSpecs []models.JobS
 	for _, jSpec := range jobSpecs {
 		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
 			return func() (interface{}, error) {
+				checked := checkedJobSpec{
+					name:         currentSpec.Name,
+					dependencies: staticDependencies[currentSpec.Name],
+				}
+
+				// check schedule
+				if err := utils.CronIntervalValidator(currentSpec.Schedule.Interval, ""); err != nil {
+					if obs != nil {
+						obs.Notify(&EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("schedule: %s\n", err.Error())})
+					}
+					return nil, errors.Wrapf(err, "invalid schedule interval of job %s", currentSpec.Name)
+				}
+				if currentSpec.Schedule.Trigger != nil {
+					if err := currentSpec.Schedule.Trigger.Validate(); err != nil {
+						if obs != nil {
+							obs.Notify(&EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("trigger: %s\n", err.Error())})
+						}
+						return nil, errors.Wrapf(err, "invalid schedule trigger of job %s", currentSpec.Name)
+					}
+				}
+
 				// check dependencies
 				if currentSpec.Task.Unit.DependencyMod != nil {
-					if _, err := currentSpec.Task.Unit.DependencyMod.GenerateDependencies(context.TODO(), models.GenerateDependenciesRequest{
+					dependencyResp, err := currentSpec.Task.Unit.DependencyMod.GenerateDependencies(context.TODO(), models.GenerateDependenciesRequest{
 						Config:  models.PluginConfigs{}.FromJobSpec(currentSpec.Task.Config),
 						Assets:  models.PluginAssets{}.FromJobSpec(currentSpec.Assets),
 						Project: namespace.ProjectSpec,
 						PluginOptions: models.PluginOptions{
 							DryRun: true,
 						},
-					}); err != nil {
+					})
+					if err != nil {
 						if obs != nil {
 							obs.Notify(&EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("dependency resolution: %s\n", err.Error())})
 						}
 						return nil, errors.Wrapf(err, "failed to resolve dependencies %s", currentSpec.Name)
 					}
+					checked.destinationDependencies = dependencyResp.Dependencies
+
+					destinationResp, err := currentSpec.Task.Unit.DependencyMod.GenerateDestination(context.TODO(), models.GenerateDestinationRequest{
+						Config:  models.PluginConfigs{}.FromJobSpec(currentSpec.Task.Config),
+						Assets:  models.PluginAssets{}.FromJobSpec(currentSpec.Assets),
+						Project: namespace.ProjectSpec,
+						PluginOptions: models.PluginOptions{
+							DryRun: true,
+						},
+					})
+					if err != nil {
+						if obs != nil {
+							obs.Notify(&EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("destination: %s\n", err.Error())})
+						}
+						return nil, errors.Wrapf(err, "failed to generate destination %s", currentSpec.Name)
+					}
+					checked.destination = destinationResp.Destination
 				}
 
 				// check compilation
@@ -186,18 +322,159 @@ func (srv *Service) Check(namespace models.NamespaceSpec, jobSpecs []models.JobS
 				if obs != nil {
 					obs.Notify(&EventJobCheckSuccess{Name: currentSpec.Name})
 				}
-				return nil, nil
+				return checked, nil
 			}
 		}(jSpec))
 	}
+	var checkedSpecs []checkedJobSpec
 	for _, result := range runner.Run() {
 		if result.Err != nil {
 			err = multierror.Append(err, result.Err)
+			continue
+		}
+		checkedSpecs = append(checkedSpecs, result.Val.(checkedJobSpec))
+	}
+	if err != nil {
+		return err
+	}
+
+	jobDestinations := map[string]string{}
+	for _, checked := range checkedSpecs {
+		jobDestinations[checked.name] = checked.destination
+	}
+	if err := srv.checkDuplicateDestinations(jobDestinations, nil, obs); err != nil {
+		return err
+	}
+
+	// jobs can be valid on their own but still depend on each other in a cycle
+	return checkDependencyCycle(checkedSpecs)
+}
+
+// checkedJobSpec keeps what a job depends on, as found while checking it
+type checkedJobSpec struct {
+	name                    string
+	destination             string
+	dependencies            []string
+	destinationDependencies []string
+}
+
+// checkDependencyCycle looks for cyclic dependencies among checked jobs,
+// dependencies on jobs that were not checked are ignored
+func checkDependencyCycle(checkedSpecs []checkedJobSpec) error {
+	dependencyTree := tree.NewMultiRootTree()
+	destinationNodes := map[string]*tree.TreeNode{}
+	for _, checked := range checkedSpecs {
+		node := tree.NewTreeNode(models.JobSpec{Name: checked.name})
+		dependencyTree.AddNode(node)
+		if checked.destination != "" {
+			destinationNodes[checked.destination] = node
+		}
+	}
+	for _, checked := range checkedSpecs {
+		node, _ := dependencyTree.GetNodeByName(checked.name)
+		for _, depName := range checked.dependencies {
+			if parentNode, ok := dependencyTree.GetNodeByName(depName); ok {
+				parentNode.AddDependent(node)
+			}
+		}
+		for _, depDestination := range checked.destinationDependencies {
+			// a job reading from its own destination is not a dependency
+			if parentNode, ok := destinationNodes[depDestination]; ok && parentNode != node {
+				parentNode.AddDependent(node)
+			}
+		}
+	}
+	return dependencyTree.IsCyclic()
+}
+
+// checkDuplicateDestinations fails if multiple jobs write to the same
+// destination, if namespaceJobNames is set only duplicates involving those
+// jobs are reported
+func (srv *Service) checkDuplicateDestinations(jobDestinations map[string]string, namespaceJobNames map[string]bool,
+	obs progress.Observer) error {
+	jobsByDestination := map[string][]string{}
+	for name, destination := range jobDestinations {
+		if destination != "" {
+			jobsByDestination[destination] = append(jobsByDestination[destination], name)
 		}
 	}
+	var destinations []string
+	for destination := range jobsByDestination {
+		destinations = append(destinations, destination)
+	}
+	sort.Strings(destinations)
+
+	var err error
+	for _, destination := range destinations {
+		jobNames := jobsByDestination[destination]
+		if len(jobNames) < 2 || !srv.anyJobOf(jobNames, namespaceJobNames) {
+			continue
+		}
+		sort.Strings(jobNames)
+
+		if srv.WarnDuplicateDestination {
+			srv.notifyProgress(obs, &EventJobSpecDuplicateDestination{Destination: destination, Jobs: jobNames})
+			continue
+		}
+		err = multierror.Append(err, errors.Wrapf(ErrDuplicateDestination, "jobs %s write to the same destination %s",
+			strings.Join(jobNames, ", "), destination))
+	}
 	return err
 }
 
+func (srv *Service) anyJobOf(jobNames []string, filter map[string]bool) bool {
+	if filter == nil {
+		return true
+	}
+	for _, name := range jobNames {
+		if filter[name] {
+			return true
+		}
+	}
+	return false
+}
+
+type jobDestination struct {
+	name        string
+	destination string
+}
+
+// generateDestinations maps names of jobs to the destination their task writes to
+func (srv *Service) generateDestinations(proj models.ProjectSpec, jobSpecs []models.JobSpec) (map[string]string, error) {
+	runner := parallel.NewRunner(parallel.WithTicket(ConcurrentTicketPerSec), parallel.WithLimit(ConcurrentLimit))
+	for _, jobSpec := range jobSpecs {
+		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				if currentSpec.Task.Unit == nil || currentSpec.Task.Unit.DependencyMod == nil {
+					return nil, nil
+				}
+				resp, err := currentSpec.Task.Unit.DependencyMod.GenerateDestination(context.TODO(), models.GenerateDestinationRequest{
+					Config:  models.PluginConfigs{}.FromJobSpec(currentSpec.Task.Config),
+					Assets:  models.PluginAssets{}.FromJobSpec(currentSpec.Assets),
+					Project: proj,
+				})
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to generate destination for job %s", currentSpec.Name)
+				}
+				return jobDestination{name: currentSpec.Name, destination: resp.Destination}, nil
+			}
+		}(jobSpec))
+	}
+
+	var err error
+	jobDestinations := map[string]string{}
+	for _, state := range runner.Run() {
+		if state.Err != nil {
+			err = multierror.Append(err, state.Err)
+			continue
+		}
+		if generated, ok := state.Val.(jobDestination); ok {
+			jobDestinations[generated.name] = generated.destination
+		}
+	}
+	return jobDestinations, err
+}
+
 // Delete deletes a job spec from all spec repos
 func (srv *Service) Delete(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec) error {
 	if err := srv.isJobDeletable(namespace.ProjectSpec, jobSpec); err != nil {
@@ -208,6 +485,9 @@ func (srv *Service) Delete(ctx context.Context, namespace models.NamespaceSpec,
 	if err := jobSpecRepo.Delete(jobSpec.Name); err != nil {
 		return errors.Wrapf(err, "failed to delete spec: %s", jobSpec.Name)
 	}
+	if err := srv.publishTombstones(namespace, []string{jobSpec.Name}, nil); err != nil {
+		return err
+	}
 
 	if err := srv.Sync(ctx, namespace, nil); err != nil {
 		return err
@@ -216,6 +496,83 @@ func (srv *Service) Delete(ctx context.Context, namespace models.NamespaceSpec,
 	return nil
 }
 
+// Restore brings back a job spec deleted from the namespace, it is deleted
+// again if the namespace fails to sync with it
+func (srv *Service) Restore(ctx context.Context, namespace models.NamespaceSpec, name string) (models.JobSpec, error) {
+	jobSpecRepo := srv.jobSpecRepoFactory.New(namespace)
+	if err := jobSpecRepo.Restore(name); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "failed to restore spec: %s", name)
+	}
+
+	if err := srv.Sync(ctx, namespace, nil); err != nil {
+		if deleteErr := jobSpecRepo.Delete(name); deleteErr != nil {
+			err = multierror.Append(err, errors.Wrapf(deleteErr, "failed to delete spec: %s", name))
+		}
+		return models.JobSpec{}, err
+	}
+	return jobSpecRepo.GetByName(name)
+}
+
+// GetVersions lists versions of a job saved on every deployment, latest first
+func (srv *Service) GetVersions(namespace models.NamespaceSpec, name string) ([]models.JobSpecVersion, error) {
+	versions, err := srv.jobSpecRepoFactory.New(namespace).GetVersions(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve versions of job: %s", name)
+	}
+	return versions, nil
+}
+
+// GetVersion fetches a job as it was deployed at revision
+func (srv *Service) GetVersion(namespace models.NamespaceSpec, name string, revision int) (models.JobSpecVersion, error) {
+	version, err := srv.jobSpecRepoFactory.New(namespace).GetVersion(name, revision)
+	if err != nil {
+		return models.JobSpecVersion{}, errors.Wrapf(err, "failed to retrieve revision %d of job: %s", revision, name)
+	}
+	return version, nil
+}
+
+// Rollback redeploys a job as it was at an earlier revision, the rolled back
+// spec is saved as a new revision and the current spec is saved again if
+// syncing fails
+func (srv *Service) Rollback(ctx context.Context, namespace models.NamespaceSpec, name string, revision int) (models.JobSpec, error) {
+	current, err := srv.GetByName(name, namespace)
+	if err != nil {
+		return models.JobSpec{}, err
+	}
+	version, err := srv.GetVersion(namespace, name, revision)
+	if err != nil {
+		return models.JobSpec{}, err
+	}
+
+	spec := version.Spec
+	spec.ID = current.ID
+	spec.Revision = current.Revision
+	spec.Paused = current.Paused
+	if err := srv.Create(namespace, spec); err != nil {
+		return models.JobSpec{}, err
+	}
+
+	if err := srv.Sync(ctx, namespace, nil); err != nil {
+		current.Revision = 0
+		if saveErr := srv.Create(namespace, current); saveErr != nil {
+			err = multierror.Append(err, saveErr)
+		}
+		return models.JobSpec{}, err
+	}
+	return srv.GetByName(name, namespace)
+}
+
+// SetPaused pauses or resumes a job without redeploying it, a paused job
+// stays in spec store and metadata but is removed from scheduler
+func (srv *Service) SetPaused(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec, paused bool) error {
+	jobSpec.Paused = paused
+	jobSpecRepo := srv.jobSpecRepoFactory.New(namespace)
+	if err := jobSpecRepo.Save(jobSpec); err != nil {
+		return errors.Wrapf(err, "failed to save spec: %s", jobSpec.Name)
+	}
+	return srv.Sync(ctx, namespace, nil)
+}
+
 // Sync fetches all the jobs that belong to a project, resolves its dependencies
 // assign proper priority weights, compiles it and uploads it to the destination
 // store
@@ -233,26 +590,54 @@ func (srv *Service) Sync(ctx context.Context, namespace models.NamespaceSpec, pr
 	}
 	srv.notifyProgress(progressObserver, &EventJobPriorityWeightAssign{})
 
-	jobSpecs, err = srv.filterJobSpecForNamespace(jobSpecs, namespace)
+	namespaceJobSpecs, err := srv.filterJobSpecForNamespace(jobSpecs, namespace)
 	if err != nil {
 		return err
 	}
 
-	jobRepo, err := srv.jobRepoFactory.New(ctx, namespace.ProjectSpec)
+	// destinations are checked across the project, but only duplicates
+	// involving jobs of this namespace fail its sync
+	jobDestinations, err := srv.generateDestinations(namespace.ProjectSpec, jobSpecs)
 	if err != nil {
 		return err
 	}
+	namespaceJobNames := map[string]bool{}
+	for _, jobSpec := range namespaceJobSpecs {
+		namespaceJobNames[jobSpec.Name] = true
+	}
+	if err := srv.checkDuplicateDestinations(jobDestinations, namespaceJobNames, progressObserver); err != nil {
+		return err
+	}
+	jobSpecs = namespaceJobSpecs
+
+	// jobs past their end date and paused jobs are not uploaded, which
+	// removes them from scheduler along with jobs deleted from specs
+	var activeJobSpecs, scheduledJobSpecs []models.JobSpec
+	for _, jobSpec := range jobSpecs {
+		if srv.IsRetired(jobSpec) {
+			srv.notifyProgress(progressObserver, &EventJobRetired{Name: jobSpec.Name})
+			continue
+		}
+		activeJobSpecs = append(activeJobSpecs, jobSpec)
+		if jobSpec.Paused {
+			srv.notifyProgress(progressObserver, &EventJobPaused{Name: jobSpec.Name})
+			continue
+		}
+		scheduledJobSpecs = append(scheduledJobSpecs, jobSpec)
+	}
 
-	if err = srv.uploadSpecs(ctx, jobSpecs, jobRepo, namespace, progressObserver); err != nil {
+	if err = srv.scheduler.DeployJobs(ctx, namespace, scheduledJobSpecs, progressObserver); err != nil {
 		return err
 	}
 
-	if err = srv.publishMetadata(namespace, jobSpecs, progressObserver); err != nil {
+	// paused jobs are still published as they can be resumed anytime
+	if err = srv.publishMetadata(namespace, activeJobSpecs, progressObserver); err != nil {
 		return err
 	}
+	jobSpecs = scheduledJobSpecs
 
-	// get all the stored job names
-	destJobNames, err := jobRepo.ListNames(ctx, namespace)
+	// get all the deployed job names
+	destJobNames, err := srv.scheduler.ListJobs(ctx, namespace)
 	if err != nil {
 		return err
 	}
@@ -264,14 +649,7 @@ func (srv *Service) Sync(ctx context.Context, namespace models.NamespaceSpec, pr
 	}
 	jobsToDelete := setSubstract(destJobNames, sourceJobNames)
 	jobsToDelete = jobDeletionFilter(jobsToDelete)
-	for _, dagName := range jobsToDelete {
-		// delete compiled spec
-		if err := jobRepo.Delete(ctx, namespace, dagName); err != nil {
-			return err
-		}
-		srv.notifyProgress(progressObserver, &EventJobRemoteDelete{dagName})
-	}
-	return nil
+	return srv.scheduler.DeleteJobs(ctx, namespace, jobsToDelete, progressObserver)
 }
 
 // KeepOnly only keeps the provided jobSpecs in argument and deletes rest from spec repository
@@ -302,7 +680,13 @@ func (srv *Service) KeepOnly(namespace models.NamespaceSpec, specsToKeep []model
 		}
 		srv.notifyProgress(progressObserver, &EventSavedJobDelete{jobName})
 	}
-	return nil
+	return srv.publishTombstones(namespace, jobsToDelete, progressObserver)
+}
+
+// IsRetired checks if the schedule of job has ended for longer than
+// the retirement grace period
+func (srv *Service) IsRetired(jobSpec models.JobSpec) bool {
+	return jobSpec.Schedule.HasEnded(srv.Now().Add(-srv.RetirementGrace))
 }
 
 // filterJobSpecForNamespace returns only job specs of a given namespace
@@ -368,45 +752,29 @@ func (srv *Service) GetDependencyResolvedSpecs(proj models.ProjectSpec, projectJ
 }
 
 // uploadSpecs compiles a Job and uploads it to the destination store
-func (srv *Service) uploadSpecs(ctx context.Context, jobSpecs []models.JobSpec, jobRepo store.JobRepository,
-	namespace models.NamespaceSpec, progressObserver progress.Observer) error {
-	runner := parallel.NewRunner(parallel.WithTicket(ConcurrentTicketPerSec))
-	for _, jobSpec := range jobSpecs {
-		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
-			return func() (interface{}, error) {
-				compiledJob, err := srv.compiler.Compile(namespace, currentSpec)
-				if err != nil {
-					return nil, err
-				}
-				srv.notifyProgress(progressObserver, &EventJobSpecCompile{
-					Name: currentSpec.Name,
-				})
-
-				if err = jobRepo.Save(ctx, compiledJob); err != nil {
-					return nil, err
-				}
-				return nil, nil
-			}
-		}(jobSpec))
+func (srv *Service) publishMetadata(namespace models.NamespaceSpec, jobSpecs []models.JobSpec,
+	progressObserver progress.Observer) error {
+	if srv.metaSvcFactory == nil {
+		return nil
 	}
 
-	for runIdx, state := range runner.Run() {
-		srv.notifyProgress(progressObserver, &EventJobUpload{
-			Job: jobSpecs[runIdx],
-			Err: state.Err,
-		})
+	metadataJobService := srv.metaSvcFactory.New()
+	if err := metadataJobService.Publish(namespace, jobSpecs, progressObserver); err != nil {
+		return err
 	}
 	return nil
 }
 
-func (srv *Service) publishMetadata(namespace models.NamespaceSpec, jobSpecs []models.JobSpec,
+// publishTombstones retires metadata of deleted jobs, metadata of jobs
+// restored later is published again as they are synced
+func (srv *Service) publishTombstones(namespace models.NamespaceSpec, jobNames []string,
 	progressObserver progress.Observer) error {
-	if srv.metaSvcFactory == nil {
+	if srv.metaSvcFactory == nil || len(jobNames) == 0 {
 		return nil
 	}
 
 	metadataJobService := srv.metaSvcFactory.New()
-	if err := metadataJobService.Publish(namespace, jobSpecs, progressObserver); err != nil {
+	if err := metadataJobService.Tombstone(namespace, jobNames, progressObserver); err != nil {
 		return err
 	}
 	return nil
@@ -481,7 +849,7 @@ func jobDeletionFilter(dagNames []string) []string {
 
 // NewService creates a new instance of JobService, requiring
 // the necessary dependencies as arguments
-func NewService(jobSpecRepoFactory SpecRepoFactory, jobRepoFact JobRepoFactory,
+func NewService(jobSpecRepoFactory SpecRepoFactory, scheduler models.SchedulerUnit,
 	compiler models.JobCompiler, assetCompiler AssetCompiler, dependencyResolver DependencyResolver,
 	priorityResolver PriorityResolver, metaSvcFactory meta.MetaSvcFactory,
 	projectJobSpecRepoFactory ProjectJobSpecRepoFactory,
@@ -489,7 +857,7 @@ func NewService(jobSpecRepoFactory SpecRepoFactory, jobRepoFact JobRepoFactory,
 ) *Service {
 	return &Service{
 		jobSpecRepoFactory:        jobSpecRepoFactory,
-		jobRepoFactory:            jobRepoFact,
+		scheduler:                 scheduler,
 		compiler:                  compiler,
 		dependencyResolver:        dependencyResolver,
 		priorityResolver:          priorityResolver,
@@ -518,6 +886,13 @@ type (
 		Dependency string
 	}
 
+	// EventJobSpecDuplicateDestination represents multiple jobs
+	// writing to the same destination
+	EventJobSpecDuplicateDestination struct {
+		Destination string
+		Jobs        []string
+	}
+
 	// EventJobSpecCompile represents a specification
 	// being compiled to a Job
 	EventJobSpecCompile struct{ Name string }
@@ -533,10 +908,30 @@ type (
 	// compiled job from a remote repository is being deleted
 	EventJobRemoteDelete struct{ Name string }
 
+	// EventSchedulerArtifactUpload represents an artifact shared
+	// by jobs of a project being uploaded during bootstrap
+	EventSchedulerArtifactUpload struct {
+		Project string
+		Name    string
+		Err     error
+	}
+
+	// EventJobRetired signifies that a job is not
+	// scheduled anymore as its end date has passed
+	EventJobRetired struct{ Name string }
+
+	// EventJobPaused signifies that a job is not
+	// scheduled as it is paused
+	EventJobPaused struct{ Name string }
+
 	// EventSavedJobDelete signifies that a raw
 	// job from a repository is being deleted
 	EventSavedJobDelete struct{ Name string }
 
+	// EventJobSpecRevert signifies that a raw job
+	// is restored to the version before deployment
+	EventJobSpecRevert struct{ Name string }
+
 	// EventJobPriorityWeightAssign signifies that a
 	// job is being assigned a priority weight
 	EventJobPriorityWeightAssign struct{}
@@ -570,10 +965,29 @@ func (e *EventJobRemoteDelete) String() string {
 	return fmt.Sprintf("deleting: %s", e.Name)
 }
 
+func (e *EventSchedulerArtifactUpload) String() string {
+	if e.Err != nil {
+		return fmt.Sprintf("uploading artifact: %s of project %s, failed with error: %s", e.Name, e.Project, e.Err.Error())
+	}
+	return fmt.Sprintf("uploaded artifact: %s of project %s", e.Name, e.Project)
+}
+
+func (e *EventJobRetired) String() string {
+	return fmt.Sprintf("retired: %s, schedule has ended", e.Name)
+}
+
+func (e *EventJobPaused) String() string {
+	return fmt.Sprintf("paused: %s, not scheduled", e.Name)
+}
+
 func (e *EventSavedJobDelete) String() string {
 	return fmt.Sprintf("deleting: %s", e.Name)
 }
 
+func (e *EventJobSpecRevert) String() string {
+	return fmt.Sprintf("reverted: %s", e.Name)
+}
+
 func (e *EventJobPriorityWeightAssign) String() string {
 	return fmt.Sprintf("assigned priority weights")
 }
@@ -586,6 +1000,10 @@ func (e *EventJobSpecUnknownDependencyUsed) String() string {
 	return fmt.Sprintf("could not find registered destination '%s' during compiling dependencies for the provided job %s", e.Dependency, e.Job)
 }
 
+func (e *EventJobSpecDuplicateDestination) String() string {
+	return fmt.Sprintf("jobs %s write to the same destination %s", strings.Join(e.Jobs, ", "), e.Destination)
+}
+
 func (e *EventJobCheckFailed) String() string {
 	return fmt.Sprintf("check for job failed: %s, reason: %s", e.Name, e.Reason)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 	testMock "github.com/stretchr/testify/mock"
 )
@@ -89,6 +90,63 @@ func TestService(t *testing.T) {
 			err := svc.Create(namespaceSpec, jobSpec)
 			assert.NotNil(t, err)
 		})
+
+		t.Run("should fail without saving if schedule interval is not a valid cron", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-team-1",
+				ProjectSpec: models.ProjectSpec{
+					Name: "proj",
+				},
+			}
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "* * * *",
+				},
+			}
+
+			repoFac := new(mock.JobSpecRepoFactory)
+			defer repoFac.AssertExpectations(t)
+
+			svc := job.NewService(repoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			err := svc.Create(namespaceSpec, jobSpec)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid schedule interval of job test")
+		})
+		t.Run("should fail without saving if schedule trigger is missing config", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-team-1",
+				ProjectSpec: models.ProjectSpec{
+					Name: "proj",
+				},
+			}
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  models.JobTriggerInterval,
+					Trigger: &models.JobSpecTrigger{
+						Type:   models.JobTriggerTypeKafka,
+						Config: map[string]string{"TOPIC": "orders"},
+					},
+				},
+			}
+
+			repoFac := new(mock.JobSpecRepoFactory)
+			defer repoFac.AssertExpectations(t)
+
+			svc := job.NewService(repoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			err := svc.Create(namespaceSpec, jobSpec)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid schedule trigger of job test")
+		})
 	})
 
 	t.Run("Check", func(t *testing.T) {
@@ -146,6 +204,14 @@ func TestService(t *testing.T) {
 					DryRun: true,
 				},
 			}).Return(&models.GenerateDependenciesResponse{}, nil)
+			depMode.On("GenerateDestination", context.TODO(), models.GenerateDestinationRequest{
+				Config:  models.PluginConfigs{}.FromJobSpec(currentSpec.Task.Config),
+				Assets:  models.PluginAssets{}.FromJobSpec(currentSpec.Assets),
+				Project: namespaceSpec.ProjectSpec,
+				PluginOptions: models.PluginOptions{
+					DryRun: true,
+				},
+			}).Return(&models.GenerateDestinationResponse{}, nil)
 
 			compiler := new(mock.Compiler)
 			compiler.On("Compile", namespaceSpec, currentSpec).Return(models.Job{}, nil)
@@ -155,6 +221,167 @@ func TestService(t *testing.T) {
 			err := service.Check(namespaceSpec, []models.JobSpec{currentSpec}, nil)
 			assert.Nil(t, err)
 		})
+		t.Run("should fail check with the cycle if jobs depend on each other", func(t *testing.T) {
+			depMode := new(mock.DependencyResolverMod)
+			defer depMode.AssertExpectations(t)
+			specA := models.JobSpec{
+				Version: 1,
+				Name:    "a",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "@daily",
+				},
+				Task: models.JobSpecTask{
+					Unit:   &models.Plugin{DependencyMod: depMode},
+					Config: models.JobSpecConfigs{{Name: "table", Value: "a"}},
+				},
+				Dependencies: map[string]models.JobSpecDependency{
+					"c": {},
+				},
+			}
+			specB := models.JobSpec{
+				Version: 1,
+				Name:    "b",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "@daily",
+				},
+				Task: models.JobSpecTask{
+					Unit:   &models.Plugin{DependencyMod: depMode},
+					Config: models.JobSpecConfigs{{Name: "table", Value: "b"}},
+				},
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+			specC := models.JobSpec{
+				Version: 1,
+				Name:    "c",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "@daily",
+				},
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{},
+				},
+				Dependencies: map[string]models.JobSpecDependency{
+					"b": {},
+				},
+			}
+			for spec, dependencies := range map[*models.JobSpec][]string{&specA: nil, &specB: {"a_table"}} {
+				depMode.On("GenerateDependencies", context.TODO(), models.GenerateDependenciesRequest{
+					Config:  models.PluginConfigs{}.FromJobSpec(spec.Task.Config),
+					Assets:  models.PluginAssets{}.FromJobSpec(spec.Assets),
+					Project: namespaceSpec.ProjectSpec,
+					PluginOptions: models.PluginOptions{
+						DryRun: true,
+					},
+				}).Return(&models.GenerateDependenciesResponse{Dependencies: dependencies}, nil)
+				depMode.On("GenerateDestination", context.TODO(), models.GenerateDestinationRequest{
+					Config:  models.PluginConfigs{}.FromJobSpec(spec.Task.Config),
+					Assets:  models.PluginAssets{}.FromJobSpec(spec.Assets),
+					Project: namespaceSpec.ProjectSpec,
+					PluginOptions: models.PluginOptions{
+						DryRun: true,
+					},
+				}).Return(&models.GenerateDestinationResponse{Destination: spec.Name + "_table"}, nil)
+			}
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, testMock.Anything).Return(models.Job{}, nil)
+			defer compiler.AssertExpectations(t)
+
+			service := job.NewService(nil, nil, compiler, dumpAssets, nil, nil, nil, nil, nil)
+			err := service.Check(namespaceSpec, []models.JobSpec{specA, specB, specC}, nil)
+			assert.EqualError(t, err, "a → b → c → a: a cycle dependency encountered in the tree")
+		})
+		t.Run("should fail check if jobs write to the same destination", func(t *testing.T) {
+			depMode := new(mock.DependencyResolverMod)
+			defer depMode.AssertExpectations(t)
+			var specs []models.JobSpec
+			for _, name := range []string{"b", "a"} {
+				specs = append(specs, models.JobSpec{
+					Version: 1,
+					Name:    name,
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{
+						Unit:   &models.Plugin{DependencyMod: depMode},
+						Config: models.JobSpecConfigs{{Name: "job", Value: name}},
+					},
+					Dependencies: map[string]models.JobSpecDependency{},
+				})
+			}
+			for _, spec := range specs {
+				depMode.On("GenerateDependencies", context.TODO(), models.GenerateDependenciesRequest{
+					Config:  models.PluginConfigs{}.FromJobSpec(spec.Task.Config),
+					Assets:  models.PluginAssets{}.FromJobSpec(spec.Assets),
+					Project: namespaceSpec.ProjectSpec,
+					PluginOptions: models.PluginOptions{
+						DryRun: true,
+					},
+				}).Return(&models.GenerateDependenciesResponse{}, nil)
+				depMode.On("GenerateDestination", context.TODO(), models.GenerateDestinationRequest{
+					Config:  models.PluginConfigs{}.FromJobSpec(spec.Task.Config),
+					Assets:  models.PluginAssets{}.FromJobSpec(spec.Assets),
+					Project: namespaceSpec.ProjectSpec,
+					PluginOptions: models.PluginOptions{
+						DryRun: true,
+					},
+				}).Return(&models.GenerateDestinationResponse{Destination: "project.dataset.table"}, nil)
+			}
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, testMock.Anything).Return(models.Job{}, nil)
+			defer compiler.AssertExpectations(t)
+
+			t.Run("with both job names", func(t *testing.T) {
+				service := job.NewService(nil, nil, compiler, dumpAssets, nil, nil, nil, nil, nil)
+				err := service.Check(namespaceSpec, specs, nil)
+				assert.True(t, errors.Is(err, job.ErrDuplicateDestination))
+				assert.Contains(t, err.Error(), "jobs a, b write to the same destination project.dataset.table")
+			})
+			t.Run("unless configured to only warn", func(t *testing.T) {
+				obs := new(mock.PipelineLogObserver)
+				obs.On("Notify", &job.EventJobSpecDuplicateDestination{
+					Destination: "project.dataset.table",
+					Jobs:        []string{"a", "b"},
+				}).Return()
+				defer obs.AssertExpectations(t)
+				obs.On("Notify", testMock.AnythingOfType("*job.EventJobCheckSuccess")).Return()
+
+				service := job.NewService(nil, nil, compiler, dumpAssets, nil, nil, nil, nil, nil)
+				service.WarnDuplicateDestination = true
+				err := service.Check(namespaceSpec, specs, obs)
+				assert.Nil(t, err)
+			})
+		})
+		t.Run("should fail check for an invalid schedule interval", func(t *testing.T) {
+			currentSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "0 2 * *",
+				},
+				Task: models.JobSpecTask{
+					Unit: &models.Plugin{},
+				},
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+			compiler := new(mock.Compiler)
+			defer compiler.AssertExpectations(t)
+
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", testMock.AnythingOfType("*job.EventJobCheckFailed")).Return()
+			defer obs.AssertExpectations(t)
+
+			service := job.NewService(nil, nil, compiler, dumpAssets, nil, nil, nil, nil, nil)
+			err := service.Check(namespaceSpec, []models.JobSpec{currentSpec}, obs)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid schedule interval of job test")
+		})
 	})
 
 	t.Run("Sync", func(t *testing.T) {
@@ -208,11 +435,65 @@ func TestService(t *testing.T) {
 				},
 			}
 
-			jobs := []models.Job{
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			// resolve dependencies
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			// resolve priority
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			// deploy to scheduler
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeployJobs", ctx, namespaceSpec, jobSpecsAfterPriorityResolve, nil).Return(nil)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			scheduler.On("DeleteJobs", ctx, namespaceSpec, []string{}, nil).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, scheduler, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			err := svc.Sync(ctx, namespaceSpec, nil)
+			assert.Nil(t, err)
+		})
+
+		t.Run("should delete jobs from target store once their schedule has ended for retirement grace", func(t *testing.T) {
+			endDate := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			jobSpecsBase := []models.JobSpec{
 				{
-					Name:        "test",
-					Contents:    []byte(`come string`),
-					NamespaceID: namespaceSpec.Name,
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+				},
+				{
+					Version: 1,
+					Name:    "ended",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						EndDate:   &endDate,
+						Interval:  "@daily",
+					},
 				},
 			}
 
@@ -232,37 +513,105 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
-			jobRepo := new(mock.JobRepository)
-			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
-			defer jobRepo.AssertExpectations(t)
+			depenResolver := new(mock.DependencyResolver)
+			for _, jobSpec := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, testMock.Anything).Return(jobSpec, nil)
+			}
+			defer depenResolver.AssertExpectations(t)
 
-			jobRepoFac := new(mock.JobRepoFactory)
-			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
-			defer jobRepoFac.AssertExpectations(t)
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", testMock.Anything).Return(jobSpecsBase, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			progressObserver := new(mock.PipelineLogObserver)
+			progressObserver.On("Notify", testMock.Anything).Return()
+			defer progressObserver.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeployJobs", ctx, namespaceSpec, jobSpecsBase[:1], progressObserver).Return(nil)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"test", "ended"}, nil)
+			scheduler.On("DeleteJobs", ctx, namespaceSpec, []string{"ended"}, progressObserver).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, scheduler, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc.RetirementGrace = 24 * time.Hour
+			svc.Now = func() time.Time { return endDate.Add(36 * time.Hour) }
+			err := svc.Sync(ctx, namespaceSpec, progressObserver)
+			assert.Nil(t, err)
+			progressObserver.AssertCalled(t, "Notify", &job.EventJobRetired{Name: "ended"})
+		})
+
+		t.Run("should delete paused jobs from target store but keep publishing their metadata", func(t *testing.T) {
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+				},
+				{
+					Version: 1,
+					Name:    "paused",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Paused: true,
+				},
+			}
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
-			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
+			for _, jobSpec := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, testMock.Anything).Return(jobSpec, nil)
+			}
 			defer depenResolver.AssertExpectations(t)
 
-			// resolve priority
 			priorityResolver := new(mock.PriorityResolver)
-			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
+			priorityResolver.On("Resolve", testMock.Anything).Return(jobSpecsBase, nil)
 			defer priorityResolver.AssertExpectations(t)
 
-			compiler := new(mock.Compiler)
-			defer compiler.AssertExpectations(t)
+			progressObserver := new(mock.PipelineLogObserver)
+			progressObserver.On("Notify", testMock.Anything).Return()
+			defer progressObserver.AssertExpectations(t)
 
-			// compile to dag and save
-			for idx, compiledJob := range jobs {
-				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
-				jobRepo.On("Save", ctx, compiledJob).Return(nil)
-			}
+			metaSvc := new(mock.MetaService)
+			metaSvc.On("Publish", namespaceSpec, jobSpecsBase, progressObserver).Return(nil)
+			defer metaSvc.AssertExpectations(t)
 
-			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
-			err := svc.Sync(ctx, namespaceSpec, nil)
+			metaSvcFact := new(mock.MetaSvcFactory)
+			metaSvcFact.On("New").Return(metaSvc)
+			defer metaSvcFact.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeployJobs", ctx, namespaceSpec, jobSpecsBase[:1], progressObserver).Return(nil)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"test", "paused"}, nil)
+			scheduler.On("DeleteJobs", ctx, namespaceSpec, []string{"paused"}, progressObserver).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, scheduler, nil, dumpAssets, depenResolver, priorityResolver, metaSvcFact, projJobSpecRepoFac, nil)
+			err := svc.Sync(ctx, namespaceSpec, progressObserver)
 			assert.Nil(t, err)
+			progressObserver.AssertCalled(t, "Notify", &job.EventJobPaused{Name: "paused"})
 		})
 
 		t.Run("should delete job specs from target store if there are existing specs that are no longer present in job specs", func(t *testing.T) {
@@ -305,19 +654,6 @@ func TestService(t *testing.T) {
 				},
 			}
 
-			jobs := []models.Job{
-				{
-					Name:        "test",
-					Contents:    []byte(`some string`),
-					NamespaceID: namespaceSpec.Name,
-				},
-				{
-					Name:        "test2",
-					Contents:    []byte(`some string`),
-					NamespaceID: namespaceSpec.Name,
-				},
-			}
-
 			// used to store raw job specs
 			jobSpecRepo := new(mock.JobSpecRepository)
 			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
@@ -335,15 +671,6 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
-			jobRepo := new(mock.JobRepository)
-			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test", "test2"}, nil)
-			defer jobRepo.AssertExpectations(t)
-
-			jobRepoFac := new(mock.JobRepoFactory)
-			jobRepoFac.On("New", testMock.Anything, projSpec).Return(jobRepo, nil)
-			defer jobRepoFac.AssertExpectations(t)
-
 			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
 			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
@@ -354,29 +681,14 @@ func TestService(t *testing.T) {
 			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
 			defer priorityResolver.AssertExpectations(t)
 
-			compiler := new(mock.Compiler)
-			defer compiler.AssertExpectations(t)
-
-			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test", "test2"}, nil)
-
-			// resolve dependencies
-			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
+			// deploy the remaining one and delete unwanted
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeployJobs", ctx, namespaceSpec, jobSpecsAfterPriorityResolve, nil).Return(nil)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"test", "test2"}, nil)
+			scheduler.On("DeleteJobs", ctx, namespaceSpec, []string{"test2"}, nil).Return(nil)
+			defer scheduler.AssertExpectations(t)
 
-			// resolve priority
-			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
-			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
-
-			// compile to dag and save the first one
-			compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[0]).Return(jobs[0], nil)
-			jobRepo.On("Save", ctx, jobs[0]).Return(nil)
-
-			// fetch currently stored
-			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
-
-			// delete unwanted
-			jobRepo.On("Delete", ctx, namespaceSpec, jobs[1].Name).Return(nil)
-
-			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc := job.NewService(jobSpecRepoFac, scheduler, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
 			err := svc.Sync(ctx, namespaceSpec, nil)
 			assert.Nil(t, err)
 		})
@@ -472,14 +784,6 @@ func TestService(t *testing.T) {
 				},
 			}
 
-			jobs := []models.Job{
-				{
-					Name:        "test",
-					Contents:    []byte(`come string`),
-					NamespaceID: namespaceSpec.Name,
-				},
-			}
-
 			jobSpecRepo := new(mock.JobSpecRepository)
 			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
 			defer jobSpecRepo.AssertExpectations(t)
@@ -496,15 +800,6 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
-			jobRepo := new(mock.JobRepository)
-			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
-			defer jobRepo.AssertExpectations(t)
-
-			jobRepoFac := new(mock.JobRepoFactory)
-			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
-			defer jobRepoFac.AssertExpectations(t)
-
 			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
 			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
@@ -515,9 +810,6 @@ func TestService(t *testing.T) {
 			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
 			defer priorityResolver.AssertExpectations(t)
 
-			compiler := new(mock.Compiler)
-			defer compiler.AssertExpectations(t)
-
 			metaSvc := new(mock.MetaService)
 			metaSvc.On("Publish", namespaceSpec, jobSpecsAfterPriorityResolve, nil).Return(nil)
 			defer metaSvc.AssertExpectations(t)
@@ -526,13 +818,14 @@ func TestService(t *testing.T) {
 			metaSvcFact.On("New").Return(metaSvc)
 			defer metaSvcFact.AssertExpectations(t)
 
-			// compile to dag and save
-			for idx, compiledJob := range jobs {
-				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
-				jobRepo.On("Save", ctx, compiledJob).Return(nil)
-			}
+			// deploy to scheduler
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeployJobs", ctx, namespaceSpec, jobSpecsAfterPriorityResolve, nil).Return(nil)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			scheduler.On("DeleteJobs", ctx, namespaceSpec, []string{}, nil).Return(nil)
+			defer scheduler.AssertExpectations(t)
 
-			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, metaSvcFact, projJobSpecRepoFac, nil)
+			svc := job.NewService(jobSpecRepoFac, scheduler, nil, dumpAssets, depenResolver, priorityResolver, metaSvcFact, projJobSpecRepoFac, nil)
 			err := svc.Sync(ctx, namespaceSpec, nil)
 			assert.Nil(t, err)
 		})
@@ -610,6 +903,33 @@ func TestService(t *testing.T) {
 			err := svc.KeepOnly(namespaceSpec, toKeep, nil)
 			assert.Nil(t, err)
 		})
+		t.Run("should publish tombstones of deleted specs", func(t *testing.T) {
+			jobSpecsBase := []models.JobSpec{
+				{Version: 1, Name: "test-1"},
+				{Version: 1, Name: "test-2"},
+			}
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			jobSpecRepo.On("Delete", "test-1").Return(nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			metaSvc := new(mock.MetaService)
+			metaSvc.On("Tombstone", namespaceSpec, []string{"test-1"}, nil).Return(nil)
+			defer metaSvc.AssertExpectations(t)
+
+			metaSvcFact := new(mock.MetaSvcFactory)
+			metaSvcFact.On("New").Return(metaSvc)
+			defer metaSvcFact.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, nil, nil, metaSvcFact, nil, nil)
+			err := svc.KeepOnly(namespaceSpec, jobSpecsBase[1:], nil)
+			assert.Nil(t, err)
+		})
 	})
 
 	t.Run("Dump", func(t *testing.T) {
@@ -686,13 +1006,6 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
-			jobRepo := new(mock.JobRepository)
-			defer jobRepo.AssertExpectations(t)
-
-			jobRepoFac := new(mock.JobRepoFactory)
-			defer jobRepoFac.AssertExpectations(t)
-
 			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
 			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
@@ -711,7 +1024,7 @@ func TestService(t *testing.T) {
 				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
 			}
 
-			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc := job.NewService(jobSpecRepoFac, nil, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
 			compiledJob, err := svc.Dump(namespaceSpec, jobSpecsBase[0])
 			assert.Nil(t, err)
 			assert.Equal(t, "come string", string(compiledJob.Contents))
@@ -719,6 +1032,96 @@ func TestService(t *testing.T) {
 		})
 	})
 
+	t.Run("Compile", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: projSpec,
+		}
+
+		t.Run("should compile given spec in place of the stored one", func(t *testing.T) {
+			storedSpecs := []models.JobSpec{
+				{
+					Name: "test",
+					Schedule: models.JobSpecSchedule{
+						Interval: "@daily",
+					},
+				},
+				{
+					Name: "downstream",
+					Schedule: models.JobSpecSchedule{
+						Interval: "@daily",
+					},
+				},
+			}
+			givenSpec := models.JobSpec{
+				Name: "test",
+				Schedule: models.JobSpecSchedule{
+					Interval: "@hourly",
+				},
+			}
+			prioritizedSpec := givenSpec
+			prioritizedSpec.Task.Priority = 10000
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(storedSpecs, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			for _, jobSpec := range append(storedSpecs, givenSpec) {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, nil).Return(jobSpec, nil)
+			}
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", testMock.MatchedBy(func(jobSpecs []models.JobSpec) bool {
+				return len(jobSpecs) == 2 && (jobSpecs[0].Schedule.Interval == "@hourly" || jobSpecs[1].Schedule.Interval == "@hourly")
+			})).Return([]models.JobSpec{storedSpecs[1], prioritizedSpec}, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, prioritizedSpec).Return(models.Job{
+				Name:     "test",
+				Contents: []byte("hourly dag"),
+			}, nil)
+			defer compiler.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			compiledJob, err := svc.Compile(namespaceSpec, givenSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, "hourly dag", string(compiledJob.Contents))
+		})
+		t.Run("should fail if given spec can't be resolved", func(t *testing.T) {
+			givenSpec := models.JobSpec{
+				Name: "test",
+			}
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return([]models.JobSpec{}, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, givenSpec, nil).Return(models.JobSpec{}, errors.New("unknown upstream"))
+			defer depenResolver.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			_, err := svc.Compile(namespaceSpec, givenSpec)
+			assert.Contains(t, err.Error(), "unknown upstream")
+		})
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		projSpec := models.ProjectSpec{
 			Name: "proj",
@@ -770,14 +1173,6 @@ func TestService(t *testing.T) {
 				},
 			}
 
-			jobs := []models.Job{
-				{
-					Name:        "test",
-					Contents:    []byte(`come string`),
-					NamespaceID: namespaceSpec.Name,
-				},
-			}
-
 			jobSpecRepo := new(mock.JobSpecRepository)
 			jobSpecRepo.On("Delete", "test").Return(nil)
 			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
@@ -795,15 +1190,6 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
-			jobRepo := new(mock.JobRepository)
-			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
-			defer jobRepo.AssertExpectations(t)
-
-			jobRepoFac := new(mock.JobRepoFactory)
-			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
-			defer jobRepoFac.AssertExpectations(t)
-
 			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
 			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
@@ -814,16 +1200,14 @@ func TestService(t *testing.T) {
 			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
 			defer priorityResolver.AssertExpectations(t)
 
-			compiler := new(mock.Compiler)
-			defer compiler.AssertExpectations(t)
+			// deploy to scheduler
+			scheduler := new(mock.Scheduler)
+			scheduler.On("DeployJobs", ctx, namespaceSpec, jobSpecsAfterPriorityResolve, nil).Return(nil)
+			scheduler.On("ListJobs", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			scheduler.On("DeleteJobs", ctx, namespaceSpec, []string{}, nil).Return(nil)
+			defer scheduler.AssertExpectations(t)
 
-			// compile to dag and save
-			for idx, compiledJob := range jobs {
-				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
-				jobRepo.On("Save", ctx, compiledJob).Return(nil)
-			}
-
-			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc := job.NewService(jobSpecRepoFac, scheduler, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
 			err := svc.Delete(ctx, namespaceSpec, jobSpecsBase[0])
 			assert.Nil(t, err)
 		})
@@ -892,13 +1276,6 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
-			jobRepo := new(mock.JobRepository)
-			defer jobRepo.AssertExpectations(t)
-
-			jobRepoFac := new(mock.JobRepoFactory)
-			defer jobRepoFac.AssertExpectations(t)
-
 			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
 			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
@@ -909,13 +1286,88 @@ func TestService(t *testing.T) {
 			priorityResolver := new(mock.PriorityResolver)
 			defer priorityResolver.AssertExpectations(t)
 
-			compiler := new(mock.Compiler)
-			defer compiler.AssertExpectations(t)
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
 
-			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc := job.NewService(jobSpecRepoFac, scheduler, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
 			err := svc.Delete(ctx, namespaceSpec, jobSpecsBase[0])
 			assert.NotNil(t, err)
 			assert.Equal(t, "cannot delete job test since it's dependency of job downstream-test", err.Error())
 		})
 	})
+
+	t.Run("SetPaused", func(t *testing.T) {
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: models.ProjectSpec{Name: "proj"},
+		}
+		t.Run("should save job spec as paused", func(t *testing.T) {
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+			}
+			pausedJobSpec := jobSpec
+			pausedJobSpec.Paused = true
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("Save", pausedJobSpec).Return(errors.New("unknown error"))
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			err := svc.SetPaused(ctx, namespaceSpec, jobSpec, true)
+			assert.Equal(t, "failed to save spec: test: unknown error", err.Error())
+		})
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: models.ProjectSpec{Name: "proj"},
+		}
+		t.Run("should fail if there is no deleted job of the name", func(t *testing.T) {
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("Restore", "test").Return(store.ErrResourceNotFound)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			_, err := svc.Restore(ctx, namespaceSpec, "test")
+			assert.True(t, errors.Is(err, store.ErrResourceNotFound))
+			assert.Equal(t, "failed to restore spec: test: resource not found", err.Error())
+		})
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: models.ProjectSpec{Name: "proj"},
+		}
+		t.Run("should fail without saving the job if revision doesn't exist", func(t *testing.T) {
+			jobSpec := models.JobSpec{Name: "test", Revision: 2}
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetByName", "test").Return(jobSpec, nil)
+			jobSpecRepo.On("GetVersion", "test", 5).Return(models.JobSpecVersion{}, store.ErrResourceNotFound)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			_, err := svc.Rollback(ctx, namespaceSpec, "test", 5)
+			assert.True(t, errors.Is(err, store.ErrResourceNotFound))
+			assert.Equal(t, "failed to retrieve revision 5 of job: test: resource not found", err.Error())
+		})
+	})
 }
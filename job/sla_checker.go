@@ -0,0 +1,263 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/odpf/optimus/core/cron"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// layout of scheduled_at in events, the same scheduler sends
+	slaEventTimeLayout = "2006-01-02T15:04:05Z"
+
+	// furthest back the previous schedule of a run is looked for
+	maxScheduleGap = 400 * 24 * time.Hour
+)
+
+// JobEventService registers events of jobs, notifying their channels
+type JobEventService interface {
+	Register(context.Context, models.NamespaceSpec, models.JobSpec, models.JobEvent) error
+}
+
+// SLAChecker periodically compares runs of jobs having an sla against run
+// history and raises sla_miss events for runs which haven't succeeded by
+// their deadline, so misses are reported even when scheduler fails to.
+// Deadline of a run is its sla past the end of its schedule interval, which
+// is when scheduler starts it. Each check looks at deadlines passed since
+// the previous one, runs scheduler already reported a miss for and paused
+// jobs are skipped
+type SLAChecker struct {
+	wg   sync.WaitGroup
+	done chan struct{}
+
+	jobSvc               models.JobService
+	projectRepoFactory   ProjectRepoFactory
+	namespaceRepoFactory NamespaceRepoFactory
+	jobRunRepoFactory    JobRunRepoFactory
+	eventService         JobEventService
+
+	interval time.Duration
+
+	mu          sync.Mutex
+	lastChecked time.Time
+
+	Now func() time.Time
+}
+
+// Check raises sla_miss events for runs whose deadline passed since the
+// previous check, the first check looks back an interval
+func (c *SLAChecker) Check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.Now()
+	since := c.lastChecked
+	if since.IsZero() {
+		since = now.Add(-c.interval)
+	}
+
+	projects, err := c.projectRepoFactory.New().GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch projects")
+	}
+
+	var checkErrors error
+	for _, projectSpec := range projects {
+		namespaces, err := c.namespaceRepoFactory.New(projectSpec).GetAll()
+		if err != nil {
+			checkErrors = multierror.Append(checkErrors, errors.Wrapf(err, "failed to fetch namespaces of %s", projectSpec.Name))
+			continue
+		}
+		jobRunRepo := c.jobRunRepoFactory.New(projectSpec)
+		for _, namespace := range namespaces {
+			if err := c.checkNamespace(ctx, jobRunRepo, namespace, since, now); err != nil {
+				checkErrors = multierror.Append(checkErrors, errors.Wrapf(err, "failed to check sla of jobs of %s/%s",
+					projectSpec.Name, namespace.Name))
+			}
+		}
+	}
+	// misses of a failed check are not looked for again, they would be
+	// raised late and possibly twice
+	c.lastChecked = now
+	return checkErrors
+}
+
+func (c *SLAChecker) checkNamespace(ctx context.Context, jobRunRepo store.JobRunRepository, namespace models.NamespaceSpec,
+	since, now time.Time) error {
+	jobSpecs, err := c.jobSvc.GetAll(namespace)
+	if err != nil {
+		return err
+	}
+
+	var checkErrors error
+	for _, jobSpec := range jobSpecs {
+		if err := c.checkJob(ctx, jobRunRepo, namespace, jobSpec, since, now); err != nil {
+			checkErrors = multierror.Append(checkErrors, errors.Wrapf(err, "failed to check sla of job %s", jobSpec.Name))
+		}
+	}
+	return checkErrors
+}
+
+func (c *SLAChecker) checkJob(ctx context.Context, jobRunRepo store.JobRunRepository, namespace models.NamespaceSpec,
+	jobSpec models.JobSpec, since, now time.Time) error {
+	sla, err := jobSpec.Behavior.SLAMissDuration()
+	if err != nil {
+		return err
+	}
+	// paused jobs are not run by scheduler, their runs can't miss slas
+	if sla <= 0 || jobSpec.Schedule.Interval == "" || jobSpec.Paused {
+		return nil
+	}
+	schedule, err := cron.ParseCronSchedule(jobSpec.Schedule.Interval)
+	if err != nil {
+		return errors.Wrapf(err, "invalid schedule interval %s", jobSpec.Schedule.Interval)
+	}
+
+	// runs started by scheduler between these were due between since and now
+	loc := jobSpec.Schedule.Location()
+	var scheduledAts, deadlines []time.Time
+	for start := schedule.Next(since.Add(-sla).In(loc)); !start.After(now.Add(-sla)); start = schedule.Next(start) {
+		scheduledAt := previousSchedule(schedule, start)
+		if scheduledAt.IsZero() || scheduledAt.Before(jobSpec.Schedule.StartDate) || jobSpec.Schedule.HasEnded(scheduledAt) {
+			continue
+		}
+		scheduledAts = append(scheduledAts, scheduledAt.UTC())
+		deadlines = append(deadlines, start.Add(sla).UTC())
+	}
+	if len(scheduledAts) == 0 {
+		return nil
+	}
+
+	runs, err := jobRunRepo.GetAll(models.JobRunFilter{
+		JobName:   jobSpec.Name,
+		StartDate: scheduledAts[0],
+		EndDate:   scheduledAts[len(scheduledAts)-1],
+	})
+	if err != nil {
+		return err
+	}
+	known := map[int64]models.JobRun{}
+	for _, run := range runs {
+		known[run.ScheduledAt.Unix()] = run
+	}
+
+	var checkErrors error
+	for i, scheduledAt := range scheduledAts {
+		run, ok := known[scheduledAt.Unix()]
+		if ok && run.State == models.JobStatusStateSuccess && (run.EndTime.IsZero() || !run.EndTime.After(deadlines[i])) {
+			continue
+		}
+		// scheduler already reported the miss
+		if ok && !run.SLAMissedAt.IsZero() {
+			continue
+		}
+		state := "missing"
+		if ok {
+			state = run.State.String()
+		}
+		logger.I(fmt.Sprintf("run of job %s scheduled at %s missed its sla of %s, it is %s", jobSpec.Name,
+			scheduledAt.Format(time.RFC3339), sla, state))
+		if err := c.raise(ctx, namespace, jobSpec, scheduledAt, deadlines[i], state); err != nil {
+			checkErrors = multierror.Append(checkErrors, err)
+		}
+	}
+	return checkErrors
+}
+
+// raise registers a sla_miss event shaped like the ones sent by scheduler,
+// synthetic is set so receivers can tell them apart
+func (c *SLAChecker) raise(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec,
+	scheduledAt, deadline time.Time, state string) error {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"slas": []interface{}{
+			map[string]interface{}{
+				"task_id":      jobSpec.Name,
+				"scheduled_at": scheduledAt.Format(slaEventTimeLayout),
+			},
+		},
+		"scheduled_at": scheduledAt.Format(slaEventTimeLayout),
+		"deadline":     deadline.Format(slaEventTimeLayout),
+		"state":        state,
+		"synthetic":    true,
+	})
+	if err != nil {
+		return err
+	}
+	return c.eventService.Register(ctx, namespace, jobSpec, models.JobEvent{
+		Type:  models.JobEventTypeSLAMiss,
+		Value: value.GetFields(),
+	})
+}
+
+// previousSchedule returns the last time of schedule before t, zero if
+// there is none within maxScheduleGap
+func previousSchedule(schedule *cron.ScheduleSpec, t time.Time) time.Time {
+	for gap := time.Minute; gap <= maxScheduleGap; gap *= 2 {
+		previous := schedule.Next(t.Add(-gap))
+		if !previous.Before(t) {
+			continue
+		}
+		for next := schedule.Next(previous); next.Before(t); next = schedule.Next(next) {
+			previous = next
+		}
+		return previous
+	}
+	return time.Time{}
+}
+
+func (c *SLAChecker) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), c.interval)
+			if err := c.Check(ctx); err != nil {
+				logger.E(errors.Wrap(err, "failed to check sla of jobs"))
+			}
+			cancel()
+		}
+	}
+}
+
+// Close stops checking and waits for a running check to finish
+func (c *SLAChecker) Close() error {
+	close(c.done)
+	c.wg.Wait()
+	return nil
+}
+
+// NewSLAChecker constructs a checker raising sla misses of jobs from their
+// project's run history, checks run every interval and are not scheduled if
+// it is not set
+func NewSLAChecker(jobSvc models.JobService, projectRepoFactory ProjectRepoFactory, namespaceRepoFactory NamespaceRepoFactory,
+	jobRunRepoFactory JobRunRepoFactory, eventService JobEventService, interval time.Duration) *SLAChecker {
+	checker := &SLAChecker{
+		done:                 make(chan struct{}),
+		jobSvc:               jobSvc,
+		projectRepoFactory:   projectRepoFactory,
+		namespaceRepoFactory: namespaceRepoFactory,
+		jobRunRepoFactory:    jobRunRepoFactory,
+		eventService:         eventService,
+		interval:             interval,
+		Now:                  time.Now,
+	}
+	if interval > 0 {
+		checker.wg.Add(1)
+		go checker.run()
+	}
+	return checker
+}
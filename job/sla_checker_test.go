@@ -0,0 +1,166 @@
+package job_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
+)
+
+func TestSLAChecker(t *testing.T) {
+	logger.InitWithWriter(logger.DEBUG, ioutil.Discard)
+	ctx := context.Background()
+
+	projectSpec := models.ProjectSpec{
+		Name: "proj",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	jobSpec := models.JobSpec{
+		Name: "job-1",
+		Schedule: models.JobSpecSchedule{
+			StartDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Interval:  "0 2 * * *",
+		},
+		Behavior: models.JobSpecBehavior{
+			Notify: []models.JobSpecNotifier{
+				{
+					On:     models.JobEventTypeSLAMiss,
+					Config: map[string]string{"duration": "2h"},
+				},
+			},
+		},
+	}
+	// run of 4th is started on 5th at 02:00 and is due by 04:00
+	scheduledAt := time.Date(2021, 1, 4, 2, 0, 0, 0, time.UTC)
+	checkedAt := time.Date(2021, 1, 5, 4, 30, 0, 0, time.UTC)
+	runFilter := models.JobRunFilter{
+		JobName:   jobSpec.Name,
+		StartDate: scheduledAt,
+		EndDate:   scheduledAt,
+	}
+
+	newCheckerOf := func(jobSpec models.JobSpec, jobRunRepo *mock.JobRunRepository, eventService *mock.EventService) *job.SLAChecker {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetAll").Return([]models.ProjectSpec{projectSpec}, nil)
+		projectRepoFac := new(mock.ProjectRepoFactory)
+		projectRepoFac.On("New").Return(projectRepo)
+
+		namespaceRepo := new(mock.NamespaceRepository)
+		namespaceRepo.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+		namespaceRepoFac := new(mock.NamespaceRepoFactory)
+		namespaceRepoFac.On("New", projectSpec).Return(namespaceRepo)
+
+		jobSvc := new(mock.JobService)
+		jobSvc.On("GetAll", namespaceSpec).Return([]models.JobSpec{jobSpec}, nil)
+
+		jobRunRepoFac := new(mock.JobRunRepoFactory)
+		jobRunRepoFac.On("New", projectSpec).Return(jobRunRepo)
+
+		checker := job.NewSLAChecker(jobSvc, projectRepoFac, namespaceRepoFac, jobRunRepoFac, eventService, time.Hour)
+		checker.Now = func() time.Time { return checkedAt }
+		return checker
+	}
+	newChecker := func(jobRunRepo *mock.JobRunRepository, eventService *mock.EventService) *job.SLAChecker {
+		return newCheckerOf(jobSpec, jobRunRepo, eventService)
+	}
+
+	t.Run("Check", func(t *testing.T) {
+		t.Run("should raise sla miss of runs not succeeded by their deadline", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", runFilter).Return([]models.JobRun{
+				{JobName: jobSpec.Name, ScheduledAt: scheduledAt, State: models.JobStatusStateRunning},
+			}, nil)
+			defer jobRunRepo.AssertExpectations(t)
+
+			eventService := new(mock.EventService)
+			eventService.On("Register", ctx, namespaceSpec, jobSpec, mock2.MatchedBy(func(evt models.JobEvent) bool {
+				return evt.Type == models.JobEventTypeSLAMiss &&
+					evt.Value["scheduled_at"].GetStringValue() == "2021-01-04T02:00:00Z" &&
+					evt.Value["deadline"].GetStringValue() == "2021-01-05T04:00:00Z" &&
+					evt.Value["state"].GetStringValue() == "running" &&
+					evt.Value["synthetic"].GetBoolValue()
+			})).Return(nil).Once()
+			defer eventService.AssertExpectations(t)
+
+			checker := newChecker(jobRunRepo, eventService)
+			defer checker.Close()
+			assert.Nil(t, checker.Check(ctx))
+		})
+		t.Run("should not raise sla miss of runs succeeded by their deadline", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", runFilter).Return([]models.JobRun{
+				{
+					JobName:     jobSpec.Name,
+					ScheduledAt: scheduledAt,
+					State:       models.JobStatusStateSuccess,
+					EndTime:     time.Date(2021, 1, 5, 3, 0, 0, 0, time.UTC),
+				},
+			}, nil)
+			eventService := new(mock.EventService)
+			defer eventService.AssertExpectations(t)
+
+			checker := newChecker(jobRunRepo, eventService)
+			defer checker.Close()
+			assert.Nil(t, checker.Check(ctx))
+		})
+		t.Run("should not raise sla miss of runs scheduler reported a miss for", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", runFilter).Return([]models.JobRun{
+				{
+					JobName:     jobSpec.Name,
+					ScheduledAt: scheduledAt,
+					State:       models.JobStatusStateRunning,
+					SLAMissedAt: time.Date(2021, 1, 5, 4, 5, 0, 0, time.UTC),
+				},
+			}, nil)
+			eventService := new(mock.EventService)
+			defer eventService.AssertExpectations(t)
+
+			checker := newChecker(jobRunRepo, eventService)
+			defer checker.Close()
+			assert.Nil(t, checker.Check(ctx))
+		})
+		t.Run("should not raise sla miss of paused jobs", func(t *testing.T) {
+			pausedJobSpec := jobSpec
+			pausedJobSpec.Paused = true
+			jobRunRepo := new(mock.JobRunRepository)
+			defer jobRunRepo.AssertExpectations(t)
+			eventService := new(mock.EventService)
+			defer eventService.AssertExpectations(t)
+
+			checker := newCheckerOf(pausedJobSpec, jobRunRepo, eventService)
+			defer checker.Close()
+			assert.Nil(t, checker.Check(ctx))
+		})
+		t.Run("should only look at deadlines passed since the previous check", func(t *testing.T) {
+			jobRunRepo := new(mock.JobRunRepository)
+			jobRunRepo.On("GetAll", runFilter).Return([]models.JobRun{}, nil).Once()
+			defer jobRunRepo.AssertExpectations(t)
+
+			eventService := new(mock.EventService)
+			eventService.On("Register", ctx, namespaceSpec, jobSpec, mock2.MatchedBy(func(evt models.JobEvent) bool {
+				return evt.Value["state"].GetStringValue() == "missing"
+			})).Return(nil).Once()
+			defer eventService.AssertExpectations(t)
+
+			checker := newChecker(jobRunRepo, eventService)
+			defer checker.Close()
+			assert.Nil(t, checker.Check(ctx))
+
+			checker.Now = func() time.Time { return checkedAt.Add(time.Hour) }
+			assert.Nil(t, checker.Check(ctx))
+		})
+	})
+}
@@ -0,0 +1,110 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/pkg/errors"
+)
+
+// TombstoneSweeper periodically purges job specs and projects which were
+// deleted longer than retention ago, they can be restored till they are
+// purged
+type TombstoneSweeper struct {
+	wg   sync.WaitGroup
+	done chan struct{}
+
+	projectRepoFactory   ProjectRepoFactory
+	namespaceRepoFactory NamespaceRepoFactory
+	jobSpecRepoFactory   SpecRepoFactory
+
+	retention time.Duration
+	interval  time.Duration
+
+	Now func() time.Time
+}
+
+// Sweep purges expired deleted specs of every namespace and expired deleted
+// projects along with everything they own
+func (s *TombstoneSweeper) Sweep() error {
+	projectRepo := s.projectRepoFactory.New()
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch projects")
+	}
+
+	deletedBefore := s.Now().Add(-s.retention)
+	var sweepErrors error
+	purged, err := projectRepo.Purge(deletedBefore)
+	if len(purged) > 0 {
+		logger.I(fmt.Sprintf("purged deleted projects %v", purged))
+	}
+	if err != nil {
+		sweepErrors = multierror.Append(sweepErrors, errors.Wrap(err, "failed to purge deleted projects"))
+	}
+	for _, projectSpec := range projects {
+		namespaces, err := s.namespaceRepoFactory.New(projectSpec).GetAll()
+		if err != nil {
+			sweepErrors = multierror.Append(sweepErrors, errors.Wrapf(err, "failed to fetch namespaces of %s", projectSpec.Name))
+			continue
+		}
+		for _, namespace := range namespaces {
+			purged, err := s.jobSpecRepoFactory.New(namespace).Purge(deletedBefore)
+			if len(purged) > 0 {
+				logger.I(fmt.Sprintf("purged deleted jobs %v of %s/%s", purged, projectSpec.Name, namespace.Name))
+			}
+			if err != nil {
+				sweepErrors = multierror.Append(sweepErrors, errors.Wrapf(err, "failed to purge deleted jobs of %s/%s",
+					projectSpec.Name, namespace.Name))
+			}
+		}
+	}
+	return sweepErrors
+}
+
+func (s *TombstoneSweeper) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := s.Sweep(); err != nil {
+				logger.E(errors.Wrap(err, "failed to sweep deleted jobs and projects"))
+			}
+		}
+	}
+}
+
+// Close stops sweeping and waits for a running sweep to finish
+func (s *TombstoneSweeper) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
+
+// NewTombstoneSweeper constructs a sweeper purging specs and projects deleted
+// longer than retention ago, sweeps run every interval and are not scheduled if it is not set
+func NewTombstoneSweeper(projectRepoFactory ProjectRepoFactory, namespaceRepoFactory NamespaceRepoFactory,
+	jobSpecRepoFactory SpecRepoFactory, retention, interval time.Duration) *TombstoneSweeper {
+	sweeper := &TombstoneSweeper{
+		done:                 make(chan struct{}),
+		projectRepoFactory:   projectRepoFactory,
+		namespaceRepoFactory: namespaceRepoFactory,
+		jobSpecRepoFactory:   jobSpecRepoFactory,
+		retention:            retention,
+		interval:             interval,
+		Now:                  time.Now,
+	}
+	if interval > 0 {
+		sweeper.wg.Add(1)
+		go sweeper.run()
+	}
+	return sweeper
+}
@@ -0,0 +1,109 @@
+package job_test
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTombstoneSweeper(t *testing.T) {
+	logger.InitWithWriter(logger.DEBUG, ioutil.Discard)
+
+	projectSpec := models.ProjectSpec{
+		Name: "proj",
+	}
+	namespaceSpecs := []models.NamespaceSpec{
+		{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: projectSpec,
+		},
+		{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-2",
+			ProjectSpec: projectSpec,
+		},
+	}
+	now := time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC)
+	deletedBefore := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newRepoFactories := func(purgedProjects []string) (*mock.ProjectRepoFactory, *mock.NamespaceRepoFactory) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetAll").Return([]models.ProjectSpec{projectSpec}, nil)
+		projectRepo.On("Purge", deletedBefore).Return(purgedProjects, nil)
+		projectRepoFac := new(mock.ProjectRepoFactory)
+		projectRepoFac.On("New").Return(projectRepo)
+
+		namespaceRepo := new(mock.NamespaceRepository)
+		namespaceRepo.On("GetAll").Return(namespaceSpecs, nil)
+		namespaceRepoFac := new(mock.NamespaceRepoFactory)
+		namespaceRepoFac.On("New", projectSpec).Return(namespaceRepo)
+		return projectRepoFac, namespaceRepoFac
+	}
+
+	t.Run("Sweep", func(t *testing.T) {
+		t.Run("should purge jobs deleted before retention of every namespace", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories(nil)
+
+			jobSpecRepo1 := new(mock.JobSpecRepository)
+			jobSpecRepo1.On("Purge", deletedBefore).Return([]string{"job-1"}, nil)
+			defer jobSpecRepo1.AssertExpectations(t)
+			jobSpecRepo2 := new(mock.JobSpecRepository)
+			jobSpecRepo2.On("Purge", deletedBefore).Return(nil, nil)
+			defer jobSpecRepo2.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpecs[0]).Return(jobSpecRepo1)
+			jobSpecRepoFac.On("New", namespaceSpecs[1]).Return(jobSpecRepo2)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			sweeper := job.NewTombstoneSweeper(projectRepoFac, namespaceRepoFac, jobSpecRepoFac, 30*24*time.Hour, 0)
+			sweeper.Now = func() time.Time { return now }
+			assert.Nil(t, sweeper.Sweep())
+			assert.Nil(t, sweeper.Close())
+		})
+		t.Run("should continue with other namespaces if purging one fails", func(t *testing.T) {
+			projectRepoFac, namespaceRepoFac := newRepoFactories(nil)
+
+			jobSpecRepo1 := new(mock.JobSpecRepository)
+			jobSpecRepo1.On("Purge", deletedBefore).Return(nil, errors.New("db down"))
+			defer jobSpecRepo1.AssertExpectations(t)
+			jobSpecRepo2 := new(mock.JobSpecRepository)
+			jobSpecRepo2.On("Purge", deletedBefore).Return([]string{"job-2"}, nil)
+			defer jobSpecRepo2.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpecs[0]).Return(jobSpecRepo1)
+			jobSpecRepoFac.On("New", namespaceSpecs[1]).Return(jobSpecRepo2)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			sweeper := job.NewTombstoneSweeper(projectRepoFac, namespaceRepoFac, jobSpecRepoFac, 30*24*time.Hour, 0)
+			sweeper.Now = func() time.Time { return now }
+			err := sweeper.Sweep()
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "failed to purge deleted jobs of proj/dev-team-1")
+			assert.Nil(t, sweeper.Close())
+		})
+		t.Run("should purge projects deleted before retention", func(t *testing.T) {
+			projectRepo := new(mock.ProjectRepository)
+			projectRepo.On("GetAll").Return([]models.ProjectSpec{}, nil)
+			projectRepo.On("Purge", deletedBefore).Return([]string{"deleted-proj"}, nil)
+			defer projectRepo.AssertExpectations(t)
+			projectRepoFac := new(mock.ProjectRepoFactory)
+			projectRepoFac.On("New").Return(projectRepo)
+
+			sweeper := job.NewTombstoneSweeper(projectRepoFac, nil, nil, 30*24*time.Hour, 0)
+			sweeper.Now = func() time.Time { return now }
+			assert.Nil(t, sweeper.Sweep())
+			assert.Nil(t, sweeper.Close())
+		})
+	})
+}
@@ -21,6 +21,9 @@ import (
 	_ "github.com/odpf/optimus/ext/datastore"
 	"github.com/odpf/optimus/models"
 	_ "github.com/odpf/optimus/plugin"
+
+	// job schedules are in iana timezones, images may not ship tz database
+	_ "time/tzdata"
 )
 
 var (
@@ -51,7 +54,7 @@ func main() {
 		Name:   "optimus",
 		Output: os.Stdout,
 		Level:  pluginLogLevel,
-	})); err != nil {
+	}), configuration.GetPlugin().Dir); err != nil {
 		hPlugin.CleanupClients()
 		fmt.Printf("ERROR: %s\n", err.Error())
 		os.Exit(1)
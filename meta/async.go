@@ -0,0 +1,253 @@
+package meta
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxRetryBackoff caps the wait between attempts of sending a batch
+	maxRetryBackoff = 30 * time.Second
+
+	defaultFlushInterval = time.Second
+)
+
+var ErrWriterClosed = errors.New("metadata writer is closed")
+
+// BufferedWriter keeps messages it fails to send buffered, they are sent
+// again on the next flush unless discarded
+type BufferedWriter interface {
+	models.MetadataWriter
+	Discard()
+}
+
+type AsyncWriterConfig struct {
+	// BatchSize is the number of messages sent together
+	BatchSize int
+	// QueueSize is the number of messages waiting to be sent, messages
+	// written while the queue is full go to dead letters
+	QueueSize int
+	// FlushInterval is the longest a message waits for its batch to fill
+	FlushInterval time.Duration
+	// MaxAttempts is the number of times a batch is tried to be sent
+	// before its messages go to dead letters
+	MaxAttempts int
+	// Backoff is the wait after the first failed attempt, it doubles with
+	// every attempt
+	Backoff time.Duration
+}
+
+type asyncMessage struct {
+	key   []byte
+	value []byte
+}
+
+// AsyncWriter queues metadata and sends it in batches from a worker, which
+// keeps publishing out of the deploy path. Batches failing to be sent are
+// retried with exponential backoff and written to dead letter writer once
+// attempts run out, they are dropped if dead letter writer isn't set
+type AsyncWriter struct {
+	writer BufferedWriter
+	config AsyncWriterConfig
+
+	deadLetterMu sync.Mutex
+	deadLetter   models.MetadataWriter
+
+	queue     chan asyncMessage
+	flushes   chan chan error
+	stop      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// Write queues a message to be sent in a batch
+func (w *AsyncWriter) Write(protobufkey []byte, protobuf []byte) error {
+	select {
+	case <-w.stop:
+		return ErrWriterClosed
+	default:
+	}
+
+	msg := asyncMessage{key: protobufkey, value: protobuf}
+	select {
+	case w.queue <- msg:
+		return nil
+	default:
+		logger.W("metadata queue is full, writing message to dead letters")
+		return w.writeDeadLetters([]asyncMessage{msg})
+	}
+}
+
+// Flush sends all the queued messages and waits till they are either sent
+// or written to dead letters
+func (w *AsyncWriter) Flush() error {
+	reply := make(chan error, 1)
+	select {
+	case w.flushes <- reply:
+		return <-reply
+	case <-w.stop:
+		return ErrWriterClosed
+	}
+}
+
+// Close sends the queued messages and closes the underlying writers
+func (w *AsyncWriter) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		<-w.stopped
+		if closeErr := w.writer.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+		if w.deadLetter != nil {
+			if closeErr := w.deadLetter.Close(); closeErr != nil {
+				err = multierror.Append(err, closeErr)
+			}
+		}
+	})
+	return err
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.stopped)
+
+	ticker := time.NewTicker(w.config.FlushInterval)
+	defer ticker.Stop()
+
+	var batch []asyncMessage
+	for {
+		select {
+		case msg := <-w.queue:
+			batch = append(batch, msg)
+			if len(batch) >= w.config.BatchSize {
+				if err := w.send(batch); err != nil {
+					logger.E(err)
+				}
+				batch = nil
+			}
+		case <-ticker.C:
+			if err := w.send(batch); err != nil {
+				logger.E(err)
+			}
+			batch = nil
+		case reply := <-w.flushes:
+			reply <- w.sendAll(batch)
+			batch = nil
+		case <-w.stop:
+			if err := w.sendAll(batch); err != nil {
+				logger.E(err)
+			}
+			return
+		}
+	}
+}
+
+// sendAll sends batch along with all the queued messages
+func (w *AsyncWriter) sendAll(batch []asyncMessage) error {
+	var err error
+	for {
+		select {
+		case msg := <-w.queue:
+			batch = append(batch, msg)
+			if len(batch) < w.config.BatchSize {
+				continue
+			}
+			if sendErr := w.send(batch); sendErr != nil {
+				err = multierror.Append(err, sendErr)
+			}
+			batch = nil
+		default:
+			if sendErr := w.send(batch); sendErr != nil {
+				err = multierror.Append(err, sendErr)
+			}
+			return err
+		}
+	}
+}
+
+// send writes batch retrying on failure, messages which can't be sent are
+// written to dead letters. Error is returned only if dead letters fail too
+func (w *AsyncWriter) send(batch []asyncMessage) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	// messages failing to be written stay buffered in writer, attempts
+	// after the first one only flush
+	var err error
+	for _, msg := range batch {
+		if writeErr := w.writer.Write(msg.key, msg.value); writeErr != nil {
+			err = writeErr
+		}
+	}
+	if err == nil {
+		err = w.writer.Flush()
+	}
+
+	backoff := w.config.Backoff
+	for attempt := 1; err != nil && attempt < w.config.MaxAttempts; attempt++ {
+		logger.W(fmt.Sprintf("%s: failed to send %d metadata messages, retrying in %s", err.Error(), len(batch), backoff))
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+		err = w.writer.Flush()
+	}
+	if err == nil {
+		return nil
+	}
+
+	logger.E(fmt.Sprintf("%s: failed to send %d metadata messages after %d attempts", err.Error(), len(batch), w.config.MaxAttempts))
+	w.writer.Discard()
+	return w.writeDeadLetters(batch)
+}
+
+func (w *AsyncWriter) writeDeadLetters(batch []asyncMessage) error {
+	if w.deadLetter == nil {
+		logger.E(fmt.Sprintf("dropped %d metadata messages, dead letters are not configured", len(batch)))
+		return nil
+	}
+
+	w.deadLetterMu.Lock()
+	defer w.deadLetterMu.Unlock()
+	for _, msg := range batch {
+		if err := w.deadLetter.Write(msg.key, msg.value); err != nil {
+			return errors.Wrap(err, "failed to write metadata dead letters")
+		}
+	}
+	if err := w.deadLetter.Flush(); err != nil {
+		return errors.Wrap(err, "failed to write metadata dead letters")
+	}
+	return nil
+}
+
+// NewAsyncWriter starts a worker sending messages through writer, messages
+// failing to be sent go to deadLetter which can be nil
+func NewAsyncWriter(writer BufferedWriter, deadLetter models.MetadataWriter, config AsyncWriterConfig) *AsyncWriter {
+	if config.BatchSize < 1 {
+		config.BatchSize = 1
+	}
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaultFlushInterval
+	}
+	w := &AsyncWriter{
+		writer:     writer,
+		config:     config,
+		deadLetter: deadLetter,
+		queue:      make(chan asyncMessage, config.QueueSize),
+		flushes:    make(chan chan error),
+		stop:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
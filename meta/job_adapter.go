@@ -3,19 +3,22 @@ package meta
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
 	"github.com/odpf/optimus/models"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type JobAdapter struct {
 }
 
-func (a JobAdapter) buildUrn(projectSpec models.ProjectSpec, jobSpec models.JobSpec) string {
-	return fmt.Sprintf("%s::job/%s", projectSpec.Name, jobSpec.Name)
+// JobUrn identifies metadata of job in project
+func JobUrn(projectName, jobName string) string {
+	return fmt.Sprintf("%s::job/%s", projectName, jobName)
 }
 
 func (a JobAdapter) FromJobSpec(namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec) (*models.JobMetadata, error) {
@@ -47,30 +50,39 @@ func (a JobAdapter) FromJobSpec(namespaceSpec models.NamespaceSpec, jobSpec mode
 		jobDestination = jobDestinationResponse.Destination
 	}
 
+	slaMissDuration, err := jobSpec.Behavior.SLAMissDuration()
+	if err != nil {
+		return nil, err
+	}
+
 	taskMetadata := models.JobTaskMetadata{
-		Name:        taskSchema.Name,
-		Image:       taskSchema.Image,
-		Description: taskSchema.Description,
-		Destination: jobDestination,
-		Config:      jobSpec.Task.Config,
-		Window:      jobSpec.Task.Window,
-		Priority:    jobSpec.Task.Priority,
+		Name:          taskSchema.Name,
+		Version:       taskSchema.PluginVersion,
+		PinnedVersion: jobSpec.Task.Version,
+		Image:         taskSchema.Image,
+		Description:   taskSchema.Description,
+		Destination:   jobDestination,
+		Config:        jobSpec.Task.Config,
+		Window:        jobSpec.Task.Window,
+		Priority:      jobSpec.Task.Priority,
+		Resource:      jobSpec.Task.Resource,
 	}
 
 	resourceMetadata := models.JobMetadata{
-		Urn:          a.buildUrn(namespaceSpec.ProjectSpec, jobSpec),
-		Name:         jobSpec.Name,
-		Tenant:       namespaceSpec.ProjectSpec.Name,
-		Namespace:    namespaceSpec.Name,
-		Version:      jobSpec.Version,
-		Description:  jobSpec.Description,
-		Labels:       CompileSpecLabels(jobSpec),
-		Owner:        jobSpec.Owner,
-		Task:         taskMetadata,
-		Schedule:     jobSpec.Schedule,
-		Behavior:     jobSpec.Behavior,
-		Dependencies: []models.JobDependencyMetadata{},
-		Hooks:        []models.JobHookMetadata{},
+		Urn:             JobUrn(namespaceSpec.ProjectSpec.Name, jobSpec.Name),
+		Name:            jobSpec.Name,
+		Tenant:          namespaceSpec.ProjectSpec.Name,
+		Namespace:       namespaceSpec.Name,
+		Version:         jobSpec.Version,
+		Description:     jobSpec.Description,
+		Labels:          jobSpec.Labels,
+		Owner:           jobSpec.Owner,
+		Task:            taskMetadata,
+		Schedule:        jobSpec.Schedule,
+		Behavior:        jobSpec.Behavior,
+		SLAMissDuration: slaMissDuration,
+		Dependencies:    []models.JobDependencyMetadata{},
+		Hooks:           []models.JobHookMetadata{},
 	}
 
 	for _, depJob := range jobSpec.Dependencies {
@@ -80,16 +92,34 @@ func (a JobAdapter) FromJobSpec(namespaceSpec models.NamespaceSpec, jobSpec mode
 			Type:   depJob.Type.String(),
 		})
 	}
+	for _, depHTTP := range jobSpec.HTTPDependencies {
+		resourceMetadata.Dependencies = append(resourceMetadata.Dependencies, models.JobDependencyMetadata{
+			Job:  depHTTP.Name,
+			Type: models.JobSpecDependencyTypeExtra.String(),
+			URL:  depHTTP.URL,
+		})
+	}
+	for _, depExternal := range jobSpec.ExternalDependencies {
+		resourceMetadata.Dependencies = append(resourceMetadata.Dependencies, models.JobDependencyMetadata{
+			Tenant: depExternal.ProjectName,
+			Job:    depExternal.JobName,
+			Type:   models.JobSpecDependencyTypeExternal.String(),
+			URL:    depExternal.Host,
+		})
+	}
 
 	for _, hook := range jobSpec.Hooks {
 		schema := hook.Unit.Info()
 		resourceMetadata.Hooks = append(resourceMetadata.Hooks, models.JobHookMetadata{
-			Name:        schema.Name,
-			Image:       schema.Image,
-			Description: schema.Description,
-			Config:      hook.Config,
-			Type:        schema.HookType,
-			DependsOn:   schema.DependsOn,
+			Name:          schema.Name,
+			Version:       schema.PluginVersion,
+			PinnedVersion: hook.Version,
+			Image:         schema.Image,
+			Description:   schema.Description,
+			Config:        hook.Config,
+			Type:          schema.HookType,
+			DependsOn:     schema.DependsOn,
+			Resource:      hook.Resource,
 		})
 	}
 
@@ -110,6 +140,11 @@ func (a JobAdapter) CompileMessage(jobMetadata *models.JobMetadata) ([]byte, err
 		return nil, err
 	}
 
+	var slaMissDuration *durationpb.Duration
+	if jobMetadata.SLAMissDuration > 0 {
+		slaMissDuration = durationpb.New(jobMetadata.SLAMissDuration)
+	}
+
 	return proto.Marshal(&pb.JobMetadata{
 		Urn:         jobMetadata.Urn,
 		Name:        jobMetadata.Name,
@@ -124,10 +159,17 @@ func (a JobAdapter) CompileMessage(jobMetadata *models.JobMetadata) ([]byte, err
 		Behaviour: &pb.JobBehavior{
 			DependsOnPast: jobMetadata.Behavior.DependsOnPast,
 			Catchup:       jobMetadata.Behavior.CatchUp,
+			Retry: &pb.JobBehavior_Retry{
+				Count:              int32(jobMetadata.Behavior.Retry.Count),
+				Delay:              durationpb.New(jobMetadata.Behavior.Retry.Delay),
+				ExponentialBackoff: jobMetadata.Behavior.Retry.ExponentialBackoff,
+			},
+			Notify: a.compileNotifiers(jobMetadata),
 		},
-		Hooks:          a.compileHooks(jobMetadata),
-		Dependencies:   a.compileDependency(jobMetadata),
-		EventTimestamp: timestamp,
+		SlaMissDuration: slaMissDuration,
+		Hooks:           a.compileHooks(jobMetadata),
+		Dependencies:    a.compileDependency(jobMetadata),
+		EventTimestamp:  timestamp,
 	})
 }
 
@@ -147,13 +189,16 @@ func (a JobAdapter) compileTask(resource *models.JobMetadata) *pb.JobTask {
 	}
 
 	return &pb.JobTask{
-		Name:        resource.Task.Name,
-		Image:       resource.Task.Image,
-		Description: resource.Task.Description,
-		Destination: resource.Task.Destination,
-		Config:      taskConfig,
-		Window:      taskWindow,
-		Priority:    int32(resource.Task.Priority),
+		Name:          resource.Task.Name,
+		PluginVersion: resource.Task.Version,
+		PinnedVersion: resource.Task.PinnedVersion,
+		Image:         resource.Task.Image,
+		Description:   resource.Task.Description,
+		Destination:   resource.Task.Destination,
+		Config:        taskConfig,
+		Window:        taskWindow,
+		Priority:      int32(resource.Task.Priority),
+		Resource:      a.compileResource(resource.Task.Resource),
 	}
 }
 
@@ -167,17 +212,46 @@ func (a JobAdapter) compileHooks(resource *models.JobMetadata) (hooks []*pb.JobH
 			})
 		}
 		hooks = append(hooks, &pb.JobHook{
-			Name:        hook.Name,
-			Image:       hook.Image,
-			Description: hook.Description,
-			Config:      hookConfig,
-			Type:        hook.Type.String(),
-			DependsOn:   hook.DependsOn,
+			Name:          hook.Name,
+			PluginVersion: hook.Version,
+			PinnedVersion: hook.PinnedVersion,
+			Image:         hook.Image,
+			Description:   hook.Description,
+			Config:        hookConfig,
+			Type:          hook.Type.String(),
+			DependsOn:     hook.DependsOn,
+			Resource:      a.compileResource(hook.Resource),
+		})
+	}
+	return
+}
+
+// compileNotifiers keeps alerts of job, the events they are raised on and
+// channels they are sent to
+func (a JobAdapter) compileNotifiers(resource *models.JobMetadata) (notifiers []*pb.JobBehavior_Notifier) {
+	for _, notify := range resource.Behavior.Notify {
+		notifiers = append(notifiers, &pb.JobBehavior_Notifier{
+			On:       string(notify.On),
+			Config:   notify.Config,
+			Channels: notify.Channels,
 		})
 	}
 	return
 }
 
+func (a JobAdapter) compileResource(resource models.JobSpecResource) *pb.JobResource {
+	return &pb.JobResource{
+		Request: &pb.JobResource_Config{
+			Cpu:    resource.Request.CPU,
+			Memory: resource.Request.Memory,
+		},
+		Limit: &pb.JobResource_Config{
+			Cpu:    resource.Limit.CPU,
+			Memory: resource.Limit.Memory,
+		},
+	}
+}
+
 func (a JobAdapter) compileJobSchedule(resource *models.JobMetadata) (*pb.JobSchedule, error) {
 	scheduleStartDate := timestamppb.New(resource.Schedule.StartDate)
 
@@ -199,26 +273,24 @@ func (a JobAdapter) compileDependency(resource *models.JobMetadata) (dependencie
 			Tenant: dependency.Tenant,
 			Job:    dependency.Job,
 			Type:   dependency.Type,
+			Url:    dependency.URL,
 		})
 	}
 	return
 }
 
+// compileProtoLabels keeps the list of name and value items of metadata
+// messages, sorted by name so messages of unchanged jobs are identical
 func (a JobAdapter) compileProtoLabels(resource *models.JobMetadata) (labels []*pb.JobLabel) {
-	for _, config := range resource.Labels {
-		labels = append(labels, &pb.JobLabel{
-			Name:  config.Name,
-			Value: config.Value,
-		})
+	names := make([]string, 0, len(resource.Labels))
+	for name := range resource.Labels {
+		names = append(names, name)
 	}
-	return
-}
-
-func CompileSpecLabels(resource models.JobSpec) (labels []models.JobMetadataLabelItem) {
-	for k, v := range resource.Labels {
-		labels = append(labels, models.JobMetadataLabelItem{
-			Name:  k,
-			Value: v,
+	sort.Strings(names)
+	for _, name := range names {
+		labels = append(labels, &pb.JobLabel{
+			Name:  name,
+			Value: resource.Labels[name],
 		})
 	}
 	return
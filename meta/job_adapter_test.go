@@ -5,10 +5,12 @@ import (
 	"testing"
 	"time"
 
+	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
 	"github.com/odpf/optimus/meta"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestJobAdapter(t *testing.T) {
@@ -40,13 +42,24 @@ func TestJobAdapter(t *testing.T) {
 			Behavior: models.JobSpecBehavior{
 				CatchUp:       true,
 				DependsOnPast: false,
+				Retry: models.JobSpecBehaviorRetry{
+					Count:              3,
+					Delay:              2 * time.Minute,
+					ExponentialBackoff: true,
+				},
+				Notify: []models.JobSpecNotifier{{
+					On:       models.JobEventTypeSLAMiss,
+					Config:   map[string]string{"duration": "2h"},
+					Channels: []string{"slack://#data-alerts"},
+				}},
 			},
 			Schedule: models.JobSpecSchedule{
 				StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
 				Interval:  "* * * * *",
 			},
 			Task: models.JobSpecTask{
-				Unit: &models.Plugin{Base: execUnit, DependencyMod: depMod},
+				Unit:    &models.Plugin{Base: execUnit, DependencyMod: depMod},
+				Version: "0.1.0",
 				Config: models.JobSpecConfigs{
 					{
 						Name:  "do",
@@ -59,6 +72,9 @@ func TestJobAdapter(t *testing.T) {
 					Offset:     0,
 					TruncateTo: "d",
 				},
+				Resource: models.JobSpecResource{
+					Request: models.JobSpecResourceConfig{CPU: "500m", Memory: "2Gi"},
+				},
 			},
 			Assets: *models.JobAssets{}.New(
 				[]models.JobSpecAsset{
@@ -76,6 +92,15 @@ func TestJobAdapter(t *testing.T) {
 				},
 				Type: models.JobSpecDependencyTypeInter,
 			}},
+			HTTPDependencies: []models.JobSpecHTTPDependency{{
+				Name: "partner-feed",
+				URL:  "https://partner.example.io/feeds/status",
+			}},
+			ExternalDependencies: []models.JobSpecExternalDependency{{
+				Host:        "optimus.partner.example.io",
+				ProjectName: "partner_project",
+				JobName:     "job-3",
+			}},
 			Hooks: []models.JobSpecHook{
 				{
 					Config: models.JobSpecConfigs{
@@ -95,9 +120,10 @@ func TestJobAdapter(t *testing.T) {
 	}
 
 	execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
-		Name:        "bq2bq",
-		Image:       "image",
-		Description: "description",
+		Name:          "bq2bq",
+		Image:         "image",
+		Description:   "description",
+		PluginVersion: "0.1.0",
 	}, nil)
 	depMod.On("GenerateDestination", context.TODO(), models.GenerateDestinationRequest{
 		Config: models.PluginConfigs{}.FromJobSpec(jobSpecs[0].Task.Config),
@@ -121,26 +147,39 @@ func TestJobAdapter(t *testing.T) {
 			Tenant:      "humara-projectSpec",
 			Version:     100,
 			Description: "",
-			Labels:      meta.CompileSpecLabels(jobSpec1),
+			Labels:      map[string]string{"l1": "lv1"},
 			Owner:       "mee@mee",
 			Task: models.JobTaskMetadata{
-				Name:        "bq2bq",
-				Image:       "image",
-				Description: "description",
-				Destination: "destination_table",
+				Name:          "bq2bq",
+				Version:       "0.1.0",
+				PinnedVersion: "0.1.0",
+				Image:         "image",
+				Description:   "description",
+				Destination:   "destination_table",
 				Config: []models.JobSpecConfigItem{{
 					Name:  "do",
 					Value: "this",
 				}},
 				Window:   jobSpec1.Task.Window,
 				Priority: 2000,
+				Resource: jobSpec1.Task.Resource,
 			},
-			Schedule: jobSpec1.Schedule,
-			Behavior: jobSpec1.Behavior,
+			Schedule:        jobSpec1.Schedule,
+			Behavior:        jobSpec1.Behavior,
+			SLAMissDuration: 2 * time.Hour,
 			Dependencies: []models.JobDependencyMetadata{{
 				Tenant: "some_other_project",
 				Job:    "job-2",
 				Type:   models.JobSpecDependencyTypeInter.String(),
+			}, {
+				Job:  "partner-feed",
+				Type: models.JobSpecDependencyTypeExtra.String(),
+				URL:  "https://partner.example.io/feeds/status",
+			}, {
+				Tenant: "partner_project",
+				Job:    "job-3",
+				Type:   models.JobSpecDependencyTypeExternal.String(),
+				URL:    "optimus.partner.example.io",
 			}},
 			Hooks: []models.JobHookMetadata{{
 				Name:        "transporter",
@@ -165,7 +204,35 @@ func TestJobAdapter(t *testing.T) {
 		_, err = meta.JobAdapter{}.CompileKey(jobSpec1.Name)
 		assert.Nil(t, err)
 
-		_, err = meta.JobAdapter{}.CompileMessage(resourceMetadata)
+		message, err := meta.JobAdapter{}.CompileMessage(resourceMetadata)
 		assert.Nil(t, err)
+
+		var compiled pb.JobMetadata
+		assert.Nil(t, proto.Unmarshal(message, &compiled))
+		assert.Equal(t, int32(3), compiled.GetBehaviour().GetRetry().GetCount())
+		assert.Equal(t, 2*time.Minute, compiled.GetBehaviour().GetRetry().GetDelay().AsDuration())
+		assert.True(t, compiled.GetBehaviour().GetRetry().GetExponentialBackoff())
+		assert.Equal(t, 1, len(compiled.GetLabels()))
+		assert.Equal(t, "l1", compiled.GetLabels()[0].GetName())
+		assert.Equal(t, "lv1", compiled.GetLabels()[0].GetValue())
+		assert.Equal(t, "0.1.0", compiled.GetTask().GetPluginVersion())
+		assert.Equal(t, "0.1.0", compiled.GetTask().GetPinnedVersion())
+		assert.Equal(t, "", compiled.GetHooks()[0].GetPinnedVersion())
+		assert.Equal(t, "humara-namespaceSpec", compiled.GetNamespace())
+		assert.Equal(t, 2*time.Hour, compiled.GetSlaMissDuration().AsDuration())
+		assert.Equal(t, 1, len(compiled.GetBehaviour().GetNotify()))
+		assert.Equal(t, "sla_miss", compiled.GetBehaviour().GetNotify()[0].GetOn())
+		assert.Equal(t, map[string]string{"duration": "2h"}, compiled.GetBehaviour().GetNotify()[0].GetConfig())
+		assert.Equal(t, []string{"slack://#data-alerts"}, compiled.GetBehaviour().GetNotify()[0].GetChannels())
+	})
+	t.Run("should fail to build JobMetadata if sla of job is invalid", func(t *testing.T) {
+		jobSpec := jobSpecs[0]
+		jobSpec.Behavior.Notify = []models.JobSpecNotifier{{
+			On:     models.JobEventTypeSLAMiss,
+			Config: map[string]string{"duration": "2 hours"},
+		}}
+		_, err := meta.JobAdapter{}.FromJobSpec(namespaceSpec, jobSpec)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "failed to parse sla_miss duration 2 hours")
 	})
 }
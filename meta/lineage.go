@@ -0,0 +1,85 @@
+package meta
+
+import (
+	"context"
+
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+// LineageRecorder keeps the destination and dependencies generated for
+// jobs as their metadata is published, they are walked to serve lineage
+// graphs of jobs across projects
+type LineageRecorder struct {
+	repo store.JobLineageRepository
+}
+
+// Publish saves urns jobs read and write, replacing what was saved before
+func (r *LineageRecorder) Publish(namespaceSpec models.NamespaceSpec, jobSpecs []models.JobSpec, _ progress.Observer) error {
+	for _, jobSpec := range jobSpecs {
+		lineage, err := r.compile(context.Background(), namespaceSpec, jobSpec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compile lineage of job %s", jobSpec.Name)
+		}
+		if err := r.repo.Save(lineage); err != nil {
+			return errors.Wrapf(err, "failed to save lineage of job %s", jobSpec.Name)
+		}
+	}
+	return nil
+}
+
+// Tombstone forgets lineage of deleted jobs
+func (r *LineageRecorder) Tombstone(namespaceSpec models.NamespaceSpec, jobNames []string, _ progress.Observer) error {
+	for _, jobName := range jobNames {
+		if err := r.repo.Delete(namespaceSpec.ProjectSpec.Name, jobName); err != nil {
+			return errors.Wrapf(err, "failed to delete lineage of job %s", jobName)
+		}
+	}
+	return nil
+}
+
+// compile generates urns job reads and writes through its task plugin,
+// jobs of plugins which can't generate them have none
+func (r *LineageRecorder) compile(ctx context.Context, namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec) (models.JobLineage, error) {
+	lineage := models.JobLineage{
+		ProjectName:   namespaceSpec.ProjectSpec.Name,
+		NamespaceName: namespaceSpec.Name,
+		JobName:       jobSpec.Name,
+	}
+	if jobSpec.Task.Unit == nil || jobSpec.Task.Unit.DependencyMod == nil {
+		return lineage, nil
+	}
+	config := models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config)
+	assets := models.PluginAssets{}.FromJobSpec(jobSpec.Assets)
+
+	destination, err := jobSpec.Task.Unit.DependencyMod.GenerateDestination(ctx, models.GenerateDestinationRequest{
+		Config:  config,
+		Assets:  assets,
+		Project: namespaceSpec.ProjectSpec,
+	})
+	if err != nil {
+		return models.JobLineage{}, err
+	}
+	lineage.Destination = destination.Destination
+
+	dependencies, err := jobSpec.Task.Unit.DependencyMod.GenerateDependencies(ctx, models.GenerateDependenciesRequest{
+		Config:  config,
+		Assets:  assets,
+		Project: namespaceSpec.ProjectSpec,
+	})
+	if err != nil {
+		return models.JobLineage{}, err
+	}
+	lineage.Dependencies = dependencies.Dependencies
+	return lineage, nil
+}
+
+// NewLineageRecorder returns a models.MetadataService saving lineage of
+// jobs to repo
+func NewLineageRecorder(repo store.JobLineageRepository) *LineageRecorder {
+	return &LineageRecorder{
+		repo: repo,
+	}
+}
@@ -0,0 +1,90 @@
+package meta_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/odpf/optimus/meta"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineageRecorder(t *testing.T) {
+	projectSpec := models.ProjectSpec{
+		Name: "a-data-project",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	newJobSpec := func(depMod models.DependencyResolverMod) models.JobSpec {
+		return models.JobSpec{
+			Name: "a-data-job",
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{DependencyMod: depMod},
+				Config: models.JobSpecConfigs{
+					{Name: "TABLE", Value: "orders"},
+				},
+			},
+			Assets: *models.JobAssets{}.New([]models.JobSpecAsset{
+				{Name: "query.sql", Value: "select * from raw"},
+			}),
+		}
+	}
+
+	t.Run("should save destination and dependencies of jobs", func(t *testing.T) {
+		depMod := new(mock.DependencyResolverMod)
+		defer depMod.AssertExpectations(t)
+		jobSpec := newJobSpec(depMod)
+		config := models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config)
+		assets := models.PluginAssets{}.FromJobSpec(jobSpec.Assets)
+		depMod.On("GenerateDestination", context.Background(), models.GenerateDestinationRequest{
+			Config: config, Assets: assets, Project: projectSpec,
+		}).Return(&models.GenerateDestinationResponse{Destination: "bigquery://a-data-project:sales.orders"}, nil)
+		depMod.On("GenerateDependencies", context.Background(), models.GenerateDependenciesRequest{
+			Config: config, Assets: assets, Project: projectSpec,
+		}).Return(&models.GenerateDependenciesResponse{Dependencies: []string{"bigquery://a-data-project:sales.raw"}}, nil)
+
+		repo := memory.NewJobLineageRepository(memory.NewDB())
+		recorder := meta.NewLineageRecorder(repo)
+		assert.Nil(t, recorder.Publish(namespaceSpec, []models.JobSpec{jobSpec}, nil))
+
+		lineage, err := repo.GetByJob("a-data-project", "a-data-job")
+		assert.Nil(t, err)
+		assert.Equal(t, "dev-team-1", lineage.NamespaceName)
+		assert.Equal(t, "bigquery://a-data-project:sales.orders", lineage.Destination)
+		assert.Equal(t, []string{"bigquery://a-data-project:sales.raw"}, lineage.Dependencies)
+
+		assert.Nil(t, recorder.Tombstone(namespaceSpec, []string{"a-data-job"}, nil))
+		_, err = repo.GetByJob("a-data-project", "a-data-job")
+		assert.True(t, errors.Is(err, store.ErrResourceNotFound))
+	})
+	t.Run("should save jobs of plugins without dependency mod with no urns", func(t *testing.T) {
+		repo := memory.NewJobLineageRepository(memory.NewDB())
+		assert.Nil(t, meta.NewLineageRecorder(repo).Publish(namespaceSpec, []models.JobSpec{newJobSpec(nil)}, nil))
+
+		lineage, err := repo.GetByJob("a-data-project", "a-data-job")
+		assert.Nil(t, err)
+		assert.Empty(t, lineage.Destination)
+		assert.Empty(t, lineage.Dependencies)
+	})
+	t.Run("should fail if urns can't be generated", func(t *testing.T) {
+		depMod := new(mock.DependencyResolverMod)
+		defer depMod.AssertExpectations(t)
+		jobSpec := newJobSpec(depMod)
+		depMod.On("GenerateDestination", context.Background(), models.GenerateDestinationRequest{
+			Config:  models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+			Assets:  models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+			Project: projectSpec,
+		}).Return(&models.GenerateDestinationResponse{}, errors.New("bad query"))
+
+		repo := memory.NewJobLineageRepository(memory.NewDB())
+		err := meta.NewLineageRecorder(repo).Publish(namespaceSpec, []models.JobSpec{jobSpec}, nil)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "bad query")
+	})
+}
@@ -0,0 +1,47 @@
+package meta
+
+import (
+	"encoding/json"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// jobRecord is job metadata written as json by writers other than kafka
+type jobRecord struct {
+	Key     json.RawMessage `json:"key"`
+	Message json.RawMessage `json:"message"`
+}
+
+// newJobRecord decodes protobuf key and message of job metadata, a nil
+// message is a tombstone and is kept as null
+func newJobRecord(protobufKey, protobuf []byte) (jobRecord, error) {
+	var key pb.JobMetadataKey
+	if err := proto.Unmarshal(protobufKey, &key); err != nil {
+		return jobRecord{}, errors.Wrap(err, "failed to decode metadata key")
+	}
+	marshaler := protojson.MarshalOptions{UseProtoNames: true}
+	keyJSON, err := marshaler.Marshal(&key)
+	if err != nil {
+		return jobRecord{}, err
+	}
+	if protobuf == nil {
+		return jobRecord{Key: keyJSON}, nil
+	}
+
+	var message pb.JobMetadata
+	if err := proto.Unmarshal(protobuf, &message); err != nil {
+		return jobRecord{}, errors.Wrap(err, "failed to decode metadata message")
+	}
+
+	messageJSON, err := marshaler.Marshal(&message)
+	if err != nil {
+		return jobRecord{}, err
+	}
+	return jobRecord{
+		Key:     keyJSON,
+		Message: messageJSON,
+	}, nil
+}
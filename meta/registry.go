@@ -0,0 +1,230 @@
+package meta
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoprint"
+	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	registryContentType = "application/vnd.schemaregistry.v1+json"
+	registrySchemaType  = "PROTOBUF"
+
+	// registryMagicByte starts every message framed for schema registry
+	registryMagicByte = 0
+)
+
+// SchemaRegistry registers schemas with a confluent compatible schema
+// registry
+type SchemaRegistry struct {
+	url    string
+	client HTTPClient
+}
+
+// Register adds schema under subject if it isn't already, returns the id
+// of schema in registry
+func (r *SchemaRegistry) Register(ctx context.Context, subject, schema string) (int, error) {
+	payload, err := json.Marshal(map[string]string{
+		"schemaType": registrySchemaType,
+		"schema":     schema,
+	})
+	if err != nil {
+		return 0, err
+	}
+	endpoint := fmt.Sprintf("%s/subjects/%s/versions", r.url, url.PathEscape(subject))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", registryContentType)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to register schema of %s", subject)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, errors.Errorf("failed to register schema of %s: %s", subject, resp.Status)
+	}
+	var registered struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&registered); err != nil {
+		return 0, errors.Wrapf(err, "failed to decode schema id of %s", subject)
+	}
+	return registered.ID, nil
+}
+
+// NewSchemaRegistry returns a client of schema registry at url
+func NewSchemaRegistry(url string, client HTTPClient) *SchemaRegistry {
+	return &SchemaRegistry{
+		url:    strings.TrimSuffix(url, "/"),
+		client: client,
+	}
+}
+
+// metadataMessage is a generated metadata message, usable with both the
+// current and the legacy proto api
+type metadataMessage interface {
+	proto.Message
+	Reset()
+	String() string
+	ProtoMessage()
+}
+
+// RegistryWriter frames keys and messages with the ids of their schemas in
+// registry, as confluent serializers do, before writing them. Tombstones
+// are written as is
+type RegistryWriter struct {
+	BufferedWriter
+	registry *SchemaRegistry
+	schema   string
+	key      metadataMessage
+	message  metadataMessage
+	topic    string
+
+	mu sync.Mutex
+	// headers framing messages of each topic, subjects are named after
+	// topics
+	headers map[string]registryHeaders
+}
+
+type registryHeaders struct {
+	key     []byte
+	message []byte
+}
+
+func (w *RegistryWriter) Write(protobufkey []byte, protobuf []byte) error {
+	headers, err := w.headersOf(context.Background(), w.topic)
+	if err != nil {
+		return err
+	}
+	protobufkey, protobuf = headers.frame(protobufkey, protobuf)
+	return w.BufferedWriter.Write(protobufkey, protobuf)
+}
+
+// WriteRouted frames messages with ids of schemas registered for topic of
+// route, schemas are registered as messages are first routed to a topic
+func (w *RegistryWriter) WriteRouted(route Route, protobufkey []byte, protobuf []byte) error {
+	routed, ok := w.BufferedWriter.(RoutedWriter)
+	if !ok {
+		return errors.New("failed to route metadata: writer has a single topic")
+	}
+	topic := route.Topic
+	if topic == "" {
+		topic = w.topic
+	}
+	headers, err := w.headersOf(context.Background(), topic)
+	if err != nil {
+		return err
+	}
+	protobufkey, protobuf = headers.frame(protobufkey, protobuf)
+	return routed.WriteRouted(route, protobufkey, protobuf)
+}
+
+// headersOf registers schemas of key and message for topic if they
+// aren't yet, and returns headers framing them
+func (w *RegistryWriter) headersOf(ctx context.Context, topic string) (registryHeaders, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if headers, ok := w.headers[topic]; ok {
+		return headers, nil
+	}
+	keyID, err := w.registry.Register(ctx, topic+"-key", w.schema)
+	if err != nil {
+		return registryHeaders{}, err
+	}
+	messageID, err := w.registry.Register(ctx, topic+"-value", w.schema)
+	if err != nil {
+		return registryHeaders{}, err
+	}
+	headers := registryHeaders{
+		key:     registryHeader(keyID, w.key),
+		message: registryHeader(messageID, w.message),
+	}
+	w.headers[topic] = headers
+	return headers, nil
+}
+
+func (h registryHeaders) frame(protobufkey []byte, protobuf []byte) ([]byte, []byte) {
+	protobufkey = append(append([]byte{}, h.key...), protobufkey...)
+	if protobuf != nil {
+		protobuf = append(append([]byte{}, h.message...), protobuf...)
+	}
+	return protobufkey, protobuf
+}
+
+// NewRegistryWriter registers schema of job metadata key and message for
+// topic, subjects are named after the topic, and frames what is written
+// through writer with their ids. Topics with TopicProjectPlaceholder are
+// registered as messages are routed to topics of projects
+func NewRegistryWriter(ctx context.Context, writer BufferedWriter, registry *SchemaRegistry, topic string) (*RegistryWriter, error) {
+	return newRegistryWriter(ctx, writer, registry, topic, &pb.JobMetadataKey{}, &pb.JobMetadata{})
+}
+
+// NewResourceRegistryWriter is NewRegistryWriter for resource metadata
+func NewResourceRegistryWriter(ctx context.Context, writer BufferedWriter, registry *SchemaRegistry, topic string) (*RegistryWriter, error) {
+	return newRegistryWriter(ctx, writer, registry, topic, &pb.ResourceMetadataKey{}, &pb.ResourceMetadata{})
+}
+
+func newRegistryWriter(ctx context.Context, writer BufferedWriter, registry *SchemaRegistry, topic string,
+	key, message metadataMessage) (*RegistryWriter, error) {
+	schema, err := metadataSchema(message)
+	if err != nil {
+		return nil, err
+	}
+	w := &RegistryWriter{
+		BufferedWriter: writer,
+		registry:       registry,
+		schema:         schema,
+		key:            key,
+		message:        message,
+		topic:          topic,
+		headers:        map[string]registryHeaders{},
+	}
+	if !strings.Contains(topic, TopicProjectPlaceholder) {
+		if _, err := w.headersOf(ctx, topic); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// metadataSchema returns the proto file metadata messages are defined in
+func metadataSchema(message metadataMessage) (string, error) {
+	md, err := desc.LoadMessageDescriptorForMessage(message)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load metadata descriptor")
+	}
+	return (&protoprint.Printer{}).PrintProtoToString(md.GetFile())
+}
+
+// registryHeader returns the magic byte, schema id and indexes of msg in
+// its proto file, messages are top level so their path is a single index
+func registryHeader(schemaID int, msg proto.Message) []byte {
+	header := []byte{registryMagicByte, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(header[1:], uint32(schemaID))
+
+	index := msg.ProtoReflect().Descriptor().Index()
+	if index == 0 {
+		// path of the first message is shortened to a single zero
+		return append(header, 0)
+	}
+	varint := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(varint, 1)
+	header = append(header, varint[:n]...)
+	n = binary.PutVarint(varint, int64(index))
+	return append(header, varint[:n]...)
+}
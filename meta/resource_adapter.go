@@ -0,0 +1,117 @@
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+// resourceYamlSpecKey is the key spec of a resource is under in its yaml
+const resourceYamlSpecKey = "spec"
+
+type ResourceAdapter struct {
+}
+
+// ResourceUrn identifies metadata of resource of datastore in project
+func ResourceUrn(projectName, datastoreName, resourceName string) string {
+	return fmt.Sprintf("%s::resource/%s/%s", projectName, datastoreName, resourceName)
+}
+
+func (a ResourceAdapter) FromResourceSpec(namespaceSpec models.NamespaceSpec, resourceSpec models.ResourceSpec) (*models.ResourceMetadata, error) {
+	datastoreName := resourceSpec.Datastore.Name()
+	spec, err := a.compileSpec(resourceSpec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read spec of resource %s", resourceSpec.Name)
+	}
+
+	return &models.ResourceMetadata{
+		Urn:       ResourceUrn(namespaceSpec.ProjectSpec.Name, datastoreName, resourceSpec.Name),
+		Name:      resourceSpec.Name,
+		Tenant:    namespaceSpec.ProjectSpec.Name,
+		Namespace: namespaceSpec.Name,
+		Datastore: datastoreName,
+		Type:      resourceSpec.Type.String(),
+		Version:   resourceSpec.Version,
+		Labels:    resourceSpec.Labels,
+		Spec:      spec,
+	}, nil
+}
+
+func (a ResourceAdapter) CompileKey(urn string) ([]byte, error) {
+	return proto.Marshal(&pb.ResourceMetadataKey{
+		Urn: urn,
+	})
+}
+
+func (a ResourceAdapter) CompileMessage(resourceMetadata *models.ResourceMetadata) ([]byte, error) {
+	spec, err := structpb.NewStruct(resourceMetadata.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&pb.ResourceMetadata{
+		Urn:            resourceMetadata.Urn,
+		Name:           resourceMetadata.Name,
+		Tenant:         resourceMetadata.Tenant,
+		Namespace:      resourceMetadata.Namespace,
+		Datastore:      resourceMetadata.Datastore,
+		Type:           resourceMetadata.Type,
+		Version:        int32(resourceMetadata.Version),
+		Labels:         a.compileProtoLabels(resourceMetadata),
+		Spec:           spec,
+		EventTimestamp: timestamppb.New(time.Now()),
+	})
+}
+
+// compileSpec reads spec of resource from the yaml its datastore adapter
+// serializes it to, round tripped through json so it only holds values
+// a proto struct can
+func (a ResourceAdapter) compileSpec(resourceSpec models.ResourceSpec) (map[string]interface{}, error) {
+	typeController, ok := resourceSpec.Datastore.Types()[resourceSpec.Type]
+	if !ok {
+		return nil, errors.Errorf("unsupported resource type %s of datastore %s", resourceSpec.Type, resourceSpec.Datastore.Name())
+	}
+	resourceYaml, err := typeController.Adapter().ToYaml(resourceSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource map[string]interface{}
+	if err := yaml.Unmarshal(resourceYaml, &resource); err != nil {
+		return nil, err
+	}
+	specJSON, err := json.Marshal(resource[resourceYamlSpecKey])
+	if err != nil {
+		return nil, err
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(specJSON, &spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// compileProtoLabels sorts labels by name, as labels of jobs are
+func (a ResourceAdapter) compileProtoLabels(resource *models.ResourceMetadata) (labels []*pb.JobLabel) {
+	names := make([]string, 0, len(resource.Labels))
+	for name := range resource.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		labels = append(labels, &pb.JobLabel{
+			Name:  name,
+			Value: resource.Labels[name],
+		})
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package meta_test
+
+import (
+	"testing"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
+	"github.com/odpf/optimus/meta"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestResourceAdapter(t *testing.T) {
+	namespaceSpec := models.NamespaceSpec{
+		Name: "dev-team-1",
+		ProjectSpec: models.ProjectSpec{
+			Name: "a-data-project",
+		},
+	}
+	newResourceSpec := func() (models.ResourceSpec, *mock.Datastorer, *mock.DatastoreTypeAdapter) {
+		datastorer := new(mock.Datastorer)
+		adapter := new(mock.DatastoreTypeAdapter)
+		typeController := new(mock.DatastoreTypeController)
+		typeController.On("Adapter").Return(adapter)
+		datastorer.On("Name").Return("bigquery")
+		datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+			models.ResourceTypeTable: typeController,
+		})
+		return models.ResourceSpec{
+			Version:   2,
+			Name:      "proj.sales.orders",
+			Type:      models.ResourceTypeTable,
+			Datastore: datastorer,
+			Labels: map[string]string{
+				"owner":  "sales",
+				"domain": "orders",
+			},
+		}, datastorer, adapter
+	}
+
+	t.Run("should compile metadata of resource with its spec from yaml", func(t *testing.T) {
+		resourceSpec, datastorer, adapter := newResourceSpec()
+		adapter.On("ToYaml", resourceSpec).Return([]byte(`version: 2
+name: proj.sales.orders
+type: table
+spec:
+  description: orders of every day
+  partition:
+    field: created_at
+  schema:
+  - name: id
+    type: INTEGER
+`), nil)
+		defer adapter.AssertExpectations(t)
+		defer datastorer.AssertExpectations(t)
+
+		adapt := meta.ResourceAdapter{}
+		resourceMetadata, err := adapt.FromResourceSpec(namespaceSpec, resourceSpec)
+		assert.Nil(t, err)
+		assert.Equal(t, "a-data-project::resource/bigquery/proj.sales.orders", resourceMetadata.Urn)
+		assert.Equal(t, "bigquery", resourceMetadata.Datastore)
+		assert.Equal(t, "table", resourceMetadata.Type)
+		assert.Equal(t, "dev-team-1", resourceMetadata.Namespace)
+		assert.Equal(t, map[string]interface{}{
+			"description": "orders of every day",
+			"partition":   map[string]interface{}{"field": "created_at"},
+			"schema": []interface{}{
+				map[string]interface{}{"name": "id", "type": "INTEGER"},
+			},
+		}, resourceMetadata.Spec)
+
+		protoKey, err := adapt.CompileKey(resourceMetadata.Urn)
+		assert.Nil(t, err)
+		var key pb.ResourceMetadataKey
+		assert.Nil(t, proto.Unmarshal(protoKey, &key))
+		assert.Equal(t, resourceMetadata.Urn, key.Urn)
+
+		protoMsg, err := adapt.CompileMessage(resourceMetadata)
+		assert.Nil(t, err)
+		var msg pb.ResourceMetadata
+		assert.Nil(t, proto.Unmarshal(protoMsg, &msg))
+		assert.Equal(t, int32(2), msg.Version)
+		assert.Equal(t, "a-data-project", msg.Tenant)
+		assert.Equal(t, "domain", msg.Labels[0].Name)
+		assert.Equal(t, "owner", msg.Labels[1].Name)
+		assert.Equal(t, "orders of every day", msg.Spec.Fields["description"].GetStringValue())
+		assert.Equal(t, "created_at", msg.Spec.Fields["partition"].GetStructValue().Fields["field"].GetStringValue())
+		assert.NotNil(t, msg.EventTimestamp)
+	})
+	t.Run("should fail if datastore doesn't support type of resource", func(t *testing.T) {
+		resourceSpec, _, _ := newResourceSpec()
+		resourceSpec.Type = models.ResourceTypeView
+
+		_, err := meta.ResourceAdapter{}.FromResourceSpec(namespaceSpec, resourceSpec)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unsupported resource type view of datastore bigquery")
+	})
+}
@@ -0,0 +1,108 @@
+package meta
+
+import (
+	"strings"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// TopicProjectPlaceholder in a topic is replaced by the project of
+	// messages, routing metadata of each project to a topic of its own
+	TopicProjectPlaceholder = "{project}"
+
+	// PartitionKeyHeader carries the key partition of a message is picked
+	// by when it isn't the key of message
+	PartitionKeyHeader = "partition_key"
+
+	// PartitionKeyUrn partitions messages by their key, the urn of job or
+	// resource
+	PartitionKeyUrn = "urn"
+	// PartitionKeyTenant partitions messages by their project, keeping
+	// metadata of a project in order within one partition
+	PartitionKeyTenant = "tenant"
+)
+
+// Route is where a message is written to
+type Route struct {
+	// Topic of message, topic of the writer if empty
+	Topic string
+	// PartitionKey picks partition of message, its key does if empty
+	PartitionKey []byte
+}
+
+// RoutedWriter writes messages along their routes
+type RoutedWriter interface {
+	BufferedWriter
+	WriteRouted(route Route, protobufkey []byte, protobuf []byte) error
+}
+
+// Router routes metadata to the topic of its project when topic has
+// TopicProjectPlaceholder, and partitions it by urn or project as strategy
+// says. Keys of messages stay their urns so compaction and tombstones work
+// the same either way
+type Router struct {
+	RoutedWriter
+	topic    string
+	strategy string
+}
+
+func (r *Router) Write(protobufkey []byte, protobuf []byte) error {
+	route, err := r.route(protobufkey)
+	if err != nil {
+		return err
+	}
+	return r.RoutedWriter.WriteRouted(route, protobufkey, protobuf)
+}
+
+func (r *Router) route(protobufkey []byte) (Route, error) {
+	// keys of job and resource metadata both carry urn as their first field
+	var key pb.JobMetadataKey
+	if err := proto.Unmarshal(protobufkey, &key); err != nil {
+		return Route{}, errors.Wrap(err, "failed to decode metadata key")
+	}
+	tenant := UrnTenant(key.Urn)
+
+	var route Route
+	if strings.Contains(r.topic, TopicProjectPlaceholder) {
+		route.Topic = strings.ReplaceAll(r.topic, TopicProjectPlaceholder, tenant)
+	}
+	if r.strategy == PartitionKeyTenant {
+		route.PartitionKey = []byte(tenant)
+	}
+	return route, nil
+}
+
+// NewRouter returns a router writing through writer, topic is the one
+// messages are written to and may have TopicProjectPlaceholder
+func NewRouter(writer RoutedWriter, topic string, strategy string) *Router {
+	return &Router{
+		RoutedWriter: writer,
+		topic:        topic,
+		strategy:     strategy,
+	}
+}
+
+// UrnTenant returns the project a job or resource urn belongs to
+func UrnTenant(urn string) string {
+	return strings.SplitN(urn, "::", 2)[0]
+}
+
+// PartitionKeyBalancer hashes PartitionKeyHeader of messages, or their key
+// if they don't have it, so messages of the same key share a partition
+type PartitionKeyBalancer struct {
+	hash kafka.Hash
+}
+
+func (b *PartitionKeyBalancer) Balance(msg kafka.Message, partitions ...int) int {
+	for _, header := range msg.Headers {
+		if header.Key == PartitionKeyHeader {
+			msg.Key = header.Value
+			break
+		}
+	}
+	return b.hash.Balance(msg, partitions...)
+}
@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"github.com/hashicorp/go-multierror"
 	"github.com/odpf/optimus/core/progress"
 	"github.com/odpf/optimus/models"
 	"github.com/pkg/errors"
@@ -46,3 +47,99 @@ func (service Service) Publish(namespaceSpec models.NamespaceSpec, jobSpecs []mo
 
 	return nil
 }
+
+func (service Service) Tombstone(namespaceSpec models.NamespaceSpec, jobNames []string, po progress.Observer) error {
+	for _, jobName := range jobNames {
+		urn := JobUrn(namespaceSpec.ProjectSpec.Name, jobName)
+		protoKey, err := service.jobAdapter.CompileKey(urn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compile metadata proto key: %s", urn)
+		}
+
+		// a nil message is the tombstone of key
+		if err = service.writer.Write(protoKey, nil); err != nil {
+			return errors.Wrapf(err, "failed to write metadata tombstone: %s", urn)
+		}
+	}
+
+	return nil
+}
+
+// MultiService publishes metadata of jobs through all of its services,
+// metadata is published through every service even if some of them fail
+type MultiService []models.MetadataService
+
+func (services MultiService) Publish(namespaceSpec models.NamespaceSpec, jobSpecs []models.JobSpec, po progress.Observer) error {
+	var err error
+	for _, service := range services {
+		if publishErr := service.Publish(namespaceSpec, jobSpecs, po); publishErr != nil {
+			err = multierror.Append(err, publishErr)
+		}
+	}
+	return err
+}
+
+func (services MultiService) Tombstone(namespaceSpec models.NamespaceSpec, jobNames []string, po progress.Observer) error {
+	var err error
+	for _, service := range services {
+		if tombstoneErr := service.Tombstone(namespaceSpec, jobNames, po); tombstoneErr != nil {
+			err = multierror.Append(err, tombstoneErr)
+		}
+	}
+	return err
+}
+
+// ResourceService publishes metadata of datastore resources, usually to a
+// sink separate from metadata of jobs
+type ResourceService struct {
+	writer          models.MetadataWriter
+	resourceAdapter models.ResourceMetadataAdapter
+}
+
+func NewResourceService(writer models.MetadataWriter, adapter models.ResourceMetadataAdapter) *ResourceService {
+	return &ResourceService{
+		writer:          writer,
+		resourceAdapter: adapter,
+	}
+}
+
+func (service ResourceService) Publish(namespaceSpec models.NamespaceSpec, resourceSpecs []models.ResourceSpec, po progress.Observer) error {
+	for _, resourceSpec := range resourceSpecs {
+		resource, err := service.resourceAdapter.FromResourceSpec(namespaceSpec, resourceSpec)
+		if err != nil {
+			return err
+		}
+
+		protoKey, err := service.resourceAdapter.CompileKey(resource.Urn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compile metadata proto key: %s", resource.Urn)
+		}
+
+		protoMsg, err := service.resourceAdapter.CompileMessage(resource)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compile metadata proto message: %s", resource.Urn)
+		}
+
+		if err = service.writer.Write(protoKey, protoMsg); err != nil {
+			return errors.Wrapf(err, "failed to write metadata message: %s", resource.Urn)
+		}
+	}
+
+	return nil
+}
+
+func (service ResourceService) Tombstone(namespaceSpec models.NamespaceSpec, resourceSpecs []models.ResourceSpec, po progress.Observer) error {
+	for _, resourceSpec := range resourceSpecs {
+		urn := ResourceUrn(namespaceSpec.ProjectSpec.Name, resourceSpec.Datastore.Name(), resourceSpec.Name)
+		protoKey, err := service.resourceAdapter.CompileKey(urn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compile metadata proto key: %s", urn)
+		}
+
+		if err = service.writer.Write(protoKey, nil); err != nil {
+			return errors.Wrapf(err, "failed to write metadata tombstone: %s", urn)
+		}
+	}
+
+	return nil
+}
@@ -93,4 +93,90 @@ func TestService(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Equal(t, "failed to write metadata message: job-1: kafka is down", err.Error())
 	})
+	t.Run("should write tombstones of deleted jobs", func(t *testing.T) {
+		urn := meta.JobUrn(projectSpec.Name, "job-1")
+		protoKey := []byte("key")
+
+		builder := new(mock.MetaBuilder)
+		builder.On("CompileKey", urn).Return(protoKey, nil)
+		defer builder.AssertExpectations(t)
+
+		writer := new(mock.MetaWriter)
+		writer.On("Write", protoKey, []byte(nil)).Return(nil)
+		defer writer.AssertExpectations(t)
+
+		service := meta.NewService(writer, builder)
+		err := service.Tombstone(namespaceSpec, []string{"job-1"}, nil)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should publish through all services even if one of them fails", func(t *testing.T) {
+		po := new(mock.PipelineLogObserver)
+
+		failing := new(mock.MetaService)
+		failing.On("Publish", namespaceSpec, jobSpecs, po).Return(errors.New("kafka is down"))
+		defer failing.AssertExpectations(t)
+
+		other := new(mock.MetaService)
+		other.On("Publish", namespaceSpec, jobSpecs, po).Return(nil)
+		defer other.AssertExpectations(t)
+
+		err := meta.MultiService{failing, other}.Publish(namespaceSpec, jobSpecs, po)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "kafka is down")
+	})
+}
+
+func TestResourceService(t *testing.T) {
+	namespaceSpec := models.NamespaceSpec{
+		Name: "dev-team-1",
+		ProjectSpec: models.ProjectSpec{
+			Name: "a-data-project",
+		},
+	}
+
+	t.Run("should write tombstones of deleted resources", func(t *testing.T) {
+		datastorer := new(mock.Datastorer)
+		datastorer.On("Name").Return("bigquery")
+		defer datastorer.AssertExpectations(t)
+		resourceSpec := models.ResourceSpec{
+			Name:      "proj.sales.orders",
+			Type:      models.ResourceTypeTable,
+			Datastore: datastorer,
+		}
+
+		adapter := meta.ResourceAdapter{}
+		protoKey, err := adapter.CompileKey("a-data-project::resource/bigquery/proj.sales.orders")
+		assert.Nil(t, err)
+
+		writer := new(mock.MetaWriter)
+		writer.On("Write", protoKey, []byte(nil)).Return(nil)
+		defer writer.AssertExpectations(t)
+
+		service := meta.NewResourceService(writer, adapter)
+		assert.Nil(t, service.Tombstone(namespaceSpec, []models.ResourceSpec{resourceSpec}, nil))
+	})
+	t.Run("should return error if writing metadata of resource fails", func(t *testing.T) {
+		datastorer := new(mock.Datastorer)
+		datastorer.On("Name").Return("bigquery")
+		resourceSpec := models.ResourceSpec{
+			Name:      "proj.sales",
+			Type:      models.ResourceTypeDataset,
+			Datastore: datastorer,
+		}
+
+		adapter := meta.ResourceAdapter{}
+		protoKey, err := adapter.CompileKey("a-data-project::resource/bigquery/proj.sales")
+		assert.Nil(t, err)
+
+		writer := new(mock.MetaWriter)
+		writer.On("Write", protoKey, []byte(nil)).Return(errors.New("kafka is down"))
+		defer writer.AssertExpectations(t)
+
+		service := meta.NewResourceService(writer, adapter)
+		err = service.Tombstone(namespaceSpec, []models.ResourceSpec{resourceSpec}, nil)
+		assert.NotNil(t, err)
+		assert.Equal(t, "failed to write metadata tombstone: a-data-project::resource/bigquery/proj.sales: kafka is down", err.Error())
+	})
 }
@@ -0,0 +1,95 @@
+package meta
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// StreamWriter writes metadata as json records, one per line, to an
+// output like stdout or a file. Records are written as they come, the
+// ones failing to be written are kept till they are flushed
+type StreamWriter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	closer  io.Closer
+	pending []byte
+}
+
+// Write appends a record of message to the output
+func (w *StreamWriter) Write(protobufkey []byte, protobuf []byte) error {
+	record, err := newJobRecord(protobufkey, protobuf)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(append(w.pending, line...), '\n')
+	return w.flush()
+}
+
+// Flush writes records which failed to be written earlier
+func (w *StreamWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+func (w *StreamWriter) flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	if _, err := w.out.Write(w.pending); err != nil {
+		return err
+	}
+	w.pending = nil
+	return nil
+}
+
+// Discard drops records which failed to be written
+func (w *StreamWriter) Discard() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = nil
+}
+
+// Close closes the file opened by writer, outputs handed to writer are
+// left open
+func (w *StreamWriter) Close() error {
+	err := w.Flush()
+	if w.closer == nil {
+		return err
+	}
+	if closeErr := w.closer.Close(); closeErr != nil {
+		return closeErr
+	}
+	return err
+}
+
+// NewStreamWriter returns a writer of metadata to out e.g. os.Stdout
+func NewStreamWriter(out io.Writer) *StreamWriter {
+	return &StreamWriter{
+		out: out,
+	}
+}
+
+// NewFileWriter returns a writer appending metadata to file at path, file
+// is created if it doesn't exist
+func NewFileWriter(path string) (*StreamWriter, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open metadata file %s", path)
+	}
+	return &StreamWriter{
+		out:    file,
+		closer: file,
+	}, nil
+}
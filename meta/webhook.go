@@ -0,0 +1,97 @@
+package meta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// HTTPClient is used to post metadata to webhooks
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// WebhookWriter posts metadata as a json array of records to an http
+// endpoint, records are buffered and posted in batches of buffer size
+type WebhookWriter struct {
+	url        string
+	client     HTTPClient
+	bufferSize int
+
+	mu              sync.Mutex
+	bufferedRecords []jobRecord
+}
+
+// Write queues a message, buffered messages are posted once buffer is full
+func (w *WebhookWriter) Write(protobufkey []byte, protobuf []byte) error {
+	record, err := newJobRecord(protobufkey, protobuf)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.bufferedRecords = append(w.bufferedRecords, record)
+	if len(w.bufferedRecords) >= w.bufferSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Flush posts all the queued up messages
+func (w *WebhookWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+func (w *WebhookWriter) flush() error {
+	if len(w.bufferedRecords) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(w.bufferedRecords)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post metadata")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("failed to post metadata: %s", resp.Status)
+	}
+	w.bufferedRecords = nil
+	return nil
+}
+
+// Discard drops messages left in buffer after failing to be posted
+func (w *WebhookWriter) Discard() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.bufferedRecords = nil
+}
+
+// Close posts messages left in buffer
+func (w *WebhookWriter) Close() error {
+	return w.Flush()
+}
+
+// NewWebhookWriter returns a writer posting metadata to url
+func NewWebhookWriter(url string, client HTTPClient, buffSize int) *WebhookWriter {
+	return &WebhookWriter{
+		url:        url,
+		client:     client,
+		bufferSize: buffSize,
+	}
+}
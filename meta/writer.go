@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -19,6 +20,14 @@ type Writer struct {
 	client           KafkaWriter
 	bufferSize       int
 	bufferedMessages []kafka.Message
+	// bufferedTopics are topics of buffered messages, empty for the topic
+	// of client
+	bufferedTopics []string
+
+	// TopicClient creates clients of topics messages are routed to, they
+	// fail to be written if it isn't set
+	TopicClient  func(topic string) KafkaWriter
+	topicClients map[string]KafkaWriter
 }
 
 // NewWriter returns a instance for writer used over kafka client
@@ -27,17 +36,31 @@ func NewWriter(w KafkaWriter, buffSize int) *Writer {
 		client:           w,
 		bufferSize:       buffSize,
 		bufferedMessages: make([]kafka.Message, 0),
+		topicClients:     map[string]KafkaWriter{},
 	}
 }
 
 // Write push messages to kafka
 // this will throw an error if connection was closed in the middle of write
 func (w *Writer) Write(protobufkey []byte, protobuf []byte) error {
+	return w.WriteRouted(Route{}, protobufkey, protobuf)
+}
+
+// WriteRouted pushes message to topic of route, partition key of route is
+// sent as PartitionKeyHeader for PartitionKeyBalancer to pick by
+func (w *Writer) WriteRouted(route Route, protobufkey []byte, protobuf []byte) error {
+	if route.Topic != "" && w.TopicClient == nil {
+		return errors.Errorf("failed to route metadata to topic %s: writer has a single topic", route.Topic)
+	}
 	msg := kafka.Message{
 		Key:   protobufkey,
 		Value: protobuf,
 	}
+	if len(route.PartitionKey) > 0 {
+		msg.Headers = []kafka.Header{{Key: PartitionKeyHeader, Value: route.PartitionKey}}
+	}
 	w.bufferedMessages = append(w.bufferedMessages, msg)
+	w.bufferedTopics = append(w.bufferedTopics, route.Topic)
 
 	var err error
 	if len(w.bufferedMessages) >= w.bufferSize {
@@ -46,15 +69,85 @@ func (w *Writer) Write(protobufkey []byte, protobuf []byte) error {
 	return err
 }
 
-// Flush will push all the queued up messages to kafka
+// Flush will push all the queued up messages to kafka, messages of each
+// topic are pushed together. Messages of topics failing to be pushed stay
+// buffered
 func (w *Writer) Flush() error {
+	if len(w.bufferedMessages) == 0 {
+		return nil
+	}
+
+	var topics []string
+	byTopic := map[string][]kafka.Message{}
+	for i, msg := range w.bufferedMessages {
+		topic := w.bufferedTopics[i]
+		if _, ok := byTopic[topic]; !ok {
+			topics = append(topics, topic)
+		}
+		byTopic[topic] = append(byTopic[topic], msg)
+	}
+
 	var err error
+	var published int
+	failed := map[string]bool{}
+	for _, topic := range topics {
+		if writeErr := w.clientOf(topic).WriteMessages(context.Background(), byTopic[topic]...); writeErr != nil {
+			if err == nil {
+				err = writeErr
+			}
+			failed[topic] = true
+			continue
+		}
+		published += len(byTopic[topic])
+	}
+	if published > 0 {
+		fmt.Println("Published metadata for", published, "specs")
+	}
 
-	if len(w.bufferedMessages) > 0 {
-		err = w.client.WriteMessages(context.Background(), w.bufferedMessages...)
-		if err == nil {
-			w.bufferedMessages = make([]kafka.Message, 0)
-			fmt.Println("Published metadata for", len(w.bufferedMessages), "specs")
+	var messages []kafka.Message
+	var messageTopics []string
+	for i, msg := range w.bufferedMessages {
+		if failed[w.bufferedTopics[i]] {
+			messages = append(messages, msg)
+			messageTopics = append(messageTopics, w.bufferedTopics[i])
+		}
+	}
+	w.bufferedMessages = append(make([]kafka.Message, 0), messages...)
+	w.bufferedTopics = messageTopics
+	return err
+}
+
+// clientOf returns client of topic, creating it as messages are first
+// routed to topic
+func (w *Writer) clientOf(topic string) KafkaWriter {
+	if topic == "" {
+		return w.client
+	}
+	client, ok := w.topicClients[topic]
+	if !ok {
+		client = w.TopicClient(topic)
+		w.topicClients[topic] = client
+	}
+	return client
+}
+
+// Discard drops messages left in buffer after failing to be pushed
+func (w *Writer) Discard() {
+	w.bufferedMessages = make([]kafka.Message, 0)
+	w.bufferedTopics = nil
+}
+
+// Close pushes messages left in buffer and closes the kafka clients, the
+// first error is returned
+func (w *Writer) Close() error {
+	err := w.Flush()
+	clients := []KafkaWriter{w.client}
+	for _, client := range w.topicClients {
+		clients = append(clients, client)
+	}
+	for _, client := range clients {
+		if closeErr := client.Close(); closeErr != nil && err == nil {
+			err = closeErr
 		}
 	}
 	return err
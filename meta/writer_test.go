@@ -1,13 +1,24 @@
 package meta_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	pb "github.com/odpf/optimus/api/proto/odpf/metadata/optimus"
 	"github.com/odpf/optimus/meta"
 	"github.com/odpf/optimus/mock"
 	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestWriter(t *testing.T) {
@@ -60,3 +71,299 @@ func TestWriter(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+// jobMetadataMessage returns protobuf key and message of a job metadata
+func jobMetadataMessage(t *testing.T, urn string) ([]byte, []byte) {
+	key, err := meta.JobAdapter{}.CompileKey(urn)
+	assert.Nil(t, err)
+	msg, err := proto.Marshal(&pb.JobMetadata{Urn: urn, Name: "job"})
+	assert.Nil(t, err)
+	return key, msg
+}
+
+func TestWebhookWriter(t *testing.T) {
+	t.Run("should post buffered messages as json once buffer is full", func(t *testing.T) {
+		var posted [][]map[string]map[string]interface{}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var records []map[string]map[string]interface{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&records))
+			posted = append(posted, records)
+		}))
+		defer srv.Close()
+
+		writer := meta.NewWebhookWriter(srv.URL, srv.Client(), 2)
+		for _, urn := range []string{"a::job/one", "a::job/two", "a::job/three"} {
+			key, msg := jobMetadataMessage(t, urn)
+			assert.Nil(t, writer.Write(key, msg))
+		}
+		assert.Len(t, posted, 1)
+		assert.Equal(t, "a::job/one", posted[0][0]["key"]["urn"])
+		assert.Equal(t, "a::job/two", posted[0][1]["message"]["urn"])
+
+		assert.Nil(t, writer.Close())
+		assert.Len(t, posted, 2)
+		assert.Equal(t, "a::job/three", posted[1][0]["key"]["urn"])
+	})
+	t.Run("should keep messages if webhook fails", func(t *testing.T) {
+		failing := true
+		var posted int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var records []interface{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&records))
+			posted += len(records)
+		}))
+		defer srv.Close()
+
+		writer := meta.NewWebhookWriter(srv.URL, srv.Client(), 1)
+		key, msg := jobMetadataMessage(t, "a::job/one")
+		assert.NotNil(t, writer.Write(key, msg))
+
+		failing = false
+		assert.Nil(t, writer.Flush())
+		assert.Equal(t, 1, posted)
+	})
+}
+
+func TestStreamWriter(t *testing.T) {
+	t.Run("should write a json line per message", func(t *testing.T) {
+		var out bytes.Buffer
+		writer := meta.NewStreamWriter(&out)
+		for _, urn := range []string{"a::job/one", "a::job/two"} {
+			key, msg := jobMetadataMessage(t, urn)
+			assert.Nil(t, writer.Write(key, msg))
+		}
+		assert.Nil(t, writer.Close())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 2)
+		var record map[string]map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(lines[1]), &record))
+		assert.Equal(t, "a::job/two", record["key"]["urn"])
+		assert.Equal(t, "job", record["message"]["name"])
+	})
+	t.Run("should append messages to file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "metadata.jsonl")
+		for i := 0; i < 2; i++ {
+			writer, err := meta.NewFileWriter(path)
+			assert.Nil(t, err)
+			key, msg := jobMetadataMessage(t, "a::job/one")
+			assert.Nil(t, writer.Write(key, msg))
+			assert.Nil(t, writer.Close())
+		}
+
+		raw, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, strings.Count(string(raw), "\n"))
+	})
+	t.Run("should write tombstones with null message", func(t *testing.T) {
+		var out bytes.Buffer
+		key, _ := jobMetadataMessage(t, "a::job/one")
+		assert.Nil(t, meta.NewStreamWriter(&out).Write(key, nil))
+		assert.Equal(t, `{"key":{"urn":"a::job/one"},"message":null}`, strings.TrimSpace(out.String()))
+	})
+}
+
+// flakyOutput fails the first failures writes
+type flakyOutput struct {
+	bytes.Buffer
+	failures int
+}
+
+func (o *flakyOutput) Write(p []byte) (int, error) {
+	if o.failures > 0 {
+		o.failures--
+		return 0, errors.New("output is unavailable")
+	}
+	return o.Buffer.Write(p)
+}
+
+func TestAsyncWriter(t *testing.T) {
+	config := meta.AsyncWriterConfig{
+		BatchSize:     2,
+		QueueSize:     10,
+		FlushInterval: time.Hour,
+		MaxAttempts:   3,
+		Backoff:       time.Millisecond,
+	}
+	urns := []string{"a::job/one", "a::job/two", "a::job/three"}
+
+	t.Run("should send queued messages in batches", func(t *testing.T) {
+		out := &flakyOutput{}
+		writer := meta.NewAsyncWriter(meta.NewStreamWriter(out), nil, config)
+		for _, urn := range urns {
+			key, msg := jobMetadataMessage(t, urn)
+			assert.Nil(t, writer.Write(key, msg))
+		}
+		assert.Nil(t, writer.Flush())
+		assert.Equal(t, 3, strings.Count(out.String(), "\n"))
+		assert.Nil(t, writer.Close())
+	})
+	t.Run("should retry sending batches which fail", func(t *testing.T) {
+		out := &flakyOutput{failures: 2}
+		deadLetters := &flakyOutput{}
+		writer := meta.NewAsyncWriter(meta.NewStreamWriter(out), meta.NewStreamWriter(deadLetters), config)
+		key, msg := jobMetadataMessage(t, urns[0])
+		assert.Nil(t, writer.Write(key, msg))
+		assert.Nil(t, writer.Flush())
+		assert.Equal(t, 1, strings.Count(out.String(), "\n"))
+		assert.Empty(t, deadLetters.String())
+		assert.Nil(t, writer.Close())
+	})
+	t.Run("should write messages to dead letters once attempts run out", func(t *testing.T) {
+		out := &flakyOutput{failures: 100}
+		deadLetters := &flakyOutput{}
+		writer := meta.NewAsyncWriter(meta.NewStreamWriter(out), meta.NewStreamWriter(deadLetters), config)
+		for _, urn := range urns {
+			key, msg := jobMetadataMessage(t, urn)
+			assert.Nil(t, writer.Write(key, msg))
+		}
+		assert.Nil(t, writer.Flush())
+		assert.Empty(t, out.String())
+		assert.Equal(t, 3, strings.Count(deadLetters.String(), "\n"))
+
+		// dropped messages are not sent again once output recovers
+		out.failures = 0
+		assert.Nil(t, writer.Close())
+		assert.Empty(t, out.String())
+	})
+	t.Run("should not accept messages once closed", func(t *testing.T) {
+		writer := meta.NewAsyncWriter(meta.NewStreamWriter(&flakyOutput{}), nil, config)
+		assert.Nil(t, writer.Close())
+		key, msg := jobMetadataMessage(t, urns[0])
+		assert.Equal(t, meta.ErrWriterClosed, writer.Write(key, msg))
+	})
+}
+
+func TestRegistryWriter(t *testing.T) {
+	t.Run("should frame keys and messages with ids of registered schemas", func(t *testing.T) {
+		schemaIDs := map[string]int{"/subjects/job-log-key/versions": 7, "/subjects/job-log-value/versions": 8}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]string
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "PROTOBUF", req["schemaType"])
+			assert.Contains(t, req["schema"], "message JobMetadata {")
+			assert.Nil(t, json.NewEncoder(w).Encode(map[string]int{"id": schemaIDs[r.URL.Path]}))
+		}))
+		defer srv.Close()
+
+		key, msg := jobMetadataMessage(t, "a::job/one")
+		writer := new(mock.MetaWriter)
+		writer.On("Write", append([]byte{0, 0, 0, 0, 7, 0}, key...), append([]byte{0, 0, 0, 0, 8, 2, 2}, msg...)).Return(nil)
+		writer.On("Write", append([]byte{0, 0, 0, 0, 7, 0}, key...), []byte(nil)).Return(nil)
+		defer writer.AssertExpectations(t)
+
+		registry := meta.NewSchemaRegistry(srv.URL, srv.Client())
+		registryWriter, err := meta.NewRegistryWriter(context.Background(), writer, registry, "job-log")
+		assert.Nil(t, err)
+		assert.Nil(t, registryWriter.Write(key, msg))
+		assert.Nil(t, registryWriter.Write(key, nil))
+	})
+	t.Run("should fail if schema can't be registered", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer srv.Close()
+
+		registry := meta.NewSchemaRegistry(srv.URL, srv.Client())
+		_, err := meta.NewRegistryWriter(context.Background(), new(mock.MetaWriter), registry, "job-log")
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "409 Conflict")
+	})
+}
+
+func TestRouter(t *testing.T) {
+	t.Run("should route metadata of projects to their topics partitioned by tenant", func(t *testing.T) {
+		keyA, msgA := jobMetadataMessage(t, "a::job/one")
+		keyB, msgB := jobMetadataMessage(t, "b::job/two")
+
+		clients := map[string]*mock.MetaKafkaWriter{"job-log-a": {}, "job-log-b": {}}
+		clients["job-log-a"].On("WriteMessages", context.Background(), []kafka.Message{
+			{Key: keyA, Value: msgA, Headers: []kafka.Header{{Key: meta.PartitionKeyHeader, Value: []byte("a")}}},
+		}).Return(nil)
+		clients["job-log-b"].On("WriteMessages", context.Background(), []kafka.Message{
+			{Key: keyB, Value: msgB, Headers: []kafka.Header{{Key: meta.PartitionKeyHeader, Value: []byte("b")}}},
+		}).Return(nil)
+		for _, client := range clients {
+			client.On("Close").Return(nil)
+			defer client.AssertExpectations(t)
+		}
+		defaultClient := &mock.MetaKafkaWriter{}
+		defaultClient.On("Close").Return(nil)
+		defer defaultClient.AssertExpectations(t)
+
+		writer := meta.NewWriter(defaultClient, 10)
+		writer.TopicClient = func(topic string) meta.KafkaWriter {
+			return clients[topic]
+		}
+		router := meta.NewRouter(writer, "job-log-{project}", meta.PartitionKeyTenant)
+		assert.Nil(t, router.Write(keyA, msgA))
+		assert.Nil(t, router.Write(keyB, msgB))
+		assert.Nil(t, router.Close())
+	})
+	t.Run("should keep messages keyed by urn in the topic of writer", func(t *testing.T) {
+		key, msg := jobMetadataMessage(t, "a::job/one")
+		client := &mock.MetaKafkaWriter{}
+		client.On("WriteMessages", context.Background(), []kafka.Message{{Key: key, Value: msg}}).Return(nil)
+		defer client.AssertExpectations(t)
+
+		router := meta.NewRouter(meta.NewWriter(client, 1), "job-log", meta.PartitionKeyUrn)
+		assert.Nil(t, router.Write(key, msg))
+	})
+	t.Run("should fail to route messages if writer has a single topic", func(t *testing.T) {
+		key, msg := jobMetadataMessage(t, "a::job/one")
+		router := meta.NewRouter(meta.NewWriter(&mock.MetaKafkaWriter{}, 1), "job-log-{project}", meta.PartitionKeyUrn)
+		err := router.Write(key, msg)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "job-log-a")
+	})
+	t.Run("should register schemas of topics as messages are routed to them", func(t *testing.T) {
+		var subjects []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			subjects = append(subjects, r.URL.Path)
+			assert.Nil(t, json.NewEncoder(w).Encode(map[string]int{"id": 7}))
+		}))
+		defer srv.Close()
+
+		key, msg := jobMetadataMessage(t, "a::job/one")
+		client := &mock.MetaKafkaWriter{}
+		client.On("WriteMessages", context.Background(), []kafka.Message{
+			{Key: append([]byte{0, 0, 0, 0, 7, 0}, key...), Value: append([]byte{0, 0, 0, 0, 7, 2, 2}, msg...)},
+		}).Return(nil)
+		defer client.AssertExpectations(t)
+		writer := meta.NewWriter(&mock.MetaKafkaWriter{}, 1)
+		writer.TopicClient = func(topic string) meta.KafkaWriter {
+			assert.Equal(t, "job-log-a", topic)
+			return client
+		}
+
+		registry := meta.NewSchemaRegistry(srv.URL, srv.Client())
+		registryWriter, err := meta.NewRegistryWriter(context.Background(), writer, registry, "job-log-{project}")
+		assert.Nil(t, err)
+		assert.Empty(t, subjects)
+
+		router := meta.NewRouter(registryWriter, "job-log-{project}", meta.PartitionKeyUrn)
+		assert.Nil(t, router.Write(key, msg))
+		assert.Equal(t, []string{"/subjects/job-log-a-key/versions", "/subjects/job-log-a-value/versions"}, subjects)
+	})
+}
+
+func TestPartitionKeyBalancer(t *testing.T) {
+	balancer := &meta.PartitionKeyBalancer{}
+	partitions := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	header := []kafka.Header{{Key: meta.PartitionKeyHeader, Value: []byte("a-data-project")}}
+
+	partition := balancer.Balance(kafka.Message{Key: []byte("a::job/one"), Headers: header}, partitions...)
+	for _, urn := range []string{"a::job/two", "a::job/three", "a::job/four"} {
+		assert.Equal(t, partition, balancer.Balance(kafka.Message{Key: []byte(urn), Headers: header}, partitions...))
+	}
+	assert.Equal(t,
+		balancer.Balance(kafka.Message{Key: []byte("a::job/one")}, partitions...),
+		balancer.Balance(kafka.Message{Key: []byte("a::job/one")}, partitions...),
+	)
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditEventRepository struct {
+	mock.Mock
+}
+
+func (repo *AuditEventRepository) Save(event models.AuditEvent) error {
+	return repo.Called(event).Error(0)
+}
+
+func (repo *AuditEventRepository) GetAll(filter models.AuditEventFilter) ([]models.AuditEvent, error) {
+	args := repo.Called(filter)
+	return args.Get(0).([]models.AuditEvent), args.Error(1)
+}
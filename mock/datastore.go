@@ -3,6 +3,8 @@ package mock
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/odpf/optimus/store"
 
 	"github.com/odpf/optimus/core/progress"
@@ -37,6 +39,21 @@ func (d *Datastorer) ReadResource(ctx context.Context, inp models.ReadResourceRe
 func (d *Datastorer) DeleteResource(ctx context.Context, inp models.DeleteResourceRequest) error {
 	return d.Called(ctx, inp).Error(0)
 }
+func (d *Datastorer) ResourceURN(spec models.ResourceSpec) (string, error) {
+	args := d.Called(spec)
+	return args.Get(0).(string), args.Error(1)
+}
+func (d *Datastorer) BackupResource(ctx context.Context, inp models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	args := d.Called(ctx, inp)
+	return args.Get(0).(models.BackupResourceResponse), args.Error(1)
+}
+func (d *Datastorer) RestoreResource(ctx context.Context, inp models.RestoreResourceRequest) error {
+	return d.Called(ctx, inp).Error(0)
+}
+func (d *Datastorer) CheckResource(ctx context.Context, inp models.CheckResourceRequest) (models.CheckResourceResponse, error) {
+	args := d.Called(ctx, inp)
+	return args.Get(0).(models.CheckResourceResponse), args.Error(1)
+}
 
 type DatastoreTypeController struct {
 	mock.Mock
@@ -104,6 +121,11 @@ func (d *DatastoreService) DeleteResource(ctx context.Context, namespace models.
 	return d.Called(ctx, namespace, datastoreName, name).Error(1)
 }
 
+func (d *DatastoreService) CheckResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string) (models.CheckResourceResponse, error) {
+	args := d.Called(ctx, namespace, datastoreName, name)
+	return args.Get(0).(models.CheckResourceResponse), args.Error(1)
+}
+
 type SupportedDatastoreRepo struct {
 	mock.Mock
 }
@@ -164,12 +186,57 @@ type ProjectResourceSpecRepository struct {
 	mock.Mock
 }
 
-func (r *ProjectResourceSpecRepository) GetByName(s string) (models.ResourceSpec, error) {
+func (r *ProjectResourceSpecRepository) GetByName(s string) (models.ResourceSpec, models.NamespaceSpec, error) {
 	args := r.Called(s)
-	return args.Get(0).(models.ResourceSpec), args.Error(1)
+	return args.Get(0).(models.ResourceSpec), args.Get(1).(models.NamespaceSpec), args.Error(2)
 }
 
 func (r *ProjectResourceSpecRepository) GetAll() ([]models.ResourceSpec, error) {
 	args := r.Called()
 	return args.Get(0).([]models.ResourceSpec), args.Error(1)
 }
+
+type BackupRepoFactory struct {
+	mock.Mock
+}
+
+func (fac *BackupRepoFactory) New(projectSpec models.ProjectSpec) store.BackupRepository {
+	return fac.Called(projectSpec).Get(0).(store.BackupRepository)
+}
+
+type BackupRepository struct {
+	mock.Mock
+}
+
+func (repo *BackupRepository) Save(spec models.BackupSpec) error {
+	return repo.Called(spec).Error(0)
+}
+
+func (repo *BackupRepository) GetByID(id uuid.UUID) (models.BackupSpec, error) {
+	args := repo.Called(id)
+	return args.Get(0).(models.BackupSpec), args.Error(1)
+}
+
+func (repo *BackupRepository) GetAll(datastore string) ([]models.BackupSpec, error) {
+	args := repo.Called(datastore)
+	return args.Get(0).([]models.BackupSpec), args.Error(1)
+}
+
+type BackupService struct {
+	mock.Mock
+}
+
+func (srv *BackupService) Backup(ctx context.Context, request models.BackupRequest) (models.BackupResponse, error) {
+	args := srv.Called(ctx, request)
+	return args.Get(0).(models.BackupResponse), args.Error(1)
+}
+
+func (srv *BackupService) List(ctx context.Context, namespace models.NamespaceSpec, datastoreName string) ([]models.BackupSpec, error) {
+	args := srv.Called(ctx, namespace, datastoreName)
+	return args.Get(0).([]models.BackupSpec), args.Error(1)
+}
+
+func (srv *BackupService) Restore(ctx context.Context, namespace models.NamespaceSpec, datastoreName string, id uuid.UUID) (models.BackupSpec, error) {
+	args := srv.Called(ctx, namespace, datastoreName, id)
+	return args.Get(0).(models.BackupSpec), args.Error(1)
+}
@@ -0,0 +1,62 @@
+package mock
+
+import (
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/mock"
+)
+
+type DeployManager struct {
+	mock.Mock
+}
+
+func (dm *DeployManager) Deploy(req models.DeployRequest) (string, error) {
+	args := dm.Called(req)
+	return args.Get(0).(string), args.Error(1)
+}
+
+func (dm *DeployManager) GetStatus(id uuid.UUID) (models.DeploymentSpec, error) {
+	args := dm.Called(id)
+	return args.Get(0).(models.DeploymentSpec), args.Error(1)
+}
+
+func (dm *DeployManager) Record(deployment models.DeploymentSpec) error {
+	return dm.Called(deployment).Error(0)
+}
+
+func (dm *DeployManager) GetDeployment(projectSpec models.ProjectSpec, id uuid.UUID) (models.DeploymentSpec, error) {
+	args := dm.Called(projectSpec, id)
+	return args.Get(0).(models.DeploymentSpec), args.Error(1)
+}
+
+func (dm *DeployManager) ListDeployments(projectSpec models.ProjectSpec, filter models.DeploymentFilter) ([]models.DeploymentSpec, error) {
+	args := dm.Called(projectSpec, filter)
+	return args.Get(0).([]models.DeploymentSpec), args.Error(1)
+}
+
+type DeploymentRepoFactory struct {
+	mock.Mock
+}
+
+func (fac *DeploymentRepoFactory) New(projectSpec models.ProjectSpec) store.DeploymentRepository {
+	return fac.Called(projectSpec).Get(0).(store.DeploymentRepository)
+}
+
+type DeploymentRepository struct {
+	mock.Mock
+}
+
+func (repo *DeploymentRepository) Save(deployment models.DeploymentSpec) error {
+	return repo.Called(deployment).Error(0)
+}
+
+func (repo *DeploymentRepository) GetByID(id uuid.UUID) (models.DeploymentSpec, error) {
+	args := repo.Called(id)
+	return args.Get(0).(models.DeploymentSpec), args.Error(1)
+}
+
+func (repo *DeploymentRepository) GetAll(filter models.DeploymentFilter) ([]models.DeploymentSpec, error) {
+	args := repo.Called(filter)
+	return args.Get(0).([]models.DeploymentSpec), args.Error(1)
+}
@@ -2,7 +2,9 @@ package mock
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/odpf/optimus/job"
 
 	"github.com/odpf/optimus/core/tree"
@@ -70,6 +72,10 @@ func (repo *JobSpecRepository) Save(t models.JobSpec) error {
 	return repo.Called(t).Error(0)
 }
 
+func (repo *JobSpecRepository) SaveAll(specs []models.JobSpec) error {
+	return repo.Called(specs).Error(0)
+}
+
 func (repo *JobSpecRepository) GetByName(name string) (models.JobSpec, error) {
 	args := repo.Called(name)
 	if args.Get(0) != nil {
@@ -82,6 +88,31 @@ func (repo *JobSpecRepository) Delete(name string) error {
 	return repo.Called(name).Error(0)
 }
 
+func (repo *JobSpecRepository) Restore(name string) error {
+	return repo.Called(name).Error(0)
+}
+
+func (repo *JobSpecRepository) Purge(deletedBefore time.Time) ([]string, error) {
+	args := repo.Called(deletedBefore)
+	if args.Get(0) != nil {
+		return args.Get(0).([]string), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (repo *JobSpecRepository) GetVersions(name string) ([]models.JobSpecVersion, error) {
+	args := repo.Called(name)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.JobSpecVersion), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (repo *JobSpecRepository) GetVersion(name string, revision int) (models.JobSpecVersion, error) {
+	args := repo.Called(name, revision)
+	return args.Get(0).(models.JobSpecVersion), args.Error(1)
+}
+
 func (repo *JobSpecRepository) GetAll() ([]models.JobSpec, error) {
 	args := repo.Called()
 	if args.Get(0) != nil {
@@ -103,8 +134,8 @@ type JobRepoFactory struct {
 	mock.Mock
 }
 
-func (repo *JobRepoFactory) New(ctx context.Context, proj models.ProjectSpec) (store.JobRepository, error) {
-	args := repo.Called(ctx, proj)
+func (repo *JobRepoFactory) New(ctx context.Context, storagePath, storageSecret, jobsExtension string) (store.JobRepository, error) {
+	args := repo.Called(ctx, storagePath, storageSecret, jobsExtension)
 	return args.Get(0).(store.JobRepository), args.Error(1)
 }
 
@@ -156,6 +187,11 @@ func (srv *JobService) Create(spec2 models.NamespaceSpec, spec models.JobSpec) e
 	return args.Error(0)
 }
 
+func (srv *JobService) CreateAll(namespace models.NamespaceSpec, specs []models.JobSpec) error {
+	args := srv.Called(namespace, specs)
+	return args.Error(0)
+}
+
 func (srv *JobService) GetByName(s string, spec models.NamespaceSpec) (models.JobSpec, error) {
 	args := srv.Called(s, spec)
 	return args.Get(0).(models.JobSpec), args.Error(1)
@@ -166,8 +202,13 @@ func (srv *JobService) Dump(spec2 models.NamespaceSpec, spec3 models.JobSpec) (m
 	return args.Get(0).(models.Job), args.Error(1)
 }
 
+func (srv *JobService) Compile(namespace models.NamespaceSpec, jobSpec models.JobSpec) (models.Job, error) {
+	args := srv.Called(namespace, jobSpec)
+	return args.Get(0).(models.Job), args.Error(1)
+}
+
 func (srv *JobService) KeepOnly(spec models.NamespaceSpec, specs []models.JobSpec, observer progress.Observer) error {
-	args := srv.Called(spec, specs)
+	args := srv.Called(spec, specs, observer)
 	return args.Error(0)
 }
 
@@ -196,6 +237,34 @@ func (j *JobService) Delete(ctx context.Context, c models.NamespaceSpec, job mod
 	return args.Error(0)
 }
 
+func (j *JobService) SetPaused(ctx context.Context, c models.NamespaceSpec, job models.JobSpec, paused bool) error {
+	args := j.Called(ctx, c, job, paused)
+	return args.Error(0)
+}
+
+func (j *JobService) Restore(ctx context.Context, c models.NamespaceSpec, name string) (models.JobSpec, error) {
+	args := j.Called(ctx, c, name)
+	return args.Get(0).(models.JobSpec), args.Error(1)
+}
+
+func (j *JobService) GetVersions(c models.NamespaceSpec, name string) ([]models.JobSpecVersion, error) {
+	args := j.Called(c, name)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.JobSpecVersion), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (j *JobService) GetVersion(c models.NamespaceSpec, name string, revision int) (models.JobSpecVersion, error) {
+	args := j.Called(c, name, revision)
+	return args.Get(0).(models.JobSpecVersion), args.Error(1)
+}
+
+func (j *JobService) Rollback(ctx context.Context, c models.NamespaceSpec, name string, revision int) (models.JobSpec, error) {
+	args := j.Called(ctx, c, name, revision)
+	return args.Get(0).(models.JobSpec), args.Error(1)
+}
+
 func (j *JobService) ReplayDryRun(replayRequest *models.ReplayWorkerRequest) (*tree.TreeNode, error) {
 	args := j.Called(replayRequest)
 	return args.Get(0).(*tree.TreeNode), args.Error(1)
@@ -206,6 +275,16 @@ func (j *JobService) Replay(ctx context.Context, replayRequest *models.ReplayWor
 	return args.Get(0).(string), args.Error(1)
 }
 
+func (j *JobService) GetReplay(jobSpec models.JobSpec, replayID uuid.UUID) (models.ReplaySpec, error) {
+	args := j.Called(jobSpec, replayID)
+	return args.Get(0).(models.ReplaySpec), args.Error(1)
+}
+
+func (j *JobService) GetReplayList(projectID uuid.UUID) ([]models.ReplaySpec, error) {
+	args := j.Called(projectID)
+	return args.Get(0).([]models.ReplaySpec), args.Error(1)
+}
+
 type Compiler struct {
 	mock.Mock
 }
@@ -225,6 +304,14 @@ func (srv *DependencyResolver) Resolve(projectSpec models.ProjectSpec, projectJo
 	return args.Get(0).(models.JobSpec), args.Error(1)
 }
 
+type ExternalJobChecker struct {
+	mock.Mock
+}
+
+func (srv *ExternalJobChecker) Check(ctx context.Context, dependency models.JobSpecExternalDependency) error {
+	return srv.Called(ctx, dependency).Error(0)
+}
+
 type PriorityResolver struct {
 	mock.Mock
 }
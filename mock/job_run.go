@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/mock"
+)
+
+type JobRunRepoFactory struct {
+	mock.Mock
+}
+
+func (fac *JobRunRepoFactory) New(projectSpec models.ProjectSpec) store.JobRunRepository {
+	return fac.Called(projectSpec).Get(0).(store.JobRunRepository)
+}
+
+type JobRunRepository struct {
+	mock.Mock
+}
+
+func (repo *JobRunRepository) Save(run models.JobRun) error {
+	return repo.Called(run).Error(0)
+}
+
+func (repo *JobRunRepository) GetAll(filter models.JobRunFilter) ([]models.JobRun, error) {
+	args := repo.Called(filter)
+	return args.Get(0).([]models.JobRun), args.Error(1)
+}
+
+func (repo *JobRunRepository) CountByState(filter models.JobRunFilter) (map[models.JobStatusState]int, error) {
+	args := repo.Called(filter)
+	return args.Get(0).(map[models.JobStatusState]int), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/mock"
+)
+
+type MacroRepoFactory struct {
+	mock.Mock
+}
+
+func (fac *MacroRepoFactory) New(p models.ProjectSpec) store.MacroRepository {
+	args := fac.Called(p)
+	return args.Get(0).(store.MacroRepository)
+}
+
+type MacroRepository struct {
+	mock.Mock
+}
+
+func (repo *MacroRepository) Save(macro models.Macro) error {
+	return repo.Called(macro).Error(0)
+}
+
+func (repo *MacroRepository) Delete(name, namespace string) error {
+	return repo.Called(name, namespace).Error(0)
+}
+
+func (repo *MacroRepository) GetAll() ([]models.Macro, error) {
+	args := repo.Called()
+	return args.Get(0).([]models.Macro), args.Error(1)
+}
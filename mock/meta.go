@@ -27,6 +27,23 @@ func (srv *MetaService) Publish(namespaceSpec models.NamespaceSpec, jobSpecs []m
 	return srv.Called(namespaceSpec, jobSpecs, po).Error(0)
 }
 
+func (srv *MetaService) Tombstone(namespaceSpec models.NamespaceSpec, jobNames []string, po progress.Observer) error {
+	return srv.Called(namespaceSpec, jobNames, po).Error(0)
+}
+
+// ResourceMetaService publishes metadata of datastore resources
+type ResourceMetaService struct {
+	mock.Mock
+}
+
+func (srv *ResourceMetaService) Publish(namespaceSpec models.NamespaceSpec, resourceSpecs []models.ResourceSpec, po progress.Observer) error {
+	return srv.Called(namespaceSpec, resourceSpecs, po).Error(0)
+}
+
+func (srv *ResourceMetaService) Tombstone(namespaceSpec models.NamespaceSpec, resourceSpecs []models.ResourceSpec, po progress.Observer) error {
+	return srv.Called(namespaceSpec, resourceSpecs, po).Error(0)
+}
+
 type MetaWriter struct {
 	mock.Mock
 }
@@ -39,6 +56,14 @@ func (w *MetaWriter) Flush() error {
 	return w.Called().Error(0)
 }
 
+func (w *MetaWriter) Close() error {
+	return w.Called().Error(0)
+}
+
+func (w *MetaWriter) Discard() {
+	w.Called()
+}
+
 type MetaKafkaWriter struct {
 	mock.Mock
 }
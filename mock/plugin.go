@@ -20,17 +20,24 @@ func (repo *SupportedPluginRepo) GetByName(s string) (*models.Plugin, error) {
 	return args.Get(0).(*models.Plugin), args.Error(1)
 }
 
+func (repo *SupportedPluginRepo) GetByNameAndVersion(name, version string) (*models.Plugin, error) {
+	args := repo.Called(name, version)
+	return args.Get(0).(*models.Plugin), args.Error(1)
+}
+
 func (repo *SupportedPluginRepo) GetAll() []*models.Plugin {
 	args := repo.Called()
 	return args.Get(0).([]*models.Plugin)
 }
 
 func (repo *SupportedPluginRepo) GetTasks() []*models.Plugin {
-	panic("implement me")
+	args := repo.Called()
+	return args.Get(0).([]*models.Plugin)
 }
 
 func (repo *SupportedPluginRepo) GetHooks() []*models.Plugin {
-	panic("implement me")
+	args := repo.Called()
+	return args.Get(0).([]*models.Plugin)
 }
 
 func (repo *SupportedPluginRepo) GetCommandLines() []models.CommandLineMod {
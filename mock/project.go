@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"time"
+
 	"github.com/odpf/optimus/core/progress"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
@@ -25,6 +27,22 @@ func (pr *ProjectRepository) GetAll() ([]models.ProjectSpec, error) {
 	return args.Get(0).([]models.ProjectSpec), args.Error(1)
 }
 
+func (pr *ProjectRepository) Delete(name string) error {
+	return pr.Called(name).Error(0)
+}
+
+func (pr *ProjectRepository) Restore(name string) error {
+	return pr.Called(name).Error(0)
+}
+
+func (pr *ProjectRepository) Purge(deletedBefore time.Time) ([]string, error) {
+	args := pr.Called(deletedBefore)
+	if args.Get(0) != nil {
+		return args.Get(0).([]string), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 type ProjectRepoFactory struct {
 	mock.Mock
 }
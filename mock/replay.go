@@ -36,6 +36,11 @@ func (repo *ReplayRepository) GetByJobIDAndStatus(jobID uuid.UUID, status []stri
 	return args.Get(0).([]models.ReplaySpec), args.Error(1)
 }
 
+func (repo *ReplayRepository) GetByProjectID(projectID uuid.UUID) ([]models.ReplaySpec, error) {
+	args := repo.Called(projectID)
+	return args.Get(0).([]models.ReplaySpec), args.Error(1)
+}
+
 type ReplaySpecRepoFactory struct {
 	mock.Mock
 }
@@ -53,6 +58,11 @@ func (rm *ReplayManager) Replay(ctx context.Context, reqInput *models.ReplayWork
 	return args.Get(0).(string), args.Error(1)
 }
 
+func (rm *ReplayManager) GetReplay(jobSpec models.JobSpec, replayID uuid.UUID) (models.ReplaySpec, error) {
+	args := rm.Called(jobSpec, replayID)
+	return args.Get(0).(models.ReplaySpec), args.Error(1)
+}
+
 func (rm *ReplayManager) Init() {
 	rm.Called()
 	return
@@ -66,3 +76,8 @@ func (rm *ReplayWorker) Process(ctx context.Context, replayRequest *models.Repla
 	args := rm.Called(ctx, replayRequest)
 	return args.Error(0)
 }
+
+func (rm *ReplayManager) GetReplayList(projectID uuid.UUID) ([]models.ReplaySpec, error) {
+	args := rm.Called(projectID)
+	return args.Get(0).([]models.ReplaySpec), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleBindingRepoFactory struct {
+	mock.Mock
+}
+
+func (fac *RoleBindingRepoFactory) New(p models.ProjectSpec) store.RoleBindingRepository {
+	args := fac.Called(p)
+	return args.Get(0).(store.RoleBindingRepository)
+}
+
+type RoleBindingRepository struct {
+	mock.Mock
+}
+
+func (repo *RoleBindingRepository) Save(binding models.RoleBinding) error {
+	return repo.Called(binding).Error(0)
+}
+
+func (repo *RoleBindingRepository) Delete(subject, namespace string) error {
+	return repo.Called(subject, namespace).Error(0)
+}
+
+func (repo *RoleBindingRepository) GetAll() ([]models.RoleBinding, error) {
+	args := repo.Called()
+	return args.Get(0).([]models.RoleBinding), args.Error(1)
+}
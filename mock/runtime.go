@@ -41,3 +41,121 @@ func (r *RuntimeService_DeployJobSpecificationServer) SendMsg(m interface{}) err
 func (r *RuntimeService_DeployJobSpecificationServer) RecvMsg(m interface{}) error {
 	panic("implement me")
 }
+
+type RuntimeService_ImportJobSpecificationsServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) Send(response *pb.DeployJobSpecificationResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) Recv() (*pb.ImportJobSpecificationsRequest, error) {
+	args := r.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ImportJobSpecificationsRequest), args.Error(1)
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportJobSpecificationsServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+type RuntimeService_DeployJobSpecificationStreamServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) Send(response *pb.DeployJobSpecificationResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) Recv() (*pb.DeployJobSpecificationRequest, error) {
+	args := r.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.DeployJobSpecificationRequest), args.Error(1)
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployJobSpecificationStreamServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+type RuntimeService_ReplayStatusServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_ReplayStatusServer) Send(response *pb.ReplayStatusResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_ReplayStatusServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReplayStatusServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReplayStatusServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReplayStatusServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_ReplayStatusServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReplayStatusServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
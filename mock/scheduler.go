@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/odpf/optimus/core/progress"
 	"github.com/odpf/optimus/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -28,8 +29,27 @@ func (ms *Scheduler) GetJobsExtension() string {
 	return ""
 }
 
-func (ms *Scheduler) Bootstrap(ctx context.Context, projectSpec models.ProjectSpec) error {
-	return ms.Called(ctx, projectSpec).Error(0)
+func (ms *Scheduler) Bootstrap(ctx context.Context, projectSpec models.ProjectSpec, obs progress.Observer) error {
+	return ms.Called(ctx, projectSpec, obs).Error(0)
+}
+
+func (ms *Scheduler) ValidateProject(projectSpec models.ProjectSpec) error {
+	return ms.Called(projectSpec).Error(0)
+}
+
+func (ms *Scheduler) DeployJobs(ctx context.Context, namespace models.NamespaceSpec, jobs []models.JobSpec,
+	obs progress.Observer) error {
+	return ms.Called(ctx, namespace, jobs, obs).Error(0)
+}
+
+func (ms *Scheduler) DeleteJobs(ctx context.Context, namespace models.NamespaceSpec, jobNames []string,
+	obs progress.Observer) error {
+	return ms.Called(ctx, namespace, jobNames, obs).Error(0)
+}
+
+func (ms *Scheduler) ListJobs(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	args := ms.Called(ctx, namespace)
+	return args.Get(0).([]string), args.Error(1)
 }
 
 func (ms *Scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus, error) {
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent records a call of a mutating rpc
+type AuditEvent struct {
+	ID          uuid.UUID
+	ProjectName string
+	Namespace   string
+	// Method is the rpc called, e.g. DeployJobSpecification
+	Method string
+	// Actor is the identity of the caller, empty if not known
+	Actor string
+	// Summary describes the requested change, it never contains values
+	// of secrets
+	Summary string
+	Success bool
+	// Error returned to the caller if the call failed
+	Error     string
+	CreatedAt time.Time
+}
+
+// AuditEventFilter narrows down the audit events read from store
+type AuditEventFilter struct {
+	ProjectName string
+	// Namespace, Actor and Method are matched exactly if set
+	Namespace string
+	Actor     string
+	Method    string
+	// Since and Until bound the time events were recorded at if set
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of events read, most recent first
+	Limit int
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// BackupConfigDataset is the dataset, or the like of datastore, backups
+	// are kept in
+	BackupConfigDataset = "dataset"
+	// BackupConfigPrefix is prepended to names of backups
+	BackupConfigPrefix = "prefix"
+	// BackupConfigTTL is how long backups are kept, e.g. 720h
+	BackupConfigTTL = "ttl"
+)
+
+// BackupRequest asks to back up a resource of a namespace and, unless
+// downstream is ignored, the resources written by jobs reading it
+type BackupRequest struct {
+	ResourceName     string
+	Namespace        NamespaceSpec
+	Datastore        string
+	Description      string
+	IgnoreDownstream bool
+	// Config overrides defaults of datastore, see BackupConfigDataset,
+	// BackupConfigPrefix and BackupConfigTTL
+	Config map[string]string
+	// DryRun resolves resources to be backed up without backing them up
+	DryRun bool
+}
+
+// BackupResult is where a resource is backed up to
+type BackupResult struct {
+	ResourceName string `json:"resource_name"`
+	URN          string `json:"urn"`
+}
+
+// BackupSpec is a recorded backup of a resource and the ones downstream
+// of it
+type BackupSpec struct {
+	ID           uuid.UUID
+	ResourceName string
+	Datastore    string
+	Description  string
+	Config       map[string]string
+	Results      []BackupResult
+	CreatedAt    time.Time
+}
+
+// BackupResponse is the backup taken, or to be taken on a dry run, along
+// with urns downstream which aren't resources of the project
+type BackupResponse struct {
+	Backup           BackupSpec
+	IgnoredResources []string
+}
+
+type BackupResourceRequest struct {
+	Resource   ResourceSpec
+	Project    ProjectSpec
+	Backup     BackupSpec
+	BackupTime time.Time
+}
+
+type BackupResourceResponse struct {
+	// URN of the copy resource is backed up to
+	URN string
+}
+
+type RestoreResourceRequest struct {
+	Resource ResourceSpec
+	Project  ProjectSpec
+	// URN of the copy resource is restored from
+	URN string
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ProjectCalendarHolidays is a comma separated list of dates formatted
+	// as JobDatetimeLayout that are not working days
+	ProjectCalendarHolidays = "CALENDAR_HOLIDAYS"
+	// ProjectCalendarWeekend is a comma separated list of week days that are
+	// not working days, defaults to saturday,sunday
+	ProjectCalendarWeekend = "CALENDAR_WEEKEND"
+	// ProjectCalendarFiscalYearStart is the month, 1 to 12, fiscal year
+	// starts in, defaults to january
+	ProjectCalendarFiscalYearStart = "CALENDAR_FISCAL_YEAR_START"
+
+	// JobSpecTaskWindowModifierPreviousWorkingDay shifts a window back a day
+	// at a time till it starts on a working day
+	JobSpecTaskWindowModifierPreviousWorkingDay = "previous_working_day"
+)
+
+var (
+	// JobSpecTaskWindowModifiers are the supported modifiers of a window
+	JobSpecTaskWindowModifiers = []string{JobSpecTaskWindowModifierPreviousWorkingDay}
+
+	defaultWeekend = []time.Weekday{time.Saturday, time.Sunday}
+)
+
+// Calendar of a project, used for windows that are aligned to the fiscal
+// year or skip weekends and holidays
+type Calendar struct {
+	Weekend         []time.Weekday
+	Holidays        []time.Time
+	FiscalYearStart time.Month
+}
+
+// IsWorkingDay checks if the day of t, in the timezone of t, is neither a
+// weekend nor a holiday
+func (c Calendar) IsWorkingDay(t time.Time) bool {
+	weekend := c.Weekend
+	if weekend == nil {
+		weekend = defaultWeekend
+	}
+	for _, day := range weekend {
+		if t.Weekday() == day {
+			return false
+		}
+	}
+	for _, holiday := range c.Holidays {
+		if holiday.Year() == t.Year() && holiday.YearDay() == t.YearDay() {
+			return false
+		}
+	}
+	return true
+}
+
+// GetFiscalYearStart returns the month fiscal year starts in
+func (c Calendar) GetFiscalYearStart() time.Month {
+	if c.FiscalYearStart == 0 {
+		return time.January
+	}
+	return c.FiscalYearStart
+}
+
+// Calendar parses the calendar from project config, weekends default to
+// saturday and sunday and fiscal year to the calendar year
+func (s ProjectSpec) Calendar() (Calendar, error) {
+	calendar := Calendar{}
+	for _, date := range strings.Split(s.Config[ProjectCalendarHolidays], ",") {
+		if date = strings.TrimSpace(date); date == "" {
+			continue
+		}
+		holiday, err := time.Parse(JobDatetimeLayout, date)
+		if err != nil {
+			return Calendar{}, fmt.Errorf("invalid holiday %s in %s: %w", date, ProjectCalendarHolidays, err)
+		}
+		calendar.Holidays = append(calendar.Holidays, holiday)
+	}
+
+	if weekend, ok := s.Config[ProjectCalendarWeekend]; ok {
+		calendar.Weekend = []time.Weekday{}
+		for _, name := range strings.Split(weekend, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			day, err := parseWeekday(name)
+			if err != nil {
+				return Calendar{}, fmt.Errorf("invalid week day %s in %s: %w", name, ProjectCalendarWeekend, err)
+			}
+			calendar.Weekend = append(calendar.Weekend, day)
+		}
+	}
+
+	if month := strings.TrimSpace(s.Config[ProjectCalendarFiscalYearStart]); month != "" {
+		m, err := strconv.Atoi(month)
+		if err != nil || m < 1 || m > 12 {
+			return Calendar{}, fmt.Errorf("invalid month %s in %s, should be between 1 and 12",
+				month, ProjectCalendarFiscalYearStart)
+		}
+		calendar.FiscalYearStart = time.Month(m)
+	}
+	return calendar, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("unknown week day")
+}
@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestCalendar(t *testing.T) {
+	t.Run("should parse calendar from project config", func(t *testing.T) {
+		spec := models.ProjectSpec{
+			Config: map[string]string{
+				models.ProjectCalendarHolidays:        "2021-01-01, 2021-12-25",
+				models.ProjectCalendarWeekend:         "Friday,saturday",
+				models.ProjectCalendarFiscalYearStart: "4",
+			},
+		}
+		calendar, err := spec.Calendar()
+		assert.Nil(t, err)
+		assert.Equal(t, models.Calendar{
+			Weekend: []time.Weekday{time.Friday, time.Saturday},
+			Holidays: []time.Time{
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 12, 25, 0, 0, 0, 0, time.UTC),
+			},
+			FiscalYearStart: time.April,
+		}, calendar)
+	})
+	t.Run("should default to saturday and sunday weekends and january fiscal year", func(t *testing.T) {
+		calendar, err := models.ProjectSpec{}.Calendar()
+		assert.Nil(t, err)
+		assert.Equal(t, time.January, calendar.GetFiscalYearStart())
+		assert.False(t, calendar.IsWorkingDay(time.Date(2021, 3, 6, 0, 0, 0, 0, time.UTC)))
+		assert.False(t, calendar.IsWorkingDay(time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, calendar.IsWorkingDay(time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("should fail to parse invalid calendar", func(t *testing.T) {
+		for key, value := range map[string]string{
+			models.ProjectCalendarHolidays:        "2021-13-01",
+			models.ProjectCalendarWeekend:         "someday",
+			models.ProjectCalendarFiscalYearStart: "13",
+		} {
+			_, err := models.ProjectSpec{Config: map[string]string{key: value}}.Calendar()
+			assert.NotNil(t, err, key)
+		}
+	})
+	t.Run("should not treat holidays as working days", func(t *testing.T) {
+		calendar := models.Calendar{
+			Holidays: []time.Time{time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)},
+		}
+		assert.False(t, calendar.IsWorkingDay(time.Date(2021, 3, 8, 10, 0, 0, 0, time.UTC)))
+		assert.True(t, calendar.IsWorkingDay(time.Date(2021, 3, 9, 10, 0, 0, 0, time.UTC)))
+	})
+}
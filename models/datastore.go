@@ -62,6 +62,21 @@ type Datastorer interface {
 
 	// DeleteResource will delete the requested resource if exists
 	DeleteResource(context.Context, DeleteResourceRequest) error
+
+	// ResourceURN is the urn jobs read and write the resource by, as task
+	// plugins generate their destinations and dependencies
+	ResourceURN(ResourceSpec) (string, error)
+
+	// BackupResource copies the resource as is, resources which can't be
+	// backed up fail with ErrUnsupportedResource
+	BackupResource(context.Context, BackupResourceRequest) (BackupResourceResponse, error)
+
+	// RestoreResource overwrites the resource with a copy of its backup
+	RestoreResource(context.Context, RestoreResourceRequest) error
+
+	// CheckResource compares the resource as declared with the one living in
+	// datastore, resources which can't be checked fail with ErrUnsupportedResource
+	CheckResource(context.Context, CheckResourceRequest) (CheckResourceResponse, error)
 }
 
 type DatastoreTypeController interface {
@@ -116,11 +131,44 @@ type DeleteResourceRequest struct {
 	Project  ProjectSpec
 }
 
+type CheckResourceRequest struct {
+	Resource ResourceSpec
+	Project  ProjectSpec
+}
+
+type CheckResourceResponse struct {
+	// Drifts are differences of the live resource from the declared one,
+	// a resource not yet created in datastore has none
+	Drifts []ResourceDrift
+}
+
+// Incompatible is true if any drift can't be reconciled by deploying the
+// declared resource again
+func (r CheckResourceResponse) Incompatible() bool {
+	for _, drift := range r.Drifts {
+		if drift.Incompatible {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceDrift is a field of resource whose live value differs from the
+// declared one, empty values denote fields missing on either side
+type ResourceDrift struct {
+	Field        string
+	Declared     string
+	Live         string
+	Incompatible bool
+}
+
 var (
 	DatastoreRegistry = &supportedDatastore{
 		data: map[string]Datastorer{},
 	}
 	ErrUnsupportedDatastore = errors.New("unsupported datastore requested")
+	ErrUnsupportedResource  = errors.New("unsupported resource")
+	ErrIncompatibleDrift    = errors.New("resource drifted incompatibly from its spec")
 )
 
 type DatastoreRepo interface {
@@ -170,4 +218,5 @@ type DatastoreService interface {
 	UpdateResource(ctx context.Context, namespace NamespaceSpec, resourceSpecs []ResourceSpec, obs progress.Observer) error
 	ReadResource(ctx context.Context, namespace NamespaceSpec, datastoreName, name string) (ResourceSpec, error)
 	DeleteResource(ctx context.Context, namespace NamespaceSpec, datastoreName, name string) error
+	CheckResource(ctx context.Context, namespace NamespaceSpec, datastoreName, name string) (CheckResourceResponse, error)
 }
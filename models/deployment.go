@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DeploymentStatusAccepted deployment is queued for a worker
+	DeploymentStatusAccepted   = "accepted"
+	DeploymentStatusInProgress = "inprogress"
+	// DeploymentStatusFailed worker failed while processing the deployment
+	DeploymentStatusFailed  = "failed"  // end state
+	DeploymentStatusSuccess = "success" // end state
+
+	JobDeploymentStatusPending  = "pending"
+	JobDeploymentStatusDeployed = "deployed"
+	JobDeploymentStatusFailed   = "failed"
+	// JobDeploymentStatusSkipped job is same as the stored specification
+	JobDeploymentStatusSkipped = "skipped"
+	// JobDeploymentStatusDeleted job is deleted as it is not part of a
+	// reconciled deployment
+	JobDeploymentStatusDeleted = "deleted"
+	// JobDeploymentStatusReverted job is restored to the version before
+	// deployment as the deployment failed
+	JobDeploymentStatusReverted = "reverted"
+)
+
+// DeployRequest asks to deploy a set of jobs in a namespace, on reconcile
+// jobs of the namespace which are not part of the request are deleted
+type DeployRequest struct {
+	ID        uuid.UUID
+	Namespace NamespaceSpec
+	Jobs      []JobSpec
+	Reconcile bool
+	// RequestedBy is the user who requested the deployment
+	RequestedBy string
+}
+
+// JobDeployment is the deployment state of a single job
+type JobDeployment struct {
+	Name    string
+	Status  string
+	Message string
+}
+
+// DeploymentSpec tracks the progress of a deployment
+type DeploymentSpec struct {
+	ID          uuid.UUID
+	Namespace   NamespaceSpec
+	Status      string
+	Message     string
+	RequestedBy string
+	Jobs        []JobDeployment
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsFinished returns true once deployment is in an end state
+func (d DeploymentSpec) IsFinished() bool {
+	return d.Status == DeploymentStatusSuccess || d.Status == DeploymentStatusFailed
+}
+
+// Duration returns the time taken by a finished deployment
+func (d DeploymentSpec) Duration() time.Duration {
+	if !d.IsFinished() {
+		return 0
+	}
+	return d.UpdatedAt.Sub(d.CreatedAt)
+}
+
+// DeploymentFilter narrows down the deployments read from history
+type DeploymentFilter struct {
+	// Namespace name, deployments of all namespaces are read if not set
+	Namespace string
+	// Since only reads deployments created after it if set
+	Since time.Time
+	// Limit is the maximum number of deployments read, most recent first
+	Limit int
+}
@@ -0,0 +1,46 @@
+package models
+
+import "context"
+
+const (
+	AuthMethodAPIKey = "apikey"
+	AuthMethodOIDC   = "oidc"
+	// AuthMethodClientCert identifies callers by client certificate verified
+	// during mutual tls handshake
+	AuthMethodClientCert = "clientcert"
+)
+
+// Identity is the authenticated caller of an api
+type Identity struct {
+	// Subject identifies the caller, it is the name of api key, subject
+	// claim of the bearer token or common name of the client certificate
+	Subject string
+
+	// Email of the caller when provided by identity provider
+	Email string
+
+	// Method used to authenticate the caller, one of AuthMethodAPIKey,
+	// AuthMethodOIDC, AuthMethodClientCert
+	Method string
+}
+
+// Name returns a human readable name of the caller
+func (i Identity) Name() string {
+	if i.Email != "" {
+		return i.Email
+	}
+	return i.Subject
+}
+
+type identityContextKey struct{}
+
+// ContextWithIdentity attaches the authenticated caller to ctx
+func ContextWithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext returns the authenticated caller attached to ctx
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityContextKey{}).(Identity)
+	return identity, ok
+}
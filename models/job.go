@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -24,11 +26,19 @@ var (
 	ErrNoResources = errors.New("no resources found")
 	ErrNoSuchAsset = errors.New("asset not found")
 	ErrNoSuchHook  = errors.New("hook not found")
+
+	ErrUnknownWindowPreset = errors.New("unknown window preset")
+
+	ErrUnsupportedJobSpecVersion = errors.New("unsupported job spec version")
 )
 
 const (
 	JobDatetimeLayout = "2006-01-02"
 
+	// JobSpecVersion is the latest schema version of job specifications,
+	// specifications of older versions are upgraded when read
+	JobSpecVersion = 2
+
 	// assuming all month are 30 days long for simplicity
 	HoursInMonth = time.Duration(30) * 24 * time.Hour
 
@@ -38,11 +48,68 @@ const (
 	JobSpecDependencyTypeInter JobSpecDependencyType = "inter"
 	// outside optimus
 	JobSpecDependencyTypeExtra JobSpecDependencyType = "extra"
+	// on another optimus server
+	JobSpecDependencyTypeExternal JobSpecDependencyType = "external"
 
 	JobEventTypeSLAMiss JobEventType = "sla_miss"
 	JobEventTypeFailure JobEventType = "failure"
+	JobEventTypeSuccess JobEventType = "success"
+	JobEventTypeStart   JobEventType = "start"
+	JobEventTypeRetry   JobEventType = "retry"
+	JobEventTypeSlowRun JobEventType = "slow_run"
+
+	// object arriving in a gcs bucket
+	JobTriggerTypeGCS JobTriggerType = "gcs"
+	// message published to a kafka topic
+	JobTriggerTypeKafka JobTriggerType = "kafka"
+	// run of an optimus job finishing successfully
+	JobTriggerTypeJob JobTriggerType = "job"
+
+	// JobTriggerInterval is how often runs of triggered jobs without an
+	// interval start waiting for their event
+	JobTriggerInterval = "@hourly"
+
+	// scheduler pool task instances of a job run in
+	JobRuntimeLabelPool = "pool"
+	// scheduler queue task instances of a job are sent to
+	JobRuntimeLabelQueue = "queue"
+)
+
+// resourceQuantityRegex matches kubernetes quantities like 500m, 2, 1.5Gi
+var resourceQuantityRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([munkMGTPE]|[KMGTPE]i)?$`)
+
+var (
+	jobHTTPDependencyNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-.]+$`)
+
+	jobRuntimeEnvRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// jobLabelKeyRegex allows keys usable as labels of scheduler and cloud
+	// resources, starting and ending with a letter or digit
+	jobLabelKeyRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.\-]{0,61}[A-Za-z0-9])?$`)
+
+	// jobLabelKeyPrefixRegex allows dns subdomains, e.g. app.kubernetes.io,
+	// label keys can be prefixed with one followed by a slash
+	jobLabelKeyPrefixRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9\-]*[a-z0-9])?)*$`)
+
+	// jobRuntimeReservedEnvs are set by optimus on every task and hook container
+	jobRuntimeReservedEnvs = map[string]bool{
+		"JOB_NAME": true, "OPTIMUS_HOSTNAME": true, "JOB_LABELS": true, "JOB_DIR": true, "PROJECT": true,
+		"NAMESPACE": true, "INSTANCE_TYPE": true, "INSTANCE_NAME": true, "SCHEDULED_AT": true,
+	}
+
+	jobRuntimeLabels = map[string]bool{
+		JobRuntimeLabelPool:  true,
+		JobRuntimeLabelQueue: true,
+	}
 )
 
+// jobTriggerRequiredConfigs are config keys each trigger type needs
+var jobTriggerRequiredConfigs = map[JobTriggerType][]string{
+	JobTriggerTypeGCS:   {"BUCKET", "OBJECT"},
+	JobTriggerTypeKafka: {"BROKERS", "TOPIC"},
+	JobTriggerTypeJob:   {"JOB"},
+}
+
 // JobSpec represents a job
 // internal representation of the job
 type JobSpec struct {
@@ -58,12 +125,90 @@ type JobSpec struct {
 	Dependencies map[string]JobSpecDependency // job name to dependency
 	Assets       JobAssets
 	Hooks        []JobSpecHook
+	Runtime      JobSpecRuntime
+	// HTTPDependencies are checks on systems outside optimus job waits for
+	HTTPDependencies []JobSpecHTTPDependency
+	// ExternalDependencies are jobs of other optimus servers job waits for
+	ExternalDependencies []JobSpecExternalDependency
+	// ResourceDependencies are resources job waits for, they are resolved
+	// to dependencies on the jobs writing them
+	ResourceDependencies []JobSpecResourceDependency
+	// Paused jobs are kept in store and metadata but not scheduled
+	Paused bool
+	// Revision of the stored spec, incremented on every save. Saving a spec
+	// with a revision other than the stored one fails, zero skips the check
+	Revision int
+}
+
+// JobSpecVersion is a job spec as it was saved at a revision
+type JobSpecVersion struct {
+	Revision  int
+	Spec      JobSpec
+	CreatedAt time.Time
 }
 
 func (js JobSpec) GetName() string {
 	return js.Name
 }
 
+// HasOptionalDependencies checks if job can run without any of its upstreams
+func (js JobSpec) HasOptionalDependencies() bool {
+	for _, dependency := range js.Dependencies {
+		if dependency.Optional {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateHTTPDependencies checks if all http dependencies are valid and
+// uniquely named
+func (js JobSpec) ValidateHTTPDependencies() error {
+	names := map[string]bool{}
+	for _, dependency := range js.HTTPDependencies {
+		if err := dependency.Validate(); err != nil {
+			return err
+		}
+		if names[dependency.Name] {
+			return fmt.Errorf("duplicate http dependency %s", dependency.Name)
+		}
+		names[dependency.Name] = true
+	}
+	return nil
+}
+
+// ValidateExternalDependencies checks if all external dependencies are
+// valid and unique
+func (js JobSpec) ValidateExternalDependencies() error {
+	dependencies := map[JobSpecExternalDependency]bool{}
+	for _, dependency := range js.ExternalDependencies {
+		if err := dependency.Validate(); err != nil {
+			return err
+		}
+		if dependencies[dependency] {
+			return fmt.Errorf("duplicate external dependency %s", dependency)
+		}
+		dependencies[dependency] = true
+	}
+	return nil
+}
+
+// ValidateResourceDependencies checks if all resource dependencies are
+// valid and unique
+func (js JobSpec) ValidateResourceDependencies() error {
+	urns := map[string]bool{}
+	for _, dependency := range js.ResourceDependencies {
+		if err := dependency.Validate(); err != nil {
+			return err
+		}
+		if urns[dependency.URN] {
+			return fmt.Errorf("duplicate resource dependency %s", dependency.URN)
+		}
+		urns[dependency.URN] = true
+	}
+	return nil
+}
+
 func (js JobSpec) GetHookByName(name string) (JobSpecHook, error) {
 	for _, hook := range js.Hooks {
 		if hook.Unit.Info().Name == name {
@@ -73,6 +218,29 @@ func (js JobSpec) GetHookByName(name string) (JobSpecHook, error) {
 	return JobSpecHook{}, ErrNoSuchHook
 }
 
+// ValidateLabels checks if label keys are at most 63 letters, digits,
+// underscores, dots or dashes starting and ending with a letter or digit,
+// optionally prefixed with a dns subdomain and a slash, e.g.
+// app.kubernetes.io/name
+func (js JobSpec) ValidateLabels() error {
+	for key := range js.Labels {
+		name := key
+		if i := strings.Index(key, "/"); i >= 0 {
+			prefix := key[:i]
+			if len(prefix) > 253 || !jobLabelKeyPrefixRegex.MatchString(prefix) {
+				return fmt.Errorf("invalid label key %s, prefix should be a dns subdomain of at most 253 "+
+					"characters", key)
+			}
+			name = key[i+1:]
+		}
+		if !jobLabelKeyRegex.MatchString(name) {
+			return fmt.Errorf("invalid label key %s, should be at most 63 letters, digits, underscores, dots "+
+				"or dashes starting and ending with a letter or digit", key)
+		}
+	}
+	return nil
+}
+
 func (js JobSpec) GetLabelsAsString() string {
 	labels := ""
 	for k, v := range js.Labels {
@@ -85,6 +253,87 @@ type JobSpecSchedule struct {
 	StartDate time.Time
 	EndDate   *time.Time
 	Interval  string
+	// Timezone is the IANA name of timezone start, end dates and interval
+	// are in, UTC if not set
+	Timezone string
+	// Trigger makes every run wait for an event before executing, runs
+	// are started every interval
+	Trigger *JobSpecTrigger
+}
+
+// JobSpecRuntime is how scheduler runs task and hooks of a job
+type JobSpecRuntime struct {
+	// Env are variables set on every task and hook container
+	Env map[string]string
+	// Labels are scheduler options of job tasks, e.g. pool and queue
+	Labels map[string]string
+}
+
+// Validate checks if env names are valid and not reserved by optimus and
+// if labels are supported by schedulers
+func (r JobSpecRuntime) Validate() error {
+	for name := range r.Env {
+		if !jobRuntimeEnvRegex.MatchString(name) {
+			return fmt.Errorf("invalid env name %s", name)
+		}
+		if jobRuntimeReservedEnvs[name] {
+			return fmt.Errorf("env %s is reserved by optimus", name)
+		}
+	}
+	for name := range r.Labels {
+		if !jobRuntimeLabels[name] {
+			return fmt.Errorf("unknown runtime label %s, should be one of %s, %s", name, JobRuntimeLabelPool, JobRuntimeLabelQueue)
+		}
+	}
+	return nil
+}
+
+type JobTriggerType string
+
+func (t JobTriggerType) String() string {
+	return string(t)
+}
+
+// JobSpecTrigger is an external event runs of a job wait on
+type JobSpecTrigger struct {
+	Type   JobTriggerType
+	Config map[string]string
+}
+
+// Validate checks if trigger type is known and has configs it needs
+func (t JobSpecTrigger) Validate() error {
+	requiredConfigs, ok := jobTriggerRequiredConfigs[t.Type]
+	if !ok {
+		return fmt.Errorf("unknown trigger type %s, should be one of gcs, kafka, job", t.Type)
+	}
+	for _, key := range requiredConfigs {
+		if t.Config[key] == "" {
+			return fmt.Errorf("trigger %s requires config %s", t.Type, key)
+		}
+	}
+	return nil
+}
+
+// Location returns the timezone of schedule, schedules with an unknown
+// timezone are in UTC
+func (s JobSpecSchedule) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// HasEnded is true once at is past the end date of schedule, a schedule
+// without an end date never ends
+func (s JobSpecSchedule) HasEnded(at time.Time) bool {
+	if s.EndDate == nil {
+		return false
+	}
+	end := *s.EndDate
+	end = time.Date(end.Year(), end.Month(), end.Day(), end.Hour(), end.Minute(), end.Second(),
+		end.Nanosecond(), s.Location())
+	return at.After(end)
 }
 
 type JobSpecBehavior struct {
@@ -94,6 +343,26 @@ type JobSpecBehavior struct {
 	Notify        []JobSpecNotifier
 }
 
+// SLAMissDuration returns the duration of the sla_miss notifier, the last
+// one wins if there are several. Zero if the job has no sla
+func (b JobSpecBehavior) SLAMissDuration() (time.Duration, error) {
+	var sla time.Duration
+	for _, notify := range b.Notify {
+		if notify.On != JobEventTypeSLAMiss {
+			continue
+		}
+		if _, ok := notify.Config["duration"]; !ok {
+			continue
+		}
+		dur, err := time.ParseDuration(notify.Config["duration"])
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse sla_miss duration %s: %w", notify.Config["duration"], err)
+		}
+		sla = dur
+	}
+	return sla, nil
+}
+
 type JobSpecBehaviorRetry struct {
 	Count              int
 	Delay              time.Duration
@@ -107,10 +376,57 @@ type JobSpecNotifier struct {
 }
 
 type JobSpecTask struct {
-	Unit     *Plugin
+	Unit *Plugin
+	// Version pins the plugin version used by task, latest installed one
+	// is used if empty
+	Version  string
 	Config   JobSpecConfigs
 	Window   JobSpecTaskWindow
 	Priority int
+	Resource JobSpecResource
+}
+
+// JobSpecResource is the compute a task or hook container requests and
+// is limited to, unset values fall back to scheduler defaults
+type JobSpecResource struct {
+	Request JobSpecResourceConfig
+	Limit   JobSpecResourceConfig
+}
+
+type JobSpecResourceConfig struct {
+	CPU    string
+	Memory string
+}
+
+func (r JobSpecResource) IsEmpty() bool {
+	return r.Request == JobSpecResourceConfig{} && r.Limit == JobSpecResourceConfig{}
+}
+
+// Validate checks if all set values are valid resource quantities
+func (r JobSpecResource) Validate() error {
+	for _, quantity := range []struct{ Name, Value string }{
+		{"request cpu", r.Request.CPU},
+		{"request memory", r.Request.Memory},
+		{"limit cpu", r.Limit.CPU},
+		{"limit memory", r.Limit.Memory},
+	} {
+		if quantity.Value != "" && !resourceQuantityRegex.MatchString(quantity.Value) {
+			return fmt.Errorf("invalid %s quantity %s", quantity.Name, quantity.Value)
+		}
+	}
+	return nil
+}
+
+// ToMap returns set values keyed by their kubernetes resource name
+func (c JobSpecResourceConfig) ToMap() map[string]string {
+	resources := map[string]string{}
+	if c.CPU != "" {
+		resources["cpu"] = c.CPU
+	}
+	if c.Memory != "" {
+		resources["memory"] = c.Memory
+	}
+	return resources
 }
 
 // using array to keep order, map would be more performant
@@ -134,13 +450,84 @@ type JobSpecTaskWindow struct {
 	Size       time.Duration
 	Offset     time.Duration
 	TruncateTo string
+	// Modifier adjusts the window after truncation, e.g. to skip weekends
+	// and holidays of the project calendar
+	Modifier string
+
+	// Calendar of the project the window is computed with, it is not part
+	// of the specification and is set before the window is compiled
+	Calendar Calendar
+}
+
+// JobSpecTaskWindowTruncations are the boundaries a window can be aligned to,
+// hour, day, week, month, quarter and year, quarters and years start
+// with the fiscal year of the project calendar
+var JobSpecTaskWindowTruncations = []string{"h", "d", "w", "M", "Q", "Y"}
+
+// JobSpecTaskWindowPresets are named windows that can be used instead of
+// specifying size, offset and truncate_to
+var JobSpecTaskWindowPresets = map[string]JobSpecTaskWindow{
+	"last_hour":    {Size: time.Hour, Offset: 0, TruncateTo: "h"},
+	"yesterday":    {Size: 24 * time.Hour, Offset: 0, TruncateTo: "d"},
+	"last_7_days":  {Size: 7 * 24 * time.Hour, Offset: 0, TruncateTo: "d"},
+	"last_30_days": {Size: 30 * 24 * time.Hour, Offset: 0, TruncateTo: "d"},
+	"last_week":    {Size: 7 * 24 * time.Hour, Offset: -7 * 24 * time.Hour, TruncateTo: "w"},
+	"last_month":   {Size: HoursInMonth, Offset: -HoursInMonth, TruncateTo: "M"},
+	"last_quarter": {Size: 3 * HoursInMonth, Offset: -3 * HoursInMonth, TruncateTo: "Q"},
+}
+
+// GetJobSpecTaskWindowPreset returns the window of a named preset
+func GetJobSpecTaskWindowPreset(name string) (JobSpecTaskWindow, error) {
+	window, ok := JobSpecTaskWindowPresets[name]
+	if !ok {
+		return JobSpecTaskWindow{}, fmt.Errorf("%w: %s", ErrUnknownWindowPreset, name)
+	}
+	return window, nil
+}
+
+// Validate checks if window has a positive size, is truncated to one of
+// the supported boundaries and uses a known modifier
+func (w *JobSpecTaskWindow) Validate() error {
+	if w.Size <= 0 {
+		return fmt.Errorf("window size should be positive, got %s", w.Size)
+	}
+	if err := w.validateModifier(); err != nil {
+		return err
+	}
+	if w.TruncateTo == "" {
+		return nil
+	}
+	for _, truncation := range JobSpecTaskWindowTruncations {
+		if w.TruncateTo == truncation {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid window truncate_to %s, possible values: %s", w.TruncateTo,
+		strings.Join(JobSpecTaskWindowTruncations, "/"))
+}
+
+func (w *JobSpecTaskWindow) validateModifier() error {
+	if w.Modifier == "" {
+		return nil
+	}
+	for _, modifier := range JobSpecTaskWindowModifiers {
+		if w.Modifier == modifier {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid window modifier %s, possible values: %s", w.Modifier,
+		strings.Join(JobSpecTaskWindowModifiers, "/"))
 }
 
+// GetStart returns start of the window, truncation happens at boundaries
+// of timezone scheduledAt is in
 func (w *JobSpecTaskWindow) GetStart(scheduledAt time.Time) time.Time {
 	s, _ := w.getWindowDate(scheduledAt, w.Size, w.Offset, w.TruncateTo)
 	return s
 }
 
+// GetEnd returns end of the window, truncation happens at boundaries
+// of timezone scheduledAt is in
 func (w *JobSpecTaskWindow) GetEnd(scheduledAt time.Time) time.Time {
 	_, e := w.getWindowDate(scheduledAt, w.Size, w.Offset, w.TruncateTo)
 	return e
@@ -148,45 +535,48 @@ func (w *JobSpecTaskWindow) GetEnd(scheduledAt time.Time) time.Time {
 
 func (w *JobSpecTaskWindow) getWindowDate(today time.Time, windowSize, windowOffset time.Duration, windowTruncateTo string) (time.Time, time.Time) {
 	floatingEnd := today
+	loc := today.Location()
 
-	// apply truncation to end
+	// apply truncation to end, day boundaries are of the timezone of today
 	if windowTruncateTo == "h" {
 		// remove time upto hours
-		floatingEnd = floatingEnd.Truncate(time.Hour)
+		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), floatingEnd.Day(), floatingEnd.Hour(), 0, 0, 0, loc)
 	} else if windowTruncateTo == "d" {
 		// remove time upto day
-		floatingEnd = floatingEnd.Truncate(24 * time.Hour)
+		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), floatingEnd.Day(), 0, 0, 0, 0, loc)
 	} else if windowTruncateTo == "w" {
 		// shift current window to nearest Sunday
-		nearestSunday := time.Duration(time.Saturday-floatingEnd.Weekday()+1) * 24 * time.Hour
-		floatingEnd = floatingEnd.Add(nearestSunday)
-		floatingEnd = floatingEnd.Truncate(24 * time.Hour)
+		nearestSunday := int(time.Saturday - floatingEnd.Weekday() + 1)
+		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), floatingEnd.Day()+nearestSunday, 0, 0, 0, 0, loc)
 	}
 
 	windowEnd := floatingEnd.Add(windowOffset)
 	windowStart := windowEnd.Add(-windowSize)
 
-	// handle monthly windows separately as every month is not of same size
-	if windowTruncateTo == "M" {
+	// handle monthly, quarterly and yearly windows separately as every month is not of same size
+	if months, ok := map[string]int{"M": 1, "Q": 3, "Y": 12}[windowTruncateTo]; ok {
 		floatingEnd = today
 		// shift current window to nearest month start and end
 
 		// truncate the date
-		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, time.UTC)
+		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, loc)
 
 		// then add the month offset
 		// for handling offset, treat 30 days as 1 month
 		offsetMonths := windowOffset / HoursInMonth
 		floatingEnd = floatingEnd.AddDate(0, int(offsetMonths), 0)
 
-		// then find the last day of this month
-		floatingEnd = floatingEnd.AddDate(0, 1, -1)
+		// shift to the start of the quarter or year counted from the start of
+		// fiscal year, then find the last day of it
+		sinceFiscalStart := (int(floatingEnd.Month()) - int(w.Calendar.GetFiscalYearStart()) + 12) % months
+		floatingEnd = floatingEnd.AddDate(0, -sinceFiscalStart, 0)
+		floatingEnd = floatingEnd.AddDate(0, months, -1)
 
 		// final end is computed
-		windowEnd = floatingEnd.Truncate(time.Hour * 24)
+		windowEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), floatingEnd.Day(), 0, 0, 0, 0, loc)
 
 		// truncate days/hours from window start as well
-		floatingStart := time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, time.UTC)
+		floatingStart := time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, loc)
 		// for handling size, treat 30 days as 1 month, and as we have already truncated current month
 		// subtract 1 from this
 		sizeMonths := (windowSize / HoursInMonth) - 1
@@ -198,13 +588,26 @@ func (w *JobSpecTaskWindow) getWindowDate(today time.Time, windowSize, windowOff
 		windowStart = floatingStart
 	}
 
+	if w.Modifier == JobSpecTaskWindowModifierPreviousWorkingDay {
+		// shift window back a day at a time till it starts on a working day,
+		// bounded to avoid looping forever on a calendar without working days
+		for i := 0; i < 366 && !w.Calendar.IsWorkingDay(windowStart); i++ {
+			windowStart = windowStart.AddDate(0, 0, -1)
+			windowEnd = windowEnd.AddDate(0, 0, -1)
+		}
+	}
+
 	return windowStart, windowEnd
 }
 
 type JobSpecHook struct {
-	Config    JobSpecConfigs
-	Unit      *Plugin
+	Config JobSpecConfigs
+	Unit   *Plugin
+	// Version pins the plugin version used by hook, latest installed one
+	// is used if empty
+	Version   string
 	DependsOn []*JobSpecHook
+	Resource  JobSpecResource
 }
 
 type JobSpecAsset struct {
@@ -291,22 +694,143 @@ type JobSpecDependency struct {
 	Project *ProjectSpec
 	Job     *JobSpec
 	Type    JobSpecDependencyType
+	// Optional dependencies are waited for but don't block the job if
+	// upstream fails or doesn't finish in time
+	Optional bool
+	// Timeout is how long to wait for upstream and PokeInterval how often
+	// to check it, scheduler defaults are used if not set
+	Timeout      time.Duration
+	PokeInterval time.Duration
+}
+
+// JobSpecHTTPDependency waits for an http endpoint to respond with the
+// expected status and, if JSONPath is set, with ExpectedValue at the
+// dot separated path of json response body
+type JobSpecHTTPDependency struct {
+	Name           string
+	URL            string
+	Headers        map[string]string
+	ExpectedStatus int
+	JSONPath       string
+	ExpectedValue  string
+}
+
+// Validate checks if dependency can be compiled into a sensor
+func (d JobSpecHTTPDependency) Validate() error {
+	if !jobHTTPDependencyNameRegex.MatchString(d.Name) {
+		return fmt.Errorf("invalid http dependency name %s", d.Name)
+	}
+	parsedURL, err := url.Parse(d.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("invalid url %s of http dependency %s", d.URL, d.Name)
+	}
+	if d.ExpectedStatus != 0 && (d.ExpectedStatus < 100 || d.ExpectedStatus > 599) {
+		return fmt.Errorf("invalid expected status %d of http dependency %s", d.ExpectedStatus, d.Name)
+	}
+	return nil
+}
+
+// JobSpecExternalDependency is a job deployed on another optimus server
+// reachable at Host
+type JobSpecExternalDependency struct {
+	Host        string
+	ProjectName string
+	JobName     string
+}
+
+func (d JobSpecExternalDependency) String() string {
+	return fmt.Sprintf("%s/%s/%s", d.Host, d.ProjectName, d.JobName)
+}
+
+// Validate checks if dependency references a job of an optimus server
+func (d JobSpecExternalDependency) Validate() error {
+	if d.ProjectName == "" || d.JobName == "" {
+		return fmt.Errorf("project and job of external dependency %s are required", d)
+	}
+	host := d.Host
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	parsedURL, err := url.Parse(host)
+	if err != nil || parsedURL.Host == "" {
+		return fmt.Errorf("invalid host of external dependency %s", d)
+	}
+	return nil
+}
+
+// JobSpecResourceDependency is a resource, by the urn task plugins
+// generate destinations as, job waits to be refreshed. It is resolved to a
+// dependency on the job writing it
+type JobSpecResourceDependency struct {
+	URN string
+	// Optional, Timeout and PokeInterval are those of the dependency on the
+	// job writing the resource
+	Optional     bool
+	Timeout      time.Duration
+	PokeInterval time.Duration
+}
+
+// Validate checks if dependency references a resource by its urn, e.g.
+// bigquery://project:dataset.table
+func (d JobSpecResourceDependency) Validate() error {
+	if parts := strings.SplitN(d.URN, "://", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid urn %s of resource dependency", d.URN)
+	}
+	if err := d.Dependency().Validate(); err != nil {
+		return fmt.Errorf("invalid resource dependency %s: %w", d.URN, err)
+	}
+	return nil
+}
+
+// Dependency returns the dependency on the job writing the resource
+func (d JobSpecResourceDependency) Dependency() JobSpecDependency {
+	return JobSpecDependency{
+		Optional:     d.Optional,
+		Timeout:      d.Timeout,
+		PokeInterval: d.PokeInterval,
+	}
+}
+
+// Validate checks if wait settings of dependency can be used by sensors
+func (d JobSpecDependency) Validate() error {
+	if d.Timeout < 0 || d.PokeInterval < 0 {
+		return errors.New("timeout and poke interval of dependency can't be negative")
+	}
+	if d.Timeout > 0 && d.PokeInterval > d.Timeout {
+		return fmt.Errorf("poke interval %s of dependency exceeds its timeout %s", d.PokeInterval, d.Timeout)
+	}
+	return nil
 }
 
 // JobService provides a high-level operations on DAGs
 type JobService interface {
 	// Create constructs a Job and commits it to a storage
 	Create(NamespaceSpec, JobSpec) error
+	// CreateAll commits multiple Jobs to storage at once, none of them
+	// are stored if any fails
+	CreateAll(NamespaceSpec, []JobSpec) error
 	// GetByName fetches a Job by name for a specific namespace
 	GetByName(string, NamespaceSpec) (JobSpec, error)
 	// Dump returns the compiled Job
 	Dump(NamespaceSpec, JobSpec) (Job, error)
+	// Compile returns the Job scheduler would receive if the spec was deployed
+	Compile(NamespaceSpec, JobSpec) (Job, error)
 	// KeepOnly deletes all jobs except the ones provided for a namespace
 	KeepOnly(NamespaceSpec, []JobSpec, progress.Observer) error
 	// GetAll reads all job specifications of the given namespace
 	GetAll(NamespaceSpec) ([]JobSpec, error)
 	// Delete deletes a job spec from all repos
 	Delete(context.Context, NamespaceSpec, JobSpec) error
+	// Restore brings back a deleted job spec of namespace and schedules it again
+	Restore(context.Context, NamespaceSpec, string) (JobSpec, error)
+	// GetVersions lists saved versions of a job spec, latest first
+	GetVersions(NamespaceSpec, string) ([]JobSpecVersion, error)
+	// GetVersion fetches a job spec as it was saved at a revision
+	GetVersion(NamespaceSpec, string, int) (JobSpecVersion, error)
+	// Rollback saves a job spec as it was at a revision and syncs namespace
+	Rollback(context.Context, NamespaceSpec, string, int) (JobSpec, error)
+	// SetPaused pauses or resumes scheduling of a job without redeploying it
+	SetPaused(context.Context, NamespaceSpec, JobSpec, bool) error
 
 	// following methods are executed at a project level, instead of a client
 	// GetByNameForProject fetches a Job by name for a specific project
@@ -317,6 +841,10 @@ type JobService interface {
 	ReplayDryRun(*ReplayWorkerRequest) (*tree.TreeNode, error)
 	// Replay replays the jobSpec and its dependencies between start and endDate
 	Replay(context.Context, *ReplayWorkerRequest) (string, error)
+	// GetReplay returns the replay of jobSpec by its id
+	GetReplay(JobSpec, uuid.UUID) (ReplaySpec, error)
+	// GetReplayList returns replays of jobs of a project, latest first
+	GetReplayList(projectID uuid.UUID) ([]ReplaySpec, error)
 }
 
 // JobCompiler takes template file of a scheduler and after applying
@@ -349,6 +877,9 @@ type NotifyAttrs struct {
 	JobEvent JobEvent
 
 	Route string
+	// Config of the notifier of job the event is sent for, e.g. template
+	// of messages
+	Config map[string]string
 }
 
 type Notifier interface {
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRun is a run of a job by the scheduler for one of its schedules, runs
+// are kept as history so they can be read even if scheduler is unavailable
+type JobRun struct {
+	ID          uuid.UUID
+	JobName     string
+	Namespace   NamespaceSpec
+	ScheduledAt time.Time
+	State       JobStatusState
+	// StartTime and EndTime are when the run started and finished, they
+	// are zero till scheduler reports them
+	StartTime time.Time
+	EndTime   time.Time
+	// Attempt is the number of times the run was tried, zero if unknown
+	Attempt int
+	// SLAMissedAt is when scheduler reported the run missed its sla, zero
+	// if it didn't
+	SLAMissedAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// JobRunFilter narrows down the runs read from history
+type JobRunFilter struct {
+	// JobName reads runs of a single job if set
+	JobName string
+	// StartDate and EndDate bound the scheduled time of runs read if set
+	StartDate time.Time
+	EndDate   time.Time
+	// States reads runs in any of the states if set
+	States []JobStatusState
+	// Limit reads only the latest runs matching if set, it is ignored
+	// while counting runs
+	Limit int
+}
@@ -1,6 +1,8 @@
 package models_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -131,6 +133,30 @@ func TestJob(t *testing.T) {
 					ExpectedStart:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
 					ExpectedEnd:        time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
 				},
+				{
+					Today:              time.Date(2021, 2, 25, 6, 33, 22, 0, time.UTC),
+					WindowSize:         24 * 90 * time.Hour,
+					WindowOffset:       0,
+					WindowTruncateUpto: "Q",
+					ExpectedStart:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpectedEnd:        time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Today:              time.Date(2021, 2, 25, 6, 33, 22, 0, time.UTC),
+					WindowSize:         24 * 90 * time.Hour,
+					WindowOffset:       -24 * 90 * time.Hour,
+					WindowTruncateUpto: "Q",
+					ExpectedStart:      time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+					ExpectedEnd:        time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Today:              time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC),
+					WindowSize:         24 * 180 * time.Hour,
+					WindowOffset:       0,
+					WindowTruncateUpto: "Q",
+					ExpectedStart:      time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+					ExpectedEnd:        time.Date(2021, 9, 30, 0, 0, 0, 0, time.UTC),
+				},
 			}
 
 			for _, tcase := range cases {
@@ -145,5 +171,282 @@ func TestJob(t *testing.T) {
 				assert.Equal(t, tcase.ExpectedEnd, windowEnd)
 			}
 		})
+		t.Run("should expand presets to windows", func(t *testing.T) {
+			today := time.Date(2021, 3, 10, 6, 33, 22, 0, time.UTC)
+			cases := map[string][]time.Time{
+				"last_hour":    {time.Date(2021, 3, 10, 5, 0, 0, 0, time.UTC), time.Date(2021, 3, 10, 6, 0, 0, 0, time.UTC)},
+				"yesterday":    {time.Date(2021, 3, 9, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)},
+				"last_7_days":  {time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)},
+				"last_week":    {time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC)},
+				"last_month":   {time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+				"last_quarter": {time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)},
+			}
+			for preset, expected := range cases {
+				win, err := models.GetJobSpecTaskWindowPreset(preset)
+				assert.Nil(t, err)
+				assert.Nil(t, win.Validate())
+				assert.Equal(t, expected[0], win.GetStart(today), preset)
+				assert.Equal(t, expected[1], win.GetEnd(today), preset)
+			}
+		})
+		t.Run("should fail for unknown presets", func(t *testing.T) {
+			_, err := models.GetJobSpecTaskWindowPreset("last_decade")
+			assert.True(t, errors.Is(err, models.ErrUnknownWindowPreset))
+		})
+		t.Run("should validate size, truncation and modifier", func(t *testing.T) {
+			assert.Nil(t, (&models.JobSpecTaskWindow{Size: time.Hour}).Validate())
+			assert.Nil(t, (&models.JobSpecTaskWindow{Size: time.Hour, Modifier: "previous_working_day"}).Validate())
+			assert.NotNil(t, (&models.JobSpecTaskWindow{Size: 0, TruncateTo: "d"}).Validate())
+			assert.NotNil(t, (&models.JobSpecTaskWindow{Size: time.Hour, TruncateTo: "y"}).Validate())
+			assert.NotNil(t, (&models.JobSpecTaskWindow{Size: time.Hour, Modifier: "next_full_moon"}).Validate())
+		})
+		t.Run("should shift window to previous working day", func(t *testing.T) {
+			win := &models.JobSpecTaskWindow{
+				Size:       24 * time.Hour,
+				TruncateTo: "d",
+				Modifier:   models.JobSpecTaskWindowModifierPreviousWorkingDay,
+				Calendar: models.Calendar{
+					Holidays: []time.Time{time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)},
+				},
+			}
+			// monday, window is of the friday before
+			monday := time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC)
+			assert.Equal(t, time.Date(2021, 2, 26, 0, 0, 0, 0, time.UTC), win.GetStart(monday))
+			assert.Equal(t, time.Date(2021, 2, 27, 0, 0, 0, 0, time.UTC), win.GetEnd(monday))
+
+			// wednesday, window is of tuesday
+			wednesday := time.Date(2021, 3, 3, 2, 0, 0, 0, time.UTC)
+			assert.Equal(t, time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC), win.GetStart(wednesday))
+
+			// tuesday after a holiday on monday, window is of friday
+			tuesday := time.Date(2021, 3, 9, 2, 0, 0, 0, time.UTC)
+			assert.Equal(t, time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC), win.GetStart(tuesday))
+			assert.Equal(t, time.Date(2021, 3, 6, 0, 0, 0, 0, time.UTC), win.GetEnd(tuesday))
+		})
+		t.Run("should align quarters and years to the fiscal year", func(t *testing.T) {
+			calendar := models.Calendar{FiscalYearStart: time.April}
+			today := time.Date(2021, 2, 25, 6, 33, 22, 0, time.UTC)
+
+			quarter := &models.JobSpecTaskWindow{Size: 3 * models.HoursInMonth, TruncateTo: "Q", Calendar: calendar}
+			assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), quarter.GetStart(today))
+			assert.Equal(t, time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC), quarter.GetEnd(today))
+
+			calendar.FiscalYearStart = time.February
+			quarter.Calendar = calendar
+			assert.Equal(t, time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), quarter.GetStart(today))
+			assert.Equal(t, time.Date(2021, 4, 30, 0, 0, 0, 0, time.UTC), quarter.GetEnd(today))
+
+			year := &models.JobSpecTaskWindow{Size: 12 * models.HoursInMonth, TruncateTo: "Y",
+				Calendar: models.Calendar{FiscalYearStart: time.April}}
+			assert.Equal(t, time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), year.GetStart(today))
+			assert.Equal(t, time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC), year.GetEnd(today))
+		})
+	})
+	t.Run("JobSpecSchedule", func(t *testing.T) {
+		endDate := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+		t.Run("should never end without an end date", func(t *testing.T) {
+			schedule := models.JobSpecSchedule{StartDate: endDate}
+			assert.False(t, schedule.HasEnded(endDate.AddDate(10, 0, 0)))
+		})
+		t.Run("should end only after the end date", func(t *testing.T) {
+			schedule := models.JobSpecSchedule{EndDate: &endDate}
+			assert.False(t, schedule.HasEnded(endDate.Add(-time.Hour)))
+			assert.False(t, schedule.HasEnded(endDate))
+			assert.True(t, schedule.HasEnded(endDate.Add(time.Second)))
+		})
+		t.Run("should end after the end date in timezone of schedule", func(t *testing.T) {
+			schedule := models.JobSpecSchedule{EndDate: &endDate, Timezone: "Asia/Kolkata"}
+			assert.True(t, schedule.HasEnded(endDate.Add(-5*time.Hour)))
+			assert.False(t, schedule.HasEnded(endDate.Add(-6*time.Hour)))
+		})
+		t.Run("should be located in UTC without a valid timezone", func(t *testing.T) {
+			assert.Equal(t, time.UTC, models.JobSpecSchedule{}.Location())
+			assert.Equal(t, time.UTC, models.JobSpecSchedule{Timezone: "Mars/Olympus"}.Location())
+			assert.Equal(t, "Asia/Kolkata", models.JobSpecSchedule{Timezone: "Asia/Kolkata"}.Location().String())
+		})
+	})
+	t.Run("should check if job has optional dependencies", func(t *testing.T) {
+		jobSpec := models.JobSpec{
+			Dependencies: map[string]models.JobSpecDependency{
+				"upstream": {Type: models.JobSpecDependencyTypeIntra},
+			},
+		}
+		assert.False(t, jobSpec.HasOptionalDependencies())
+
+		jobSpec.Dependencies["enrichment"] = models.JobSpecDependency{Type: models.JobSpecDependencyTypeIntra, Optional: true}
+		assert.True(t, jobSpec.HasOptionalDependencies())
+	})
+	t.Run("JobSpecDependency", func(t *testing.T) {
+		t.Run("should be valid with poke interval within timeout", func(t *testing.T) {
+			assert.Nil(t, models.JobSpecDependency{}.Validate())
+			assert.Nil(t, models.JobSpecDependency{Timeout: time.Hour, PokeInterval: time.Minute}.Validate())
+			assert.Nil(t, models.JobSpecDependency{PokeInterval: time.Minute}.Validate())
+		})
+		t.Run("should be invalid with negative durations", func(t *testing.T) {
+			assert.NotNil(t, models.JobSpecDependency{Timeout: -time.Hour}.Validate())
+		})
+		t.Run("should be invalid with poke interval exceeding timeout", func(t *testing.T) {
+			dependency := models.JobSpecDependency{Timeout: time.Minute, PokeInterval: time.Hour}
+			assert.EqualError(t, dependency.Validate(), "poke interval 1h0m0s of dependency exceeds its timeout 1m0s")
+		})
+	})
+	t.Run("JobSpecHTTPDependency", func(t *testing.T) {
+		t.Run("should be valid with an http url and known status", func(t *testing.T) {
+			jobSpec := models.JobSpec{HTTPDependencies: []models.JobSpecHTTPDependency{
+				{Name: "billing-export", URL: "https://billing.example.io/exports"},
+				{Name: "ledger", URL: "http://ledger.example.io/status", ExpectedStatus: 204},
+			}}
+			assert.Nil(t, jobSpec.ValidateHTTPDependencies())
+		})
+		t.Run("should be invalid with a malformed name, url or status", func(t *testing.T) {
+			assert.EqualError(t, models.JobSpecHTTPDependency{Name: "billing export", URL: "https://billing.example.io"}.Validate(),
+				"invalid http dependency name billing export")
+			assert.EqualError(t, models.JobSpecHTTPDependency{Name: "billing", URL: "ftp://billing.example.io"}.Validate(),
+				"invalid url ftp://billing.example.io of http dependency billing")
+			assert.EqualError(t, models.JobSpecHTTPDependency{Name: "billing", URL: "https://billing.example.io", ExpectedStatus: 42}.Validate(),
+				"invalid expected status 42 of http dependency billing")
+		})
+		t.Run("should be invalid with duplicate names", func(t *testing.T) {
+			jobSpec := models.JobSpec{HTTPDependencies: []models.JobSpecHTTPDependency{
+				{Name: "billing", URL: "https://billing.example.io/exports"},
+				{Name: "billing", URL: "https://billing.example.io/status"},
+			}}
+			assert.EqualError(t, jobSpec.ValidateHTTPDependencies(), "duplicate http dependency billing")
+		})
+	})
+	t.Run("JobSpecExternalDependency", func(t *testing.T) {
+		t.Run("should be valid with a host with or without scheme", func(t *testing.T) {
+			jobSpec := models.JobSpec{ExternalDependencies: []models.JobSpecExternalDependency{
+				{Host: "https://optimus.partner.example.io", ProjectName: "partner", JobName: "billing"},
+				{Host: "optimus.partner.example.io:8080", ProjectName: "partner", JobName: "ledger"},
+			}}
+			assert.Nil(t, jobSpec.ValidateExternalDependencies())
+		})
+		t.Run("should be invalid without a host, project or job", func(t *testing.T) {
+			assert.EqualError(t, models.JobSpecExternalDependency{ProjectName: "partner", JobName: "billing"}.Validate(),
+				"invalid host of external dependency /partner/billing")
+			assert.EqualError(t, models.JobSpecExternalDependency{Host: "optimus.partner.example.io", JobName: "billing"}.Validate(),
+				"project and job of external dependency optimus.partner.example.io//billing are required")
+		})
+		t.Run("should be invalid with duplicates", func(t *testing.T) {
+			dependency := models.JobSpecExternalDependency{Host: "optimus.partner.example.io", ProjectName: "partner", JobName: "billing"}
+			jobSpec := models.JobSpec{ExternalDependencies: []models.JobSpecExternalDependency{dependency, dependency}}
+			assert.EqualError(t, jobSpec.ValidateExternalDependencies(),
+				"duplicate external dependency optimus.partner.example.io/partner/billing")
+		})
+	})
+	t.Run("should validate label keys", func(t *testing.T) {
+		assert.Nil(t, models.JobSpec{Labels: map[string]string{"team": "data", "cost.center_1": "42",
+			"app.kubernetes.io/name": "optimus", "example.com/team": "data"}}.ValidateLabels())
+		for _, key := range []string{"", "-team", "team-", "team name", strings.Repeat("a", 64),
+			"/name", "app.kubernetes.io/", "App.io/name", "-app.io/name", "app..io/name", "app.io/team/name",
+			strings.Repeat("a", 254) + "/name"} {
+			assert.NotNil(t, models.JobSpec{Labels: map[string]string{key: "data"}}.ValidateLabels(), key)
+		}
+	})
+	t.Run("JobSpecRuntime", func(t *testing.T) {
+		t.Run("should be valid with custom env and known labels", func(t *testing.T) {
+			runtime := models.JobSpecRuntime{
+				Env:    map[string]string{"LOG_LEVEL": "debug"},
+				Labels: map[string]string{models.JobRuntimeLabelPool: "bigquery", models.JobRuntimeLabelQueue: "heavy"},
+			}
+			assert.Nil(t, runtime.Validate())
+		})
+		t.Run("should be invalid with a malformed or reserved env name", func(t *testing.T) {
+			assert.EqualError(t, models.JobSpecRuntime{Env: map[string]string{"LOG-LEVEL": "debug"}}.Validate(),
+				"invalid env name LOG-LEVEL")
+			assert.EqualError(t, models.JobSpecRuntime{Env: map[string]string{"SCHEDULED_AT": "now"}}.Validate(),
+				"env SCHEDULED_AT is reserved by optimus")
+		})
+		t.Run("should be invalid with an unknown label", func(t *testing.T) {
+			runtime := models.JobSpecRuntime{Labels: map[string]string{"executor": "celery"}}
+			assert.NotNil(t, runtime.Validate())
+		})
+	})
+	t.Run("JobSpecResource", func(t *testing.T) {
+		t.Run("should be valid with kubernetes quantities", func(t *testing.T) {
+			resource := models.JobSpecResource{
+				Request: models.JobSpecResourceConfig{CPU: "250m", Memory: "512Mi"},
+				Limit:   models.JobSpecResourceConfig{CPU: "1.5", Memory: "2G"},
+			}
+			assert.Nil(t, resource.Validate())
+			assert.False(t, resource.IsEmpty())
+			assert.True(t, models.JobSpecResource{}.IsEmpty())
+		})
+		t.Run("should be invalid with a malformed quantity", func(t *testing.T) {
+			resource := models.JobSpecResource{
+				Limit: models.JobSpecResourceConfig{Memory: "2 GB"},
+			}
+			assert.EqualError(t, resource.Validate(), "invalid limit memory quantity 2 GB")
+		})
+		t.Run("should map only set values by kubernetes resource name", func(t *testing.T) {
+			config := models.JobSpecResourceConfig{Memory: "1Gi"}
+			assert.Equal(t, map[string]string{"memory": "1Gi"}, config.ToMap())
+		})
+	})
+	t.Run("JobSpecTrigger", func(t *testing.T) {
+		t.Run("should be valid with configs its type requires", func(t *testing.T) {
+			trigger := models.JobSpecTrigger{
+				Type:   models.JobTriggerTypeGCS,
+				Config: map[string]string{"BUCKET": "landing", "OBJECT": "events/_SUCCESS"},
+			}
+			assert.Nil(t, trigger.Validate())
+		})
+		t.Run("should be invalid without configs its type requires", func(t *testing.T) {
+			trigger := models.JobSpecTrigger{
+				Type:   models.JobTriggerTypeKafka,
+				Config: map[string]string{"TOPIC": "orders"},
+			}
+			assert.EqualError(t, trigger.Validate(), "trigger kafka requires config BROKERS")
+		})
+		t.Run("should be invalid with an unknown type", func(t *testing.T) {
+			trigger := models.JobSpecTrigger{Type: "pubsub"}
+			assert.NotNil(t, trigger.Validate())
+		})
+	})
+	t.Run("JobSpecTaskWindow in a timezone", func(t *testing.T) {
+		loc, err := time.LoadLocation("Asia/Kolkata")
+		assert.Nil(t, err)
+		cases := []struct {
+			TruncateTo    string
+			Size          time.Duration
+			ExpectedStart time.Time
+			ExpectedEnd   time.Time
+		}{
+			{
+				TruncateTo:    "h",
+				Size:          time.Hour,
+				ExpectedStart: time.Date(2021, 2, 25, 1, 0, 0, 0, loc),
+				ExpectedEnd:   time.Date(2021, 2, 25, 2, 0, 0, 0, loc),
+			},
+			{
+				TruncateTo:    "d",
+				Size:          24 * time.Hour,
+				ExpectedStart: time.Date(2021, 2, 24, 0, 0, 0, 0, loc),
+				ExpectedEnd:   time.Date(2021, 2, 25, 0, 0, 0, 0, loc),
+			},
+			{
+				TruncateTo:    "w",
+				Size:          7 * 24 * time.Hour,
+				ExpectedStart: time.Date(2021, 2, 21, 0, 0, 0, 0, loc),
+				ExpectedEnd:   time.Date(2021, 2, 28, 0, 0, 0, 0, loc),
+			},
+			{
+				TruncateTo:    "M",
+				Size:          models.HoursInMonth,
+				ExpectedStart: time.Date(2021, 2, 1, 0, 0, 0, 0, loc),
+				ExpectedEnd:   time.Date(2021, 2, 28, 0, 0, 0, 0, loc),
+			},
+		}
+		// 2021-02-24 20:45 in UTC
+		scheduledAt := time.Date(2021, 2, 25, 2, 15, 0, 0, loc)
+		for _, tcase := range cases {
+			win := &models.JobSpecTaskWindow{
+				Size:       tcase.Size,
+				TruncateTo: tcase.TruncateTo,
+			}
+			assert.True(t, tcase.ExpectedStart.Equal(win.GetStart(scheduledAt)), tcase.TruncateTo)
+			assert.True(t, tcase.ExpectedEnd.Equal(win.GetEnd(scheduledAt)), tcase.TruncateTo)
+		}
 	})
 }
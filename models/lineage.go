@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+)
+
+// JobLineage is the destination a job writes and the dependencies it
+// reads, as urns generated by its task plugin when metadata of the job is
+// compiled. Jobs of different projects connect through the same urns
+type JobLineage struct {
+	ProjectName   string
+	NamespaceName string
+	JobName       string
+	Destination   string
+	Dependencies  []string
+	UpdatedAt     time.Time
+}
+
+// LineageDirection is the way a lineage graph is walked from its job
+type LineageDirection string
+
+const (
+	// LineageDirectionUpstream walks to the jobs writing what a job reads
+	LineageDirectionUpstream LineageDirection = "upstream"
+	// LineageDirectionDownstream walks to the jobs reading what a job writes
+	LineageDirectionDownstream LineageDirection = "downstream"
+	// LineageDirectionBoth walks both upstream and downstream
+	LineageDirectionBoth LineageDirection = ""
+)
+
+type LineageNodeType string
+
+const (
+	LineageNodeTypeJob      LineageNodeType = "job"
+	LineageNodeTypeResource LineageNodeType = "resource"
+)
+
+// LineageNode is a job or a resource it reads or writes, urn of a job
+// node is its destination
+type LineageNode struct {
+	ID            string
+	Type          LineageNodeType
+	ProjectName   string
+	NamespaceName string
+	JobName       string
+	Urn           string
+}
+
+// LineageEdge points from a resource to the job reading it, or from a job
+// to the resource it writes
+type LineageEdge struct {
+	Source string
+	Target string
+}
+
+// LineageGraph is what is reachable from a job within a depth
+type LineageGraph struct {
+	Nodes []LineageNode
+	Edges []LineageEdge
+}
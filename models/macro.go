@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	MacroTypeString = "string"
+	MacroTypeInt    = "int"
+	MacroTypeFloat  = "float"
+	MacroTypeBool   = "bool"
+)
+
+var (
+	// ErrUndefinedMacro is returned when a template refers to a macro that
+	// is neither defined in the project nor in the namespace of the job
+	ErrUndefinedMacro = errors.New("undefined macro")
+
+	// MacroTypes are the supported types of a macro value
+	MacroTypes = []string{MacroTypeString, MacroTypeInt, MacroTypeFloat, MacroTypeBool}
+
+	macroNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Macro is a named value available to job templates as .macro.<Name>,
+// macros of a namespace take precedence over the ones of its project
+type Macro struct {
+	ID uuid.UUID
+
+	Name string
+
+	// Type of the value, one of MacroTypes, defaults to string
+	Type string
+
+	// Default value of the macro, parsed as Type while compiling
+	Default string
+
+	Description string
+
+	// Namespace the macro is defined in, empty for the whole project
+	Namespace string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GetType returns the type of the macro value
+func (m Macro) GetType() string {
+	if m.Type == "" {
+		return MacroTypeString
+	}
+	return m.Type
+}
+
+// Value parses the default value of macro as its type
+func (m Macro) Value() (interface{}, error) {
+	switch m.GetType() {
+	case MacroTypeString:
+		return m.Default, nil
+	case MacroTypeInt:
+		return strconv.ParseInt(m.Default, 10, 64)
+	case MacroTypeFloat:
+		return strconv.ParseFloat(m.Default, 64)
+	case MacroTypeBool:
+		return strconv.ParseBool(m.Default)
+	}
+	return nil, fmt.Errorf("unknown type %s of macro %s, possible values: %v", m.Type, m.Name, MacroTypes)
+}
+
+// Validate checks macro name and that its default value matches its type
+func (m Macro) Validate() error {
+	if !macroNameRegex.MatchString(m.Name) {
+		return fmt.Errorf("invalid macro name %s, should only contain letters, digits and underscores", m.Name)
+	}
+	if _, err := m.Value(); err != nil {
+		return errors.Wrapf(err, "invalid default value of macro %s", m.Name)
+	}
+	return nil
+}
+
+type ProjectMacros []Macro
+
+// Resolve returns values of macros visible in namespace, namespace level
+// macros override project level ones with the same name
+func (s ProjectMacros) Resolve(namespace string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, scope := range []string{"", namespace} {
+		for _, m := range s {
+			if m.Namespace != scope {
+				continue
+			}
+			value, err := m.Value()
+			if err != nil {
+				return nil, err
+			}
+			values[m.Name] = value
+		}
+		if namespace == "" {
+			break
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,59 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestMacro(t *testing.T) {
+	t.Run("should parse default value as macro type", func(t *testing.T) {
+		for _, tc := range []struct {
+			macro    models.Macro
+			expected interface{}
+		}{
+			{models.Macro{Name: "table", Default: "orders"}, "orders"},
+			{models.Macro{Name: "limit", Type: models.MacroTypeInt, Default: "10"}, int64(10)},
+			{models.Macro{Name: "ratio", Type: models.MacroTypeFloat, Default: "0.5"}, 0.5},
+			{models.Macro{Name: "enabled", Type: models.MacroTypeBool, Default: "true"}, true},
+		} {
+			assert.Nil(t, tc.macro.Validate())
+			value, err := tc.macro.Value()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, value)
+		}
+	})
+	t.Run("should fail to validate invalid macros", func(t *testing.T) {
+		for _, macro := range []models.Macro{
+			{Name: "", Default: "orders"},
+			{Name: "my-table", Default: "orders"},
+			{Name: "limit", Type: models.MacroTypeInt, Default: "ten"},
+			{Name: "limit", Type: "decimal", Default: "10"},
+		} {
+			assert.NotNil(t, macro.Validate(), macro.Name)
+		}
+	})
+	t.Run("should override project macros with namespace macros", func(t *testing.T) {
+		macros := models.ProjectMacros{
+			{Name: "dataset", Default: "playground", Namespace: "team-a"},
+			{Name: "dataset", Default: "common"},
+			{Name: "limit", Type: models.MacroTypeInt, Default: "10"},
+			{Name: "region", Default: "eu", Namespace: "team-b"},
+		}
+		values, err := macros.Resolve("team-a")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"dataset": "playground",
+			"limit":   int64(10),
+		}, values)
+
+		values, err = macros.Resolve("")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"dataset": "common",
+			"limit":   int64(10),
+		}, values)
+	})
+}
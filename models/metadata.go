@@ -1,11 +1,16 @@
 package models
 
 import (
+	"time"
+
 	"github.com/odpf/optimus/core/progress"
 )
 
 type MetadataService interface {
 	Publish(NamespaceSpec, []JobSpec, progress.Observer) error
+	// Tombstone publishes a null message for each of the deleted jobs
+	// of namespace, letting consumers retire them
+	Tombstone(NamespaceSpec, []string, progress.Observer) error
 }
 
 type JobMetadataAdapter interface {
@@ -14,53 +19,94 @@ type JobMetadataAdapter interface {
 	CompileKey(string) ([]byte, error)
 }
 
+// MetadataWriter publishes job metadata to a sink like kafka, a webhook
+// or a file
 type MetadataWriter interface {
 	Write(key []byte, message []byte) error
 	Flush() error
+	// Close flushes buffered messages and releases the sink
+	Close() error
 }
 
 type JobMetadata struct {
-	Urn          string
-	Name         string
-	Tenant       string
-	Namespace    string
-	Version      int
-	Description  string
-	Labels       []JobMetadataLabelItem
-	Owner        string
-	Task         JobTaskMetadata
-	Schedule     JobSpecSchedule
-	Behavior     JobSpecBehavior
-	Dependencies []JobDependencyMetadata
-	Hooks        []JobHookMetadata
-}
-
-type JobMetadataLabelItem struct {
-	Name  string
-	Value string
+	Urn         string
+	Name        string
+	Tenant      string
+	Namespace   string
+	Version     int
+	Description string
+	Labels      map[string]string
+	Owner       string
+	Task        JobTaskMetadata
+	Schedule    JobSpecSchedule
+	Behavior    JobSpecBehavior
+	// SLAMissDuration is the duration of the sla_miss notifier of
+	// behavior, zero if the job has no sla
+	SLAMissDuration time.Duration
+	Dependencies    []JobDependencyMetadata
+	Hooks           []JobHookMetadata
 }
 
 type JobTaskMetadata struct {
-	Name        string
-	Image       string
-	Description string
-	Destination string
-	Config      JobSpecConfigs
-	Window      JobSpecTaskWindow
-	Priority    int
+	Name    string
+	Version string
+	// PinnedVersion is the plugin version job pins, empty if it follows
+	// the latest installed one
+	PinnedVersion string
+	Image         string
+	Description   string
+	Destination   string
+	Config        JobSpecConfigs
+	Window        JobSpecTaskWindow
+	Priority      int
+	Resource      JobSpecResource
 }
 
 type JobHookMetadata struct {
-	Name        string
-	Image       string
-	Description string
-	Config      JobSpecConfigs
-	Type        HookType
-	DependsOn   []string
+	Name          string
+	Version       string
+	PinnedVersion string
+	Image         string
+	Description   string
+	Config        JobSpecConfigs
+	Type          HookType
+	DependsOn     []string
+	Resource      JobSpecResource
 }
 
 type JobDependencyMetadata struct {
 	Tenant string
 	Job    string
 	Type   string
+	// URL of checked endpoint for http dependencies or host of optimus
+	// server for external dependencies
+	URL string
+}
+
+// ResourceMetadataService publishes metadata of resources managed in
+// datastores, alongside metadata of jobs
+type ResourceMetadataService interface {
+	Publish(NamespaceSpec, []ResourceSpec, progress.Observer) error
+	// Tombstone publishes a null message for each of the deleted
+	// resources of namespace
+	Tombstone(NamespaceSpec, []ResourceSpec, progress.Observer) error
+}
+
+type ResourceMetadataAdapter interface {
+	FromResourceSpec(NamespaceSpec, ResourceSpec) (*ResourceMetadata, error)
+	CompileMessage(*ResourceMetadata) ([]byte, error)
+	CompileKey(string) ([]byte, error)
+}
+
+type ResourceMetadata struct {
+	Urn       string
+	Name      string
+	Tenant    string
+	Namespace string
+	Datastore string
+	Type      string
+	Version   int
+	Labels    map[string]string
+	// Spec is the spec of resource as written in its yaml
+	Spec map[string]interface{}
 }
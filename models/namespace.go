@@ -14,3 +14,15 @@ type NamespaceSpec struct {
 	// ProjectSpec is the project that this namespace belongs to
 	ProjectSpec ProjectSpec
 }
+
+// MergedConfig returns project config overridden by namespace config
+func (n NamespaceSpec) MergedConfig() map[string]string {
+	config := make(map[string]string, len(n.ProjectSpec.Config)+len(n.Config))
+	for key, val := range n.ProjectSpec.Config {
+		config[key] = val
+	}
+	for key, val := range n.Config {
+		config[key] = val
+	}
+	return config
+}
@@ -2,9 +2,11 @@ package models
 
 import (
 	"context"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/pkg/errors"
 )
 
@@ -119,10 +121,39 @@ type PluginQuestion struct {
 	Help        string
 	Default     string
 	Multiselect []string
+	// Validation is an optional regex answers should match
+	Validation string
 
 	SubQuestions []PluginSubQuestion
 }
 
+// IsValid checks answer is one of the options of question and matches its
+// validation regex, plugins can still reject it using ValidateQuestion
+func (q PluginQuestion) IsValid(value string) error {
+	if len(q.Multiselect) > 0 {
+		found := false
+		for _, option := range q.Multiselect {
+			if option == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Wrapf(ErrInvalidPluginAnswer, "%s should be one of %s", q.Name, strings.Join(q.Multiselect, ", "))
+		}
+	}
+	if q.Validation != "" {
+		re, err := regexp.Compile(q.Validation)
+		if err != nil {
+			return errors.Wrapf(err, "invalid validation of question %s", q.Name)
+		}
+		if !re.MatchString(value) {
+			return errors.Wrapf(ErrInvalidPluginAnswer, "%s should match %s", q.Name, q.Validation)
+		}
+	}
+	return nil
+}
+
 type PluginSubQuestion struct {
 	// IfValue is used as an if condition to match with user input
 	// if user value matches this only then ask sub questions
@@ -329,13 +360,18 @@ type GenerateDependenciesResponse struct {
 
 var (
 	// PluginRegistry holds all supported plugins for this run
-	PluginRegistry       PluginRepository = NewPluginRepository()
-	ErrUnsupportedPlugin                  = errors.New("unsupported plugin requested, make sure its correctly installed")
+	PluginRegistry              PluginRepository = NewPluginRepository()
+	ErrUnsupportedPlugin                         = errors.New("unsupported plugin requested, make sure its correctly installed")
+	ErrInvalidPluginAnswer                       = errors.New("invalid answer")
+	ErrUnsupportedPluginVersion                  = errors.New("unsupported plugin version requested, make sure its correctly installed")
 )
 
 type PluginRepository interface {
 	Add(BasePlugin, CommandLineMod, DependencyResolverMod) error
 	GetByName(string) (*Plugin, error)
+	// GetByNameAndVersion returns an installed version of plugin, the latest
+	// one is used if version is empty
+	GetByNameAndVersion(name, version string) (*Plugin, error)
 	GetAll() []*Plugin
 	GetTasks() []*Plugin
 	GetHooks() []*Plugin
@@ -359,8 +395,36 @@ func (p *Plugin) Info() *PluginInfoResponse {
 	return resp
 }
 
+// GenerateDefaults returns config and assets plugin scaffolds for a new job
+// using answers to its questions, plugins without cli mod have no defaults
+func (p *Plugin) GenerateDefaults(ctx context.Context, answers PluginAnswers, options PluginOptions) (JobSpecConfigs, JobAssets, error) {
+	if p.CLIMod == nil {
+		return JobSpecConfigs{}, JobAssets{}, nil
+	}
+	configResp, err := p.CLIMod.DefaultConfig(ctx, DefaultConfigRequest{
+		PluginOptions: options,
+		Answers:       answers,
+	})
+	if err != nil {
+		return nil, JobAssets{}, err
+	}
+	assetsResp, err := p.CLIMod.DefaultAssets(ctx, DefaultAssetsRequest{
+		PluginOptions: options,
+		Answers:       answers,
+	})
+	if err != nil {
+		return nil, JobAssets{}, err
+	}
+	return configResp.Config.ToJobSpec(), *assetsResp.Assets.ToJobSpec(), nil
+}
+
 type registeredPlugins struct {
+	// data has the latest installed version of each plugin
 	data map[string]*Plugin
+
+	// versions has all the installed versions of each plugin, jobs can pin
+	// an older one so upgrading a plugin doesn't change their behavior
+	versions map[string]map[string]*Plugin
 }
 
 func (s *registeredPlugins) GetByName(name string) (*Plugin, error) {
@@ -370,6 +434,20 @@ func (s *registeredPlugins) GetByName(name string) (*Plugin, error) {
 	return nil, errors.Wrap(ErrUnsupportedPlugin, name)
 }
 
+func (s *registeredPlugins) GetByNameAndVersion(name, version string) (*Plugin, error) {
+	if version == "" {
+		return s.GetByName(name)
+	}
+	versions, ok := s.versions[name]
+	if !ok {
+		return nil, errors.Wrap(ErrUnsupportedPlugin, name)
+	}
+	if unit, ok := versions[version]; ok {
+		return unit, nil
+	}
+	return nil, errors.Wrapf(ErrUnsupportedPluginVersion, "%s@%s", name, version)
+}
+
 func (s *registeredPlugins) GetAll() []*Plugin {
 	var list []*Plugin
 	for _, unit := range s.data {
@@ -427,9 +505,9 @@ func (s *registeredPlugins) Add(baseMod BasePlugin, cliMod CommandLineMod, drMod
 		return errors.New("plugin name cannot be empty")
 	}
 
-	// check if name is already used
-	if _, ok := s.data[info.Name]; ok {
-		return errors.Errorf("plugin name already in use %s", info.Name)
+	// check if name and version is already used
+	if _, ok := s.versions[info.Name][info.PluginVersion]; ok {
+		return errors.Errorf("plugin name already in use %s@%s", info.Name, info.PluginVersion)
 	}
 
 	// image is a required field
@@ -450,14 +528,40 @@ func (s *registeredPlugins) Add(baseMod BasePlugin, cliMod CommandLineMod, drMod
 		return ErrUnsupportedPlugin
 	}
 
-	s.data[info.Name] = &Plugin{
+	unit := &Plugin{
 		Base:          baseMod,
 		CLIMod:        cliMod,
 		DependencyMod: drMod,
 	}
+	if _, ok := s.versions[info.Name]; !ok {
+		s.versions[info.Name] = map[string]*Plugin{}
+	}
+	s.versions[info.Name][info.PluginVersion] = unit
+
+	// keep the latest version as default of plugin, versions which can't be
+	// compared replace the one added before
+	if latest, ok := s.data[info.Name]; ok && isOlderVersion(info.PluginVersion, latest.Info().PluginVersion) {
+		return nil
+	}
+	s.data[info.Name] = unit
 	return nil
 }
 
+func isOlderVersion(ver, than string) bool {
+	v1, err := version.NewVersion(ver)
+	if err != nil {
+		return false
+	}
+	v2, err := version.NewVersion(than)
+	if err != nil {
+		return false
+	}
+	return v1.LessThan(v2)
+}
+
 func NewPluginRepository() *registeredPlugins {
-	return &registeredPlugins{data: map[string]*Plugin{}}
+	return &registeredPlugins{
+		data:     map[string]*Plugin{},
+		versions: map[string]map[string]*Plugin{},
+	}
 }
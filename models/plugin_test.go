@@ -0,0 +1,86 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+func TestPluginRepository(t *testing.T) {
+	newPlugin := func(version string) *mock.BasePlugin {
+		base := new(mock.BasePlugin)
+		base.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:          "bq2bq",
+			Image:         "odpf/bq2bq:" + version,
+			PluginVersion: version,
+			PluginType:    models.PluginTypeTask,
+		}, nil)
+		return base
+	}
+
+	t.Run("should keep all versions of a plugin and default to the latest", func(t *testing.T) {
+		repo := models.NewPluginRepository()
+		assert.Nil(t, repo.Add(newPlugin("0.2.0"), nil, nil))
+		assert.Nil(t, repo.Add(newPlugin("0.10.0"), nil, nil))
+		assert.Nil(t, repo.Add(newPlugin("0.1.0"), nil, nil))
+
+		latest, err := repo.GetByName("bq2bq")
+		assert.Nil(t, err)
+		assert.Equal(t, "0.10.0", latest.Info().PluginVersion)
+		assert.Equal(t, 1, len(repo.GetTasks()))
+
+		pinned, err := repo.GetByNameAndVersion("bq2bq", "0.1.0")
+		assert.Nil(t, err)
+		assert.Equal(t, "odpf/bq2bq:0.1.0", pinned.Info().Image)
+
+		unpinned, err := repo.GetByNameAndVersion("bq2bq", "")
+		assert.Nil(t, err)
+		assert.Equal(t, latest, unpinned)
+	})
+	t.Run("should fail to get a version which is not installed", func(t *testing.T) {
+		repo := models.NewPluginRepository()
+		assert.Nil(t, repo.Add(newPlugin("0.1.0"), nil, nil))
+
+		_, err := repo.GetByNameAndVersion("bq2bq", "0.3.0")
+		assert.True(t, errors.Is(err, models.ErrUnsupportedPluginVersion))
+
+		_, err = repo.GetByNameAndVersion("bq3bq", "0.1.0")
+		assert.True(t, errors.Is(err, models.ErrUnsupportedPlugin))
+	})
+	t.Run("should fail to add same version of a plugin twice", func(t *testing.T) {
+		repo := models.NewPluginRepository()
+		assert.Nil(t, repo.Add(newPlugin("0.1.0"), nil, nil))
+		assert.NotNil(t, repo.Add(newPlugin("0.1.0"), nil, nil))
+	})
+}
+
+func TestPluginQuestion(t *testing.T) {
+	t.Run("should accept answers among options and matching validation", func(t *testing.T) {
+		question := models.PluginQuestion{
+			Name:        "LOAD_METHOD",
+			Multiselect: []string{"APPEND", "REPLACE"},
+			Validation:  "^[A-Z]+$",
+		}
+		assert.Nil(t, question.IsValid("REPLACE"))
+	})
+	t.Run("should reject answers not among options", func(t *testing.T) {
+		question := models.PluginQuestion{
+			Name:        "LOAD_METHOD",
+			Multiselect: []string{"APPEND", "REPLACE"},
+		}
+		err := question.IsValid("MERGE")
+		assert.True(t, errors.Is(err, models.ErrInvalidPluginAnswer))
+		assert.Contains(t, err.Error(), "APPEND, REPLACE")
+	})
+	t.Run("should reject answers not matching validation", func(t *testing.T) {
+		question := models.PluginQuestion{
+			Name:       "PROJECT",
+			Validation: "^[a-z-]+$",
+		}
+		assert.True(t, errors.Is(question.IsValid("Example_1"), models.ErrInvalidPluginAnswer))
+	})
+}
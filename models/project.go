@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"path"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -14,6 +15,18 @@ import (
 const (
 	ProjectStoragePathKey = "STORAGE_PATH"
 	ProjectSchedulerHost  = "SCHEDULER_HOST"
+	// ProjectSchedulerName is the scheduler jobs of project are deployed
+	// to, e.g. airflow or airflow2, server default is used if not set
+	ProjectSchedulerName = "SCHEDULER_NAME"
+	// ProjectSchedulerPool is the scheduler pool jobs run in, can be
+	// overridden per namespace or job
+	ProjectSchedulerPool = "SCHEDULER_POOL"
+	// ProjectReplayParallelism is the number of runs of a job replays
+	// of project clear at once, overrides the server default
+	ProjectReplayParallelism = "REPLAY_PARALLELISM"
+	// ProjectSensitiveConfigKeys is a comma separated list of config keys
+	// whose values are stored encrypted when envelope encryption is enabled
+	ProjectSensitiveConfigKeys = "SENSITIVE_CONFIG_KEYS"
 
 	// Secret used for uploading prepared scheduler specifications to cloud
 	// e.g. for gcs it will be base64 encoded service account for the bucket
@@ -24,6 +37,10 @@ const (
 )
 
 var (
+	// ConfigRedactedValue replaces values of sensitive config in responses
+	// to callers who are not allowed to read them
+	ConfigRedactedValue = "*redacted*"
+
 	// PluginSecretString generates plugin secret identifier using its type
 	// and name, e.g. task, bq2bq
 	PluginSecretString = func(pluginType InstanceType, pluginName string) string {
@@ -41,6 +58,7 @@ type ProjectSpec struct {
 	// suggested are gcs/s3 or similar object store
 	// - ProjectSchedulerHost: host url to connect with the scheduler used by
 	// the tenant
+	// - ProjectSchedulerName: scheduler used by the tenant
 	Config map[string]string
 
 	// Secret contains key value pair for project level credentials and gets
@@ -51,12 +69,68 @@ type ProjectSpec struct {
 	// Name: <plugintype>_<plugin_name>, Value: <base64encodedstring>
 	// For example: TASK_BQ2BQ: secret_as_base64
 	Secret ProjectSecrets
+
+	// Macros are named values available to job templates of the project
+	Macros ProjectMacros
 }
 
 func (s ProjectSpec) String() string {
 	return fmt.Sprintf("%s, %v", s.Name, s.Config)
 }
 
+// SensitiveConfigKeys returns config keys flagged as sensitive
+func (p ProjectSpec) SensitiveConfigKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(p.Config[ProjectSensitiveConfigKeys], ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsSensitiveConfig checks if key is flagged as sensitive by the project
+// or matches one of patterns
+func (p ProjectSpec) IsSensitiveConfig(key string, patterns ConfigKeyPatterns) bool {
+	for _, sensitiveKey := range p.SensitiveConfigKeys() {
+		if strings.EqualFold(sensitiveKey, key) {
+			return true
+		}
+	}
+	return patterns.Matches(key)
+}
+
+// ConfigKeyPatterns are glob patterns of config keys e.g. *_TOKEN, keys
+// are matched case insensitively
+type ConfigKeyPatterns []string
+
+// Matches checks if key matches any of the patterns
+func (p ConfigKeyPatterns) Matches(key string) bool {
+	key = strings.ToUpper(key)
+	for _, pattern := range p {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConfigKeyPatterns reads comma separated glob patterns of config keys
+func ParseConfigKeyPatterns(raw string) (ConfigKeyPatterns, error) {
+	var patterns ConfigKeyPatterns
+	for _, pattern := range strings.Split(raw, ",") {
+		pattern = strings.ToUpper(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid config key pattern %s", pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
 type ProjectSecrets []ProjectSecretItem
 
 func (s ProjectSecrets) String() string {
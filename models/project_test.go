@@ -39,6 +39,40 @@ func TestProjectSpec(t *testing.T) {
 			})
 		}
 	})
+	t.Run("SensitiveConfigKeys", func(t *testing.T) {
+		t.Run("should return trimmed keys flagged as sensitive", func(t *testing.T) {
+			spec := models.ProjectSpec{
+				Config: map[string]string{
+					models.ProjectSensitiveConfigKeys: "API_TOKEN, DB_PASSWORD,,",
+				},
+			}
+			assert.Equal(t, []string{"API_TOKEN", "DB_PASSWORD"}, spec.SensitiveConfigKeys())
+		})
+		t.Run("should return nothing when no key is flagged", func(t *testing.T) {
+			assert.Empty(t, models.ProjectSpec{}.SensitiveConfigKeys())
+		})
+	})
+	t.Run("IsSensitiveConfig", func(t *testing.T) {
+		spec := models.ProjectSpec{
+			Config: map[string]string{
+				models.ProjectSensitiveConfigKeys: "DB_PASSWORD",
+			},
+		}
+		patterns, err := models.ParseConfigKeyPatterns("*_secret, *_TOKEN,")
+		assert.Nil(t, err)
+
+		assert.True(t, spec.IsSensitiveConfig("DB_PASSWORD", patterns))
+		assert.True(t, spec.IsSensitiveConfig("CLIENT_SECRET", patterns))
+		assert.True(t, spec.IsSensitiveConfig("api_token", patterns))
+		assert.False(t, spec.IsSensitiveConfig("STORAGE_PATH", patterns))
+		assert.False(t, spec.IsSensitiveConfig("TOKEN_URL", nil))
+	})
+	t.Run("ParseConfigKeyPatterns", func(t *testing.T) {
+		t.Run("should fail for malformed patterns", func(t *testing.T) {
+			_, err := models.ParseConfigKeyPatterns("*_TOKEN,[")
+			assert.NotNil(t, err)
+		})
+	})
 	t.Run("ApplicationHash", func(t *testing.T) {
 		rawSecret := "super secret string"
 		t.Run("should encrypt text correctly with hash", func(t *testing.T) {
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RoleViewer can read specifications and statuses
+	RoleViewer = "viewer"
+	// RoleEditor can additionally deploy, modify and run jobs and resources
+	RoleEditor = "editor"
+	// RoleAdmin can additionally manage namespaces, secrets and role bindings
+	RoleAdmin = "admin"
+)
+
+// roleRanks orders roles, a role includes permissions of all lower ranked roles
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// IsValidRole checks if role is one of the known roles
+func IsValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// RoleIncludes checks if role grants all the permissions of required role
+func RoleIncludes(role, required string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
+}
+
+// RoleBinding grants a role to a subject in a project or in one of its
+// namespaces
+type RoleBinding struct {
+	ID uuid.UUID
+
+	// Subject of the authenticated identity, matched with either subject
+	// or email of the identity
+	Subject string
+
+	Role string
+
+	// Namespace the role is granted in, empty for the whole project
+	Namespace string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Matches checks if binding belongs to identity
+func (b RoleBinding) Matches(identity Identity) bool {
+	return b.Subject == identity.Subject || (identity.Email != "" && b.Subject == identity.Email)
+}
+
+// AppliesTo checks if binding grants its role in namespace, project level
+// access is checked with an empty namespace
+func (b RoleBinding) AppliesTo(namespace string) bool {
+	return b.Namespace == "" || b.Namespace == namespace
+}
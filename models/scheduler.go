@@ -3,6 +3,8 @@ package models
 import (
 	"context"
 	"time"
+
+	"github.com/odpf/optimus/core/progress"
 )
 
 var (
@@ -33,8 +35,24 @@ type SchedulerUnit interface {
 	GetJobsExtension() string
 
 	// Bootstrap will be executed per project when the application boots up
-	// this can be used to do adhoc commands for initialization of scheduler
-	Bootstrap(context.Context, ProjectSpec) error
+	// and when a project is registered, this can be used to do adhoc
+	// commands for initialization of scheduler like uploading artifacts
+	// shared by jobs of the project
+	Bootstrap(ctx context.Context, projSpec ProjectSpec, obs progress.Observer) error
+
+	// ValidateProject checks config of a project needed by scheduler
+	// before the project is registered
+	ValidateProject(projSpec ProjectSpec) error
+
+	// DeployJobs compiles and uploads jobs of a namespace to scheduler,
+	// replacing already deployed jobs with the same name
+	DeployJobs(ctx context.Context, namespace NamespaceSpec, jobs []JobSpec, obs progress.Observer) error
+
+	// DeleteJobs removes deployed jobs of a namespace from scheduler
+	DeleteJobs(ctx context.Context, namespace NamespaceSpec, jobNames []string, obs progress.Observer) error
+
+	// ListJobs returns names of jobs of a namespace deployed to scheduler
+	ListJobs(ctx context.Context, namespace NamespaceSpec) ([]string, error)
 
 	// GetJobStatus should return the current and previous status of job
 	GetJobStatus(ctx context.Context, projSpec ProjectSpec, jobName string) ([]JobStatus, error)
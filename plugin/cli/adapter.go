@@ -12,6 +12,7 @@ func AdaptQuestionToProto(q models.PluginQuestion) *pb.PluginQuestion {
 		Help:        q.Help,
 		Default:     q.Default,
 		Multiselect: q.Multiselect,
+		Validation:  q.Validation,
 	}
 	var protoSubQuestions []*pb.PluginQuestion_SubQuestion
 	if len(q.SubQuestions) > 0 {
@@ -37,6 +38,7 @@ func AdaptQuestionFromProto(q *pb.PluginQuestion) models.PluginQuestion {
 		Help:         q.Help,
 		Default:      q.Default,
 		Multiselect:  q.Multiselect,
+		Validation:   q.Validation,
 		SubQuestions: []models.PluginSubQuestion{},
 	}
 	if len(q.SubQuestions) > 0 {
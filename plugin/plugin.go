@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/odpf/optimus/plugin/dependencyresolver"
@@ -23,8 +24,10 @@ import (
 	"github.com/hashicorp/go-plugin"
 )
 
-func Initialize(pluginLogger hclog.Logger) error {
-	discoveredPlugins, err := DiscoverPlugins(pluginLogger)
+// Initialize discovers plugin binaries, validates their handshake and api
+// version and registers them, pluginDir if provided is searched first
+func Initialize(pluginLogger hclog.Logger, pluginDir string) error {
+	discoveredPlugins, err := DiscoverPlugins(pluginLogger, pluginDir)
 	if err != nil {
 		return errors.Wrap(err, "DiscoverPlugins")
 	}
@@ -51,6 +54,7 @@ func Initialize(pluginLogger hclog.Logger) error {
 		// connect via GRPC
 		rpcClient, err := pluginClient.Client()
 		if err != nil {
+			pluginClient.Kill()
 			return errors.Wrapf(err, "client.Client(): %s", pluginPath)
 		}
 
@@ -67,8 +71,14 @@ func Initialize(pluginLogger hclog.Logger) error {
 		baseClient = raw.(models.BasePlugin)
 		baseInfo, err := baseClient.PluginInfo()
 		if err != nil {
+			pluginClient.Kill()
 			return errors.Wrapf(err, "failed to read plugin info: %s", pluginPath)
 		}
+		if !apiVersionSupported(baseInfo.APIVersion) {
+			pluginClient.Kill()
+			return errors.Errorf("plugin %s supports api versions %s, optimus needs %d: %s",
+				baseInfo.Name, strings.Join(baseInfo.APIVersion, ", "), base.ProtocolVersion, pluginPath)
+		}
 		pluginLogger.Debug("plugin connection established: ", baseInfo.Name)
 
 		if modSupported(baseInfo.PluginMods, models.ModTypeCLI) {
@@ -92,6 +102,7 @@ func Initialize(pluginLogger hclog.Logger) error {
 		}
 
 		if err := models.PluginRegistry.Add(baseClient, cliClient, drClient); err != nil {
+			pluginClient.Kill()
 			return errors.Wrapf(err, "PluginRegistry.Add: %s", pluginPath)
 		}
 		pluginLogger.Debug("plugin ready: ", baseInfo.Name)
@@ -100,6 +111,20 @@ func Initialize(pluginLogger hclog.Logger) error {
 	return nil
 }
 
+// apiVersionSupported checks plugin implements protocol of this core,
+// plugins not declaring their api versions are assumed to support it
+func apiVersionSupported(versions []string) bool {
+	if len(versions) == 0 {
+		return true
+	}
+	for _, v := range versions {
+		if v == strconv.Itoa(base.ProtocolVersion) {
+			return true
+		}
+	}
+	return false
+}
+
 func modSupported(mods []models.PluginMod, mod models.PluginMod) bool {
 	for _, m := range mods {
 		if m == mod {
@@ -111,6 +136,7 @@ func modSupported(mods []models.PluginMod, mod models.PluginMod) bool {
 
 // DiscoverPlugins look for plugin binaries in following folders
 // order to search is top to down
+// <pluginDir>/ if configured
 // ./
 // <exec>/
 // <exec>/.optimus/plugins
@@ -118,9 +144,11 @@ func modSupported(mods []models.PluginMod, mod models.PluginMod) bool {
 // /usr/bin
 // /usr/local/bin
 //
-// for duplicate binaries(even with different versions for now), only the first found will be used
-// sample plugin name: optimus-myplugin_linux_amd64
-func DiscoverPlugins(pluginLogger hclog.Logger) ([]string, error) {
+// for duplicate binaries, only the first found will be used. Older versions of a plugin
+// can be installed along with the latest one by keeping the version in binary name,
+// jobs can pin them in their spec
+// sample plugin name: optimus-myplugin_linux_amd64, optimus-myplugin-0.1.0_linux_amd64
+func DiscoverPlugins(pluginLogger hclog.Logger, pluginDir string) ([]string, error) {
 	var (
 		prefix            = "optimus-"
 		suffix            = fmt.Sprintf("_%s_%s", runtime.GOOS, runtime.GOARCH)
@@ -128,6 +156,14 @@ func DiscoverPlugins(pluginLogger hclog.Logger) ([]string, error) {
 	)
 
 	var dirs []string
+	if pluginDir != "" {
+		// configured directory should exist, a typo would otherwise
+		// silently run without its plugins
+		if info, err := os.Stat(pluginDir); err != nil || !info.IsDir() {
+			return nil, errors.Errorf("plugin directory %s is not readable", pluginDir)
+		}
+		dirs = append(dirs, pluginDir)
+	}
 	// current working directory
 	if p, err := os.Getwd(); err == nil {
 		dirs = append(dirs, p)
@@ -181,15 +217,13 @@ func DiscoverPlugins(pluginLogger hclog.Logger) ([]string, error) {
 				continue
 			}
 
-			// get plugin name
-			pluginName := strings.Split(fullName, "_")[0]
 			absPath = filepath.Clean(absPath)
 
-			// check for duplicate binaries, could be different versions
+			// check for duplicate binaries in different folders
 			// if we have already discovered one, ignore rest
 			isAlreadyFound := false
 			for _, storedName := range discoveredPlugins {
-				if strings.Contains(storedName, pluginName) {
+				if filepath.Base(storedName) == fullName {
 					isAlreadyFound = true
 				}
 			}
@@ -0,0 +1,34 @@
+package plugin_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/odpf/optimus/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverPlugins(t *testing.T) {
+	t.Run("should discover plugins of configured directory first", func(t *testing.T) {
+		pluginDir, err := ioutil.TempDir("", "optimus-plugins")
+		assert.Nil(t, err)
+		defer os.RemoveAll(pluginDir)
+
+		binaryName := fmt.Sprintf("optimus-neo-test_%s_%s", runtime.GOOS, runtime.GOARCH)
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(pluginDir, binaryName), []byte{}, 0755))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(pluginDir, "neo-test"), []byte{}, 0755))
+
+		discovered, err := plugin.DiscoverPlugins(hclog.NewNullLogger(), pluginDir)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(pluginDir, binaryName), discovered[0])
+	})
+	t.Run("should fail if configured directory doesn't exist", func(t *testing.T) {
+		_, err := plugin.DiscoverPlugins(hclog.NewNullLogger(), "/non-existent/optimus-plugins")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,112 @@
+// Package cache keeps projects and namespaces read from the store for a
+// short while, as most of the requests look them up by name before doing
+// anything else. Cached specs are dropped as soon as a project, its
+// namespaces, secrets or macros are saved through the wrapped repositories,
+// changes made by other servers are picked once the cached specs expire.
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/odpf/optimus/models"
+)
+
+type projectEntry struct {
+	spec      models.ProjectSpec
+	expiresAt time.Time
+}
+
+type namespaceEntry struct {
+	spec      models.NamespaceSpec
+	expiresAt time.Time
+}
+
+// Lookups caches projects and namespaces by name for ttl, it is shared by
+// repositories of all the requests
+type Lookups struct {
+	mu  sync.Mutex
+	ttl time.Duration
+
+	projects map[string]projectEntry
+	// namespaces by project name and then namespace name
+	namespaces map[string]map[string]namespaceEntry
+	// generations of projects are bumped on invalidation so that specs
+	// read before it are not cached after it
+	generations map[string]uint64
+
+	Now func() time.Time
+}
+
+func (c *Lookups) project(name string) (models.ProjectSpec, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.projects[name]
+	if !ok || !c.Now().Before(entry.expiresAt) {
+		return models.ProjectSpec{}, c.generations[name], false
+	}
+	return entry.spec, c.generations[name], true
+}
+
+func (c *Lookups) setProject(spec models.ProjectSpec, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[spec.Name] != generation {
+		return
+	}
+	c.projects[spec.Name] = projectEntry{
+		spec:      spec,
+		expiresAt: c.Now().Add(c.ttl),
+	}
+}
+
+func (c *Lookups) namespace(projectName, name string) (models.NamespaceSpec, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.namespaces[projectName][name]
+	if !ok || !c.Now().Before(entry.expiresAt) {
+		return models.NamespaceSpec{}, c.generations[projectName], false
+	}
+	return entry.spec, c.generations[projectName], true
+}
+
+func (c *Lookups) setNamespace(projectName string, spec models.NamespaceSpec, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[projectName] != generation {
+		return
+	}
+	if c.namespaces[projectName] == nil {
+		c.namespaces[projectName] = map[string]namespaceEntry{}
+	}
+	c.namespaces[projectName][spec.Name] = namespaceEntry{
+		spec:      spec,
+		expiresAt: c.Now().Add(c.ttl),
+	}
+}
+
+// InvalidateProject drops the cached project along with its namespaces,
+// namespaces carry config, secrets and macros of their project
+func (c *Lookups) InvalidateProject(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.projects, name)
+	delete(c.namespaces, name)
+	c.generations[name]++
+}
+
+// NewLookups constructs a cache keeping specs for ttl
+func NewLookups(ttl time.Duration) *Lookups {
+	return &Lookups{
+		ttl:         ttl,
+		projects:    map[string]projectEntry{},
+		namespaces:  map[string]map[string]namespaceEntry{},
+		generations: map[string]uint64{},
+		Now:         time.Now,
+	}
+}
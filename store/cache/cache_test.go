@@ -0,0 +1,113 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookups(t *testing.T) {
+	projectSpec := models.ProjectSpec{
+		Name:   "t-optimus",
+		Config: map[string]string{"bucket": "gs://some_folder"},
+	}
+	namespaceSpec := models.NamespaceSpec{
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should read projects from repository once till they expire", func(t *testing.T) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetByName", projectSpec.Name).Return(projectSpec, nil).Twice()
+		defer projectRepo.AssertExpectations(t)
+
+		lookups := cache.NewLookups(time.Minute)
+		lookups.Now = func() time.Time { return now }
+		repo := cache.NewProjectRepository(projectRepo, lookups)
+		for i := 0; i < 3; i++ {
+			spec, err := repo.GetByName(projectSpec.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, projectSpec, spec)
+		}
+
+		lookups.Now = func() time.Time { return now.Add(time.Minute) }
+		spec, err := repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, projectSpec, spec)
+	})
+	t.Run("should not cache projects which fail to be read", func(t *testing.T) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetByName", "unknown").Return(models.ProjectSpec{}, store.ErrResourceNotFound).Twice()
+		defer projectRepo.AssertExpectations(t)
+
+		repo := cache.NewProjectRepository(projectRepo, cache.NewLookups(time.Minute))
+		for i := 0; i < 2; i++ {
+			_, err := repo.GetByName("unknown")
+			assert.Equal(t, store.ErrResourceNotFound, err)
+		}
+	})
+	t.Run("should drop project and its namespaces once the project is saved", func(t *testing.T) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetByName", projectSpec.Name).Return(projectSpec, nil).Twice()
+		projectRepo.On("Save", projectSpec).Return(nil)
+		defer projectRepo.AssertExpectations(t)
+
+		namespaceRepo := new(mock.NamespaceRepository)
+		namespaceRepo.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil).Twice()
+		defer namespaceRepo.AssertExpectations(t)
+
+		lookups := cache.NewLookups(time.Minute)
+		repo := cache.NewProjectRepository(projectRepo, lookups)
+		nsRepo := cache.NewNamespaceRepository(namespaceRepo, projectSpec, lookups)
+		_, err := repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+		_, err = nsRepo.GetByName(namespaceSpec.Name)
+		assert.Nil(t, err)
+
+		assert.Nil(t, repo.Save(projectSpec))
+		_, err = repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+		_, err = nsRepo.GetByName(namespaceSpec.Name)
+		assert.Nil(t, err)
+	})
+	t.Run("should drop project once it is deleted", func(t *testing.T) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetByName", projectSpec.Name).Return(projectSpec, nil).Once()
+		projectRepo.On("Delete", projectSpec.Name).Return(nil)
+		projectRepo.On("GetByName", projectSpec.Name).Return(models.ProjectSpec{}, store.ErrResourceNotFound).Once()
+		defer projectRepo.AssertExpectations(t)
+
+		repo := cache.NewProjectRepository(projectRepo, cache.NewLookups(time.Minute))
+		_, err := repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+
+		assert.Nil(t, repo.Delete(projectSpec.Name))
+		_, err = repo.GetByName(projectSpec.Name)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+	t.Run("should drop project once its secrets are saved", func(t *testing.T) {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetByName", projectSpec.Name).Return(projectSpec, nil).Twice()
+		defer projectRepo.AssertExpectations(t)
+
+		secret := models.ProjectSecretItem{Name: "secret", Value: "value"}
+		secretRepo := new(mock.ProjectSecretRepository)
+		secretRepo.On("Save", secret).Return(nil)
+		defer secretRepo.AssertExpectations(t)
+
+		lookups := cache.NewLookups(time.Minute)
+		repo := cache.NewProjectRepository(projectRepo, lookups)
+		_, err := repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+
+		assert.Nil(t, cache.NewSecretRepository(secretRepo, projectSpec, lookups).Save(secret))
+		_, err = repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+// projectRepository reads projects through the cache
+type projectRepository struct {
+	store.ProjectRepository
+	lookups *Lookups
+}
+
+func (repo *projectRepository) Save(spec models.ProjectSpec) error {
+	defer repo.lookups.InvalidateProject(spec.Name)
+	return repo.ProjectRepository.Save(spec)
+}
+
+func (repo *projectRepository) GetByName(name string) (models.ProjectSpec, error) {
+	spec, generation, ok := repo.lookups.project(name)
+	if ok {
+		return spec, nil
+	}
+	spec, err := repo.ProjectRepository.GetByName(name)
+	if err != nil {
+		return models.ProjectSpec{}, err
+	}
+	repo.lookups.setProject(spec, generation)
+	return spec, nil
+}
+
+func (repo *projectRepository) Delete(name string) error {
+	defer repo.lookups.InvalidateProject(name)
+	return repo.ProjectRepository.Delete(name)
+}
+
+func (repo *projectRepository) Restore(name string) error {
+	defer repo.lookups.InvalidateProject(name)
+	return repo.ProjectRepository.Restore(name)
+}
+
+func (repo *projectRepository) Purge(deletedBefore time.Time) ([]string, error) {
+	purged, err := repo.ProjectRepository.Purge(deletedBefore)
+	for _, name := range purged {
+		repo.lookups.InvalidateProject(name)
+	}
+	return purged, err
+}
+
+// NewProjectRepository caches projects read by name from repo
+func NewProjectRepository(repo store.ProjectRepository, lookups *Lookups) *projectRepository {
+	return &projectRepository{
+		ProjectRepository: repo,
+		lookups:           lookups,
+	}
+}
+
+// namespaceRepository reads namespaces of a project through the cache
+type namespaceRepository struct {
+	store.NamespaceRepository
+	project models.ProjectSpec
+	lookups *Lookups
+}
+
+func (repo *namespaceRepository) Save(spec models.NamespaceSpec) error {
+	defer repo.lookups.InvalidateProject(repo.project.Name)
+	return repo.NamespaceRepository.Save(spec)
+}
+
+func (repo *namespaceRepository) GetByName(name string) (models.NamespaceSpec, error) {
+	spec, generation, ok := repo.lookups.namespace(repo.project.Name, name)
+	if ok {
+		return spec, nil
+	}
+	spec, err := repo.NamespaceRepository.GetByName(name)
+	if err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	repo.lookups.setNamespace(repo.project.Name, spec, generation)
+	return spec, nil
+}
+
+// NewNamespaceRepository caches namespaces of project read by name from repo
+func NewNamespaceRepository(repo store.NamespaceRepository, project models.ProjectSpec, lookups *Lookups) *namespaceRepository {
+	return &namespaceRepository{
+		NamespaceRepository: repo,
+		project:             project,
+		lookups:             lookups,
+	}
+}
+
+// secretRepository drops the cached project when its secrets change
+type secretRepository struct {
+	store.ProjectSecretRepository
+	project models.ProjectSpec
+	lookups *Lookups
+}
+
+func (repo *secretRepository) Save(item models.ProjectSecretItem) error {
+	defer repo.lookups.InvalidateProject(repo.project.Name)
+	return repo.ProjectSecretRepository.Save(item)
+}
+
+// NewSecretRepository invalidates the cached project as secrets are saved
+func NewSecretRepository(repo store.ProjectSecretRepository, project models.ProjectSpec, lookups *Lookups) *secretRepository {
+	return &secretRepository{
+		ProjectSecretRepository: repo,
+		project:                 project,
+		lookups:                 lookups,
+	}
+}
+
+// macroRepository drops the cached project when its macros change
+type macroRepository struct {
+	store.MacroRepository
+	project models.ProjectSpec
+	lookups *Lookups
+}
+
+func (repo *macroRepository) Save(macro models.Macro) error {
+	defer repo.lookups.InvalidateProject(repo.project.Name)
+	return repo.MacroRepository.Save(macro)
+}
+
+func (repo *macroRepository) Delete(name, namespace string) error {
+	defer repo.lookups.InvalidateProject(repo.project.Name)
+	return repo.MacroRepository.Delete(name, namespace)
+}
+
+// NewMacroRepository invalidates the cached project as macros are changed
+func NewMacroRepository(repo store.MacroRepository, project models.ProjectSpec, lookups *Lookups) *macroRepository {
+	return &macroRepository{
+		MacroRepository: repo,
+		project:         project,
+		lookups:         lookups,
+	}
+}
@@ -0,0 +1,246 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// SchemePrefix marks storage paths kept in a git repository, rest of
+	// the scheme is the transport used to reach the remote
+	SchemePrefix = "git+"
+
+	repoSuffix = ".git"
+
+	// pushAttempts is how many times a change is rebuilt on top of the
+	// remote when pushes are rejected by concurrent writers
+	pushAttempts = 3
+
+	commitAuthorName  = "optimus"
+	commitAuthorEmail = "optimus@localhost"
+)
+
+// Remote is a git repository parsed from storage paths of the form
+// git+<transport>://<host>/<repo>.git[/<dir>][?ref=<branch>]
+type Remote struct {
+	// URL used to clone the repository, it carries credentials if any
+	URL string
+
+	// Path of repository on host, e.g. /org/dags.git
+	Path string
+
+	// Dir inside repository where objects are kept
+	Dir string
+
+	// Ref is the branch objects are pushed to, remote's default branch
+	// is used if empty
+	Ref string
+}
+
+// ParseRemote parses git remote of a storage path, secret is used as
+// password of http remotes while ssh remotes rely on keys of the host
+func ParseRemote(storagePath, secret string) (Remote, error) {
+	p, err := url.Parse(storagePath)
+	if err != nil {
+		return Remote{}, err
+	}
+	if !strings.HasPrefix(p.Scheme, SchemePrefix) {
+		return Remote{}, errors.Errorf("%s is not a git storage", storagePath)
+	}
+	idx := strings.Index(p.Path+"/", repoSuffix+"/")
+	if idx < 0 {
+		return Remote{}, errors.Errorf("git storage %s should point to a repository ending with %s", storagePath, repoSuffix)
+	}
+
+	remoteURL := url.URL{
+		Scheme: strings.TrimPrefix(p.Scheme, SchemePrefix),
+		User:   p.User,
+		Host:   p.Host,
+		Path:   p.Path[:idx+len(repoSuffix)],
+	}
+	if secret != "" && strings.HasPrefix(remoteURL.Scheme, "http") {
+		username := "optimus"
+		if p.User != nil && p.User.Username() != "" {
+			username = p.User.Username()
+		}
+		remoteURL.User = url.UserPassword(username, secret)
+	}
+	return Remote{
+		URL:  remoteURL.String(),
+		Path: remoteURL.Path,
+		Dir:  strings.Trim(p.Path[idx+len(repoSuffix):], "/"),
+		Ref:  p.Query().Get("ref"),
+	}, nil
+}
+
+// Repository is a working copy of a remote, every change is committed and
+// pushed to the remote right away. Changes are serialized as a working copy
+// can not be shared by concurrent git commands
+type Repository struct {
+	mu sync.Mutex
+
+	remote  Remote
+	workDir string
+	ref     string
+	fs      afero.Fs
+}
+
+// Read calls fn with working copy synced with remote
+func (r *Repository) Read(ctx context.Context, fn func(fs afero.Fs) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.sync(ctx); err != nil {
+		return err
+	}
+	return fn(r.fs)
+}
+
+// Publish applies change to working copy, commits and pushes it. The change
+// is applied again on top of the remote if the push is rejected
+func (r *Repository) Publish(ctx context.Context, message string, change func(fs afero.Fs) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pushErr error
+	for attempt := 0; attempt < pushAttempts; attempt++ {
+		if err := r.sync(ctx); err != nil {
+			return err
+		}
+		if err := change(r.fs); err != nil {
+			return err
+		}
+		if _, err := r.git(ctx, "add", "--all"); err != nil {
+			return err
+		}
+		status, err := r.git(ctx, "status", "--porcelain")
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(status) == "" {
+			return nil
+		}
+		if _, err := r.git(ctx, "commit", "--message", message); err != nil {
+			return err
+		}
+		if _, pushErr = r.git(ctx, "push", "origin", "HEAD:refs/heads/"+r.ref); pushErr == nil {
+			return nil
+		}
+	}
+	return errors.Wrapf(pushErr, "failed to push to %s", r.remote.Path)
+}
+
+// sync resets working copy to the latest commit of remote
+func (r *Repository) sync(ctx context.Context) error {
+	if _, err := r.git(ctx, "fetch", "--depth", "1", "origin", r.ref); err != nil {
+		return err
+	}
+	if _, err := r.git(ctx, "reset", "--hard", "FETCH_HEAD"); err != nil {
+		return err
+	}
+	// drop leftovers of changes which failed before being committed
+	_, err := r.git(ctx, "clean", "--force", "-d")
+	return err
+}
+
+func (r *Repository) git(ctx context.Context, args ...string) (string, error) {
+	return runGit(ctx, r.workDir, args...)
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// never wait on credential prompts
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_AUTHOR_NAME="+commitAuthorName,
+		"GIT_AUTHOR_EMAIL="+commitAuthorEmail,
+		"GIT_COMMITTER_NAME="+commitAuthorName,
+		"GIT_COMMITTER_EMAIL="+commitAuthorEmail,
+	)
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Clone clones remote in workDir, an existing clone in it is reused
+func Clone(ctx context.Context, remote Remote, workDir string) (*Repository, error) {
+	if _, err := os.Stat(filepath.Join(workDir, repoSuffix)); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(workDir), os.ModePerm); err != nil {
+			return nil, err
+		}
+		args := []string{"clone", "--depth", "1"}
+		if remote.Ref != "" {
+			args = append(args, "--branch", remote.Ref)
+		}
+		if _, err := runGit(ctx, "", append(args, remote.URL, workDir)...); err != nil {
+			return nil, errors.Wrapf(err, "failed to clone %s", remote.Path)
+		}
+	} else if _, err := runGit(ctx, workDir, "remote", "set-url", "origin", remote.URL); err != nil {
+		return nil, err
+	}
+
+	ref := remote.Ref
+	if ref == "" {
+		head, err := runGit(ctx, workDir, "symbolic-ref", "--short", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		ref = strings.TrimSpace(head)
+	}
+	return &Repository{
+		remote:  remote,
+		workDir: workDir,
+		ref:     ref,
+		fs:      afero.NewBasePathFs(afero.NewOsFs(), workDir),
+	}, nil
+}
+
+// Pool keeps a single working copy for every remote
+type Pool struct {
+	mu    sync.Mutex
+	dir   string
+	repos map[string]*Repository
+}
+
+// Get returns working copy of remote, cloning it on first use
+func (p *Pool) Get(ctx context.Context, remote Remote) (*Repository, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := fmt.Sprintf("%s#%s", remote.URL, remote.Ref)
+	if repo, ok := p.repos[key]; ok {
+		return repo, nil
+	}
+	sum := sha256.Sum256([]byte(key))
+	repo, err := Clone(ctx, remote, filepath.Join(p.dir, hex.EncodeToString(sum[:8])))
+	if err != nil {
+		return nil, err
+	}
+	p.repos[key] = repo
+	return repo, nil
+}
+
+// NewPool constructs a pool keeping working copies in dir
+func NewPool(dir string) *Pool {
+	return &Pool{
+		dir:   dir,
+		repos: map[string]*Repository{},
+	}
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/local"
+	"github.com/spf13/afero"
+)
+
+// jobRepository keeps compiled jobs in a dir of a git repository in the
+// same layout as local filesystem storage
+type jobRepository struct {
+	repo   *Repository
+	dir    string
+	suffix string
+}
+
+func (r *jobRepository) Save(ctx context.Context, j models.Job) error {
+	return r.repo.Publish(ctx, fmt.Sprintf("deploy job %s", j.Name), func(fs afero.Fs) error {
+		return r.jobsOf(fs).Save(ctx, j)
+	})
+}
+
+func (r *jobRepository) Delete(ctx context.Context, namespace models.NamespaceSpec, jobName string) error {
+	return r.repo.Publish(ctx, fmt.Sprintf("delete job %s", jobName), func(fs afero.Fs) error {
+		return r.jobsOf(fs).Delete(ctx, namespace, jobName)
+	})
+}
+
+func (r *jobRepository) GetAll(ctx context.Context) (jobs []models.Job, err error) {
+	err = r.repo.Read(ctx, func(fs afero.Fs) error {
+		jobs, err = r.jobsOf(fs).GetAll(ctx)
+		return err
+	})
+	return jobs, err
+}
+
+func (r *jobRepository) ListNames(ctx context.Context, namespace models.NamespaceSpec) (names []string, err error) {
+	err = r.repo.Read(ctx, func(fs afero.Fs) error {
+		names, err = r.jobsOf(fs).ListNames(ctx, namespace)
+		return err
+	})
+	return names, err
+}
+
+func (r *jobRepository) GetByName(ctx context.Context, jobName string) (j models.Job, err error) {
+	err = r.repo.Read(ctx, func(fs afero.Fs) error {
+		j, err = r.jobsOf(fs).GetByName(ctx, jobName)
+		return err
+	})
+	return j, err
+}
+
+func (r *jobRepository) jobsOf(fs afero.Fs) store.JobRepository {
+	return local.NewCompiledJobRepository(fs, r.dir, r.suffix)
+}
+
+// NewJobRepository constructs a repository keeping compiled jobs in dir
+// of the git repository, every change is pushed as a commit
+func NewJobRepository(repo *Repository, dir, suffix string) *jobRepository {
+	return &jobRepository{
+		repo:   repo,
+		dir:    filepath.Join(string(filepath.Separator), dir),
+		suffix: suffix,
+	}
+}
+
+// ObjectWriter writes objects as files of a git repository, paths of
+// objects include path of repository on its host as buckets of other
+// storages do
+type ObjectWriter struct {
+	Repo *Repository
+}
+
+func (w *ObjectWriter) NewWriter(ctx context.Context, bucket, path string) (io.WriteCloser, error) {
+	repoPath := strings.Trim(w.Repo.remote.Path, "/")
+	return &objectWriter{
+		ctx:  ctx,
+		repo: w.Repo,
+		path: strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(path, "/"), repoPath), "/"),
+	}, nil
+}
+
+// objectWriter buffers object and pushes it on close
+type objectWriter struct {
+	bytes.Buffer
+
+	ctx  context.Context
+	repo *Repository
+	path string
+}
+
+func (w *objectWriter) Close() error {
+	return w.repo.Publish(w.ctx, fmt.Sprintf("upload %s", w.path), func(fs afero.Fs) error {
+		if err := fs.MkdirAll(filepath.Dir(w.path), os.ModePerm); err != nil {
+			return err
+		}
+		return afero.WriteFile(fs, w.path, w.Bytes(), 0644)
+	})
+}
@@ -0,0 +1,88 @@
+package local
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// MaxArchiveSize is the maximum size of uncompressed contents
+	// accepted from a spec archive
+	MaxArchiveSize = 256 << 20
+)
+
+var (
+	ErrArchiveTooLarge = errors.New("archive contents exceed maximum allowed size")
+)
+
+// ExtractArchive unpacks a gzipped tar archive of specifications in provided
+// file system, entries are kept relative to the root of the archive
+func ExtractArchive(archive io.Reader, fs afero.Fs) error {
+	gzr, err := gzip.NewReader(archive)
+	if err != nil {
+		return errors.Wrap(err, "failed to read archive")
+	}
+	defer gzr.Close()
+
+	var extractedSize int64
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read archive entry")
+		}
+
+		entryPath, err := archiveEntryPath(header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fs.MkdirAll(entryPath, os.FileMode(0765)|os.ModeDir); err != nil {
+				return errors.Wrapf(err, "fs.MkdirAll: %s", entryPath)
+			}
+		case tar.TypeReg:
+			extractedSize += header.Size
+			if extractedSize > MaxArchiveSize {
+				return ErrArchiveTooLarge
+			}
+			if err := fs.MkdirAll(filepath.Dir(entryPath), os.FileMode(0765)|os.ModeDir); err != nil {
+				return errors.Wrapf(err, "fs.MkdirAll: %s", filepath.Dir(entryPath))
+			}
+			fd, err := fs.OpenFile(entryPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(0755))
+			if err != nil {
+				return errors.Wrapf(err, "fs.OpenFile: %s", entryPath)
+			}
+			if _, err := io.CopyN(fd, tr, header.Size); err != nil {
+				fd.Close()
+				return errors.Wrapf(err, "failed to extract %s", entryPath)
+			}
+			fd.Close()
+		default:
+			// links and special files are not part of a spec directory
+			continue
+		}
+	}
+	return nil
+}
+
+// archiveEntryPath cleans the name of an archive entry and makes sure
+// it doesn't point outside of the extraction root
+func archiveEntryPath(name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid archive entry: %s", name)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,68 @@
+package local_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/store/local"
+)
+
+func buildArchive(t *testing.T, files map[string]string) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		assert.Nil(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		}))
+		_, err := tw.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gzw.Close())
+	return buf
+}
+
+func TestExtractArchive(t *testing.T) {
+	t.Run("should extract all files of archive", func(t *testing.T) {
+		archive := buildArchive(t, map[string]string{
+			"this.yaml":                 "version: 1",
+			"job-a/job.yaml":            testJobContents,
+			"job-a/assets/query.sql":    "select * from 1",
+			"./nested/job-b/job.yaml":   testJobContents,
+			"nested/job-b/../this.yaml": "version: 1",
+		})
+
+		fs := afero.NewMemMapFs()
+		err := local.ExtractArchive(archive, fs)
+		assert.Nil(t, err)
+
+		for _, path := range []string{"this.yaml", "job-a/job.yaml", "job-a/assets/query.sql", "nested/job-b/job.yaml", "nested/this.yaml"} {
+			exists, err := afero.Exists(fs, path)
+			assert.Nil(t, err)
+			assert.True(t, exists, path)
+		}
+		content, err := afero.ReadFile(fs, "job-a/assets/query.sql")
+		assert.Nil(t, err)
+		assert.Equal(t, "select * from 1", string(content))
+	})
+	t.Run("should fail if an entry points outside of archive root", func(t *testing.T) {
+		archive := buildArchive(t, map[string]string{
+			"../job.yaml": testJobContents,
+		})
+		err := local.ExtractArchive(archive, afero.NewMemMapFs())
+		assert.NotNil(t, err)
+	})
+	t.Run("should fail if input is not a gzipped archive", func(t *testing.T) {
+		err := local.ExtractArchive(bytes.NewBufferString("job.yaml"), afero.NewMemMapFs())
+		assert.NotNil(t, err)
+	})
+}
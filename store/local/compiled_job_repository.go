@@ -0,0 +1,145 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+var (
+	errEmptyJobName = errors.New("job name cannot be an empty string")
+)
+
+// compiledJobRepository keeps compiled jobs as files of a directory, with
+// a sub directory for every namespace
+type compiledJobRepository struct {
+	fs     afero.Fs
+	dir    string
+	suffix string
+}
+
+func (repo *compiledJobRepository) Save(ctx context.Context, j models.Job) error {
+	filePath := repo.pathFor(j.NamespaceID, j.Name)
+	if err := repo.fs.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+	return afero.WriteFile(repo.fs, filePath, j.Contents, 0644)
+}
+
+func (repo *compiledJobRepository) Delete(ctx context.Context, namespace models.NamespaceSpec, jobName string) error {
+	if strings.TrimSpace(jobName) == "" {
+		return errEmptyJobName
+	}
+	filePath := repo.pathFor(namespace.ID.String(), jobName)
+	if _, err := repo.fs.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrap(models.ErrNoSuchJob, jobName)
+		}
+		return err
+	}
+	return repo.fs.Remove(filePath)
+}
+
+func (repo *compiledJobRepository) GetAll(ctx context.Context) ([]models.Job, error) {
+	filePaths, err := repo.jobFiles(repo.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []models.Job
+	for _, filePath := range filePaths {
+		contents, err := afero.ReadFile(repo.fs, filePath)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, models.Job{
+			Name:        repo.jobNameFromPath(filePath),
+			NamespaceID: filepath.Base(filepath.Dir(filePath)),
+			Contents:    contents,
+		})
+	}
+	return jobs, nil
+}
+
+func (repo *compiledJobRepository) ListNames(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	filePaths, err := repo.jobFiles(filepath.Join(repo.dir, namespace.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	var jobNames []string
+	for _, filePath := range filePaths {
+		jobNames = append(jobNames, repo.jobNameFromPath(filePath))
+	}
+	return jobNames, nil
+}
+
+func (repo *compiledJobRepository) GetByName(ctx context.Context, jobName string) (models.Job, error) {
+	if strings.TrimSpace(jobName) == "" {
+		return models.Job{}, errEmptyJobName
+	}
+
+	// job names are unique across namespaces of a project
+	filePaths, err := afero.Glob(repo.fs, repo.pathFor("*", jobName))
+	if err != nil {
+		return models.Job{}, err
+	}
+	if len(filePaths) == 0 {
+		return models.Job{}, errors.Wrap(models.ErrNoSuchJob, jobName)
+	}
+	contents, err := afero.ReadFile(repo.fs, filePaths[0])
+	if err != nil {
+		return models.Job{}, err
+	}
+	return models.Job{
+		Name:        jobName,
+		NamespaceID: filepath.Base(filepath.Dir(filePaths[0])),
+		Contents:    contents,
+	}, nil
+}
+
+// jobFiles walks dir for compiled jobs, a missing dir has no jobs
+func (repo *compiledJobRepository) jobFiles(dir string) ([]string, error) {
+	var filePaths []string
+	err := afero.Walk(repo.fs, dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(filePath, repo.suffix) {
+			filePaths = append(filePaths, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filePaths)
+	return filePaths, nil
+}
+
+func (repo *compiledJobRepository) pathFor(namespaceID, jobName string) string {
+	return filepath.Join(repo.dir, namespaceID, jobName+repo.suffix)
+}
+
+func (repo *compiledJobRepository) jobNameFromPath(filePath string) string {
+	return strings.TrimSuffix(filepath.Base(filePath), repo.suffix)
+}
+
+// NewCompiledJobRepository constructs a repository keeping compiled jobs
+// with the given suffix in dir of fs
+func NewCompiledJobRepository(fs afero.Fs, dir, suffix string) *compiledJobRepository {
+	return &compiledJobRepository{
+		fs:     fs,
+		dir:    dir,
+		suffix: suffix,
+	}
+}
@@ -0,0 +1,126 @@
+package local_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store/local"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompiledJobRepository(t *testing.T) {
+	ctx := context.Background()
+	namespaceSpec := models.NamespaceSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "dev-team-1",
+	}
+	otherNamespaceSpec := models.NamespaceSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "dev-team-2",
+	}
+	jobs := []models.Job{
+		{Name: "job-1", NamespaceID: namespaceSpec.ID.String(), Contents: []byte("print('job-1')")},
+		{Name: "job-2", NamespaceID: namespaceSpec.ID.String(), Contents: []byte("print('job-2')")},
+		{Name: "job-3", NamespaceID: otherNamespaceSpec.ID.String(), Contents: []byte("print('job-3')")},
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("should write job in dir of its namespace", func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			repo := local.NewCompiledJobRepository(fs, "/optimus/dags", ".py")
+			assert.Nil(t, repo.Save(ctx, jobs[0]))
+
+			contents, err := afero.ReadFile(fs, "/optimus/dags/"+namespaceSpec.ID.String()+"/job-1.py")
+			assert.Nil(t, err)
+			assert.Equal(t, jobs[0].Contents, contents)
+		})
+	})
+	t.Run("GetAll", func(t *testing.T) {
+		t.Run("should read jobs of all namespaces", func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			repo := local.NewCompiledJobRepository(fs, "/optimus/dags", ".py")
+			for _, j := range jobs {
+				assert.Nil(t, repo.Save(ctx, j))
+			}
+			assert.Nil(t, afero.WriteFile(fs, "/optimus/dags/__lib.txt", []byte("not a job"), 0644))
+
+			all, err := repo.GetAll(ctx)
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, jobs, all)
+		})
+		t.Run("should return no jobs when dir does not exist", func(t *testing.T) {
+			repo := local.NewCompiledJobRepository(afero.NewMemMapFs(), "/optimus/dags", ".py")
+			all, err := repo.GetAll(ctx)
+			assert.Nil(t, err)
+			assert.Empty(t, all)
+		})
+	})
+	t.Run("ListNames", func(t *testing.T) {
+		t.Run("should list jobs of namespace only", func(t *testing.T) {
+			repo := local.NewCompiledJobRepository(afero.NewMemMapFs(), "/optimus/dags", ".py")
+			for _, j := range jobs {
+				assert.Nil(t, repo.Save(ctx, j))
+			}
+
+			names, err := repo.ListNames(ctx, namespaceSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-1", "job-2"}, names)
+		})
+	})
+	t.Run("GetByName", func(t *testing.T) {
+		repo := local.NewCompiledJobRepository(afero.NewMemMapFs(), "/optimus/dags", ".py")
+		for _, j := range jobs {
+			assert.Nil(t, repo.Save(ctx, j))
+		}
+
+		t.Run("should find job in any namespace", func(t *testing.T) {
+			j, err := repo.GetByName(ctx, "job-3")
+			assert.Nil(t, err)
+			assert.Equal(t, jobs[2], j)
+		})
+		t.Run("should fail for unknown job", func(t *testing.T) {
+			_, err := repo.GetByName(ctx, "unknown")
+			assert.True(t, errors.Is(err, models.ErrNoSuchJob))
+		})
+	})
+	t.Run("Delete", func(t *testing.T) {
+		repo := local.NewCompiledJobRepository(afero.NewMemMapFs(), "/optimus/dags", ".py")
+		for _, j := range jobs {
+			assert.Nil(t, repo.Save(ctx, j))
+		}
+
+		t.Run("should remove job of namespace", func(t *testing.T) {
+			assert.Nil(t, repo.Delete(ctx, namespaceSpec, "job-1"))
+			names, err := repo.ListNames(ctx, namespaceSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-2"}, names)
+		})
+		t.Run("should fail for job of other namespace", func(t *testing.T) {
+			err := repo.Delete(ctx, namespaceSpec, "job-3")
+			assert.True(t, errors.Is(err, models.ErrNoSuchJob))
+		})
+	})
+}
+
+func TestObjectStore(t *testing.T) {
+	t.Run("should write objects creating missing dirs", func(t *testing.T) {
+		store := local.NewObjectStore(afero.NewMemMapFs())
+		w, err := store.NewWriter(context.Background(), "", "optimus/dags/__lib.py")
+		assert.Nil(t, err)
+		_, err = w.Write([]byte("lib"))
+		assert.Nil(t, err)
+		assert.Nil(t, w.Close())
+
+		r, err := store.NewReader("", "optimus/dags/__lib.py")
+		assert.Nil(t, err)
+		defer r.Close()
+		contents, err := ioutil.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Equal(t, "lib", string(contents))
+	})
+}
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	JobConfigVersion = 1
+	JobConfigVersion = models.JobSpecVersion
 )
 
 var (
@@ -26,6 +26,7 @@ var (
 
 func init() {
 	_ = validator.SetValidationFunc("isCron", utils.CronIntervalValidator)
+	_ = validator.SetValidationFunc("isTimezone", utils.TimezoneValidator)
 }
 
 // Job are inputs from user to create a job
@@ -42,12 +43,31 @@ type Job struct {
 	Labels       map[string]string `yaml:"labels,omitempty"`
 	Dependencies []JobDependency
 	Hooks        []JobHook
+	Runtime      JobRuntime `yaml:"runtime,omitempty"`
+	// Paused keeps the job out of scheduler without removing it
+	Paused bool `yaml:"paused,omitempty"`
+}
+
+// JobRuntime configures how scheduler runs the job, e.g.
+// env: {LOG_LEVEL: debug} and labels: {pool: bigquery, queue: heavy}
+type JobRuntime struct {
+	Env    map[string]string `yaml:"env,omitempty"`
+	Labels map[string]string `yaml:"labels,omitempty"`
 }
 
 type JobSchedule struct {
 	StartDate string `yaml:"start_date" json:"start_date" validate:"regexp=^\\d{4}-\\d{2}-\\d{2}$"`
 	EndDate   string `yaml:"end_date,omitempty" json:"end_date"`
 	Interval  string `yaml:"interval" validate:"isCron"`
+	Timezone  string `yaml:"timezone,omitempty" json:"timezone" validate:"isTimezone"`
+	// Trigger makes runs wait for an event, interval defaults to
+	// models.JobTriggerInterval for triggered jobs
+	Trigger *JobTrigger `yaml:"trigger,omitempty" json:"trigger"`
+}
+
+type JobTrigger struct {
+	Type   string            `yaml:"type" json:"type"`
+	Config map[string]string `yaml:"config,omitempty" json:"config"`
 }
 
 type JobBehavior struct {
@@ -64,45 +84,115 @@ type JobBehaviorRetry struct {
 }
 
 type JobNotifier struct {
-	On       string `yaml:"on" json:"on" validate:"regexp=^(sla_miss|failure|)$"`
+	On       string `yaml:"on" json:"on" validate:"regexp=^(sla_miss|failure|retry|slow_run|)$"`
 	Config   map[string]string
 	Channels []string
 }
 
 type JobTask struct {
-	Name   string
-	Config yaml.MapSlice `yaml:"config,omitempty"`
-	Window JobTaskWindow
+	Name string
+	// Version pins the plugin version, latest installed one is used if empty
+	Version  string        `yaml:"version,omitempty"`
+	Config   yaml.MapSlice `yaml:"config,omitempty"`
+	Window   JobTaskWindow
+	Resource JobResource `yaml:"resource,omitempty"`
 }
 
 type JobTaskWindow struct {
 	Size       string
 	Offset     string
-	TruncateTo string `yaml:"truncate_to" validate:"regexp=^(h|d|w|M|m)$"`
+	TruncateTo string `yaml:"truncate_to" validate:"regexp=^(h|d|w|M|Q|Y)$"`
+	// Preset is a named window like last_7_days, size, offset and
+	// truncate_to if provided override values of the preset
+	Preset string `yaml:"preset,omitempty"`
+	// Modifier adjusts the window using the project calendar, e.g.
+	// previous_working_day
+	Modifier string `yaml:"modifier,omitempty"`
+}
+
+// JobResource is the compute requested for and limiting a task or hook
+// container, e.g. cpu: 500m and memory: 2Gi
+type JobResource struct {
+	Request JobResourceConfig `yaml:"request,omitempty"`
+	Limit   JobResourceConfig `yaml:"limit,omitempty"`
+}
+
+type JobResourceConfig struct {
+	CPU    string `yaml:"cpu,omitempty"`
+	Memory string `yaml:"memory,omitempty"`
+}
+
+// ToSpec converts the local's JobResource representation to the optimus' models.JobSpecResource
+func (a JobResource) ToSpec() (models.JobSpecResource, error) {
+	resource := models.JobSpecResource{
+		Request: models.JobSpecResourceConfig{CPU: a.Request.CPU, Memory: a.Request.Memory},
+		Limit:   models.JobSpecResourceConfig{CPU: a.Limit.CPU, Memory: a.Limit.Memory},
+	}
+	return resource, resource.Validate()
+}
+
+// FromSpec converts the optimus' models.JobSpecResource representation to the local's JobResource
+func (a JobResource) FromSpec(spec models.JobSpecResource) JobResource {
+	return JobResource{
+		Request: JobResourceConfig{CPU: spec.Request.CPU, Memory: spec.Request.Memory},
+		Limit:   JobResourceConfig{CPU: spec.Limit.CPU, Memory: spec.Limit.Memory},
+	}
+}
+
+// MergeFrom copies values not set on this resource from parent
+func (a *JobResource) MergeFrom(parent JobResource) {
+	if a.Request.CPU == "" {
+		a.Request.CPU = parent.Request.CPU
+	}
+	if a.Request.Memory == "" {
+		a.Request.Memory = parent.Request.Memory
+	}
+	if a.Limit.CPU == "" {
+		a.Limit.CPU = parent.Limit.CPU
+	}
+	if a.Limit.Memory == "" {
+		a.Limit.Memory = parent.Limit.Memory
+	}
 }
 
 type JobHook struct {
-	Name   string
-	Config yaml.MapSlice `yaml:"config,omitempty"`
+	Name     string
+	Version  string        `yaml:"version,omitempty"`
+	Config   yaml.MapSlice `yaml:"config,omitempty"`
+	Resource JobResource   `yaml:"resource,omitempty"`
 }
 
 // ToSpec converts the local's JobHook representation to the optimus' models.JobSpecHook
 func (a JobHook) ToSpec(pluginsRepo models.PluginRepository) (models.JobSpecHook, error) {
-	hookUnit, err := pluginsRepo.GetByName(a.Name)
+	var hookUnit *models.Plugin
+	var err error
+	if a.Version != "" {
+		hookUnit, err = pluginsRepo.GetByNameAndVersion(a.Name, a.Version)
+	} else {
+		hookUnit, err = pluginsRepo.GetByName(a.Name)
+	}
 	if err != nil {
 		return models.JobSpecHook{}, errors.Wrap(err, "spec reading error")
 	}
+	resource, err := a.Resource.ToSpec()
+	if err != nil {
+		return models.JobSpecHook{}, errors.Wrapf(err, "invalid resource of hook %s", a.Name)
+	}
 	return models.JobSpecHook{
-		Config: JobSpecConfigFromYamlSlice(a.Config),
-		Unit:   hookUnit,
+		Config:   JobSpecConfigFromYamlSlice(a.Config),
+		Unit:     hookUnit,
+		Version:  a.Version,
+		Resource: resource,
 	}, nil
 }
 
 // FromSpec converts the optimus' models.JobSpecHook representation to the local's JobHook
 func (a JobHook) FromSpec(spec models.JobSpecHook) (JobHook, error) {
 	return JobHook{
-		Name:   spec.Unit.Info().Name,
-		Config: JobSpecConfigToYamlSlice(spec.Config),
+		Name:     spec.Unit.Info().Name,
+		Version:  spec.Version,
+		Config:   JobSpecConfigToYamlSlice(spec.Config),
+		Resource: JobResource{}.FromSpec(spec.Resource),
 	}, nil
 }
 
@@ -124,6 +214,12 @@ func (conf *Job) MergeFrom(parent Job) {
 	if conf.Schedule.EndDate == "" {
 		conf.Schedule.EndDate = parent.Schedule.EndDate
 	}
+	if conf.Schedule.Timezone == "" {
+		conf.Schedule.Timezone = parent.Schedule.Timezone
+	}
+	if conf.Schedule.Interval == "" && conf.Schedule.Trigger != nil {
+		conf.Schedule.Interval = models.JobTriggerInterval
+	}
 
 	if conf.Behavior.Retry.ExponentialBackoff == false {
 		conf.Behavior.Retry.ExponentialBackoff = parent.Behavior.Retry.ExponentialBackoff
@@ -201,6 +297,27 @@ func (conf *Job) MergeFrom(parent Job) {
 		}
 	}
 
+	if parent.Runtime.Env != nil {
+		if conf.Runtime.Env == nil {
+			conf.Runtime.Env = map[string]string{}
+		}
+	}
+	for k, v := range parent.Runtime.Env {
+		if _, ok := conf.Runtime.Env[k]; !ok {
+			conf.Runtime.Env[k] = v
+		}
+	}
+	if parent.Runtime.Labels != nil {
+		if conf.Runtime.Labels == nil {
+			conf.Runtime.Labels = map[string]string{}
+		}
+	}
+	for k, v := range parent.Runtime.Labels {
+		if _, ok := conf.Runtime.Labels[k]; !ok {
+			conf.Runtime.Labels[k] = v
+		}
+	}
+
 	if parent.Dependencies != nil {
 		if conf.Dependencies == nil {
 			conf.Dependencies = []JobDependency{}
@@ -209,7 +326,7 @@ func (conf *Job) MergeFrom(parent Job) {
 	for _, dep := range parent.Dependencies {
 		alreadyExists := false
 		for _, cd := range conf.Dependencies {
-			if dep.JobName == cd.JobName && dep.Type == cd.Type {
+			if dep.isSame(cd) {
 				alreadyExists = true
 				break
 			}
@@ -231,6 +348,13 @@ func (conf *Job) MergeFrom(parent Job) {
 	if conf.Task.Window.Size == "" {
 		conf.Task.Window.Size = parent.Task.Window.Size
 	}
+	if conf.Task.Window.Preset == "" {
+		conf.Task.Window.Preset = parent.Task.Window.Preset
+	}
+	if conf.Task.Window.Modifier == "" {
+		conf.Task.Window.Modifier = parent.Task.Window.Modifier
+	}
+	conf.Task.Resource.MergeFrom(parent.Task.Resource)
 	if parent.Task.Config != nil {
 		if conf.Task.Config == nil {
 			conf.Task.Config = []yaml.MapItem{}
@@ -273,6 +397,7 @@ func (conf *Job) MergeFrom(parent Job) {
 						conf.Hooks[chi].Config = append(conf.Hooks[chi].Config, phc)
 					}
 				}
+				conf.Hooks[chi].Resource.MergeFrom(ph.Resource)
 			}
 		}
 	}
@@ -280,8 +405,9 @@ func (conf *Job) MergeFrom(parent Job) {
 		// copy non existing hooks
 		if _, ok := existingHooks[ph.Name]; !ok {
 			conf.Hooks = append(conf.Hooks, JobHook{
-				Name:   ph.Name,
-				Config: append(yaml.MapSlice{}, ph.Config...),
+				Name:     ph.Name,
+				Config:   append(yaml.MapSlice{}, ph.Config...),
+				Resource: ph.Resource,
 			})
 		}
 	}
@@ -294,9 +420,16 @@ func (conf *Job) prepareWindow() (models.JobSpecTaskWindow, error) {
 	window.Offset = 0
 	window.TruncateTo = "d"
 
+	if conf.Task.Window.Preset != "" {
+		window, err = models.GetJobSpecTaskWindowPreset(conf.Task.Window.Preset)
+		if err != nil {
+			return window, errors.Wrapf(err, "failed to parse task window %s", conf.Name)
+		}
+	}
 	if conf.Task.Window.TruncateTo != "" {
 		window.TruncateTo = conf.Task.Window.TruncateTo
 	}
+	window.Modifier = conf.Task.Window.Modifier
 
 	// check if string contains monthly notation
 	if conf.Task.Window.Size != "" {
@@ -322,12 +455,74 @@ func (conf *Job) prepareWindow() (models.JobSpecTaskWindow, error) {
 		}
 	}
 
+	if err := window.Validate(); err != nil {
+		return window, errors.Wrapf(err, "invalid task window %s", conf.Name)
+	}
 	return window, nil
 }
 
 type JobDependency struct {
-	JobName string `yaml:"job"`
-	Type    string `yaml:"type,omitempty"`
+	JobName  string `yaml:"job,omitempty"`
+	Type     string `yaml:"type,omitempty"`
+	Optional bool   `yaml:"optional,omitempty"`
+	// Timeout and PokeInterval are durations like 2h and 5m
+	Timeout      string `yaml:"timeout,omitempty"`
+	PokeInterval string `yaml:"poke_interval,omitempty"`
+	// HTTP makes it a dependency on an endpoint outside optimus instead of a job
+	HTTP *JobHTTPDependency `yaml:"http,omitempty"`
+	// External makes it a dependency on a job of another optimus server
+	External *JobExternalDependency `yaml:"external,omitempty"`
+	// Resource makes it a dependency on the job writing the resource of urn,
+	// e.g. bigquery://project:dataset.table
+	Resource string `yaml:"resource,omitempty"`
+}
+
+// waitSettings parses timeout and poke interval of dependency
+func (dep JobDependency) waitSettings() (timeout, pokeInterval time.Duration, err error) {
+	name := dep.JobName
+	if dep.Resource != "" {
+		name = dep.Resource
+	}
+	if dep.Timeout != "" {
+		if timeout, err = time.ParseDuration(dep.Timeout); err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to parse timeout of dependency %s", name)
+		}
+	}
+	if dep.PokeInterval != "" {
+		if pokeInterval, err = time.ParseDuration(dep.PokeInterval); err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to parse poke interval of dependency %s", name)
+		}
+	}
+	return timeout, pokeInterval, nil
+}
+
+type JobHTTPDependency struct {
+	Name           string            `yaml:"name"`
+	URL            string            `yaml:"url"`
+	Headers        map[string]string `yaml:"headers,omitempty"`
+	ExpectedStatus int               `yaml:"expected_status,omitempty"`
+	JSONPath       string            `yaml:"json_path,omitempty"`
+	ExpectedValue  string            `yaml:"expected_value,omitempty"`
+}
+
+type JobExternalDependency struct {
+	Host        string `yaml:"host"`
+	ProjectName string `yaml:"project"`
+	JobName     string `yaml:"job"`
+}
+
+// isSame checks if both refer to the same upstream job or http endpoint
+func (dep JobDependency) isSame(other JobDependency) bool {
+	if dep.HTTP != nil || other.HTTP != nil {
+		return dep.HTTP != nil && other.HTTP != nil && dep.HTTP.Name == other.HTTP.Name
+	}
+	if dep.External != nil || other.External != nil {
+		return dep.External != nil && other.External != nil && *dep.External == *other.External
+	}
+	if dep.Resource != "" || other.Resource != "" {
+		return dep.Resource == other.Resource
+	}
+	return dep.JobName == other.JobName && dep.Type == other.Type
 }
 
 type JobSpecAdapter struct {
@@ -351,9 +546,60 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		endDate = &end
 	}
 
+	var trigger *models.JobSpecTrigger
+	if conf.Schedule.Trigger != nil {
+		trigger = &models.JobSpecTrigger{
+			Type:   models.JobTriggerType(conf.Schedule.Trigger.Type),
+			Config: map[string]string{},
+		}
+		for key, value := range conf.Schedule.Trigger.Config {
+			trigger.Config[strings.ToUpper(key)] = value
+		}
+		if err := trigger.Validate(); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
 	// prep dirty dependencies
 	dependencies := map[string]models.JobSpecDependency{}
+	var httpDependencies []models.JobSpecHTTPDependency
+	var externalDependencies []models.JobSpecExternalDependency
+	var resourceDependencies []models.JobSpecResourceDependency
 	for _, dep := range conf.Dependencies {
+		if dep.HTTP != nil {
+			httpDependencies = append(httpDependencies, models.JobSpecHTTPDependency{
+				Name:           dep.HTTP.Name,
+				URL:            dep.HTTP.URL,
+				Headers:        dep.HTTP.Headers,
+				ExpectedStatus: dep.HTTP.ExpectedStatus,
+				JSONPath:       dep.HTTP.JSONPath,
+				ExpectedValue:  dep.HTTP.ExpectedValue,
+			})
+			continue
+		}
+		if dep.External != nil {
+			externalDependencies = append(externalDependencies, models.JobSpecExternalDependency{
+				Host:        dep.External.Host,
+				ProjectName: dep.External.ProjectName,
+				JobName:     dep.External.JobName,
+			})
+			continue
+		}
+
+		timeout, pokeInterval, err := dep.waitSettings()
+		if err != nil {
+			return models.JobSpec{}, err
+		}
+		if dep.Resource != "" {
+			resourceDependencies = append(resourceDependencies, models.JobSpecResourceDependency{
+				URN:          dep.Resource,
+				Optional:     dep.Optional,
+				Timeout:      timeout,
+				PokeInterval: pokeInterval,
+			})
+			continue
+		}
+
 		depType := models.JobSpecDependencyTypeIntra
 		switch dep.Type {
 		case string(models.JobSpecDependencyTypeIntra):
@@ -363,9 +609,16 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		case string(models.JobSpecDependencyTypeExtra):
 			depType = models.JobSpecDependencyTypeExtra
 		}
-		dependencies[dep.JobName] = models.JobSpecDependency{
-			Type: depType,
+		dependency := models.JobSpecDependency{
+			Type:         depType,
+			Optional:     dep.Optional,
+			Timeout:      timeout,
+			PokeInterval: pokeInterval,
+		}
+		if err := dependency.Validate(); err != nil {
+			return models.JobSpec{}, errors.Wrapf(err, "invalid dependency %s", dep.JobName)
 		}
+		dependencies[dep.JobName] = dependency
 	}
 
 	// prep hooks
@@ -378,13 +631,31 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		hooks = append(hooks, adaptHook)
 	}
 
+	taskResource, err := conf.Task.Resource.ToSpec()
+	if err != nil {
+		return models.JobSpec{}, errors.Wrap(err, "invalid task resource")
+	}
+
+	runtime := models.JobSpecRuntime{
+		Env:    conf.Runtime.Env,
+		Labels: conf.Runtime.Labels,
+	}
+	if err := runtime.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrap(err, "invalid runtime")
+	}
+
 	// prep window
 	window, err := conf.prepareWindow()
 	if err != nil {
 		return models.JobSpec{}, err
 	}
 
-	execUnit, err := adapt.pluginRepo.GetByName(conf.Task.Name)
+	var execUnit *models.Plugin
+	if conf.Task.Version != "" {
+		execUnit, err = adapt.pluginRepo.GetByNameAndVersion(conf.Task.Name, conf.Task.Version)
+	} else {
+		execUnit, err = adapt.pluginRepo.GetByName(conf.Task.Name)
+	}
 	if err != nil {
 		return models.JobSpec{}, errors.Wrapf(err, "spec reading error, failed to find exec unit %s", conf.Task.Name)
 	}
@@ -429,6 +700,8 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 			StartDate: startDate,
 			EndDate:   endDate,
 			Interval:  conf.Schedule.Interval,
+			Timezone:  conf.Schedule.Timezone,
+			Trigger:   trigger,
 		},
 		Behavior: models.JobSpecBehavior{
 			CatchUp:       conf.Behavior.Catchup,
@@ -441,13 +714,32 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 			Notify: jobNotifiers,
 		},
 		Task: models.JobSpecTask{
-			Unit:   execUnit,
-			Config: taskConf,
-			Window: window,
+			Unit:     execUnit,
+			Version:  conf.Task.Version,
+			Config:   taskConf,
+			Window:   window,
+			Resource: taskResource,
 		},
-		Assets:       models.JobAssets{}.FromMap(conf.Asset),
-		Dependencies: dependencies,
-		Hooks:        hooks,
+		Assets:               models.JobAssets{}.FromMap(conf.Asset),
+		Dependencies:         dependencies,
+		Hooks:                hooks,
+		Runtime:              runtime,
+		HTTPDependencies:     httpDependencies,
+		ExternalDependencies: externalDependencies,
+		ResourceDependencies: resourceDependencies,
+		Paused:               conf.Paused,
+	}
+	if err := job.ValidateLabels(); err != nil {
+		return models.JobSpec{}, err
+	}
+	if err := job.ValidateHTTPDependencies(); err != nil {
+		return models.JobSpec{}, err
+	}
+	if err := job.ValidateExternalDependencies(); err != nil {
+		return models.JobSpec{}, err
+	}
+	if err := job.ValidateResourceDependencies(); err != nil {
+		return models.JobSpec{}, err
 	}
 	return job, nil
 }
@@ -493,6 +785,7 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 		Schedule: JobSchedule{
 			Interval:  spec.Schedule.Interval,
 			StartDate: spec.Schedule.StartDate.Format(models.JobDatetimeLayout),
+			Timezone:  spec.Schedule.Timezone,
 		},
 		Behavior: JobBehavior{
 			DependsOnPast: spec.Behavior.DependsOnPast,
@@ -505,28 +798,84 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 			Notify: notifiers,
 		},
 		Task: JobTask{
-			Name:   spec.Task.Unit.Info().Name,
-			Config: taskConf,
+			Name:    spec.Task.Unit.Info().Name,
+			Version: spec.Task.Version,
+			Config:  taskConf,
 			Window: JobTaskWindow{
 				Size:       spec.Task.Window.SizeString(),
 				Offset:     spec.Task.Window.OffsetString(),
 				TruncateTo: spec.Task.Window.TruncateTo,
+				Modifier:   spec.Task.Window.Modifier,
 			},
+			Resource: JobResource{}.FromSpec(spec.Task.Resource),
 		},
 		Asset:        spec.Assets.ToMap(),
 		Dependencies: []JobDependency{},
 		Hooks:        []JobHook{},
+		Runtime: JobRuntime{
+			Env:    spec.Runtime.Env,
+			Labels: spec.Runtime.Labels,
+		},
+		Paused: spec.Paused,
 	}
 
 	if spec.Schedule.EndDate != nil {
 		parsed.Schedule.EndDate = spec.Schedule.EndDate.Format(models.JobDatetimeLayout)
 	}
+	if spec.Schedule.Trigger != nil {
+		parsed.Schedule.Trigger = &JobTrigger{
+			Type:   spec.Schedule.Trigger.Type.String(),
+			Config: spec.Schedule.Trigger.Config,
+		}
+	}
 	for name, dep := range spec.Dependencies {
+		dependency := JobDependency{
+			JobName:  name,
+			Type:     dep.Type.String(),
+			Optional: dep.Optional,
+		}
+		if dep.Timeout > 0 {
+			dependency.Timeout = dep.Timeout.String()
+		}
+		if dep.PokeInterval > 0 {
+			dependency.PokeInterval = dep.PokeInterval.String()
+		}
+		parsed.Dependencies = append(parsed.Dependencies, dependency)
+	}
+	for _, dep := range spec.HTTPDependencies {
 		parsed.Dependencies = append(parsed.Dependencies, JobDependency{
-			JobName: name,
-			Type:    dep.Type.String(),
+			HTTP: &JobHTTPDependency{
+				Name:           dep.Name,
+				URL:            dep.URL,
+				Headers:        dep.Headers,
+				ExpectedStatus: dep.ExpectedStatus,
+				JSONPath:       dep.JSONPath,
+				ExpectedValue:  dep.ExpectedValue,
+			},
 		})
 	}
+	for _, dep := range spec.ExternalDependencies {
+		parsed.Dependencies = append(parsed.Dependencies, JobDependency{
+			External: &JobExternalDependency{
+				Host:        dep.Host,
+				ProjectName: dep.ProjectName,
+				JobName:     dep.JobName,
+			},
+		})
+	}
+	for _, dep := range spec.ResourceDependencies {
+		dependency := JobDependency{
+			Resource: dep.URN,
+			Optional: dep.Optional,
+		}
+		if dep.Timeout > 0 {
+			dependency.Timeout = dep.Timeout.String()
+		}
+		if dep.PokeInterval > 0 {
+			dependency.PokeInterval = dep.PokeInterval.String()
+		}
+		parsed.Dependencies = append(parsed.Dependencies, dependency)
+	}
 
 	// prep hooks
 	for _, hook := range spec.Hooks {
@@ -1,8 +1,10 @@
 package local_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/odpf/optimus/models"
 
@@ -22,6 +24,7 @@ owner: test@example.com
 schedule:
   start_date: "2021-02-03"
   interval: 0 2 * * *
+  timezone: Asia/Kolkata
 behavior:
   depends_on_past: true
   catch_up: false
@@ -45,6 +48,7 @@ labels:
   orchestrator: optimus
 dependencies: []
 hooks: []
+paused: true
 `
 		var localJobParsed local.Job
 		err := yaml.Unmarshal([]byte(yamlSpec), &localJobParsed)
@@ -63,12 +67,203 @@ hooks: []
 
 		modelJob, err := adapter.ToSpec(localJobParsed)
 		assert.Nil(t, err)
+		assert.True(t, modelJob.Paused)
 
 		localJobBack, err := adapter.FromSpec(modelJob)
 		assert.Nil(t, err)
 
 		assert.Equal(t, localJobParsed, localJobBack)
 	})
+	t.Run("should convert wait settings of dependencies from yaml", func(t *testing.T) {
+		yamlSpec := `
+version: 1
+name: test_job
+owner: test@example.com
+schedule:
+  start_date: "2021-02-03"
+  interval: 0 2 * * *
+task:
+  name: bq2bq
+dependencies:
+  - job: upstream_job
+    optional: true
+    timeout: 2h
+    poke_interval: 5m
+`
+		var localJobParsed local.Job
+		err := yaml.Unmarshal([]byte(yamlSpec), &localJobParsed)
+		assert.Nil(t, err)
+
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "bq2bq").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := local.NewJobSpecAdapter(pluginRepo)
+
+		modelJob, err := adapter.ToSpec(localJobParsed)
+		assert.Nil(t, err)
+		assert.Equal(t, models.JobSpecDependency{
+			Type:         models.JobSpecDependencyTypeIntra,
+			Optional:     true,
+			Timeout:      2 * time.Hour,
+			PokeInterval: 5 * time.Minute,
+		}, modelJob.Dependencies["upstream_job"])
+	})
+	t.Run("should convert http and external dependencies from yaml", func(t *testing.T) {
+		yamlSpec := `
+version: 1
+name: test_job
+owner: test@example.com
+schedule:
+  start_date: "2021-02-03"
+  interval: 0 2 * * *
+task:
+  name: bq2bq
+dependencies:
+  - job: upstream_job
+  - http:
+      name: billing-export
+      url: https://billing.example.io/exports
+      headers:
+        Authorization: Bearer token
+      expected_status: 200
+      json_path: $.status
+      expected_value: done
+  - external:
+      host: optimus.partner.example.io
+      project: partner_project
+      job: billing_export
+`
+		var localJobParsed local.Job
+		err := yaml.Unmarshal([]byte(yamlSpec), &localJobParsed)
+		assert.Nil(t, err)
+
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "bq2bq").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := local.NewJobSpecAdapter(pluginRepo)
+
+		modelJob, err := adapter.ToSpec(localJobParsed)
+		assert.Nil(t, err)
+		assert.Len(t, modelJob.Dependencies, 1)
+		assert.Equal(t, []models.JobSpecHTTPDependency{
+			{
+				Name:           "billing-export",
+				URL:            "https://billing.example.io/exports",
+				Headers:        map[string]string{"Authorization": "Bearer token"},
+				ExpectedStatus: 200,
+				JSONPath:       "$.status",
+				ExpectedValue:  "done",
+			},
+		}, modelJob.HTTPDependencies)
+		assert.Equal(t, []models.JobSpecExternalDependency{
+			{Host: "optimus.partner.example.io", ProjectName: "partner_project", JobName: "billing_export"},
+		}, modelJob.ExternalDependencies)
+	})
+	t.Run("should convert resource dependencies from yaml", func(t *testing.T) {
+		yamlSpec := `
+version: 1
+name: test_job
+owner: test@example.com
+schedule:
+  start_date: "2021-02-03"
+  interval: 0 2 * * *
+task:
+  name: bq2bq
+dependencies:
+  - resource: bigquery://project:sales.orders
+    optional: true
+    timeout: 2h
+  - resource: orders
+`
+		var localJobParsed local.Job
+		err := yaml.Unmarshal([]byte(yamlSpec), &localJobParsed)
+		assert.Nil(t, err)
+
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "bq2bq").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := local.NewJobSpecAdapter(pluginRepo)
+
+		_, err = adapter.ToSpec(localJobParsed)
+		assert.NotNil(t, err)
+
+		localJobParsed.Dependencies = localJobParsed.Dependencies[:1]
+		modelJob, err := adapter.ToSpec(localJobParsed)
+		assert.Nil(t, err)
+		assert.Len(t, modelJob.Dependencies, 0)
+		assert.Equal(t, []models.JobSpecResourceDependency{
+			{URN: "bigquery://project:sales.orders", Optional: true, Timeout: 2 * time.Hour},
+		}, modelJob.ResourceDependencies)
+	})
+	t.Run("should convert window preset from yaml", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "bq2bq").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := local.NewJobSpecAdapter(pluginRepo)
+
+		modelJob, err := adapter.ToSpec(local.Job{
+			Version:  1,
+			Name:     "test_job",
+			Schedule: local.JobSchedule{StartDate: "2021-02-03", Interval: "0 2 * * *"},
+			Task: local.JobTask{
+				Name:   "bq2bq",
+				Window: local.JobTaskWindow{Preset: "last_quarter", Offset: "0"},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, models.JobSpecTaskWindow{
+			Size:       3 * models.HoursInMonth,
+			Offset:     0,
+			TruncateTo: "Q",
+		}, modelJob.Task.Window)
+	})
+	t.Run("should fail to convert unknown window preset and invalid window", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "bq2bq").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := local.NewJobSpecAdapter(pluginRepo)
+
+		_, err := adapter.ToSpec(local.Job{
+			Version:  1,
+			Name:     "test_job",
+			Schedule: local.JobSchedule{StartDate: "2021-02-03", Interval: "0 2 * * *"},
+			Task:     local.JobTask{Name: "bq2bq", Window: local.JobTaskWindow{Preset: "last_decade"}},
+		})
+		assert.True(t, errors.Is(err, models.ErrUnknownWindowPreset))
+
+		_, err = adapter.ToSpec(local.Job{
+			Version:  1,
+			Name:     "test_job",
+			Schedule: local.JobSchedule{StartDate: "2021-02-03", Interval: "0 2 * * *"},
+			Task:     local.JobTask{Name: "bq2bq", Window: local.JobTaskWindow{Size: "-24h"}},
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "invalid task window test_job")
+	})
+	t.Run("should fail to convert dependency with poke interval exceeding timeout", func(t *testing.T) {
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "bq2bq").Return(&models.Plugin{
+			Base: new(mock.BasePlugin),
+		}, nil)
+		adapter := local.NewJobSpecAdapter(pluginRepo)
+
+		_, err := adapter.ToSpec(local.Job{
+			Version:  1,
+			Name:     "test_job",
+			Schedule: local.JobSchedule{StartDate: "2021-02-03", Interval: "0 2 * * *"},
+			Task:     local.JobTask{Name: "bq2bq"},
+			Dependencies: []local.JobDependency{
+				{JobName: "upstream_job", Timeout: "1m", PokeInterval: "1h"},
+			},
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "invalid dependency upstream_job")
+	})
 }
 
 func TestJob_MergeFrom(t *testing.T) {
@@ -123,6 +318,7 @@ func TestJob_MergeFrom(t *testing.T) {
 						StartDate: "2020",
 						EndDate:   "2021",
 						Interval:  "@daily",
+						Timezone:  "Asia/Kolkata",
 					},
 				},
 			},
@@ -146,9 +342,110 @@ func TestJob_MergeFrom(t *testing.T) {
 						StartDate: "2020",
 						EndDate:   "2021",
 						Interval:  "@daily",
+						Timezone:  "Asia/Kolkata",
+					},
+				},
+			},
+		},
+		{
+			name: "should merge runtime env and labels of parent",
+			fields: fields{
+				child: local.Job{
+					Runtime: local.JobRuntime{
+						Env: map[string]string{"LOG_LEVEL": "debug"},
+					},
+				},
+				expected: local.Job{
+					Runtime: local.JobRuntime{
+						Env:    map[string]string{"LOG_LEVEL": "debug", "REGION": "asia"},
+						Labels: map[string]string{"pool": "bigquery"},
+					},
+				},
+			},
+			args: args{
+				parent: local.Job{
+					Runtime: local.JobRuntime{
+						Env:    map[string]string{"LOG_LEVEL": "info", "REGION": "asia"},
+						Labels: map[string]string{"pool": "bigquery"},
+					},
+				},
+			},
+		},
+		{
+			name: "should inherit resources of task and hooks not set on child",
+			fields: fields{
+				child: local.Job{
+					Task: local.JobTask{
+						Resource: local.JobResource{
+							Limit: local.JobResourceConfig{Memory: "8Gi"},
+						},
+					},
+					Hooks: []local.JobHook{
+						{
+							Name: "transporter",
+						},
+					},
+				},
+				expected: local.Job{
+					Task: local.JobTask{
+						Resource: local.JobResource{
+							Request: local.JobResourceConfig{CPU: "500m", Memory: "2Gi"},
+							Limit:   local.JobResourceConfig{Memory: "8Gi"},
+						},
+					},
+					Hooks: []local.JobHook{
+						{
+							Name: "transporter",
+							Resource: local.JobResource{
+								Limit: local.JobResourceConfig{Memory: "1Gi"},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				parent: local.Job{
+					Task: local.JobTask{
+						Resource: local.JobResource{
+							Request: local.JobResourceConfig{CPU: "500m", Memory: "2Gi"},
+							Limit:   local.JobResourceConfig{Memory: "4Gi"},
+						},
+					},
+					Hooks: []local.JobHook{
+						{
+							Name: "transporter",
+							Resource: local.JobResource{
+								Limit: local.JobResourceConfig{Memory: "1Gi"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "should default interval of child waiting on a trigger",
+			fields: fields{
+				child: local.Job{
+					Schedule: local.JobSchedule{
+						Trigger: &local.JobTrigger{
+							Type:   "job",
+							Config: map[string]string{"job": "upstream"},
+						},
+					},
+				},
+				expected: local.Job{
+					Schedule: local.JobSchedule{
+						Interval: models.JobTriggerInterval,
+						Trigger: &local.JobTrigger{
+							Type:   "job",
+							Config: map[string]string{"job": "upstream"},
+						},
 					},
 				},
 			},
+			args: args{
+				parent: local.Job{},
+			},
 		},
 		{
 			name: "should not merge if child already contains non zero values",
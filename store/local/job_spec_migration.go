@@ -0,0 +1,144 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// jobMigrations upgrade a raw job specification of a version to the next
+// version, each migration should be safe to apply on a partial spec like
+// this.yaml as fields missing there are inherited from parents
+var jobMigrations = map[int]func(spec yaml.MapSlice) (yaml.MapSlice, error){
+	1: migrateJobV1,
+}
+
+// decodeJob reads a job specification upgrading it to JobConfigVersion,
+// specs without a version are migrated from the first version as they may
+// inherit it from parent directories
+func decodeJob(data []byte) (Job, error) {
+	var raw yaml.MapSlice
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return Job{}, err
+	}
+
+	version := 1
+	value, hasVersion := mapSliceGet(raw, "version")
+	if hasVersion {
+		v, ok := value.(int)
+		if !ok {
+			return Job{}, fmt.Errorf("invalid version %v, should be a number", value)
+		}
+		version = v
+	}
+	if version > JobConfigVersion {
+		return Job{}, fmt.Errorf("%w %d, latest supported version is %d",
+			models.ErrUnsupportedJobSpecVersion, version, JobConfigVersion)
+	}
+	for ; version < JobConfigVersion; version++ {
+		migrate, ok := jobMigrations[version]
+		if !ok {
+			return Job{}, fmt.Errorf("%w %d, no migration to version %d",
+				models.ErrUnsupportedJobSpecVersion, version, version+1)
+		}
+		var err error
+		if raw, err = migrate(raw); err != nil {
+			return Job{}, errors.Wrapf(err, "failed to migrate spec from version %d", version)
+		}
+	}
+	if hasVersion {
+		raw = mapSliceSet(raw, "version", JobConfigVersion)
+	}
+
+	migrated, err := yaml.Marshal(raw)
+	if err != nil {
+		return Job{}, err
+	}
+	var job Job
+	if err := yaml.Unmarshal(migrated, &job); err != nil {
+		return Job{}, err
+	}
+	return job, nil
+}
+
+// migrateJobV1 converts labels and runtime labels written as a list of
+// name and value items to a map and renames month truncation m to M
+func migrateJobV1(spec yaml.MapSlice) (yaml.MapSlice, error) {
+	var err error
+	if spec, err = labelListToMap(spec); err != nil {
+		return nil, err
+	}
+	if runtime, ok := mapSliceGetMap(spec, "runtime"); ok {
+		if runtime, err = labelListToMap(runtime); err != nil {
+			return nil, errors.Wrap(err, "runtime")
+		}
+		spec = mapSliceSet(spec, "runtime", runtime)
+	}
+	if task, ok := mapSliceGetMap(spec, "task"); ok {
+		if window, ok := mapSliceGetMap(task, "window"); ok {
+			if truncateTo, _ := mapSliceGet(window, "truncate_to"); truncateTo == "m" {
+				window = mapSliceSet(window, "truncate_to", "M")
+				spec = mapSliceSet(spec, "task", mapSliceSet(task, "window", window))
+			}
+		}
+	}
+	return spec, nil
+}
+
+func labelListToMap(spec yaml.MapSlice) (yaml.MapSlice, error) {
+	value, ok := mapSliceGet(spec, "labels")
+	if !ok {
+		return spec, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return spec, nil
+	}
+	labels := yaml.MapSlice{}
+	for _, item := range items {
+		label, ok := item.(yaml.MapSlice)
+		if !ok {
+			return nil, fmt.Errorf("invalid label %v, should have a name and a value", item)
+		}
+		name, _ := mapSliceGet(label, "name")
+		if name == nil || name == "" {
+			return nil, fmt.Errorf("invalid label %v, name cannot be empty", item)
+		}
+		if _, ok := mapSliceGet(labels, name); ok {
+			return nil, fmt.Errorf("duplicate label %v", name)
+		}
+		labelValue, _ := mapSliceGet(label, "value")
+		labels = append(labels, yaml.MapItem{Key: name, Value: labelValue})
+	}
+	return mapSliceSet(spec, "labels", labels), nil
+}
+
+func mapSliceGet(m yaml.MapSlice, key interface{}) (interface{}, bool) {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+func mapSliceGetMap(m yaml.MapSlice, key interface{}) (yaml.MapSlice, bool) {
+	value, ok := mapSliceGet(m, key)
+	if !ok {
+		return nil, false
+	}
+	nested, ok := value.(yaml.MapSlice)
+	return nested, ok
+}
+
+func mapSliceSet(m yaml.MapSlice, key, value interface{}) yaml.MapSlice {
+	for i, item := range m {
+		if item.Key == key {
+			m[i].Value = value
+			return m
+		}
+	}
+	return append(m, yaml.MapItem{Key: key, Value: value})
+}
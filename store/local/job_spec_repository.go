@@ -181,9 +181,12 @@ func (repo *jobRepository) findInDir(dirName string, inheritedSpec Job) (models.
 	}
 	defer fd.Close()
 
-	dec := yaml.NewDecoder(fd)
-	var inputs Job
-	if err = dec.Decode(&inputs); err != nil {
+	raw, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return jobSpec, err
+	}
+	inputs, err := decodeJob(raw)
+	if err != nil {
 		return jobSpec, errors.Wrapf(err, "error parsing job spec in %s", dirName)
 	}
 	inputs.MergeFrom(inheritedSpec)
@@ -197,34 +200,9 @@ func (repo *jobRepository) findInDir(dirName string, inheritedSpec Job) (models.
 		return jobSpec, errors.Wrapf(err, "failed to read spec in: %s", dirName)
 	}
 
-	assets := map[string]string{}
-	assetFolderFd, err := repo.fs.Open(repo.assetFolderPath(dirName))
-	if err == nil {
-		fileNames, err := assetFolderFd.Readdirnames(-1)
-		if err != nil {
-			return jobSpec, err
-		}
-		for _, fileName := range fileNames {
-			// skip directories in assets folder
-			if isDir, err := afero.IsDir(repo.fs, repo.assetFilePath(dirName, fileName)); err == nil && isDir {
-				continue
-			} else if err != nil {
-				return jobSpec, err
-			}
-
-			assetFd, err := repo.fs.Open(repo.assetFilePath(dirName, fileName))
-			if err != nil {
-				return jobSpec, err
-			}
-
-			raw, err := ioutil.ReadAll(assetFd)
-			if err != nil {
-				return jobSpec, err
-			}
-			assets[fileName] = string(raw)
-			assetFd.Close()
-		}
-		defer assetFolderFd.Close()
+	assets, err := repo.readAssets(dirName)
+	if err != nil {
+		return jobSpec, err
 	}
 	jobSpec.Assets = models.JobAssets{}.FromMap(assets)
 
@@ -238,6 +216,43 @@ func (repo *jobRepository) findInDir(dirName string, inheritedSpec Job) (models.
 	return jobSpec, nil
 }
 
+// readAssets reads files in assets folder of a job, directories in it are
+// skipped
+func (repo *jobRepository) readAssets(dirName string) (map[string]string, error) {
+	assets := map[string]string{}
+	assetFolderFd, err := repo.fs.Open(repo.assetFolderPath(dirName))
+	if err != nil {
+		return assets, nil
+	}
+	defer assetFolderFd.Close()
+
+	fileNames, err := assetFolderFd.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileName := range fileNames {
+		// skip directories in assets folder
+		if isDir, err := afero.IsDir(repo.fs, repo.assetFilePath(dirName, fileName)); err == nil && isDir {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		assetFd, err := repo.fs.Open(repo.assetFilePath(dirName, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := ioutil.ReadAll(assetFd)
+		assetFd.Close()
+		if err != nil {
+			return nil, err
+		}
+		assets[fileName] = string(raw)
+	}
+	return assets, nil
+}
+
 func (repo *jobRepository) scanDirs(path string, inheritedSpec Job) ([]models.JobSpec, error) {
 	specs := []models.JobSpec{}
 
@@ -285,9 +300,12 @@ func (repo *jobRepository) getThisSpec(dirName string) (Job, error) {
 	defer fd.Close()
 
 	// prepare a clone
-	var inputs Job
-	dec := yaml.NewDecoder(fd)
-	if err = dec.Decode(&inputs); err != nil {
+	raw, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return Job{}, err
+	}
+	inputs, err := decodeJob(raw)
+	if err != nil {
 		return Job{}, errors.Wrapf(err, "error parsing job spec in %s", dirName)
 	}
 	return inputs, nil
@@ -1,6 +1,7 @@
 package local_test
 
 import (
+	"errors"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -120,8 +121,9 @@ func TestJobSpecRepository(t *testing.T) {
 		}),
 	}
 
+	// specs are upgraded to the latest version when read
 	spec2 := models.JobSpec{
-		Version: 1,
+		Version: models.JobSpecVersion,
 		Name:    "test",
 		Owner:   "optimus",
 		Schedule: models.JobSpecSchedule{
@@ -358,6 +360,55 @@ task:
 			})
 			assert.Equal(t, expectedSpec, returnedSpec)
 		})
+		t.Run("should upgrade specs of version 1 with labels as a list and month truncation as m", func(t *testing.T) {
+			oldJobContents := `version: 1
+name: test
+owner: optimus
+schedule:
+  start_date: "2020-12-02"
+  interval: '@daily'
+behavior:
+  depends_on_past: false
+  catch_up: true
+task:
+  name: foo
+  config:
+    table: tab1
+  window:
+    size: 720h
+    offset: "0"
+    truncate_to: m
+labels:
+- name: team
+  value: data
+- name: tier
+  value: 1
+dependencies:
+- job: bar
+hooks: []
+`
+			appFS := afero.NewMemMapFs()
+			appFS.MkdirAll(spec.Name, 0755)
+			afero.WriteFile(appFS, filepath.Join(spec.Name, local.JobSpecFileName), []byte(oldJobContents), 0644)
+
+			repo := local.NewJobSpecRepository(appFS, adapter)
+			returnedSpec, err := repo.GetByName(spec.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, models.JobSpecVersion, returnedSpec.Version)
+			assert.Equal(t, map[string]string{"team": "data", "tier": "1"}, returnedSpec.Labels)
+			assert.Equal(t, "M", returnedSpec.Task.Window.TruncateTo)
+			assert.Equal(t, spec2.Task.Config, returnedSpec.Task.Config)
+		})
+		t.Run("should fail for specs of unsupported versions", func(t *testing.T) {
+			appFS := afero.NewMemMapFs()
+			appFS.MkdirAll(spec.Name, 0755)
+			afero.WriteFile(appFS, filepath.Join(spec.Name, local.JobSpecFileName),
+				[]byte(strings.Replace(testJobContents, "version: 1", "version: 99", 1)), 0644)
+
+			repo := local.NewJobSpecRepository(appFS, adapter)
+			_, err := repo.GetByName(spec.Name)
+			assert.True(t, errors.Is(err, models.ErrUnsupportedJobSpecVersion))
+		})
 		t.Run("should use cache if file is requested more than once", func(t *testing.T) {
 			// create test files and directories
 			appFS := afero.NewMemMapFs()
@@ -447,7 +498,7 @@ hooks: []`,
 		}
 		jobspecs := []models.JobSpec{
 			{
-				Version: 1,
+				Version: models.JobSpecVersion,
 				Name:    "test",
 				Owner:   "optimus",
 				Schedule: models.JobSpecSchedule{
@@ -472,7 +523,7 @@ hooks: []`,
 				Labels:       map[string]string{},
 			},
 			{
-				Version: 1,
+				Version: models.JobSpecVersion,
 				Name:    "fooo",
 				Owner:   "meee",
 				Schedule: models.JobSpecSchedule{
@@ -553,4 +604,99 @@ hooks: []`,
 			assert.Equal(t, len(result), len(resultAgain))
 		})
 	})
+	t.Run("Validate", func(t *testing.T) {
+		invalidJobContents := `version: 1
+name: invalid-job
+owner: optimus
+schedule:
+  start_date: "2020/12/02"
+  interval: '@daily'
+task:
+  name: foo
+  window:
+    size: 24h
+    offset: "0"
+    truncate_to: d
+`
+		brokenJobContents := `name: broken-job
+owner: optimus
+schedule:
+  interval: "@daily
+`
+		newFS := func() afero.Fs {
+			appFS := afero.NewMemMapFs()
+			for dir, contents := range map[string]string{
+				spec.Name:     testJobContents,
+				"invalid-job": invalidJobContents,
+				"broken-job":  brokenJobContents,
+			} {
+				appFS.MkdirAll(dir, 0755)
+				afero.WriteFile(appFS, filepath.Join(dir, local.JobSpecFileName), []byte(contents), 0644)
+			}
+			return appFS
+		}
+
+		t.Run("should report issues of every spec with the file and line they are at", func(t *testing.T) {
+			repo := local.NewJobSpecRepository(newFS(), adapter)
+			validated, err := repo.Validate()
+			assert.Nil(t, err)
+			assert.Equal(t, 3, len(validated))
+
+			// directories are read in order
+			broken := validated[0]
+			assert.Equal(t, filepath.Join("broken-job", local.JobSpecFileName), broken.File)
+			assert.Equal(t, "", broken.Name)
+			assert.Equal(t, 1, len(broken.Issues))
+			assert.True(t, broken.Issues[0].Line > 0)
+
+			invalid := validated[1]
+			assert.Equal(t, "invalid-job", invalid.Name)
+			assert.Equal(t, models.JobSpec{}, invalid.Spec)
+			assert.Equal(t, 1, len(invalid.Issues))
+			assert.Equal(t, filepath.Join("invalid-job", local.JobSpecFileName), invalid.Issues[0].File)
+			assert.Equal(t, 5, invalid.Issues[0].Line)
+			assert.Equal(t, "schedule.start_date", invalid.Issues[0].Field)
+
+			valid := validated[2]
+			assert.Equal(t, spec.Name, valid.Name)
+			assert.Equal(t, 0, len(valid.Issues))
+			assert.Equal(t, spec.Name, valid.Spec.Name)
+		})
+		t.Run("should report field inherited from parent directory at its line in this.yaml", func(t *testing.T) {
+			appFS := afero.NewMemMapFs()
+			appFS.MkdirAll(filepath.Join("team", spec.Name), 0755)
+			afero.WriteFile(appFS, filepath.Join("team", local.JobSpecParentName), []byte(`version: 1
+owner: optimus
+schedule:
+  interval: 'every day'
+`), 0644)
+			afero.WriteFile(appFS, filepath.Join("team", spec.Name, local.JobSpecFileName), []byte(`name: test
+schedule:
+  start_date: "2020-12-02"
+task:
+  name: foo
+  window:
+    size: 24h
+    offset: "0"
+    truncate_to: d
+`), 0644)
+
+			repo := local.NewJobSpecRepository(appFS, adapter)
+			validated, err := repo.Validate()
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(validated))
+			assert.Equal(t, 1, len(validated[0].Issues))
+			assert.Equal(t, local.JobSpecIssue{
+				File:    filepath.Join("team", local.JobSpecParentName),
+				Line:    4,
+				Field:   "schedule.interval",
+				Message: validated[0].Issues[0].Message,
+			}, validated[0].Issues[0])
+		})
+		t.Run("should return ErrNoSpecsFound if the root directory has no specs", func(t *testing.T) {
+			repo := local.NewJobSpecRepository(afero.NewMemMapFs(), adapter)
+			_, err := repo.Validate()
+			assert.Equal(t, models.ErrNoDAGSpecs, err)
+		})
+	})
 }
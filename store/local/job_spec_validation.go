@@ -0,0 +1,317 @@
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"gopkg.in/validator.v2"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+)
+
+// JobSpecIssue is a problem found in a job spec file, line is 0 if it is not
+// known and field is empty if the issue is not of a single field
+type JobSpecIssue struct {
+	File    string
+	Line    int
+	Field   string
+	Message string
+}
+
+func (issue JobSpecIssue) String() string {
+	location := issue.File
+	if issue.Line > 0 {
+		location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+	}
+	if issue.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", location, issue.Field, issue.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, issue.Message)
+}
+
+// ValidatedJobSpec is a job spec file along with issues found checking it,
+// spec is only read if there are none. Name is the one declared in the
+// file, it is empty if the file couldn't be parsed
+type ValidatedJobSpec struct {
+	File   string
+	Name   string
+	Spec   models.JobSpec
+	Issues []JobSpecIssue
+}
+
+// specSource is a spec file a job spec is read from, its own or one of
+// this.yaml it inherits
+type specSource struct {
+	file string
+	raw  []byte
+}
+
+type jobSpecValidation struct {
+	// file of every job name, names must be unique across directories
+	files     map[string]string
+	validated []ValidatedJobSpec
+}
+
+// Validate checks every job spec against the schema of specs. Unlike GetAll
+// it doesn't stop at the first invalid spec, issues of each spec are
+// reported along with the file and line they are found at
+func (repo *jobRepository) Validate() ([]ValidatedJobSpec, error) {
+	validation := &jobSpecValidation{
+		files: map[string]string{},
+	}
+	if err := repo.validateDir(".", Job{}, nil, validation); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(validation.validated) < 1 {
+		return nil, models.ErrNoDAGSpecs
+	}
+	return validation.validated, nil
+}
+
+func (repo *jobRepository) validateDir(dirName string, inheritedSpec Job, parents []specSource,
+	validation *jobSpecValidation) error {
+	thisFile := repo.thisFilePath(dirName)
+	if raw, err := repo.readSpecFile(thisFile); err == nil {
+		thisSpec, err := decodeJob(raw)
+		if err != nil {
+			// children are checked with what they inherit from above
+			validation.validated = append(validation.validated, ValidatedJobSpec{
+				File:   thisFile,
+				Issues: []JobSpecIssue{parseIssue(thisFile, err)},
+			})
+		} else {
+			thisSpec.MergeFrom(inheritedSpec)
+			inheritedSpec = thisSpec
+			parents = append([]specSource{{file: thisFile, raw: raw}}, parents...)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	folders, err := repo.getDirs(dirName)
+	if err != nil {
+		return err
+	}
+	sort.Strings(folders)
+	for _, folder := range folders {
+		if err := repo.validateDir(filepath.Join(dirName, folder), inheritedSpec, parents, validation); err != nil &&
+			!os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	jobFile := repo.jobFilePath(dirName)
+	raw, err := repo.readSpecFile(jobFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	validated := repo.validateJob(dirName, specSource{file: jobFile, raw: raw}, inheritedSpec, parents)
+	if validated.Name != "" {
+		if otherFile, ok := validation.files[validated.Name]; ok {
+			validated.Spec = models.JobSpec{}
+			validated.Issues = append(validated.Issues, fieldIssue(append([]specSource{{file: jobFile, raw: raw}}, parents...),
+				"Name", fmt.Sprintf("job name should be unique across directories, %s is also used by %s",
+					validated.Name, otherFile)))
+		} else {
+			validation.files[validated.Name] = jobFile
+		}
+	}
+	validation.validated = append(validation.validated, validated)
+	return nil
+}
+
+func (repo *jobRepository) validateJob(dirName string, source specSource, inheritedSpec Job,
+	parents []specSource) ValidatedJobSpec {
+	validated := ValidatedJobSpec{
+		File: source.file,
+	}
+	inputs, err := decodeJob(source.raw)
+	if err != nil {
+		validated.Issues = append(validated.Issues, parseIssue(source.file, err))
+		return validated
+	}
+	inputs.MergeFrom(inheritedSpec)
+	validated.Name = inputs.Name
+
+	sources := append([]specSource{source}, parents...)
+	if err := validator.Validate(inputs); err != nil {
+		errs, ok := err.(validator.ErrorMap)
+		if !ok {
+			validated.Issues = append(validated.Issues, JobSpecIssue{File: source.file, Message: err.Error()})
+			return validated
+		}
+		var fieldPaths []string
+		for fieldPath := range errs {
+			fieldPaths = append(fieldPaths, fieldPath)
+		}
+		sort.Strings(fieldPaths)
+		for _, fieldPath := range fieldPaths {
+			validated.Issues = append(validated.Issues, fieldIssue(sources, fieldPath, errs[fieldPath].Error()))
+		}
+		return validated
+	}
+
+	jobSpec, err := repo.adapter.ToSpec(inputs)
+	if err != nil {
+		validated.Issues = append(validated.Issues, JobSpecIssue{File: source.file, Message: err.Error()})
+		return validated
+	}
+	assets, err := repo.readAssets(dirName)
+	if err != nil {
+		validated.Issues = append(validated.Issues, JobSpecIssue{
+			File:    repo.assetFolderPath(dirName),
+			Message: err.Error(),
+		})
+		return validated
+	}
+	jobSpec.Assets = models.JobAssets{}.FromMap(assets)
+	validated.Spec = jobSpec
+	return validated
+}
+
+func (repo *jobRepository) readSpecFile(path string) ([]byte, error) {
+	fd, err := repo.fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	return ioutil.ReadAll(fd)
+}
+
+// parseIssue reports a spec file which couldn't be parsed, at the line yaml
+// parser complained about
+func parseIssue(file string, err error) JobSpecIssue {
+	issue := JobSpecIssue{
+		File:    file,
+		Message: err.Error(),
+	}
+	if match := yamlErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+		issue.Line, _ = strconv.Atoi(match[1])
+	}
+	return issue
+}
+
+// fieldIssue reports an invalid field at the line of the first source
+// declaring it, job's own file is looked at before the ones it inherits
+func fieldIssue(sources []specSource, fieldPath, message string) JobSpecIssue {
+	keys, field := specKeys(fieldPath)
+	issue := JobSpecIssue{
+		File:    sources[0].file,
+		Field:   field,
+		Message: message,
+	}
+	for i, source := range sources {
+		line, declared := specLine(source.raw, keys)
+		if declared {
+			issue.File, issue.Line = source.file, line
+			break
+		}
+		// closest parent of the field in job's own file, if nothing
+		// declares it
+		if i == 0 {
+			issue.Line = line
+		}
+	}
+	return issue
+}
+
+// specKeys maps a field path of validation errors, e.g.
+// Behavior.Notify[0].On, to keys of spec files and the field they are
+// shown as, e.g. behavior.notify[0].on
+func specKeys(fieldPath string) ([]string, string) {
+	var keys []string
+	var field strings.Builder
+	fieldType := reflect.TypeOf(Job{})
+	for i, segment := range strings.Split(fieldPath, ".") {
+		name, index := segment, ""
+		if start := strings.Index(segment, "["); start >= 0 && strings.HasSuffix(segment, "]") {
+			name, index = segment[:start], segment[start+1:len(segment)-1]
+		}
+
+		key := strings.ToLower(name)
+		if fieldType != nil {
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			structField, ok := reflect.StructField{}, false
+			if fieldType.Kind() == reflect.Struct {
+				structField, ok = fieldType.FieldByName(name)
+			}
+			fieldType = nil
+			if ok {
+				if tag := strings.Split(structField.Tag.Get("yaml"), ",")[0]; tag != "" && tag != "-" {
+					key = tag
+				}
+				fieldType = structField.Type
+			}
+		}
+		keys = append(keys, key)
+		if i > 0 {
+			field.WriteString(".")
+		}
+		field.WriteString(key)
+
+		if index != "" {
+			keys = append(keys, index)
+			fmt.Fprintf(&field, "[%s]", index)
+			if fieldType != nil {
+				for fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				switch fieldType.Kind() {
+				case reflect.Slice, reflect.Array, reflect.Map:
+					fieldType = fieldType.Elem()
+				default:
+					fieldType = nil
+				}
+			}
+		}
+	}
+	return keys, field.String()
+}
+
+// specLine returns the line keys are declared at in a spec file, if they
+// aren't the line of the closest parent declared is returned
+func specLine(raw []byte, keys []string) (int, bool) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil || len(doc.Content) == 0 {
+		return 0, false
+	}
+	node := doc.Content[0]
+	line := 0
+	for _, key := range keys {
+		found := false
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					line, node, found = node.Content[i].Line, node.Content[i+1], true
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(node.Content) {
+				node, found = node.Content[i], true
+				line = node.Line
+			}
+		}
+		if !found {
+			return line, false
+		}
+	}
+	return line, true
+}
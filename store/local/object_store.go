@@ -0,0 +1,34 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// ObjectStore reads and writes objects as files of a filesystem, buckets
+// are directories from root of the filesystem
+type ObjectStore struct {
+	Fs afero.Fs
+}
+
+func (s *ObjectStore) NewWriter(ctx context.Context, bucket, path string) (io.WriteCloser, error) {
+	filePath := filepath.Join(string(filepath.Separator), bucket, path)
+	if err := s.Fs.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return nil, err
+	}
+	return s.Fs.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+}
+
+func (s *ObjectStore) NewReader(bucket, path string) (io.ReadCloser, error) {
+	return s.Fs.Open(filepath.Join(string(filepath.Separator), bucket, path))
+}
+
+func NewObjectStore(fs afero.Fs) *ObjectStore {
+	return &ObjectStore{
+		Fs: fs,
+	}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+)
+
+type auditEventRepository struct {
+	db *DB
+}
+
+func (repo *auditEventRepository) Save(spec models.AuditEvent) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	if spec.CreatedAt.IsZero() {
+		spec.CreatedAt = repo.db.Now().UTC()
+	}
+	repo.db.auditEvents = append(repo.db.auditEvents, spec)
+	return nil
+}
+
+func (repo *auditEventRepository) GetAll(filter models.AuditEventFilter) ([]models.AuditEvent, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	var specs []models.AuditEvent
+	for _, event := range repo.db.auditEvents {
+		if event.ProjectName != filter.ProjectName {
+			continue
+		}
+		if filter.Namespace != "" && event.Namespace != filter.Namespace {
+			continue
+		}
+		if filter.Actor != "" && event.Actor != filter.Actor {
+			continue
+		}
+		if filter.Method != "" && event.Method != filter.Method {
+			continue
+		}
+		if !filter.Since.IsZero() && event.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && !event.CreatedAt.Before(filter.Until) {
+			continue
+		}
+		specs = append(specs, event)
+	}
+	sort.SliceStable(specs, func(i, j int) bool {
+		return specs[i].CreatedAt.After(specs[j].CreatedAt)
+	})
+	if filter.Limit > 0 && len(specs) > filter.Limit {
+		specs = specs[:filter.Limit]
+	}
+	return specs, nil
+}
+
+func NewAuditEventRepository(db *DB) *auditEventRepository {
+	return &auditEventRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,43 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEventRepository(t *testing.T) {
+	t.Run("should read matching events most recent first", func(t *testing.T) {
+		now := time.Date(2021, 10, 1, 10, 0, 0, 0, time.UTC)
+		repo := memory.NewAuditEventRepository(memory.NewDB())
+		for idx, event := range []models.AuditEvent{
+			{ProjectName: "t-optimus", Method: "DeployJobSpecification", Actor: "ci"},
+			{ProjectName: "t-optimus", Method: "RegisterSecret", Actor: "ci"},
+			{ProjectName: "t-other", Method: "DeployJobSpecification", Actor: "ci"},
+			{ProjectName: "t-optimus", Method: "DeployJobSpecification", Actor: "ci"},
+		} {
+			event.CreatedAt = now.Add(time.Duration(idx) * time.Minute)
+			assert.Nil(t, repo.Save(event))
+		}
+
+		events, err := repo.GetAll(models.AuditEventFilter{
+			ProjectName: "t-optimus",
+			Method:      "DeployJobSpecification",
+		})
+		assert.Nil(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, now.Add(3*time.Minute), events[0].CreatedAt)
+
+		events, err = repo.GetAll(models.AuditEventFilter{
+			ProjectName: "t-optimus",
+			Until:       now.Add(3 * time.Minute),
+			Limit:       1,
+		})
+		assert.Nil(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, "RegisterSecret", events[0].Method)
+	})
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+type backupRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *backupRepository) Save(spec models.BackupSpec) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	if spec.CreatedAt.IsZero() {
+		spec.CreatedAt = repo.db.Now()
+	}
+	repo.db.backups[spec.ID] = backupRecord{
+		spec:      copyBackup(spec),
+		projectID: repo.project.ID,
+	}
+	return nil
+}
+
+func (repo *backupRepository) GetByID(id uuid.UUID) (models.BackupSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	record, ok := repo.db.backups[id]
+	if !ok || record.projectID != repo.project.ID {
+		return models.BackupSpec{}, store.ErrResourceNotFound
+	}
+	return copyBackup(record.spec), nil
+}
+
+func (repo *backupRepository) GetAll(datastore string) ([]models.BackupSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	var specs []models.BackupSpec
+	for _, record := range repo.db.backups {
+		if record.projectID != repo.project.ID || record.spec.Datastore != datastore {
+			continue
+		}
+		specs = append(specs, copyBackup(record.spec))
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].CreatedAt.After(specs[j].CreatedAt)
+	})
+	return specs, nil
+}
+
+func copyBackup(spec models.BackupSpec) models.BackupSpec {
+	spec.Config = copyConfig(spec.Config)
+	spec.Results = append([]models.BackupResult(nil), spec.Results...)
+	return spec
+}
+
+func NewBackupRepository(db *DB, project models.ProjectSpec) *backupRepository {
+	return &backupRepository{
+		db:      db,
+		project: project,
+	}
+}
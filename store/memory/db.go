@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+)
+
+// DB keeps records of every repository in memory, repositories are views
+// over it scoped the same way as their postgres counterparts. Records are
+// lost when the server stops, it is meant for local development and tests
+type DB struct {
+	mu sync.RWMutex
+
+	projects     map[uuid.UUID]models.ProjectSpec
+	namespaces   map[uuid.UUID]namespaceRecord
+	secrets      map[uuid.UUID][]models.ProjectSecretItem
+	macros       map[uuid.UUID][]models.Macro
+	roleBindings map[uuid.UUID][]models.RoleBinding
+	jobs         map[uuid.UUID]jobRecord
+	jobVersions  map[uuid.UUID][]models.JobSpecVersion
+	resources    map[uuid.UUID]resourceRecord
+	instances    map[uuid.UUID]models.InstanceSpec
+	replays      map[uuid.UUID]models.ReplaySpec
+	deployments  map[uuid.UUID]deploymentRecord
+	jobRuns      map[uuid.UUID]jobRunRecord
+	auditEvents  []models.AuditEvent
+	jobLineages  map[string]models.JobLineage
+	backups      map[uuid.UUID]backupRecord
+
+	// projectsDeletedAt marks deleted projects kept till they are purged
+	projectsDeletedAt map[uuid.UUID]time.Time
+
+	// Now is used for timestamps set by repositories
+	Now func() time.Time
+}
+
+type namespaceRecord struct {
+	spec      models.NamespaceSpec
+	projectID uuid.UUID
+}
+
+type jobRecord struct {
+	spec        models.JobSpec
+	namespaceID uuid.UUID
+	projectID   uuid.UUID
+	destination string
+	// deletedAt is set for deleted jobs kept till they are purged
+	deletedAt *time.Time
+}
+
+type resourceRecord struct {
+	spec        models.ResourceSpec
+	namespaceID uuid.UUID
+	projectID   uuid.UUID
+	datastore   string
+}
+
+type deploymentRecord struct {
+	spec      models.DeploymentSpec
+	projectID uuid.UUID
+}
+
+type backupRecord struct {
+	spec      models.BackupSpec
+	projectID uuid.UUID
+}
+
+type jobRunRecord struct {
+	spec      models.JobRun
+	projectID uuid.UUID
+}
+
+// project reads project with its secrets and macros, callers should hold
+// the lock
+func (db *DB) project(id uuid.UUID) (models.ProjectSpec, bool) {
+	spec, ok := db.projects[id]
+	if !ok {
+		return models.ProjectSpec{}, false
+	}
+	spec.Config = copyConfig(spec.Config)
+	spec.Secret = append(models.ProjectSecrets{}, db.secrets[id]...)
+	spec.Macros = append(models.ProjectMacros(nil), db.macros[id]...)
+	return spec, true
+}
+
+// namespace reads namespace with its project, callers should hold the lock
+func (db *DB) namespace(id uuid.UUID) (models.NamespaceSpec, bool) {
+	record, ok := db.namespaces[id]
+	if !ok {
+		return models.NamespaceSpec{}, false
+	}
+	spec := record.spec
+	spec.Config = copyConfig(spec.Config)
+	spec.ProjectSpec, _ = db.project(record.projectID)
+	return spec, true
+}
+
+func copyConfig(conf map[string]string) map[string]string {
+	if conf == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(conf))
+	for k, v := range conf {
+		copied[k] = v
+	}
+	return copied
+}
+
+func NewDB() *DB {
+	return &DB{
+		projects:     map[uuid.UUID]models.ProjectSpec{},
+		namespaces:   map[uuid.UUID]namespaceRecord{},
+		secrets:      map[uuid.UUID][]models.ProjectSecretItem{},
+		macros:       map[uuid.UUID][]models.Macro{},
+		roleBindings: map[uuid.UUID][]models.RoleBinding{},
+		jobs:         map[uuid.UUID]jobRecord{},
+		jobVersions:  map[uuid.UUID][]models.JobSpecVersion{},
+		resources:    map[uuid.UUID]resourceRecord{},
+		instances:    map[uuid.UUID]models.InstanceSpec{},
+		replays:      map[uuid.UUID]models.ReplaySpec{},
+		deployments:  map[uuid.UUID]deploymentRecord{},
+		jobRuns:      map[uuid.UUID]jobRunRecord{},
+		jobLineages:  map[string]models.JobLineage{},
+		backups:      map[uuid.UUID]backupRecord{},
+		Now:          time.Now,
+
+		projectsDeletedAt: map[uuid.UUID]time.Time{},
+	}
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+type deploymentRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *deploymentRepository) Save(spec models.DeploymentSpec) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	if spec.CreatedAt.IsZero() {
+		spec.CreatedAt = repo.db.Now()
+	}
+	spec.Jobs = append([]models.JobDeployment(nil), spec.Jobs...)
+	repo.db.deployments[spec.ID] = deploymentRecord{
+		spec:      spec,
+		projectID: repo.project.ID,
+	}
+	return nil
+}
+
+func (repo *deploymentRepository) GetByID(id uuid.UUID) (models.DeploymentSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	record, ok := repo.db.deployments[id]
+	if !ok || record.projectID != repo.project.ID {
+		return models.DeploymentSpec{}, store.ErrResourceNotFound
+	}
+	return repo.toSpec(record), nil
+}
+
+func (repo *deploymentRepository) GetAll(filter models.DeploymentFilter) ([]models.DeploymentSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	var specs []models.DeploymentSpec
+	for _, record := range repo.db.deployments {
+		if record.projectID != repo.project.ID {
+			continue
+		}
+		spec := repo.toSpec(record)
+		if filter.Namespace != "" && spec.Namespace.Name != filter.Namespace {
+			continue
+		}
+		if !filter.Since.IsZero() && spec.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].CreatedAt.After(specs[j].CreatedAt)
+	})
+	if filter.Limit > 0 && len(specs) > filter.Limit {
+		specs = specs[:filter.Limit]
+	}
+	return specs, nil
+}
+
+func (repo *deploymentRepository) toSpec(record deploymentRecord) models.DeploymentSpec {
+	spec := record.spec
+	if namespaceSpec, ok := repo.db.namespace(spec.Namespace.ID); ok {
+		spec.Namespace = namespaceSpec
+	}
+	spec.Jobs = append([]models.JobDeployment(nil), spec.Jobs...)
+	return spec
+}
+
+func NewDeploymentRepository(db *DB, project models.ProjectSpec) *deploymentRepository {
+	return &deploymentRepository{
+		db:      db,
+		project: project,
+	}
+}
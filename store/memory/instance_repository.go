@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+type instanceRepository struct {
+	db  *DB
+	job models.JobSpec
+}
+
+func (repo *instanceRepository) Save(spec models.InstanceSpec) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	if existing, ok := repo.byScheduledAt(spec.ScheduledAt); ok {
+		spec.ID = existing.ID
+	} else if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	spec.Job = repo.job
+	spec.Data = append([]models.InstanceSpecData(nil), spec.Data...)
+	repo.db.instances[spec.ID] = spec
+	return nil
+}
+
+func (repo *instanceRepository) GetByScheduledAt(scheduled time.Time) (models.InstanceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	spec, ok := repo.byScheduledAt(scheduled)
+	if !ok {
+		return models.InstanceSpec{}, store.ErrResourceNotFound
+	}
+	spec.Job = repo.job
+	spec.Data = append([]models.InstanceSpecData(nil), spec.Data...)
+	return spec, nil
+}
+
+func (repo *instanceRepository) Clear(scheduled time.Time) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	if spec, ok := repo.byScheduledAt(scheduled); ok {
+		spec.Data = nil
+		repo.db.instances[spec.ID] = spec
+	}
+	return nil
+}
+
+func (repo *instanceRepository) byScheduledAt(scheduled time.Time) (models.InstanceSpec, bool) {
+	for _, spec := range repo.db.instances {
+		if spec.Job.ID == repo.job.ID && spec.ScheduledAt.Equal(scheduled) {
+			return spec, true
+		}
+	}
+	return models.InstanceSpec{}, false
+}
+
+func NewInstanceRepository(db *DB, job models.JobSpec) *instanceRepository {
+	return &instanceRepository{
+		db:  db,
+		job: job,
+	}
+}
+
+// deleteInstancesOf drops instances of a deleted job, callers should hold
+// the lock
+func (db *DB) deleteInstancesOf(jobID uuid.UUID) {
+	for id, spec := range db.instances {
+		if spec.Job.ID == jobID {
+			delete(db.instances, id)
+		}
+	}
+}
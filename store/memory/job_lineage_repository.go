@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+type jobLineageRepository struct {
+	db *DB
+}
+
+func (repo *jobLineageRepository) Save(lineage models.JobLineage) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	if lineage.UpdatedAt.IsZero() {
+		lineage.UpdatedAt = repo.db.Now().UTC()
+	}
+	// dependencies are kept unique and sorted as postgres reads them
+	seen := map[string]bool{}
+	var dependencies []string
+	for _, urn := range lineage.Dependencies {
+		if !seen[urn] {
+			seen[urn] = true
+			dependencies = append(dependencies, urn)
+		}
+	}
+	sort.Strings(dependencies)
+	lineage.Dependencies = dependencies
+	repo.db.jobLineages[jobLineageKey(lineage.ProjectName, lineage.JobName)] = lineage
+	return nil
+}
+
+func (repo *jobLineageRepository) Delete(projectName, jobName string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	delete(repo.db.jobLineages, jobLineageKey(projectName, jobName))
+	return nil
+}
+
+func (repo *jobLineageRepository) GetByJob(projectName, jobName string) (models.JobLineage, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	lineage, ok := repo.db.jobLineages[jobLineageKey(projectName, jobName)]
+	if !ok {
+		return models.JobLineage{}, store.ErrResourceNotFound
+	}
+	return lineage, nil
+}
+
+func (repo *jobLineageRepository) GetByDestination(urn string) ([]models.JobLineage, error) {
+	return repo.filter(func(lineage models.JobLineage) bool {
+		return lineage.Destination == urn
+	}), nil
+}
+
+func (repo *jobLineageRepository) GetByDependency(urn string) ([]models.JobLineage, error) {
+	return repo.filter(func(lineage models.JobLineage) bool {
+		for _, dependency := range lineage.Dependencies {
+			if dependency == urn {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// filter returns lineage of jobs matching, ordered by project and job so
+// reads are stable as postgres ones are
+func (repo *jobLineageRepository) filter(match func(models.JobLineage) bool) []models.JobLineage {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	var lineages []models.JobLineage
+	for _, lineage := range repo.db.jobLineages {
+		if match(lineage) {
+			lineages = append(lineages, lineage)
+		}
+	}
+	sort.Slice(lineages, func(i, j int) bool {
+		return jobLineageKey(lineages[i].ProjectName, lineages[i].JobName) < jobLineageKey(lineages[j].ProjectName, lineages[j].JobName)
+	})
+	return lineages
+}
+
+func jobLineageKey(projectName, jobName string) string {
+	return projectName + "/" + jobName
+}
+
+func NewJobLineageRepository(db *DB) *jobLineageRepository {
+	return &jobLineageRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+)
+
+type jobRunRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *jobRunRepository) Save(spec models.JobRun) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	for id, record := range repo.db.jobRuns {
+		if record.projectID == repo.project.ID && record.spec.JobName == spec.JobName &&
+			record.spec.ScheduledAt.Equal(spec.ScheduledAt) {
+			record.spec.Namespace = spec.Namespace
+			record.spec.State = spec.State
+			record.spec.StartTime = spec.StartTime
+			record.spec.EndTime = spec.EndTime
+			record.spec.Attempt = spec.Attempt
+			record.spec.SLAMissedAt = spec.SLAMissedAt
+			record.spec.UpdatedAt = spec.UpdatedAt
+			repo.db.jobRuns[id] = record
+			return nil
+		}
+	}
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	if spec.CreatedAt.IsZero() {
+		spec.CreatedAt = repo.db.Now()
+	}
+	repo.db.jobRuns[spec.ID] = jobRunRecord{
+		spec:      spec,
+		projectID: repo.project.ID,
+	}
+	return nil
+}
+
+func (repo *jobRunRepository) GetAll(filter models.JobRunFilter) ([]models.JobRun, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	var specs []models.JobRun
+	for _, spec := range repo.filter(filter) {
+		if namespaceSpec, ok := repo.db.namespace(spec.Namespace.ID); ok {
+			spec.Namespace = namespaceSpec
+		}
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].ScheduledAt.Before(specs[j].ScheduledAt)
+	})
+	if filter.Limit > 0 && len(specs) > filter.Limit {
+		specs = specs[len(specs)-filter.Limit:]
+	}
+	return specs, nil
+}
+
+func (repo *jobRunRepository) CountByState(filter models.JobRunFilter) (map[models.JobStatusState]int, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	counts := map[models.JobStatusState]int{}
+	for _, spec := range repo.filter(filter) {
+		counts[spec.State]++
+	}
+	return counts, nil
+}
+
+// filter returns runs of project matching, callers should hold the lock
+func (repo *jobRunRepository) filter(filter models.JobRunFilter) []models.JobRun {
+	var specs []models.JobRun
+	for _, record := range repo.db.jobRuns {
+		spec := record.spec
+		if record.projectID != repo.project.ID {
+			continue
+		}
+		if filter.JobName != "" && spec.JobName != filter.JobName {
+			continue
+		}
+		if !filter.StartDate.IsZero() && spec.ScheduledAt.Before(filter.StartDate) {
+			continue
+		}
+		if !filter.EndDate.IsZero() && spec.ScheduledAt.After(filter.EndDate) {
+			continue
+		}
+		if len(filter.States) > 0 && !containsState(filter.States, spec.State) {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+func containsState(states []models.JobStatusState, state models.JobStatusState) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func NewJobRunRepository(db *DB, project models.ProjectSpec) *jobRunRepository {
+	return &jobRunRepository{
+		db:      db,
+		project: project,
+	}
+}
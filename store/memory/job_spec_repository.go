@@ -0,0 +1,311 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type projectJobSpecRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *projectJobSpecRepository) GetByName(name string) (models.JobSpec, models.NamespaceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	for _, record := range repo.db.jobs {
+		if record.deletedAt == nil && record.projectID == repo.project.ID && record.spec.Name == name {
+			namespaceSpec, _ := repo.db.namespace(record.namespaceID)
+			return record.spec, namespaceSpec, nil
+		}
+	}
+	return models.JobSpec{}, models.NamespaceSpec{}, store.ErrResourceNotFound
+}
+
+func (repo *projectJobSpecRepository) GetAll() ([]models.JobSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return repo.db.jobSpecs(func(record jobRecord) bool {
+		return record.projectID == repo.project.ID
+	}), nil
+}
+
+func (repo *projectJobSpecRepository) GetByDestination(destination string) (models.JobSpec, models.ProjectSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	// destinations are looked up across projects
+	for _, record := range repo.db.jobs {
+		if record.deletedAt == nil && record.destination != "" && record.destination == destination {
+			projectSpec, _ := repo.db.project(record.projectID)
+			return record.spec, projectSpec, nil
+		}
+	}
+	return models.JobSpec{}, models.ProjectSpec{}, store.ErrResourceNotFound
+}
+
+func NewProjectJobSpecRepository(db *DB, project models.ProjectSpec) *projectJobSpecRepository {
+	return &projectJobSpecRepository{
+		db:      db,
+		project: project,
+	}
+}
+
+type jobSpecRepository struct {
+	db        *DB
+	namespace models.NamespaceSpec
+}
+
+func (repo *jobSpecRepository) Save(spec models.JobSpec) error {
+	return repo.SaveAll([]models.JobSpec{spec})
+}
+
+// SaveAll checks all the specs before saving any of them, so either all
+// specs are saved or none
+func (repo *jobSpecRepository) SaveAll(specs []models.JobSpec) error {
+	destinations := make([]string, len(specs))
+	names := map[string]bool{}
+	for idx, spec := range specs {
+		if len(spec.Name) == 0 {
+			return errors.New("name cannot be empty")
+		}
+		if names[spec.Name] {
+			return errors.Errorf("job %s is repeated", spec.Name)
+		}
+		names[spec.Name] = true
+
+		destination, err := destinationOf(spec)
+		if err != nil {
+			return err
+		}
+		destinations[idx] = destination
+	}
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	records := make([]jobRecord, len(specs))
+	var replaced []uuid.UUID
+	for idx, spec := range specs {
+		record, deleted, err := repo.prepareSave(spec, destinations[idx])
+		if err != nil {
+			return err
+		}
+		records[idx] = record
+		replaced = append(replaced, deleted...)
+	}
+
+	// new jobs replace the deleted ones of the same name
+	for _, id := range replaced {
+		repo.db.deleteJob(id)
+	}
+	for _, record := range records {
+		repo.db.jobs[record.spec.ID] = record
+		repo.db.saveJobVersion(record.spec)
+	}
+	return nil
+}
+
+// prepareSave returns the record to be saved for spec along with deleted
+// jobs of the same name it replaces, callers should hold the lock
+func (repo *jobSpecRepository) prepareSave(spec models.JobSpec, destination string) (jobRecord, []uuid.UUID, error) {
+	revision := 1
+	var replaced []uuid.UUID
+	for id, record := range repo.db.jobs {
+		if record.projectID != repo.namespace.ProjectSpec.ID || record.spec.Name != spec.Name {
+			continue
+		}
+		if record.deletedAt != nil {
+			// jobs deleted from other namespaces are kept till they are purged
+			if record.namespaceID != repo.namespace.ID {
+				return jobRecord{}, nil, errors.Wrapf(store.ErrResourceDeleted, "job %s is deleted from another namespace of the project %s "+
+					"and can be restored till it is purged", spec.Name, repo.namespace.ProjectSpec.Name)
+			}
+			replaced = append(replaced, id)
+			continue
+		}
+		if record.namespaceID != repo.namespace.ID {
+			return jobRecord{}, nil, errors.Wrapf(store.ErrResourceAlreadyExists, "job %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name)
+		}
+		if spec.Revision != 0 && spec.Revision != record.spec.Revision {
+			return jobRecord{}, nil, errors.Wrapf(store.ErrRevisionConflict, "job %s is at revision %d, expected revision %d", spec.Name, record.spec.Revision, spec.Revision)
+		}
+		spec.ID = id
+		revision = record.spec.Revision + 1
+	}
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	spec.Revision = revision
+	return jobRecord{
+		spec:        spec,
+		namespaceID: repo.namespace.ID,
+		projectID:   repo.namespace.ProjectSpec.ID,
+		destination: destination,
+	}, replaced, nil
+}
+
+func (repo *jobSpecRepository) GetByName(name string) (models.JobSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	for _, record := range repo.db.jobs {
+		if record.deletedAt == nil && record.namespaceID == repo.namespace.ID && record.spec.Name == name {
+			return record.spec, nil
+		}
+	}
+	return models.JobSpec{}, store.ErrResourceNotFound
+}
+
+func (repo *jobSpecRepository) GetAll() ([]models.JobSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return repo.db.jobSpecs(func(record jobRecord) bool {
+		return record.namespaceID == repo.namespace.ID
+	}), nil
+}
+
+func (repo *jobSpecRepository) Delete(name string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	now := repo.db.Now()
+	for id, record := range repo.db.jobs {
+		if record.deletedAt == nil && record.namespaceID == repo.namespace.ID && record.spec.Name == name {
+			record.deletedAt = &now
+			repo.db.jobs[id] = record
+		}
+	}
+	return nil
+}
+
+func (repo *jobSpecRepository) Restore(name string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	for _, record := range repo.db.jobs {
+		if record.deletedAt == nil && record.projectID == repo.namespace.ProjectSpec.ID &&
+			record.namespaceID != repo.namespace.ID && record.spec.Name == name {
+			return errors.Wrapf(store.ErrResourceAlreadyExists, "job %s already exists for the project %s", name, repo.namespace.ProjectSpec.Name)
+		}
+	}
+	for id, record := range repo.db.jobs {
+		if record.deletedAt != nil && record.namespaceID == repo.namespace.ID && record.spec.Name == name {
+			record.deletedAt = nil
+			record.spec.Revision++
+			repo.db.jobs[id] = record
+			repo.db.saveJobVersion(record.spec)
+			return nil
+		}
+	}
+	return store.ErrResourceNotFound
+}
+
+func (repo *jobSpecRepository) Purge(deletedBefore time.Time) ([]string, error) {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	var purged []string
+	for id, record := range repo.db.jobs {
+		if record.deletedAt != nil && record.namespaceID == repo.namespace.ID && record.deletedAt.Before(deletedBefore) {
+			repo.db.deleteJob(id)
+			purged = append(purged, record.spec.Name)
+		}
+	}
+	sort.Strings(purged)
+	return purged, nil
+}
+
+func (repo *jobSpecRepository) GetVersions(name string) ([]models.JobSpecVersion, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	for id, record := range repo.db.jobs {
+		if record.deletedAt == nil && record.namespaceID == repo.namespace.ID && record.spec.Name == name {
+			versions := repo.db.jobVersions[id]
+			latestFirst := make([]models.JobSpecVersion, 0, len(versions))
+			for idx := len(versions) - 1; idx >= 0; idx-- {
+				latestFirst = append(latestFirst, versions[idx])
+			}
+			return latestFirst, nil
+		}
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+func (repo *jobSpecRepository) GetVersion(name string, revision int) (models.JobSpecVersion, error) {
+	versions, err := repo.GetVersions(name)
+	if err != nil {
+		return models.JobSpecVersion{}, err
+	}
+	for _, version := range versions {
+		if version.Revision == revision {
+			return version, nil
+		}
+	}
+	return models.JobSpecVersion{}, store.ErrResourceNotFound
+}
+
+func NewJobSpecRepository(db *DB, namespace models.NamespaceSpec) *jobSpecRepository {
+	return &jobSpecRepository{
+		db:        db,
+		namespace: namespace,
+	}
+}
+
+// saveJobVersion keeps the spec as a version of its job, callers should
+// hold the lock
+func (db *DB) saveJobVersion(spec models.JobSpec) {
+	db.jobVersions[spec.ID] = append(db.jobVersions[spec.ID], models.JobSpecVersion{
+		Revision:  spec.Revision,
+		Spec:      spec,
+		CreatedAt: db.Now(),
+	})
+}
+
+// deleteJob removes job along with its versions and instances, callers
+// should hold the lock
+func (db *DB) deleteJob(id uuid.UUID) {
+	delete(db.jobs, id)
+	delete(db.jobVersions, id)
+	db.deleteInstancesOf(id)
+}
+
+// jobSpecs lists specs of matching jobs which are not deleted sorted by
+// name, callers should hold the lock
+func (db *DB) jobSpecs(match func(jobRecord) bool) []models.JobSpec {
+	specs := []models.JobSpec{}
+	for _, record := range db.jobs {
+		if record.deletedAt == nil && match(record) {
+			specs = append(specs, record.spec)
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs
+}
+
+// destinationOf generates destination of job the same way specs saved in
+// postgres get it
+func destinationOf(spec models.JobSpec) (string, error) {
+	if spec.Task.Unit == nil || spec.Task.Unit.DependencyMod == nil {
+		return "", nil
+	}
+	resp, err := spec.Task.Unit.DependencyMod.GenerateDestination(context.TODO(), models.GenerateDestinationRequest{
+		Config: models.PluginConfigs{}.FromJobSpec(spec.Task.Config),
+		Assets: models.PluginAssets{}.FromJobSpec(spec.Assets),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Destination, nil
+}
@@ -0,0 +1,178 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSpecRepository(t *testing.T) {
+	setup := func() (*memory.DB, models.NamespaceSpec, models.NamespaceSpec) {
+		db := memory.NewDB()
+		projectRepo := memory.NewProjectRepository(db)
+		_ = projectRepo.Save(models.ProjectSpec{Name: "t-optimus"})
+		projectSpec, _ := projectRepo.GetByName("t-optimus")
+		namespaceRepo := memory.NewNamespaceRepository(db, projectSpec)
+		_ = namespaceRepo.Save(models.NamespaceSpec{Name: "dev-team-1"})
+		_ = namespaceRepo.Save(models.NamespaceSpec{Name: "dev-team-2"})
+		first, _ := namespaceRepo.GetByName("dev-team-1")
+		second, _ := namespaceRepo.GetByName("dev-team-2")
+		return db, first, second
+	}
+
+	t.Run("should upsert jobs by name and look them up by destination", func(t *testing.T) {
+		db, namespaceSpec, _ := setup()
+		depMod := new(mock.DependencyResolverMod)
+		defer depMod.AssertExpectations(t)
+		jobSpec := models.JobSpec{
+			Name: "job-1",
+			Task: models.JobSpecTask{
+				Unit: &models.Plugin{DependencyMod: depMod},
+			},
+		}
+		depMod.On("GenerateDestination", context.TODO(), models.GenerateDestinationRequest{
+			Config: models.PluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+			Assets: models.PluginAssets{}.FromJobSpec(jobSpec.Assets),
+		}).Return(&models.GenerateDestinationResponse{Destination: "p.d.t"}, nil)
+
+		repo := memory.NewJobSpecRepository(db, namespaceSpec)
+		assert.Nil(t, repo.Save(jobSpec))
+		jobSpec.Owner = "optimus"
+		assert.Nil(t, repo.Save(jobSpec))
+
+		all, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Len(t, all, 1)
+		assert.Equal(t, "optimus", all[0].Owner)
+
+		found, projectSpec, err := memory.NewProjectJobSpecRepository(db, namespaceSpec.ProjectSpec).GetByDestination("p.d.t")
+		assert.Nil(t, err)
+		assert.Equal(t, all[0].ID, found.ID)
+		assert.Equal(t, namespaceSpec.ProjectSpec.ID, projectSpec.ID)
+	})
+	t.Run("should fail saving a job of another namespace of the project", func(t *testing.T) {
+		db, first, second := setup()
+		assert.Nil(t, memory.NewJobSpecRepository(db, first).Save(models.JobSpec{Name: "job-1"}))
+
+		err := memory.NewJobSpecRepository(db, second).Save(models.JobSpec{Name: "job-1"})
+		assert.True(t, errors.Is(err, store.ErrResourceAlreadyExists))
+	})
+	t.Run("should reject saving a job at an outdated revision", func(t *testing.T) {
+		db, namespaceSpec, _ := setup()
+		repo := memory.NewJobSpecRepository(db, namespaceSpec)
+		assert.Nil(t, repo.Save(models.JobSpec{Name: "job-1"}))
+		stored, err := repo.GetByName("job-1")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, stored.Revision)
+
+		stored.Owner = "optimus"
+		assert.Nil(t, repo.Save(stored))
+		err = repo.Save(stored)
+		assert.True(t, errors.Is(err, store.ErrRevisionConflict))
+
+		updated, err := repo.GetByName("job-1")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, updated.Revision)
+		assert.Equal(t, "optimus", updated.Owner)
+	})
+	t.Run("should save none of the jobs if any of them fails to save", func(t *testing.T) {
+		db, first, second := setup()
+		assert.Nil(t, memory.NewJobSpecRepository(db, second).Save(models.JobSpec{Name: "job-2"}))
+
+		repo := memory.NewJobSpecRepository(db, first)
+		err := repo.SaveAll([]models.JobSpec{{Name: "job-1"}, {Name: "job-2"}})
+		assert.True(t, errors.Is(err, store.ErrResourceAlreadyExists))
+		_, err = repo.GetByName("job-1")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+
+		assert.Nil(t, repo.SaveAll([]models.JobSpec{{Name: "job-1"}, {Name: "job-3"}}))
+		all, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Len(t, all, 2)
+	})
+	t.Run("should keep a version of job on every save", func(t *testing.T) {
+		db, namespaceSpec, _ := setup()
+		repo := memory.NewJobSpecRepository(db, namespaceSpec)
+		assert.Nil(t, repo.Save(models.JobSpec{Name: "job-1", Owner: "optimus"}))
+		stored, err := repo.GetByName("job-1")
+		assert.Nil(t, err)
+		stored.Owner = "odpf"
+		assert.Nil(t, repo.Save(stored))
+
+		versions, err := repo.GetVersions("job-1")
+		assert.Nil(t, err)
+		assert.Len(t, versions, 2)
+		assert.Equal(t, 2, versions[0].Revision)
+		assert.Equal(t, "odpf", versions[0].Spec.Owner)
+		assert.Equal(t, 1, versions[1].Revision)
+		assert.Equal(t, "optimus", versions[1].Spec.Owner)
+
+		version, err := repo.GetVersion("job-1", 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "optimus", version.Spec.Owner)
+		_, err = repo.GetVersion("job-1", 3)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+		_, err = repo.GetVersions("job-2")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+	t.Run("should delete jobs of namespace", func(t *testing.T) {
+		db, namespaceSpec, _ := setup()
+		repo := memory.NewJobSpecRepository(db, namespaceSpec)
+		assert.Nil(t, repo.Save(models.JobSpec{Name: "job-1"}))
+
+		assert.Nil(t, repo.Delete("job-1"))
+		_, err := repo.GetByName("job-1")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+		_, _, err = memory.NewProjectJobSpecRepository(db, namespaceSpec.ProjectSpec).GetByName("job-1")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+	t.Run("should restore deleted jobs till they are purged", func(t *testing.T) {
+		db, namespaceSpec, _ := setup()
+		deletedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		db.Now = func() time.Time { return deletedAt }
+		repo := memory.NewJobSpecRepository(db, namespaceSpec)
+		assert.Nil(t, repo.Save(models.JobSpec{Name: "job-1"}))
+		assert.Nil(t, repo.Save(models.JobSpec{Name: "job-2"}))
+		assert.Nil(t, repo.Delete("job-1"))
+		assert.Nil(t, repo.Delete("job-2"))
+
+		assert.Nil(t, repo.Restore("job-1"))
+		restored, err := repo.GetByName("job-1")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, restored.Revision)
+		assert.Equal(t, store.ErrResourceNotFound, repo.Restore("job-1"))
+
+		purged, err := repo.Purge(deletedAt)
+		assert.Nil(t, err)
+		assert.Empty(t, purged)
+		purged, err = repo.Purge(deletedAt.Add(time.Second))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"job-2"}, purged)
+		assert.Equal(t, store.ErrResourceNotFound, repo.Restore("job-2"))
+
+		all, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Len(t, all, 1)
+	})
+	t.Run("should keep a deleted job restorable while another namespace saves its name", func(t *testing.T) {
+		db, first, second := setup()
+		repo := memory.NewJobSpecRepository(db, first)
+		assert.Nil(t, repo.Save(models.JobSpec{Name: "job-1"}))
+		assert.Nil(t, repo.Delete("job-1"))
+
+		err := memory.NewJobSpecRepository(db, second).Save(models.JobSpec{Name: "job-1"})
+		assert.True(t, errors.Is(err, store.ErrResourceDeleted))
+
+		assert.Nil(t, repo.Restore("job-1"))
+		versions, err := repo.GetVersions("job-1")
+		assert.Nil(t, err)
+		assert.Len(t, versions, 2)
+	})
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+type macroRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *macroRepository) Save(spec models.Macro) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	macros := repo.db.macros[repo.project.ID]
+	for idx, macro := range macros {
+		if macro.Name == spec.Name && macro.Namespace == spec.Namespace {
+			spec.ID = macro.ID
+			macros[idx] = spec
+			return nil
+		}
+	}
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	macros = append(macros, spec)
+	sort.SliceStable(macros, func(i, j int) bool {
+		if macros[i].Name != macros[j].Name {
+			return macros[i].Name < macros[j].Name
+		}
+		return macros[i].Namespace < macros[j].Namespace
+	})
+	repo.db.macros[repo.project.ID] = macros
+	return nil
+}
+
+func (repo *macroRepository) Delete(name, namespace string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	macros := repo.db.macros[repo.project.ID]
+	for idx, macro := range macros {
+		if macro.Name == name && macro.Namespace == namespace {
+			repo.db.macros[repo.project.ID] = append(macros[:idx:idx], macros[idx+1:]...)
+			return nil
+		}
+	}
+	return store.ErrResourceNotFound
+}
+
+func (repo *macroRepository) GetAll() ([]models.Macro, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return append([]models.Macro(nil), repo.db.macros[repo.project.ID]...), nil
+}
+
+func NewMacroRepository(db *DB, project models.ProjectSpec) *macroRepository {
+	return &macroRepository{
+		db:      db,
+		project: project,
+	}
+}
@@ -0,0 +1,303 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type projectRepository struct {
+	db *DB
+}
+
+func (repo *projectRepository) Save(spec models.ProjectSpec) error {
+	if len(spec.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	existing, ok := repo.byName(spec.Name)
+	if ok {
+		spec.ID = existing.ID
+	} else if _, deleted := repo.deletedByName(spec.Name); deleted {
+		return errors.Wrapf(store.ErrResourceDeleted, "project %s is deleted and can be restored till it is purged", spec.Name)
+	} else if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	// secrets and macros are kept by their own repositories
+	spec.Config = copyConfig(spec.Config)
+	spec.Secret = nil
+	spec.Macros = nil
+	repo.db.projects[spec.ID] = spec
+	return nil
+}
+
+func (repo *projectRepository) GetByName(name string) (models.ProjectSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	existing, ok := repo.byName(name)
+	if !ok {
+		return models.ProjectSpec{}, store.ErrResourceNotFound
+	}
+	spec, _ := repo.db.project(existing.ID)
+	return spec, nil
+}
+
+func (repo *projectRepository) GetAll() ([]models.ProjectSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	specs := []models.ProjectSpec{}
+	for id := range repo.db.projects {
+		if _, deleted := repo.db.projectsDeletedAt[id]; deleted {
+			continue
+		}
+		spec, _ := repo.db.project(id)
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs, nil
+}
+
+func (repo *projectRepository) Delete(name string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	existing, ok := repo.byName(name)
+	if !ok {
+		return store.ErrResourceNotFound
+	}
+	repo.db.projectsDeletedAt[existing.ID] = repo.db.Now()
+	return nil
+}
+
+func (repo *projectRepository) Restore(name string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	deleted, ok := repo.deletedByName(name)
+	if !ok {
+		return store.ErrResourceNotFound
+	}
+	delete(repo.db.projectsDeletedAt, deleted.ID)
+	return nil
+}
+
+func (repo *projectRepository) Purge(deletedBefore time.Time) ([]string, error) {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	var purged []string
+	for id, deletedAt := range repo.db.projectsDeletedAt {
+		if deletedAt.Before(deletedBefore) {
+			purged = append(purged, repo.db.projects[id].Name)
+			repo.db.deleteProject(id)
+		}
+	}
+	sort.Strings(purged)
+	return purged, nil
+}
+
+func (repo *projectRepository) byName(name string) (models.ProjectSpec, bool) {
+	for id, spec := range repo.db.projects {
+		if _, deleted := repo.db.projectsDeletedAt[id]; !deleted && spec.Name == name {
+			return spec, true
+		}
+	}
+	return models.ProjectSpec{}, false
+}
+
+func (repo *projectRepository) deletedByName(name string) (models.ProjectSpec, bool) {
+	for id, spec := range repo.db.projects {
+		if _, deleted := repo.db.projectsDeletedAt[id]; deleted && spec.Name == name {
+			return spec, true
+		}
+	}
+	return models.ProjectSpec{}, false
+}
+
+// deleteProject removes a project along with everything it owns, audit
+// events are kept. Callers should hold the lock
+func (db *DB) deleteProject(id uuid.UUID) {
+	name := db.projects[id].Name
+	for jobID, record := range db.jobs {
+		if record.projectID == id {
+			db.deleteJob(jobID)
+		}
+	}
+	for nsID, record := range db.namespaces {
+		if record.projectID == id {
+			delete(db.namespaces, nsID)
+		}
+	}
+	for resourceID, record := range db.resources {
+		if record.projectID == id {
+			delete(db.resources, resourceID)
+		}
+	}
+	for deploymentID, record := range db.deployments {
+		if record.projectID == id {
+			delete(db.deployments, deploymentID)
+		}
+	}
+	for runID, record := range db.jobRuns {
+		if record.projectID == id {
+			delete(db.jobRuns, runID)
+		}
+	}
+	for backupID, record := range db.backups {
+		if record.projectID == id {
+			delete(db.backups, backupID)
+		}
+	}
+	for key, lineage := range db.jobLineages {
+		if lineage.ProjectName == name {
+			delete(db.jobLineages, key)
+		}
+	}
+	delete(db.secrets, id)
+	delete(db.macros, id)
+	delete(db.roleBindings, id)
+	delete(db.projects, id)
+	delete(db.projectsDeletedAt, id)
+}
+
+func NewProjectRepository(db *DB) *projectRepository {
+	return &projectRepository{
+		db: db,
+	}
+}
+
+type namespaceRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *namespaceRepository) Save(spec models.NamespaceSpec) error {
+	if len(spec.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	existing, ok := repo.byName(spec.Name)
+	if ok {
+		spec.ID = existing.spec.ID
+	} else if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	spec.Config = copyConfig(spec.Config)
+	spec.ProjectSpec = models.ProjectSpec{}
+	repo.db.namespaces[spec.ID] = namespaceRecord{
+		spec:      spec,
+		projectID: repo.project.ID,
+	}
+	return nil
+}
+
+func (repo *namespaceRepository) GetByName(name string) (models.NamespaceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	existing, ok := repo.byName(name)
+	if !ok {
+		return models.NamespaceSpec{}, store.ErrResourceNotFound
+	}
+	spec, _ := repo.db.namespace(existing.spec.ID)
+	return spec, nil
+}
+
+func (repo *namespaceRepository) GetAll() ([]models.NamespaceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	specs := []models.NamespaceSpec{}
+	for id, record := range repo.db.namespaces {
+		if record.projectID != repo.project.ID {
+			continue
+		}
+		spec, _ := repo.db.namespace(id)
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs, nil
+}
+
+func (repo *namespaceRepository) byName(name string) (namespaceRecord, bool) {
+	for _, record := range repo.db.namespaces {
+		if record.projectID == repo.project.ID && record.spec.Name == name {
+			return record, true
+		}
+	}
+	return namespaceRecord{}, false
+}
+
+func NewNamespaceRepository(db *DB, project models.ProjectSpec) *namespaceRepository {
+	return &namespaceRepository{
+		db:      db,
+		project: project,
+	}
+}
+
+type secretRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *secretRepository) Save(spec models.ProjectSecretItem) error {
+	if len(spec.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	secrets := repo.db.secrets[repo.project.ID]
+	for idx, secret := range secrets {
+		if secret.Name == spec.Name {
+			spec.ID = secret.ID
+			secrets[idx] = spec
+			return nil
+		}
+	}
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	repo.db.secrets[repo.project.ID] = append(secrets, spec)
+	return nil
+}
+
+func (repo *secretRepository) GetByName(name string) (models.ProjectSecretItem, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	for _, secret := range repo.db.secrets[repo.project.ID] {
+		if secret.Name == name {
+			return secret, nil
+		}
+	}
+	return models.ProjectSecretItem{}, store.ErrResourceNotFound
+}
+
+func (repo *secretRepository) GetAll() ([]models.ProjectSecretItem, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return append([]models.ProjectSecretItem{}, repo.db.secrets[repo.project.ID]...), nil
+}
+
+func NewSecretRepository(db *DB, project models.ProjectSpec) *secretRepository {
+	return &secretRepository{
+		db:      db,
+		project: project,
+	}
+}
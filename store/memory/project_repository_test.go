@@ -0,0 +1,106 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectRepository(t *testing.T) {
+	t.Run("should upsert projects by name with their secrets and macros", func(t *testing.T) {
+		db := memory.NewDB()
+		repo := memory.NewProjectRepository(db)
+
+		assert.Nil(t, repo.Save(models.ProjectSpec{Name: "t-optimus", Config: map[string]string{"bucket": "gs://a"}}))
+		assert.Nil(t, repo.Save(models.ProjectSpec{Name: "t-optimus", Config: map[string]string{"bucket": "gs://b"}}))
+		projectSpec, err := repo.GetByName("t-optimus")
+		assert.Nil(t, err)
+		assert.Equal(t, "gs://b", projectSpec.Config["bucket"])
+
+		assert.Nil(t, memory.NewSecretRepository(db, projectSpec).Save(models.ProjectSecretItem{Name: "STORAGE", Value: "secret"}))
+		assert.Nil(t, memory.NewMacroRepository(db, projectSpec).Save(models.Macro{Name: "region", Default: "eu"}))
+
+		all, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Len(t, all, 1)
+		assert.Equal(t, projectSpec.ID, all[0].ID)
+		assert.Equal(t, "secret", all[0].Secret[0].Value)
+		assert.Equal(t, "eu", all[0].Macros[0].Default)
+	})
+	t.Run("should return not found for unknown projects", func(t *testing.T) {
+		_, err := memory.NewProjectRepository(memory.NewDB()).GetByName("unknown")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+	t.Run("should fail for projects without name", func(t *testing.T) {
+		err := memory.NewProjectRepository(memory.NewDB()).Save(models.ProjectSpec{})
+		assert.NotNil(t, err)
+	})
+	t.Run("should keep a deleted project with its jobs till it is purged", func(t *testing.T) {
+		db := memory.NewDB()
+		now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		db.Now = func() time.Time { return now }
+		repo := memory.NewProjectRepository(db)
+		assert.Nil(t, repo.Save(models.ProjectSpec{Name: "t-optimus"}))
+		projectSpec, _ := repo.GetByName("t-optimus")
+		namespaceRepo := memory.NewNamespaceRepository(db, projectSpec)
+		assert.Nil(t, namespaceRepo.Save(models.NamespaceSpec{Name: "dev-team-1"}))
+		namespaceSpec, _ := namespaceRepo.GetByName("dev-team-1")
+		assert.Nil(t, memory.NewJobSpecRepository(db, namespaceSpec).Save(models.JobSpec{Name: "job-1"}))
+
+		assert.Nil(t, repo.Delete("t-optimus"))
+		_, err := repo.GetByName("t-optimus")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+		all, _ := repo.GetAll()
+		assert.Empty(t, all)
+		err = repo.Save(models.ProjectSpec{Name: "t-optimus"})
+		assert.True(t, errors.Is(err, store.ErrResourceDeleted))
+
+		assert.Nil(t, repo.Restore("t-optimus"))
+		restored, err := repo.GetByName("t-optimus")
+		assert.Nil(t, err)
+		assert.Equal(t, projectSpec.ID, restored.ID)
+		_, err = memory.NewJobSpecRepository(db, namespaceSpec).GetByName("job-1")
+		assert.Nil(t, err)
+		assert.Equal(t, store.ErrResourceNotFound, repo.Restore("t-optimus"))
+
+		assert.Nil(t, repo.Delete("t-optimus"))
+		purged, err := repo.Purge(now)
+		assert.Nil(t, err)
+		assert.Empty(t, purged)
+		purged, err = repo.Purge(now.Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"t-optimus"}, purged)
+		assert.Equal(t, store.ErrResourceNotFound, repo.Restore("t-optimus"))
+		_, err = memory.NewJobSpecRepository(db, namespaceSpec).GetByName("job-1")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+		assert.Nil(t, repo.Save(models.ProjectSpec{Name: "t-optimus"}))
+	})
+	t.Run("should return not found deleting unknown projects", func(t *testing.T) {
+		err := memory.NewProjectRepository(memory.NewDB()).Delete("unknown")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+}
+
+func TestNamespaceRepository(t *testing.T) {
+	t.Run("should scope namespaces by project", func(t *testing.T) {
+		db := memory.NewDB()
+		projectRepo := memory.NewProjectRepository(db)
+		assert.Nil(t, projectRepo.Save(models.ProjectSpec{Name: "t-optimus"}))
+		assert.Nil(t, projectRepo.Save(models.ProjectSpec{Name: "t-other"}))
+		projectSpec, _ := projectRepo.GetByName("t-optimus")
+		otherSpec, _ := projectRepo.GetByName("t-other")
+
+		assert.Nil(t, memory.NewNamespaceRepository(db, projectSpec).Save(models.NamespaceSpec{Name: "dev-team-1"}))
+
+		namespaceSpec, err := memory.NewNamespaceRepository(db, projectSpec).GetByName("dev-team-1")
+		assert.Nil(t, err)
+		assert.Equal(t, projectSpec.ID, namespaceSpec.ProjectSpec.ID)
+		_, err = memory.NewNamespaceRepository(db, otherSpec).GetByName("dev-team-1")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+}
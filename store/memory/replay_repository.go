@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type replayRepository struct {
+	db      *DB
+	jobSpec models.JobSpec
+}
+
+func (repo *replayRepository) Insert(replay *models.ReplaySpec) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	spec := *replay
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	if spec.CreatedAt.IsZero() {
+		spec.CreatedAt = repo.db.Now()
+	}
+	repo.db.replays[spec.ID] = spec
+	return nil
+}
+
+func (repo *replayRepository) GetByID(id uuid.UUID) (models.ReplaySpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	spec, ok := repo.db.replays[id]
+	if !ok {
+		return models.ReplaySpec{}, store.ErrResourceNotFound
+	}
+	spec.Job = repo.jobSpec
+	return spec, nil
+}
+
+func (repo *replayRepository) UpdateStatus(replayID uuid.UUID, status string, message models.ReplayMessage) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	spec, ok := repo.db.replays[replayID]
+	if !ok {
+		return errors.New("could not update non-existing replay")
+	}
+	spec.Status = status
+	spec.Message = message
+	repo.db.replays[replayID] = spec
+	return nil
+}
+
+func (repo *replayRepository) GetByStatus(status []string) ([]models.ReplaySpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return repo.db.replaySpecs(func(spec models.ReplaySpec) bool {
+		return containsString(status, spec.Status)
+	}), nil
+}
+
+func (repo *replayRepository) GetByJobIDAndStatus(jobID uuid.UUID, status []string) ([]models.ReplaySpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return repo.db.replaySpecs(func(spec models.ReplaySpec) bool {
+		return spec.Job.ID == jobID && containsString(status, spec.Status)
+	}), nil
+}
+
+func (repo *replayRepository) GetByProjectID(projectID uuid.UUID) ([]models.ReplaySpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	specs := repo.db.replaySpecs(func(spec models.ReplaySpec) bool {
+		record, ok := repo.db.jobs[spec.Job.ID]
+		return ok && record.deletedAt == nil && record.projectID == projectID
+	})
+	// latest first, as postgres lists them
+	for i, j := 0, len(specs)-1; i < j; i, j = i+1, j-1 {
+		specs[i], specs[j] = specs[j], specs[i]
+	}
+	return specs, nil
+}
+
+func NewReplayRepository(db *DB, jobSpec models.JobSpec) *replayRepository {
+	return &replayRepository{
+		db:      db,
+		jobSpec: jobSpec,
+	}
+}
+
+// replaySpecs lists matching replays with latest specs of their jobs in the
+// order they were created, callers should hold the lock
+func (db *DB) replaySpecs(match func(models.ReplaySpec) bool) []models.ReplaySpec {
+	var specs []models.ReplaySpec
+	for _, spec := range db.replays {
+		if !match(spec) {
+			continue
+		}
+		if record, ok := db.jobs[spec.Job.ID]; ok {
+			spec.Job = record.spec
+		}
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].CreatedAt.Before(specs[j].CreatedAt)
+	})
+	return specs
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
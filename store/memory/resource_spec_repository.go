@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type projectResourceSpecRepository struct {
+	db        *DB
+	project   models.ProjectSpec
+	datastore models.Datastorer
+}
+
+func (repo *projectResourceSpecRepository) GetByName(name string) (models.ResourceSpec, models.NamespaceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	for _, record := range repo.db.resources {
+		if record.projectID == repo.project.ID && record.datastore == repo.datastore.Name() && record.spec.Name == name {
+			namespaceSpec, _ := repo.db.namespace(record.namespaceID)
+			return record.spec, namespaceSpec, nil
+		}
+	}
+	return models.ResourceSpec{}, models.NamespaceSpec{}, store.ErrResourceNotFound
+}
+
+func (repo *projectResourceSpecRepository) GetAll() ([]models.ResourceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return repo.db.resourceSpecs(func(record resourceRecord) bool {
+		return record.projectID == repo.project.ID && record.datastore == repo.datastore.Name()
+	}), nil
+}
+
+func NewProjectResourceSpecRepository(db *DB, project models.ProjectSpec, ds models.Datastorer) *projectResourceSpecRepository {
+	return &projectResourceSpecRepository{
+		db:        db,
+		project:   project,
+		datastore: ds,
+	}
+}
+
+type resourceSpecRepository struct {
+	db        *DB
+	namespace models.NamespaceSpec
+	datastore models.Datastorer
+}
+
+func (repo *resourceSpecRepository) Save(spec models.ResourceSpec) error {
+	if len(spec.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	for id, record := range repo.db.resources {
+		if record.projectID != repo.namespace.ProjectSpec.ID || record.datastore != repo.datastore.Name() || record.spec.Name != spec.Name {
+			continue
+		}
+		if record.namespaceID != repo.namespace.ID {
+			return errors.Wrapf(store.ErrResourceAlreadyExists, "resource %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name)
+		}
+		spec.ID = id
+	}
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	repo.db.resources[spec.ID] = resourceRecord{
+		spec:        spec,
+		namespaceID: repo.namespace.ID,
+		projectID:   repo.namespace.ProjectSpec.ID,
+		datastore:   repo.datastore.Name(),
+	}
+	return nil
+}
+
+func (repo *resourceSpecRepository) GetByName(name string) (models.ResourceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	for _, record := range repo.db.resources {
+		if repo.owns(record) && record.spec.Name == name {
+			return record.spec, nil
+		}
+	}
+	return models.ResourceSpec{}, store.ErrResourceNotFound
+}
+
+func (repo *resourceSpecRepository) GetAll() ([]models.ResourceSpec, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return repo.db.resourceSpecs(repo.owns), nil
+}
+
+func (repo *resourceSpecRepository) Delete(name string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	for id, record := range repo.db.resources {
+		if repo.owns(record) && record.spec.Name == name {
+			delete(repo.db.resources, id)
+		}
+	}
+	return nil
+}
+
+func (repo *resourceSpecRepository) owns(record resourceRecord) bool {
+	return record.namespaceID == repo.namespace.ID && record.datastore == repo.datastore.Name()
+}
+
+func NewResourceSpecRepository(db *DB, namespace models.NamespaceSpec, ds models.Datastorer) *resourceSpecRepository {
+	return &resourceSpecRepository{
+		db:        db,
+		namespace: namespace,
+		datastore: ds,
+	}
+}
+
+// resourceSpecs lists specs of matching resources sorted by name, callers
+// should hold the lock
+func (db *DB) resourceSpecs(match func(resourceRecord) bool) []models.ResourceSpec {
+	specs := []models.ResourceSpec{}
+	for _, record := range db.resources {
+		if match(record) {
+			specs = append(specs, record.spec)
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type roleBindingRepository struct {
+	db      *DB
+	project models.ProjectSpec
+}
+
+func (repo *roleBindingRepository) Save(spec models.RoleBinding) error {
+	if spec.Subject == "" {
+		return errors.New("subject cannot be empty")
+	}
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	bindings := repo.db.roleBindings[repo.project.ID]
+	for idx, binding := range bindings {
+		if binding.Subject == spec.Subject && binding.Namespace == spec.Namespace {
+			bindings[idx].Role = spec.Role
+			return nil
+		}
+	}
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	bindings = append(bindings, spec)
+	sort.SliceStable(bindings, func(i, j int) bool {
+		if bindings[i].Subject != bindings[j].Subject {
+			return bindings[i].Subject < bindings[j].Subject
+		}
+		return bindings[i].Namespace < bindings[j].Namespace
+	})
+	repo.db.roleBindings[repo.project.ID] = bindings
+	return nil
+}
+
+func (repo *roleBindingRepository) Delete(subject, namespace string) error {
+	repo.db.mu.Lock()
+	defer repo.db.mu.Unlock()
+
+	bindings := repo.db.roleBindings[repo.project.ID]
+	for idx, binding := range bindings {
+		if binding.Subject == subject && binding.Namespace == namespace {
+			repo.db.roleBindings[repo.project.ID] = append(bindings[:idx:idx], bindings[idx+1:]...)
+			return nil
+		}
+	}
+	return store.ErrResourceNotFound
+}
+
+func (repo *roleBindingRepository) GetAll() ([]models.RoleBinding, error) {
+	repo.db.mu.RLock()
+	defer repo.db.mu.RUnlock()
+
+	return append([]models.RoleBinding(nil), repo.db.roleBindings[repo.project.ID]...), nil
+}
+
+func NewRoleBindingRepository(db *DB, project models.ProjectSpec) *roleBindingRepository {
+	return &roleBindingRepository{
+		db:      db,
+		project: project,
+	}
+}
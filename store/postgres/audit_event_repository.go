@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+)
+
+type AuditEvent struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	// project and namespace are kept as names, calls made for projects
+	// which failed to register are audited too
+	ProjectName string
+	Namespace   string
+
+	Method  string `gorm:"not null"`
+	Actor   string
+	Summary string
+	Success bool
+	Error   string
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+}
+
+func (e AuditEvent) FromSpec(spec models.AuditEvent) AuditEvent {
+	return AuditEvent{
+		ID:          spec.ID,
+		ProjectName: spec.ProjectName,
+		Namespace:   spec.Namespace,
+		Method:      spec.Method,
+		Actor:       spec.Actor,
+		Summary:     spec.Summary,
+		Success:     spec.Success,
+		Error:       spec.Error,
+		CreatedAt:   spec.CreatedAt.UTC(),
+	}
+}
+
+func (e AuditEvent) ToSpec() models.AuditEvent {
+	return models.AuditEvent{
+		ID:          e.ID,
+		ProjectName: e.ProjectName,
+		Namespace:   e.Namespace,
+		Method:      e.Method,
+		Actor:       e.Actor,
+		Summary:     e.Summary,
+		Success:     e.Success,
+		Error:       e.Error,
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+type auditEventRepository struct {
+	db *gorm.DB
+}
+
+func (repo *auditEventRepository) Save(spec models.AuditEvent) error {
+	event := AuditEvent{}.FromSpec(spec)
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+	return repo.db.Create(&event).Error
+}
+
+func (repo *auditEventRepository) GetAll(filter models.AuditEventFilter) ([]models.AuditEvent, error) {
+	query := repo.db.Where("project_name = ?", filter.ProjectName)
+	if filter.Namespace != "" {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until.UTC())
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []AuditEvent
+	if err := query.Order("created_at desc").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	var specs []models.AuditEvent
+	for _, event := range events {
+		specs = append(specs, event.ToSpec())
+	}
+	return specs, nil
+}
+
+func NewAuditEventRepository(db *gorm.DB) *auditEventRepository {
+	return &auditEventRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,120 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEventRepository(t *testing.T) {
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		return dbConn
+	}
+
+	startTime := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+	events := []models.AuditEvent{
+		{
+			ProjectName: "t-optimus",
+			Namespace:   "dev-team-1",
+			Method:      "DeployJobSpecification",
+			Actor:       "ci",
+			Summary:     "deploy 3 jobs",
+			Success:     true,
+			CreatedAt:   startTime,
+		},
+		{
+			ProjectName: "t-optimus",
+			Method:      "RegisterSecret",
+			Actor:       "alice@example.io",
+			Summary:     "register secret STORAGE",
+			Success:     false,
+			Error:       "secret already exists",
+			CreatedAt:   startTime.Add(time.Hour),
+		},
+		{
+			ProjectName: "t-optimus",
+			Namespace:   "dev-team-2",
+			Method:      "DeleteJobSpecification",
+			Actor:       "ci",
+			Summary:     "delete job job-1",
+			Success:     true,
+			CreatedAt:   startTime.Add(2 * time.Hour),
+		},
+		{
+			ProjectName: "t-optimus-other",
+			Method:      "RegisterProject",
+			Actor:       "root",
+			Success:     true,
+			CreatedAt:   startTime.Add(3 * time.Hour),
+		},
+	}
+
+	t.Run("GetAll", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		repo := NewAuditEventRepository(db)
+		for _, event := range events {
+			assert.Nil(t, repo.Save(event))
+		}
+
+		t.Run("should return events of project most recent first", func(t *testing.T) {
+			found, err := repo.GetAll(models.AuditEventFilter{ProjectName: "t-optimus"})
+			assert.Nil(t, err)
+			assert.Equal(t, 3, len(found))
+			assert.Equal(t, "DeleteJobSpecification", found[0].Method)
+			assert.Equal(t, "secret already exists", found[1].Error)
+			assert.False(t, found[1].Success)
+			assert.Equal(t, "DeployJobSpecification", found[2].Method)
+		})
+		t.Run("should apply filters", func(t *testing.T) {
+			found, err := repo.GetAll(models.AuditEventFilter{ProjectName: "t-optimus", Actor: "ci", Namespace: "dev-team-1"})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(found))
+			assert.Equal(t, "deploy 3 jobs", found[0].Summary)
+
+			found, err = repo.GetAll(models.AuditEventFilter{ProjectName: "t-optimus", Method: "RegisterSecret"})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(found))
+
+			found, err = repo.GetAll(models.AuditEventFilter{
+				ProjectName: "t-optimus",
+				Since:       startTime.Add(30 * time.Minute),
+				Until:       startTime.Add(2 * time.Hour),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(found))
+			assert.Equal(t, "RegisterSecret", found[0].Method)
+		})
+		t.Run("should limit number of events", func(t *testing.T) {
+			found, err := repo.GetAll(models.AuditEventFilter{ProjectName: "t-optimus", Limit: 2})
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(found))
+			assert.Equal(t, "DeleteJobSpecification", found[0].Method)
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+)
+
+type Backup struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	ProjectID uuid.UUID `gorm:"not null"`
+
+	Datastore    string `gorm:"not null"`
+	ResourceName string `gorm:"not null"`
+	Description  string
+	Config       datatypes.JSON
+	Results      datatypes.JSON
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+func (b Backup) FromSpec(spec models.BackupSpec, project models.ProjectSpec) (Backup, error) {
+	config, err := json.Marshal(spec.Config)
+	if err != nil {
+		return Backup{}, err
+	}
+	results, err := json.Marshal(spec.Results)
+	if err != nil {
+		return Backup{}, err
+	}
+	return Backup{
+		ID:           spec.ID,
+		ProjectID:    project.ID,
+		Datastore:    spec.Datastore,
+		ResourceName: spec.ResourceName,
+		Description:  spec.Description,
+		Config:       config,
+		Results:      results,
+		CreatedAt:    spec.CreatedAt.UTC(),
+		UpdatedAt:    spec.CreatedAt.UTC(),
+	}, nil
+}
+
+func (b Backup) ToSpec() (models.BackupSpec, error) {
+	var config map[string]string
+	if len(b.Config) > 0 {
+		if err := json.Unmarshal(b.Config, &config); err != nil {
+			return models.BackupSpec{}, err
+		}
+	}
+	var results []models.BackupResult
+	if len(b.Results) > 0 {
+		if err := json.Unmarshal(b.Results, &results); err != nil {
+			return models.BackupSpec{}, err
+		}
+	}
+	return models.BackupSpec{
+		ID:           b.ID,
+		ResourceName: b.ResourceName,
+		Datastore:    b.Datastore,
+		Description:  b.Description,
+		Config:       config,
+		Results:      results,
+		CreatedAt:    b.CreatedAt,
+	}, nil
+}
+
+type backupRepository struct {
+	DB      *gorm.DB
+	project models.ProjectSpec
+}
+
+func (repo *backupRepository) Save(spec models.BackupSpec) error {
+	b, err := Backup{}.FromSpec(spec, repo.project)
+	if err != nil {
+		return err
+	}
+	return repo.DB.Save(&b).Error
+}
+
+func (repo *backupRepository) GetByID(id uuid.UUID) (models.BackupSpec, error) {
+	var b Backup
+	if err := repo.DB.Where("project_id = ? AND id = ?", repo.project.ID, id).First(&b).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.BackupSpec{}, store.ErrResourceNotFound
+		}
+		return models.BackupSpec{}, err
+	}
+	return b.ToSpec()
+}
+
+func (repo *backupRepository) GetAll(datastore string) ([]models.BackupSpec, error) {
+	var backups []Backup
+	if err := repo.DB.Where("project_id = ? AND datastore = ?", repo.project.ID, datastore).
+		Order("created_at desc").Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	var specs []models.BackupSpec
+	for _, b := range backups {
+		spec, err := b.ToSpec()
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func NewBackupRepository(db *gorm.DB, project models.ProjectSpec) *backupRepository {
+	return &backupRepository{
+		DB:      db,
+		project: project,
+	}
+}
@@ -0,0 +1,108 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupRepository(t *testing.T) {
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		return dbConn
+	}
+
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus",
+		Config: map[string]string{
+			"bucket": "gs://some_folder",
+		},
+	}
+	createdAt := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	backupSpecs := []models.BackupSpec{
+		{
+			ID:           uuid.Must(uuid.NewRandom()),
+			ResourceName: "proj.sales.orders",
+			Datastore:    "bigquery",
+			Description:  "before replay",
+			Config:       map[string]string{models.BackupConfigTTL: "24h"},
+			Results: []models.BackupResult{
+				{ResourceName: "proj.sales.orders", URN: "bigquery://proj:optimus_backup.orders"},
+			},
+			CreatedAt: createdAt,
+		},
+		{
+			ID:           uuid.Must(uuid.NewRandom()),
+			ResourceName: "proj.sales.daily_orders",
+			Datastore:    "bigquery",
+			CreatedAt:    createdAt.Add(time.Hour),
+		},
+	}
+
+	t.Run("Save and GetByID", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		assert.Nil(t, NewProjectRepository(db, hash).Save(projectSpec))
+
+		repo := NewBackupRepository(db, projectSpec)
+		assert.Nil(t, repo.Save(backupSpecs[0]))
+
+		checkModel, err := repo.GetByID(backupSpecs[0].ID)
+		assert.Nil(t, err)
+		assert.Equal(t, backupSpecs[0].ResourceName, checkModel.ResourceName)
+		assert.Equal(t, backupSpecs[0].Config, checkModel.Config)
+		assert.Equal(t, backupSpecs[0].Results, checkModel.Results)
+
+		_, err = repo.GetByID(uuid.Must(uuid.NewRandom()))
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		assert.Nil(t, NewProjectRepository(db, hash).Save(projectSpec))
+
+		repo := NewBackupRepository(db, projectSpec)
+		for _, backupSpec := range backupSpecs {
+			assert.Nil(t, repo.Save(backupSpec))
+		}
+
+		all, err := repo.GetAll("bigquery")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(all))
+		// most recent first
+		assert.Equal(t, backupSpecs[1].ID, all[0].ID)
+
+		other, err := repo.GetAll("gcs")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(other))
+	})
+}
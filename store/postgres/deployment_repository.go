@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+)
+
+type Deployment struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	ProjectID uuid.UUID `gorm:"not null"`
+
+	NamespaceID uuid.UUID `gorm:"not null"`
+	Namespace   Namespace `gorm:"foreignKey:NamespaceID"`
+
+	Status      string `gorm:"not null"`
+	Message     string
+	RequestedBy string
+	JobCount    int
+	Jobs        datatypes.JSON
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+func (d Deployment) FromSpec(spec models.DeploymentSpec) (Deployment, error) {
+	jsonBytes, err := json.Marshal(spec.Jobs)
+	if err != nil {
+		return Deployment{}, err
+	}
+	return Deployment{
+		ID:          spec.ID,
+		ProjectID:   spec.Namespace.ProjectSpec.ID,
+		NamespaceID: spec.Namespace.ID,
+		Status:      spec.Status,
+		Message:     spec.Message,
+		RequestedBy: spec.RequestedBy,
+		JobCount:    len(spec.Jobs),
+		Jobs:        jsonBytes,
+		CreatedAt:   spec.CreatedAt.UTC(),
+		UpdatedAt:   spec.UpdatedAt.UTC(),
+	}, nil
+}
+
+func (d Deployment) ToSpec(project models.ProjectSpec) (models.DeploymentSpec, error) {
+	var jobs []models.JobDeployment
+	if len(d.Jobs) > 0 {
+		if err := json.Unmarshal(d.Jobs, &jobs); err != nil {
+			return models.DeploymentSpec{}, err
+		}
+	}
+	namespace, err := d.Namespace.ToSpec(project)
+	if err != nil {
+		return models.DeploymentSpec{}, err
+	}
+	return models.DeploymentSpec{
+		ID:          d.ID,
+		Namespace:   namespace,
+		Status:      d.Status,
+		Message:     d.Message,
+		RequestedBy: d.RequestedBy,
+		Jobs:        jobs,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}, nil
+}
+
+type deploymentRepository struct {
+	DB      *gorm.DB
+	project models.ProjectSpec
+}
+
+func (repo *deploymentRepository) Save(spec models.DeploymentSpec) error {
+	d, err := Deployment{}.FromSpec(spec)
+	if err != nil {
+		return err
+	}
+	// creates the record if it doesn't exist yet
+	return repo.DB.Save(&d).Error
+}
+
+func (repo *deploymentRepository) GetByID(id uuid.UUID) (models.DeploymentSpec, error) {
+	var d Deployment
+	if err := repo.DB.Preload("Namespace").Where("project_id = ? AND id = ?", repo.project.ID, id).First(&d).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DeploymentSpec{}, store.ErrResourceNotFound
+		}
+		return models.DeploymentSpec{}, err
+	}
+	return d.ToSpec(repo.project)
+}
+
+func (repo *deploymentRepository) GetAll(filter models.DeploymentFilter) ([]models.DeploymentSpec, error) {
+	query := repo.DB.Preload("Namespace").Where("deployment.project_id = ?", repo.project.ID)
+	if filter.Namespace != "" {
+		query = query.Joins("JOIN namespace ON namespace.id = deployment.namespace_id").
+			Where("namespace.name = ?", filter.Namespace)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("deployment.created_at >= ?", filter.Since.UTC())
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var deployments []Deployment
+	if err := query.Order("deployment.created_at desc").Find(&deployments).Error; err != nil {
+		return nil, err
+	}
+
+	var specs []models.DeploymentSpec
+	for _, d := range deployments {
+		spec, err := d.ToSpec(repo.project)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func NewDeploymentRepository(db *gorm.DB, project models.ProjectSpec) *deploymentRepository {
+	return &deploymentRepository{
+		DB:      db,
+		project: project,
+	}
+}
@@ -0,0 +1,153 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentRepository(t *testing.T) {
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		return dbConn
+	}
+
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus",
+		Config: map[string]string{
+			"bucket": "gs://some_folder",
+		},
+	}
+	namespaceSpecs := []models.NamespaceSpec{
+		{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			Config:      map[string]string{},
+			ProjectSpec: projectSpec,
+		},
+		{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-2",
+			Config:      map[string]string{},
+			ProjectSpec: projectSpec,
+		},
+	}
+	createdAt := time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)
+	deploymentSpecs := []models.DeploymentSpec{
+		{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Namespace:   namespaceSpecs[0],
+			Status:      models.DeploymentStatusSuccess,
+			RequestedBy: "alice",
+			Jobs: []models.JobDeployment{
+				{Name: "job-1", Status: models.JobDeploymentStatusDeployed},
+				{Name: "job-2", Status: models.JobDeploymentStatusSkipped},
+			},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt.Add(time.Minute),
+		},
+		{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Namespace:   namespaceSpecs[1],
+			Status:      models.DeploymentStatusFailed,
+			Message:     "scheduler down",
+			RequestedBy: "bob",
+			Jobs: []models.JobDeployment{
+				{Name: "job-3", Status: models.JobDeploymentStatusReverted},
+			},
+			CreatedAt: createdAt.Add(time.Hour),
+			UpdatedAt: createdAt.Add(time.Hour + time.Minute),
+		},
+	}
+
+	setupProject := func(t *testing.T, db *gorm.DB) {
+		projRepo := NewProjectRepository(db, hash)
+		assert.Nil(t, projRepo.Save(projectSpec))
+		namespaceRepo := NewNamespaceRepository(db, projectSpec, hash)
+		for _, namespaceSpec := range namespaceSpecs {
+			assert.Nil(t, namespaceRepo.Insert(namespaceSpec))
+		}
+	}
+
+	t.Run("Save and GetByID", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		setupProject(t, db)
+
+		repo := NewDeploymentRepository(db, projectSpec)
+		inProgress := deploymentSpecs[0]
+		inProgress.Status = models.DeploymentStatusInProgress
+		assert.Nil(t, repo.Save(inProgress))
+		assert.Nil(t, repo.Save(deploymentSpecs[0]))
+
+		checkModel, err := repo.GetByID(deploymentSpecs[0].ID)
+		assert.Nil(t, err)
+		assert.Equal(t, models.DeploymentStatusSuccess, checkModel.Status)
+		assert.Equal(t, "alice", checkModel.RequestedBy)
+		assert.Equal(t, namespaceSpecs[0].Name, checkModel.Namespace.Name)
+		assert.Equal(t, deploymentSpecs[0].Jobs, checkModel.Jobs)
+		assert.Equal(t, time.Minute, checkModel.Duration())
+
+		_, err = repo.GetByID(uuid.Must(uuid.NewRandom()))
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		setupProject(t, db)
+
+		repo := NewDeploymentRepository(db, projectSpec)
+		for _, deploymentSpec := range deploymentSpecs {
+			assert.Nil(t, repo.Save(deploymentSpec))
+		}
+
+		all, err := repo.GetAll(models.DeploymentFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(all))
+		// most recent first
+		assert.Equal(t, deploymentSpecs[1].ID, all[0].ID)
+
+		filtered, err := repo.GetAll(models.DeploymentFilter{Namespace: namespaceSpecs[0].Name})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(filtered))
+		assert.Equal(t, deploymentSpecs[0].ID, filtered[0].ID)
+
+		since, err := repo.GetAll(models.DeploymentFilter{Since: createdAt.Add(time.Minute)})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(since))
+		assert.Equal(t, deploymentSpecs[1].ID, since[0].ID)
+
+		limited, err := repo.GetAll(models.DeploymentFilter{Limit: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(limited))
+	})
+}
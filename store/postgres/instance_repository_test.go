@@ -98,7 +98,7 @@ func TestInstanceRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+// JobLineage is kept by names of project and job as lineage is read across
+// projects
+type JobLineage struct {
+	ProjectName   string `gorm:"primary_key"`
+	JobName       string `gorm:"primary_key"`
+	NamespaceName string
+	Destination   string
+
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// JobLineageDependency is a urn read by a job, one row per urn so jobs
+// reading a urn can be looked up by index
+type JobLineageDependency struct {
+	ProjectName string `gorm:"primary_key"`
+	JobName     string `gorm:"primary_key"`
+	Urn         string `gorm:"primary_key"`
+}
+
+func (l JobLineage) FromSpec(spec models.JobLineage) JobLineage {
+	return JobLineage{
+		ProjectName:   spec.ProjectName,
+		JobName:       spec.JobName,
+		NamespaceName: spec.NamespaceName,
+		Destination:   spec.Destination,
+		UpdatedAt:     spec.UpdatedAt.UTC(),
+	}
+}
+
+func (l JobLineage) ToSpec(dependencies []JobLineageDependency) models.JobLineage {
+	spec := models.JobLineage{
+		ProjectName:   l.ProjectName,
+		NamespaceName: l.NamespaceName,
+		JobName:       l.JobName,
+		Destination:   l.Destination,
+		UpdatedAt:     l.UpdatedAt,
+	}
+	for _, dependency := range dependencies {
+		spec.Dependencies = append(spec.Dependencies, dependency.Urn)
+	}
+	return spec
+}
+
+type jobLineageRepository struct {
+	db *gorm.DB
+}
+
+func (repo *jobLineageRepository) Save(spec models.JobLineage) error {
+	lineage := JobLineage{}.FromSpec(spec)
+	if lineage.UpdatedAt.IsZero() {
+		lineage.UpdatedAt = time.Now().UTC()
+	}
+
+	tx := repo.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := repo.delete(tx, spec.ProjectName, spec.JobName); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(&lineage).Error; err != nil {
+		tx.Rollback()
+		return errors.Wrapf(err, "failed to save lineage of job %s", spec.JobName)
+	}
+	seen := map[string]bool{}
+	for _, urn := range spec.Dependencies {
+		if seen[urn] {
+			continue
+		}
+		seen[urn] = true
+		if err := tx.Create(&JobLineageDependency{
+			ProjectName: spec.ProjectName,
+			JobName:     spec.JobName,
+			Urn:         urn,
+		}).Error; err != nil {
+			tx.Rollback()
+			return errors.Wrapf(err, "failed to save lineage of job %s", spec.JobName)
+		}
+	}
+	return tx.Commit().Error
+}
+
+func (repo *jobLineageRepository) Delete(projectName, jobName string) error {
+	tx := repo.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := repo.delete(tx, projectName, jobName); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (repo *jobLineageRepository) delete(db *gorm.DB, projectName, jobName string) error {
+	if err := db.Where("project_name = ? AND job_name = ?", projectName, jobName).Delete(&JobLineageDependency{}).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete lineage of job %s", jobName)
+	}
+	if err := db.Where("project_name = ? AND job_name = ?", projectName, jobName).Delete(&JobLineage{}).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete lineage of job %s", jobName)
+	}
+	return nil
+}
+
+func (repo *jobLineageRepository) GetByJob(projectName, jobName string) (models.JobLineage, error) {
+	var lineage JobLineage
+	if err := repo.db.Where("project_name = ? AND job_name = ?", projectName, jobName).First(&lineage).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.JobLineage{}, store.ErrResourceNotFound
+		}
+		return models.JobLineage{}, err
+	}
+	specs, err := repo.withDependencies([]JobLineage{lineage})
+	if err != nil {
+		return models.JobLineage{}, err
+	}
+	return specs[0], nil
+}
+
+func (repo *jobLineageRepository) GetByDestination(urn string) ([]models.JobLineage, error) {
+	var lineages []JobLineage
+	if err := repo.db.Where("destination = ?", urn).Order("project_name, job_name").Find(&lineages).Error; err != nil {
+		return nil, err
+	}
+	return repo.withDependencies(lineages)
+}
+
+func (repo *jobLineageRepository) GetByDependency(urn string) ([]models.JobLineage, error) {
+	var lineages []JobLineage
+	if err := repo.db.Select("job_lineage.*").
+		Joins("JOIN job_lineage_dependency dep ON dep.project_name = job_lineage.project_name AND dep.job_name = job_lineage.job_name").
+		Where("dep.urn = ?", urn).Order("job_lineage.project_name, job_lineage.job_name").Find(&lineages).Error; err != nil {
+		return nil, err
+	}
+	return repo.withDependencies(lineages)
+}
+
+// withDependencies reads urns each of the jobs depends on
+func (repo *jobLineageRepository) withDependencies(lineages []JobLineage) ([]models.JobLineage, error) {
+	var specs []models.JobLineage
+	for _, lineage := range lineages {
+		var dependencies []JobLineageDependency
+		if err := repo.db.Where("project_name = ? AND job_name = ?", lineage.ProjectName, lineage.JobName).
+			Order("urn").Find(&dependencies).Error; err != nil {
+			return nil, err
+		}
+		specs = append(specs, lineage.ToSpec(dependencies))
+	}
+	return specs, nil
+}
+
+func NewJobLineageRepository(db *gorm.DB) *jobLineageRepository {
+	return &jobLineageRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,121 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobLineageRepository(t *testing.T) {
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		return dbConn
+	}
+
+	lineages := []models.JobLineage{
+		{
+			ProjectName:   "proj-a",
+			NamespaceName: "ns-1",
+			JobName:       "ingest",
+			Destination:   "bigquery://proj-a:sales.clean",
+			Dependencies:  []string{"bigquery://proj-a:sales.raw", "bigquery://proj-a:sales.raw"},
+		},
+		{
+			ProjectName:   "proj-b",
+			NamespaceName: "ns-2",
+			JobName:       "report",
+			Destination:   "bigquery://proj-b:bi.report",
+			Dependencies:  []string{"bigquery://proj-a:sales.clean", "bigquery://proj-a:sales.raw"},
+		},
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("should replace lineage saved before", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			repo := NewJobLineageRepository(db)
+
+			assert.Nil(t, repo.Save(lineages[1]))
+			updated := lineages[1]
+			updated.Dependencies = []string{"bigquery://proj-a:sales.clean"}
+			assert.Nil(t, repo.Save(updated))
+
+			lineage, err := repo.GetByJob("proj-b", "report")
+			assert.Nil(t, err)
+			assert.Equal(t, "ns-2", lineage.NamespaceName)
+			assert.Equal(t, []string{"bigquery://proj-a:sales.clean"}, lineage.Dependencies)
+			assert.False(t, lineage.UpdatedAt.IsZero())
+		})
+	})
+	t.Run("GetByDestination", func(t *testing.T) {
+		t.Run("should return jobs writing urn", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			repo := NewJobLineageRepository(db)
+			for _, lineage := range lineages {
+				assert.Nil(t, repo.Save(lineage))
+			}
+
+			found, err := repo.GetByDestination("bigquery://proj-a:sales.clean")
+			assert.Nil(t, err)
+			assert.Len(t, found, 1)
+			assert.Equal(t, "ingest", found[0].JobName)
+			assert.Equal(t, []string{"bigquery://proj-a:sales.raw"}, found[0].Dependencies)
+		})
+	})
+	t.Run("GetByDependency", func(t *testing.T) {
+		t.Run("should return jobs reading urn across projects", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			repo := NewJobLineageRepository(db)
+			for _, lineage := range lineages {
+				assert.Nil(t, repo.Save(lineage))
+			}
+
+			found, err := repo.GetByDependency("bigquery://proj-a:sales.raw")
+			assert.Nil(t, err)
+			assert.Len(t, found, 2)
+			assert.Equal(t, "proj-a", found[0].ProjectName)
+			assert.Equal(t, "proj-b", found[1].ProjectName)
+		})
+	})
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("should forget lineage of job", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			repo := NewJobLineageRepository(db)
+			assert.Nil(t, repo.Save(lineages[0]))
+
+			assert.Nil(t, repo.Delete("proj-a", "ingest"))
+			_, err := repo.GetByJob("proj-a", "ingest")
+			assert.Equal(t, store.ErrResourceNotFound, err)
+			found, err := repo.GetByDependency("bigquery://proj-a:sales.raw")
+			assert.Nil(t, err)
+			assert.Empty(t, found)
+		})
+	})
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+type JobRun struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	ProjectID uuid.UUID `gorm:"not null"`
+
+	NamespaceID uuid.UUID `gorm:"not null"`
+	Namespace   Namespace `gorm:"foreignKey:NamespaceID"`
+
+	JobName     string    `gorm:"not null"`
+	ScheduledAt time.Time `gorm:"not null"`
+	State       string    `gorm:"not null"`
+	StartTime   *time.Time
+	EndTime     *time.Time
+	Attempt     int
+	SLAMissedAt *time.Time
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+func (r JobRun) FromSpec(spec models.JobRun) JobRun {
+	return JobRun{
+		ID:          spec.ID,
+		ProjectID:   spec.Namespace.ProjectSpec.ID,
+		NamespaceID: spec.Namespace.ID,
+		JobName:     spec.JobName,
+		ScheduledAt: spec.ScheduledAt.UTC(),
+		State:       spec.State.String(),
+		StartTime:   utcTimeOrNil(spec.StartTime),
+		EndTime:     utcTimeOrNil(spec.EndTime),
+		Attempt:     spec.Attempt,
+		SLAMissedAt: utcTimeOrNil(spec.SLAMissedAt),
+		CreatedAt:   spec.CreatedAt.UTC(),
+		UpdatedAt:   spec.UpdatedAt.UTC(),
+	}
+}
+
+func (r JobRun) ToSpec(project models.ProjectSpec) (models.JobRun, error) {
+	namespace, err := r.Namespace.ToSpec(project)
+	if err != nil {
+		return models.JobRun{}, err
+	}
+	run := models.JobRun{
+		ID:          r.ID,
+		JobName:     r.JobName,
+		Namespace:   namespace,
+		ScheduledAt: r.ScheduledAt.UTC(),
+		State:       models.JobStatusState(r.State),
+		Attempt:     r.Attempt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.StartTime != nil {
+		run.StartTime = r.StartTime.UTC()
+	}
+	if r.EndTime != nil {
+		run.EndTime = r.EndTime.UTC()
+	}
+	if r.SLAMissedAt != nil {
+		run.SLAMissedAt = r.SLAMissedAt.UTC()
+	}
+	return run, nil
+}
+
+func utcTimeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+type jobRunRepository struct {
+	db      *gorm.DB
+	project models.ProjectSpec
+}
+
+func (repo *jobRunRepository) Save(spec models.JobRun) error {
+	var existing JobRun
+	err := repo.db.Where("project_id = ? AND job_name = ? AND scheduled_at = ?", repo.project.ID, spec.JobName,
+		spec.ScheduledAt.UTC()).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		run := JobRun{}.FromSpec(spec)
+		if run.ID == uuid.Nil {
+			run.ID = uuid.New()
+		}
+		return repo.db.Create(&run).Error
+	} else if err != nil {
+		return errors.Wrap(err, "unable to find job run")
+	}
+	return repo.db.Model(&existing).Updates(map[string]interface{}{
+		"namespace_id":  spec.Namespace.ID,
+		"state":         spec.State.String(),
+		"start_time":    utcTimeOrNil(spec.StartTime),
+		"end_time":      utcTimeOrNil(spec.EndTime),
+		"attempt":       spec.Attempt,
+		"sla_missed_at": utcTimeOrNil(spec.SLAMissedAt),
+		"updated_at":    spec.UpdatedAt.UTC(),
+	}).Error
+}
+
+func (repo *jobRunRepository) filter(filter models.JobRunFilter) *gorm.DB {
+	query := repo.db.Where("project_id = ?", repo.project.ID)
+	if filter.JobName != "" {
+		query = query.Where("job_name = ?", filter.JobName)
+	}
+	if !filter.StartDate.IsZero() {
+		query = query.Where("scheduled_at >= ?", filter.StartDate.UTC())
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("scheduled_at <= ?", filter.EndDate.UTC())
+	}
+	if len(filter.States) > 0 {
+		var states []string
+		for _, state := range filter.States {
+			states = append(states, state.String())
+		}
+		query = query.Where("state IN (?)", states)
+	}
+	return query
+}
+
+func (repo *jobRunRepository) GetAll(filter models.JobRunFilter) ([]models.JobRun, error) {
+	query := repo.filter(filter).Preload("Namespace")
+	if filter.Limit > 0 {
+		query = query.Order("scheduled_at desc").Limit(filter.Limit)
+	} else {
+		query = query.Order("scheduled_at")
+	}
+	var runs []JobRun
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	if filter.Limit > 0 {
+		// latest runs are read first, they are returned oldest first
+		for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+			runs[i], runs[j] = runs[j], runs[i]
+		}
+	}
+
+	var specs []models.JobRun
+	for _, run := range runs {
+		spec, err := run.ToSpec(repo.project)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func (repo *jobRunRepository) CountByState(filter models.JobRunFilter) (map[models.JobStatusState]int, error) {
+	var rows []struct {
+		State string
+		Count int
+	}
+	if err := repo.filter(filter).Model(&JobRun{}).Select("state, count(*) AS count").Group("state").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := map[models.JobStatusState]int{}
+	for _, row := range rows {
+		counts[models.JobStatusState(row.State)] = row.Count
+	}
+	return counts, nil
+}
+
+func NewJobRunRepository(db *gorm.DB, project models.ProjectSpec) *jobRunRepository {
+	return &jobRunRepository{
+		db:      db,
+		project: project,
+	}
+}
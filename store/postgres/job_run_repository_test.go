@@ -0,0 +1,176 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobRunRepository(t *testing.T) {
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		return dbConn
+	}
+
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus",
+		Config: map[string]string{
+			"bucket": "gs://some_folder",
+		},
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		Config:      map[string]string{},
+		ProjectSpec: projectSpec,
+	}
+	scheduledAt := time.Date(2021, 1, 15, 2, 0, 0, 0, time.UTC)
+	polledAt := time.Date(2021, 1, 15, 3, 0, 0, 0, time.UTC)
+	jobRuns := []models.JobRun{
+		{
+			JobName:     "job-1",
+			Namespace:   namespaceSpec,
+			ScheduledAt: scheduledAt,
+			State:       models.JobStatusStateSuccess,
+			CreatedAt:   polledAt,
+			UpdatedAt:   polledAt,
+		},
+		{
+			JobName:     "job-1",
+			Namespace:   namespaceSpec,
+			ScheduledAt: scheduledAt.Add(time.Hour * 24),
+			State:       models.JobStatusStateFailed,
+			CreatedAt:   polledAt,
+			UpdatedAt:   polledAt,
+		},
+		{
+			JobName:     "job-2",
+			Namespace:   namespaceSpec,
+			ScheduledAt: scheduledAt,
+			State:       models.JobStatusStateRunning,
+			CreatedAt:   polledAt,
+			UpdatedAt:   polledAt,
+		},
+	}
+
+	setupProject := func(t *testing.T, db *gorm.DB) {
+		projRepo := NewProjectRepository(db, hash)
+		assert.Nil(t, projRepo.Save(projectSpec))
+		namespaceRepo := NewNamespaceRepository(db, projectSpec, hash)
+		assert.Nil(t, namespaceRepo.Insert(namespaceSpec))
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		setupProject(t, db)
+
+		repo := NewJobRunRepository(db, projectSpec)
+		running := jobRuns[0]
+		running.State = models.JobStatusStateRunning
+		assert.Nil(t, repo.Save(running))
+
+		// run of the same schedule is updated
+		succeeded := jobRuns[0]
+		succeeded.StartTime = scheduledAt.Add(time.Minute)
+		succeeded.EndTime = scheduledAt.Add(time.Minute * 30)
+		succeeded.Attempt = 2
+		succeeded.UpdatedAt = polledAt.Add(time.Hour)
+		assert.Nil(t, repo.Save(succeeded))
+
+		all, err := repo.GetAll(models.JobRunFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(all))
+		assert.Equal(t, models.JobStatusStateSuccess, all[0].State)
+		assert.Equal(t, succeeded.StartTime, all[0].StartTime)
+		assert.Equal(t, succeeded.EndTime, all[0].EndTime)
+		assert.Equal(t, 2, all[0].Attempt)
+		assert.Equal(t, scheduledAt, all[0].ScheduledAt)
+		assert.Equal(t, namespaceSpec.Name, all[0].Namespace.Name)
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		setupProject(t, db)
+
+		repo := NewJobRunRepository(db, projectSpec)
+		for _, jobRun := range jobRuns {
+			assert.Nil(t, repo.Save(jobRun))
+		}
+
+		byJob, err := repo.GetAll(models.JobRunFilter{JobName: "job-1"})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(byJob))
+		// oldest schedule first
+		assert.Equal(t, models.JobStatusStateSuccess, byJob[0].State)
+		assert.Equal(t, models.JobStatusStateFailed, byJob[1].State)
+
+		between, err := repo.GetAll(models.JobRunFilter{
+			JobName:   "job-1",
+			StartDate: scheduledAt.Add(time.Hour),
+			EndDate:   scheduledAt.Add(time.Hour * 24),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(between))
+		assert.Equal(t, jobRuns[1].ScheduledAt, between[0].ScheduledAt)
+
+		// latest runs are read, still oldest first
+		latest, err := repo.GetAll(models.JobRunFilter{JobName: "job-1", Limit: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(latest))
+		assert.Equal(t, jobRuns[1].ScheduledAt, latest[0].ScheduledAt)
+	})
+
+	t.Run("CountByState", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		setupProject(t, db)
+
+		repo := NewJobRunRepository(db, projectSpec)
+		for _, jobRun := range jobRuns {
+			assert.Nil(t, repo.Save(jobRun))
+		}
+
+		counts, err := repo.CountByState(models.JobRunFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, map[models.JobStatusState]int{
+			models.JobStatusStateSuccess: 1,
+			models.JobStatusStateFailed:  1,
+			models.JobStatusStateRunning: 1,
+		}, counts)
+
+		finished, err := repo.GetAll(models.JobRunFilter{
+			States: []models.JobStatusState{models.JobStatusStateSuccess, models.JobStatusStateFailed},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(finished))
+	})
+}
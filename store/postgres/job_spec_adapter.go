@@ -23,9 +23,18 @@ type Job struct {
 	StartDate    time.Time
 	EndDate      *time.Time
 	Interval     string
+	Timezone     string
+	Trigger      datatypes.JSON
 	Destination  string
 	Dependencies datatypes.JSON
-	Behavior     datatypes.JSON
+	// HTTPDependencies are checks on endpoints outside optimus
+	HTTPDependencies datatypes.JSON
+	// ExternalDependencies are jobs of other optimus servers
+	ExternalDependencies datatypes.JSON
+	// ResourceDependencies are resources waited for through jobs writing them
+	ResourceDependencies datatypes.JSON
+	Behavior             datatypes.JSON
+	Runtime              datatypes.JSON
 
 	ProjectID uuid.UUID
 	Project   Project `gorm:"foreignKey:ProjectID"`
@@ -34,14 +43,22 @@ type Job struct {
 	Namespace   Namespace `gorm:"foreignKey:NamespaceID"`
 
 	TaskName         string
+	TaskVersion      string
 	TaskConfig       datatypes.JSON
 	WindowSize       *int64 //duration in nanos
 	WindowOffset     *int64
 	WindowTruncateTo *string
+	WindowModifier   *string
+	TaskResource     datatypes.JSON
 
 	Assets datatypes.JSON
 	Hooks  datatypes.JSON
 
+	// Paused jobs are not scheduled
+	Paused bool
+	// Revision is incremented on every update of the job
+	Revision int
+
 	CreatedAt time.Time `gorm:"not null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
 	DeletedAt *time.Time
@@ -86,13 +103,21 @@ func (a JobAsset) FromSpec(spec models.JobSpecAsset) JobAsset {
 }
 
 type JobHook struct {
-	Name   string
-	Config datatypes.JSON
+	Name     string
+	Version  string
+	Config   datatypes.JSON
+	Resource models.JobSpecResource
 }
 
 // ToSpec converts the postgres' JobHook representation to the optimus' models.JobSpecHook
 func (a JobHook) ToSpec(pluginRepo models.PluginRepository) (models.JobSpecHook, error) {
-	hookUnit, err := pluginRepo.GetByName(a.Name)
+	var hookUnit *models.Plugin
+	var err error
+	if a.Version != "" {
+		hookUnit, err = pluginRepo.GetByNameAndVersion(a.Name, a.Version)
+	} else {
+		hookUnit, err = pluginRepo.GetByName(a.Name)
+	}
 	if err != nil {
 		return models.JobSpecHook{}, errors.Wrap(err, "spec reading error")
 	}
@@ -103,8 +128,10 @@ func (a JobHook) ToSpec(pluginRepo models.PluginRepository) (models.JobSpecHook,
 	}
 
 	return models.JobSpecHook{
-		Config: conf,
-		Unit:   hookUnit,
+		Config:   conf,
+		Unit:     hookUnit,
+		Version:  a.Version,
+		Resource: a.Resource,
 	}, nil
 }
 
@@ -114,8 +141,10 @@ func (a JobHook) FromSpec(spec models.JobSpecHook) (JobHook, error) {
 		return JobHook{}, err
 	}
 	return JobHook{
-		Name:   spec.Unit.Info().Name,
-		Config: configJSON,
+		Name:     spec.Unit.Info().Name,
+		Version:  spec.Version,
+		Config:   configJSON,
+		Resource: spec.Resource,
 	}, nil
 }
 
@@ -181,7 +210,13 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		jobHooks = append(jobHooks, hookSpec)
 	}
 
-	execUnit, err := adapt.pluginRepo.GetByName(conf.TaskName)
+	var execUnit *models.Plugin
+	var err error
+	if conf.TaskVersion != "" {
+		execUnit, err = adapt.pluginRepo.GetByNameAndVersion(conf.TaskName, conf.TaskVersion)
+	} else {
+		execUnit, err = adapt.pluginRepo.GetByName(conf.TaskName)
+	}
 	if err != nil {
 		return models.JobSpec{}, errors.Wrap(err, "spec reading error")
 	}
@@ -195,6 +230,54 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		})
 	}
 
+	var httpDependencies []models.JobSpecHTTPDependency
+	if len(conf.HTTPDependencies) > 0 {
+		if err := json.Unmarshal(conf.HTTPDependencies, &httpDependencies); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
+	var externalDependencies []models.JobSpecExternalDependency
+	if len(conf.ExternalDependencies) > 0 {
+		if err := json.Unmarshal(conf.ExternalDependencies, &externalDependencies); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
+	var resourceDependencies []models.JobSpecResourceDependency
+	if len(conf.ResourceDependencies) > 0 {
+		if err := json.Unmarshal(conf.ResourceDependencies, &resourceDependencies); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
+	var runtime models.JobSpecRuntime
+	if len(conf.Runtime) > 0 {
+		if err := json.Unmarshal(conf.Runtime, &runtime); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
+	var taskResource models.JobSpecResource
+	if len(conf.TaskResource) > 0 {
+		if err := json.Unmarshal(conf.TaskResource, &taskResource); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
+	var trigger *models.JobSpecTrigger
+	if len(conf.Trigger) > 0 {
+		if err := json.Unmarshal(conf.Trigger, &trigger); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
+	// modifier is not set for jobs saved before it was introduced
+	var windowModifier string
+	if conf.WindowModifier != nil {
+		windowModifier = *conf.WindowModifier
+	}
+
 	job := models.JobSpec{
 		ID:          conf.ID,
 		Version:     conf.Version,
@@ -206,6 +289,8 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 			StartDate: conf.StartDate,
 			EndDate:   conf.EndDate,
 			Interval:  conf.Interval,
+			Timezone:  conf.Timezone,
+			Trigger:   trigger,
 		},
 		Behavior: models.JobSpecBehavior{
 			DependsOnPast: behavior.DependsOnPast,
@@ -218,17 +303,26 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 			Notify: notifiers,
 		},
 		Task: models.JobSpecTask{
-			Unit:   execUnit,
-			Config: taskConf,
+			Unit:    execUnit,
+			Version: conf.TaskVersion,
+			Config:  taskConf,
 			Window: models.JobSpecTaskWindow{
 				Size:       time.Duration(*conf.WindowSize),
 				Offset:     time.Duration(*conf.WindowOffset),
 				TruncateTo: *conf.WindowTruncateTo,
+				Modifier:   windowModifier,
 			},
+			Resource: taskResource,
 		},
-		Assets:       *(models.JobAssets{}).New(jobAssets),
-		Dependencies: dependencies,
-		Hooks:        jobHooks,
+		Assets:               *(models.JobAssets{}).New(jobAssets),
+		Dependencies:         dependencies,
+		Hooks:                jobHooks,
+		Runtime:              runtime,
+		HTTPDependencies:     httpDependencies,
+		ExternalDependencies: externalDependencies,
+		ResourceDependencies: resourceDependencies,
+		Paused:               conf.Paused,
+		Revision:             conf.Revision,
 	}
 	return job, nil
 }
@@ -307,6 +401,44 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 		return Job{}, err
 	}
 
+	var triggerJSON datatypes.JSON
+	if spec.Schedule.Trigger != nil {
+		if triggerJSON, err = json.Marshal(spec.Schedule.Trigger); err != nil {
+			return Job{}, err
+		}
+	}
+
+	taskResourceJSON, err := json.Marshal(spec.Task.Resource)
+	if err != nil {
+		return Job{}, err
+	}
+
+	runtimeJSON, err := json.Marshal(spec.Runtime)
+	if err != nil {
+		return Job{}, err
+	}
+
+	var httpDependenciesJSON datatypes.JSON
+	if len(spec.HTTPDependencies) > 0 {
+		if httpDependenciesJSON, err = json.Marshal(spec.HTTPDependencies); err != nil {
+			return Job{}, err
+		}
+	}
+
+	var externalDependenciesJSON datatypes.JSON
+	if len(spec.ExternalDependencies) > 0 {
+		if externalDependenciesJSON, err = json.Marshal(spec.ExternalDependencies); err != nil {
+			return Job{}, err
+		}
+	}
+
+	var resourceDependenciesJSON datatypes.JSON
+	if len(spec.ResourceDependencies) > 0 {
+		if resourceDependenciesJSON, err = json.Marshal(spec.ResourceDependencies); err != nil {
+			return Job{}, err
+		}
+	}
+
 	wsize := spec.Task.Window.Size.Nanoseconds()
 	woffset := spec.Task.Window.Offset.Nanoseconds()
 
@@ -323,25 +455,36 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 	}
 
 	return Job{
-		ID:               spec.ID,
-		Version:          spec.Version,
-		Name:             spec.Name,
-		Owner:            spec.Owner,
-		Description:      spec.Description,
-		Labels:           labelsJSON,
-		StartDate:        spec.Schedule.StartDate,
-		EndDate:          spec.Schedule.EndDate,
-		Interval:         spec.Schedule.Interval,
-		Behavior:         behaviorJSON,
-		Destination:      jobDestination,
-		Dependencies:     dependenciesJSON,
-		TaskName:         spec.Task.Unit.Info().Name,
-		TaskConfig:       taskConfigJSON,
-		WindowSize:       &wsize,
-		WindowOffset:     &woffset,
-		WindowTruncateTo: &spec.Task.Window.TruncateTo,
-		Assets:           assetsJSON,
-		Hooks:            hooksJSON,
+		ID:                   spec.ID,
+		Version:              spec.Version,
+		Name:                 spec.Name,
+		Owner:                spec.Owner,
+		Description:          spec.Description,
+		Labels:               labelsJSON,
+		StartDate:            spec.Schedule.StartDate,
+		EndDate:              spec.Schedule.EndDate,
+		Interval:             spec.Schedule.Interval,
+		Timezone:             spec.Schedule.Timezone,
+		Trigger:              triggerJSON,
+		Behavior:             behaviorJSON,
+		Destination:          jobDestination,
+		Dependencies:         dependenciesJSON,
+		HTTPDependencies:     httpDependenciesJSON,
+		ExternalDependencies: externalDependenciesJSON,
+		ResourceDependencies: resourceDependenciesJSON,
+		TaskName:             spec.Task.Unit.Info().Name,
+		TaskVersion:          spec.Task.Version,
+		TaskConfig:           taskConfigJSON,
+		WindowSize:           &wsize,
+		WindowOffset:         &woffset,
+		WindowTruncateTo:     &spec.Task.Window.TruncateTo,
+		WindowModifier:       &spec.Task.Window.Modifier,
+		TaskResource:         taskResourceJSON,
+		Runtime:              runtimeJSON,
+		Assets:               assetsJSON,
+		Hooks:                hooksJSON,
+		Paused:               spec.Paused,
+		Revision:             spec.Revision,
 	}, nil
 }
 
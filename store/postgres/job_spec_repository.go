@@ -1,13 +1,16 @@
 package postgres
 
 import (
-	"fmt"
+	"encoding/json"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
 	"github.com/pkg/errors"
+	"gorm.io/datatypes"
 )
 
 type ProjectJobSpecRepository struct {
@@ -85,6 +88,16 @@ func (repo *ProjectJobSpecRepository) GetByDestination(destination string) (mode
 	return jSpec, pSpec, err
 }
 
+// JobSpecVersion keeps a job as it was saved at a revision, spec holds
+// the job record encoded as json
+type JobSpecVersion struct {
+	ID        uuid.UUID `gorm:"primary_key;type:uuid"`
+	JobID     uuid.UUID `gorm:"not null"`
+	Revision  int       `gorm:"not null"`
+	Spec      datatypes.JSON
+	CreatedAt time.Time `gorm:"not null"`
+}
+
 type JobSpecRepository struct {
 	db                 *gorm.DB
 	namespace          models.NamespaceSpec
@@ -100,11 +113,15 @@ func (repo *JobSpecRepository) Insert(spec models.JobSpec) error {
 	if len(resource.Name) == 0 {
 		return errors.New("name cannot be empty")
 	}
+	resource.Revision = 1
 	// if soft deleted earlier
 	if err := repo.HardDelete(spec.Name); err != nil {
 		return err
 	}
-	return repo.db.Create(&resource).Error
+	if err := repo.db.Create(&resource).Error; err != nil {
+		return err
+	}
+	return repo.saveVersion(resource)
 }
 
 func (repo *JobSpecRepository) Save(spec models.JobSpec) error {
@@ -117,7 +134,11 @@ func (repo *JobSpecRepository) Save(spec models.JobSpec) error {
 	}
 
 	if namespaceSpec.ID != repo.namespace.ID {
-		return errors.New(fmt.Sprintf("job %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name))
+		return errors.Wrapf(store.ErrResourceAlreadyExists, "job %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name)
+	}
+
+	if spec.Revision != 0 && spec.Revision != existingJobSpec.Revision {
+		return revisionConflict(spec.Name, spec.Revision, existingJobSpec.Revision)
 	}
 
 	resource, err := repo.adapter.FromSpec(spec)
@@ -125,8 +146,373 @@ func (repo *JobSpecRepository) Save(spec models.JobSpec) error {
 		return err
 	}
 	resource.ID = existingJobSpec.ID
+	resource.Revision = existingJobSpec.Revision + 1
+
+	// revision is checked again while updating in case the job was saved
+	// after it was read
+	result := repo.db.Model(resource).Where("revision = ?", existingJobSpec.Revision).Updates(resource)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(store.ErrRevisionConflict, "job %s was saved after revision %d was read", spec.Name, existingJobSpec.Revision)
+	}
+	// zero values are skipped by Updates, so a resumed job is updated explicitly
+	if err := repo.db.Model(resource).Update("paused", resource.Paused).Error; err != nil {
+		return err
+	}
+	return repo.saveVersion(resource)
+}
+
+// jobUpsertColumns are the columns of job written by SaveAll, in the order
+// of values of each row
+var jobUpsertColumns = []string{
+	"id", "version", "name", "owner", "description", "labels", "start_date", "end_date", "interval", "timezone",
+	"trigger", "destination", "dependencies", "http_dependencies", "external_dependencies", "resource_dependencies",
+	"behavior", "runtime", "project_id", "namespace_id", "task_name", "task_version", "task_config", "window_size",
+	"window_offset", "window_truncate_to", "window_modifier", "task_resource", "assets", "hooks", "paused", "revision",
+	"created_at", "updated_at",
+}
+
+const (
+	// postgresDialect is the name of the gorm dialect of postgres, bulk
+	// upserts are only run on it
+	postgresDialect = "postgres"
+
+	// postgresMaxQueryParams is the most bind parameters postgres accepts
+	// in a query
+	postgresMaxQueryParams = 65535
+	// sqliteMaxQueryParams is the most bind parameters sqlite accepts in a
+	// query unless it is built with a higher SQLITE_MAX_VARIABLE_NUMBER
+	sqliteMaxQueryParams = 999
+)
+
+// maxQueryParams returns the most bind parameters the database of db
+// accepts in a query
+func maxQueryParams(db *gorm.DB) int {
+	if db.Dialect().GetName() == postgresDialect {
+		return postgresMaxQueryParams
+	}
+	return sqliteMaxQueryParams
+}
+
+// SaveAll saves specs in a single transaction, none of them are saved if
+// any fails. Jobs are written with bulk upserts on postgres and one by one
+// on sqlite which doesn't support RETURNING, a job saved by someone else
+// after it was read fails with store.ErrRevisionConflict
+func (repo *JobSpecRepository) SaveAll(specs []models.JobSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+	tx := repo.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := repo.withDB(tx).saveAll(specs); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (repo *JobSpecRepository) saveAll(specs []models.JobSpec) error {
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.Name
+	}
+
+	// jobs soft deleted earlier are created again, same as Insert
+	var deleted []Job
+	if err := repo.db.Unscoped().Where("project_id = ? AND name IN (?) AND deleted_at IS NOT NULL",
+		repo.namespace.ProjectSpec.ID, names).Find(&deleted).Error; err != nil {
+		return errors.Wrap(err, "failed to fetch soft deleted resource")
+	}
+	for _, r := range deleted {
+		if err := repo.checkReplaceable(r); err != nil {
+			return err
+		}
+		if err := repo.deleteJob(r.ID); err != nil {
+			return err
+		}
+	}
+
+	var existing []Job
+	if err := repo.db.Where("project_id = ? AND name IN (?)", repo.namespace.ProjectSpec.ID, names).
+		Find(&existing).Error; err != nil {
+		return errors.Wrap(err, "unable to retrieve specs by name")
+	}
+	existingByName := make(map[string]Job, len(existing))
+	for _, r := range existing {
+		existingByName[r.Name] = r
+	}
+
+	now := time.Now().UTC()
+	resources := make([]Job, len(specs))
+	for i, spec := range specs {
+		resource, err := repo.adapter.FromSpecWithNamespace(spec, repo.namespace)
+		if err != nil {
+			return errors.Wrapf(err, "failed to save job %s", spec.Name)
+		}
+		if len(resource.Name) == 0 {
+			return errors.New("name cannot be empty")
+		}
+		resource.CreatedAt, resource.UpdatedAt = now, now
+		if stored, ok := existingByName[spec.Name]; ok {
+			if stored.NamespaceID != repo.namespace.ID {
+				return errors.Wrapf(store.ErrResourceAlreadyExists, "job %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name)
+			}
+			if spec.Revision != 0 && spec.Revision != stored.Revision {
+				return revisionConflict(spec.Name, spec.Revision, stored.Revision)
+			}
+			resource.ID = stored.ID
+			resource.Revision = stored.Revision + 1
+		} else {
+			if resource.ID == uuid.Nil {
+				resource.ID = uuid.New()
+			}
+			resource.Revision = 1
+		}
+		resources[i] = resource
+	}
+
+	if repo.db.Dialect().GetName() == postgresDialect {
+		if err := repo.upsertAll(resources); err != nil {
+			return err
+		}
+	} else if err := repo.saveEach(resources); err != nil {
+		return err
+	}
+	return repo.saveVersions(resources)
+}
+
+// upsertAll writes jobs with bulk upserts, in as many queries as bind
+// parameters of postgres need
+func (repo *JobSpecRepository) upsertAll(resources []Job) error {
+	rowsPerQuery := maxQueryParams(repo.db) / len(jobUpsertColumns)
+	for start := 0; start < len(resources); start += rowsPerQuery {
+		end := start + rowsPerQuery
+		if end > len(resources) {
+			end = len(resources)
+		}
+		if err := repo.upsertJobs(resources[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveEach writes jobs one by one for databases without upserts returning
+// rows, stored jobs are only updated if they are still at the revision
+// before the one being written same as Save
+func (repo *JobSpecRepository) saveEach(resources []Job) error {
+	for _, resource := range resources {
+		if resource.Revision == 1 {
+			if err := repo.db.Create(&resource).Error; err != nil {
+				return errors.Wrapf(err, "failed to save job %s", resource.Name)
+			}
+			continue
+		}
 
-	return repo.db.Model(resource).Updates(resource).Error
+		// created_at of stored job is kept, zero values are skipped by Updates
+		resource.CreatedAt = time.Time{}
+		result := repo.db.Model(&resource).Where("revision = ?", resource.Revision-1).Updates(resource)
+		if result.Error != nil {
+			return errors.Wrapf(result.Error, "failed to save job %s", resource.Name)
+		}
+		if result.RowsAffected == 0 {
+			return errors.Wrapf(store.ErrRevisionConflict, "job %s was saved after revision %d was read", resource.Name, resource.Revision-1)
+		}
+		// zero values are skipped by Updates, so a resumed job is updated explicitly
+		if err := repo.db.Model(&resource).Update("paused", resource.Paused).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertJobs writes jobs in a single query, stored jobs are only updated if
+// they are still in the namespace at the revision before the one being
+// written, which catches jobs saved after they were read
+func (repo *JobSpecRepository) upsertJobs(resources []Job) error {
+	var query strings.Builder
+	query.WriteString("INSERT INTO job (" + strings.Join(jobUpsertColumns, ", ") + ") VALUES ")
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(jobUpsertColumns)), ", ") + ")"
+	args := make([]interface{}, 0, len(resources)*len(jobUpsertColumns))
+	for i, r := range resources {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(row)
+		args = append(args, r.ID, r.Version, r.Name, r.Owner, r.Description, r.Labels, r.StartDate, r.EndDate,
+			r.Interval, r.Timezone, r.Trigger, r.Destination, r.Dependencies, r.HTTPDependencies, r.ExternalDependencies,
+			r.ResourceDependencies, r.Behavior, r.Runtime, r.ProjectID, r.NamespaceID, r.TaskName, r.TaskVersion,
+			r.TaskConfig, r.WindowSize, r.WindowOffset, r.WindowTruncateTo, r.WindowModifier, r.TaskResource, r.Assets,
+			r.Hooks, r.Paused, r.Revision, r.CreatedAt, r.UpdatedAt)
+	}
+	query.WriteString(" ON CONFLICT (project_id, name) DO UPDATE SET ")
+	var updates []string
+	for _, column := range jobUpsertColumns {
+		switch column {
+		case "id", "project_id", "name", "created_at":
+			continue
+		}
+		updates = append(updates, column+" = EXCLUDED."+column)
+	}
+	query.WriteString(strings.Join(updates, ", "))
+	query.WriteString(" WHERE job.namespace_id = EXCLUDED.namespace_id AND job.deleted_at IS NULL" +
+		" AND job.revision = EXCLUDED.revision - 1 RETURNING name")
+
+	rows, err := repo.db.Raw(query.String(), args...).Rows()
+	if err != nil {
+		return errors.Wrap(err, "failed to save jobs")
+	}
+	defer rows.Close()
+	written := make(map[string]bool, len(resources))
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return errors.Wrap(err, "failed to save jobs")
+		}
+		written[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "failed to save jobs")
+	}
+	for _, r := range resources {
+		if !written[r.Name] {
+			return errors.Wrapf(store.ErrRevisionConflict, "job %s was saved after revision %d was read", r.Name, r.Revision-1)
+		}
+	}
+	return nil
+}
+
+// saveVersions keeps the jobs as they are saved at their revision, in a
+// single query for each batch of them
+func (repo *JobSpecRepository) saveVersions(resources []Job) error {
+	const columns = 5
+	now := time.Now().UTC()
+	rowsPerQuery := maxQueryParams(repo.db) / columns
+	for start := 0; start < len(resources); start += rowsPerQuery {
+		end := start + rowsPerQuery
+		if end > len(resources) {
+			end = len(resources)
+		}
+		var query strings.Builder
+		query.WriteString("INSERT INTO job_spec_version (id, job_id, revision, spec, created_at) VALUES ")
+		args := make([]interface{}, 0, (end-start)*columns)
+		for i, resource := range resources[start:end] {
+			resource.Project = Project{}
+			resource.Namespace = Namespace{}
+			encoded, err := json.Marshal(resource)
+			if err != nil {
+				return errors.Wrapf(err, "failed to encode version of job %s", resource.Name)
+			}
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteString("(?, ?, ?, ?, ?)")
+			args = append(args, uuid.New(), resource.ID, resource.Revision, datatypes.JSON(encoded), now)
+		}
+		if err := repo.db.Exec(query.String(), args...).Error; err != nil {
+			return errors.Wrap(err, "failed to save versions of jobs")
+		}
+	}
+	return nil
+}
+
+// withDB returns a copy of repository running queries on db, lookups of
+// project jobs run on the same db
+func (repo *JobSpecRepository) withDB(db *gorm.DB) *JobSpecRepository {
+	projectJobSpecRepo := repo.projectJobSpecRepo
+	if projectRepo, ok := projectJobSpecRepo.(*ProjectJobSpecRepository); ok {
+		projectJobSpecRepo = NewProjectJobSpecRepository(db, projectRepo.project, projectRepo.adapter)
+	}
+	return NewJobSpecRepository(db, repo.namespace, projectJobSpecRepo, repo.adapter)
+}
+
+// saveVersion keeps the job as it is saved at its revision
+func (repo *JobSpecRepository) saveVersion(resource Job) error {
+	resource.Project = Project{}
+	resource.Namespace = Namespace{}
+	encoded, err := json.Marshal(resource)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode version of job %s", resource.Name)
+	}
+	version := JobSpecVersion{
+		ID:       uuid.New(),
+		JobID:    resource.ID,
+		Revision: resource.Revision,
+		Spec:     encoded,
+	}
+	if err := repo.db.Create(&version).Error; err != nil {
+		return errors.Wrapf(err, "failed to save version of job %s", resource.Name)
+	}
+	return nil
+}
+
+// GetVersions lists versions of a job saved since it was created
+func (repo *JobSpecRepository) GetVersions(name string) ([]models.JobSpecVersion, error) {
+	var r Job
+	if err := repo.db.Where("namespace_id = ? AND name = ?", repo.namespace.ID, name).Find(&r).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrResourceNotFound
+		}
+		return nil, err
+	}
+
+	var versions []JobSpecVersion
+	if err := repo.db.Where("job_id = ?", r.ID).Order("revision desc").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	specs := make([]models.JobSpecVersion, 0, len(versions))
+	for _, version := range versions {
+		spec, err := repo.versionToSpec(version)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// GetVersion fetches a job as it was saved at revision
+func (repo *JobSpecRepository) GetVersion(name string, revision int) (models.JobSpecVersion, error) {
+	var r Job
+	if err := repo.db.Where("namespace_id = ? AND name = ?", repo.namespace.ID, name).Find(&r).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.JobSpecVersion{}, store.ErrResourceNotFound
+		}
+		return models.JobSpecVersion{}, err
+	}
+
+	var version JobSpecVersion
+	if err := repo.db.Where("job_id = ? AND revision = ?", r.ID, revision).Find(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.JobSpecVersion{}, store.ErrResourceNotFound
+		}
+		return models.JobSpecVersion{}, err
+	}
+	return repo.versionToSpec(version)
+}
+
+func (repo *JobSpecRepository) versionToSpec(version JobSpecVersion) (models.JobSpecVersion, error) {
+	var resource Job
+	if err := json.Unmarshal(version.Spec, &resource); err != nil {
+		return models.JobSpecVersion{}, errors.Wrapf(err, "failed to decode revision %d of job", version.Revision)
+	}
+	spec, err := repo.adapter.ToSpec(resource)
+	if err != nil {
+		return models.JobSpecVersion{}, err
+	}
+	return models.JobSpecVersion{
+		Revision:  version.Revision,
+		Spec:      spec,
+		CreatedAt: version.CreatedAt,
+	}, nil
+}
+
+func revisionConflict(name string, expected, stored int) error {
+	return errors.Wrapf(store.ErrRevisionConflict, "job %s is at revision %d, expected revision %d", name, stored, expected)
 }
 
 func (repo *JobSpecRepository) GetByID(id uuid.UUID) (models.JobSpec, error) {
@@ -157,6 +543,59 @@ func (repo *JobSpecRepository) Delete(name string) error {
 	return repo.db.Where("namespace_id = ? AND name = ?", repo.namespace.ID, name).Delete(&Job{}).Error
 }
 
+// Restore brings back a deleted job of the namespace, deleted jobs are
+// kept along with their instances till they are purged. It fails with
+// store.ErrResourceAlreadyExists if another namespace of the project has
+// taken the name since
+func (repo *JobSpecRepository) Restore(name string) error {
+	var existing Job
+	if err := repo.db.Where("project_id = ? AND namespace_id <> ? AND name = ?", repo.namespace.ProjectSpec.ID,
+		repo.namespace.ID, name).Find(&existing).Error; err == nil {
+		return errors.Wrapf(store.ErrResourceAlreadyExists, "job %s already exists for the project %s", name, repo.namespace.ProjectSpec.Name)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrap(err, "failed to fetch job of the project")
+	}
+
+	var r Job
+	if err := repo.db.Unscoped().Where("namespace_id = ? AND name = ? AND deleted_at IS NOT NULL", repo.namespace.ID, name).Find(&r).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return store.ErrResourceNotFound
+		}
+		return err
+	}
+	r.Revision++
+	if err := repo.db.Unscoped().Model(&r).Updates(map[string]interface{}{
+		"deleted_at": gorm.Expr("NULL"),
+		"revision":   r.Revision,
+	}).Error; err != nil {
+		return err
+	}
+	return repo.saveVersion(r)
+}
+
+// Purge permanently removes jobs of the namespace deleted before the given
+// time along with their instances and versions, names of the purged jobs
+// are returned
+func (repo *JobSpecRepository) Purge(deletedBefore time.Time) ([]string, error) {
+	var jobs []Job
+	if err := repo.db.Unscoped().Where("namespace_id = ? AND deleted_at < ?", repo.namespace.ID, deletedBefore).
+		Order("name").Find(&jobs).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to fetch deleted jobs")
+	}
+
+	var purged []string
+	for _, r := range jobs {
+		if err := repo.deleteJob(r.ID); err != nil {
+			return purged, errors.Wrapf(err, "failed to purge job %s", r.Name)
+		}
+		purged = append(purged, r.Name)
+	}
+	return purged, nil
+}
+
+// HardDelete permanently removes job of the namespace, deleted or not. A
+// job deleted from another namespace of the project is kept till it is
+// purged and fails with store.ErrResourceDeleted
 func (repo *JobSpecRepository) HardDelete(name string) error {
 	//find the base job
 	var r Job
@@ -166,11 +605,34 @@ func (repo *JobSpecRepository) HardDelete(name string) error {
 	} else if err != nil {
 		return errors.Wrap(err, "failed to fetch soft deleted resource")
 	}
-	// cascade delete instances
-	if err := repo.db.Unscoped().Where("job_id = ?", r.ID).Delete(&Instance{}).Error; err != nil {
+	if err := repo.checkReplaceable(r); err != nil {
+		return err
+	}
+	return repo.deleteJob(r.ID)
+}
+
+// checkReplaceable fails if a stored job of the project can't be replaced
+// by one of the namespace with the same name
+func (repo *JobSpecRepository) checkReplaceable(r Job) error {
+	if r.NamespaceID == repo.namespace.ID {
+		return nil
+	}
+	if r.DeletedAt != nil {
+		return errors.Wrapf(store.ErrResourceDeleted, "job %s is deleted from another namespace of the project %s "+
+			"and can be restored till it is purged", r.Name, repo.namespace.ProjectSpec.Name)
+	}
+	return errors.Wrapf(store.ErrResourceAlreadyExists, "job %s already exists for the project %s", r.Name, repo.namespace.ProjectSpec.Name)
+}
+
+// deleteJob permanently removes a job cascading to its instances and versions
+func (repo *JobSpecRepository) deleteJob(id uuid.UUID) error {
+	if err := repo.db.Unscoped().Where("job_id = ?", id).Delete(&Instance{}).Error; err != nil {
 		return errors.Wrap(err, "failed to cascade delete instances for the job")
 	}
-	return repo.db.Unscoped().Where("id = ?", r.ID).Delete(&Job{}).Error
+	if err := repo.db.Where("job_id = ?", id).Delete(&JobSpecVersion{}).Error; err != nil {
+		return errors.Wrap(err, "failed to cascade delete versions for the job")
+	}
+	return repo.db.Unscoped().Where("id = ?", id).Delete(&Job{}).Error
 }
 
 func (repo *JobSpecRepository) GetAll() ([]models.JobSpec, error) {
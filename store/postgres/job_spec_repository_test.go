@@ -12,6 +12,8 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,7 +23,7 @@ func TestJobRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -358,6 +360,59 @@ func TestJobRepository(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, "g-optimus-id", checkModel.Name)
 		})
+		t.Run("should save paused job and resume it", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			testModelA := testConfigs[0]
+			testModelA.Paused = true
+
+			projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+			repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+			err := repo.Save(testModelA)
+			assert.Nil(t, err)
+
+			checkModel, err := repo.GetByName(testModelA.Name)
+			assert.Nil(t, err)
+			assert.True(t, checkModel.Paused)
+
+			testModelA.Paused = false
+			err = repo.Save(testModelA)
+			assert.Nil(t, err)
+
+			checkModel, err = repo.GetByName(testModelA.Name)
+			assert.Nil(t, err)
+			assert.False(t, checkModel.Paused)
+		})
+		t.Run("should increment revision and reject saves of an outdated revision", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			testModelA := testConfigs[0]
+
+			projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+			repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+			err := repo.Save(testModelA)
+			assert.Nil(t, err)
+			checkModel, err := repo.GetByName(testModelA.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, checkModel.Revision)
+
+			testModelA.Revision = checkModel.Revision
+			testModelA.Owner = "optimus"
+			err = repo.Save(testModelA)
+			assert.Nil(t, err)
+			checkModel, err = repo.GetByName(testModelA.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, checkModel.Revision)
+
+			// saved again with the revision it was read at
+			err = repo.Save(testModelA)
+			assert.True(t, errors.Is(err, store.ErrRevisionConflict))
+			checkModel, err = repo.GetByName(testModelA.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, checkModel.Revision)
+		})
 		t.Run("should update same job with hooks when provided separately", func(t *testing.T) {
 			db := DBSetup()
 			defer db.Close()
@@ -481,7 +536,7 @@ func TestJobRepository(t *testing.T) {
 			// try to create same job with second namespace and it should fail.
 			err = jobRepoNamespace2.Save(testModelA)
 			assert.NotNil(t, err)
-			assert.Equal(t, "job g-optimus-id already exists for the project t-optimus-id", err.Error())
+			assert.Equal(t, "job g-optimus-id already exists for the project t-optimus-id: resource already exists", err.Error())
 		})
 		t.Run("should properly insert spec behavior, reading and writing", func(t *testing.T) {
 			db := DBSetup()
@@ -558,6 +613,149 @@ func TestJobRepository(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, 2, len(checkModels))
 	})
+
+	t.Run("Restore", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		testModels := []models.JobSpec{}
+		testModels = append(testModels, testConfigs...)
+
+		projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+		repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+		err := repo.Insert(testModels[0])
+		assert.Nil(t, err)
+		err = repo.Delete(testModels[0].Name)
+		assert.Nil(t, err)
+		_, err = repo.GetByName(testModels[0].Name)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+
+		err = repo.Restore(testModels[0].Name)
+		assert.Nil(t, err)
+		checkModel, err := repo.GetByName(testModels[0].Name)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, checkModel.Revision)
+
+		err = repo.Restore(testModels[0].Name)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+
+		// another namespace can't take the name while the job is restorable
+		err = repo.Delete(testModels[0].Name)
+		assert.Nil(t, err)
+		jobRepoNamespace2 := NewJobSpecRepository(db, namespaceSpec2, projectJobSpecRepo, adapter)
+		err = jobRepoNamespace2.Insert(testModels[0])
+		assert.True(t, errors.Is(err, store.ErrResourceDeleted))
+		err = jobRepoNamespace2.SaveAll([]models.JobSpec{testModels[0]})
+		assert.True(t, errors.Is(err, store.ErrResourceDeleted))
+
+		err = repo.Restore(testModels[0].Name)
+		assert.Nil(t, err)
+		versions, err := repo.GetVersions(testModels[0].Name)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(versions))
+	})
+
+	t.Run("SaveAll", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		testModels := []models.JobSpec{}
+		testModels = append(testModels, testConfigs...)
+
+		projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+		repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+		err := repo.Insert(testModels[0])
+		assert.Nil(t, err)
+
+		// outdated revision of the first job fails the whole batch
+		outdated := testModels[0]
+		outdated.Revision = 5
+		err = repo.SaveAll([]models.JobSpec{testModels[2], outdated})
+		assert.True(t, errors.Is(err, store.ErrRevisionConflict))
+		_, err = repo.GetByName(testModels[2].Name)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+
+		err = repo.SaveAll([]models.JobSpec{testModels[0], testModels[2]})
+		assert.Nil(t, err)
+		checkModels, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(checkModels))
+
+		checkModel, err := repo.GetByName(testModels[0].Name)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, checkModel.Revision)
+		versions, err := repo.GetVersions(testModels[0].Name)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(versions))
+
+		// jobs of other namespaces are not taken over
+		jobRepoNamespace2 := NewJobSpecRepository(db, namespaceSpec2, projectJobSpecRepo, adapter)
+		err = jobRepoNamespace2.SaveAll([]models.JobSpec{testModels[2]})
+		assert.True(t, errors.Is(err, store.ErrResourceAlreadyExists))
+	})
+
+	t.Run("GetVersions", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		testModels := []models.JobSpec{}
+		testModels = append(testModels, testConfigs...)
+
+		projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+		repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+		err := repo.Insert(testModels[0])
+		assert.Nil(t, err)
+		checkModel, err := repo.GetByName(testModels[0].Name)
+		assert.Nil(t, err)
+		checkModel.Owner = "optimus"
+		err = repo.Save(checkModel)
+		assert.Nil(t, err)
+
+		versions, err := repo.GetVersions(testModels[0].Name)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(versions))
+		assert.Equal(t, 2, versions[0].Revision)
+		assert.Equal(t, "optimus", versions[0].Spec.Owner)
+		assert.Equal(t, 1, versions[1].Revision)
+		assert.Equal(t, testModels[0].Owner, versions[1].Spec.Owner)
+
+		version, err := repo.GetVersion(testModels[0].Name, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, testModels[0].Owner, version.Spec.Owner)
+		_, err = repo.GetVersion(testModels[0].Name, 3)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		testModels := []models.JobSpec{}
+		testModels = append(testModels, testConfigs...)
+
+		projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+		repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+		err := repo.Insert(testModels[0])
+		assert.Nil(t, err)
+		err = repo.Insert(testModels[2])
+		assert.Nil(t, err)
+		err = repo.Delete(testModels[0].Name)
+		assert.Nil(t, err)
+
+		purged, err := repo.Purge(time.Now().Add(-time.Hour))
+		assert.Nil(t, err)
+		assert.Empty(t, purged)
+
+		purged, err = repo.Purge(time.Now().Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{testModels[0].Name}, purged)
+		err = repo.Restore(testModels[0].Name)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+
+		checkModels, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(checkModels))
+	})
 }
 
 func TestProjectJobRepository(t *testing.T) {
@@ -566,7 +764,7 @@ func TestProjectJobRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
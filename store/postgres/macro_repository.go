@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type Macro struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	ProjectID uuid.UUID `gorm:"not null"`
+
+	Name         string `gorm:"not null"`
+	Type         string `gorm:"not null"`
+	DefaultValue string
+	Description  string
+	Namespace    string
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+func (m Macro) FromSpec(spec models.Macro, proj models.ProjectSpec) Macro {
+	return Macro{
+		ID:           spec.ID,
+		ProjectID:    proj.ID,
+		Name:         spec.Name,
+		Type:         spec.GetType(),
+		DefaultValue: spec.Default,
+		Description:  spec.Description,
+		Namespace:    spec.Namespace,
+	}
+}
+
+func (m Macro) ToSpec() models.Macro {
+	return models.Macro{
+		ID:          m.ID,
+		Name:        m.Name,
+		Type:        m.Type,
+		Default:     m.DefaultValue,
+		Description: m.Description,
+		Namespace:   m.Namespace,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+type macroRepository struct {
+	db      *gorm.DB
+	project models.ProjectSpec
+}
+
+func (repo *macroRepository) Save(spec models.Macro) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	var existing Macro
+	err := repo.db.Where("project_id = ? AND name = ? AND namespace = ?", repo.project.ID, spec.Name, spec.Namespace).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		macro := Macro{}.FromSpec(spec, repo.project)
+		if macro.ID == uuid.Nil {
+			macro.ID = uuid.New()
+		}
+		return repo.db.Create(&macro).Error
+	} else if err != nil {
+		return errors.Wrap(err, "unable to find macro")
+	}
+	return repo.db.Model(&existing).Updates(map[string]interface{}{
+		"type":          spec.GetType(),
+		"default_value": spec.Default,
+		"description":   spec.Description,
+	}).Error
+}
+
+func (repo *macroRepository) Delete(name, namespace string) error {
+	result := repo.db.Where("project_id = ? AND name = ? AND namespace = ?", repo.project.ID, name, namespace).Delete(&Macro{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (repo *macroRepository) GetAll() ([]models.Macro, error) {
+	var macros []Macro
+	if err := repo.db.Where("project_id = ?", repo.project.ID).Order("name, namespace").Find(&macros).Error; err != nil {
+		return nil, err
+	}
+	var specs []models.Macro
+	for _, macro := range macros {
+		specs = append(specs, macro.ToSpec())
+	}
+	return specs, nil
+}
+
+func NewMacroRepository(db *gorm.DB, project models.ProjectSpec) *macroRepository {
+	return &macroRepository{
+		db:      db,
+		project: project,
+	}
+}
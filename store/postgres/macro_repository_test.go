@@ -0,0 +1,98 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMacroRepository(t *testing.T) {
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		return dbConn
+	}
+
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus",
+		Config: map[string]string{
+			"bucket": "gs://some_folder",
+		},
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("should create macro or replace it in same scope", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			assert.Nil(t, NewProjectRepository(db, hash).Save(projectSpec))
+
+			repo := NewMacroRepository(db, projectSpec)
+			assert.Nil(t, repo.Save(models.Macro{Name: "dataset", Default: "common"}))
+			assert.Nil(t, repo.Save(models.Macro{Name: "dataset", Default: "playground", Namespace: "dev-team-1"}))
+			assert.Nil(t, repo.Save(models.Macro{Name: "dataset", Default: "shared", Description: "default dataset"}))
+
+			macros, err := repo.GetAll()
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(macros))
+			assert.Equal(t, "shared", macros[0].Default)
+			assert.Equal(t, models.MacroTypeString, macros[0].Type)
+			assert.Equal(t, "default dataset", macros[0].Description)
+			assert.Equal(t, "playground", macros[1].Default)
+			assert.Equal(t, "dev-team-1", macros[1].Namespace)
+
+			// macros are loaded with the project
+			proj, err := NewProjectRepository(db, hash).GetByName(projectSpec.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(proj.Macros))
+		})
+		t.Run("should fail for default value not matching type", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			assert.Nil(t, NewProjectRepository(db, hash).Save(projectSpec))
+
+			err := NewMacroRepository(db, projectSpec).Save(models.Macro{Name: "limit", Type: models.MacroTypeInt, Default: "ten"})
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("Delete", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		assert.Nil(t, NewProjectRepository(db, hash).Save(projectSpec))
+
+		repo := NewMacroRepository(db, projectSpec)
+		assert.Nil(t, repo.Save(models.Macro{Name: "dataset", Default: "common"}))
+
+		assert.Nil(t, repo.Delete("dataset", ""))
+		assert.Equal(t, store.ErrResourceNotFound, repo.Delete("dataset", ""))
+
+		macros, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Empty(t, macros)
+	})
+}
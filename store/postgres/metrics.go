@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/core/logger"
+)
+
+const (
+	queryStartedAtKey = "optimus:query_started_at"
+	unknownTable      = "unknown"
+)
+
+// queryLatencyBuckets are upper bounds in milliseconds of latency histograms
+var queryLatencyBuckets = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// queryOperations are gorm callbacks timed for each statement kind
+var queryOperations = []struct {
+	name      string
+	callback  string
+	processor func(*gorm.Callback) *gorm.CallbackProcessor
+}{
+	{"create", "gorm:create", (*gorm.Callback).Create},
+	{"query", "gorm:query", (*gorm.Callback).Query},
+	{"row_query", "gorm:row_query", (*gorm.Callback).RowQuery},
+	{"update", "gorm:update", (*gorm.Callback).Update},
+	{"delete", "gorm:delete", (*gorm.Callback).Delete},
+}
+
+// QueryStat is the number of statements run on a table and their latency
+type QueryStat struct {
+	Count  int64 `json:"count"`
+	Errors int64 `json:"errors"`
+	// LatencyMsSum is the total time taken by statements in milliseconds
+	LatencyMsSum float64 `json:"latency_ms_sum"`
+	// LatencyMsBuckets are cumulative counts of statements keyed by upper
+	// bound of latency in milliseconds, last bucket is "+Inf"
+	LatencyMsBuckets map[string]int64 `json:"latency_ms_buckets"`
+}
+
+// QueryStats counts statements run by repositories keyed by table and
+// operation e.g. "job.update", stats are exported as json through expvar
+type QueryStats struct {
+	mu    sync.Mutex
+	stats map[string]*QueryStat
+}
+
+func (s *QueryStats) observe(table, operation string, elapsed time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := table + "." + operation
+	stat, ok := s.stats[key]
+	if !ok {
+		stat = &QueryStat{LatencyMsBuckets: map[string]int64{}}
+		s.stats[key] = stat
+	}
+	stat.Count++
+	if failed {
+		stat.Errors++
+	}
+	elapsedMs := float64(elapsed) / float64(time.Millisecond)
+	stat.LatencyMsSum += elapsedMs
+	for _, bucket := range queryLatencyBuckets {
+		if elapsedMs <= bucket {
+			stat.LatencyMsBuckets[strconv.FormatFloat(bucket, 'f', -1, 64)]++
+		}
+	}
+	stat.LatencyMsBuckets["+Inf"]++
+}
+
+// Get returns a copy of stats of operation on table
+func (s *QueryStats) Get(table, operation string) QueryStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stat, ok := s.stats[table+"."+operation]
+	if !ok {
+		return QueryStat{}
+	}
+	buckets := make(map[string]int64, len(stat.LatencyMsBuckets))
+	for bucket, count := range stat.LatencyMsBuckets {
+		buckets[bucket] = count
+	}
+	copied := *stat
+	copied.LatencyMsBuckets = buckets
+	return copied
+}
+
+// String renders stats as json, it makes QueryStats an expvar.Var
+func (s *QueryStats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := json.Marshal(s.stats)
+	if err != nil {
+		return "{}"
+	}
+	return string(raw)
+}
+
+func NewQueryStats() *QueryStats {
+	return &QueryStats{
+		stats: map[string]*QueryStat{},
+	}
+}
+
+// Instrument times every statement run through db and records it in stats,
+// statements taking longer than slowThreshold are logged along with their
+// sql, query arguments are left out as they may hold secrets. Slow
+// statements are not logged if threshold is not set
+func Instrument(db *gorm.DB, stats *QueryStats, slowThreshold time.Duration) {
+	for _, op := range queryOperations {
+		operation := op.name
+		processor := op.processor(db.Callback())
+		processor.Before(op.callback).Register("optimus:before_"+operation, func(scope *gorm.Scope) {
+			scope.Set(queryStartedAtKey, time.Now())
+		})
+		processor.After(op.callback).Register("optimus:after_"+operation, func(scope *gorm.Scope) {
+			startedAt, ok := scope.Get(queryStartedAtKey)
+			if !ok {
+				return
+			}
+			elapsed := time.Since(startedAt.(time.Time))
+			table := scope.TableName()
+			if table == "" {
+				table = unknownTable
+			}
+			err := scope.DB().Error
+			failed := err != nil && !gorm.IsRecordNotFoundError(err)
+			stats.observe(table, operation, elapsed, failed)
+
+			if slowThreshold > 0 && elapsed >= slowThreshold {
+				logger.W(fmt.Sprintf("slow %s on %s took %s: %s", operation, table, elapsed, scope.SQL))
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS role_binding (
+  id UUID PRIMARY KEY NOT NULL,
+  project_id UUID NOT NULL REFERENCES project (id),
+  subject varchar(220) NOT NULL,
+  role varchar(30) NOT NULL,
+  namespace varchar(100) NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  UNIQUE (project_id, subject, namespace)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS audit_event (
+  id UUID PRIMARY KEY NOT NULL,
+  project_name varchar(100) NOT NULL DEFAULT '',
+  namespace varchar(100) NOT NULL DEFAULT '',
+  method varchar(100) NOT NULL,
+  actor varchar(220) NOT NULL DEFAULT '',
+  summary TEXT,
+  success BOOLEAN NOT NULL,
+  error TEXT,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_event_project_name_created_at_idx ON audit_event (project_name, created_at);
@@ -0,0 +1 @@
+ALTER TABLE job ADD IF NOT EXISTS trigger JSONB;
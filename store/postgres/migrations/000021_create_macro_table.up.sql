@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS macro (
+  id UUID PRIMARY KEY NOT NULL,
+  project_id UUID NOT NULL REFERENCES project (id),
+  name varchar(100) NOT NULL,
+  type varchar(20) NOT NULL,
+  default_value text NOT NULL DEFAULT '',
+  description text NOT NULL DEFAULT '',
+  namespace varchar(100) NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  UNIQUE (project_id, name, namespace)
+);
@@ -0,0 +1 @@
+ALTER TABLE job DROP IF EXISTS task_version;
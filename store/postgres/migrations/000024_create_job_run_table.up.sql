@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS job_run (
+  id UUID PRIMARY KEY NOT NULL,
+  project_id UUID NOT NULL REFERENCES project (id),
+  namespace_id UUID NOT NULL REFERENCES namespace (id),
+  job_name varchar(220) NOT NULL,
+  scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  state varchar(30) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS job_run_project_id_job_name_scheduled_at_idx ON job_run (project_id, job_name, scheduled_at);
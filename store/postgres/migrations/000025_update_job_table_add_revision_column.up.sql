@@ -0,0 +1 @@
+ALTER TABLE job ADD IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 1;
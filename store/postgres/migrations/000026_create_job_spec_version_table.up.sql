@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS job_spec_version (
+  id UUID PRIMARY KEY NOT NULL,
+  job_id UUID NOT NULL REFERENCES job (id),
+  revision INTEGER NOT NULL,
+  spec JSONB NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS job_spec_version_job_id_revision_idx ON job_spec_version (job_id, revision);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_lineage_dependency;
+DROP TABLE IF EXISTS job_lineage;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS job_lineage (
+  project_name varchar(100) NOT NULL,
+  job_name varchar(220) NOT NULL,
+  namespace_name varchar(100) NOT NULL DEFAULT '',
+  destination TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (project_name, job_name)
+);
+CREATE INDEX IF NOT EXISTS job_lineage_destination_idx ON job_lineage (destination);
+CREATE TABLE IF NOT EXISTS job_lineage_dependency (
+  project_name varchar(100) NOT NULL,
+  job_name varchar(220) NOT NULL,
+  urn TEXT NOT NULL,
+  PRIMARY KEY (project_name, job_name, urn)
+);
+CREATE INDEX IF NOT EXISTS job_lineage_dependency_urn_idx ON job_lineage_dependency (urn);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS backup (
+  id UUID PRIMARY KEY NOT NULL,
+  project_id UUID NOT NULL REFERENCES project (id),
+  datastore varchar(100) NOT NULL,
+  resource_name varchar(250) NOT NULL,
+  description TEXT,
+  config JSONB,
+  results JSONB,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS backup_project_id_datastore_created_at_idx ON backup (project_id, datastore, created_at);
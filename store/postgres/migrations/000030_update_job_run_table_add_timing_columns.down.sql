@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS job_run_project_id_state_idx;
+ALTER TABLE job_run DROP IF EXISTS attempt;
+ALTER TABLE job_run DROP IF EXISTS end_time;
+ALTER TABLE job_run DROP IF EXISTS start_time;
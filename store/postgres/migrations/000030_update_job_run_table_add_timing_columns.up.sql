@@ -0,0 +1,4 @@
+ALTER TABLE job_run ADD IF NOT EXISTS start_time TIMESTAMP WITH TIME ZONE;
+ALTER TABLE job_run ADD IF NOT EXISTS end_time TIMESTAMP WITH TIME ZONE;
+ALTER TABLE job_run ADD IF NOT EXISTS attempt INT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS job_run_project_id_state_idx ON job_run (project_id, state);
@@ -0,0 +1 @@
+ALTER TABLE job_run DROP IF EXISTS sla_missed_at;
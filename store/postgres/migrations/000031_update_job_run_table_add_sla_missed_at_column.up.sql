@@ -0,0 +1 @@
+ALTER TABLE job_run ADD IF NOT EXISTS sla_missed_at TIMESTAMP WITH TIME ZONE;
@@ -122,7 +122,7 @@ func (repo *namespaceRepository) Save(spec models.NamespaceSpec) error {
 
 func (repo *namespaceRepository) GetByName(name string) (models.NamespaceSpec, error) {
 	var r Namespace
-	if err := repo.db.Preload("Project").Preload("Project.Secrets").Where("name = ? AND project_id = ?", name, repo.project.ID).Find(&r).Error; err != nil {
+	if err := repo.db.Preload("Project").Preload("Project.Secrets").Preload("Project.Macros").Where("name = ? AND project_id = ?", name, repo.project.ID).Find(&r).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.NamespaceSpec{}, store.ErrResourceNotFound
 		}
@@ -134,7 +134,7 @@ func (repo *namespaceRepository) GetByName(name string) (models.NamespaceSpec, e
 func (repo *namespaceRepository) GetAll() ([]models.NamespaceSpec, error) {
 	specs := []models.NamespaceSpec{}
 	namespaces := []Namespace{}
-	if err := repo.db.Preload("Project").Preload("Project.Secrets").Where("project_id = ?", repo.project.ID).Find(&namespaces).Error; err != nil {
+	if err := repo.db.Preload("Project").Preload("Project.Secrets").Preload("Project.Macros").Where("project_id = ?", repo.project.ID).Find(&namespaces).Error; err != nil {
 		return specs, err
 	}
 
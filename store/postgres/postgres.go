@@ -3,11 +3,18 @@ package postgres
 import (
 	"embed"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/source/httpfs"
 	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/store/sqlite"
 	"github.com/pkg/errors"
 
 	_ "embed"
@@ -21,32 +28,104 @@ var migrationFs embed.FS
 
 const (
 	resourcePath = "migrations"
+
+	// statementTimeoutParam is sent by the driver as a run-time parameter
+	// of sessions
+	statementTimeoutParam = "statement_timeout"
 )
 
+// keyValueStatementTimeout matches statement_timeout set in a key/value
+// connection string, e.g. host=localhost statement_timeout=100
+var keyValueStatementTimeout = regexp.MustCompile(`(^|\s)` + statementTimeoutParam + `\s*=`)
+
+var (
+	// ErrSchemaDirty is returned when a migration failed midway, schema
+	// needs to be fixed by hand before migrating again
+	ErrSchemaDirty = errors.New("database schema is dirty")
+
+	// ErrSchemaOutdated is returned when migrations of this build are not
+	// applied to the database yet
+	ErrSchemaOutdated = errors.New("database schema is outdated")
+
+	// ErrSchemaAhead is returned when the database is migrated by a newer
+	// build than this one
+	ErrSchemaAhead = errors.New("database schema is newer than this build")
+)
+
+// MigrationStatus is version of schema applied to database against the
+// latest migration shipped with this build
+type MigrationStatus struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+}
+
 // NewHTTPFSMigrator reads the migrations from httpfs and returns the migrate.Migrate
 func NewHTTPFSMigrator(DBConnURL string) (*migrate.Migrate, error) {
-	src, err := httpfs.New(http.FS(migrationFs), resourcePath)
+	var migrations fs.FS = migrationFs
+	if sqlite.IsSqlite(DBConnURL) {
+		// same migrations are rewritten for sqlite
+		migrations = sqlite.MigrationFS(migrationFs)
+	}
+	src, err := httpfs.New(http.FS(migrations), resourcePath)
 	if err != nil {
 		return &migrate.Migrate{}, fmt.Errorf("db migrator: %v", err)
 	}
 	return migrate.NewWithSourceInstance("httpfs", src, DBConnURL)
 }
 
-// Connect connect to the DB with custom configuration.
-func Connect(connURL string, maxIdleConnections, maxOpenConnections int) (*gorm.DB, error) {
-	var db *gorm.DB
-	var err error
+// Connect connect to the DB with custom configuration. Connection strings
+// with sqlite3 scheme open an sqlite database instead, statement timeout
+// is ignored for sqlite. Connections are never closed for their age if
+// connMaxLifetime is not set
+func Connect(connURL string, maxIdleConnections, maxOpenConnections int, connMaxLifetime, statementTimeout time.Duration) (*gorm.DB, error) {
+	if sqlite.IsSqlite(connURL) {
+		return sqlite.Connect(connURL, maxIdleConnections)
+	}
+
+	connURL, err := withStatementTimeout(connURL, statementTimeout)
+	if err != nil {
+		return nil, err
+	}
 
+	var db *gorm.DB
 	if db, err = gorm.Open("postgres", connURL); err != nil {
 		return nil, err
 	}
 
 	db.DB().SetMaxIdleConns(maxIdleConnections)
 	db.DB().SetMaxOpenConns(maxOpenConnections)
+	db.DB().SetConnMaxLifetime(connMaxLifetime)
 	db.SingularTable(true)
 	return db, nil
 }
 
+// withStatementTimeout sets statement_timeout of sessions opened with
+// connURL in milliseconds, a timeout already part of connURL is kept.
+// Both URLs and key/value connection strings are accepted
+func withStatementTimeout(connURL string, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		return connURL, nil
+	}
+	if !strings.HasPrefix(connURL, "postgres://") && !strings.HasPrefix(connURL, "postgresql://") {
+		if keyValueStatementTimeout.MatchString(connURL) {
+			return connURL, nil
+		}
+		return fmt.Sprintf("%s %s=%d", strings.TrimSpace(connURL), statementTimeoutParam, timeout.Milliseconds()), nil
+	}
+	parsed, err := url.Parse(connURL)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse connection string")
+	}
+	query := parsed.Query()
+	if query.Get(statementTimeoutParam) != "" {
+		return connURL, nil
+	}
+	query.Set(statementTimeoutParam, strconv.FormatInt(timeout.Milliseconds(), 10))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
 // Migrate to run up migrations
 func Migrate(connURL string) error {
 	m, err := NewHTTPFSMigrator(connURL)
@@ -60,3 +139,94 @@ func Migrate(connURL string) error {
 	}
 	return nil
 }
+
+// Rollback reverts the last steps migrations applied to database
+func Rollback(connURL string, steps int) error {
+	m, err := NewHTTPFSMigrator(connURL)
+	if err != nil {
+		return errors.Wrap(err, "db migrator")
+	}
+	defer m.Close()
+
+	if err := m.Steps(-steps); err != nil && err != migrate.ErrNoChange {
+		return errors.Wrap(err, "db migrator")
+	}
+	return nil
+}
+
+// MigrateTo migrates schema up or down to the given version
+func MigrateTo(connURL string, version uint) error {
+	m, err := NewHTTPFSMigrator(connURL)
+	if err != nil {
+		return errors.Wrap(err, "db migrator")
+	}
+	defer m.Close()
+
+	if err := m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		return errors.Wrap(err, "db migrator")
+	}
+	return nil
+}
+
+// GetMigrationStatus reads version of schema applied to database, version
+// is zero if no migration is applied yet
+func GetMigrationStatus(connURL string) (MigrationStatus, error) {
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	m, err := NewHTTPFSMigrator(connURL)
+	if err != nil {
+		return MigrationStatus{}, errors.Wrap(err, "db migrator")
+	}
+	defer m.Close()
+
+	version, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return MigrationStatus{}, errors.Wrap(err, "db migrator")
+	}
+	return MigrationStatus{
+		Version: version,
+		Dirty:   dirty,
+		Latest:  latest,
+	}, nil
+}
+
+// CheckSchema verifies schema of database is at the latest migration
+// shipped with this build
+func CheckSchema(connURL string) error {
+	status, err := GetMigrationStatus(connURL)
+	if err != nil {
+		return err
+	}
+	switch {
+	case status.Dirty:
+		return errors.Wrapf(ErrSchemaDirty, "migration %d failed", status.Version)
+	case status.Version < status.Latest:
+		return errors.Wrapf(ErrSchemaOutdated, "at version %d, expected %d", status.Version, status.Latest)
+	case status.Version > status.Latest:
+		return errors.Wrapf(ErrSchemaAhead, "at version %d, expected %d", status.Version, status.Latest)
+	}
+	return nil
+}
+
+// LatestMigrationVersion returns version of the last migration shipped
+// with this build, migration files are prefixed with their version
+func LatestMigrationVersion() (uint, error) {
+	entries, err := fs.ReadDir(migrationFs, resourcePath)
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, entry := range entries {
+		prefix := strings.SplitN(entry.Name(), "_", 2)[0]
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid migration %s", entry.Name())
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	return latest, nil
+}
@@ -21,6 +21,9 @@ type Project struct {
 	// Secrets are read only and will not be saved by updating it here
 	Secrets []Secret
 
+	// Macros are read only and will not be saved by updating it here
+	Macros []Macro
+
 	CreatedAt time.Time `gorm:"not null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
 	DeletedAt *time.Time
@@ -63,11 +66,16 @@ func (p Project) ToSpecWithSecrets(h models.ApplicationKey) (models.ProjectSpec,
 		}
 		specSecrets = append(specSecrets, specSecret)
 	}
+	var specMacros models.ProjectMacros
+	for _, macro := range p.Macros {
+		specMacros = append(specMacros, macro.ToSpec())
+	}
 	return models.ProjectSpec{
 		ID:     p.ID,
 		Name:   p.Name,
 		Config: conf,
 		Secret: specSecrets,
+		Macros: specMacros,
 	}, nil
 }
 
@@ -87,9 +95,17 @@ func (repo *ProjectRepository) Insert(resource models.ProjectSpec) error {
 	return repo.db.Create(&p).Error
 }
 
+// Save creates the project or updates it if it exists. A deleted project
+// keeps its name till it is purged and fails with store.ErrResourceDeleted
 func (repo *ProjectRepository) Save(spec models.ProjectSpec) error {
 	existingResource, err := repo.GetByName(spec.Name)
 	if errors.Is(err, store.ErrResourceNotFound) {
+		var deleted Project
+		if err := repo.db.Unscoped().Where("name = ? AND deleted_at IS NOT NULL", spec.Name).Find(&deleted).Error; err == nil {
+			return errors.Wrapf(store.ErrResourceDeleted, "project %s is deleted and can be restored till it is purged", spec.Name)
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, "unable to find deleted project by name")
+		}
 		return repo.Insert(spec)
 	} else if err != nil {
 		return errors.Wrap(err, "unable to find project by name")
@@ -104,7 +120,7 @@ func (repo *ProjectRepository) Save(spec models.ProjectSpec) error {
 
 func (repo *ProjectRepository) GetByName(name string) (models.ProjectSpec, error) {
 	var r Project
-	if err := repo.db.Preload("Secrets").Where("name = ?", name).Find(&r).Error; err != nil {
+	if err := repo.db.Preload("Secrets").Preload("Macros").Where("name = ?", name).Find(&r).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.ProjectSpec{}, store.ErrResourceNotFound
 		}
@@ -115,7 +131,7 @@ func (repo *ProjectRepository) GetByName(name string) (models.ProjectSpec, error
 
 func (repo *ProjectRepository) GetByID(id uuid.UUID) (models.ProjectSpec, error) {
 	var r Project
-	if err := repo.db.Preload("Secrets").Where("id = ?", id).Find(&r).Error; err != nil {
+	if err := repo.db.Preload("Secrets").Preload("Macros").Where("id = ?", id).Find(&r).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.ProjectSpec{}, store.ErrResourceNotFound
 		}
@@ -127,7 +143,7 @@ func (repo *ProjectRepository) GetByID(id uuid.UUID) (models.ProjectSpec, error)
 func (repo *ProjectRepository) GetAll() ([]models.ProjectSpec, error) {
 	specs := []models.ProjectSpec{}
 	projs := []Project{}
-	if err := repo.db.Preload("Secrets").Find(&projs).Error; err != nil {
+	if err := repo.db.Preload("Secrets").Preload("Macros").Find(&projs).Error; err != nil {
 		return specs, err
 	}
 	for _, proj := range projs {
@@ -140,6 +156,101 @@ func (repo *ProjectRepository) GetAll() ([]models.ProjectSpec, error) {
 	return specs, nil
 }
 
+// Delete soft deletes a project, it is kept along with everything it owns
+// till it is purged
+func (repo *ProjectRepository) Delete(name string) error {
+	res := repo.db.Where("name = ?", name).Delete(&Project{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return store.ErrResourceNotFound
+	}
+	return nil
+}
+
+// Restore brings back a deleted project till it is purged
+func (repo *ProjectRepository) Restore(name string) error {
+	res := repo.db.Unscoped().Model(&Project{}).Where("name = ? AND deleted_at IS NOT NULL", name).
+		Update("deleted_at", gorm.Expr("NULL"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return store.ErrResourceNotFound
+	}
+	return nil
+}
+
+// Purge permanently removes projects deleted before the given time along
+// with everything they own, names of the purged projects are returned.
+// Audit events are kept
+func (repo *ProjectRepository) Purge(deletedBefore time.Time) ([]string, error) {
+	var projects []Project
+	if err := repo.db.Unscoped().Where("deleted_at < ?", deletedBefore).Order("name").Find(&projects).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to fetch deleted projects")
+	}
+
+	var purged []string
+	for _, p := range projects {
+		tx := repo.db.Begin()
+		if tx.Error != nil {
+			return purged, tx.Error
+		}
+		if err := deleteProject(tx, p); err != nil {
+			tx.Rollback()
+			return purged, errors.Wrapf(err, "failed to purge project %s", p.Name)
+		}
+		if err := tx.Commit().Error; err != nil {
+			return purged, errors.Wrapf(err, "failed to purge project %s", p.Name)
+		}
+		purged = append(purged, p.Name)
+	}
+	return purged, nil
+}
+
+// deleteProject permanently removes a project cascading to everything it
+// owns, children are removed before the rows they reference
+func deleteProject(db *gorm.DB, p Project) error {
+	var jobIDs []uuid.UUID
+	if err := db.Unscoped().Model(&Job{}).Where("project_id = ?", p.ID).Pluck("id", &jobIDs).Error; err != nil {
+		return errors.Wrap(err, "failed to fetch jobs of the project")
+	}
+	if len(jobIDs) > 0 {
+		if err := db.Unscoped().Where("job_id IN (?)", jobIDs).Delete(&Instance{}).Error; err != nil {
+			return errors.Wrap(err, "failed to cascade delete instances for the project")
+		}
+		if err := db.Where("job_id IN (?)", jobIDs).Delete(&JobSpecVersion{}).Error; err != nil {
+			return errors.Wrap(err, "failed to cascade delete job versions for the project")
+		}
+	}
+	for _, owned := range []struct {
+		name  string
+		model interface{}
+	}{
+		{"job runs", &JobRun{}},
+		{"deployments", &Deployment{}},
+		{"jobs", &Job{}},
+		{"backups", &Backup{}},
+		{"resources", &Resource{}},
+		{"secrets", &Secret{}},
+		{"macros", &Macro{}},
+		{"role bindings", &RoleBinding{}},
+		{"namespaces", &Namespace{}},
+	} {
+		if err := db.Unscoped().Where("project_id = ?", p.ID).Delete(owned.model).Error; err != nil {
+			return errors.Wrapf(err, "failed to cascade delete %s for the project", owned.name)
+		}
+	}
+	if err := db.Where("project_name = ?", p.Name).Delete(&JobLineageDependency{}).Error; err != nil {
+		return errors.Wrap(err, "failed to cascade delete lineage for the project")
+	}
+	if err := db.Where("project_name = ?", p.Name).Delete(&JobLineage{}).Error; err != nil {
+		return errors.Wrap(err, "failed to cascade delete lineage for the project")
+	}
+	return db.Unscoped().Where("id = ?", p.ID).Delete(&Project{}).Error
+}
+
 func NewProjectRepository(db *gorm.DB, hash models.ApplicationKey) *ProjectRepository {
 	return &ProjectRepository{
 		db:   db,
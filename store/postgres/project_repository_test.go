@@ -6,10 +6,13 @@ import (
 	"os"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,7 +22,7 @@ func TestProjectRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -212,4 +215,52 @@ func TestProjectRepository(t *testing.T) {
 		sec, _ = checkModels[1].Secret.GetByName("t2")
 		assert.Equal(t, "v2", sec)
 	})
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("should keep deleted project with its namespaces and secrets till it is purged", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			projectSpec := testConfigs[0]
+
+			repo := NewProjectRepository(db, hash)
+			assert.Nil(t, repo.Insert(projectSpec))
+			assert.Nil(t, NewSecretRepository(db, projectSpec, hash).Save(models.ProjectSecretItem{
+				Name:  "t1",
+				Value: "v1",
+			}))
+			assert.Nil(t, NewNamespaceRepository(db, projectSpec, hash).Save(models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-team-1",
+			}))
+
+			assert.Nil(t, repo.Delete(projectSpec.Name))
+			_, err := repo.GetByName(projectSpec.Name)
+			assert.Equal(t, store.ErrResourceNotFound, err)
+			err = repo.Save(projectSpec)
+			assert.True(t, errors.Is(err, store.ErrResourceDeleted))
+
+			assert.Nil(t, repo.Restore(projectSpec.Name))
+			checkModel, err := repo.GetByName(projectSpec.Name)
+			assert.Nil(t, err)
+			sec, _ := checkModel.Secret.GetByName("t1")
+			assert.Equal(t, "v1", sec)
+			assert.Equal(t, store.ErrResourceNotFound, repo.Restore(projectSpec.Name))
+
+			assert.Nil(t, repo.Delete(projectSpec.Name))
+			purged, err := repo.Purge(time.Now().Add(-time.Hour))
+			assert.Nil(t, err)
+			assert.Empty(t, purged)
+			purged, err = repo.Purge(time.Now().Add(time.Hour))
+			assert.Nil(t, err)
+			assert.Equal(t, []string{projectSpec.Name}, purged)
+			assert.Equal(t, store.ErrResourceNotFound, repo.Restore(projectSpec.Name))
+			assert.Nil(t, repo.Save(projectSpec))
+		})
+		t.Run("should return not found for unknown projects", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+
+			err := NewProjectRepository(db, hash).Delete("unknown")
+			assert.Equal(t, store.ErrResourceNotFound, err)
+		})
+	})
 }
@@ -153,3 +153,29 @@ func (repo *replayRepository) GetByJobIDAndStatus(jobID uuid.UUID, status []stri
 	}
 	return replaySpecs, nil
 }
+
+func (repo *replayRepository) GetByProjectID(projectID uuid.UUID) ([]models.ReplaySpec, error) {
+	var replays []Replay
+	if err := repo.DB.Joins("JOIN job ON job.id = replay.job_id").
+		Where("job.project_id = ? AND job.deleted_at IS NULL", projectID).Order("replay.created_at desc").
+		Preload("Job").Find(&replays).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []models.ReplaySpec{}, store.ErrResourceNotFound
+		}
+		return []models.ReplaySpec{}, err
+	}
+
+	var replaySpecs []models.ReplaySpec
+	for _, r := range replays {
+		jobSpec, err := repo.adapter.ToSpec(r.Job)
+		if err != nil {
+			return []models.ReplaySpec{}, err
+		}
+		replaySpec, err := r.ToSpec(jobSpec)
+		if err != nil {
+			return []models.ReplaySpec{}, err
+		}
+		replaySpecs = append(replaySpecs, replaySpec)
+	}
+	return replaySpecs, nil
+}
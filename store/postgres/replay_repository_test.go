@@ -72,7 +72,7 @@ func TestReplayRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -263,4 +263,57 @@ func TestReplayRepository(t *testing.T) {
 			assert.Equal(t, jobConfigs[2].ID, replays[0].Job.ID)
 		})
 	})
+
+	t.Run("GetByProjectID", func(t *testing.T) {
+		t.Run("should return replays of jobs of the project", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			var testModels []*models.ReplaySpec
+			testModels = append(testModels, testConfigs...)
+
+			execUnit1 := new(mock.BasePlugin)
+			defer execUnit1.AssertExpectations(t)
+			execUnit1.On("PluginInfo").Return(&models.PluginInfoResponse{
+				Name: gTask,
+			}, nil)
+			depMod1 := new(mock.DependencyResolverMod)
+			defer depMod1.AssertExpectations(t)
+			for idx, jobConfig := range jobConfigs {
+				jobConfig.Task = models.JobSpecTask{Unit: &models.Plugin{Base: execUnit1, DependencyMod: depMod1}}
+				testConfigs[idx].Job = jobConfig
+			}
+
+			pluginRepo := new(mock.SupportedPluginRepo)
+			defer pluginRepo.AssertExpectations(t)
+			pluginRepo.On("GetByName", gTask).Return(&models.Plugin{Base: execUnit1, DependencyMod: depMod1}, nil)
+			adapter := NewAdapter(pluginRepo)
+
+			unitData := models.GenerateDestinationRequest{
+				Config: models.PluginConfigs{}.FromJobSpec(jobConfigs[0].Task.Config),
+				Assets: models.PluginAssets{}.FromJobSpec(jobConfigs[0].Assets),
+			}
+			depMod1.On("GenerateDestination", context.TODO(), unitData).Return(&models.GenerateDestinationResponse{Destination: "p.d.t"}, nil)
+
+			projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+			jobRepo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+			err := jobRepo.Insert(testModels[0].Job)
+			assert.Nil(t, err)
+			err = jobRepo.Insert(testModels[1].Job)
+			assert.Nil(t, err)
+
+			repo := NewReplayRepository(db, models.JobSpec{}, adapter)
+			err = repo.Insert(testModels[0])
+			assert.Nil(t, err)
+			err = repo.Insert(testModels[1])
+			assert.Nil(t, err)
+
+			replays, err := repo.GetByProjectID(projectSpec.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(replays))
+
+			replays, err = repo.GetByProjectID(uuid.Must(uuid.NewRandom()))
+			assert.Nil(t, err)
+			assert.Empty(t, replays)
+		})
+	})
 }
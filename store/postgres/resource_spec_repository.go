@@ -216,7 +216,7 @@ func (repo *resourceSpecRepository) Save(spec models.ResourceSpec) error {
 	}
 
 	if namespaceSpec.ID != repo.namespace.ID {
-		return errors.New(fmt.Sprintf("resource %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name))
+		return errors.Wrapf(store.ErrResourceAlreadyExists, "resource %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name)
 	}
 
 	resource, err := Resource{}.FromSpec(spec)
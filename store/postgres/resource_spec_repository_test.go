@@ -42,7 +42,7 @@ func TestResourceSpecRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -243,7 +243,7 @@ func TestResourceSpecRepository(t *testing.T) {
 			// try to create same resource with second client and it should fail.
 			err = resourceSpecNamespace2.Save(testModelA)
 			assert.NotNil(t, err)
-			assert.Equal(t, "resource proj.ttt.test2 already exists for the project t-optimus-project", err.Error())
+			assert.Equal(t, "resource proj.ttt.test2 already exists for the project t-optimus-project: resource already exists", err.Error())
 		})
 	})
 
@@ -293,7 +293,7 @@ func TestProjectResourceSpecRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
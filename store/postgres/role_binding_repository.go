@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+type RoleBinding struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	ProjectID uuid.UUID `gorm:"not null"`
+
+	Subject   string `gorm:"not null"`
+	Role      string `gorm:"not null"`
+	Namespace string
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+func (b RoleBinding) FromSpec(spec models.RoleBinding, proj models.ProjectSpec) RoleBinding {
+	return RoleBinding{
+		ID:        spec.ID,
+		ProjectID: proj.ID,
+		Subject:   spec.Subject,
+		Role:      spec.Role,
+		Namespace: spec.Namespace,
+	}
+}
+
+func (b RoleBinding) ToSpec() models.RoleBinding {
+	return models.RoleBinding{
+		ID:        b.ID,
+		Subject:   b.Subject,
+		Role:      b.Role,
+		Namespace: b.Namespace,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+type roleBindingRepository struct {
+	db      *gorm.DB
+	project models.ProjectSpec
+}
+
+func (repo *roleBindingRepository) Save(spec models.RoleBinding) error {
+	if spec.Subject == "" {
+		return errors.New("subject cannot be empty")
+	}
+	var existing RoleBinding
+	err := repo.db.Where("project_id = ? AND subject = ? AND namespace = ?", repo.project.ID, spec.Subject, spec.Namespace).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		binding := RoleBinding{}.FromSpec(spec, repo.project)
+		if binding.ID == uuid.Nil {
+			binding.ID = uuid.New()
+		}
+		return repo.db.Create(&binding).Error
+	} else if err != nil {
+		return errors.Wrap(err, "unable to find role binding")
+	}
+	return repo.db.Model(&existing).Update("role", spec.Role).Error
+}
+
+func (repo *roleBindingRepository) Delete(subject, namespace string) error {
+	result := repo.db.Where("project_id = ? AND subject = ? AND namespace = ?", repo.project.ID, subject, namespace).Delete(&RoleBinding{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (repo *roleBindingRepository) GetAll() ([]models.RoleBinding, error) {
+	var bindings []RoleBinding
+	if err := repo.db.Where("project_id = ?", repo.project.ID).Order("subject, namespace").Find(&bindings).Error; err != nil {
+		return nil, err
+	}
+	var specs []models.RoleBinding
+	for _, binding := range bindings {
+		specs = append(specs, binding.ToSpec())
+	}
+	return specs, nil
+}
+
+func NewRoleBindingRepository(db *gorm.DB, project models.ProjectSpec) *roleBindingRepository {
+	return &roleBindingRepository{
+		db:      db,
+		project: project,
+	}
+}
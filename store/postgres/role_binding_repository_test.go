@@ -0,0 +1,105 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleBindingRepository(t *testing.T) {
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		return dbConn
+	}
+
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus",
+		Config: map[string]string{
+			"bucket": "gs://some_folder",
+		},
+	}
+	otherProjectSpec := models.ProjectSpec{
+		ID:     uuid.Must(uuid.NewRandom()),
+		Name:   "t-optimus-other",
+		Config: map[string]string{},
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("should create binding or replace role in same scope", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			assert.Nil(t, NewProjectRepository(db, hash).Save(projectSpec))
+
+			repo := NewRoleBindingRepository(db, projectSpec)
+			assert.Nil(t, repo.Save(models.RoleBinding{Subject: "alice@example.io", Role: models.RoleViewer}))
+			assert.Nil(t, repo.Save(models.RoleBinding{Subject: "alice@example.io", Role: models.RoleEditor, Namespace: "dev-team-1"}))
+			assert.Nil(t, repo.Save(models.RoleBinding{Subject: "alice@example.io", Role: models.RoleAdmin}))
+
+			bindings, err := repo.GetAll()
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(bindings))
+			assert.Equal(t, models.RoleAdmin, bindings[0].Role)
+			assert.Equal(t, "", bindings[0].Namespace)
+			assert.Equal(t, models.RoleEditor, bindings[1].Role)
+			assert.Equal(t, "dev-team-1", bindings[1].Namespace)
+		})
+		t.Run("should fail for empty subject", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			assert.Nil(t, NewProjectRepository(db, hash).Save(projectSpec))
+
+			err := NewRoleBindingRepository(db, projectSpec).Save(models.RoleBinding{Role: models.RoleViewer})
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("Delete", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+		projRepo := NewProjectRepository(db, hash)
+		assert.Nil(t, projRepo.Save(projectSpec))
+		assert.Nil(t, projRepo.Save(otherProjectSpec))
+
+		repo := NewRoleBindingRepository(db, projectSpec)
+		otherRepo := NewRoleBindingRepository(db, otherProjectSpec)
+		assert.Nil(t, repo.Save(models.RoleBinding{Subject: "ci", Role: models.RoleEditor}))
+		assert.Nil(t, otherRepo.Save(models.RoleBinding{Subject: "ci", Role: models.RoleEditor}))
+
+		assert.Nil(t, repo.Delete("ci", ""))
+		assert.Equal(t, store.ErrResourceNotFound, repo.Delete("ci", ""))
+
+		bindings, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Empty(t, bindings)
+		// bindings of other projects are untouched
+		otherBindings, err := otherRepo.GetAll()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(otherBindings))
+	})
+}
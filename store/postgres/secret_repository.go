@@ -124,7 +124,7 @@ func (repo *secretRepository) GetByID(id uuid.UUID) (models.ProjectSecretItem, e
 func (repo *secretRepository) GetAll() ([]models.ProjectSecretItem, error) {
 	specs := []models.ProjectSecretItem{}
 	resources := []Secret{}
-	if err := repo.db.Find(&resources).Error; err != nil {
+	if err := repo.db.Where("project_id = ?", repo.project.ID).Find(&resources).Error; err != nil {
 		return specs, err
 	}
 	for _, res := range resources {
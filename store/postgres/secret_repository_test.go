@@ -27,7 +27,7 @@ func TestSecretRepository(t *testing.T) {
 		if !ok {
 			panic("unable to find TEST_OPTIMUS_DB_URL env var")
 		}
-		dbConn, err := Connect(dbURL, 1, 1)
+		dbConn, err := Connect(dbURL, 1, 1, 0, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -164,4 +164,27 @@ func TestSecretRepository(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, "g-optimus", checkModel.Name)
 	})
+	t.Run("GetAll", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		otherProjectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "t-optimus-other",
+		}
+		projRepo := NewProjectRepository(db, hash)
+		assert.Nil(t, projRepo.Save(otherProjectSpec))
+
+		repo := NewSecretRepository(db, projectSpec, hash)
+		assert.Nil(t, repo.Insert(testConfigs[0]))
+		otherRepo := NewSecretRepository(db, otherProjectSpec, hash)
+		assert.Nil(t, otherRepo.Insert(testConfigs[2]))
+
+		// secrets of other projects are not returned
+		secrets, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(secrets))
+		assert.Equal(t, "g-optimus", secrets[0].Name)
+		assert.Equal(t, "secret", secrets[0].Value)
+	})
 }
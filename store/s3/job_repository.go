@@ -0,0 +1,200 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	// errCodeNotFound is returned by head requests of missing objects as
+	// they have no body to carry awss3.ErrCodeNoSuchKey
+	errCodeNotFound = "NotFound"
+)
+
+var (
+	errEmptyJobName = errors.New("job name cannot be an empty string")
+)
+
+type JobRepository struct {
+	ObjectReader store.ObjectReader
+	ObjectWriter store.ObjectWriter
+	Client       s3iface.S3API
+	Bucket       string
+	Prefix       string
+	Suffix       string
+}
+
+func (repo *JobRepository) Save(ctx context.Context, j models.Job) (err error) {
+	dst, err := repo.ObjectWriter.NewWriter(ctx, repo.Bucket, repo.pathFor(j.NamespaceID, j.Name))
+	if err != nil {
+		return err
+	}
+	if _, err := dst.Write(j.Contents); err != nil {
+		return err
+	}
+	return dst.Close()
+}
+
+func (repo *JobRepository) Delete(ctx context.Context, namespace models.NamespaceSpec, jobName string) error {
+	if strings.TrimSpace(jobName) == "" {
+		return errEmptyJobName
+	}
+
+	key := repo.pathFor(namespace.ID.String(), jobName)
+	if _, err := repo.Client.HeadObjectWithContext(ctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(repo.Bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		if isNotFound(err) {
+			return errors.Wrap(models.ErrNoSuchJob, jobName)
+		}
+		return err
+	}
+
+	_, err := repo.Client.DeleteObjectWithContext(ctx, &awss3.DeleteObjectInput{
+		Bucket: aws.String(repo.Bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (repo *JobRepository) GetAll(ctx context.Context) ([]models.Job, error) {
+	keys, err := repo.jobKeys(ctx, repo.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []models.Job
+	for _, key := range keys {
+		contents, err := repo.read(key)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, models.Job{
+			Name:     repo.jobNameFromPath(key),
+			Contents: contents,
+		})
+	}
+	return jobs, nil
+}
+
+func (repo *JobRepository) ListNames(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	keys, err := repo.jobKeys(ctx, path.Join(repo.Prefix, namespace.ID.String())+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	var jobNames []string
+	for _, key := range keys {
+		jobNames = append(jobNames, repo.jobNameFromPath(key))
+	}
+	return jobNames, nil
+}
+
+func (repo *JobRepository) GetByName(ctx context.Context, jobName string) (models.Job, error) {
+	if strings.TrimSpace(jobName) == "" {
+		return models.Job{}, errEmptyJobName
+	}
+
+	// job names are unique across namespaces of a project
+	keys, err := repo.jobKeys(ctx, repo.Prefix)
+	if err != nil {
+		return models.Job{}, err
+	}
+	for _, key := range keys {
+		if repo.jobNameFromPath(key) != jobName {
+			continue
+		}
+		contents, err := repo.read(key)
+		if err != nil {
+			return models.Job{}, err
+		}
+		return models.Job{
+			Name:     jobName,
+			Contents: contents,
+		}, nil
+	}
+	return models.Job{}, errors.Wrap(models.ErrNoSuchJob, jobName)
+}
+
+// jobKeys lists keys of compiled jobs under prefix
+func (repo *JobRepository) jobKeys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := repo.Client.ListObjectsV2PagesWithContext(ctx, &awss3.ListObjectsV2Input{
+		Bucket: aws.String(repo.Bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *awss3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			if key := aws.StringValue(obj.Key); strings.HasSuffix(key, repo.Suffix) {
+				keys = append(keys, key)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (repo *JobRepository) read(key string) ([]byte, error) {
+	reader, err := repo.ObjectReader.NewReader(repo.Bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var b bytes.Buffer
+	if _, err := b.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (repo *JobRepository) pathFor(namespaceID, jobName string) string {
+	return fmt.Sprintf("%s%s", path.Join(repo.Prefix, namespaceID, jobName), repo.Suffix)
+}
+
+func (repo *JobRepository) jobNameFromPath(filePath string) string {
+	return strings.TrimSuffix(path.Base(filePath), repo.Suffix)
+}
+
+func isNotFound(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == errCodeNotFound || awsErr.Code() == awss3.ErrCodeNoSuchKey
+	}
+	return false
+}
+
+func cleanPrefix(prefix string) string {
+	prefix = strings.TrimPrefix(prefix, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// NewJobRepository constructs a repository keeping compiled jobs in a s3
+// bucket
+func NewJobRepository(bucket, prefix, suffix string, c s3iface.S3API) *JobRepository {
+	return &JobRepository{
+		ObjectReader: &objectReader{c},
+		ObjectWriter: &ObjectWriter{c},
+		Client:       c,
+		Bucket:       bucket,
+		Prefix:       cleanPrefix(prefix),
+		Suffix:       suffix,
+	}
+}
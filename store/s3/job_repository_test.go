@@ -0,0 +1,142 @@
+package s3_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store/s3"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type s3ClientMock struct {
+	s3iface.S3API
+	mock.Mock
+}
+
+func (c *s3ClientMock) PutObjectWithContext(ctx aws.Context, input *awss3.PutObjectInput,
+	opts ...request.Option) (*awss3.PutObjectOutput, error) {
+	body, _ := ioutil.ReadAll(input.Body)
+	args := c.Called(aws.StringValue(input.Bucket), aws.StringValue(input.Key), string(body))
+	return &awss3.PutObjectOutput{}, args.Error(0)
+}
+
+func (c *s3ClientMock) GetObject(input *awss3.GetObjectInput) (*awss3.GetObjectOutput, error) {
+	args := c.Called(aws.StringValue(input.Bucket), aws.StringValue(input.Key))
+	return &awss3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader([]byte(args.String(0)))),
+	}, args.Error(1)
+}
+
+func (c *s3ClientMock) HeadObjectWithContext(ctx aws.Context, input *awss3.HeadObjectInput,
+	opts ...request.Option) (*awss3.HeadObjectOutput, error) {
+	args := c.Called(aws.StringValue(input.Bucket), aws.StringValue(input.Key))
+	return &awss3.HeadObjectOutput{}, args.Error(0)
+}
+
+func (c *s3ClientMock) DeleteObjectWithContext(ctx aws.Context, input *awss3.DeleteObjectInput,
+	opts ...request.Option) (*awss3.DeleteObjectOutput, error) {
+	args := c.Called(aws.StringValue(input.Bucket), aws.StringValue(input.Key))
+	return &awss3.DeleteObjectOutput{}, args.Error(0)
+}
+
+func (c *s3ClientMock) ListObjectsV2PagesWithContext(ctx aws.Context, input *awss3.ListObjectsV2Input,
+	fn func(*awss3.ListObjectsV2Output, bool) bool, opts ...request.Option) error {
+	args := c.Called(aws.StringValue(input.Bucket), aws.StringValue(input.Prefix))
+	var objects []*awss3.Object
+	for _, key := range args.Get(0).([]string) {
+		objects = append(objects, &awss3.Object{Key: aws.String(key)})
+	}
+	fn(&awss3.ListObjectsV2Output{Contents: objects}, true)
+	return args.Error(1)
+}
+
+func TestJobRepository(t *testing.T) {
+	ctx := context.Background()
+	namespaceSpec := models.NamespaceSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "dev-team-1",
+	}
+	jobKey := "optimus/dags/" + namespaceSpec.ID.String() + "/job-1.py"
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("should upload job in dir of its namespace", func(t *testing.T) {
+			client := new(s3ClientMock)
+			client.On("PutObjectWithContext", "bucket", jobKey, "print('job-1')").Return(nil)
+			defer client.AssertExpectations(t)
+
+			repo := s3.NewJobRepository("bucket", "/optimus/dags", ".py", client)
+			err := repo.Save(ctx, models.Job{
+				Name:        "job-1",
+				NamespaceID: namespaceSpec.ID.String(),
+				Contents:    []byte("print('job-1')"),
+			})
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("ListNames", func(t *testing.T) {
+		t.Run("should list jobs of namespace", func(t *testing.T) {
+			client := new(s3ClientMock)
+			client.On("ListObjectsV2PagesWithContext", "bucket", "optimus/dags/"+namespaceSpec.ID.String()+"/").
+				Return([]string{jobKey, "optimus/dags/" + namespaceSpec.ID.String() + "/readme.md"}, nil)
+			defer client.AssertExpectations(t)
+
+			repo := s3.NewJobRepository("bucket", "optimus/dags", ".py", client)
+			names, err := repo.ListNames(ctx, namespaceSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"job-1"}, names)
+		})
+	})
+	t.Run("GetByName", func(t *testing.T) {
+		t.Run("should read job from any namespace", func(t *testing.T) {
+			client := new(s3ClientMock)
+			client.On("ListObjectsV2PagesWithContext", "bucket", "optimus/dags/").Return([]string{jobKey}, nil)
+			client.On("GetObject", "bucket", jobKey).Return("print('job-1')", nil)
+			defer client.AssertExpectations(t)
+
+			repo := s3.NewJobRepository("bucket", "optimus/dags", ".py", client)
+			j, err := repo.GetByName(ctx, "job-1")
+			assert.Nil(t, err)
+			assert.Equal(t, models.Job{Name: "job-1", Contents: []byte("print('job-1')")}, j)
+		})
+		t.Run("should fail for unknown job", func(t *testing.T) {
+			client := new(s3ClientMock)
+			client.On("ListObjectsV2PagesWithContext", "bucket", "optimus/dags/").Return([]string{jobKey}, nil)
+
+			repo := s3.NewJobRepository("bucket", "optimus/dags", ".py", client)
+			_, err := repo.GetByName(ctx, "unknown")
+			assert.True(t, errors.Is(err, models.ErrNoSuchJob))
+		})
+	})
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("should delete job of namespace", func(t *testing.T) {
+			client := new(s3ClientMock)
+			client.On("HeadObjectWithContext", "bucket", jobKey).Return(nil)
+			client.On("DeleteObjectWithContext", "bucket", jobKey).Return(nil)
+			defer client.AssertExpectations(t)
+
+			repo := s3.NewJobRepository("bucket", "optimus/dags", ".py", client)
+			assert.Nil(t, repo.Delete(ctx, namespaceSpec, "job-1"))
+		})
+		t.Run("should fail when job does not exist", func(t *testing.T) {
+			client := new(s3ClientMock)
+			client.On("HeadObjectWithContext", "bucket", jobKey).
+				Return(awserr.New("NotFound", "not found", nil))
+			defer client.AssertExpectations(t)
+
+			repo := s3.NewJobRepository("bucket", "optimus/dags", ".py", client)
+			err := repo.Delete(ctx, namespaceSpec, "job-1")
+			assert.True(t, errors.Is(err, models.ErrNoSuchJob))
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+)
+
+// Credentials of an s3 compatible storage, kept as storage secret of
+// project. Endpoint is only needed for storages other than aws s3
+type Credentials struct {
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	SessionToken    string `json:"session_token"`
+	Region          string `json:"region"`
+	Endpoint        string `json:"endpoint"`
+}
+
+// NewClient creates a s3 client from credentials json, credentials are
+// picked from the default aws credential chain if secret is empty
+func NewClient(secret string) (*awss3.S3, error) {
+	var creds Credentials
+	if secret != "" {
+		if err := json.Unmarshal([]byte(secret), &creds); err != nil {
+			return nil, errors.Wrap(err, "failed to decode s3 credentials")
+		}
+	}
+
+	conf := aws.NewConfig()
+	if creds.AccessKeyID != "" {
+		conf = conf.WithCredentials(credentials.NewStaticCredentials(creds.AccessKeyID, creds.SecretAccessKey,
+			creds.SessionToken))
+	}
+	if creds.Region != "" {
+		conf = conf.WithRegion(creds.Region)
+	}
+	if creds.Endpoint != "" {
+		conf = conf.WithEndpoint(creds.Endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *conf,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return awss3.New(sess), nil
+}
+
+// ObjectWriter buffers objects in memory and uploads them on close as s3
+// does not support streaming uploads of unknown size
+type ObjectWriter struct {
+	Client s3iface.S3API
+}
+
+func (w *ObjectWriter) NewWriter(ctx context.Context, bucket, path string) (io.WriteCloser, error) {
+	return &objectWriter{
+		ctx:    ctx,
+		client: w.Client,
+		bucket: bucket,
+		key:    path,
+	}, nil
+}
+
+type objectWriter struct {
+	bytes.Buffer
+
+	ctx    context.Context
+	client s3iface.S3API
+	bucket string
+	key    string
+}
+
+func (w *objectWriter) Close() error {
+	_, err := w.client.PutObjectWithContext(w.ctx, &awss3.PutObjectInput{
+		Bucket: aws.String(w.bucket),
+		Key:    aws.String(w.key),
+		Body:   bytes.NewReader(w.Bytes()),
+	})
+	return err
+}
+
+type objectReader struct {
+	client s3iface.S3API
+}
+
+func (r *objectReader) NewReader(bucket, path string) (io.ReadCloser, error) {
+	resp, err := r.client.GetObject(&awss3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(path),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
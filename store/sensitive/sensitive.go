@@ -0,0 +1,217 @@
+// Package sensitive encrypts values of project and namespace config whose
+// keys match the configured patterns, e.g. *_SECRET or *_TOKEN, before they
+// are handed to the underlying repository. Values are sealed with the app
+// key, the same way secrets are, and opened again as they are read.
+package sensitive
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/gtank/cryptopasta"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+// SealedPrefix marks config values encrypted with the app key
+const SealedPrefix = "sealed:"
+
+// Sealer encrypts config values of keys matching patterns
+type Sealer struct {
+	hash     models.ApplicationKey
+	patterns models.ConfigKeyPatterns
+}
+
+// sealConfig encrypts values of sensitive keys in config, values already
+// sealed are kept as is
+func (s *Sealer) sealConfig(config map[string]string) (map[string]string, error) {
+	sealed := make(map[string]string, len(config))
+	for key, val := range config {
+		if !s.patterns.Matches(key) || IsSealed(val) {
+			sealed[key] = val
+			continue
+		}
+		cipher, err := cryptopasta.Encrypt([]byte(val), s.hash.GetKey())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encrypt config %s", key)
+		}
+		sealed[key] = SealedPrefix + base64.StdEncoding.EncodeToString(cipher)
+	}
+	return sealed, nil
+}
+
+// openConfig decrypts all sealed values in config, values which are not
+// sealed are returned as is so that existing config keeps working till it
+// is saved again
+func (s *Sealer) openConfig(config map[string]string) (map[string]string, error) {
+	opened := make(map[string]string, len(config))
+	for key, val := range config {
+		if !IsSealed(val) {
+			opened[key] = val
+			continue
+		}
+		cipher, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(val, SealedPrefix))
+		if err != nil {
+			return nil, errors.Wrapf(err, "malformed config %s", key)
+		}
+		cleartext, err := cryptopasta.Decrypt(cipher, s.hash.GetKey())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt config %s", key)
+		}
+		opened[key] = string(cleartext)
+	}
+	return opened, nil
+}
+
+func (s *Sealer) openProject(spec models.ProjectSpec) (models.ProjectSpec, error) {
+	var err error
+	if spec.Config, err = s.openConfig(spec.Config); err != nil {
+		return models.ProjectSpec{}, err
+	}
+	return spec, nil
+}
+
+func (s *Sealer) openNamespace(spec models.NamespaceSpec) (models.NamespaceSpec, error) {
+	var err error
+	if spec.Config, err = s.openConfig(spec.Config); err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	if spec.ProjectSpec, err = s.openProject(spec.ProjectSpec); err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	return spec, nil
+}
+
+// IsSealed checks if value is encrypted with the app key
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, SealedPrefix)
+}
+
+func NewSealer(hash models.ApplicationKey, patterns models.ConfigKeyPatterns) *Sealer {
+	return &Sealer{
+		hash:     hash,
+		patterns: patterns,
+	}
+}
+
+// projectRepository encrypts sensitive config of projects
+type projectRepository struct {
+	store.ProjectRepository
+	sealer *Sealer
+}
+
+func (repo *projectRepository) Save(spec models.ProjectSpec) error {
+	var err error
+	if spec.Config, err = repo.sealer.sealConfig(spec.Config); err != nil {
+		return err
+	}
+	return repo.ProjectRepository.Save(spec)
+}
+
+func (repo *projectRepository) GetByName(name string) (models.ProjectSpec, error) {
+	spec, err := repo.ProjectRepository.GetByName(name)
+	if err != nil {
+		return models.ProjectSpec{}, err
+	}
+	return repo.sealer.openProject(spec)
+}
+
+func (repo *projectRepository) GetAll() ([]models.ProjectSpec, error) {
+	specs, err := repo.ProjectRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for idx := range specs {
+		if specs[idx], err = repo.sealer.openProject(specs[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return specs, nil
+}
+
+func NewProjectRepository(repo store.ProjectRepository, sealer *Sealer) *projectRepository {
+	return &projectRepository{
+		ProjectRepository: repo,
+		sealer:            sealer,
+	}
+}
+
+// namespaceRepository encrypts sensitive config of namespaces, it can
+// override sensitive config of the project
+type namespaceRepository struct {
+	store.NamespaceRepository
+	sealer *Sealer
+}
+
+func (repo *namespaceRepository) Save(spec models.NamespaceSpec) error {
+	var err error
+	if spec.Config, err = repo.sealer.sealConfig(spec.Config); err != nil {
+		return err
+	}
+	return repo.NamespaceRepository.Save(spec)
+}
+
+func (repo *namespaceRepository) GetByName(name string) (models.NamespaceSpec, error) {
+	spec, err := repo.NamespaceRepository.GetByName(name)
+	if err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	return repo.sealer.openNamespace(spec)
+}
+
+func (repo *namespaceRepository) GetAll() ([]models.NamespaceSpec, error) {
+	specs, err := repo.NamespaceRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for idx := range specs {
+		if specs[idx], err = repo.sealer.openNamespace(specs[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return specs, nil
+}
+
+func NewNamespaceRepository(repo store.NamespaceRepository, sealer *Sealer) *namespaceRepository {
+	return &namespaceRepository{
+		NamespaceRepository: repo,
+		sealer:              sealer,
+	}
+}
+
+// projectJobSpecRepository opens sensitive config of namespaces and
+// projects jobs are read along with
+type projectJobSpecRepository struct {
+	store.ProjectJobSpecRepository
+	sealer *Sealer
+}
+
+func (repo *projectJobSpecRepository) GetByName(name string) (models.JobSpec, models.NamespaceSpec, error) {
+	jobSpec, namespaceSpec, err := repo.ProjectJobSpecRepository.GetByName(name)
+	if err != nil {
+		return models.JobSpec{}, models.NamespaceSpec{}, err
+	}
+	if namespaceSpec, err = repo.sealer.openNamespace(namespaceSpec); err != nil {
+		return models.JobSpec{}, models.NamespaceSpec{}, err
+	}
+	return jobSpec, namespaceSpec, nil
+}
+
+func (repo *projectJobSpecRepository) GetByDestination(destination string) (models.JobSpec, models.ProjectSpec, error) {
+	jobSpec, projectSpec, err := repo.ProjectJobSpecRepository.GetByDestination(destination)
+	if err != nil {
+		return models.JobSpec{}, models.ProjectSpec{}, err
+	}
+	if projectSpec, err = repo.sealer.openProject(projectSpec); err != nil {
+		return models.JobSpec{}, models.ProjectSpec{}, err
+	}
+	return jobSpec, projectSpec, nil
+}
+
+func NewProjectJobSpecRepository(repo store.ProjectJobSpecRepository, sealer *Sealer) *projectJobSpecRepository {
+	return &projectJobSpecRepository{
+		ProjectJobSpecRepository: repo,
+		sealer:                   sealer,
+	}
+}
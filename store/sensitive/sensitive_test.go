@@ -0,0 +1,81 @@
+package sensitive_test
+
+import (
+	"testing"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store/memory"
+	"github.com/odpf/optimus/store/sensitive"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensitiveConfig(t *testing.T) {
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+	patterns, err := models.ParseConfigKeyPatterns("*_SECRET,*_TOKEN")
+	assert.Nil(t, err)
+	sealer := sensitive.NewSealer(hash, patterns)
+
+	projectSpec := models.ProjectSpec{
+		Name: "t-optimus",
+		Config: map[string]string{
+			"STORAGE_PATH": "gs://some_folder",
+			"API_TOKEN":    "token",
+		},
+	}
+
+	t.Run("should store project config matching patterns encrypted", func(t *testing.T) {
+		db := memory.NewDB()
+		repo := sensitive.NewProjectRepository(memory.NewProjectRepository(db), sealer)
+		assert.Nil(t, repo.Save(projectSpec))
+
+		stored, err := memory.NewProjectRepository(db).GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, "gs://some_folder", stored.Config["STORAGE_PATH"])
+		assert.True(t, sensitive.IsSealed(stored.Config["API_TOKEN"]))
+
+		read, err := repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, projectSpec.Config, read.Config)
+
+		all, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Equal(t, projectSpec.Config, all[0].Config)
+
+		// saving a read spec again doesn't seal values twice
+		assert.Nil(t, memory.NewProjectRepository(db).Save(stored))
+		assert.Nil(t, repo.Save(stored))
+		read, err = repo.GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, "token", read.Config["API_TOKEN"])
+	})
+	t.Run("should read config saved before it was sealed", func(t *testing.T) {
+		db := memory.NewDB()
+		assert.Nil(t, memory.NewProjectRepository(db).Save(projectSpec))
+
+		read, err := sensitive.NewProjectRepository(memory.NewProjectRepository(db), sealer).GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, projectSpec.Config, read.Config)
+	})
+	t.Run("should store namespace config matching patterns encrypted", func(t *testing.T) {
+		db := memory.NewDB()
+		assert.Nil(t, sensitive.NewProjectRepository(memory.NewProjectRepository(db), sealer).Save(projectSpec))
+		stored, err := memory.NewProjectRepository(db).GetByName(projectSpec.Name)
+		assert.Nil(t, err)
+
+		namespaceSpec := models.NamespaceSpec{
+			Name:   "dev-team-1",
+			Config: map[string]string{"CLIENT_SECRET": "secret"},
+		}
+		repo := sensitive.NewNamespaceRepository(memory.NewNamespaceRepository(db, stored), sealer)
+		assert.Nil(t, repo.Save(namespaceSpec))
+
+		raw, err := memory.NewNamespaceRepository(db, stored).GetByName(namespaceSpec.Name)
+		assert.Nil(t, err)
+		assert.True(t, sensitive.IsSealed(raw.Config["CLIENT_SECRET"]))
+
+		read, err := repo.GetByName(namespaceSpec.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", read.Config["CLIENT_SECRET"])
+		assert.Equal(t, "token", read.ProjectSpec.Config["API_TOKEN"])
+	})
+}
@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	upSuffix = ".up.sql"
+)
+
+var (
+	// ErrDropColumn is returned for migrations reverting added columns,
+	// sqlite bundled with the driver can't drop columns
+	ErrDropColumn = errors.New("dropping columns is not supported by sqlite")
+
+	createExtensionRegex = regexp.MustCompile(`(?i)^CREATE\s+EXTENSION\b`)
+	alterTableRegex      = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(\S+)\s+(.*)$`)
+	addColumnRegex       = regexp.MustCompile(`(?is)^ADD\s+(?:COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(.*)$`)
+	dropColumnRegex      = regexp.MustCompile(`(?is)^DROP\s+(?:COLUMN\s+)?(?:IF\s+EXISTS\s+)?(\S+)$`)
+	defaultRegex         = regexp.MustCompile(`(?i)\bDEFAULT\b`)
+	notNullRegex         = regexp.MustCompile(`(?i)\s+NOT\s+NULL\b`)
+
+	// replacements of postgres only column types and defaults, ids are
+	// generated while creating records when there is no default
+	replacements = []struct {
+		regex *regexp.Regexp
+		with  string
+	}{
+		{regexp.MustCompile(`(?i)\s+DEFAULT\s+uuid_generate_v4\(\)`), ""},
+		{regexp.MustCompile(`(?i)\bTIMESTAMP\s+WITH\s+TIME\s+ZONE\b`), "TIMESTAMP"},
+		{regexp.MustCompile(`(?i)\bJSONB\b`), "TEXT"},
+		{regexp.MustCompile(`(?i)\bUUID\b`), "TEXT"},
+		{regexp.MustCompile(`(?i)\bBYTEA\b`), "BLOB"},
+	}
+)
+
+// MigrationFS rewrites postgres migrations of src for sqlite as they are
+// read, so both databases share the same migration versions
+func MigrationFS(src fs.FS) fs.FS {
+	return &migrationFS{src: src}
+}
+
+type migrationFS struct {
+	src fs.FS
+}
+
+func (m *migrationFS) Open(name string) (fs.File, error) {
+	f, err := m.src.Open(name)
+	if err != nil || !strings.HasSuffix(name, ".sql") {
+		return f, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	translated, err := Translate(string(raw), strings.HasSuffix(name, upSuffix))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read migration %s for sqlite", name)
+	}
+	return &migrationFile{
+		Reader: bytes.NewReader([]byte(translated)),
+		info: migrationFileInfo{
+			FileInfo: stat,
+			size:     int64(len(translated)),
+		},
+	}, nil
+}
+
+// Translate rewrites statements of a postgres migration for sqlite.
+// Columns dropped by up migrations are kept as they are no longer used,
+// down migrations dropping columns fail with ErrDropColumn
+func Translate(migration string, up bool) (string, error) {
+	var statements []string
+	for _, statement := range strings.Split(migration, ";") {
+		statement = strings.TrimSpace(statement)
+		if statement == "" || createExtensionRegex.MatchString(statement) {
+			continue
+		}
+		for _, r := range replacements {
+			statement = r.regex.ReplaceAllString(statement, r.with)
+		}
+
+		match := alterTableRegex.FindStringSubmatch(statement)
+		if match == nil {
+			statements = append(statements, statement)
+			continue
+		}
+		// sqlite alters a single column per statement
+		table := match[1]
+		for _, action := range strings.Split(match[2], ",") {
+			action = strings.TrimSpace(action)
+			if add := addColumnRegex.FindStringSubmatch(action); add != nil {
+				column := add[1]
+				// sqlite can't add not null columns without a default
+				if !defaultRegex.MatchString(column) {
+					column = notNullRegex.ReplaceAllString(column, "")
+				}
+				statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, column))
+			} else if drop := dropColumnRegex.FindStringSubmatch(action); drop != nil {
+				if !up {
+					return "", errors.Wrapf(ErrDropColumn, "%s.%s", table, drop[1])
+				}
+			} else {
+				return "", errors.Errorf("unsupported alter table statement for sqlite: %s", action)
+			}
+		}
+	}
+	if len(statements) == 0 {
+		return "", nil
+	}
+	return strings.Join(statements, ";\n") + ";\n", nil
+}
+
+type migrationFile struct {
+	*bytes.Reader
+	info migrationFileInfo
+}
+
+func (f *migrationFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *migrationFile) Close() error {
+	return nil
+}
+
+type migrationFileInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (i migrationFileInfo) Size() int64 {
+	return i.size
+}
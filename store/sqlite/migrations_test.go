@@ -0,0 +1,50 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/odpf/optimus/store/sqlite"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Run("should rewrite postgres column types and defaults", func(t *testing.T) {
+		migration := `CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+CREATE TABLE IF NOT EXISTS project (
+   id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+   config JSONB,
+   created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);`
+		translated, err := sqlite.Translate(migration, true)
+		assert.Nil(t, err)
+		assert.Equal(t, `CREATE TABLE IF NOT EXISTS project (
+   id TEXT PRIMARY KEY,
+   config TEXT,
+   created_at TIMESTAMP NOT NULL
+);
+`, translated)
+	})
+	t.Run("should add a single column per statement", func(t *testing.T) {
+		migration := `ALTER TABLE job
+    ADD IF NOT EXISTS description TEXT,
+    ADD IF NOT EXISTS namespace_id UUID NOT NULL REFERENCES namespace (id),
+    ADD IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;`
+		translated, err := sqlite.Translate(migration, true)
+		assert.Nil(t, err)
+		assert.Equal(t, `ALTER TABLE job ADD COLUMN description TEXT;
+ALTER TABLE job ADD COLUMN namespace_id TEXT REFERENCES namespace (id);
+ALTER TABLE job ADD COLUMN paused BOOLEAN NOT NULL DEFAULT FALSE;
+`, translated)
+	})
+	t.Run("should keep columns dropped by up migrations", func(t *testing.T) {
+		translated, err := sqlite.Translate(`ALTER TABLE job ADD IF NOT EXISTS behavior JSONB;
+ALTER TABLE job DROP IF EXISTS catch_up;`, true)
+		assert.Nil(t, err)
+		assert.Equal(t, "ALTER TABLE job ADD COLUMN behavior TEXT;\n", translated)
+	})
+	t.Run("should fail down migrations dropping columns", func(t *testing.T) {
+		_, err := sqlite.Translate(`ALTER TABLE job DROP IF EXISTS paused;`, false)
+		assert.True(t, errors.Is(err, sqlite.ErrDropColumn))
+	})
+}
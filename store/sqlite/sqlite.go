@@ -0,0 +1,90 @@
+// Package sqlite lets repositories of the postgres store run on an sqlite
+// database for single node installs. Repositories only use gorm queries
+// which both databases understand, migrations are shared and rewritten for
+// sqlite while being read.
+package sqlite
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3" // required for sqlite migrate driver
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+const (
+	// Scheme of sqlite database connection strings, e.g.
+	// sqlite3:///var/lib/optimus/optimus.db
+	Scheme = "sqlite3"
+
+	dialect = "sqlite3"
+)
+
+// IsSqlite returns true if connection string points to an sqlite database
+func IsSqlite(connURL string) bool {
+	return strings.HasPrefix(connURL, Scheme+"://")
+}
+
+// Connect opens the sqlite database file of connection string. sqlite
+// allows a single writer, connections are not pooled to avoid waiting on
+// locks held by other connections of the same server
+func Connect(connURL string, maxIdleConnections int) (*gorm.DB, error) {
+	path, err := filePath(connURL)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialect, path)
+	if err != nil {
+		return nil, err
+	}
+
+	db.DB().SetMaxIdleConns(maxIdleConnections)
+	db.DB().SetMaxOpenConns(1)
+	db.SingularTable(true)
+	// timestamps are compared as text, keep them in a single timezone
+	db.SetNowFuncOverride(func() time.Time {
+		return time.Now().UTC()
+	})
+	db.Callback().Create().Before("gorm:create").Register("optimus:generate_id", generateID)
+	return db, nil
+}
+
+// generateID sets uuid primary keys left blank, postgres generates them
+// with defaults of columns
+func generateID(scope *gorm.Scope) {
+	field, ok := scope.FieldByName("ID")
+	if !ok || !field.IsBlank {
+		return
+	}
+	if _, isUUID := field.Field.Interface().(uuid.UUID); isUUID {
+		scope.Err(field.Set(uuid.New()))
+	}
+}
+
+// filePath converts connection string to the form expected by sqlite
+// driver, options of migrate are dropped while options of the driver
+// like _busy_timeout are kept
+func filePath(connURL string) (string, error) {
+	p, err := url.Parse(connURL)
+	if err != nil {
+		return "", err
+	}
+	query := p.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "x-") {
+			query.Del(key)
+		}
+	}
+	path := strings.TrimPrefix(connURL, Scheme+"://")
+	if idx := strings.Index(path, "?"); idx >= 0 {
+		path = path[:idx]
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path, nil
+}
@@ -0,0 +1,115 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/postgres"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlite(t *testing.T) {
+	t.Run("should run repositories on migrated database", func(t *testing.T) {
+		dbURL := "sqlite3://" + filepath.Join(t.TempDir(), "optimus.db")
+		assert.Nil(t, postgres.Migrate(dbURL))
+		assert.Nil(t, postgres.CheckSchema(dbURL))
+
+		dbConn, err := postgres.Connect(dbURL, 1, 1, 0, 0)
+		assert.Nil(t, err)
+		defer dbConn.Close()
+
+		hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+		projectRepo := postgres.NewProjectRepository(dbConn, hash)
+		assert.Nil(t, projectRepo.Save(models.ProjectSpec{
+			Name:   "t-optimus",
+			Config: map[string]string{"bucket": "gs://some_folder"},
+		}))
+		projectSpec, err := projectRepo.GetByName("t-optimus")
+		assert.Nil(t, err)
+		assert.Equal(t, "gs://some_folder", projectSpec.Config["bucket"])
+
+		namespaceRepo := postgres.NewNamespaceRepository(dbConn, projectSpec, hash)
+		assert.Nil(t, namespaceRepo.Save(models.NamespaceSpec{Name: "dev-team-1"}))
+		namespaceSpec, err := namespaceRepo.GetByName("dev-team-1")
+		assert.Nil(t, err)
+		assert.Equal(t, projectSpec.ID, namespaceSpec.ProjectSpec.ID)
+	})
+	t.Run("should save jobs in bulk checking their revision", func(t *testing.T) {
+		dbURL := "sqlite3://" + filepath.Join(t.TempDir(), "optimus.db")
+		assert.Nil(t, postgres.Migrate(dbURL))
+
+		dbConn, err := postgres.Connect(dbURL, 1, 1, 0, 0)
+		assert.Nil(t, err)
+		defer dbConn.Close()
+
+		hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+		projectRepo := postgres.NewProjectRepository(dbConn, hash)
+		assert.Nil(t, projectRepo.Save(models.ProjectSpec{Name: "t-optimus"}))
+		projectSpec, err := projectRepo.GetByName("t-optimus")
+		assert.Nil(t, err)
+		namespaceRepo := postgres.NewNamespaceRepository(dbConn, projectSpec, hash)
+		assert.Nil(t, namespaceRepo.Save(models.NamespaceSpec{Name: "dev-team-1"}))
+		namespaceSpec, err := namespaceRepo.GetByName("dev-team-1")
+		assert.Nil(t, err)
+
+		execUnit := new(mock.BasePlugin)
+		execUnit.On("PluginInfo").Return(&models.PluginInfoResponse{
+			Name:       "g-task",
+			PluginType: models.PluginTypeTask,
+		}, nil)
+		pluginRepo := new(mock.SupportedPluginRepo)
+		pluginRepo.On("GetByName", "g-task").Return(&models.Plugin{Base: execUnit}, nil)
+		adapter := postgres.NewAdapter(pluginRepo)
+		jobRepo := postgres.NewJobSpecRepository(dbConn, namespaceSpec,
+			postgres.NewProjectJobSpecRepository(dbConn, projectSpec, adapter), adapter)
+
+		task := models.JobSpecTask{Unit: &models.Plugin{Base: execUnit}}
+		assert.Nil(t, jobRepo.SaveAll([]models.JobSpec{{Name: "job-1", Task: task}, {Name: "job-2", Task: task}}))
+		assert.Nil(t, jobRepo.SaveAll([]models.JobSpec{{Name: "job-1", Task: task, Revision: 1}}))
+		saved, err := jobRepo.GetByName("job-1")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, saved.Revision)
+		versions, err := jobRepo.GetVersions("job-1")
+		assert.Nil(t, err)
+		assert.Len(t, versions, 2)
+
+		// outdated revision of a job fails the whole batch
+		err = jobRepo.SaveAll([]models.JobSpec{{Name: "job-2", Task: task}, {Name: "job-1", Task: task, Revision: 1}})
+		assert.True(t, errors.Is(err, store.ErrRevisionConflict))
+		saved, err = jobRepo.GetByName("job-2")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, saved.Revision)
+	})
+	t.Run("should record stats of statements run by repositories", func(t *testing.T) {
+		dbURL := "sqlite3://" + filepath.Join(t.TempDir(), "optimus.db")
+		assert.Nil(t, postgres.Migrate(dbURL))
+
+		dbConn, err := postgres.Connect(dbURL, 1, 1, 0, 0)
+		assert.Nil(t, err)
+		defer dbConn.Close()
+		stats := postgres.NewQueryStats()
+		postgres.Instrument(dbConn, stats, time.Minute)
+
+		hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+		projectRepo := postgres.NewProjectRepository(dbConn, hash)
+		assert.Nil(t, projectRepo.Save(models.ProjectSpec{Name: "t-optimus"}))
+		_, err = projectRepo.GetByName("t-optimus")
+		assert.Nil(t, err)
+		_, err = projectRepo.GetByName("unknown")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+
+		created := stats.Get("project", "create")
+		assert.Equal(t, int64(1), created.Count)
+		assert.Equal(t, int64(1), created.LatencyMsBuckets["+Inf"])
+		queried := stats.Get("project", "query")
+		assert.True(t, queried.Count >= 2)
+		// missing records are not failures
+		assert.Equal(t, int64(0), queried.Errors)
+		assert.Contains(t, stats.String(), `"project.create"`)
+	})
+}
@@ -12,7 +12,14 @@ import (
 )
 
 var (
-	ErrResourceNotFound = errors.New("resource not found")
+	ErrResourceNotFound      = errors.New("resource not found")
+	ErrResourceAlreadyExists = errors.New("resource already exists")
+	// ErrRevisionConflict is returned when a spec was modified after the
+	// revision being saved was read
+	ErrRevisionConflict = errors.New("resource was modified concurrently")
+	// ErrResourceDeleted is returned when a resource can't be created as a
+	// deleted one of the same name elsewhere can still be restored
+	ErrResourceDeleted = errors.New("resource is deleted and can still be restored")
 )
 
 // ProjectJobSpecRepository represents a storage interface for Job specifications at a project level
@@ -27,6 +34,12 @@ type ProjectRepository interface {
 	Save(models.ProjectSpec) error
 	GetByName(string) (models.ProjectSpec, error)
 	GetAll() ([]models.ProjectSpec, error)
+	// Delete soft deletes a project, it can be restored till it is purged
+	Delete(string) error
+	Restore(string) error
+	// Purge permanently removes projects deleted before the given time and
+	// returns their names
+	Purge(deletedBefore time.Time) ([]string, error)
 }
 
 // ProjectSecretRepository stores secrets attached to projects
@@ -36,6 +49,22 @@ type ProjectSecretRepository interface {
 	GetAll() ([]models.ProjectSecretItem, error)
 }
 
+// RoleBindingRepository stores roles granted in a project
+type RoleBindingRepository interface {
+	// Save creates the binding or replaces role of subject in same namespace
+	Save(models.RoleBinding) error
+	Delete(subject, namespace string) error
+	GetAll() ([]models.RoleBinding, error)
+}
+
+// MacroRepository stores macros of a project and its namespaces
+type MacroRepository interface {
+	// Save creates the macro or replaces it if it exists in same namespace
+	Save(models.Macro) error
+	Delete(name, namespace string) error
+	GetAll() ([]models.Macro, error)
+}
+
 // NamespaceRepository represents a storage interface for registered namespaces
 type NamespaceRepository interface {
 	Save(models.NamespaceSpec) error
@@ -95,4 +124,55 @@ type ReplaySpecRepository interface {
 	UpdateStatus(replayID uuid.UUID, status string, message models.ReplayMessage) error
 	GetByStatus(status []string) ([]models.ReplaySpec, error)
 	GetByJobIDAndStatus(jobID uuid.UUID, status []string) ([]models.ReplaySpec, error)
+	// GetByProjectID returns replays of jobs of the project, latest first
+	GetByProjectID(projectID uuid.UUID) ([]models.ReplaySpec, error)
+}
+
+// DeploymentRepository represents a storage interface for deployment
+// history of a project
+type DeploymentRepository interface {
+	// Save inserts the deployment or updates it if already present
+	Save(deployment models.DeploymentSpec) error
+	GetByID(id uuid.UUID) (models.DeploymentSpec, error)
+	GetAll(filter models.DeploymentFilter) ([]models.DeploymentSpec, error)
+}
+
+// JobRunRepository represents a storage interface for run history of jobs
+// of a project
+type JobRunRepository interface {
+	// Save inserts the run or updates it if a run of the job is already
+	// present for the same schedule
+	Save(run models.JobRun) error
+	GetAll(filter models.JobRunFilter) ([]models.JobRun, error)
+	// CountByState returns the number of runs matching filter in each state
+	CountByState(filter models.JobRunFilter) (map[models.JobStatusState]int, error)
+}
+
+// AuditEventRepository represents a storage interface for audit log of
+// mutating calls, events are kept even for calls made for unknown projects
+type AuditEventRepository interface {
+	Save(event models.AuditEvent) error
+	GetAll(filter models.AuditEventFilter) ([]models.AuditEvent, error)
+}
+
+// BackupRepository represents a storage interface for backups of resources
+// of a project
+type BackupRepository interface {
+	Save(spec models.BackupSpec) error
+	GetByID(id uuid.UUID) (models.BackupSpec, error)
+	// GetAll returns backups of resources of datastore, latest first
+	GetAll(datastore string) ([]models.BackupSpec, error)
+}
+
+// JobLineageRepository represents a storage interface for resources jobs
+// of all the projects read and write
+type JobLineageRepository interface {
+	// Save inserts lineage of the job or replaces it if already present
+	Save(lineage models.JobLineage) error
+	Delete(projectName, jobName string) error
+	GetByJob(projectName, jobName string) (models.JobLineage, error)
+	// GetByDestination returns lineage of the jobs writing urn
+	GetByDestination(urn string) ([]models.JobLineage, error)
+	// GetByDependency returns lineage of the jobs reading urn
+	GetByDependency(urn string) ([]models.JobLineage, error)
 }
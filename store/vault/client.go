@@ -0,0 +1,137 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	tokenHeader = "X-Vault-Token"
+
+	// secretValueKey is the field of kv data in which secret values are kept
+	secretValueKey = "value"
+)
+
+// HTTPClient is used to talk to vault server
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client reads and writes secrets in a KV version 2 secret engine
+// mounted at mount path over vault http api
+type Client struct {
+	address string
+	token   string
+	mount   string
+
+	httpClient HTTPClient
+}
+
+type kvWriteRequest struct {
+	Data map[string]string `json:"data"`
+}
+
+type kvReadResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+// Put creates a new version of secret at path
+func (c *Client) Put(ctx context.Context, path, value string) error {
+	payload, err := json.Marshal(kvWriteRequest{
+		Data: map[string]string{secretValueKey: value},
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.dataURL(path), bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrapf(err, "failed to write secret at %s", path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("failed to write secret at %s: %s", path, readError(resp))
+	}
+	return nil
+}
+
+// Get fetches latest version of secret at path
+func (c *Client) Get(ctx context.Context, path string) (string, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.dataURL(path), nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read secret at %s", path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", store.ErrResourceNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to read secret at %s: %s", path, readError(resp))
+	}
+
+	var kv kvReadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&kv); err != nil {
+		return "", errors.Wrapf(err, "failed to decode secret at %s", path)
+	}
+	value, ok := kv.Data.Data[secretValueKey]
+	if !ok {
+		return "", errors.Errorf("secret at %s has no %s field", path, secretValueKey)
+	}
+	return value, nil
+}
+
+func (c *Client) dataURL(path string) string {
+	return fmt.Sprintf("%s/v1/%s/data/%s", c.address, c.mount, strings.TrimPrefix(path, "/"))
+}
+
+func (c *Client) do(ctx context.Context, method, url string, body *bytes.Reader) (*http.Response, error) {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = http.NewRequestWithContext(ctx, method, url, body)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(tokenHeader, c.token)
+	req.Header.Set("Content-Type", "application/json")
+	return c.httpClient.Do(req)
+}
+
+// readError extracts error messages returned by vault
+func readError(resp *http.Response) string {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.Status
+	}
+	var vaultErr struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &vaultErr); err != nil || len(vaultErr.Errors) == 0 {
+		return resp.Status
+	}
+	return fmt.Sprintf("%s: %s", resp.Status, strings.Join(vaultErr.Errors, ", "))
+}
+
+func NewClient(address, token, mount string, httpClient HTTPClient) *Client {
+	return &Client{
+		address:    strings.TrimSuffix(address, "/"),
+		token:      token,
+		mount:      strings.Trim(mount, "/"),
+		httpClient: httpClient,
+	}
+}
@@ -0,0 +1,176 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ReferencePrefix marks secret values that only hold a path to
+	// the actual value kept in vault
+	ReferencePrefix = "vault:"
+
+	// pathPrefix under which all optimus secrets are kept in kv engine
+	pathPrefix = "optimus"
+)
+
+// SecretPath returns kv path of a project secret
+func SecretPath(projectName, secretName string) string {
+	return fmt.Sprintf("%s/%s/%s", pathPrefix, projectName, secretName)
+}
+
+// Resolver replaces secret references with their values stored in vault
+type Resolver struct {
+	client *Client
+}
+
+// Resolve returns a copy of secrets with all references replaced by values,
+// secrets which are not references are kept as is
+func (r *Resolver) Resolve(ctx context.Context, secrets models.ProjectSecrets) (models.ProjectSecrets, error) {
+	resolved := models.ProjectSecrets{}
+	for _, item := range secrets {
+		if strings.HasPrefix(item.Value, ReferencePrefix) {
+			value, err := r.client.Get(ctx, strings.TrimPrefix(item.Value, ReferencePrefix))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to resolve secret %s", item.Name)
+			}
+			item.Value = value
+		}
+		resolved = append(resolved, item)
+	}
+	return resolved, nil
+}
+
+func NewResolver(client *Client) *Resolver {
+	return &Resolver{
+		client: client,
+	}
+}
+
+// secretRepository keeps secret values in vault while the underlying
+// repository only stores references to them
+type secretRepository struct {
+	refRepo  store.ProjectSecretRepository
+	project  models.ProjectSpec
+	client   *Client
+	resolver *Resolver
+}
+
+func (repo *secretRepository) Save(item models.ProjectSecretItem) error {
+	path := SecretPath(repo.project.Name, item.Name)
+	if err := repo.client.Put(context.Background(), path, item.Value); err != nil {
+		return err
+	}
+	item.Value = ReferencePrefix + path
+	return repo.refRepo.Save(item)
+}
+
+func (repo *secretRepository) GetByName(name string) (models.ProjectSecretItem, error) {
+	item, err := repo.refRepo.GetByName(name)
+	if err != nil {
+		return models.ProjectSecretItem{}, err
+	}
+	resolved, err := repo.resolver.Resolve(context.Background(), models.ProjectSecrets{item})
+	if err != nil {
+		return models.ProjectSecretItem{}, err
+	}
+	return resolved[0], nil
+}
+
+func (repo *secretRepository) GetAll() ([]models.ProjectSecretItem, error) {
+	items, err := repo.refRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return repo.resolver.Resolve(context.Background(), items)
+}
+
+// NewSecretRepository writes secret values to vault and saves their
+// references using refRepo
+func NewSecretRepository(refRepo store.ProjectSecretRepository, project models.ProjectSpec, client *Client) *secretRepository {
+	return &secretRepository{
+		refRepo:  refRepo,
+		project:  project,
+		client:   client,
+		resolver: NewResolver(client),
+	}
+}
+
+// projectRepository resolves secret references of projects
+type projectRepository struct {
+	store.ProjectRepository
+	resolver *Resolver
+}
+
+func (repo *projectRepository) GetByName(name string) (models.ProjectSpec, error) {
+	spec, err := repo.ProjectRepository.GetByName(name)
+	if err != nil {
+		return models.ProjectSpec{}, err
+	}
+	if spec.Secret, err = repo.resolver.Resolve(context.Background(), spec.Secret); err != nil {
+		return models.ProjectSpec{}, err
+	}
+	return spec, nil
+}
+
+func (repo *projectRepository) GetAll() ([]models.ProjectSpec, error) {
+	specs, err := repo.ProjectRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for idx := range specs {
+		if specs[idx].Secret, err = repo.resolver.Resolve(context.Background(), specs[idx].Secret); err != nil {
+			return nil, err
+		}
+	}
+	return specs, nil
+}
+
+func NewProjectRepository(repo store.ProjectRepository, resolver *Resolver) *projectRepository {
+	return &projectRepository{
+		ProjectRepository: repo,
+		resolver:          resolver,
+	}
+}
+
+// namespaceRepository resolves secret references of namespace projects
+type namespaceRepository struct {
+	store.NamespaceRepository
+	resolver *Resolver
+}
+
+func (repo *namespaceRepository) GetByName(name string) (models.NamespaceSpec, error) {
+	spec, err := repo.NamespaceRepository.GetByName(name)
+	if err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	if spec.ProjectSpec.Secret, err = repo.resolver.Resolve(context.Background(), spec.ProjectSpec.Secret); err != nil {
+		return models.NamespaceSpec{}, err
+	}
+	return spec, nil
+}
+
+func (repo *namespaceRepository) GetAll() ([]models.NamespaceSpec, error) {
+	specs, err := repo.NamespaceRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for idx := range specs {
+		if specs[idx].ProjectSpec.Secret, err = repo.resolver.Resolve(context.Background(), specs[idx].ProjectSpec.Secret); err != nil {
+			return nil, err
+		}
+	}
+	return specs, nil
+}
+
+func NewNamespaceRepository(repo store.NamespaceRepository, resolver *Resolver) *namespaceRepository {
+	return &namespaceRepository{
+		NamespaceRepository: repo,
+		resolver:            resolver,
+	}
+}
@@ -0,0 +1,172 @@
+package vault_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/odpf/optimus/store/vault"
+	"github.com/stretchr/testify/assert"
+)
+
+// newKVServer mimics a KV version 2 engine mounted at "secret"
+func newKVServer(t *testing.T, token string) *httptest.Server {
+	var mu sync.Mutex
+	kv := map[string]map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			var req struct {
+				Data map[string]string `json:"data"`
+			}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+			kv[path] = req.Data
+			w.Write([]byte(`{"data":{"version":1}}`))
+		case http.MethodGet:
+			data, ok := kv[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"data": data},
+			})
+		}
+	}))
+}
+
+func TestVault(t *testing.T) {
+	projectSpec := models.ProjectSpec{
+		Name: "t-optimus",
+	}
+
+	t.Run("Client", func(t *testing.T) {
+		t.Run("should write and read secret values", func(t *testing.T) {
+			srv := newKVServer(t, "token")
+			defer srv.Close()
+
+			client := vault.NewClient(srv.URL, "token", "secret/", srv.Client())
+			assert.Nil(t, client.Put(context.Background(), "optimus/proj/key", "value"))
+
+			value, err := client.Get(context.Background(), "optimus/proj/key")
+			assert.Nil(t, err)
+			assert.Equal(t, "value", value)
+		})
+		t.Run("should return not found for missing secrets", func(t *testing.T) {
+			srv := newKVServer(t, "token")
+			defer srv.Close()
+
+			client := vault.NewClient(srv.URL, "token", "secret", srv.Client())
+			_, err := client.Get(context.Background(), "optimus/proj/missing")
+			assert.Equal(t, store.ErrResourceNotFound, err)
+		})
+		t.Run("should return vault errors", func(t *testing.T) {
+			srv := newKVServer(t, "token")
+			defer srv.Close()
+
+			client := vault.NewClient(srv.URL, "bad-token", "secret", srv.Client())
+			err := client.Put(context.Background(), "optimus/proj/key", "value")
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "permission denied")
+		})
+	})
+	t.Run("SecretRepository", func(t *testing.T) {
+		t.Run("should keep value in vault and save only its reference", func(t *testing.T) {
+			srv := newKVServer(t, "token")
+			defer srv.Close()
+			client := vault.NewClient(srv.URL, "token", "secret", srv.Client())
+
+			refRepo := new(mock.ProjectSecretRepository)
+			defer refRepo.AssertExpectations(t)
+			refRepo.On("Save", models.ProjectSecretItem{
+				Name:  "STORAGE",
+				Value: "vault:optimus/t-optimus/STORAGE",
+			}).Return(nil)
+
+			repo := vault.NewSecretRepository(refRepo, projectSpec, client)
+			assert.Nil(t, repo.Save(models.ProjectSecretItem{Name: "STORAGE", Value: "gcs-key"}))
+
+			value, err := client.Get(context.Background(), vault.SecretPath(projectSpec.Name, "STORAGE"))
+			assert.Nil(t, err)
+			assert.Equal(t, "gcs-key", value)
+		})
+		t.Run("should resolve references while reading secrets", func(t *testing.T) {
+			srv := newKVServer(t, "token")
+			defer srv.Close()
+			client := vault.NewClient(srv.URL, "token", "secret", srv.Client())
+			assert.Nil(t, client.Put(context.Background(), "optimus/t-optimus/STORAGE", "gcs-key"))
+
+			refRepo := new(mock.ProjectSecretRepository)
+			defer refRepo.AssertExpectations(t)
+			refRepo.On("GetAll").Return([]models.ProjectSecretItem{
+				{Name: "STORAGE", Value: "vault:optimus/t-optimus/STORAGE"},
+				{Name: "LEGACY", Value: "plain"},
+			}, nil)
+
+			repo := vault.NewSecretRepository(refRepo, projectSpec, client)
+			secrets, err := repo.GetAll()
+			assert.Nil(t, err)
+			assert.Equal(t, []models.ProjectSecretItem{
+				{Name: "STORAGE", Value: "gcs-key"},
+				{Name: "LEGACY", Value: "plain"},
+			}, secrets)
+		})
+	})
+	t.Run("ProjectRepository", func(t *testing.T) {
+		t.Run("should resolve secret references of project", func(t *testing.T) {
+			srv := newKVServer(t, "token")
+			defer srv.Close()
+			client := vault.NewClient(srv.URL, "token", "secret", srv.Client())
+			assert.Nil(t, client.Put(context.Background(), "optimus/t-optimus/STORAGE", "gcs-key"))
+
+			storedProject := projectSpec
+			storedProject.Secret = models.ProjectSecrets{
+				{Name: "STORAGE", Value: "vault:optimus/t-optimus/STORAGE"},
+			}
+			projRepo := new(mock.ProjectRepository)
+			defer projRepo.AssertExpectations(t)
+			projRepo.On("GetByName", projectSpec.Name).Return(storedProject, nil)
+
+			repo := vault.NewProjectRepository(projRepo, vault.NewResolver(client))
+			proj, err := repo.GetByName(projectSpec.Name)
+			assert.Nil(t, err)
+			value, ok := proj.Secret.GetByName("STORAGE")
+			assert.True(t, ok)
+			assert.Equal(t, "gcs-key", value)
+		})
+		t.Run("should fail when referenced secret is missing in vault", func(t *testing.T) {
+			srv := newKVServer(t, "token")
+			defer srv.Close()
+			client := vault.NewClient(srv.URL, "token", "secret", srv.Client())
+
+			storedProject := projectSpec
+			storedProject.Secret = models.ProjectSecrets{
+				{Name: "STORAGE", Value: "vault:optimus/t-optimus/STORAGE"},
+			}
+			projRepo := new(mock.ProjectRepository)
+			defer projRepo.AssertExpectations(t)
+			projRepo.On("GetAll").Return([]models.ProjectSpec{storedProject}, nil)
+
+			repo := vault.NewProjectRepository(projRepo, vault.NewResolver(client))
+			_, err := repo.GetAll()
+			assert.NotNil(t, err)
+		})
+	})
+}
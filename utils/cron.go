@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+)
+
+// cronDescriptors are the predefined schedules expanded to cron fields
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonthNames = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	cronDayNames   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// DescribeCronInterval returns a human readable description of a cron
+// interval in timezone, e.g. "every day at 02:00 UTC". Intervals which
+// can not be put in words are described as the cron expression itself
+func DescribeCronInterval(interval, timezone string) (string, error) {
+	interval = strings.TrimSpace(interval)
+	if _, err := cron.ParseStandard(interval); err != nil {
+		return "", errors.Wrapf(err, "invalid crontab entry %s", interval)
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if strings.HasPrefix(interval, "@every ") {
+		return fmt.Sprintf("every %s", strings.TrimSpace(strings.TrimPrefix(interval, "@every "))), nil
+	}
+	if expanded, ok := cronDescriptors[strings.ToLower(interval)]; ok {
+		interval = expanded
+	}
+
+	fields := strings.Fields(interval)
+	if len(fields) != 5 {
+		return fmt.Sprintf("on cron schedule %s %s", interval, timezone), nil
+	}
+	description, ok := describeCronFields(fields[0], fields[1], fields[2], fields[3], fields[4])
+	if !ok {
+		return fmt.Sprintf("on cron schedule %s %s", interval, timezone), nil
+	}
+	return fmt.Sprintf("%s %s", description, timezone), nil
+}
+
+func describeCronFields(minute, hour, dom, month, dow string) (string, bool) {
+	// days the schedule runs on, as a leading phrase for daily schedules
+	// and as a trailing one for schedules running within a day
+	everyDay, onDays, ok := describeCronDays(dom, month, dow)
+	if !ok {
+		return "", false
+	}
+	withDays := func(phrase string) string {
+		if onDays == "" {
+			return phrase
+		}
+		return phrase + " " + onDays
+	}
+
+	minuteValue, minuteIsValue := cronValue(minute, 0, 59, nil)
+	hourValue, hourIsValue := cronValue(hour, 0, 23, nil)
+	switch {
+	case minuteIsValue && hourIsValue:
+		return fmt.Sprintf("%s at %02d:%02d", everyDay, hourValue, minuteValue), true
+	case minuteIsValue && hour == "*":
+		if minuteValue == 0 {
+			return withDays("every hour"), true
+		}
+		return withDays(fmt.Sprintf("every hour at minute %d", minuteValue)), true
+	case minuteIsValue && strings.HasPrefix(hour, "*/"):
+		step, ok := cronValue(strings.TrimPrefix(hour, "*/"), 1, 23, nil)
+		if !ok {
+			return "", false
+		}
+		if minuteValue == 0 {
+			return withDays(fmt.Sprintf("every %d hours", step)), true
+		}
+		return withDays(fmt.Sprintf("every %d hours at minute %d", step, minuteValue)), true
+	case minuteIsValue:
+		hours, ok := describeCronList(hour, 0, 23, nil, func(h int) string {
+			return fmt.Sprintf("%02d:%02d", h, minuteValue)
+		})
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%s at %s", everyDay, hours), true
+	case minute == "*" && hour == "*":
+		return withDays("every minute"), true
+	case strings.HasPrefix(minute, "*/") && hour == "*":
+		step, ok := cronValue(strings.TrimPrefix(minute, "*/"), 1, 59, nil)
+		if !ok {
+			return "", false
+		}
+		return withDays(fmt.Sprintf("every %d minutes", step)), true
+	}
+	return "", false
+}
+
+// describeCronDays returns the days as "every day" style leading phrase
+// and "on Monday" style trailing phrase, trailing one is empty for every day
+func describeCronDays(dom, month, dow string) (string, string, bool) {
+	var months string
+	if month != "*" {
+		var ok bool
+		if months, ok = describeCronList(month, 1, 12, cronMonthNames, func(m int) string {
+			return time.Month(m).String()
+		}); !ok {
+			return "", "", false
+		}
+	}
+
+	switch {
+	case dom == "*" && dow == "*":
+		if months == "" {
+			return "every day", "", true
+		}
+		return "every day in " + months, "in " + months, true
+	case dom == "*":
+		days, ok := describeCronList(dow, 0, 7, cronDayNames, func(d int) string {
+			return time.Weekday(d % 7).String()
+		})
+		if !ok {
+			return "", "", false
+		}
+		if months != "" {
+			days += " in " + months
+		}
+		return "every " + days, "on " + days, true
+	case dow == "*":
+		days, ok := describeCronList(dom, 1, 31, nil, strconv.Itoa)
+		if !ok {
+			return "", "", false
+		}
+		if months == "" {
+			months = "every month"
+		}
+		phrase := fmt.Sprintf("on day %s of %s", days, months)
+		return phrase, phrase, true
+	}
+	return "", "", false
+}
+
+// describeCronList puts comma separated values and ranges of a field in words
+func describeCronList(field string, min, max int, names []string, name func(int) string) (string, bool) {
+	var items []string
+	for _, part := range strings.Split(field, ",") {
+		if bounds := strings.SplitN(part, "-", 2); len(bounds) == 2 {
+			from, fromOk := cronValue(bounds[0], min, max, names)
+			to, toOk := cronValue(bounds[1], min, max, names)
+			if !fromOk || !toOk {
+				return "", false
+			}
+			items = append(items, fmt.Sprintf("%s through %s", name(from), name(to)))
+			continue
+		}
+		value, ok := cronValue(part, min, max, names)
+		if !ok {
+			return "", false
+		}
+		items = append(items, name(value))
+	}
+	if len(items) == 1 {
+		return items[0], true
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1], true
+}
+
+// cronValue parses a single value of a field, names are its aliases
+// indexed by value
+func cronValue(field string, min, max int, names []string) (int, bool) {
+	for value, name := range names {
+		if name != "" && strings.EqualFold(field, name) {
+			return value, true
+		}
+	}
+	value, err := strconv.Atoi(field)
+	if err != nil || value < min || value > max {
+		return 0, false
+	}
+	return value, true
+}
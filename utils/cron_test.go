@@ -0,0 +1,50 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/odpf/optimus/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeCronInterval(t *testing.T) {
+	t.Run("should describe intervals in words", func(t *testing.T) {
+		cases := []struct {
+			Interval string
+			Timezone string
+			Expected string
+		}{
+			{Interval: "0 2 * * *", Expected: "every day at 02:00 UTC"},
+			{Interval: "30 14 * * *", Timezone: "Asia/Kolkata", Expected: "every day at 14:30 Asia/Kolkata"},
+			{Interval: "@daily", Expected: "every day at 00:00 UTC"},
+			{Interval: "@midnight", Expected: "every day at 00:00 UTC"},
+			{Interval: "@hourly", Expected: "every hour UTC"},
+			{Interval: "@weekly", Expected: "every Sunday at 00:00 UTC"},
+			{Interval: "@monthly", Expected: "on day 1 of every month at 00:00 UTC"},
+			{Interval: "@yearly", Expected: "on day 1 of January at 00:00 UTC"},
+			{Interval: "@every 2h", Expected: "every 2h"},
+			{Interval: "15 * * * *", Expected: "every hour at minute 15 UTC"},
+			{Interval: "0 */6 * * *", Expected: "every 6 hours UTC"},
+			{Interval: "*/10 * * * *", Expected: "every 10 minutes UTC"},
+			{Interval: "* * * * *", Expected: "every minute UTC"},
+			{Interval: "0 3 * * 1-5", Expected: "every Monday through Friday at 03:00 UTC"},
+			{Interval: "0 3 * * MON,FRI", Expected: "every Monday and Friday at 03:00 UTC"},
+			{Interval: "0 9,13,17 * * *", Expected: "every day at 09:00, 13:00 and 17:00 UTC"},
+			{Interval: "0 0 1,15 * *", Expected: "on day 1 and 15 of every month at 00:00 UTC"},
+			{Interval: "0 6 * 1,7 *", Expected: "every day in January and July at 06:00 UTC"},
+			{Interval: "0 * * * 0", Expected: "every hour on Sunday UTC"},
+			{Interval: "5 4 1/2 * *", Expected: "on cron schedule 5 4 1/2 * * UTC"},
+		}
+		for _, tcase := range cases {
+			description, err := utils.DescribeCronInterval(tcase.Interval, tcase.Timezone)
+			assert.Nil(t, err, tcase.Interval)
+			assert.Equal(t, tcase.Expected, description, tcase.Interval)
+		}
+	})
+	t.Run("should return error for invalid intervals", func(t *testing.T) {
+		for _, interval := range []string{"* * * *", "@fortnightly", "61 * * * *", ""} {
+			_, err := utils.DescribeCronInterval(interval, "")
+			assert.NotNil(t, err, interval)
+		}
+	})
+}
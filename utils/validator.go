@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 
@@ -24,6 +25,24 @@ func CronIntervalValidator(val interface{}, param string) error {
 	return nil
 }
 
+// TimezoneValidator return a nil value when an IANA timezone name is passed,
+// empty value stands for UTC
+// used in gopkg.in/validator.v2
+func TimezoneValidator(val interface{}, param string) error {
+	value, ok := val.(string)
+	if !ok {
+		return fmt.Errorf("invalid timezone, not a valid string")
+	}
+	// Local is the timezone of machine and differs across servers
+	if value == "Local" {
+		return fmt.Errorf("invalid timezone %s", value)
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return errors.Wrapf(err, "invalid timezone %s", value)
+	}
+	return nil
+}
+
 // validatorFactory, name abbreviated so that
 // the global implementation can be called 'validatorFactory'
 type VFactory struct{}
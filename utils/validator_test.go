@@ -68,6 +68,32 @@ func TestValidator(t *testing.T) {
 		})
 	})
 
+	t.Run("TimezoneValidator", func(t *testing.T) {
+		t.Run("should pass for iana timezone names and fail for others", func(t *testing.T) {
+			cases := []struct {
+				TestData string
+				IsValid  bool
+			}{
+				{TestData: "", IsValid: true},
+				{TestData: "UTC", IsValid: true},
+				{TestData: "Asia/Kolkata", IsValid: true},
+				{TestData: "America/New_York", IsValid: true},
+				{TestData: "Local", IsValid: false},
+				{TestData: "Mars/Olympus", IsValid: false},
+				{TestData: "+05:30", IsValid: false},
+			}
+
+			for _, tcase := range cases {
+				err := utils.TimezoneValidator(tcase.TestData, "")
+				if tcase.IsValid {
+					assert.Nil(t, err, tcase.TestData)
+				} else {
+					assert.NotNil(t, err, tcase.TestData)
+				}
+			}
+		})
+	})
+
 	t.Run("NewFromRegex", func(t *testing.T) {
 		t.Run("should return message if regex fails to match", func(t *testing.T) {
 			cases := []struct {